@@ -0,0 +1,211 @@
+// Command benchcheck compares the output of go test -bench against a stored baseline and exits non-zero if a
+// benchmark allocates more than the allowed threshold. Allocations hardly vary between runs and machines,
+// unlike timings: those are reported, and only fail the check with -time if they got slower by more than the
+// threshold with a significant difference, according to a Mann-Whitney U test of the runs of both files.
+//
+//	benchcheck [-threshold 20] [-time] [-alpha 0.05] baseline.txt current.txt
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"log"
+	"math"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// procsSuffix matches the GOMAXPROCS suffix go test appends to benchmark names, e.g. -8
+var procsSuffix = regexp.MustCompile(`-\d+$`)
+
+// result holds the measurements of one benchmark, one per run with -count
+type result struct {
+	nsPerOp     []float64
+	allocsPerOp []float64
+}
+
+func main() {
+	threshold := flag.Float64("threshold", 20, "Maximum allowed increase of allocs/op, and of ns/op with -time, in percent")
+	checkTime := flag.Bool("time", false, "Also fail on significant ns/op increases above the threshold")
+	alpha := flag.Float64("alpha", 0.05, "Significance level of ns/op differences")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: benchcheck [-threshold percent] [-time] [-alpha level] baseline.txt current.txt\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if flag.NArg() != 2 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	baseline, err := readResults(flag.Arg(0))
+	if err != nil {
+		log.Fatalf("Failed to read baseline: %v", err)
+	}
+	current, err := readResults(flag.Arg(1))
+	if err != nil {
+		log.Fatalf("Failed to read benchmark results: %v", err)
+	}
+	if len(current) == 0 {
+		log.Fatalf("No benchmark results in %s", flag.Arg(1))
+	}
+
+	names := make([]string, 0, len(current))
+	for name := range current {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	regressions := 0
+	fmt.Printf("%-40s %14s %14s %8s %6s %12s %12s %8s\n", "benchmark", "old ns/op", "new ns/op", "delta", "p", "old allocs", "new allocs", "delta")
+	for _, name := range names {
+		cur := current[name]
+		base, ok := baseline[name]
+		if !ok {
+			fmt.Printf("%-40s %14s %14.0f %8s %6s %12s %12.0f %8s\n", name, "-", median(cur.nsPerOp), "new", "-", "-", median(cur.allocsPerOp), "new")
+			continue
+		}
+		timeDelta := delta(median(base.nsPerOp), median(cur.nsPerOp))
+		p := mannWhitneyP(base.nsPerOp, cur.nsPerOp)
+		allocsDelta := delta(median(base.allocsPerOp), median(cur.allocsPerOp))
+		marker := ""
+		if allocsDelta > *threshold || (*checkTime && timeDelta > *threshold && p < *alpha) {
+			marker = "  REGRESSION"
+			regressions++
+		}
+		fmt.Printf("%-40s %14.0f %14.0f %7.1f%% %6.3f %12.0f %12.0f %7.1f%%%s\n", name,
+			median(base.nsPerOp), median(cur.nsPerOp), timeDelta, p,
+			median(base.allocsPerOp), median(cur.allocsPerOp), allocsDelta, marker)
+	}
+
+	if regressions > 0 {
+		fmt.Printf("\n%d benchmark(s) regressed by more than %.0f%%\n", regressions, *threshold)
+		os.Exit(1)
+	}
+}
+
+// readResults parses the benchmark lines of a go test -bench output file, keyed by benchmark name without the
+// GOMAXPROCS suffix. Lines that are not benchmark results are skipped.
+func readResults(path string) (map[string]*result, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	results := make(map[string]*result)
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 4 || !strings.HasPrefix(fields[0], "Benchmark") {
+			continue
+		}
+		name := procsSuffix.ReplaceAllString(fields[0], "")
+		r, ok := results[name]
+		if !ok {
+			r = &result{}
+			results[name] = r
+		}
+		// After the name and the iteration count, measurements come as value-unit pairs
+		for i := 2; i+1 < len(fields); i += 2 {
+			value, err := strconv.ParseFloat(fields[i], 64)
+			if err != nil {
+				continue
+			}
+			switch fields[i+1] {
+			case "ns/op":
+				r.nsPerOp = append(r.nsPerOp, value)
+			case "allocs/op":
+				r.allocsPerOp = append(r.allocsPerOp, value)
+			}
+		}
+	}
+	return results, scanner.Err()
+}
+
+// median returns the median of values, 0 if there are none.
+func median(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return (sorted[mid-1] + sorted[mid]) / 2
+	}
+	return sorted[mid]
+}
+
+// delta returns the change from old to new in percent, 0 if old is 0.
+func delta(old, new float64) float64 {
+	if old == 0 {
+		return 0
+	}
+	return (new - old) / old * 100
+}
+
+// mannWhitneyP returns the two-sided p-value of the Mann-Whitney U test of samples a and b, the probability
+// of a difference between them at least as large if both came from the same distribution. The distribution of
+// U is computed exactly, counting tied values as half, which is accurate enough for the few runs of -count.
+// Without runs in either sample, 1 is returned.
+func mannWhitneyP(a, b []float64) float64 {
+	n1, n2 := len(a), len(b)
+	if n1 == 0 || n2 == 0 {
+		return 1
+	}
+	u := 0.0
+	for _, x := range a {
+		for _, y := range b {
+			switch {
+			case x > y:
+				u++
+			case x == y:
+				u += 0.5
+			}
+		}
+	}
+	// Distance of U from its mean, the p-value counts the orderings at least as far on either side
+	dist := math.Abs(u - float64(n1*n2)/2)
+
+	counts := uCounts(n1, n2)
+	total, extreme := 0.0, 0.0
+	for v, c := range counts {
+		total += c
+		if math.Abs(float64(v)-float64(n1*n2)/2) >= dist-1e-9 {
+			extreme += c
+		}
+	}
+	return extreme / total
+}
+
+// uCounts returns how many orderings of n1 and n2 distinct values yield every value of U, indexed by U.
+func uCounts(n1, n2 int) []float64 {
+	// counts[i][j] are the counts for i and j values, built up from the last value being from either sample
+	counts := make([][][]float64, n1+1)
+	for i := range counts {
+		counts[i] = make([][]float64, n2+1)
+		for j := range counts[i] {
+			c := make([]float64, i*j+1)
+			switch {
+			case i == 0 || j == 0:
+				c[0] = 1
+			default:
+				for v := range c {
+					if v >= j {
+						c[v] += counts[i-1][j][v-j]
+					}
+					if v < len(counts[i][j-1]) {
+						c[v] += counts[i][j-1][v]
+					}
+				}
+			}
+			counts[i][j] = c
+		}
+	}
+	return counts[n1][n2]
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/fatih/color"
+	"github.com/vlad/ast2llm-go/internal/composer"
+	"github.com/vlad/ast2llm-go/internal/git"
+	"github.com/vlad/ast2llm-go/internal/parser"
+)
+
+// writeAPIChanges compares the exported API of the project at path with an older version, base, which is a
+// directory or a git ref of the project's repository, and writes the change report, or the changes as JSON,
+// to stdout or the output file.
+func writeAPIChanges(p *parser.ProjectParser, path, base string, out outputOptions) error {
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return fmt.Errorf("failed to resolve path: %w", err)
+	}
+
+	oldPath := base
+	if info, err := os.Stat(base); err != nil || !info.IsDir() {
+		snapshot, cleanup, err := git.Snapshot(absPath, base)
+		if err != nil {
+			return fmt.Errorf("failed to check out %s: %w", base, err)
+		}
+		defer cleanup()
+		oldPath = snapshot
+	}
+
+	changes, err := p.CompareProjects(oldPath, absPath)
+	if err != nil {
+		return fmt.Errorf("failed to compare APIs: %w", err)
+	}
+	rendered := composer.FormatAPIChanges(changes)
+	if out.format == formatJSON {
+		data, err := json.MarshalIndent(changes, "", "  ")
+		if err != nil {
+			return err
+		}
+		rendered = string(data) + "\n"
+	}
+
+	if out.path == "" {
+		_, err := fmt.Fprint(os.Stdout, rendered)
+		return err
+	}
+	if err := os.WriteFile(out.path, []byte(rendered), 0644); err != nil {
+		return fmt.Errorf("failed to write output file: %w", err)
+	}
+	color.Green("API changes written to %s", out.path)
+	return nil
+}
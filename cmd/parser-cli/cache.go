@@ -0,0 +1,78 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/fatih/color"
+	"github.com/vlad/ast2llm-go/internal/parser"
+)
+
+// cacheUsage describes the cache subcommands
+const cacheUsage = "usage: parser-cli [flags] cache stats | clear [--all] | warm <path>"
+
+// runCacheCommand runs a cache subcommand on the persistent cache in dir. args follow "cache" on the command
+// line. warm parses the project with p, which must persist its results in dir.
+func runCacheCommand(p *parser.ProjectParser, dir string, args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("missing cache subcommand, %s", cacheUsage)
+	}
+
+	switch args[0] {
+	case "stats":
+		stats, err := parser.ReadDiskCacheStats(dir)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Cache directory: %s\n", stats.Dir)
+		fmt.Printf("Entries: %d (%s), %d stale\n", stats.Entries, formatBytes(stats.Bytes), stats.Stale)
+		fmt.Printf("Hits: %d, misses: %d, hit rate: %.0f%%\n", stats.Hits, stats.Misses, stats.HitRate()*100)
+		return nil
+	case "clear":
+		fs := flag.NewFlagSet("cache clear", flag.ContinueOnError)
+		all := fs.Bool("all", false, "Remove every entry and reset the statistics, not only stale entries")
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+		removed, err := parser.ClearDiskCache(dir, *all)
+		if err != nil {
+			return err
+		}
+		color.Green("Removed %d cache entries from %s", removed, dir)
+		return nil
+	case "warm":
+		if len(args) != 2 {
+			return fmt.Errorf("cache warm takes the project path, %s", cacheUsage)
+		}
+		absPath, err := filepath.Abs(args[1])
+		if err != nil {
+			return fmt.Errorf("failed to resolve project path: %w", err)
+		}
+		if _, err := os.Stat(absPath); err != nil {
+			return fmt.Errorf("project %s: %w", absPath, err)
+		}
+		fileInfos, err := p.ParseProject(absPath)
+		if err != nil {
+			return fmt.Errorf("failed to parse project: %w", err)
+		}
+		color.Green("Cached %d files of %s in %s", len(fileInfos), absPath, dir)
+		return nil
+	}
+	return fmt.Errorf("unknown cache subcommand %q, %s", args[0], cacheUsage)
+}
+
+// formatBytes renders a size in bytes with a binary unit, e.g. 1.5 MiB
+func formatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
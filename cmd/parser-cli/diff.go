@@ -0,0 +1,46 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/fatih/color"
+	"github.com/vlad/ast2llm-go/internal/composer"
+	"github.com/vlad/ast2llm-go/internal/parser"
+)
+
+// writeDiffContext composes the context of the symbols changed by the unified diff in diffPath, "-" for stdin,
+// and writes it to stdout or the output file.
+func writeDiffContext(p *parser.ProjectParser, path, diffPath string, out outputOptions) error {
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return fmt.Errorf("failed to resolve path: %w", err)
+	}
+
+	var diff []byte
+	if diffPath == "-" {
+		diff, err = io.ReadAll(os.Stdin)
+	} else {
+		diff, err = os.ReadFile(diffPath)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read diff: %w", err)
+	}
+
+	rendered, err := composer.ForDiff(p, absPath, string(diff), composer.WithVerbosity(out.verbosity))
+	if err != nil {
+		return err
+	}
+
+	if out.path == "" {
+		_, err := fmt.Fprint(os.Stdout, rendered)
+		return err
+	}
+	if err := os.WriteFile(out.path, []byte(rendered), 0644); err != nil {
+		return fmt.Errorf("failed to write output file: %w", err)
+	}
+	color.Green("Diff context written to %s", out.path)
+	return nil
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/vlad/ast2llm-go/internal/parser"
+	ourtypes "github.com/vlad/ast2llm-go/internal/types"
+)
+
+// Dependency graph formats supported by the --graph flag
+const (
+	graphFormatDOT     = "dot"
+	graphFormatMermaid = "mermaid"
+)
+
+// writeDependencyGraph builds the project dependency graph and writes it in the given format to stdout or the output file.
+func writeDependencyGraph(p *parser.ProjectParser, path, format, outputPath string) error {
+	var render func(*ourtypes.DependencyGraph) string
+	switch format {
+	case graphFormatDOT:
+		render = (*ourtypes.DependencyGraph).ToDOT
+	case graphFormatMermaid:
+		render = (*ourtypes.DependencyGraph).ToMermaid
+	default:
+		return fmt.Errorf("unsupported graph format %q", format)
+	}
+
+	graph, err := p.BuildGraph(path)
+	if err != nil {
+		return fmt.Errorf("failed to build dependency graph: %w", err)
+	}
+	rendered := render(graph)
+
+	if outputPath == "" {
+		_, err := fmt.Fprint(os.Stdout, rendered)
+		return err
+	}
+	if err := os.WriteFile(outputPath, []byte(rendered), 0644); err != nil {
+		return fmt.Errorf("failed to write output file: %w", err)
+	}
+	color.Green("Dependency graph written to %s", outputPath)
+	return nil
+}
+
+// checkCycles reports the import cycles and near-cycles through internal packages of the project.
+// It returns the number of cycles found.
+func checkCycles(p *parser.ProjectParser, path string) (int, error) {
+	graph, err := p.BuildGraph(path)
+	if err != nil {
+		return 0, fmt.Errorf("failed to build dependency graph: %w", err)
+	}
+
+	cycles := graph.DetectCycles()
+	if len(cycles) == 0 {
+		color.Green("No import cycles found")
+		return 0, nil
+	}
+	for _, cycle := range cycles {
+		if cycle.ViaInternal {
+			color.Yellow("Near-cycle through internal packages: %s", strings.Join(cycle.Packages, ", "))
+		} else {
+			color.Red("Import cycle: %s", strings.Join(cycle.Packages, ", "))
+		}
+	}
+	return len(cycles), nil
+}
@@ -1,9 +1,7 @@
 package main
 
 import (
-	"encoding/json"
 	"flag"
-	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
@@ -11,7 +9,8 @@ import (
 	"time"
 
 	"github.com/fatih/color"
-	pb "github.com/schollz/progressbar/v3"
+	"github.com/vlad/ast2llm-go/internal/composer"
+	"github.com/vlad/ast2llm-go/internal/config"
 	"github.com/vlad/ast2llm-go/internal/parser"
 	ourtypes "github.com/vlad/ast2llm-go/internal/types" // Alias ourtypes
 )
@@ -19,18 +18,212 @@ import (
 func main() {
 	// Define flags
 	projectPath := flag.String("project", "", "Analyze entire project")
-	jsonOutput := flag.Bool("json", false, "Enable JSON output")
+	jsonOutput := flag.Bool("json", false, "Enable JSON output (same as --format json)")
+	format := flag.String("format", "", "Output format: text, json, markdown or yaml (default text)")
+	schemaVersion := flag.Int("schema-version", ourtypes.SchemaVersion, "Schema version of JSON output; 2 lists imports as paths, 1 also names fields like Go fields, as older releases did")
+	var outputPath string
+	flag.StringVar(&outputPath, "output", "", "Write results to this file instead of stdout")
+	flag.StringVar(&outputPath, "o", "", "Shorthand for --output")
+	watch := flag.Bool("watch", false, "Re-run analysis whenever project files change")
+	includeExternal := flag.Bool("include-external", false, "Describe used types from the standard library and third-party modules")
+	includeTests := flag.Bool("tests", false, "Include _test.go files and test packages")
+	includeExamples := flag.Bool("examples", false, "Attach the ExampleXxx functions of test files to the symbols they document")
+	exclude := flag.String("exclude", "", "Comma-separated glob patterns of files to skip, e.g. \"vendor/**,**/*_gen.go\"")
+	transitiveDepth := flag.Int("transitive-depth", 0, "Also describe the types referenced by used types from other packages, up to this many levels")
+	inlineMinLines := flag.Int("inline-min-lines", parser.DefaultInlineMinLines, "Describe anonymous structs and function literals spanning at least this many lines, 0 for none")
+	naming := flag.String("naming", "default", "How names are qualified: default, full, package or short")
+	tags := flag.String("tags", "", "Comma-separated build tags selecting the files of every package")
+	vendor := flag.Bool("vendor", false, "Resolve dependencies from the vendor directory (-mod=vendor), the default for projects with vendor/modules.txt")
+	offline := flag.Bool("offline", false, "Never download modules or toolchains, failing with the list of imported packages missing from the module cache")
+	generated := flag.String("generated", "include", "How to handle generated files: include, skip or summarize")
+	verbosity := flag.String("verbosity", "fields", "Level of detail: signatures, comments, fields or bodies")
+	graphFormat := flag.String("graph", "", "Write the package dependency graph instead of file details: dot or mermaid")
+	cycles := flag.Bool("check-cycles", false, "Report import cycles and near-cycles through internal packages, exiting non-zero if any are found")
+	tokenReport := flag.Bool("token-report", false, "Report how many tokens each section of the composed context of every file takes up")
+	budget := flag.Int("budget", 0, "Character budget of the composed context used by --token-report, 0 means unbounded")
+	since := flag.String("since", "", "Only analyze the packages affected by the files changed since this git ref, e.g. main or HEAD~1")
+	apiDiff := flag.String("api-diff", "", "Report the exported API changes since this older version: a project directory or a git ref, e.g. v1.2.0")
+	diffPath := flag.String("diff", "", "Compose only the context of the symbols changed by this unified diff file, - for stdin")
+	cacheDir := flag.String("cache-dir", "", "Directory of the persistent parse cache (default: ast2llm-go/parse in the user cache directory)")
+	noCache := flag.Bool("no-cache", false, "Parse the project even if the persistent cache holds its current state")
+	stdin := flag.Bool("stdin", false, "Parse the Go source read from stdin, e.g. an unsaved editor buffer, as the --file of --project, or on its own without --project")
+	stdinFile := flag.String("file", "", "Path of the file read with --stdin, absolute or relative to the project; it does not need to exist (default source.go)")
+	warnings := flag.String("warnings", "warning", "Minimum severity of the parse warnings printed to stderr as they are found: info, warning, error or none")
+	syntaxOnly := flag.Bool("syntax-only", false, "Only parse the project's files without type-checking them against their imports: much faster, but types from other packages are only known by name")
 
 	// Parse flags
 	flag.Parse()
 
-	p := parser.New()
+	// "cache warm <path>" parses the project like --project does
+	cacheCommand := flag.Arg(0) == "cache"
+	if cacheCommand && flag.Arg(1) == "warm" && *projectPath == "" {
+		*projectPath = flag.Arg(2)
+	}
+
+	// Settings of the project's .ast2llm.yaml apply unless overridden by flags
+	cfg := &config.Config{}
+	if *projectPath != "" {
+		loaded, err := config.Load(*projectPath)
+		if err != nil {
+			color.Red("Error: %v", err)
+			os.Exit(1)
+		}
+		cfg = loaded
+	}
+	setFlags := make(map[string]bool)
+	flag.Visit(func(f *flag.Flag) { setFlags[f.Name] = true })
+	if !setFlags["verbosity"] && cfg.Verbosity != "" {
+		*verbosity = cfg.Verbosity
+	}
+	if !setFlags["budget"] && cfg.Budget > 0 {
+		*budget = cfg.Budget
+	}
+
+	out := outputOptions{format: *format, path: outputPath, schemaVersion: *schemaVersion}
+	if out.format == "" {
+		out.format = formatText
+		if *jsonOutput {
+			out.format = formatJSON
+		}
+	}
+	if !isSupportedFormat(out.format) {
+		color.Red("Error: unsupported format %q", out.format)
+		flag.Usage()
+		os.Exit(1)
+	}
+	v, err := composer.ParseVerbosity(*verbosity)
+	if err != nil {
+		color.Red("Error: %v", err)
+		flag.Usage()
+		os.Exit(1)
+	}
+	out.verbosity = v
+
+	opts := cfg.ParserOptions()
+	if *includeExternal {
+		opts = append(opts, parser.WithExternalTypes())
+	}
+	if out.verbosity >= composer.VerbosityBodies {
+		opts = append(opts, parser.WithFunctionBodies())
+	}
+	if *includeTests {
+		opts = append(opts, parser.WithTests())
+	}
+	if *includeExamples {
+		opts = append(opts, parser.WithExamples())
+	}
+	if *exclude != "" {
+		opts = append(opts, parser.WithExcludeGlobs(strings.Split(*exclude, ",")...))
+	}
+	if *transitiveDepth > 0 {
+		opts = append(opts, parser.WithTransitiveDepth(*transitiveDepth))
+	}
+	opts = append(opts, parser.WithInlineMinLines(*inlineMinLines))
+	if !setFlags["naming"] && cfg.Naming != "" {
+		*naming = cfg.Naming
+	}
+	namingMode, err := parser.ParseNamingMode(*naming)
+	if err != nil {
+		color.Red("Error: %v", err)
+		flag.Usage()
+		os.Exit(1)
+	}
+	opts = append(opts, parser.WithNaming(namingMode))
+	if *tags != "" {
+		opts = append(opts, parser.WithBuildTags(strings.Split(*tags, ",")...))
+	}
+	if *vendor {
+		opts = append(opts, parser.WithVendor())
+	}
+	if *offline {
+		opts = append(opts, parser.WithOffline())
+	}
+	switch *generated {
+	case "include": // Default
+	case "skip":
+		opts = append(opts, parser.WithGeneratedFiles(parser.GeneratedSkip))
+	case "summarize":
+		opts = append(opts, parser.WithGeneratedFiles(parser.GeneratedSummarize))
+	default:
+		color.Red("Error: unsupported --generated value %q", *generated)
+		flag.Usage()
+		os.Exit(1)
+	}
+	if *cacheDir == "" {
+		if *cacheDir, err = parser.DefaultDiskCacheDir(); err != nil && (cacheCommand || !*noCache) {
+			color.Red("Error: %v, set --cache-dir", err)
+			os.Exit(1)
+		}
+	}
+	if !*noCache || cacheCommand {
+		opts = append(opts, parser.WithDiskCache(*cacheDir))
+	}
+	if *warnings == warningsNone {
+		opts = append(opts, parser.WithWarnings(func(parser.Warning) {}))
+	} else {
+		if liveWarnings.min, err = parser.ParseSeverity(*warnings); err != nil {
+			color.Red("Error: unsupported --warnings value %q", *warnings)
+			flag.Usage()
+			os.Exit(1)
+		}
+		opts = append(opts, parser.WithWarnings(liveWarnings.print))
+	}
+	p := parser.New(opts...)
 
 	switch {
+	case cacheCommand:
+		if err := runCacheCommand(p, *cacheDir, flag.Args()[1:]); err != nil {
+			color.Red("Error: %v", err)
+			os.Exit(1)
+		}
+	case *stdin:
+		if err := analyzeStdin(p, *projectPath, *stdinFile, out); err != nil {
+			color.Red("Error parsing source: %v", err)
+			os.Exit(1)
+		}
+	case *projectPath != "" && *cycles:
+		found, err := checkCycles(p, *projectPath)
+		if err != nil {
+			color.Red("Error: %v", err)
+			os.Exit(1)
+		}
+		if found > 0 {
+			os.Exit(1)
+		}
+	case *projectPath != "" && *tokenReport:
+		if err := writeTokenReport(p, *projectPath, *since, *budget, out); err != nil {
+			color.Red("Error: %v", err)
+			os.Exit(1)
+		}
+	case *projectPath != "" && *apiDiff != "":
+		if err := writeAPIChanges(p, *projectPath, *apiDiff, out); err != nil {
+			color.Red("Error: %v", err)
+			os.Exit(1)
+		}
+	case *projectPath != "" && *diffPath != "":
+		if err := writeDiffContext(p, *projectPath, *diffPath, out); err != nil {
+			color.Red("Error: %v", err)
+			os.Exit(1)
+		}
+	case *projectPath != "" && *graphFormat != "":
+		if err := writeDependencyGraph(p, *projectPath, *graphFormat, outputPath); err != nil {
+			color.Red("Error: %v", err)
+			os.Exit(1)
+		}
+	case *projectPath != "" && *watch:
+		if err := watchProject(p, *projectPath, out); err != nil {
+			color.Red("Error watching project: %v", err)
+			os.Exit(1)
+		}
+	case *projectPath != "" && *syntaxOnly:
+		if err := analyzeProjectSyntax(p, *projectPath, out); err != nil {
+			color.Red("Error parsing project: %v", err)
+			os.Exit(1)
+		}
 	case *projectPath != "":
-		analyzeProject(p, *projectPath, *jsonOutput)
+		analyzeProject(p, *projectPath, *since, out)
 	default:
-		color.Red("Error: specify --project flag")
+		color.Red("Error: specify --project or --stdin flag")
 		flag.Usage()
 		os.Exit(1)
 	}
@@ -43,7 +236,7 @@ var (
 	cacheTimeout      = 5 * time.Minute
 )
 
-func analyzeProject(p *parser.ProjectParser, path string, jsonOut bool) {
+func analyzeProject(p *parser.ProjectParser, path, since string, out outputOptions) {
 	// Resolve absolute path
 	absPath, err := filepath.Abs(path)
 	if err != nil {
@@ -58,134 +251,47 @@ func analyzeProject(p *parser.ProjectParser, path string, jsonOut bool) {
 	}
 
 	// Check cache first
+	cacheKey := absPath + "@" + since
 	fileInfoCacheLock.RLock()
-	if cached, ok := fileInfoCache[absPath]; ok {
+	if cached, ok := fileInfoCache[cacheKey]; ok {
 		fileInfoCacheLock.RUnlock()
-		if jsonOut {
-			err = json.NewEncoder(os.Stdout).Encode(cached)
-			if err != nil {
-				color.Red("Error encoding JSON: %v", err)
-				return
-			}
-		} else {
-			printProjectFileInfo(cached)
-		}
+		outputProjectFileInfo(cached, out)
 		return
 	}
 	fileInfoCacheLock.RUnlock()
 
-	// Create progress bar
-	bar := pb.NewOptions(-1,
-		pb.OptionSetDescription("Analyzing project..."),
-		pb.OptionShowCount(),
-		pb.OptionSetTheme(pb.Theme{
-			Saucer:        "=",
-			SaucerHead:    ">",
-			SaucerPadding: " ",
-			BarStart:      "[",
-			BarEnd:        "]",
-		}))
-
-	// Start progress bar
-	go func() {
-		for {
-			if err = bar.Add(1); err != nil {
-				panic(err)
-			}
-			time.Sleep(100 * time.Millisecond)
-		}
-	}()
-
 	// Parse project
-	fileInfos, err := p.ParseProject(absPath)
+	progress := newParseProgress()
+	fileInfos, err := parseProject(p, absPath, since, progress.report)
+	progress.finish()
 	if err != nil {
-		if err = bar.Finish(); err != nil {
-			panic(err)
-		}
 		color.Red("Error parsing project: %v", err)
 		return
 	}
 
-	// Stop progress bar
-	if err = bar.Finish(); err != nil {
-		panic(err)
-	}
 	// Cache the result
 	fileInfoCacheLock.Lock()
-	fileInfoCache[absPath] = fileInfos
+	fileInfoCache[cacheKey] = fileInfos
 	fileInfoCacheLock.Unlock()
 
 	// Start cache cleanup timer
 	go func() {
 		time.Sleep(cacheTimeout)
 		fileInfoCacheLock.Lock()
-		delete(fileInfoCache, absPath)
+		delete(fileInfoCache, cacheKey)
 		fileInfoCacheLock.Unlock()
 	}()
 
-	if jsonOut {
-		err = json.NewEncoder(os.Stdout).Encode(fileInfos)
-		if err != nil {
-			color.Red("Error encoding JSON: %v", err)
-		}
-	} else {
-		printProjectFileInfo(fileInfos)
-	}
+	outputProjectFileInfo(fileInfos, out)
 }
 
-func printProjectFileInfo(fileInfos map[string]*ourtypes.FileInfo) {
-	color.Cyan("Project Information:")
-
-	for filePath, fileInfo := range fileInfos {
-		fmt.Printf("\n--- File: %s ---\n", color.YellowString(filePath))
-		fmt.Printf("  Package Name: %s\n", fileInfo.PackageName)
-
-		fmt.Printf("  Imports:\n")
-		if len(fileInfo.Imports) == 0 {
-			fmt.Println("    (None)")
-		} else {
-			for _, imp := range fileInfo.Imports {
-				fmt.Printf("    - %s\n", imp)
-			}
-		}
-
-		fmt.Printf("  Functions:\n")
-		if len(fileInfo.Functions) == 0 {
-			fmt.Println("    (None)")
-		} else {
-			for _, fn := range fileInfo.Functions {
-				fmt.Printf("    - %s\n", fn)
-			}
-		}
-
-		fmt.Printf("  Local Structs:\n")
-		if len(fileInfo.Structs) == 0 {
-			fmt.Println("    (None)")
-		} else {
-			for _, s := range fileInfo.Structs {
-				fmt.Printf("    - %s (Comment: %q)\n", color.MagentaString(s.Name), s.Comment)
-				if len(s.Fields) > 0 {
-					fmt.Println("      Fields:")
-					for _, f := range s.Fields {
-						fmt.Printf("        - %s %s\n", f.Name, f.Type)
-					}
-				}
-				if len(s.Methods) > 0 {
-					fmt.Println("      Methods:")
-					for _, m := range s.Methods {
-						fmt.Printf("        - %s(%s) (%s) (Comment: %q)\n", m.Name, strings.Join(m.Parameters, ", "), strings.Join(m.ReturnTypes, ", "), m.Comment)
-					}
-				}
-			}
-		}
-
-		fmt.Printf("  Used Imported Structs:\n")
-		if len(fileInfo.UsedImportedStructs) == 0 {
-			fmt.Println("    (None)")
-		} else {
-			for _, s := range fileInfo.UsedImportedStructs {
-				fmt.Printf("    - %s\n", s.Name)
-			}
-		}
+// analyzeProjectSyntax writes the details of the project parsed with --syntax-only, see
+// parser.ProjectParser.ParseProjectSyntax.
+func analyzeProjectSyntax(p *parser.ProjectParser, path string, out outputOptions) error {
+	fileInfos, err := p.ParseProjectSyntax(path)
+	if err != nil {
+		return err
 	}
+	outputProjectFileInfo(fileInfos, out)
+	return nil
 }
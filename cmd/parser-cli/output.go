@@ -0,0 +1,269 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/vlad/ast2llm-go/internal/composer"
+	ourtypes "github.com/vlad/ast2llm-go/internal/types" // Alias ourtypes
+	"gopkg.in/yaml.v3"
+)
+
+// Output formats supported by --format
+const (
+	formatText     = "text"
+	formatJSON     = "json"
+	formatMarkdown = "markdown"
+	formatYAML     = "yaml"
+)
+
+// outputOptions describes how and where results are written
+type outputOptions struct {
+	format    string             // One of the format constants
+	path      string             // Output file, stdout if empty
+	verbosity composer.Verbosity // Level of detail of the output
+
+	schemaVersion int // Schema version of JSON output, see ourtypes.SchemaVersion
+}
+
+// isSupportedFormat reports whether format can be passed to --format.
+func isSupportedFormat(format string) bool {
+	switch format {
+	case formatText, formatJSON, formatMarkdown, formatYAML:
+		return true
+	}
+	return false
+}
+
+// outputProjectFileInfo writes the parsed project in the selected format to stdout or the output file.
+func outputProjectFileInfo(fileInfos map[string]*ourtypes.FileInfo, out outputOptions) {
+	var w io.Writer = os.Stdout
+	if out.path != "" {
+		f, err := os.Create(out.path)
+		if err != nil {
+			color.Red("Error creating output file: %v", err)
+			return
+		}
+		defer f.Close()
+		w = f
+	}
+
+	if err := writeProjectFileInfo(w, applyVerbosity(fileInfos, out.verbosity), out); err != nil {
+		color.Red("Error writing output: %v", err)
+		return
+	}
+	if out.path != "" {
+		color.Green("Results written to %s", out.path)
+	}
+}
+
+// writeProjectFileInfo encodes fileInfos to w. Text output is only colored when written to stdout.
+func writeProjectFileInfo(w io.Writer, fileInfos map[string]*ourtypes.FileInfo, out outputOptions) error {
+	switch out.format {
+	case formatJSON:
+		versioned, err := ourtypes.ForSchemaVersion(fileInfos, out.schemaVersion)
+		if err != nil {
+			return err
+		}
+		return json.NewEncoder(w).Encode(versioned)
+	case formatYAML:
+		enc := yaml.NewEncoder(w)
+		defer enc.Close()
+		return enc.Encode(fileInfos)
+	case formatMarkdown:
+		_, err := io.WriteString(w, markdownProjectFileInfo(fileInfos))
+		return err
+	default:
+		noColor := color.NoColor
+		color.NoColor = noColor || out.path != ""
+		defer func() { color.NoColor = noColor }()
+		printProjectFileInfo(w, fileInfos)
+		return nil
+	}
+}
+
+func printProjectFileInfo(w io.Writer, fileInfos map[string]*ourtypes.FileInfo) {
+	fmt.Fprintln(w, color.CyanString("Project Information:"))
+
+	for _, filePath := range sortedPaths(fileInfos) {
+		fileInfo := fileInfos[filePath]
+		fmt.Fprintf(w, "\n--- File: %s ---\n", color.YellowString(filePath))
+		fmt.Fprintf(w, "  Package Name: %s\n", fileInfo.PackageName)
+		if fileInfo.PackageDoc != "" {
+			fmt.Fprintf(w, "  Package Doc: %q\n", fileInfo.PackageDoc)
+		}
+
+		fmt.Fprintf(w, "  Imports:\n")
+		if len(fileInfo.Imports) == 0 {
+			fmt.Fprintln(w, "    (None)")
+		} else {
+			for _, imp := range fileInfo.Imports {
+				fmt.Fprintf(w, "    - %s\n", imp)
+			}
+		}
+
+		fmt.Fprintf(w, "  Functions:\n")
+		if len(fileInfo.Functions) == 0 {
+			fmt.Fprintln(w, "    (None)")
+		} else {
+			for _, fn := range fileInfo.Functions {
+				fmt.Fprintf(w, "    - %v\n", fn)
+			}
+		}
+
+		fmt.Fprintf(w, "  Local Structs:\n")
+		if len(fileInfo.Structs) == 0 {
+			fmt.Fprintln(w, "    (None)")
+		} else {
+			for _, s := range fileInfo.Structs {
+				fmt.Fprintf(w, "    - %s (Comment: %q)\n", color.MagentaString(s.Name), s.Comment)
+				if len(s.Fields) > 0 {
+					fmt.Fprintln(w, "      Fields:")
+					for _, f := range s.Fields {
+						fmt.Fprintf(w, "        - %s %s\n", f.Name, f.Type)
+					}
+				}
+				if len(s.Methods) > 0 {
+					fmt.Fprintln(w, "      Methods:")
+					for _, m := range s.Methods {
+						fmt.Fprintf(w, "        - %s(%s) (%s) (Comment: %q)\n", m.Name, strings.Join(m.Parameters, ", "), strings.Join(m.ReturnTypes, ", "), m.Comment)
+					}
+				}
+			}
+		}
+
+		fmt.Fprintf(w, "  Used Imported Structs:\n")
+		if len(fileInfo.UsedImportedStructs) == 0 {
+			fmt.Fprintln(w, "    (None)")
+		} else {
+			for _, s := range fileInfo.UsedImportedStructs {
+				fmt.Fprintf(w, "    - %s\n", s.Name)
+			}
+		}
+
+		fmt.Fprintf(w, "  Used Imported Interfaces:\n")
+		if len(fileInfo.UsedImportedInterfaces) == 0 {
+			fmt.Fprintln(w, "    (None)")
+		} else {
+			for _, i := range fileInfo.UsedImportedInterfaces {
+				fmt.Fprintf(w, "    - %s\n", i.Name)
+			}
+		}
+	}
+}
+
+// markdownProjectFileInfo renders fileInfos as a Markdown document with one section per file.
+func markdownProjectFileInfo(fileInfos map[string]*ourtypes.FileInfo) string {
+	var b strings.Builder
+	b.WriteString("# Project Information\n")
+
+	for _, filePath := range sortedPaths(fileInfos) {
+		fileInfo := fileInfos[filePath]
+		fmt.Fprintf(&b, "\n## `%s`\n\nPackage: `%s`\n", filePath, fileInfo.PackageName)
+		if fileInfo.PackageDoc != "" {
+			fmt.Fprintf(&b, "\n%s\n", fileInfo.PackageDoc)
+		}
+
+		if len(fileInfo.Imports) > 0 {
+			b.WriteString("\n### Imports\n\n")
+			for _, imp := range fileInfo.Imports {
+				fmt.Fprintf(&b, "- `%s`\n", imp)
+			}
+		}
+
+		if len(fileInfo.Functions) > 0 || len(fileInfo.Methods) > 0 {
+			b.WriteString("\n### Functions\n\n")
+			for _, fn := range fileInfo.Functions {
+				fmt.Fprintf(&b, "- `%s`%s\n", functionSignature(fn), markdownComment(fn.Comment))
+			}
+			for _, m := range fileInfo.Methods {
+				fmt.Fprintf(&b, "- `%s`%s\n", functionSignature(m), markdownComment(m.Comment))
+			}
+		}
+
+		if len(fileInfo.Structs) > 0 {
+			b.WriteString("\n### Structs\n")
+			for _, s := range fileInfo.Structs {
+				fmt.Fprintf(&b, "\n#### `%s`\n", s.Name)
+				if s.Comment != "" {
+					fmt.Fprintf(&b, "\n%s\n", s.Comment)
+				}
+				if len(s.Fields) > 0 {
+					b.WriteString("\n| Field | Type |\n|-------|------|\n")
+					for _, f := range s.Fields {
+						fmt.Fprintf(&b, "| `%s` | `%s` |\n", f.Name, f.Type)
+					}
+				}
+				if len(s.Methods) > 0 {
+					b.WriteString("\n")
+					for _, m := range s.Methods {
+						fmt.Fprintf(&b, "- `%s(%s) (%s)`%s\n", m.Name, strings.Join(m.Parameters, ", "), strings.Join(m.ReturnTypes, ", "), markdownComment(m.Comment))
+					}
+				}
+			}
+		}
+
+		if len(fileInfo.Interfaces) > 0 {
+			b.WriteString("\n### Interfaces\n")
+			for _, iface := range fileInfo.Interfaces {
+				fmt.Fprintf(&b, "\n#### `%s`\n", iface.Name)
+				if iface.Comment != "" {
+					fmt.Fprintf(&b, "\n%s\n", iface.Comment)
+				}
+				if len(iface.Methods) > 0 {
+					b.WriteString("\n")
+					for _, m := range iface.Methods {
+						fmt.Fprintf(&b, "- `%s(%s) (%s)`%s\n", m.Name, strings.Join(m.Parameters, ", "), strings.Join(m.ReturnTypes, ", "), markdownComment(m.Comment))
+					}
+				}
+			}
+		}
+
+		if len(fileInfo.UsedImportedStructs) > 0 || len(fileInfo.UsedImportedInterfaces) > 0 {
+			b.WriteString("\n### Used Imported Types\n\n")
+			for _, s := range fileInfo.UsedImportedStructs {
+				fmt.Fprintf(&b, "- `%s`\n", s.Name)
+			}
+			for _, i := range fileInfo.UsedImportedInterfaces {
+				fmt.Fprintf(&b, "- `%s` (interface)\n", i.Name)
+			}
+		}
+	}
+
+	return b.String()
+}
+
+// functionSignature renders a function as "(Receiver) Name(params) (returns)".
+func functionSignature(fn *ourtypes.FunctionInfo) string {
+	sig := fmt.Sprintf("%s(%s)", fn.Name, strings.Join(fn.Params, ", "))
+	if fn.Receiver != "" {
+		sig = fmt.Sprintf("(%s) %s", fn.Receiver, sig)
+	}
+	if len(fn.Returns) > 0 {
+		sig += fmt.Sprintf(" (%s)", strings.Join(fn.Returns, ", "))
+	}
+	return sig
+}
+
+// markdownComment renders the first line of a doc comment as a list item suffix.
+func markdownComment(comment string) string {
+	if comment == "" {
+		return ""
+	}
+	return " — " + strings.SplitN(comment, "\n", 2)[0]
+}
+
+// sortedPaths returns the file paths of fileInfos in a stable order.
+func sortedPaths(fileInfos map[string]*ourtypes.FileInfo) []string {
+	paths := make([]string, 0, len(fileInfos))
+	for path := range fileInfos {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	return paths
+}
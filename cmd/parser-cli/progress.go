@@ -0,0 +1,53 @@
+package main
+
+import (
+	"os"
+
+	pb "github.com/schollz/progressbar/v3"
+	"github.com/vlad/ast2llm-go/internal/parser"
+)
+
+// parseProgress shows the progress of a parse on stderr, keeping stdout clean for piped output: a spinner
+// while the packages load, then a bar counting the extracted files out of the total the load reported.
+type parseProgress struct {
+	bar   *pb.ProgressBar
+	sized bool // Whether the bar shows the number of files yet
+}
+
+// newParseProgress starts the spinner shown until the packages are loaded. Warnings printed meanwhile appear
+// above it.
+func newParseProgress() *parseProgress {
+	p := &parseProgress{bar: pb.NewOptions(-1,
+		pb.OptionSetDescription("Loading packages..."),
+		pb.OptionSetWriter(os.Stderr),
+		pb.OptionShowCount(),
+		pb.OptionSetRenderBlankState(true),
+		pb.OptionClearOnFinish(),
+		pb.OptionSetTheme(pb.Theme{
+			Saucer:        "=",
+			SaucerHead:    ">",
+			SaucerPadding: " ",
+			BarStart:      "[",
+			BarEnd:        "]",
+		}))}
+	liveWarnings.attach(p)
+	return p
+}
+
+// report is the parser.ProgressFunc updating the bar
+func (p *parseProgress) report(progress parser.Progress) {
+	if !p.sized && progress.TotalFiles > 0 {
+		p.sized = true
+		p.bar.Describe("Extracting files...")
+		p.bar.ChangeMax(progress.TotalFiles)
+	}
+	if p.sized {
+		_ = p.bar.Set(progress.FilesProcessed)
+	}
+}
+
+// finish removes the bar, which also stops the goroutine animating the spinner
+func (p *parseProgress) finish() {
+	liveWarnings.attach(nil)
+	_ = p.bar.Finish()
+}
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/vlad/ast2llm-go/internal/git"
+	"github.com/vlad/ast2llm-go/internal/parser"
+	ourtypes "github.com/vlad/ast2llm-go/internal/types"
+)
+
+// parseProject parses the project at absPath. With since set, only the packages affected by the changes since
+// that git ref are parsed, see affectedFiles. progress, which may be nil, receives the progress of full parses.
+func parseProject(p *parser.ProjectParser, absPath, since string, progress parser.ProgressFunc) (map[string]*ourtypes.FileInfo, error) {
+	if since == "" {
+		return p.ParseProjectWithProgress(absPath, progress)
+	}
+	files, err := affectedFiles(p, absPath, since)
+	if err != nil {
+		return nil, err
+	}
+	if len(files) == 0 {
+		return map[string]*ourtypes.FileInfo{}, nil
+	}
+	return p.ParseFiles(absPath, files)
+}
+
+// affectedFiles returns the source files of the project packages affected by the changes since ref: the
+// packages with changed, added or deleted Go files and the project packages importing them, whose used items
+// may have changed.
+func affectedFiles(p *parser.ProjectParser, absPath, ref string) ([]string, error) {
+	changed, err := git.ChangedFiles(absPath, ref)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list files changed since %s: %w", ref, err)
+	}
+	// git reports paths below the real repository root, which differ from absPath if it contains a symlink
+	realPath, err := filepath.EvalSymlinks(absPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve path: %w", err)
+	}
+	changedDirs := make(map[string]bool)
+	for _, file := range changed {
+		rel, err := filepath.Rel(realPath, file)
+		if err != nil || !strings.HasSuffix(file, ".go") || strings.HasPrefix(rel, "..") {
+			continue
+		}
+		changedDirs[filepath.Dir(filepath.Join(absPath, rel))] = true
+	}
+	if len(changedDirs) == 0 {
+		return nil, nil
+	}
+
+	graph, err := p.BuildGraph(absPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build dependency graph: %w", err)
+	}
+	affected := make(map[string]bool)
+	for pkgPath, node := range graph.Nodes {
+		for _, file := range node.Files {
+			if changedDirs[filepath.Dir(file)] {
+				affected[pkgPath] = true
+				for _, importer := range node.DependedOnBy {
+					affected[importer] = true
+				}
+				break
+			}
+		}
+	}
+
+	var files []string
+	for pkgPath := range affected {
+		files = append(files, graph.Nodes[pkgPath].Files...)
+	}
+	sort.Strings(files)
+	return files, nil
+}
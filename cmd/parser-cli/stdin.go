@@ -0,0 +1,24 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/vlad/ast2llm-go/internal/parser"
+)
+
+// analyzeStdin writes the details of the Go source read from stdin, parsed as the file filePath of the project
+// at path, see parser.ProjectParser.ParseSource. path may be empty to parse the source on its own.
+func analyzeStdin(p *parser.ProjectParser, path, filePath string, out outputOptions) error {
+	src, err := io.ReadAll(os.Stdin)
+	if err != nil {
+		return fmt.Errorf("failed to read source: %w", err)
+	}
+	fileInfos, err := p.ParseSource(path, filePath, src)
+	if err != nil {
+		return err
+	}
+	outputProjectFileInfo(fileInfos, out)
+	return nil
+}
@@ -0,0 +1,101 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"text/tabwriter"
+
+	"github.com/fatih/color"
+	"github.com/vlad/ast2llm-go/internal/composer"
+	"github.com/vlad/ast2llm-go/internal/parser"
+)
+
+// writeTokenReport composes every file of the project and writes how many tokens each section of the context
+// contributes, as a table or as JSON with --format json, to stdout or the output file. With since set, only the
+// packages affected by the changes since that git ref are reported.
+func writeTokenReport(p *parser.ProjectParser, path, since string, budget int, out outputOptions) error {
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return fmt.Errorf("failed to resolve path: %w", err)
+	}
+	fileInfos, err := parseProject(p, absPath, since, nil)
+	if err != nil {
+		return fmt.Errorf("failed to parse project: %w", err)
+	}
+
+	opts := []composer.Option{composer.WithVerbosity(out.verbosity)}
+	if budget > 0 {
+		opts = append(opts, composer.WithBudget(budget))
+	}
+	c := composer.New(fileInfos, opts...)
+
+	paths := make([]string, 0, len(fileInfos))
+	for filePath := range fileInfos {
+		paths = append(paths, filePath)
+	}
+	sort.Strings(paths)
+
+	reports := make(map[string][]composer.SectionTokens, len(paths))
+	for _, filePath := range paths {
+		report, err := c.TokenReport(filePath)
+		if err != nil {
+			return fmt.Errorf("failed to count tokens of %s: %w", filePath, err)
+		}
+		reports[filePath] = report
+	}
+
+	var w io.Writer = os.Stdout
+	if out.path != "" {
+		f, err := os.Create(out.path)
+		if err != nil {
+			return fmt.Errorf("failed to create output file: %w", err)
+		}
+		defer f.Close()
+		w = f
+	}
+
+	if out.format == formatJSON {
+		err = json.NewEncoder(w).Encode(reports)
+	} else {
+		err = printTokenReport(w, absPath, paths, reports)
+	}
+	if err != nil {
+		return err
+	}
+	if out.path != "" {
+		color.Green("Token report written to %s", out.path)
+	}
+	return nil
+}
+
+// printTokenReport writes one table of section token counts per file followed by the project total.
+func printTokenReport(w io.Writer, root string, paths []string, reports map[string][]composer.SectionTokens) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	total := 0
+	for _, filePath := range paths {
+		relPath, err := filepath.Rel(root, filePath)
+		if err != nil {
+			relPath = filePath
+		}
+		fileTotal := 0
+		for _, s := range reports[filePath] {
+			fileTotal += s.Tokens
+		}
+		total += fileTotal
+
+		fmt.Fprintf(tw, "%s\t\t%d tokens\n", relPath, fileTotal)
+		for _, s := range reports[filePath] {
+			items := ""
+			if s.Items > 0 {
+				items = fmt.Sprintf("%d items", s.Items)
+			}
+			fmt.Fprintf(tw, "  %s\t%s\t%d\n", s.Section, items, s.Tokens)
+		}
+	}
+	fmt.Fprintf(tw, "Total\t%d files\t%d tokens\n", len(paths), total)
+	return tw.Flush()
+}
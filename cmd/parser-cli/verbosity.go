@@ -0,0 +1,131 @@
+package main
+
+import (
+	"github.com/vlad/ast2llm-go/internal/composer"
+	ourtypes "github.com/vlad/ast2llm-go/internal/types"
+)
+
+// applyVerbosity returns copies of fileInfos without the details hidden at verbosity v.
+// Comments and examples are dropped below composer.VerbosityComments and struct fields below composer.VerbosityFields;
+// function sources are only recorded by the parser at composer.VerbosityBodies.
+func applyVerbosity(fileInfos map[string]*ourtypes.FileInfo, v composer.Verbosity) map[string]*ourtypes.FileInfo {
+	if v >= composer.VerbosityFields {
+		return fileInfos
+	}
+
+	trimmed := make(map[string]*ourtypes.FileInfo, len(fileInfos))
+	for path, fi := range fileInfos {
+		c := *fi
+		if v < composer.VerbosityComments {
+			c.PackageDoc = ""
+		}
+		c.Functions = trimFunctions(fi.Functions, v)
+		c.Methods = trimFunctions(fi.Methods, v)
+		c.Structs = trimStructs(fi.Structs, v)
+		c.Interfaces = trimInterfaces(fi.Interfaces, v)
+		c.GlobalVars = trimGlobalVars(fi.GlobalVars, v)
+		c.ConstGroups = trimConstGroups(fi.ConstGroups, v)
+		c.InlineTypes = trimInlineTypes(fi.InlineTypes)
+		c.UsedImportedStructs = trimStructs(fi.UsedImportedStructs, v)
+		c.UsedImportedInterfaces = trimInterfaces(fi.UsedImportedInterfaces, v)
+		c.UsedImportedFunctions = trimFunctions(fi.UsedImportedFunctions, v)
+		c.UsedImportedGlobalVars = trimGlobalVars(fi.UsedImportedGlobalVars, v)
+		c.ExternalStructs = trimStructs(fi.ExternalStructs, v)
+		c.ExternalInterfaces = trimInterfaces(fi.ExternalInterfaces, v)
+		trimmed[path] = &c
+	}
+	return trimmed
+}
+
+// trimFunctions copies functions, dropping comments and examples below composer.VerbosityComments
+func trimFunctions(functions []*ourtypes.FunctionInfo, v composer.Verbosity) []*ourtypes.FunctionInfo {
+	result := make([]*ourtypes.FunctionInfo, 0, len(functions))
+	for _, fn := range functions {
+		c := *fn
+		if v < composer.VerbosityComments {
+			c.Comment = ""
+			c.Examples = nil
+		}
+		result = append(result, &c)
+	}
+	return result
+}
+
+// trimStructs copies structs without their fields, dropping comments and examples below composer.VerbosityComments
+func trimStructs(structs []*ourtypes.StructInfo, v composer.Verbosity) []*ourtypes.StructInfo {
+	result := make([]*ourtypes.StructInfo, 0, len(structs))
+	for _, s := range structs {
+		c := *s
+		c.Fields = make([]*ourtypes.StructField, 0)
+		if v < composer.VerbosityComments {
+			c.Comment = ""
+			c.Examples = nil
+			c.Methods = make([]*ourtypes.StructMethod, 0, len(s.Methods))
+			for _, m := range s.Methods {
+				mc := *m
+				mc.Comment = ""
+				c.Methods = append(c.Methods, &mc)
+			}
+		}
+		result = append(result, &c)
+	}
+	return result
+}
+
+// trimInterfaces copies interfaces, dropping comments and examples below composer.VerbosityComments
+func trimInterfaces(interfaces []*ourtypes.InterfaceInfo, v composer.Verbosity) []*ourtypes.InterfaceInfo {
+	result := make([]*ourtypes.InterfaceInfo, 0, len(interfaces))
+	for _, iface := range interfaces {
+		c := *iface
+		if v < composer.VerbosityComments {
+			c.Comment = ""
+			c.Examples = nil
+			c.Methods = make([]*ourtypes.InterfaceMethod, 0, len(iface.Methods))
+			for _, m := range iface.Methods {
+				mc := *m
+				mc.Comment = ""
+				c.Methods = append(c.Methods, &mc)
+			}
+		}
+		result = append(result, &c)
+	}
+	return result
+}
+
+// trimGlobalVars copies global variables, dropping comments below composer.VerbosityComments
+func trimGlobalVars(globalVars []*ourtypes.GlobalVarInfo, v composer.Verbosity) []*ourtypes.GlobalVarInfo {
+	result := make([]*ourtypes.GlobalVarInfo, 0, len(globalVars))
+	for _, gv := range globalVars {
+		c := *gv
+		if v < composer.VerbosityComments {
+			c.Comment = ""
+		}
+		result = append(result, &c)
+	}
+	return result
+}
+
+// trimInlineTypes copies inline types without the fields of anonymous structs
+func trimInlineTypes(inlineTypes []*ourtypes.InlineType) []*ourtypes.InlineType {
+	result := make([]*ourtypes.InlineType, 0, len(inlineTypes))
+	for _, t := range inlineTypes {
+		c := *t
+		c.Fields = make([]*ourtypes.StructField, 0)
+		result = append(result, &c)
+	}
+	return result
+}
+
+// trimConstGroups copies const groups, dropping comments below composer.VerbosityComments
+func trimConstGroups(groups []*ourtypes.ConstGroup, v composer.Verbosity) []*ourtypes.ConstGroup {
+	result := make([]*ourtypes.ConstGroup, 0, len(groups))
+	for _, g := range groups {
+		c := *g
+		if v < composer.VerbosityComments {
+			c.Comment = ""
+		}
+		c.Constants = trimGlobalVars(g.Constants, v)
+		result = append(result, &c)
+	}
+	return result
+}
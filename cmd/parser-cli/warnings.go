@@ -0,0 +1,51 @@
+package main
+
+import (
+	"os"
+	"sync"
+
+	"github.com/fatih/color"
+	"github.com/vlad/ast2llm-go/internal/parser"
+)
+
+// warningsNone is the --warnings value silencing the parse warnings
+const warningsNone = "none"
+
+// warningPrinter prints the parse warnings of at least a minimum severity to stderr as they are found, above
+// the progress bar of the parse in progress, if any.
+type warningPrinter struct {
+	min parser.Severity
+
+	mu       sync.Mutex
+	progress *parseProgress // Progress bar to redraw below the warnings, nil if none is shown
+}
+
+// liveWarnings prints the warnings of the parser built by main; parseProgress attaches its bar to it
+var liveWarnings = &warningPrinter{min: parser.SeverityWarning}
+
+// print is the parser.WarningFunc of the printer
+func (w *warningPrinter) print(warning parser.Warning) {
+	if warning.Severity < w.min {
+		return
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.progress != nil {
+		_ = w.progress.bar.Clear()
+	}
+	c := color.New(color.FgYellow)
+	if warning.Severity >= parser.SeverityError {
+		c = color.New(color.FgRed)
+	}
+	_, _ = c.Fprintln(os.Stderr, warning.String())
+	if w.progress != nil {
+		_ = w.progress.bar.RenderBlank()
+	}
+}
+
+// attach makes the printer redraw progress below the warnings it prints, nil to stop.
+func (w *warningPrinter) attach(progress *parseProgress) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.progress = progress
+}
@@ -0,0 +1,109 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/fsnotify/fsnotify"
+	"github.com/vlad/ast2llm-go/internal/parser"
+)
+
+// watchDebounce is how long to wait for more events before re-running the analysis
+const watchDebounce = 300 * time.Millisecond
+
+// watchProject analyzes the project and then re-analyzes the changed packages on every file change.
+func watchProject(p *parser.ProjectParser, path string, out outputOptions) error {
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return fmt.Errorf("failed to resolve path: %w", err)
+	}
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("failed to create watcher: %w", err)
+	}
+	defer watcher.Close()
+
+	if err := addWatchDirs(watcher, absPath); err != nil {
+		return err
+	}
+
+	fileInfos, err := p.ParseProjectIncremental(absPath, nil)
+	if err != nil {
+		return fmt.Errorf("failed to parse project: %w", err)
+	}
+	outputProjectFileInfo(fileInfos, out)
+
+	changed := make(map[string]bool)
+	debounce := time.NewTimer(watchDebounce)
+	debounce.Stop()
+
+	for {
+		select {
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return nil
+			}
+			if event.Has(fsnotify.Create) {
+				if st, err := os.Stat(event.Name); err == nil && st.IsDir() {
+					if err := addWatchDirs(watcher, event.Name); err != nil {
+						color.Red("Error watching %s: %v", event.Name, err)
+					}
+					continue
+				}
+			}
+			if filepath.Ext(event.Name) != ".go" {
+				continue
+			}
+			changed[event.Name] = true
+			debounce.Reset(watchDebounce)
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return nil
+			}
+			color.Red("Watcher error: %v", err)
+		case <-debounce.C:
+			files := make([]string, 0, len(changed))
+			for f := range changed {
+				files = append(files, f)
+			}
+			sort.Strings(files)
+			changed = make(map[string]bool)
+
+			if out.path != "" || out.format == formatText {
+				color.Cyan("\nChanged: %s", strings.Join(files, ", "))
+			}
+			fileInfos, err := p.ParseProjectIncremental(absPath, files)
+			if err != nil {
+				color.Red("Error parsing project: %v", err)
+				continue
+			}
+			outputProjectFileInfo(fileInfos, out)
+		}
+	}
+}
+
+// addWatchDirs registers root and all its subdirectories, skipping hidden, vendor and testdata directories.
+func addWatchDirs(watcher *fsnotify.Watcher, root string) error {
+	return filepath.WalkDir(root, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() {
+			return nil
+		}
+		name := d.Name()
+		if path != root && (strings.HasPrefix(name, ".") || name == "vendor" || name == "testdata") {
+			return filepath.SkipDir
+		}
+		if err := watcher.Add(path); err != nil {
+			return fmt.Errorf("failed to watch %s: %w", path, err)
+		}
+		return nil
+	})
+}
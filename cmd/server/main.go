@@ -1,25 +1,107 @@
 package main
 
 import (
+	"flag"
 	"log"
+	"net"
+	"os"
 
 	"github.com/mark3labs/mcp-go/server"
+	"github.com/vlad/ast2llm-go/internal/config"
+	"github.com/vlad/ast2llm-go/internal/embeddings"
 	"github.com/vlad/ast2llm-go/internal/parser"
 	"github.com/vlad/ast2llm-go/internal/prompts"
+	"github.com/vlad/ast2llm-go/internal/rpc"
 	"github.com/vlad/ast2llm-go/internal/tools"
+	"google.golang.org/grpc"
 )
 
 func main() {
+	includeExternal := flag.Bool("include-external", false, "Describe used types from the standard library and third-party modules")
+	functionBodies := flag.Bool("function-bodies", false, "Record function sources so parse_go can return them with verbosity \"bodies\"")
+	examples := flag.Bool("examples", false, "Attach the ExampleXxx functions of test files to the symbols they document")
+	vendor := flag.Bool("vendor", false, "Resolve dependencies from the vendor directory (-mod=vendor), the default for projects with vendor/modules.txt")
+	offline := flag.Bool("offline", false, "Never download modules or toolchains, failing with the list of imported packages missing from the module cache")
+	transitiveDepth := flag.Int("transitive-depth", 0, "Also describe the types referenced by used types from other packages, up to this many levels")
+	inlineMinLines := flag.Int("inline-min-lines", parser.DefaultInlineMinLines, "Describe anonymous structs and function literals spanning at least this many lines, 0 for none")
+	naming := flag.String("naming", "", "How names are qualified: default, full, package or short")
+	grpcAddr := flag.String("grpc", "", "Serve the gRPC API on this address (e.g. :50051) instead of MCP over stdio")
+	configPath := flag.String("config", "", "Read parser settings from this file instead of the "+config.FileName+" of the working directory")
+	embeddingsBackend := flag.String("embeddings", "", "Enable the rank_context tool, embedding symbols with this backend: local or openai (any OpenAI-compatible API)")
+	embeddingsURL := flag.String("embeddings-url", embeddings.DefaultOpenAIURL, "Base URL of the OpenAI-compatible embeddings API, e.g. http://localhost:11434/v1 for Ollama")
+	embeddingsModel := flag.String("embeddings-model", embeddings.DefaultOpenAIModel, "Embedding model of the OpenAI-compatible API")
+	embeddingsDir := flag.String("embeddings-dir", "", "Directory the embeddings indexes are persisted in (default: ast2llm-go/embeddings in the user cache directory)")
+	metricsAddr := flag.String("metrics", "", "Serve parse metrics, memory usage and profiles over HTTP on this address (e.g. localhost:6060)")
+	flag.Parse()
+
+	cfg, err := loadConfig(*configPath)
+	if err != nil {
+		log.Fatalf("Failed to load configuration: %v", err)
+	}
+	opts := cfg.ParserOptions()
+	if *includeExternal {
+		opts = append(opts, parser.WithExternalTypes())
+	}
+	if *functionBodies {
+		opts = append(opts, parser.WithFunctionBodies())
+	}
+	if *examples {
+		opts = append(opts, parser.WithExamples())
+	}
+	if *vendor {
+		opts = append(opts, parser.WithVendor())
+	}
+	if *offline {
+		opts = append(opts, parser.WithOffline())
+	}
+	if *transitiveDepth > 0 {
+		opts = append(opts, parser.WithTransitiveDepth(*transitiveDepth))
+	}
+	opts = append(opts, parser.WithInlineMinLines(*inlineMinLines))
+	if *naming != "" {
+		mode, err := parser.ParseNamingMode(*naming)
+		if err != nil {
+			log.Fatalf("Invalid --naming: %v", err)
+		}
+		opts = append(opts, parser.WithNaming(mode))
+	}
+	// Parse warnings are forwarded to MCP clients as logging notifications once the server exists
+	warnings := &tools.WarningNotifier{}
+	opts = append(opts, parser.WithWarnings(warnings.Notify))
+	p := parser.New(opts...)
+
+	if *metricsAddr != "" {
+		go serveMetrics(p, *metricsAddr)
+	}
+
+	if *grpcAddr != "" {
+		serveGRPC(p, *grpcAddr)
+		return
+	}
+
 	// Initialize components
+	hooks := &server.Hooks{}
+	warnings.AddHooks(hooks)
 	s := server.NewMCPServer(
 		"AST2LLM",
 		"1.0.0",
 		server.WithToolCapabilities(false),
+		server.WithLogging(),
+		server.WithHooks(hooks),
 	)
-	p := parser.New()
+	warnings.Attach(s)
+
+	var toolOpts []tools.Option
+	if *embeddingsBackend != "" {
+		opt, err := embeddingsOption(*embeddingsBackend, *embeddingsURL, *embeddingsModel, *embeddingsDir)
+		if err != nil {
+			log.Fatalf("Invalid --embeddings: %v", err)
+		}
+		toolOpts = append(toolOpts, opt)
+	}
 
 	// Register tools
-	if err := tools.RegisterTools(s, p); err != nil {
+	if err := tools.RegisterTools(s, p, toolOpts...); err != nil {
 		log.Fatalf("Failed to register tools: %v", err)
 	}
 
@@ -33,3 +115,47 @@ func main() {
 		log.Fatalf("Server error: %v\n", err)
 	}
 }
+
+// loadConfig reads the configuration file at path, or the one of the working directory if path is empty.
+// Clients usually start the server in the project they work on, so its settings apply to every parse.
+func loadConfig(path string) (*config.Config, error) {
+	if path != "" {
+		return config.LoadFile(path)
+	}
+	return config.Load(".")
+}
+
+// embeddingsOption configures the rank_context tool. The API key of the OpenAI-compatible backend is read from
+// the AST2LLM_EMBEDDINGS_API_KEY environment variable, or OPENAI_API_KEY.
+func embeddingsOption(backend, url, model, dir string) (tools.Option, error) {
+	apiKey := os.Getenv("AST2LLM_EMBEDDINGS_API_KEY")
+	if apiKey == "" {
+		apiKey = os.Getenv("OPENAI_API_KEY")
+	}
+	e, err := embeddings.New(backend, url, model, apiKey)
+	if err != nil {
+		return nil, err
+	}
+	if dir == "" {
+		if dir, err = embeddings.DefaultDir(); err != nil {
+			return nil, err
+		}
+	}
+	return tools.WithEmbeddings(e, dir), nil
+}
+
+// serveGRPC serves the gRPC API on addr until the listener fails.
+func serveGRPC(p *parser.ProjectParser, addr string) {
+	lis, err := net.Listen("tcp", addr)
+	if err != nil {
+		log.Fatalf("Failed to listen on %s: %v", addr, err)
+	}
+
+	gs := grpc.NewServer()
+	rpc.NewServer(p).Register(gs)
+
+	log.Printf("Serving gRPC on %s", lis.Addr())
+	if err := gs.Serve(lis); err != nil {
+		log.Fatalf("gRPC server error: %v", err)
+	}
+}
@@ -0,0 +1,40 @@
+package main
+
+import (
+	"expvar"
+	"log"
+	"net/http"
+	"net/http/pprof"
+	"runtime"
+
+	"github.com/vlad/ast2llm-go/internal/parser"
+)
+
+// serveMetrics serves the parser counters and the memory usage as expvar JSON on /debug/vars, and the
+// runtime profiles on /debug/pprof/, at addr. It runs until the listener fails, which is only logged so that
+// the MCP server keeps running.
+func serveMetrics(p *parser.ProjectParser, addr string) {
+	expvar.Publish("parser", expvar.Func(func() any {
+		stats := p.Stats()
+		return struct {
+			parser.Stats
+			CacheHitRate float64 `json:"cache_hit_rate"`
+		}{stats, stats.CacheHitRate()}
+	}))
+	expvar.Publish("goroutines", expvar.Func(func() any {
+		return runtime.NumGoroutine()
+	}))
+
+	mux := http.NewServeMux()
+	mux.Handle("/debug/vars", expvar.Handler())
+	mux.HandleFunc("/debug/pprof/", pprof.Index)
+	mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+	mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+	mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+	mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+
+	log.Printf("Serving metrics on %s/debug/vars", addr)
+	if err := http.ListenAndServe(addr, mux); err != nil {
+		log.Printf("Metrics server error: %v", err)
+	}
+}
@@ -0,0 +1,46 @@
+package composer
+
+import (
+	"fmt"
+	"strings"
+
+	ourtypes "github.com/vlad/ast2llm-go/internal/types"
+)
+
+// FormatAPIChanges renders the differences between the API surfaces of two versions of a project, as returned
+// by parser.CompareProjects, as a report grouping the removed, changed and added declarations, the ones
+// breaking callers first. Changed declarations show their old and new signatures.
+func FormatAPIChanges(changes []*ourtypes.APIChange) string {
+	var builder strings.Builder
+	builder.WriteString("--- API Changes ---\n")
+	if len(changes) == 0 {
+		builder.WriteString("No changes\n")
+		return builder.String()
+	}
+
+	groups := []struct{ kind, title string }{
+		{ourtypes.APIRemoved, "Removed"},
+		{ourtypes.APIChanged, "Changed"},
+		{ourtypes.APIAdded, "Added"},
+	}
+	for _, group := range groups {
+		var section strings.Builder
+		for _, c := range changes {
+			if c.Kind != group.kind {
+				continue
+			}
+			switch c.Kind {
+			case ourtypes.APIRemoved:
+				section.WriteString(fmt.Sprintf("- %s: %s\n", c.Name, c.Old))
+			case ourtypes.APIAdded:
+				section.WriteString(fmt.Sprintf("- %s: %s\n", c.Name, c.New))
+			default:
+				section.WriteString(fmt.Sprintf("- %s\n  Old: %s\n  New: %s\n", c.Name, c.Old, c.New))
+			}
+		}
+		if section.Len() > 0 {
+			builder.WriteString(fmt.Sprintf("\n%s:\n%s", group.title, section.String()))
+		}
+	}
+	return builder.String()
+}
@@ -0,0 +1,34 @@
+package composer_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vlad/ast2llm-go/internal/composer"
+	"github.com/vlad/ast2llm-go/internal/types"
+)
+
+func TestFormatAPIChanges(t *testing.T) {
+	assert.Equal(t, "--- API Changes ---\nNo changes\n", composer.FormatAPIChanges(nil))
+
+	report := composer.FormatAPIChanges([]*types.APIChange{
+		{Kind: types.APIRemoved, Name: "example.com/app/api.Close", Old: "func Close()"},
+		{Kind: types.APIChanged, Name: "example.com/app/api.New", Old: "func New() int", New: "func New(port int) (int, error)"},
+		{Kind: types.APIAdded, Name: "example.com/app/api.Open", New: "func Open()"},
+		{Kind: types.APIRemoved, Name: "example.com/app/api.Reset", Old: "func Reset()"},
+	})
+	assert.Equal(t, `--- API Changes ---
+
+Removed:
+- example.com/app/api.Close: func Close()
+- example.com/app/api.Reset: func Reset()
+
+Changed:
+- example.com/app/api.New
+  Old: func New() int
+  New: func New(port int) (int, error)
+
+Added:
+- example.com/app/api.Open: func Open()
+`, report)
+}
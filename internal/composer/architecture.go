@@ -0,0 +1,184 @@
+package composer
+
+import (
+	"fmt"
+	"go/token"
+	"sort"
+	"strings"
+
+	ourtypes "github.com/vlad/ast2llm-go/internal/types"
+)
+
+// ComposeArchitecture describes the project as a whole: every package of graph with its doc comment, the
+// project packages it imports and is imported by, and a one-line summary of each exported declaration
+// found in the ProjectInfo. Packages are listed in dependency order, leaves first.
+func (p *ProjectComposer) ComposeArchitecture(graph *ourtypes.DependencyGraph) string {
+	var builder strings.Builder
+	builder.WriteString("--- Architecture ---\n")
+	builder.WriteString(fmt.Sprintf("Packages: %d\n\n", len(graph.Nodes)))
+
+	for _, pkgPath := range dependencyOrder(graph) {
+		node := graph.Nodes[pkgPath]
+		builder.WriteString(fmt.Sprintf("Package: %s\n", pkgPath))
+		if node.Doc != "" && p.showComments() {
+			builder.WriteString(fmt.Sprintf("  Doc: %s\n", strings.Join(strings.Fields(node.Doc), " ")))
+		}
+		if imports := projectImports(graph, node); len(imports) > 0 {
+			builder.WriteString(fmt.Sprintf("  Imports: %s\n", strings.Join(imports, ", ")))
+		}
+		if len(node.DependedOnBy) > 0 {
+			builder.WriteString(fmt.Sprintf("  Imported by: %s\n", strings.Join(node.DependedOnBy, ", ")))
+		}
+		if api := p.exportedAPI(node); len(api) > 0 {
+			builder.WriteString("  Exported API:\n")
+			for _, line := range api {
+				builder.WriteString("    - " + line + "\n")
+			}
+		}
+		builder.WriteString("\n")
+	}
+	return builder.String()
+}
+
+// dependencyOrder returns the package paths of graph so that every package comes after the project packages
+// it imports; packages in an import cycle, which have no such order, are appended sorted by path.
+func dependencyOrder(graph *ourtypes.DependencyGraph) []string {
+	paths := make([]string, 0, len(graph.Nodes))
+	for pkgPath := range graph.Nodes {
+		paths = append(paths, pkgPath)
+	}
+	sort.Strings(paths)
+
+	ordered := make([]string, 0, len(paths))
+	placed := make(map[string]bool, len(paths))
+	for len(ordered) < len(paths) {
+		progress := false
+		for _, pkgPath := range paths {
+			if placed[pkgPath] {
+				continue
+			}
+			ready := true
+			for _, dep := range projectImports(graph, graph.Nodes[pkgPath]) {
+				if !placed[dep] {
+					ready = false
+					break
+				}
+			}
+			if ready {
+				ordered = append(ordered, pkgPath)
+				placed[pkgPath] = true
+				progress = true
+			}
+		}
+		if !progress {
+			for _, pkgPath := range paths {
+				if !placed[pkgPath] {
+					ordered = append(ordered, pkgPath)
+					placed[pkgPath] = true
+				}
+			}
+		}
+	}
+	return ordered
+}
+
+// projectImports returns the imports of node that are packages of graph
+func projectImports(graph *ourtypes.DependencyGraph, node *ourtypes.Node) []string {
+	var imports []string
+	for _, dep := range node.DependsOn {
+		if _, ok := graph.Nodes[dep]; ok {
+			imports = append(imports, dep)
+		}
+	}
+	return imports
+}
+
+// exportedAPI summarizes the exported declarations of the files of node, one line each, sorted by name
+// within every kind: types first, then functions, then variables and constants.
+func (p *ProjectComposer) exportedAPI(node *ourtypes.Node) []string {
+	var types, functions, values []string
+	for _, file := range node.Files {
+		fileInfo, ok := p.projectInfo[file]
+		if !ok {
+			continue
+		}
+		for _, s := range fileInfo.Structs {
+			if token.IsExported(unqualified(s.Name)) {
+				types = append(types, summarizeStruct(s))
+			}
+		}
+		for _, iface := range fileInfo.Interfaces {
+			if token.IsExported(unqualified(iface.Name)) {
+				types = append(types, summarizeInterface(iface))
+			}
+		}
+		for _, fn := range fileInfo.Functions {
+			if token.IsExported(fn.Name) {
+				functions = append(functions, summarizeFunction(fn))
+			}
+		}
+		for _, gv := range fileInfo.GlobalVars {
+			if !token.IsExported(gv.Name) {
+				continue
+			}
+			kind := "var"
+			if gv.IsConst {
+				kind = "const"
+			}
+			values = append(values, fmt.Sprintf("%s %s %s", kind, gv.Name, gv.Type))
+		}
+		for _, g := range fileInfo.ConstGroups {
+			if name := unqualified(g.Type); token.IsExported(name) {
+				values = append(values, fmt.Sprintf("enum %s (%d values)", name, len(g.Constants)))
+			}
+		}
+	}
+	sort.Strings(types)
+	sort.Strings(functions)
+	sort.Strings(values)
+	return append(append(types, functions...), values...)
+}
+
+// summarizeStruct renders a struct with the names of its exported methods
+func summarizeStruct(s *ourtypes.StructInfo) string {
+	line := "struct " + unqualified(s.Name) + formatTypeParams(s.TypeParams)
+	var methods []string
+	for _, m := range s.Methods {
+		if m.PromotedFrom == "" && token.IsExported(m.Name) {
+			methods = append(methods, m.Name)
+		}
+	}
+	if len(methods) > 0 {
+		sort.Strings(methods)
+		line += " methods: " + strings.Join(methods, ", ")
+	}
+	return line
+}
+
+// summarizeInterface renders an interface with the names of its methods
+func summarizeInterface(iface *ourtypes.InterfaceInfo) string {
+	line := "interface " + unqualified(iface.Name) + formatTypeParams(iface.TypeParams)
+	var methods []string
+	for _, m := range iface.Methods {
+		methods = append(methods, m.Name)
+	}
+	if len(methods) > 0 {
+		sort.Strings(methods)
+		line += " methods: " + strings.Join(methods, ", ")
+	}
+	return line
+}
+
+// summarizeFunction renders a function signature on a single line
+func summarizeFunction(fn *ourtypes.FunctionInfo) string {
+	line := fmt.Sprintf("func %s%s(%s)", fn.Name, formatTypeParams(fn.TypeParams), strings.Join(fn.Params, ", "))
+	if len(fn.Returns) > 0 {
+		line += fmt.Sprintf(" -> (%s)", strings.Join(fn.Returns, ", "))
+	}
+	return line
+}
+
+// unqualified strips the package path from a fully qualified name, e.g. Store for example.com/project/db.Store
+func unqualified(name string) string {
+	return name[strings.LastIndex(name, ".")+1:]
+}
@@ -0,0 +1,90 @@
+package composer_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vlad/ast2llm-go/internal/composer"
+	"github.com/vlad/ast2llm-go/internal/parser"
+	"github.com/vlad/ast2llm-go/internal/types"
+)
+
+func TestProjectComposer_ComposeArchitecture(t *testing.T) {
+	graph := types.NewDependencyGraph()
+	graph.Nodes["example.com/app"] = &types.Node{
+		PkgPath:   "example.com/app",
+		DependsOn: []string{"example.com/app/store", "fmt"},
+		Files:     []string{"/app/main.go"},
+	}
+	graph.Nodes["example.com/app/store"] = &types.Node{
+		PkgPath: "example.com/app/store",
+		Doc:     "Package store persists\nusers.",
+		Files:   []string{"/app/store/store.go"},
+	}
+	graph.LinkReverseDeps()
+
+	projectInfo := parser.ProjectInfo{
+		"/app/main.go": {
+			PackageName: "main",
+			Functions:   []*types.FunctionInfo{{Name: "main"}},
+		},
+		"/app/store/store.go": {
+			PackageName: "store",
+			Structs: []*types.StructInfo{{
+				Name: "example.com/app/store.Store",
+				Methods: []*types.StructMethod{
+					{Name: "Save"},
+					{Name: "load"},
+				},
+			}},
+			Interfaces: []*types.InterfaceInfo{{
+				Name:    "example.com/app/store.Saver",
+				Methods: []*types.InterfaceMethod{{Name: "Save"}},
+			}},
+			Functions: []*types.FunctionInfo{
+				{Name: "Open", Params: []string{"dsn string"}, Returns: []string{"*Store", "error"}},
+				{Name: "helper"},
+			},
+			GlobalVars: []*types.GlobalVarInfo{{Name: "MaxUsers", Type: "int", IsConst: true}},
+			ConstGroups: []*types.ConstGroup{{
+				Type:      "example.com/app/store.Mode",
+				Constants: []*types.GlobalVarInfo{{Name: "ModeRead"}, {Name: "ModeWrite"}},
+			}},
+		},
+	}
+
+	expected := `--- Architecture ---
+Packages: 2
+
+Package: example.com/app/store
+  Doc: Package store persists users.
+  Imported by: example.com/app
+  Exported API:
+    - interface Saver methods: Save
+    - struct Store methods: Save
+    - func Open(dsn string) -> (*Store, error)
+    - const MaxUsers int
+    - enum Mode (2 values)
+
+Package: example.com/app
+  Imports: example.com/app/store
+
+`
+	assert.Equal(t, expected, composer.New(projectInfo).ComposeArchitecture(graph))
+
+	t.Run("signatures verbosity drops doc comments", func(t *testing.T) {
+		output := composer.New(projectInfo, composer.WithVerbosity(composer.VerbositySignatures)).ComposeArchitecture(graph)
+		assert.NotContains(t, output, "Doc:")
+	})
+
+	t.Run("import cycle", func(t *testing.T) {
+		cyclic := types.NewDependencyGraph()
+		cyclic.Nodes["example.com/b"] = &types.Node{PkgPath: "example.com/b", DependsOn: []string{"example.com/a"}}
+		cyclic.Nodes["example.com/a"] = &types.Node{PkgPath: "example.com/a", DependsOn: []string{"example.com/b"}}
+		cyclic.Nodes["example.com/c"] = &types.Node{PkgPath: "example.com/c"}
+		cyclic.LinkReverseDeps()
+
+		output := composer.New(parser.ProjectInfo{}).ComposeArchitecture(cyclic)
+		assert.Regexp(t, `(?s)Package: example.com/c\n.*Package: example.com/a\n.*Package: example.com/b\n`, output)
+	})
+}
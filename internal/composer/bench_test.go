@@ -0,0 +1,80 @@
+package composer_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/vlad/ast2llm-go/internal/composer"
+	"github.com/vlad/ast2llm-go/internal/parser"
+	"github.com/vlad/ast2llm-go/internal/types"
+)
+
+// newBenchProjectInfo generates a project of the given number of single-file packages, each declaring decls
+// structs and functions and using the declarations of the package before it.
+func newBenchProjectInfo(files, decls int) parser.ProjectInfo {
+	info := make(parser.ProjectInfo, files)
+	for f := 0; f < files; f++ {
+		pkg := fmt.Sprintf("example.com/app/pkg%d", f)
+		fileInfo := types.NewFileInfo()
+		fileInfo.PackageName = fmt.Sprintf("pkg%d", f)
+		fileInfo.PackageDoc = fmt.Sprintf("Package pkg%d is generated for benchmarks.", f)
+		if f > 0 {
+			fileInfo.Imports = []*types.ImportInfo{{Path: fmt.Sprintf("example.com/app/pkg%d", f-1)}}
+		}
+		for d := 0; d < decls; d++ {
+			name := fmt.Sprintf("%s.Item%d", pkg, d)
+			fileInfo.Structs = append(fileInfo.Structs, &types.StructInfo{
+				Name:    name,
+				Comment: fmt.Sprintf("Item%d holds generated fields.", d),
+				Fields: []*types.StructField{
+					{Name: "ID", Type: "int", Tag: `json:"id"`},
+					{Name: "Name", Type: "string", Tag: `json:"name"`},
+				},
+				Methods: []*types.StructMethod{{Name: "Describe", Comment: "Describe returns the name of the item.", ReturnTypes: []string{"string"}, ReceiverIsPointer: true}},
+			})
+			fileInfo.Functions = append(fileInfo.Functions, &types.FunctionInfo{
+				Name:    fmt.Sprintf("NewItem%d", d),
+				Comment: fmt.Sprintf("NewItem%d returns an item with the given ID.", d),
+				Params:  []string{"id int"},
+				Returns: []string{fmt.Sprintf("*Item%d", d)},
+			})
+			if f > 0 {
+				fileInfo.UsedImportedStructs = append(fileInfo.UsedImportedStructs, &types.StructInfo{
+					Name:   fmt.Sprintf("example.com/app/pkg%d.Item%d", f-1, d),
+					Fields: []*types.StructField{{Name: "ID", Type: "int"}},
+				})
+				fileInfo.UsedImportedFunctions = append(fileInfo.UsedImportedFunctions, &types.FunctionInfo{
+					Name:    fmt.Sprintf("example.com/app/pkg%d.NewItem%d", f-1, d),
+					Params:  []string{"id int"},
+					Returns: []string{fmt.Sprintf("*Item%d", d)},
+				})
+			}
+		}
+		info[fmt.Sprintf("/app/pkg%d/file.go", f)] = fileInfo
+	}
+	return info
+}
+
+func BenchmarkCompose(b *testing.B) {
+	info := newBenchProjectInfo(50, 20)
+	filePath := "/app/pkg25/file.go"
+	for _, bench := range []struct {
+		name    string
+		opts    []composer.Option
+		compose func(*composer.ProjectComposer) (string, error)
+	}{
+		{name: "text", compose: func(c *composer.ProjectComposer) (string, error) { return c.Compose(filePath) }},
+		{name: "json", compose: func(c *composer.ProjectComposer) (string, error) { return c.ComposeJSON(filePath) }},
+		{name: "budget", opts: []composer.Option{composer.WithBudget(2000)}, compose: func(c *composer.ProjectComposer) (string, error) { return c.Compose(filePath) }},
+		{name: "all", compose: (*composer.ProjectComposer).ComposeAll},
+	} {
+		b.Run(bench.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if _, err := bench.compose(composer.New(info, bench.opts...)); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,308 @@
+package composer
+
+import (
+	"fmt"
+	"go/token"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	ourtypes "github.com/vlad/ast2llm-go/internal/types"
+)
+
+// WithPackageTokenBudget limits the part of every package in ComposeAll output to maxTokens tokens, dropping
+// its least referenced types and its functions first. 0, the default, means unbounded
+func WithPackageTokenBudget(maxTokens int) Option {
+	return func(p *ProjectComposer) {
+		p.packageTokenBudget = maxTokens
+	}
+}
+
+// projectPackage groups the files of one package of the project
+type projectPackage struct {
+	dir   string // Directory relative to the project root, with slashes
+	name  string
+	files []string // Absolute paths, sorted
+}
+
+// ComposeAll describes the whole project in a single document: its entry points, then every package, sorted
+// by directory, with its doc comment, files, key types and exported functions. Key types are the exported
+// types of the package, the ones other packages use most first. See WithPackageTokenBudget to bound the
+// part of each package.
+func (p *ProjectComposer) ComposeAll() (string, error) {
+	var tokenizer Tokenizer
+	if p.packageTokenBudget > 0 {
+		var err error
+		if tokenizer, err = p.getTokenizer(); err != nil {
+			return "", err
+		}
+	}
+
+	root := commonDir(p.projectInfo)
+	packages := p.projectPackages(root)
+	references := p.typeReferences()
+
+	var builder strings.Builder
+	builder.WriteString("--- Project ---\n")
+	if p.moduleInfo != nil && p.moduleInfo.Path != "" {
+		builder.WriteString(fmt.Sprintf("Module: %s\n", p.moduleInfo.Path))
+	}
+	builder.WriteString(fmt.Sprintf("Packages: %d\n", len(packages)))
+	if entryPoints := p.entryPoints(root, packages); len(entryPoints) > 0 {
+		builder.WriteString("Entry points:\n")
+		for _, entry := range entryPoints {
+			builder.WriteString("  - " + entry + "\n")
+		}
+	}
+	if database := p.databaseSurface(packages); len(database) > 0 {
+		builder.WriteString("Database surface:\n")
+		for _, line := range database {
+			builder.WriteString("  - " + line + "\n")
+		}
+	}
+	builder.WriteString("\n")
+
+	for _, pkg := range packages {
+		builder.WriteString(p.composePackage(pkg, references, tokenizer))
+		builder.WriteString("\n")
+	}
+	return builder.String(), nil
+}
+
+// composePackage renders a package of ComposeAll output, dropping items from the end to fit the package
+// token budget if tokenizer is set.
+func (p *ProjectComposer) composePackage(pkg *projectPackage, references map[string]int, tokenizer Tokenizer) string {
+	var header strings.Builder
+	header.WriteString(fmt.Sprintf("Package: %s (%s)\n", pkg.name, pkg.dir))
+	var doc string
+	names := make([]string, len(pkg.files))
+	for i, file := range pkg.files {
+		names[i] = filepath.Base(file)
+		if doc == "" {
+			doc = firstParagraph(p.projectInfo[file].PackageDoc)
+		}
+	}
+	if doc != "" && p.showComments() {
+		header.WriteString(fmt.Sprintf("  Doc: %s\n", doc))
+	}
+	header.WriteString(fmt.Sprintf("  Files: %s\n", strings.Join(names, ", ")))
+
+	sections := []*composedSection{
+		{title: "  Key Types", items: p.keyTypes(pkg, references)},
+		{title: "  Functions", items: p.packageFunctions(pkg)},
+	}
+
+	var builder strings.Builder
+	builder.WriteString(header.String())
+	used := 0
+	if tokenizer != nil {
+		used = tokenizer.CountTokens(header.String())
+	}
+	omitted := 0
+	for _, section := range sections {
+		titleShown := false
+		for _, item := range section.items {
+			if tokenizer != nil {
+				cost := tokenizer.CountTokens(item.text)
+				if !titleShown {
+					cost += tokenizer.CountTokens(section.title + ":\n")
+				}
+				if omitted > 0 || used+cost > p.packageTokenBudget {
+					omitted++
+					continue
+				}
+				used += cost
+			}
+			if !titleShown {
+				builder.WriteString(section.title + ":\n")
+				titleShown = true
+			}
+			builder.WriteString(item.text)
+		}
+	}
+	if omitted > 0 {
+		builder.WriteString("  " + omittedMarker(omitted))
+	}
+	return builder.String()
+}
+
+// keyTypes renders the exported structs and interfaces of pkg, the most referenced ones first.
+func (p *ProjectComposer) keyTypes(pkg *projectPackage, references map[string]int) []*composedItem {
+	type keyType struct {
+		name   string
+		format func(*strings.Builder)
+	}
+	var types []keyType
+	for _, file := range pkg.files {
+		fileInfo := p.projectInfo[file]
+		for _, s := range fileInfo.Structs {
+			if token.IsExported(unqualified(s.Name)) {
+				types = append(types, keyType{s.Name, func(b *strings.Builder) { p.FormatStruct(b, s, "    ") }})
+			}
+		}
+		for _, iface := range fileInfo.Interfaces {
+			if token.IsExported(unqualified(iface.Name)) {
+				types = append(types, keyType{iface.Name, func(b *strings.Builder) { p.FormatInterface(b, iface, "    ") }})
+			}
+		}
+	}
+	sort.SliceStable(types, func(i, j int) bool {
+		if references[types[i].name] != references[types[j].name] {
+			return references[types[i].name] > references[types[j].name]
+		}
+		return types[i].name < types[j].name
+	})
+
+	items := make([]*composedItem, len(types))
+	for i, t := range types {
+		items[i] = p.renderItem(t.name, priorityLocal, t.format)
+	}
+	return items
+}
+
+// packageFunctions renders the exported functions of pkg on one line each, with the first paragraph of their
+// comment, sorted by name.
+func (p *ProjectComposer) packageFunctions(pkg *projectPackage) []*composedItem {
+	var functions []*ourtypes.FunctionInfo
+	for _, file := range pkg.files {
+		for _, fn := range p.projectInfo[file].Functions {
+			if token.IsExported(unqualified(fn.Name)) {
+				functions = append(functions, fn)
+			}
+		}
+	}
+	sort.SliceStable(functions, func(i, j int) bool { return functions[i].Name < functions[j].Name })
+
+	items := make([]*composedItem, len(functions))
+	for i, fn := range functions {
+		text := "    - " + summarizeFunction(fn) + "\n"
+		if comment := firstParagraph(fn.Comment); comment != "" && p.showComments() {
+			text += "      " + comment + "\n"
+		}
+		items[i] = p.newItem(fn.Name, text, priorityLocal)
+	}
+	return items
+}
+
+// entryPoints lists the entry points of the project, see ourtypes.EntryPoint, with the directory of their package
+// and their file.
+func (p *ProjectComposer) entryPoints(root string, packages []*projectPackage) []string {
+	var entries []string
+	for _, pkg := range packages {
+		for _, file := range pkg.files {
+			for _, e := range p.projectInfo[file].EntryPoints {
+				entries = append(entries, fmt.Sprintf("%s: %s in %s", pkg.dir, describeEntryPoint(e), relativePath(root, file)))
+			}
+		}
+	}
+	return entries
+}
+
+// databaseSurface lists the migration directories of the project, then the packages holding SQL queries with
+// the number of their queries and the tables they use.
+func (p *ProjectComposer) databaseSurface(packages []*projectPackage) []string {
+	var lines []string
+	for _, m := range p.migrations {
+		lines = append(lines, fmt.Sprintf("Migrations: %s (%s)", m.Dir, describeMigrations(m)))
+	}
+	for _, pkg := range packages {
+		count := 0
+		var tables []string
+		seen := make(map[string]bool)
+		for _, file := range pkg.files {
+			for _, q := range p.projectInfo[file].SQLQueries {
+				count++
+				for _, table := range q.Tables {
+					if !seen[table] {
+						seen[table] = true
+						tables = append(tables, table)
+					}
+				}
+			}
+		}
+		if count == 0 {
+			continue
+		}
+		line := fmt.Sprintf("%s: %d SQL queries", pkg.dir, count)
+		if count == 1 {
+			line = fmt.Sprintf("%s: 1 SQL query", pkg.dir)
+		}
+		if len(tables) > 0 {
+			sort.Strings(tables)
+			line += " on " + strings.Join(tables, ", ")
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// typeReferences counts the files using every project type from another package.
+func (p *ProjectComposer) typeReferences() map[string]int {
+	references := make(map[string]int)
+	for _, fileInfo := range p.projectInfo {
+		for _, s := range fileInfo.UsedImportedStructs {
+			references[s.Name]++
+		}
+		for _, iface := range fileInfo.UsedImportedInterfaces {
+			references[iface.Name]++
+		}
+	}
+	return references
+}
+
+// projectPackages groups the files of the project by directory and package name, sorted by directory.
+func (p *ProjectComposer) projectPackages(root string) []*projectPackage {
+	byKey := make(map[string]*projectPackage)
+	for file, fileInfo := range p.projectInfo {
+		dir := relativePath(root, filepath.Dir(file))
+		key := dir + "\x00" + fileInfo.PackageName
+		pkg, ok := byKey[key]
+		if !ok {
+			pkg = &projectPackage{dir: dir, name: fileInfo.PackageName}
+			byKey[key] = pkg
+		}
+		pkg.files = append(pkg.files, file)
+	}
+
+	packages := make([]*projectPackage, 0, len(byKey))
+	for _, pkg := range byKey {
+		sort.Strings(pkg.files)
+		packages = append(packages, pkg)
+	}
+	sort.Slice(packages, func(i, j int) bool {
+		if packages[i].dir != packages[j].dir {
+			return packages[i].dir < packages[j].dir
+		}
+		return packages[i].name < packages[j].name
+	})
+	return packages
+}
+
+// commonDir returns the deepest directory containing every file of projectInfo.
+func commonDir(projectInfo map[string]*ourtypes.FileInfo) string {
+	common := ""
+	first := true
+	for file := range projectInfo {
+		dir := filepath.Dir(file)
+		if first {
+			common, first = dir, false
+			continue
+		}
+		for common != dir && !strings.HasPrefix(dir, common+string(filepath.Separator)) {
+			parent := filepath.Dir(common)
+			if parent == common {
+				break
+			}
+			common = parent
+		}
+	}
+	return common
+}
+
+// relativePath returns path relative to root with slashes, "." for root itself.
+func relativePath(root, path string) string {
+	if rel, err := filepath.Rel(root, path); err == nil {
+		return filepath.ToSlash(rel)
+	}
+	return filepath.ToSlash(path)
+}
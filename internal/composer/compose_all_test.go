@@ -0,0 +1,94 @@
+package composer_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vlad/ast2llm-go/internal/composer"
+	"github.com/vlad/ast2llm-go/internal/modinfo"
+	"github.com/vlad/ast2llm-go/internal/parser"
+	"github.com/vlad/ast2llm-go/internal/types"
+)
+
+func newComposeAllProjectInfo() parser.ProjectInfo {
+	return parser.ProjectInfo{
+		"/app/cmd/server/main.go": {
+			PackageName: "main",
+			Functions:   []*types.FunctionInfo{{Name: "main"}},
+			EntryPoints: []*types.EntryPoint{{Kind: types.EntryMain, Name: "main"}},
+			UsedImportedStructs: []*types.StructInfo{
+				{Name: "example.com/app/store.Memory"},
+			},
+		},
+		"/app/store/store.go": {
+			PackageName: "store",
+			PackageDoc:  "Package store persists\nusers.\n\nMore details.",
+			Structs: []*types.StructInfo{
+				{Name: "example.com/app/store.Config", Fields: []*types.StructField{{Name: "Size", Type: "int"}}},
+				{Name: "example.com/app/store.entry"},
+			},
+			Interfaces: []*types.InterfaceInfo{{Name: "example.com/app/store.Store"}},
+			Functions: []*types.FunctionInfo{
+				{Name: "NewMemory", Comment: "NewMemory returns an empty store.", Params: []string{"cfg Config"}, Returns: []string{"*Memory"}},
+				{Name: "helper"},
+			},
+		},
+		"/app/store/memory.go": {
+			PackageName: "store",
+			Structs: []*types.StructInfo{
+				{Name: "example.com/app/store.Memory", Comment: "Memory keeps users in memory."},
+			},
+		},
+	}
+}
+
+func TestProjectComposer_ComposeAll(t *testing.T) {
+	c := composer.New(newComposeAllProjectInfo(), composer.WithModuleInfo(&modinfo.ModuleInfo{Path: "example.com/app"}))
+
+	output, err := c.ComposeAll()
+	require.NoError(t, err)
+	assert.Equal(t, `--- Project ---
+Module: example.com/app
+Packages: 2
+Entry points:
+  - cmd/server: Main: func main in cmd/server/main.go
+
+Package: main (cmd/server)
+  Files: main.go
+
+Package: store (store)
+  Doc: Package store persists users.
+  Files: memory.go, store.go
+  Key Types:
+    Struct: example.com/app/store.Memory
+      Comment: Memory keeps users in memory.
+    Struct: example.com/app/store.Config
+      Fields:
+        - Size int
+    Interface: example.com/app/store.Store
+  Functions:
+    - func NewMemory(cfg Config) -> (*Memory)
+      NewMemory returns an empty store.
+
+`, output)
+}
+
+func TestProjectComposer_ComposeAll_PackageTokenBudget(t *testing.T) {
+	c := composer.New(newComposeAllProjectInfo(), composer.WithTokenizer(lineTokenizer{}), composer.WithPackageTokenBudget(9))
+
+	output, err := c.ComposeAll()
+	require.NoError(t, err)
+	assert.Contains(t, output, `Package: store (store)
+  Doc: Package store persists users.
+  Files: memory.go, store.go
+  Key Types:
+    Struct: example.com/app/store.Memory
+      Comment: Memory keeps users in memory.
+    Struct: example.com/app/store.Config
+      Fields:
+        - Size int
+  ...2 items omitted
+`, "the least referenced types and the functions are dropped first")
+	assert.Contains(t, output, "Package: main (cmd/server)\n  Files: main.go\n\n")
+}
@@ -0,0 +1,170 @@
+package composer
+
+import (
+	"encoding/json"
+	"fmt"
+
+	ourtypes "github.com/vlad/ast2llm-go/internal/types"
+)
+
+// ComposedFileSchemaVersion is incremented whenever ComposedFile changes incompatibly. It follows the version of
+// the serialization of the items it holds: version 1 named their fields like the Go fields, version 2 uses
+// snake_case and leaves out empty optional fields, version 3 describes imports as objects with their alias.
+// The sections of ComposedFile are always included.
+const ComposedFileSchemaVersion = ourtypes.SchemaVersion
+
+// ComposedFile is the structured counterpart of Compose output returned by ComposeJSON.
+//
+// Items used from other packages are resolved against the project: detailed definitions end up in
+// UsedStructs, UsedInterfaces and UsedFunctions, as do external types if the project was parsed with
+// parser.WithExternalTypes. Names without a known definition (e.g. standard library types) are listed in Unresolved.
+type ComposedFile struct {
+	SchemaVersion  int                       `json:"schema_version"`
+	FilePath       string                    `json:"file_path"`
+	Package        string                    `json:"package"`
+	PackageDoc     string                    `json:"package_doc"`
+	ContentHash    string                    `json:"content_hash"` // Hex SHA-256 of the parsed content of the file
+	ModTime        string                    `json:"mod_time"`     // Modification time of the file when parsed, RFC 3339, empty if unknown
+	Generated      bool                      `json:"generated"`
+	HasCgo         bool                      `json:"has_cgo"`
+	HasAsm         bool                      `json:"has_asm"`
+	Diagnostics    []*ourtypes.Diagnostic    `json:"diagnostics"`
+	Imports        []*ourtypes.ImportInfo    `json:"imports"`
+	Dependencies   []*ComposedDependency     `json:"dependencies"`
+	EntryPoints    []*ourtypes.EntryPoint    `json:"entry_points"`
+	Directives     []*ourtypes.Directive     `json:"directives"`
+	Functions      []*ourtypes.FunctionInfo  `json:"functions"`
+	Methods        []*ourtypes.FunctionInfo  `json:"methods"`
+	GlobalVars     []*ourtypes.GlobalVarInfo `json:"global_vars"`
+	ConstGroups    []*ourtypes.ConstGroup    `json:"const_groups"`
+	Structs        []*ourtypes.StructInfo    `json:"structs"`
+	Interfaces     []*ourtypes.InterfaceInfo `json:"interfaces"`
+	PackageErrors  []*ourtypes.ErrorInfo     `json:"package_errors"`
+	SQLQueries     []*ourtypes.SQLQuery      `json:"sql_queries"`
+	Migrations     []*ourtypes.MigrationSet  `json:"migrations"` // Migration directories of the project, listed for files holding SQL queries
+	InlineTypes    []*ourtypes.InlineType    `json:"inline_types"`
+	CustomItems    []*ourtypes.CustomItem    `json:"custom_items"`
+	UsedStructs    []*ourtypes.StructInfo    `json:"used_structs"`
+	UsedInterfaces []*ourtypes.InterfaceInfo `json:"used_interfaces"`
+	UsedFunctions  []*ourtypes.FunctionInfo  `json:"used_functions"`
+	UsedGlobalVars []*ourtypes.GlobalVarInfo `json:"used_global_vars"`
+	Unresolved     []string                  `json:"unresolved"`
+}
+
+// ComposedDependency is a third-party module imported by the composed file
+type ComposedDependency struct {
+	Path        string `json:"path"`                  // Module path
+	Version     string `json:"version"`               // Required version
+	Replacement string `json:"replacement,omitempty"` // Replacement path and version from a replace directive
+}
+
+// ComposeFile builds the structured context of a given file path.
+func (p *ProjectComposer) ComposeFile(filePath string) (*ComposedFile, error) {
+	filePath, fileInfo, ok := p.lookupFile(filePath)
+	if !ok {
+		return nil, fmt.Errorf("file info not found for path: %s", filePath)
+	}
+	fileInfo = canonicalFileInfo(p.withoutExcludedSections(fileInfo))
+
+	composed := &ComposedFile{
+		SchemaVersion:  p.schemaVersion,
+		FilePath:       filePath,
+		Package:        fileInfo.PackageName,
+		PackageDoc:     fileInfo.PackageDoc,
+		ContentHash:    fileInfo.ContentHash,
+		ModTime:        fileInfo.ModTime,
+		Generated:      fileInfo.Generated,
+		HasCgo:         fileInfo.HasCgo,
+		HasAsm:         fileInfo.HasAsm,
+		Diagnostics:    nonNil(fileInfo.Diagnostics),
+		Imports:        nonNil(fileInfo.Imports),
+		Dependencies:   nonNil(p.fileDependencies(fileInfo)),
+		EntryPoints:    nonNil(fileInfo.EntryPoints),
+		Directives:     nonNil(fileInfo.Directives),
+		Functions:      nonNil(fileInfo.Functions),
+		Methods:        nonNil(fileInfo.Methods),
+		GlobalVars:     nonNil(fileInfo.GlobalVars),
+		ConstGroups:    nonNil(fileInfo.ConstGroups),
+		Structs:        nonNil(fileInfo.Structs),
+		Interfaces:     nonNil(fileInfo.Interfaces),
+		PackageErrors:  nonNil(p.packageErrorValues(filePath)),
+		SQLQueries:     nonNil(fileInfo.SQLQueries),
+		Migrations:     make([]*ourtypes.MigrationSet, 0),
+		InlineTypes:    nonNil(fileInfo.InlineTypes),
+		CustomItems:    nonNil(fileInfo.CustomItems),
+		UsedStructs:    make([]*ourtypes.StructInfo, 0),
+		UsedInterfaces: make([]*ourtypes.InterfaceInfo, 0),
+		UsedFunctions:  make([]*ourtypes.FunctionInfo, 0),
+		UsedGlobalVars: make([]*ourtypes.GlobalVarInfo, 0),
+		Unresolved:     make([]string, 0),
+	}
+
+	if len(fileInfo.SQLQueries) > 0 {
+		composed.Migrations = nonNil(p.migrations)
+	}
+
+	projectStructsMap, projectInterfacesMap, projectFunctionsMap := p.projectIndex()
+	externalStructsMap, externalInterfacesMap := externalIndex(fileInfo)
+
+	for _, s := range fileInfo.UsedImportedStructs {
+		if detailedStruct, ok := projectStructsMap[s.Name]; ok {
+			detailedStruct, _ = withUsedFields(detailedStruct, s.UsedFields)
+			composed.UsedStructs = append(composed.UsedStructs, detailedStruct)
+		} else if detailedIface, ok := projectInterfacesMap[s.Name]; ok {
+			composed.UsedInterfaces = append(composed.UsedInterfaces, detailedIface)
+		} else if detailedFunc, ok := projectFunctionsMap[s.Name]; ok {
+			composed.UsedFunctions = append(composed.UsedFunctions, detailedFunc)
+		} else if externalStruct, ok := externalStructsMap[s.Name]; ok {
+			externalStruct, _ = withUsedFields(externalStruct, s.UsedFields)
+			composed.UsedStructs = append(composed.UsedStructs, externalStruct)
+		} else if externalIface, ok := externalInterfacesMap[s.Name]; ok {
+			composed.UsedInterfaces = append(composed.UsedInterfaces, externalIface)
+		} else {
+			composed.Unresolved = append(composed.Unresolved, s.Name)
+		}
+	}
+	for _, i := range fileInfo.UsedImportedInterfaces {
+		if detailedIface, ok := projectInterfacesMap[i.Name]; ok {
+			composed.UsedInterfaces = append(composed.UsedInterfaces, detailedIface)
+		} else if externalIface, ok := externalInterfacesMap[i.Name]; ok {
+			composed.UsedInterfaces = append(composed.UsedInterfaces, externalIface)
+		} else {
+			composed.Unresolved = append(composed.Unresolved, i.Name)
+		}
+	}
+	for _, f := range fileInfo.UsedImportedFunctions {
+		composed.UsedFunctions = append(composed.UsedFunctions, f)
+	}
+	for _, gv := range fileInfo.UsedImportedGlobalVars {
+		composed.UsedGlobalVars = append(composed.UsedGlobalVars, gv)
+	}
+
+	return composed, nil
+}
+
+// ComposeJSON returns the structured context of a given file path as indented JSON, in the schema version
+// selected with WithSchemaVersion.
+func (p *ProjectComposer) ComposeJSON(filePath string) (string, error) {
+	composed, err := p.ComposeFile(filePath)
+	if err != nil {
+		return "", err
+	}
+
+	versioned, err := ourtypes.ForSchemaVersion(composed, p.schemaVersion)
+	if err != nil {
+		return "", err
+	}
+	data, err := json.MarshalIndent(versioned, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal composed file: %w", err)
+	}
+	return string(data), nil
+}
+
+// nonNil returns an empty slice instead of nil so that JSON output contains [] rather than null.
+func nonNil[T any](items []T) []T {
+	if items == nil {
+		return make([]T, 0)
+	}
+	return items
+}
@@ -0,0 +1,116 @@
+package composer_test
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vlad/ast2llm-go/internal/composer"
+	"github.com/vlad/ast2llm-go/internal/parser"
+	"github.com/vlad/ast2llm-go/internal/types"
+)
+
+func TestProjectComposer_ComposeJSON(t *testing.T) {
+	filePath := "/project/main.go"
+	projectInfo := parser.ProjectInfo{
+		"/project/other.go": {
+			PackageName: "other",
+			Structs: []*types.StructInfo{
+				{Name: "example.com/project/other.Data", Fields: []*types.StructField{{Name: "ID", Type: "int"}}},
+			},
+		},
+		filePath: {
+			PackageName: "main",
+			ContentHash: "0af3",
+			ModTime:     "2024-05-01T10:00:00Z",
+			Imports:     []*types.ImportInfo{{Path: "example.com/project/other"}, {Path: "context"}},
+			UsedImportedStructs: []*types.StructInfo{
+				{Name: "example.com/project/other.Data"},
+				{Name: "context.Context"},
+			},
+			UsedImportedFunctions: []*types.FunctionInfo{
+				{Name: "example.com/project/other.Load", Returns: []string{"error"}},
+			},
+		},
+	}
+
+	output, err := composer.New(projectInfo).ComposeJSON(filePath)
+	require.NoError(t, err)
+
+	var composed composer.ComposedFile
+	require.NoError(t, json.Unmarshal([]byte(output), &composed))
+
+	assert.Equal(t, composer.ComposedFileSchemaVersion, composed.SchemaVersion)
+	assert.Equal(t, filePath, composed.FilePath)
+	assert.Equal(t, "main", composed.Package)
+	assert.Equal(t, "0af3", composed.ContentHash)
+	assert.Equal(t, "2024-05-01T10:00:00Z", composed.ModTime)
+	assert.Equal(t, []*types.ImportInfo{{Path: "example.com/project/other"}, {Path: "context"}}, composed.Imports)
+	require.Len(t, composed.UsedStructs, 1)
+	assert.Equal(t, "example.com/project/other.Data", composed.UsedStructs[0].Name)
+	assert.Len(t, composed.UsedStructs[0].Fields, 1)
+	require.Len(t, composed.UsedFunctions, 1)
+	assert.Equal(t, "example.com/project/other.Load", composed.UsedFunctions[0].Name)
+	assert.Equal(t, []string{"context.Context"}, composed.Unresolved)
+
+	// Empty sections are serialized as empty arrays
+	assert.Contains(t, output, `"functions": []`)
+	assert.Contains(t, output, `"diagnostics": []`)
+}
+
+func TestProjectComposer_ComposeJSON_SchemaVersion(t *testing.T) {
+	filePath := "/project/main.go"
+	projectInfo := parser.ProjectInfo{
+		filePath: {
+			PackageName: "main",
+			Imports:     []*types.ImportInfo{{Path: "context", Alias: "myctx"}},
+			Functions:   []*types.FunctionInfo{{Name: "Run", Returns: []string{"error"}}},
+		},
+	}
+
+	output, err := composer.New(projectInfo).ComposeJSON(filePath)
+	require.NoError(t, err)
+	assert.Contains(t, output, `"schema_version": 3`)
+	assert.Contains(t, output, `"alias": "myctx"`)
+	assert.Contains(t, output, `"returns": [`)
+	assert.NotContains(t, output, `"comment"`, "empty optional fields are left out")
+
+	output, err = composer.New(projectInfo, composer.WithSchemaVersion(2)).ComposeJSON(filePath)
+	require.NoError(t, err)
+	assert.Contains(t, output, `"schema_version": 2`)
+	assert.Contains(t, output, "\"imports\": [\n    \"context\"\n  ]", "imports are listed as paths")
+	assert.Contains(t, output, `"returns": [`)
+	assert.NotContains(t, output, `"comment"`)
+
+	output, err = composer.New(projectInfo, composer.WithSchemaVersion(1)).ComposeJSON(filePath)
+	require.NoError(t, err)
+	assert.Contains(t, output, `"schema_version": 1`)
+	assert.Contains(t, output, `"Returns": [`)
+	assert.Contains(t, output, `"Comment": ""`)
+	assert.Contains(t, output, `"functions": [`, "sections keep their names")
+
+	_, err = composer.New(projectInfo, composer.WithSchemaVersion(7)).ComposeJSON(filePath)
+	assert.EqualError(t, err, "unsupported schema version 7, supported versions are 1 to 3")
+}
+
+func TestProjectComposer_ComposeJSON_FileNotFound(t *testing.T) {
+	_, err := composer.New(parser.ProjectInfo{}).ComposeJSON("/missing.go")
+	assert.EqualError(t, err, "file info not found for path: /missing.go")
+}
+
+func TestProjectComposer_Compose_UncleanPath(t *testing.T) {
+	projectInfo := parser.ProjectInfo{
+		filepath.Join("/project", "cmd", "main.go"): {PackageName: "main"},
+	}
+	c := composer.New(projectInfo)
+
+	output, err := c.Compose(filepath.Join("/project", "cmd") + "/../cmd/./main.go")
+	require.NoError(t, err)
+	assert.Contains(t, output, "--- File: "+filepath.Join("/project", "cmd", "main.go")+" ---", "the path of the project info is shown")
+
+	composed, err := c.ComposeFile(filepath.Join("/project", "cmd") + "/../cmd/main.go")
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join("/project", "cmd", "main.go"), composed.FilePath)
+}
@@ -0,0 +1,82 @@
+package composer
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	ourtypes "github.com/vlad/ast2llm-go/internal/types"
+)
+
+// WithDeadExports lists the exports of the composed file nothing in the project refers to, as found by
+// parser.FindDeadExports, in an Unused Exports section after the errors of the file
+func WithDeadExports(exports []*ourtypes.DeadExport) Option {
+	return func(p *ProjectComposer) {
+		p.deadExports = make(map[string]*ourtypes.DeadExport, len(exports))
+		for _, e := range exports {
+			if e.Pos != nil {
+				p.deadExports[e.Pos.String()] = e
+			}
+		}
+	}
+}
+
+// WithDropDeadExports leaves the declarations of the exports set with WithDeadExports out of Compose output,
+// so that context is spent on code that is in use. They are still listed in the Unused Exports section
+func WithDropDeadExports() Option {
+	return func(p *ProjectComposer) {
+		p.dropDeadExports = true
+	}
+}
+
+// fileDeadExports returns the unused exports declared in filePath, in source order.
+func (p *ProjectComposer) fileDeadExports(filePath string) []*ourtypes.DeadExport {
+	var exports []*ourtypes.DeadExport
+	for _, e := range p.deadExports {
+		if e.Pos.File == filePath {
+			exports = append(exports, e)
+		}
+	}
+	sort.Slice(exports, func(i, j int) bool {
+		a, b := exports[i].Pos, exports[j].Pos
+		if a.Line != b.Line {
+			return a.Line < b.Line
+		}
+		return a.Column < b.Column
+	})
+	return exports
+}
+
+// formatDeadExport renders an unused export of the file as "- line: kind name", e.g. "- 12: func Reset".
+func formatDeadExport(e *ourtypes.DeadExport) string {
+	name := e.Name
+	if i := strings.LastIndex(name, "."); i >= 0 {
+		name = name[i+1:]
+	}
+	return fmt.Sprintf("- %d: %s %s\n", e.Pos.Line, e.Kind, name)
+}
+
+// withoutDeadExports returns a copy of fileInfo without the functions, variables, constants, structs and
+// interfaces that are unused exports.
+func (p *ProjectComposer) withoutDeadExports(fileInfo *ourtypes.FileInfo) *ourtypes.FileInfo {
+	alive := func(pos *ourtypes.Position) bool {
+		return pos == nil || p.deadExports[pos.String()] == nil
+	}
+	c := *fileInfo
+	c.Functions = filterItems(fileInfo.Functions, func(fn *ourtypes.FunctionInfo) bool { return alive(fn.Pos) })
+	c.GlobalVars = filterItems(fileInfo.GlobalVars, func(gv *ourtypes.GlobalVarInfo) bool { return alive(gv.Pos) })
+	c.Structs = filterItems(fileInfo.Structs, func(s *ourtypes.StructInfo) bool { return alive(s.Pos) })
+	c.Interfaces = filterItems(fileInfo.Interfaces, func(i *ourtypes.InterfaceInfo) bool { return alive(i.Pos) })
+	return &c
+}
+
+// filterItems returns the items keep reports true for.
+func filterItems[T any](items []T, keep func(T) bool) []T {
+	var result []T
+	for _, item := range items {
+		if keep(item) {
+			result = append(result, item)
+		}
+	}
+	return result
+}
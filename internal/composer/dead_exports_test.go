@@ -0,0 +1,47 @@
+package composer_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vlad/ast2llm-go/internal/composer"
+	"github.com/vlad/ast2llm-go/internal/types"
+)
+
+func TestProjectComposer_Compose_DeadExports(t *testing.T) {
+	resetPos := &types.Position{File: "/project/store.go", Line: 12, Column: 6}
+	unusedPos := &types.Position{File: "/project/store.go", Line: 3, Column: 6}
+	projectInfo := map[string]*types.FileInfo{
+		"/project/store.go": {
+			PackageName: "store",
+			Functions: []*types.FunctionInfo{
+				{Name: "New", Pos: &types.Position{File: "/project/store.go", Line: 8, Column: 6}},
+				{Name: "Reset", Pos: resetPos},
+			},
+			Structs: []*types.StructInfo{{Name: "example.com/app/store.Unused", Pos: unusedPos}},
+		},
+	}
+	dead := []*types.DeadExport{
+		{Name: "example.com/app/store.Reset", Kind: "func", Pos: resetPos},
+		{Name: "example.com/app/store.Unused", Kind: "type", Pos: unusedPos},
+		{Name: "example.com/app/other.Old", Kind: "var", Pos: &types.Position{File: "/project/other.go", Line: 1, Column: 5}},
+	}
+
+	output, err := composer.New(projectInfo, composer.WithDeadExports(dead)).Compose("/project/store.go")
+	require.NoError(t, err)
+	assert.Contains(t, output, "Package: store\n\nUnused Exports:\n- 3: type Unused\n- 12: func Reset\n\n")
+	assert.Contains(t, output, "Function: Reset")
+	assert.Contains(t, output, "Struct: example.com/app/store.Unused")
+
+	output, err = composer.New(projectInfo, composer.WithDeadExports(dead), composer.WithDropDeadExports()).Compose("/project/store.go")
+	require.NoError(t, err)
+	assert.Contains(t, output, "Unused Exports:\n- 3: type Unused\n- 12: func Reset\n")
+	assert.Contains(t, output, "Function: New")
+	assert.NotContains(t, output, "Function: Reset")
+	assert.NotContains(t, output, "Struct:")
+
+	output, err = composer.New(projectInfo).Compose("/project/store.go")
+	require.NoError(t, err)
+	assert.NotContains(t, output, "Unused Exports")
+}
@@ -0,0 +1,43 @@
+package composer
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/vlad/ast2llm-go/internal/parser"
+)
+
+// ForDiff composes the context a reviewer of unifiedDiff needs: the declarations the diff touches, the
+// project types they reference and the functions calling them, one file at a time. The output starts with
+// the list of changed symbols; a diff touching no Go declaration yields only that list, empty.
+func ForDiff(p parser.Parser, projectPath, unifiedDiff string, opts ...Option) (string, error) {
+	fragments, changed, err := p.ExtractDiffContext(projectPath, unifiedDiff)
+	if err != nil {
+		return "", fmt.Errorf("failed to extract diff context: %w", err)
+	}
+
+	var builder strings.Builder
+	builder.WriteString("--- Changed Symbols ---\n")
+	for _, name := range changed {
+		builder.WriteString(fmt.Sprintf("- %s\n", name))
+	}
+	builder.WriteString("\n")
+
+	paths := make([]string, 0, len(fragments))
+	for path := range fragments {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	c := New(fragments, opts...)
+	for _, path := range paths {
+		text, err := c.Compose(path)
+		if err != nil {
+			return "", err
+		}
+		builder.WriteString(text)
+		builder.WriteString("\n")
+	}
+	return builder.String(), nil
+}
@@ -0,0 +1,62 @@
+package composer_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vlad/ast2llm-go/internal/composer"
+	"github.com/vlad/ast2llm-go/internal/parser"
+)
+
+func TestForDiff(t *testing.T) {
+	root := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(root, "go.mod"), []byte("module example.com/testproject\n\ngo 1.22\n"), 0644))
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "store"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "store", "store.go"), []byte(`package store
+
+// Item is a stored item.
+type Item struct {
+	Key string
+}
+
+// Get returns the item stored under key.
+func Get(key string) *Item {
+	return &Item{Key: key}
+}
+
+// Unchanged is not part of the diff.
+func Unchanged() {}
+`), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "main.go"), []byte(`package main
+
+import "example.com/testproject/store"
+
+func main() {
+	store.Get("a")
+}
+`), 0644))
+
+	diff := `--- a/store/store.go
++++ b/store/store.go
+@@ -9,3 +9,3 @@ type Item struct {
+ func Get(key string) *Item {
+-	return &Item{Key: "x"}
++	return &Item{Key: key}
+ }
+`
+	output, err := composer.ForDiff(parser.New(), root, diff)
+	require.NoError(t, err)
+
+	assert.Contains(t, output, "--- Changed Symbols ---\n- store.Get\n\n")
+	assert.Contains(t, output, "--- File: "+filepath.Join(root, "store", "store.go")+" ---")
+	assert.Contains(t, output, "Function: Get")
+	assert.Contains(t, output, "Struct: example.com/testproject/store.Item")
+	assert.Contains(t, output, "--- File: "+filepath.Join(root, "main.go")+" ---")
+	assert.NotContains(t, output, "Unchanged")
+
+	_, err = composer.ForDiff(parser.New(), root, "+++ b/main.go\n@@ bad @@\n")
+	assert.ErrorContains(t, err, "failed to extract diff context")
+}
@@ -0,0 +1,28 @@
+package composer
+
+import (
+	"fmt"
+	"strings"
+
+	ourtypes "github.com/vlad/ast2llm-go/internal/types"
+)
+
+// FormatConstGroup formats a ConstGroup into the StringBuilder as an enum listing its constants in declaration order.
+func (p *ProjectComposer) FormatConstGroup(builder *strings.Builder, g *ourtypes.ConstGroup, indent string) {
+	builder.WriteString(fmt.Sprintf("%sEnum: %s", indent, g.Type))
+	if g.Underlying != "" {
+		builder.WriteString(fmt.Sprintf(" (%s)", g.Underlying))
+	}
+	builder.WriteString("\n")
+	p.formatPosition(builder, g.Pos, indent)
+	if g.Comment != "" && p.showComments() {
+		builder.WriteString(fmt.Sprintf("%s  Comment: %s\n", indent, g.Comment))
+	}
+
+	for _, c := range g.Constants {
+		builder.WriteString(fmt.Sprintf("%s  - %s = %s\n", indent, c.Name, c.Value))
+		if c.Comment != "" && p.showComments() {
+			builder.WriteString(fmt.Sprintf("%s    Comment: %s\n", indent, c.Comment))
+		}
+	}
+}
@@ -0,0 +1,56 @@
+package composer_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vlad/ast2llm-go/internal/composer"
+	"github.com/vlad/ast2llm-go/internal/parser"
+	"github.com/vlad/ast2llm-go/internal/types"
+)
+
+func TestProjectComposer_FormatConstGroup(t *testing.T) {
+	group := &types.ConstGroup{
+		Type:       "example.com/project.Color",
+		Underlying: "int",
+		Comment:    "Supported colors.",
+		Constants: []*types.GlobalVarInfo{
+			{Name: "Red", Value: "0", IsConst: true, Comment: "Red is the default."},
+			{Name: "Green", Value: "1", IsConst: true},
+		},
+	}
+
+	var builder strings.Builder
+	composer.New(nil).FormatConstGroup(&builder, group, "  ")
+	assert.Equal(t, "  Enum: example.com/project.Color (int)\n"+
+		"    Comment: Supported colors.\n"+
+		"    - Red = 0\n"+
+		"      Comment: Red is the default.\n"+
+		"    - Green = 1\n", builder.String())
+
+	t.Run("signatures only", func(t *testing.T) {
+		var builder strings.Builder
+		composer.New(nil, composer.WithVerbosity(composer.VerbositySignatures)).FormatConstGroup(&builder, group, "")
+		assert.Equal(t, "Enum: example.com/project.Color (int)\n  - Red = 0\n  - Green = 1\n", builder.String())
+	})
+
+	t.Run("compose", func(t *testing.T) {
+		filePath := "/project/main.go"
+		projectInfo := parser.ProjectInfo{
+			filePath: {
+				PackageName: "main",
+				GlobalVars:  []*types.GlobalVarInfo{{Name: "Name", Type: "string", Value: `"app"`, IsConst: true}},
+				ConstGroups: []*types.ConstGroup{group},
+			},
+		}
+		output, err := composer.New(projectInfo).Compose(filePath)
+		require.NoError(t, err)
+		assert.Contains(t, output, "Global Variables/Constants:\n  Const: Name string = \"app\"\n\nEnums:\n  Enum: example.com/project.Color (int)\n")
+
+		composed, err := composer.New(projectInfo).ComposeFile(filePath)
+		require.NoError(t, err)
+		assert.Equal(t, []*types.ConstGroup{group}, composed.ConstGroups)
+	})
+}
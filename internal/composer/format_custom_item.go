@@ -0,0 +1,17 @@
+package composer
+
+import (
+	"fmt"
+	"strings"
+
+	ourtypes "github.com/vlad/ast2llm-go/internal/types"
+)
+
+// FormatCustomItem formats an item found by a custom extractor into the StringBuilder, followed by its details.
+func (p *ProjectComposer) FormatCustomItem(builder *strings.Builder, item *ourtypes.CustomItem, indent string) {
+	builder.WriteString(fmt.Sprintf("%s- %s\n", indent, item.Name))
+	p.formatPosition(builder, item.Pos, indent)
+	for _, detail := range item.Details {
+		builder.WriteString(fmt.Sprintf("%s  %s\n", indent, detail))
+	}
+}
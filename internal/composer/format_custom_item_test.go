@@ -0,0 +1,43 @@
+package composer_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vlad/ast2llm-go/internal/composer"
+	"github.com/vlad/ast2llm-go/internal/types"
+)
+
+func TestProjectComposer_Format_CustomItems(t *testing.T) {
+	projectInfo := map[string]*types.FileInfo{
+		"/project/main.go": {
+			PackageName: "main",
+			CustomItems: []*types.CustomItem{
+				{Section: "Wire Providers", Name: "NewStore", Details: []string{"provides *Store"}, Pos: &types.Position{File: "/project/main.go", Line: 12, Column: 1}},
+				{Section: "gRPC Services", Name: "UserService", Details: []string{"registered with RegisterUserServiceServer"}},
+				{Section: "Wire Providers", Name: "NewServer", Details: []string{"provides *Server", "needs *Store"}},
+				{Name: "Untitled"},
+			},
+		},
+	}
+
+	output, err := composer.New(projectInfo).Compose("/project/main.go")
+	require.NoError(t, err)
+	assert.Contains(t, output, `Wire Providers:
+  - NewStore
+    provides *Store
+  - NewServer
+    provides *Server
+    needs *Store
+gRPC Services:
+  - UserService
+    registered with RegisterUserServiceServer
+Custom Items:
+  - Untitled
+`)
+
+	output, err = composer.New(projectInfo, composer.WithPositions()).Compose("/project/main.go")
+	require.NoError(t, err)
+	assert.Contains(t, output, "  - NewStore\n    Position: /project/main.go:12:1\n    provides *Store\n")
+}
@@ -0,0 +1,22 @@
+package composer
+
+import (
+	"fmt"
+	"strings"
+
+	ourtypes "github.com/vlad/ast2llm-go/internal/types"
+)
+
+// FormatDirective formats a directive comment into the StringBuilder as written, followed by the declaration
+// it belongs to.
+func (p *ProjectComposer) FormatDirective(builder *strings.Builder, d *ourtypes.Directive, indent string) {
+	builder.WriteString(fmt.Sprintf("%s- //%s", indent, d.Name))
+	if d.Args != "" {
+		builder.WriteString(" " + d.Args)
+	}
+	if d.Target != "" {
+		builder.WriteString(fmt.Sprintf(" (on %s)", d.Target))
+	}
+	builder.WriteString("\n")
+	p.formatPosition(builder, d.Pos, indent)
+}
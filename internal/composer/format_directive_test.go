@@ -0,0 +1,40 @@
+package composer_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vlad/ast2llm-go/internal/composer"
+	"github.com/vlad/ast2llm-go/internal/types"
+)
+
+func TestProjectComposer_Format_Directives(t *testing.T) {
+	projectInfo := map[string]*types.FileInfo{
+		"/project/color.go": {
+			PackageName: "colors",
+			Imports:     []*types.ImportInfo{{Path: "fmt"}},
+			Directives: []*types.Directive{
+				{Name: "go:build", Args: "linux"},
+				{Name: "go:generate", Args: "stringer -type=Color", Target: "type Color", Pos: &types.Position{File: "/project/color.go", Line: 7, Column: 1}},
+				{Name: "nolint:errcheck", Target: "func Print"},
+			},
+		},
+	}
+
+	output, err := composer.New(projectInfo).Compose("/project/color.go")
+	require.NoError(t, err)
+	assert.Contains(t, output, `Imports:
+- fmt
+
+Directives:
+- //go:build linux
+- //go:generate stringer -type=Color (on type Color)
+- //nolint:errcheck (on func Print)
+
+`)
+
+	output, err = composer.New(projectInfo, composer.WithPositions()).Compose("/project/color.go")
+	require.NoError(t, err)
+	assert.Contains(t, output, "- //go:generate stringer -type=Color (on type Color)\n  Position: /project/color.go:7:1\n")
+}
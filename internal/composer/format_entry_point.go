@@ -0,0 +1,47 @@
+package composer
+
+import (
+	"fmt"
+	"strings"
+
+	ourtypes "github.com/vlad/ast2llm-go/internal/types"
+)
+
+// entryPointLabels are the labels of the kinds of entry points
+var entryPointLabels = map[string]string{
+	ourtypes.EntryMain: "Main",
+	ourtypes.EntryHTTP: "HTTP",
+	ourtypes.EntryCLI:  "Command",
+	ourtypes.EntryGRPC: "gRPC service",
+}
+
+// FormatEntryPoint formats an entry point into the StringBuilder as its kind, name and handler, e.g.
+// "HTTP: GET /users -> listUsers".
+func (p *ProjectComposer) FormatEntryPoint(builder *strings.Builder, e *ourtypes.EntryPoint, indent string) {
+	builder.WriteString(indent + describeEntryPoint(e) + "\n")
+	p.formatPosition(builder, e.Pos, indent)
+}
+
+// describeEntryPoint describes an entry point on one line.
+func describeEntryPoint(e *ourtypes.EntryPoint) string {
+	label, ok := entryPointLabels[e.Kind]
+	if !ok {
+		label = e.Kind
+	}
+	if e.Kind == ourtypes.EntryMain {
+		return label + ": func main"
+	}
+
+	line := label + ": "
+	if e.Kind == ourtypes.EntryHTTP && e.Detail != "" {
+		line += e.Detail + " "
+	}
+	line += e.Name
+	if e.Handler != "" {
+		line += " -> " + e.Handler
+	}
+	if e.Kind != ourtypes.EntryHTTP && e.Detail != "" {
+		line += fmt.Sprintf(" (%s)", e.Detail)
+	}
+	return line
+}
@@ -0,0 +1,48 @@
+package composer_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vlad/ast2llm-go/internal/composer"
+	"github.com/vlad/ast2llm-go/internal/types"
+)
+
+func TestProjectComposer_Format_EntryPoints(t *testing.T) {
+	projectInfo := map[string]*types.FileInfo{
+		"/project/main.go": {
+			PackageName: "main",
+			Imports:     []*types.ImportInfo{{Path: "net/http"}},
+			EntryPoints: []*types.EntryPoint{
+				{Kind: types.EntryCLI, Name: "serve", Handler: "runServe", Detail: "Start the server"},
+				{Kind: types.EntryHTTP, Name: "/users/{id}", Handler: "listUsers", Detail: "GET"},
+				{Kind: types.EntryHTTP, Name: "/health", Handler: "func literal"},
+				{Kind: types.EntryGRPC, Name: "Greeter", Handler: "&server{}"},
+				{Kind: types.EntryMain, Name: "main", Pos: &types.Position{File: "/project/main.go", Line: 12, Column: 1}},
+			},
+		},
+	}
+
+	output, err := composer.New(projectInfo).Compose("/project/main.go")
+	require.NoError(t, err)
+	assert.Contains(t, output, `Imports:
+- net/http
+
+Entry Points:
+  Command: serve -> runServe (Start the server)
+  HTTP: GET /users/{id} -> listUsers
+  HTTP: /health -> func literal
+  gRPC service: Greeter -> &server{}
+  Main: func main
+
+`)
+
+	output, err = composer.New(projectInfo, composer.WithPositions()).Compose("/project/main.go")
+	require.NoError(t, err)
+	assert.Contains(t, output, "  Main: func main\n    Position: /project/main.go:12:1\n")
+
+	composed, err := composer.New(projectInfo).ComposeFile("/project/main.go")
+	require.NoError(t, err)
+	assert.Len(t, composed.EntryPoints, 5)
+}
@@ -0,0 +1,30 @@
+package composer
+
+import (
+	"fmt"
+	"strings"
+
+	ourtypes "github.com/vlad/ast2llm-go/internal/types"
+)
+
+// FormatErrorInfo formats a sentinel error or an error type into the StringBuilder.
+func (p *ProjectComposer) FormatErrorInfo(builder *strings.Builder, e *ourtypes.ErrorInfo, indent string) {
+	if e.Kind == ourtypes.ErrorType {
+		name := e.Name
+		if e.PointerOnly {
+			name = "*" + name
+		}
+		builder.WriteString(fmt.Sprintf("%sError type: %s (%s)\n", indent, name, e.Type))
+	} else {
+		builder.WriteString(fmt.Sprintf("%sSentinel: %s %s", indent, e.Name, e.Type))
+		if e.Message != "" {
+			builder.WriteString(fmt.Sprintf(" = %q", e.Message))
+		}
+		builder.WriteString("\n")
+	}
+	p.formatPosition(builder, e.Pos, indent)
+
+	if e.Comment != "" && p.showComments() {
+		builder.WriteString(fmt.Sprintf("%s  Comment: %s\n", indent, e.Comment))
+	}
+}
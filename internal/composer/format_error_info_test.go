@@ -0,0 +1,50 @@
+package composer_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vlad/ast2llm-go/internal/composer"
+	"github.com/vlad/ast2llm-go/internal/types"
+)
+
+func TestProjectComposer_Format_PackageErrors(t *testing.T) {
+	projectInfo := map[string]*types.FileInfo{
+		"/project/store/store.go": {
+			PackageName: "store",
+			ErrorValues: []*types.ErrorInfo{
+				{Name: "example.com/project/store.ErrNotFound", Kind: types.ErrorSentinel, Type: "error", Message: "not found", Comment: "ErrNotFound is returned when no record matches."},
+			},
+		},
+		"/project/store/errors.go": {
+			PackageName: "store",
+			ErrorValues: []*types.ErrorInfo{
+				{Name: "example.com/project/store.ValidationError", Kind: types.ErrorType, Type: "struct{Field string}", PointerOnly: true},
+			},
+		},
+		"/project/store/store_test.go": {
+			PackageName: "store_test",
+			ErrorValues: []*types.ErrorInfo{{Name: "example.com/project/store_test.errFake", Kind: types.ErrorSentinel, Type: "error"}},
+		},
+		"/project/api/api.go": {
+			PackageName: "store",
+			ErrorValues: []*types.ErrorInfo{{Name: "example.com/project/api.ErrDenied", Kind: types.ErrorSentinel, Type: "error"}},
+		},
+	}
+
+	output, err := composer.New(projectInfo).Compose("/project/store/store.go")
+	require.NoError(t, err)
+	assert.Contains(t, output, `Package Errors:
+  Error type: *example.com/project/store.ValidationError (struct{Field string})
+  Sentinel: example.com/project/store.ErrNotFound error = "not found"
+    Comment: ErrNotFound is returned when no record matches.
+
+`)
+	assert.NotContains(t, output, "errFake", "other packages of the directory are left out")
+	assert.NotContains(t, output, "ErrDenied", "packages of other directories are left out")
+
+	composed, err := composer.New(projectInfo).ComposeFile("/project/store/errors.go")
+	require.NoError(t, err)
+	assert.Len(t, composed.PackageErrors, 2)
+}
@@ -0,0 +1,36 @@
+package composer
+
+import (
+	"fmt"
+	"strings"
+
+	ourtypes "github.com/vlad/ast2llm-go/internal/types"
+)
+
+// formatExamples writes the code and expected output of examples below the declaration they document.
+// Examples are usage documentation, so they are shown together with doc comments.
+func (p *ProjectComposer) formatExamples(builder *strings.Builder, examples []*ourtypes.Example, indent string) {
+	if len(examples) == 0 || !p.showComments() {
+		return
+	}
+	builder.WriteString(fmt.Sprintf("%s  Examples:\n", indent))
+	for _, ex := range examples {
+		builder.WriteString(fmt.Sprintf("%s    %s:\n", indent, ex.Name))
+		writeIndentedLines(builder, ex.Code, indent+"      ")
+		if ex.Output != "" {
+			builder.WriteString(fmt.Sprintf("%s      Output:\n", indent))
+			writeIndentedLines(builder, ex.Output, indent+"        ")
+		}
+	}
+}
+
+// writeIndentedLines writes every line of text prefixed with indent
+func writeIndentedLines(builder *strings.Builder, text, indent string) {
+	for _, line := range strings.Split(text, "\n") {
+		if line == "" {
+			builder.WriteString("\n")
+			continue
+		}
+		builder.WriteString(indent + line + "\n")
+	}
+}
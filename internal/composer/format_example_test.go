@@ -0,0 +1,37 @@
+package composer_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vlad/ast2llm-go/internal/composer"
+	"github.com/vlad/ast2llm-go/internal/types"
+)
+
+func TestProjectComposer_Format_Examples(t *testing.T) {
+	push := &types.Example{Name: "ExampleStack_Push", Code: "s := New()\ns.Push(1)\n\nfmt.Println(s.Len())", Output: "1"}
+	projectInfo := map[string]*types.FileInfo{
+		"/project/stack.go": {
+			PackageName: "stack",
+			Functions: []*types.FunctionInfo{
+				{Name: "New", Returns: []string{"*Stack"}, Examples: []*types.Example{{Name: "ExampleNew", Code: "s := New()"}}},
+			},
+			Structs: []*types.StructInfo{
+				{Name: "example.com/stack.Stack", Examples: []*types.Example{push}},
+			},
+			Interfaces: []*types.InterfaceInfo{
+				{Name: "example.com/stack.Pusher", Examples: []*types.Example{{Name: "ExamplePusher", Code: "var p Pusher = New()"}}},
+			},
+		},
+	}
+
+	output, err := composer.New(projectInfo).Compose("/project/stack.go")
+	assert.NoError(t, err)
+	assert.Contains(t, output, "    Signature: () -> (*Stack)\n    Examples:\n      ExampleNew:\n        s := New()\n")
+	assert.Contains(t, output, "    Examples:\n      ExampleStack_Push:\n        s := New()\n        s.Push(1)\n\n        fmt.Println(s.Len())\n        Output:\n          1\n")
+	assert.Contains(t, output, "      ExamplePusher:\n        var p Pusher = New()\n")
+
+	output, err = composer.New(projectInfo, composer.WithVerbosity(composer.VerbositySignatures)).Compose("/project/stack.go")
+	assert.NoError(t, err)
+	assert.NotContains(t, output, "Examples:")
+}
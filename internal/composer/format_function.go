@@ -7,10 +7,15 @@ import (
 	ourtypes "github.com/vlad/ast2llm-go/internal/types"
 )
 
-// FormatFunction formats a FunctionInfo into the StringBuilder.
+// FormatFunction formats a FunctionInfo into the StringBuilder. Methods are prefixed with their receiver.
 func (p *ProjectComposer) FormatFunction(builder *strings.Builder, fn *ourtypes.FunctionInfo, indent string) {
-	builder.WriteString(fmt.Sprintf("%sFunction: %s\n", indent, fn.Name))
-	if fn.Comment != "" {
+	if fn.Receiver != "" {
+		builder.WriteString(fmt.Sprintf("%sMethod: (%s) %s\n", indent, fn.Receiver, fn.Name))
+	} else {
+		builder.WriteString(fmt.Sprintf("%sFunction: %s%s\n", indent, fn.Name, formatTypeParams(fn.TypeParams)))
+	}
+	p.formatPosition(builder, fn.Pos, indent)
+	if fn.Comment != "" && p.showComments() {
 		builder.WriteString(fmt.Sprintf("%s  Comment: %s\n", indent, fn.Comment))
 	}
 	builder.WriteString(fmt.Sprintf("%s  Signature: (%s)", indent, strings.Join(fn.Params, ", ")))
@@ -18,4 +23,34 @@ func (p *ProjectComposer) FormatFunction(builder *strings.Builder, fn *ourtypes.
 		builder.WriteString(fmt.Sprintf(" -> (%s)", strings.Join(fn.Returns, ", ")))
 	}
 	builder.WriteString("\n")
+	if fn.CallSite != "" && p.showComments() {
+		builder.WriteString(fmt.Sprintf("%s  Called as: %s\n", indent, fn.CallSite))
+	}
+
+	if p.showBodies() && fn.Body != "" && (p.focusSymbol == "" || p.functionMatchesFocus(fn)) {
+		builder.WriteString(fmt.Sprintf("%s  Source:\n", indent))
+		for _, line := range strings.Split(strings.TrimRight(fn.Body, "\n"), "\n") {
+			builder.WriteString(fmt.Sprintf("%s    %s\n", indent, line))
+		}
+	}
+	p.formatExamples(builder, fn.Examples, indent)
+}
+
+// functionMatchesFocus reports whether fn is the focus symbol, accepting Type.Method for methods and its ID.
+func (p *ProjectComposer) functionMatchesFocus(fn *ourtypes.FunctionInfo) bool {
+	if fn.ID != "" && fn.ID == p.focusSymbol {
+		return true
+	}
+	if fn.Receiver != "" {
+		return p.matchesFocus(strings.TrimPrefix(fn.Receiver, "*") + "." + fn.Name)
+	}
+	return p.matchesFocus(fn.Name)
+}
+
+// formatTypeParams renders a type parameter list, e.g. "[T comparable, U any]", or nothing for non-generic entities.
+func formatTypeParams(typeParams []string) string {
+	if len(typeParams) == 0 {
+		return ""
+	}
+	return "[" + strings.Join(typeParams, ", ") + "]"
 }
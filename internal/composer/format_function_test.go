@@ -3,6 +3,7 @@ package composer_test
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -71,7 +72,7 @@ func MyPkgFunction(a, b int) (int, error) {
 	assert.NoError(t, err)
 
 	assert.Contains(t, composedOutput, "Used Items From Other Packages:")
-	assert.Contains(t, composedOutput, "Function: example.com/testproject/internal/mypkg.MyPkgFunction")
+	assert.Contains(t, composedOutput, "  Package mypkg (example.com/testproject/internal/mypkg)\n    Function: mypkg.MyPkgFunction\n")
 	assert.Contains(t, composedOutput, "  Comment: MyPkgFunction creates return int.")
 	assert.Contains(t, composedOutput, "  Signature: (a int, b int) -> (int, error)")
 }
@@ -80,7 +81,7 @@ func TestProjectComposer_Format_Function(t *testing.T) {
 	projectInfo := map[string]*types.FileInfo{
 		"/project/other.go": {
 			PackageName: "other",
-			Imports:     []string{},
+			Imports:     []*types.ImportInfo{},
 			Functions: []*types.FunctionInfo{
 				{
 					Name:    "testme/dto.MyFunction",
@@ -95,7 +96,7 @@ func TestProjectComposer_Format_Function(t *testing.T) {
 		},
 		"/project/file.go": {
 			PackageName: "main",
-			Imports:     []string{},
+			Imports:     []*types.ImportInfo{},
 			Functions:   []*types.FunctionInfo{},
 			Structs:     []*types.StructInfo{},
 			Interfaces:  []*types.InterfaceInfo{},
@@ -107,7 +108,61 @@ func TestProjectComposer_Format_Function(t *testing.T) {
 	composer := composer.New(projectInfo)
 	output, err := composer.Compose("/project/file.go")
 	assert.NoError(t, err)
-	assert.Contains(t, output, "Function: testme/dto.MyFunction")
+	assert.Contains(t, output, "  Package dto (testme/dto)\n    Function: dto.MyFunction\n")
 	assert.Contains(t, output, "Comment: Help to calculate")
 	assert.Contains(t, output, "Signature: (a int, b string) -> (int, error)")
 }
+
+func TestProjectComposer_Format_CallSite(t *testing.T) {
+	projectInfo := map[string]*types.FileInfo{
+		"/project/file.go": {
+			PackageName: "main",
+			UsedImportedFunctions: []*types.FunctionInfo{
+				{Name: "example.com/project/store.Open", Params: []string{"name string"}, Returns: []string{"error"}, CallSite: `store.Open("db")`},
+			},
+		},
+	}
+
+	output, err := composer.New(projectInfo).Compose("/project/file.go")
+	assert.NoError(t, err)
+	assert.Contains(t, output, "  Package store (example.com/project/store)\n    Function: store.Open\n      Signature: (name string) -> (error)\n      Called as: store.Open(\"db\")\n")
+
+	output, err = composer.New(projectInfo, composer.WithVerbosity(composer.VerbositySignatures)).Compose("/project/file.go")
+	assert.NoError(t, err)
+	assert.NotContains(t, output, "Called as:")
+}
+
+func TestProjectComposer_Format_Method(t *testing.T) {
+	projectInfo := map[string]*types.FileInfo{
+		"/project/counter.go": {
+			PackageName: "main",
+			Methods: []*types.FunctionInfo{
+				{Name: "Inc", Receiver: "*Counter", Comment: "Inc increments.", Params: []string{"by int"}},
+			},
+		},
+	}
+	output, err := composer.New(projectInfo).Compose("/project/counter.go")
+	assert.NoError(t, err)
+	assert.Contains(t, output, "Methods:\n  Method: (*Counter) Inc\n    Comment: Inc increments.\n    Signature: (by int)\n")
+}
+
+func TestProjectComposer_Format_FunctionBodies(t *testing.T) {
+	projectInfo := map[string]*types.FileInfo{
+		"/project/main.go": {
+			PackageName: "main",
+			Functions: []*types.FunctionInfo{
+				{Name: "Add", Params: []string{"a int", "b int"}, Returns: []string{"int"}, Body: "func Add(a, b int) int {\n\treturn a + b\n}"},
+				{Name: "main", Body: "func main() {\n}"},
+			},
+		},
+	}
+
+	output, err := composer.New(projectInfo).Compose("/project/main.go")
+	assert.NoError(t, err)
+	assert.NotContains(t, output, "Source:")
+
+	output, err = composer.New(projectInfo, composer.WithIncludeBodies(), composer.WithFocusSymbol("Add")).Compose("/project/main.go")
+	assert.NoError(t, err)
+	assert.Contains(t, output, "    Signature: (a int, b int) -> (int)\n    Source:\n      func Add(a, b int) int {\n      \treturn a + b\n      }\n")
+	assert.Equal(t, 1, strings.Count(output, "Source:"))
+}
@@ -18,8 +18,9 @@ func (p *ProjectComposer) FormatGlobalVar(builder *strings.Builder, gv *ourtypes
 		builder.WriteString(fmt.Sprintf(" = %s", gv.Value))
 	}
 	builder.WriteString("\n")
+	p.formatPosition(builder, gv.Pos, indent)
 
-	if gv.Comment != "" {
+	if gv.Comment != "" && p.showComments() {
 		builder.WriteString(fmt.Sprintf("%s  Comment: %s\n", indent, gv.Comment))
 	}
 }
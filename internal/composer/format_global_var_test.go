@@ -73,7 +73,9 @@ var MyPkgVariable = "foo"
 	composedOutput, err := composer.Compose(mainGoPath)
 	assert.NoError(t, err)
 
-	assert.Contains(t, composedOutput, "Used Items From Other Packages:")
+	assert.NotContains(t, composedOutput, "Used Items From Other Packages:")
+	assert.Contains(t, composedOutput, "Global Variables/Constants:\n  Var: _ int = mypkg.MyPkgConstant\n  Var: _ string = <computed>\n\n")
+	assert.Contains(t, composedOutput, "Used Global Variables/Constants From Other Packages:\n")
 	assert.Contains(t, composedOutput, `  Const: example.com/testproject/internal/mypkg.MyPkgConstant untyped int = 42
     Comment: MyPkgConstant is an awesome constant.`)
 	assert.Contains(t, composedOutput, `  Var: example.com/testproject/internal/mypkg.MyPkgVariable string = "foo"
@@ -95,7 +97,7 @@ func TestProjectComposer_Format_GlobalVars(t *testing.T) {
 	projectInfo := map[string]*types.FileInfo{
 		"/project/other.go": {
 			PackageName: "other",
-			Imports:     []string{},
+			Imports:     []*types.ImportInfo{},
 			Functions:   []*types.FunctionInfo{},
 			GlobalVars: []*types.GlobalVarInfo{
 				{
@@ -111,7 +113,7 @@ func TestProjectComposer_Format_GlobalVars(t *testing.T) {
 		},
 		"/project/file.go": {
 			PackageName: "main",
-			Imports:     []string{},
+			Imports:     []*types.ImportInfo{},
 			Functions:   []*types.FunctionInfo{},
 			Structs:     []*types.StructInfo{},
 			Interfaces:  []*types.InterfaceInfo{},
@@ -123,5 +125,5 @@ func TestProjectComposer_Format_GlobalVars(t *testing.T) {
 	composer := composer.New(projectInfo)
 	output, err := composer.Compose("/project/file.go")
 	assert.NoError(t, err)
-	assert.Contains(t, output, "Used Items From Other Packages:\n- testme/dto.MyGlobalVariable\n")
+	assert.Contains(t, output, "Used Items From Other Packages:\n  Package dto (testme/dto)\n    - dto.MyGlobalVariable\n")
 }
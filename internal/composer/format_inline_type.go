@@ -0,0 +1,36 @@
+package composer
+
+import (
+	"fmt"
+	"strings"
+
+	ourtypes "github.com/vlad/ast2llm-go/internal/types"
+)
+
+// FormatInlineType formats an anonymous struct or function literal into the StringBuilder, under its synthetic
+// name and the declaration it appears in.
+func (p *ProjectComposer) FormatInlineType(builder *strings.Builder, t *ourtypes.InlineType, indent string) {
+	builder.WriteString(fmt.Sprintf("%sInline: %s", indent, t.Name))
+	if t.Context != "" {
+		builder.WriteString(fmt.Sprintf(" (in %s)", t.Context))
+	}
+	builder.WriteString("\n")
+	p.formatPosition(builder, t.Pos, indent)
+
+	switch {
+	case t.Kind == ourtypes.InlineFunc:
+		builder.WriteString(fmt.Sprintf("%s  Signature: %s\n", indent, t.Signature))
+	case len(t.Fields) == 0 || !p.showFields():
+	case p.minify:
+		fields := make([]string, 0, len(t.Fields))
+		for _, f := range t.Fields {
+			fields = append(fields, fmt.Sprintf("%s %s", f.Name, f.Type))
+		}
+		builder.WriteString(fmt.Sprintf("%s  Fields: %s\n", indent, strings.Join(fields, "; ")))
+	default:
+		builder.WriteString(fmt.Sprintf("%s  Fields:\n", indent))
+		for _, f := range t.Fields {
+			builder.WriteString(fmt.Sprintf("%s    - %s %s\n", indent, f.Name, f.Type))
+		}
+	}
+}
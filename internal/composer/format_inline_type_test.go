@@ -0,0 +1,60 @@
+package composer_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vlad/ast2llm-go/internal/composer"
+	"github.com/vlad/ast2llm-go/internal/types"
+)
+
+func TestProjectComposer_Format_InlineTypes(t *testing.T) {
+	projectInfo := map[string]*types.FileInfo{
+		"/project/main.go": {
+			PackageName: "main",
+			InlineTypes: []*types.InlineType{
+				{
+					Name:    "main.go:7 anonymous struct",
+					Kind:    types.InlineStruct,
+					Context: "type Config, field Options",
+					Fields: []*types.StructField{
+						{Name: "Verbose", Type: "bool"},
+						{Name: "Retries", Type: "int"},
+					},
+					Pos: &types.Position{File: "/project/main.go", Line: 7, Column: 10},
+				},
+				{
+					Name:      "main.go:22 func literal",
+					Kind:      types.InlineFunc,
+					Context:   "func main, var handler",
+					Signature: "(name string) error",
+				},
+			},
+		},
+	}
+
+	output, err := composer.New(projectInfo).Compose("/project/main.go")
+	require.NoError(t, err)
+	assert.Contains(t, output, `Inline Types:
+  Inline: main.go:7 anonymous struct (in type Config, field Options)
+    Fields:
+      - Verbose bool
+      - Retries int
+  Inline: main.go:22 func literal (in func main, var handler)
+    Signature: (name string) error
+`)
+
+	output, err = composer.New(projectInfo, composer.WithMinify()).Compose("/project/main.go")
+	require.NoError(t, err)
+	assert.Contains(t, output, "    Fields: Verbose bool; Retries int\n")
+
+	output, err = composer.New(projectInfo, composer.WithVerbosity(composer.VerbositySignatures)).Compose("/project/main.go")
+	require.NoError(t, err)
+	assert.NotContains(t, output, "Verbose bool")
+	assert.Contains(t, output, "    Signature: (name string) error\n")
+
+	composed, err := composer.New(projectInfo).ComposeFile("/project/main.go")
+	require.NoError(t, err)
+	assert.Len(t, composed.InlineTypes, 2)
+}
@@ -9,8 +9,9 @@ import (
 
 // FormatInterface formats an InterfaceInfo into the StringBuilder.
 func (p *ProjectComposer) FormatInterface(builder *strings.Builder, iface *ourtypes.InterfaceInfo, indent string) {
-	builder.WriteString(fmt.Sprintf("%sInterface: %s\n", indent, iface.Name))
-	if iface.Comment != "" {
+	builder.WriteString(fmt.Sprintf("%sInterface: %s%s\n", indent, iface.Name, formatTypeParams(iface.TypeParams)))
+	p.formatPosition(builder, iface.Pos, indent)
+	if iface.Comment != "" && p.showComments() {
 		builder.WriteString(fmt.Sprintf("%s  Comment: %s\n", indent, iface.Comment))
 	}
 	if len(iface.Embeddeds) > 0 {
@@ -20,12 +21,37 @@ func (p *ProjectComposer) FormatInterface(builder *strings.Builder, iface *ourty
 		}
 	}
 	if len(iface.Methods) > 0 {
+		shownMethods := iface.Methods[:p.memberLimit(p.maxMethods, len(iface.Methods), iface.Name, iface.ID)]
 		builder.WriteString(fmt.Sprintf("%s  Methods:\n", indent))
-		for _, m := range iface.Methods {
+		for _, m := range shownMethods {
 			builder.WriteString(fmt.Sprintf("%s    - %s(%s) (%s)\n", indent, m.Name, strings.Join(m.Parameters, ", "), strings.Join(m.ReturnTypes, ", ")))
-			if m.Comment != "" {
+			if m.Comment != "" && p.showComments() {
 				builder.WriteString(fmt.Sprintf("%s      Comment: %s\n", indent, m.Comment))
 			}
 		}
+		if more := moreMembers(len(iface.Methods)-len(shownMethods), "method"); more != "" {
+			builder.WriteString(fmt.Sprintf("%s    %s\n", indent, more))
+		}
+	}
+	if usage := p.interfaceUsages[iface.Name]; usage != nil {
+		formatInterfaceUsers(builder, "Accepted by", usage.AcceptedBy, indent)
+		formatInterfaceUsers(builder, "Returned by", usage.ReturnedBy, indent)
+	}
+	p.formatExamples(builder, iface.Examples, indent)
+}
+
+// maxInterfaceUsers is the number of functions listed as taking or returning an interface before the rest is
+// only counted
+const maxInterfaceUsers = 10
+
+// formatInterfaceUsers lists the functions taking or returning an interface on one line.
+func formatInterfaceUsers(builder *strings.Builder, label string, functions []string, indent string) {
+	if len(functions) == 0 {
+		return
+	}
+	line := strings.Join(functions, ", ")
+	if len(functions) > maxInterfaceUsers {
+		line = fmt.Sprintf("%s and %d more", strings.Join(functions[:maxInterfaceUsers], ", "), len(functions)-maxInterfaceUsers)
 	}
+	builder.WriteString(fmt.Sprintf("%s  %s: %s\n", indent, label, line))
 }
@@ -3,6 +3,7 @@ package composer_test
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -82,10 +83,10 @@ type MyReadCloser interface {
 	assert.NoError(t, err)
 
 	assert.Contains(t, composedOutput, "Used Items From Other Packages:")
-	assert.Contains(t, composedOutput, "Interface: example.com/testproject/internal/mypkg.MyReadCloser")
+	assert.Contains(t, composedOutput, "  Package mypkg (example.com/testproject/internal/mypkg)\n    Interface: mypkg.MyReadCloser\n")
 	assert.Contains(t, composedOutput, "  Comment: MyReadCloser embeds another interface.")
 	assert.Contains(t, composedOutput, "  Embeds:")
-	assert.Contains(t, composedOutput, "    - example.com/testproject/internal/mypkg.MyReader")
+	assert.Contains(t, composedOutput, "    - mypkg.MyReader")
 	// Note: a bug in the current parser, it doesn't show external package embeds like io.Closer
 	assert.Contains(t, composedOutput, "  Methods:")
 	assert.Contains(t, composedOutput, "    - CloseThis() (error)")
@@ -98,7 +99,7 @@ type MyReadCloser interface {
 	assert.Contains(t, composedOutputPkg, "Local Interfaces:")
 	assert.Contains(t, composedOutputPkg, "Interface: example.com/testproject/internal/mypkg.MyReader")
 	assert.Contains(t, composedOutputPkg, "  Comment: MyReader is a test interface.")
-	assert.Contains(t, composedOutputPkg, "    - Read([]byte) (int, error)")
+	assert.Contains(t, composedOutputPkg, "    - Read(p []byte) (n int, err error)")
 
 	assert.Contains(t, composedOutputPkg, "Interface: example.com/testproject/internal/mypkg.MyReadCloser")
 }
@@ -134,7 +135,7 @@ func TestProjectComposer_Format_Interface(t *testing.T) {
 	assert.NoError(t, err)
 
 	assert.Contains(t, output, "Used Items From Other Packages:")
-	assert.Contains(t, output, "Interface: testme/dto.MyInterface")
+	assert.Contains(t, output, "Interface: dto.MyInterface")
 	assert.Contains(t, output, "  Comment: A test interface.")
 	assert.Contains(t, output, "  Embeds:")
 	assert.Contains(t, output, "    - io.Writer")
@@ -142,3 +143,42 @@ func TestProjectComposer_Format_Interface(t *testing.T) {
 	assert.Contains(t, output, "    - DoSomething(ctx context.Context) (error)")
 	assert.Contains(t, output, "      Comment: Performs an action.")
 }
+
+func TestProjectComposer_Format_InterfaceUsages(t *testing.T) {
+	users := make([]*types.FunctionInfo, 0, 12)
+	for _, name := range []string{"A", "B", "C", "D", "E", "F", "G", "H", "I", "J", "K", "L"} {
+		users = append(users, &types.FunctionInfo{Name: "Use" + name, ParamInterfaces: []string{"example.com/app/store.Many"}})
+	}
+	projectInfo := map[string]*types.FileInfo{
+		"/project/store/store.go": {
+			PackageName: "store",
+			Interfaces: []*types.InterfaceInfo{
+				{Name: "example.com/app/store.Store"},
+				{Name: "example.com/app/store.Many"},
+				{Name: "example.com/app/store.Unused"},
+			},
+			Functions: []*types.FunctionInfo{
+				{Name: "New", ReturnInterfaces: []string{"example.com/app/store.Store"}},
+			},
+			Methods: []*types.FunctionInfo{
+				{Name: "Wrap", Receiver: "*Cache", ParamInterfaces: []string{"example.com/app/store.Store"}, ReturnInterfaces: []string{"example.com/app/store.Store"}},
+			},
+		},
+		"/project/main.go": {
+			PackageName: "main",
+			Functions: []*types.FunctionInfo{
+				{Name: "run", ParamInterfaces: []string{"example.com/app/store.Store"}},
+			},
+		},
+		"/project/many.go": {PackageName: "main", Functions: users},
+	}
+
+	output, err := composer.New(projectInfo).Compose("/project/store/store.go")
+	assert.NoError(t, err)
+	assert.Contains(t, output, `  Interface: example.com/app/store.Store
+    Accepted by: (*store.Cache).Wrap, main.run
+    Returned by: (*store.Cache).Wrap, store.New
+`)
+	assert.Contains(t, output, "    Accepted by: main.UseA, main.UseB, main.UseC, main.UseD, main.UseE, main.UseF, main.UseG, main.UseH, main.UseI, main.UseJ and 2 more\n")
+	assert.True(t, strings.HasSuffix(output, "  Interface: example.com/app/store.Unused\n"), "interfaces nobody takes or returns get no usage lines")
+}
@@ -0,0 +1,80 @@
+package composer
+
+import (
+	"fmt"
+	"strings"
+
+	ourtypes "github.com/vlad/ast2llm-go/internal/types"
+)
+
+// WithMigrations lists the SQL migration directories of the project, see parser.FindMigrations, in the
+// Database Surface section of files holding SQL queries and in ComposeAll output
+func WithMigrations(migrations []*ourtypes.MigrationSet) Option {
+	return func(p *ProjectComposer) {
+		p.migrations = migrations
+	}
+}
+
+// FormatSQLQuery formats an SQL query into the StringBuilder as its statement, tables and origin, followed by
+// its text unless comments are hidden.
+func (p *ProjectComposer) FormatSQLQuery(builder *strings.Builder, q *ourtypes.SQLQuery, indent string) {
+	builder.WriteString(indent + q.Statement)
+	if len(q.Tables) > 0 {
+		builder.WriteString(" " + strings.Join(q.Tables, ", "))
+	}
+	switch {
+	case q.Source == "constant":
+		builder.WriteString(fmt.Sprintf(" (const %s)\n", q.Name))
+	case q.Name != "":
+		builder.WriteString(fmt.Sprintf(" (%s %s)\n", q.Source, q.Name))
+	case q.Source == "call":
+		builder.WriteString(" (inline)\n")
+	default:
+		builder.WriteString(fmt.Sprintf(" (%s)\n", q.Source))
+	}
+	p.formatPosition(builder, q.Pos, indent)
+
+	if q.Query != "" && p.showComments() {
+		builder.WriteString(fmt.Sprintf("%s  SQL: %s\n", indent, q.Query))
+	}
+}
+
+// FormatMigrationSet formats a migration directory into the StringBuilder with its tool and its first and last files.
+func (p *ProjectComposer) FormatMigrationSet(builder *strings.Builder, m *ourtypes.MigrationSet, indent string) {
+	builder.WriteString(fmt.Sprintf("%sMigrations: %s (%s)\n", indent, m.Dir, describeMigrations(m)))
+}
+
+// describeMigrations summarizes the files of a migration directory, e.g. "goose, 12 files, 0001_init.sql to 0012_index.sql".
+func describeMigrations(m *ourtypes.MigrationSet) string {
+	var parts []string
+	if m.Tool != "" {
+		parts = append(parts, m.Tool)
+	}
+	switch len(m.Files) {
+	case 0:
+	case 1:
+		parts = append(parts, "1 file, "+m.Files[0])
+	default:
+		parts = append(parts, fmt.Sprintf("%d files, %s to %s", len(m.Files), m.Files[0], m.Files[len(m.Files)-1]))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// buildDatabaseItems renders the SQL queries of a file and, if it has any, the migration directories of the project.
+func (p *ProjectComposer) buildDatabaseItems(fileInfo *ourtypes.FileInfo) []*composedItem {
+	var items []*composedItem
+	for _, q := range fileInfo.SQLQueries {
+		items = append(items, p.renderItem(q.Name, priorityLocal, func(b *strings.Builder) {
+			p.FormatSQLQuery(b, q, "  ")
+		}))
+	}
+	if len(items) == 0 {
+		return nil
+	}
+	for _, m := range p.migrations {
+		items = append(items, p.renderItem(m.Dir, priorityLocal, func(b *strings.Builder) {
+			p.FormatMigrationSet(b, m, "  ")
+		}))
+	}
+	return items
+}
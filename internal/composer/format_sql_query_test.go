@@ -0,0 +1,66 @@
+package composer_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vlad/ast2llm-go/internal/composer"
+	"github.com/vlad/ast2llm-go/internal/types"
+)
+
+func TestProjectComposer_Format_DatabaseSurface(t *testing.T) {
+	projectInfo := map[string]*types.FileInfo{
+		"/project/store/store.go": {
+			PackageName: "store",
+			SQLQueries: []*types.SQLQuery{
+				{Name: "getUser", Statement: "SELECT", Tables: []string{"users", "teams"}, Query: "SELECT id FROM users JOIN teams ON true", Source: "constant"},
+				{Name: "CreateAuthor", Statement: "INSERT", Tables: []string{"authors"}, Query: "INSERT INTO authors (name) VALUES ($1)", Source: "sqlc"},
+				{Statement: "UPDATE", Tables: []string{"users"}, Query: "UPDATE users SET name = $1", Source: "call", Pos: &types.Position{File: "/project/store/store.go", Line: 9, Column: 2}},
+				{Statement: "SELECT", Tables: []string{"orders"}, Source: "squirrel"},
+			},
+		},
+		"/project/main.go": {PackageName: "main"},
+	}
+	migrations := []*types.MigrationSet{
+		{Dir: "db/migrations", Tool: "golang-migrate", Files: []string{"0001_init.up.sql", "0001_init.down.sql", "0002_orders.up.sql"}},
+		{Dir: "sql", Files: []string{"schema.sql"}},
+	}
+
+	output, err := composer.New(projectInfo, composer.WithMigrations(migrations)).Compose("/project/store/store.go")
+	require.NoError(t, err)
+	assert.Contains(t, output, `Database Surface:
+  SELECT users, teams (const getUser)
+    SQL: SELECT id FROM users JOIN teams ON true
+  INSERT authors (sqlc CreateAuthor)
+    SQL: INSERT INTO authors (name) VALUES ($1)
+  UPDATE users (inline)
+    SQL: UPDATE users SET name = $1
+  SELECT orders (squirrel)
+  Migrations: db/migrations (golang-migrate, 3 files, 0001_init.up.sql to 0002_orders.up.sql)
+  Migrations: sql (1 file, schema.sql)
+
+`)
+
+	output, err = composer.New(projectInfo, composer.WithPositions(), composer.WithVerbosity(composer.VerbositySignatures)).Compose("/project/store/store.go")
+	require.NoError(t, err)
+	assert.Contains(t, output, "  UPDATE users (inline)\n    Position: /project/store/store.go:9:2\n  SELECT orders (squirrel)\n")
+	assert.NotContains(t, output, "SQL:", "queries are left out with comments")
+
+	output, err = composer.New(projectInfo, composer.WithMigrations(migrations)).Compose("/project/main.go")
+	require.NoError(t, err)
+	assert.NotContains(t, output, "Database Surface", "migrations are only listed for files holding queries")
+
+	composed, err := composer.New(projectInfo, composer.WithMigrations(migrations)).ComposeFile("/project/store/store.go")
+	require.NoError(t, err)
+	assert.Len(t, composed.SQLQueries, 4)
+	assert.Len(t, composed.Migrations, 2)
+
+	all, err := composer.New(projectInfo, composer.WithMigrations(migrations)).ComposeAll()
+	require.NoError(t, err)
+	assert.Contains(t, all, `Database surface:
+  - Migrations: db/migrations (golang-migrate, 3 files, 0001_init.up.sql to 0002_orders.up.sql)
+  - Migrations: sql (1 file, schema.sql)
+  - store: 4 SQL queries on authors, orders, teams, users
+`)
+}
@@ -9,25 +9,111 @@ import (
 
 // FormatStruct formats a StructInfo into the StringBuilder.
 func (p *ProjectComposer) FormatStruct(builder *strings.Builder, s *ourtypes.StructInfo, indent string) {
-	builder.WriteString(fmt.Sprintf("%sStruct: %s\n", indent, s.Name))
-	if s.Comment != "" {
+	builder.WriteString(fmt.Sprintf("%sStruct: %s%s\n", indent, s.Name, formatTypeParams(s.TypeParams)))
+	p.formatPosition(builder, s.Pos, indent)
+	if s.Comment != "" && p.showComments() {
 		builder.WriteString(fmt.Sprintf("%s  Comment: %s\n", indent, s.Comment))
 	}
+	switch {
+	case s.Table != "":
+		builder.WriteString(fmt.Sprintf("%s  Table: %s (%s)\n", indent, s.Table, s.ORM))
+	case s.ORM != "":
+		builder.WriteString(fmt.Sprintf("%s  ORM: %s\n", indent, s.ORM))
+	}
 
-	if len(s.Fields) > 0 {
+	shownFields := s.Fields[:p.memberLimit(p.maxFields, len(s.Fields), s.Name, s.ID)]
+	moreFields := moreMembers(len(s.Fields)-len(shownFields), "field")
+	switch {
+	case len(s.Fields) == 0 || !p.showFields():
+	case p.minify:
+		fields := make([]string, 0, len(shownFields)+1)
+		for _, f := range shownFields {
+			fields = append(fields, fmt.Sprintf("%s %s%s%s", f.Name, f.Type, promotedSuffix(f.PromotedFrom), columnSuffix(f.Column)))
+		}
+		if moreFields != "" {
+			fields = append(fields, moreFields)
+		}
+		builder.WriteString(fmt.Sprintf("%s  Fields: %s\n", indent, strings.Join(fields, "; ")))
+	default:
 		builder.WriteString(fmt.Sprintf("%s  Fields:\n", indent))
-		for _, f := range s.Fields {
-			builder.WriteString(fmt.Sprintf("%s    - %s %s\n", indent, f.Name, f.Type))
+		for _, f := range shownFields {
+			ref := p.fieldStruct(s, f)
+			builder.WriteString(fmt.Sprintf("%s    - %s %s%s%s%s\n", indent, f.Name, f.Type, promotedSuffix(f.PromotedFrom), columnSuffix(f.Column), p.definedAtSuffix(ref)))
+			if ref != nil && p.inlineFieldStructs {
+				for _, rf := range ref.Fields {
+					builder.WriteString(fmt.Sprintf("%s        - %s %s%s\n", indent, rf.Name, rf.Type, promotedSuffix(rf.PromotedFrom)))
+				}
+			}
+		}
+		if moreFields != "" {
+			builder.WriteString(fmt.Sprintf("%s    %s\n", indent, moreFields))
 		}
 	}
 
 	if len(s.Methods) > 0 {
+		shownMethods := s.Methods[:p.memberLimit(p.maxMethods, len(s.Methods), s.Name, s.ID)]
 		builder.WriteString(fmt.Sprintf("%s  Methods:\n", indent))
-		for _, m := range s.Methods {
-			builder.WriteString(fmt.Sprintf("%s    - %s(%s) (%s)\n", indent, m.Name, strings.Join(m.Parameters, ", "), strings.Join(m.ReturnTypes, ", ")))
-			if m.Comment != "" {
+		for _, m := range shownMethods {
+			builder.WriteString(fmt.Sprintf("%s    - %s %s(%s) (%s)%s\n", indent, methodReceiver(s, m), m.Name, strings.Join(m.Parameters, ", "), strings.Join(m.ReturnTypes, ", "), promotedSuffix(m.PromotedFrom)))
+			if m.Comment != "" && p.showComments() {
 				builder.WriteString(fmt.Sprintf("%s      Comment: %s\n", indent, m.Comment))
 			}
 		}
+		if more := moreMembers(len(s.Methods)-len(shownMethods), "method"); more != "" {
+			builder.WriteString(fmt.Sprintf("%s    %s\n", indent, more))
+		}
+	}
+	p.formatExamples(builder, s.Examples, indent)
+}
+
+// fieldStruct returns the project struct held by field f of s, or nil if it holds none, is s itself or
+// neither links nor inlined fields are printed.
+func (p *ProjectComposer) fieldStruct(s *ourtypes.StructInfo, f *ourtypes.StructField) *ourtypes.StructInfo {
+	if f.TypeRef == "" || f.TypeRef == s.Name || p.projectStructs == nil {
+		return nil
+	}
+	return p.projectStructs[f.TypeRef]
+}
+
+// definedAtSuffix links a field to the declaration of the project struct it holds if positions are printed.
+func (p *ProjectComposer) definedAtSuffix(ref *ourtypes.StructInfo) string {
+	if !p.positions || ref == nil || ref.Pos == nil {
+		return ""
+	}
+	return fmt.Sprintf(" (defined at %s)", ref.Pos)
+}
+
+// promotedSuffix marks a member promoted from an embedded type.
+func promotedSuffix(origin string) string {
+	if origin == "" {
+		return ""
+	}
+	return fmt.Sprintf(" (promoted from %s)", origin)
+}
+
+// columnSuffix names the database column a field maps to.
+func columnSuffix(column string) string {
+	if column == "" {
+		return ""
+	}
+	return fmt.Sprintf(" (column %s)", column)
+}
+
+// methodReceiver renders the receiver of a struct method like in its declaration, e.g. "(*MyStruct)".
+// Promoted methods show the embedded type declaring them.
+func methodReceiver(s *ourtypes.StructInfo, m *ourtypes.StructMethod) string {
+	recv := s.Name
+	if m.PromotedFrom != "" {
+		recv = m.PromotedFrom
+	}
+	if i := strings.LastIndex(recv, "/"); i >= 0 {
+		recv = recv[i+1:]
+	}
+	if i := strings.LastIndex(recv, "."); i >= 0 {
+		recv = recv[i+1:]
+	}
+	if m.ReceiverIsPointer {
+		return "(*" + recv + ")"
 	}
+	return "(" + recv + ")"
 }
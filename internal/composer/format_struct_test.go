@@ -82,13 +82,13 @@ func (s *MyPkgStruct) MyMethod() string {
 	assert.NoError(t, err)
 
 	assert.Contains(t, composedOutput, "Used Items From Other Packages:")
-	assert.Contains(t, composedOutput, "Struct: example.com/testproject/internal/mypkg.MyPkgStruct")
+	assert.Contains(t, composedOutput, "  Package mypkg (example.com/testproject/internal/mypkg)\n    Struct: mypkg.MyPkgStruct\n")
 	assert.Contains(t, composedOutput, "  Comment: MyPkgStruct is a test struct.")
 	assert.Contains(t, composedOutput, "  Fields:")
 	assert.Contains(t, composedOutput, "    - ID int")
 	assert.Contains(t, composedOutput, "    - Name string")
 	assert.Contains(t, composedOutput, "  Methods:")
-	assert.Contains(t, composedOutput, "    - MyMethod() (string)")
+	assert.Contains(t, composedOutput, "    - (*MyPkgStruct) MyMethod() (string)")
 
 	// Test Compose for mypkg.go (local struct)
 	mypkgGoPath := filepath.Join(mypkgDir, "mypkg.go")
@@ -107,7 +107,7 @@ func TestProjectComposer_Format_Struct(t *testing.T) {
 	projectInfo := map[string]*types.FileInfo{
 		"/project/other.go": {
 			PackageName: "other",
-			Imports:     []string{},
+			Imports:     []*types.ImportInfo{},
 			Functions:   []*types.FunctionInfo{},
 			Structs: []*types.StructInfo{
 				{
@@ -131,7 +131,7 @@ func TestProjectComposer_Format_Struct(t *testing.T) {
 		},
 		"/project/file.go": {
 			PackageName: "main",
-			Imports:     []string{},
+			Imports:     []*types.ImportInfo{},
 			Functions:   []*types.FunctionInfo{},
 			Structs:     []*types.StructInfo{},
 			Interfaces:  []*types.InterfaceInfo{},
@@ -144,11 +144,162 @@ func TestProjectComposer_Format_Struct(t *testing.T) {
 	output, err := composer.Compose("/project/file.go")
 	assert.NoError(t, err)
 	assert.Contains(t, output, "Used Items From Other Packages:")
-	assert.Contains(t, output, "Struct: testme/dto.MyStruct")
+	assert.Contains(t, output, "Struct: dto.MyStruct")
 	assert.Contains(t, output, "  Comment: A test struct.")
 	assert.Contains(t, output, "  Fields:")
 	assert.Contains(t, output, "    - FieldA string")
 	assert.Contains(t, output, "    - FieldB int")
 	assert.Contains(t, output, "  Methods:")
-	assert.Contains(t, output, "    - GetA() (string)")
+	assert.Contains(t, output, "    - (MyStruct) GetA() (string)")
+}
+
+func TestProjectComposer_Format_GenericStruct(t *testing.T) {
+	projectInfo := map[string]*types.FileInfo{
+		"/project/box.go": {
+			PackageName: "box",
+			Structs: []*types.StructInfo{
+				{
+					Name:       "example.com/project/box.Box",
+					TypeParams: []string{"T comparable", "U any"},
+					Fields: []*types.StructField{
+						{Name: "Key", Type: "T"},
+					},
+				},
+			},
+		},
+	}
+	output, err := composer.New(projectInfo).Compose("/project/box.go")
+	assert.NoError(t, err)
+	assert.Contains(t, output, "Struct: example.com/project/box.Box[T comparable, U any]\n")
+	assert.Contains(t, output, "    - Key T")
+}
+
+func TestProjectComposer_Format_PromotedMembers(t *testing.T) {
+	projectInfo := map[string]*types.FileInfo{
+		"/project/main.go": {
+			PackageName: "main",
+			Structs: []*types.StructInfo{
+				{
+					Name: "example.com/project.Derived",
+					Fields: []*types.StructField{
+						{Name: "ID", Type: "int", PromotedFrom: "example.com/project.Base"},
+					},
+					Methods: []*types.StructMethod{
+						{Name: "Describe", ReturnTypes: []string{"string"}, PromotedFrom: "example.com/project.Base"},
+					},
+				},
+			},
+		},
+	}
+	output, err := composer.New(projectInfo).Compose("/project/main.go")
+	assert.NoError(t, err)
+	assert.Contains(t, output, "    - ID int (promoted from example.com/project.Base)\n")
+	assert.Contains(t, output, "    - (Base) Describe() (string) (promoted from example.com/project.Base)\n")
+}
+
+func TestProjectComposer_Format_DottedModulePath(t *testing.T) {
+	projectInfo := map[string]*types.FileInfo{
+		"/project/node.go": {
+			PackageName: "yaml",
+			Structs: []*types.StructInfo{
+				{
+					Name: "gopkg.in/yaml.v3.Node",
+					Methods: []*types.StructMethod{
+						{Name: "Decode", ReceiverIsPointer: true},
+						{Name: "Encode", PromotedFrom: "gopkg.in/yaml.v3.Encoder"},
+					},
+				},
+			},
+		},
+	}
+	output, err := composer.New(projectInfo).Compose("/project/node.go")
+	assert.NoError(t, err)
+	assert.Contains(t, output, "    - (*Node) Decode() ()\n")
+	assert.Contains(t, output, "    - (Encoder) Encode() () (promoted from gopkg.in/yaml.v3.Encoder)\n")
+}
+
+func TestProjectComposer_Format_FieldStructs(t *testing.T) {
+	projectInfo := map[string]*types.FileInfo{
+		"/project/models/user.go": {
+			PackageName: "models",
+			Structs: []*types.StructInfo{
+				{
+					Name: "example.com/project/models.User",
+					Pos:  &types.Position{File: "/project/models/user.go", Line: 3, Column: 6},
+					Fields: []*types.StructField{
+						{Name: "Name", Type: "string"},
+						{Name: "Manager", Type: "*example.com/project/models.User", TypeRef: "example.com/project/models.User"},
+					},
+				},
+			},
+		},
+		"/project/main.go": {
+			PackageName: "main",
+			Structs: []*types.StructInfo{
+				{
+					Name: "example.com/project.Team",
+					Fields: []*types.StructField{
+						{Name: "Owner", Type: "*example.com/project/models.User", TypeRef: "example.com/project/models.User"},
+						{Name: "Started", Type: "time.Time", TypeRef: "time.Time"},
+					},
+				},
+			},
+		},
+	}
+
+	output, err := composer.New(projectInfo).Compose("/project/main.go")
+	assert.NoError(t, err)
+	assert.Contains(t, output, "      - Owner *example.com/project/models.User\n      - Started time.Time\n")
+
+	output, err = composer.New(projectInfo, composer.WithInlineFieldStructs()).Compose("/project/main.go")
+	assert.NoError(t, err)
+	assert.Contains(t, output, "      - Owner *example.com/project/models.User\n"+
+		"          - Name string\n"+
+		"          - Manager *example.com/project/models.User\n"+
+		"      - Started time.Time\n", "only project structs are inlined, one level deep")
+
+	output, err = composer.New(projectInfo, composer.WithPositions()).Compose("/project/main.go")
+	assert.NoError(t, err)
+	assert.Contains(t, output, "      - Owner *example.com/project/models.User (defined at /project/models/user.go:3:6)\n")
+
+	output, err = composer.New(projectInfo, composer.WithInlineFieldStructs()).Compose("/project/models/user.go")
+	assert.NoError(t, err)
+	assert.Contains(t, output, "      - Manager *example.com/project/models.User\n", "a struct is not inlined into itself")
+	assert.NotContains(t, output, "          - Name string")
+}
+
+func TestProjectComposer_Format_ORMMapping(t *testing.T) {
+	projectInfo := map[string]*types.FileInfo{
+		"/project/models.go": {
+			PackageName: "models",
+			Structs: []*types.StructInfo{
+				{
+					Name: "example.com/app/models.User", ORM: "gorm", Table: "accounts",
+					Fields: []*types.StructField{
+						{Name: "ID", Type: "int", Column: "id"},
+						{Name: "Cache", Type: "string"},
+					},
+				},
+			},
+		},
+		"/project/rows.go": {
+			PackageName: "models",
+			Structs: []*types.StructInfo{
+				{Name: "example.com/app/models.Row", ORM: "sqlx", Fields: []*types.StructField{{Name: "ID", Type: "int", Column: "row_id"}}},
+			},
+		},
+	}
+
+	output, err := composer.New(projectInfo).Compose("/project/models.go")
+	assert.NoError(t, err)
+	assert.Contains(t, output, `  Struct: example.com/app/models.User
+    Table: accounts (gorm)
+    Fields:
+      - ID int (column id)
+      - Cache string
+`)
+
+	output, err = composer.New(projectInfo, composer.WithMinify()).Compose("/project/rows.go")
+	assert.NoError(t, err)
+	assert.Contains(t, output, "    ORM: sqlx\n    Fields: ID int (column row_id)\n")
 }
@@ -0,0 +1,59 @@
+package composer_test
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vlad/ast2llm-go/internal/composer"
+	"github.com/vlad/ast2llm-go/internal/parser"
+)
+
+// update rewrites the golden files with the current Compose output instead of comparing it with them:
+// go test ./internal/composer -run Golden -update
+var update = flag.Bool("update", false, "rewrite the golden files in testdata with the current output")
+
+func TestProjectComposer_Compose_Golden(t *testing.T) {
+	root, err := filepath.Abs(filepath.Join("testdata", "project"))
+	require.NoError(t, err)
+	projectInfo, err := parser.New().ParseProject(root)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name string
+		file string
+		opts []composer.Option
+	}{
+		{name: "main", file: "main.go"},
+		{name: "main_minify", file: "main.go", opts: []composer.Option{composer.WithMinify()}},
+		{name: "main_signatures", file: "main.go", opts: []composer.Option{composer.WithVerbosity(composer.VerbositySignatures)}},
+		{name: "store", file: "store/store.go"},
+		{name: "store_member_limits", file: "store/store.go", opts: []composer.Option{composer.WithMaxFields(1), composer.WithMaxMethods(1)}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			output, err := composer.New(projectInfo, tt.opts...).Compose(filepath.Join(root, tt.file))
+			require.NoError(t, err)
+			assertGolden(t, tt.name, strings.ReplaceAll(output, root, "$PROJECT"))
+		})
+	}
+}
+
+// assertGolden compares output with testdata/<name>.golden, or writes it there when run with -update.
+func assertGolden(t *testing.T, name, output string) {
+	t.Helper()
+
+	path := filepath.Join("testdata", name+".golden")
+	if *update {
+		require.NoError(t, os.WriteFile(path, []byte(output), 0644))
+		return
+	}
+	want, err := os.ReadFile(path)
+	require.NoError(t, err, "missing golden file, run the test with -update to create it")
+	assert.Equal(t, string(want), output, "output differs from %s, run the test with -update and review the diff if the change is intended", path)
+}
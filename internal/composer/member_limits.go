@@ -0,0 +1,60 @@
+package composer
+
+import (
+	"fmt"
+	"strings"
+)
+
+// WithMaxFields lists at most n fields of every struct in Compose output, followed by a "(+N more fields)"
+// marker. 0, the default, means unbounded. The expand symbol is always listed in full, see WithExpandSymbol
+func WithMaxFields(n int) Option {
+	return func(p *ProjectComposer) {
+		p.maxFields = n
+	}
+}
+
+// WithMaxMethods lists at most n methods of every struct and interface in Compose output, followed by a
+// "(+N more methods)" marker. 0, the default, means unbounded. The expand symbol is always listed in full
+func WithMaxMethods(n int) Option {
+	return func(p *ProjectComposer) {
+		p.maxMethods = n
+	}
+}
+
+// WithExpandSymbol lists every field and method of the types matching symbol, a name or an ID like for
+// WithFocusSymbol, whatever the limits of WithMaxFields and WithMaxMethods
+func WithExpandSymbol(symbol string) Option {
+	return func(p *ProjectComposer) {
+		p.expandSymbol = symbol
+	}
+}
+
+// memberLimit returns how many of the n members of the type called name, with the given ID, are listed
+// under a limit of max, 0 meaning unbounded.
+func (p *ProjectComposer) memberLimit(max, n int, name, id string) int {
+	if max <= 0 || n <= max || p.expands(name, id) {
+		return n
+	}
+	return max
+}
+
+// expands reports whether the type called name, with the given ID, is the expand symbol.
+func (p *ProjectComposer) expands(name, id string) bool {
+	symbol := p.expandSymbol
+	if symbol == "" {
+		return false
+	}
+	return id == symbol || name == symbol || strings.HasSuffix(name, "."+symbol) || strings.HasSuffix(name, "/"+symbol)
+}
+
+// moreMembers renders the marker of the members of a kind, e.g. field, left out by a limit, e.g.
+// "(+12 more fields)", or "" if none were.
+func moreMembers(omitted int, kind string) string {
+	switch {
+	case omitted <= 0:
+		return ""
+	case omitted == 1:
+		return fmt.Sprintf("(+1 more %s)", kind)
+	}
+	return fmt.Sprintf("(+%d more %ss)", omitted, kind)
+}
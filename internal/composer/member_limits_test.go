@@ -0,0 +1,51 @@
+package composer_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vlad/ast2llm-go/internal/composer"
+	"github.com/vlad/ast2llm-go/internal/parser"
+	"github.com/vlad/ast2llm-go/internal/types"
+)
+
+func TestProjectComposer_Compose_MemberLimits(t *testing.T) {
+	filePath := "/project/config.go"
+	config := &types.StructInfo{Name: "Config", ID: "example.com/project.Config"}
+	for i := 1; i <= 5; i++ {
+		config.Fields = append(config.Fields, &types.StructField{Name: fmt.Sprintf("F%d", i), Type: "int"})
+		config.Methods = append(config.Methods, &types.StructMethod{Name: fmt.Sprintf("M%d", i)})
+	}
+	store := &types.InterfaceInfo{Name: "Store", ID: "example.com/project.Store", Methods: []*types.InterfaceMethod{{Name: "Get"}, {Name: "Put"}}}
+	projectInfo := parser.ProjectInfo{
+		filePath: {
+			PackageName: "project",
+			Structs:     []*types.StructInfo{config},
+			Interfaces:  []*types.InterfaceInfo{store},
+		},
+	}
+
+	output, err := composer.New(projectInfo, composer.WithMaxFields(2), composer.WithMaxMethods(1)).Compose(filePath)
+	require.NoError(t, err)
+	assert.Contains(t, output, "    Fields:\n      - F1 int\n      - F2 int\n      (+3 more fields)\n")
+	assert.Contains(t, output, "    Methods:\n      - (Config) M1() ()\n      (+4 more methods)\n")
+	assert.Contains(t, output, "    Methods:\n      - Get() ()\n      (+1 more method)\n")
+
+	output, err = composer.New(projectInfo, composer.WithMaxFields(2), composer.WithMinify()).Compose(filePath)
+	require.NoError(t, err)
+	assert.Contains(t, output, "    Fields: F1 int; F2 int; (+3 more fields)\n")
+
+	for _, symbol := range []string{"Config", "example.com/project.Config"} {
+		output, err = composer.New(projectInfo, composer.WithMaxFields(2), composer.WithMaxMethods(1), composer.WithExpandSymbol(symbol)).Compose(filePath)
+		require.NoError(t, err)
+		assert.Contains(t, output, "      - F5 int\n", "%s is listed in full", symbol)
+		assert.Contains(t, output, "      - (Config) M5() ()\n")
+		assert.Contains(t, output, "(+1 more method)", "other types keep the limits")
+	}
+
+	output, err = composer.New(projectInfo, composer.WithMaxFields(5)).Compose(filePath)
+	require.NoError(t, err)
+	assert.NotContains(t, output, "more fields", "types within the limit have no marker")
+}
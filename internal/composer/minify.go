@@ -0,0 +1,214 @@
+package composer
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// qualifiedPathPattern matches the package path of a qualified name with at least two path elements,
+// e.g. "example.com/project/models" in "*example.com/project/models.User".
+var qualifiedPathPattern = regexp.MustCompile(`[A-Za-z0-9_.~-]+(?:/[A-Za-z0-9_.~-]+)*/[A-Za-z0-9_~-]+\.[A-Za-z_]`)
+
+// moduleAliasName replaces the module path in WithModuleAlias output
+const moduleAliasName = "~"
+
+// aliasModulePath replaces modulePath with moduleAliasName in the items of all sections and returns the legend
+// line explaining it, or "" if the module path does not occur.
+func aliasModulePath(sections []*composedSection, modulePath string) string {
+	replaced := false
+	for _, section := range sections {
+		for _, item := range section.items {
+			text := replaceModulePath(item.text, modulePath)
+			if text != item.text {
+				item.text = text
+				replaced = true
+			}
+			item.group = replaceModulePath(item.group, modulePath)
+			item.groupPath = replaceModulePath(item.groupPath, modulePath)
+		}
+	}
+	if !replaced {
+		return ""
+	}
+	return fmt.Sprintf("Module alias: %s = %s\n", moduleAliasName, modulePath)
+}
+
+// replaceModulePath replaces every occurrence of modulePath that is a whole package path or a prefix of one.
+func replaceModulePath(text, modulePath string) string {
+	var b strings.Builder
+	last := 0
+	for start := 0; ; {
+		i := strings.Index(text[start:], modulePath)
+		if i < 0 {
+			break
+		}
+		i += start
+		end := i + len(modulePath)
+		start = end
+		if i > 0 && isPathByte(text[i-1]) {
+			continue
+		}
+		if end < len(text) && isPathByte(text[end]) && text[end] != '/' && text[end] != '.' {
+			continue
+		}
+		b.WriteString(text[last:i])
+		b.WriteString(moduleAliasName)
+		last = end
+	}
+	b.WriteString(text[last:])
+	return b.String()
+}
+
+// isPathByte reports whether c can be part of a package path.
+func isPathByte(c byte) bool {
+	return c == '_' || c == '.' || c == '-' || c == '~' || c == '/' || c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
+
+// packageAliases are the short aliases of the package paths abbreviated by abbreviatePackagePaths
+type packageAliases struct {
+	aliases map[string]string          // Alias of every abbreviated package path
+	paths   map[*composedItem][]string // Package paths abbreviated in each item
+}
+
+// abbreviatePackagePaths replaces the package paths of qualified names in the items of non-fixed sections
+// with short aliases, explained by the legend of the returned packageAliases, or returns nil if there are none.
+// Aliases are the last path element, numbered when several packages share it.
+func abbreviatePackagePaths(sections []*composedSection) *packageAliases {
+	itemPaths := make(map[*composedItem][]string)
+	used := make(map[string]bool)
+	for _, section := range sections {
+		if section.fixed {
+			continue
+		}
+		for _, item := range section.items {
+			// The path of the group is known, even when its last element has dots the pattern cannot tell apart
+			text, paths := item.text, []string(nil)
+			if strings.Contains(item.groupPath, "/") {
+				text = strings.ReplaceAll(text, item.groupPath+".", "")
+				paths = append(paths, item.groupPath)
+			}
+			paths = append(paths, qualifiedPackagePaths(text)...)
+			for _, pkgPath := range paths {
+				used[pkgPath] = true
+				itemPaths[item] = append(itemPaths[item], pkgPath)
+			}
+		}
+	}
+	if len(used) == 0 {
+		return nil
+	}
+
+	paths := make([]string, 0, len(used))
+	for pkgPath := range used {
+		paths = append(paths, pkgPath)
+	}
+	sort.Strings(paths)
+
+	aliases := make(map[string]string, len(paths))
+	taken := make(map[string]bool, len(paths))
+	for _, pkgPath := range paths {
+		alias := path.Base(pkgPath)
+		for n := 2; taken[alias]; n++ {
+			alias = fmt.Sprintf("%s%d", path.Base(pkgPath), n)
+		}
+		taken[alias] = true
+		aliases[pkgPath] = alias
+	}
+
+	for _, section := range sections {
+		if section.fixed {
+			continue
+		}
+		for _, item := range section.items {
+			if alias, ok := aliases[item.groupPath]; ok {
+				item.text = strings.ReplaceAll(item.text, item.groupPath+".", alias+".")
+				item.group = abbreviateGroupPath(item.group, item.groupPath, alias)
+			}
+			item.text = replacePackagePaths(item.text, aliases)
+		}
+	}
+
+	return &packageAliases{aliases: aliases, paths: itemPaths}
+}
+
+// abbreviateGroupPath replaces pkgPath with alias in a group header, e.g. "  Package store (example.com/app/store)"
+// becomes "  Package store" when the alias is the package name, and "  Package store (store2)" otherwise.
+func abbreviateGroupPath(group, pkgPath, alias string) string {
+	full := " (" + pkgPath + ")"
+	if !strings.Contains(group, full) {
+		return strings.Replace(group, "Package "+pkgPath, "Package "+alias, 1)
+	}
+	if strings.Contains(group, "Package "+alias+full) {
+		return strings.Replace(group, full, "", 1)
+	}
+	return strings.Replace(group, full, " ("+alias+")", 1)
+}
+
+// legend returns the line explaining the aliases used by the items that are not omitted, or "" if there
+// are none. Omitting items only removes aliases from it, so its length before the budget is applied bounds it.
+func (a *packageAliases) legend() string {
+	if a == nil {
+		return ""
+	}
+	shown := make(map[string]bool)
+	for item, paths := range a.paths {
+		if item.omitted {
+			continue
+		}
+		for _, pkgPath := range paths {
+			shown[pkgPath] = true
+		}
+	}
+	if len(shown) == 0 {
+		return ""
+	}
+
+	paths := make([]string, 0, len(shown))
+	for pkgPath := range shown {
+		paths = append(paths, pkgPath)
+	}
+	sort.Strings(paths)
+	legend := make([]string, 0, len(paths))
+	for _, pkgPath := range paths {
+		legend = append(legend, a.aliases[pkgPath]+" = "+pkgPath)
+	}
+	return fmt.Sprintf("Package aliases: %s\n", strings.Join(legend, ", "))
+}
+
+// qualifiedPackagePaths returns the package paths of the qualified names in text.
+func qualifiedPackagePaths(text string) []string {
+	var paths []string
+	for _, loc := range qualifiedPathPattern.FindAllStringIndex(text, -1) {
+		if loc[0] > 0 && text[loc[0]-1] == '/' {
+			continue // Part of a file path
+		}
+		match := text[loc[0]:loc[1]]
+		paths = append(paths, match[:strings.LastIndex(match, ".")])
+	}
+	return paths
+}
+
+// replacePackagePaths replaces the package path of every qualified name in text with its alias.
+func replacePackagePaths(text string, aliases map[string]string) string {
+	var b strings.Builder
+	last := 0
+	for _, loc := range qualifiedPathPattern.FindAllStringIndex(text, -1) {
+		if loc[0] > 0 && text[loc[0]-1] == '/' {
+			continue
+		}
+		match := text[loc[0]:loc[1]]
+		pkgPath := match[:strings.LastIndex(match, ".")]
+		alias, ok := aliases[pkgPath]
+		if !ok {
+			continue
+		}
+		b.WriteString(text[last:loc[0]])
+		b.WriteString(alias)
+		last = loc[0] + len(pkgPath)
+	}
+	b.WriteString(text[last:])
+	return b.String()
+}
@@ -0,0 +1,125 @@
+package composer_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vlad/ast2llm-go/internal/composer"
+	"github.com/vlad/ast2llm-go/internal/parser"
+	"github.com/vlad/ast2llm-go/internal/types"
+)
+
+func TestProjectComposer_Compose_Minify(t *testing.T) {
+	filePath := "/project/main.go"
+	projectInfo := parser.ProjectInfo{
+		filePath: {
+			PackageName: "main",
+			Imports:     []*types.ImportInfo{{Path: "example.com/project/models"}, {Path: "example.com/other/models"}},
+			Functions: []*types.FunctionInfo{
+				{Name: "save", Comment: "save stores the user.", Params: []string{"u *example.com/project/models.User", "g example.com/other/models.Group"}},
+			},
+			Structs: []*types.StructInfo{
+				{
+					Name:    "example.com/project.Config",
+					Comment: "Config holds the settings.",
+					Fields: []*types.StructField{
+						{Name: "Name", Type: "string"},
+						{Name: "Owner", Type: "*example.com/project/models.User"},
+					},
+				},
+			},
+		},
+	}
+
+	full, err := composer.New(projectInfo).Compose(filePath)
+	assert.NoError(t, err)
+	output, err := composer.New(projectInfo, composer.WithMinify()).Compose(filePath)
+	assert.NoError(t, err)
+	assert.Less(t, len(output), len(full))
+
+	assert.NotContains(t, output, "Comment:")
+	assert.Contains(t, output, "Package: main\nPackage aliases: models = example.com/other/models, project = example.com/project, models2 = example.com/project/models\n\n")
+	assert.Contains(t, output, "Signature: (u *models2.User, g models.Group)\n")
+	assert.Contains(t, output, "Struct: project.Config\n    Fields: Name string; Owner *models2.User\n")
+
+	// Imports keep their full paths.
+	assert.Contains(t, output, "Imports:\n- example.com/project/models\n- example.com/other/models\n")
+}
+
+func TestProjectComposer_Compose_MinifyWithoutQualifiedNames(t *testing.T) {
+	filePath := "/project/main.go"
+	projectInfo := parser.ProjectInfo{
+		filePath: {
+			PackageName: "main",
+			Functions:   []*types.FunctionInfo{{Name: "main"}},
+		},
+	}
+
+	output, err := composer.New(projectInfo, composer.WithMinify()).Compose(filePath)
+	assert.NoError(t, err)
+	assert.False(t, strings.Contains(output, "Package aliases:"))
+}
+
+func TestProjectComposer_Compose_ModuleAlias(t *testing.T) {
+	filePath := "/project/main.go"
+	projectInfo := parser.ProjectInfo{
+		filePath: {
+			PackageName: "main",
+			Imports:     []*types.ImportInfo{{Path: "example.com/org/project/internal/sub"}, {Path: "example.com/org/projectx/api"}},
+			Functions: []*types.FunctionInfo{
+				{Name: "run", Params: []string{"c *example.com/org/project/internal/sub.Client", "a example.com/org/projectx/api.API"}},
+			},
+			Structs: []*types.StructInfo{{Name: "example.com/org/project.Config"}},
+		},
+	}
+
+	output, err := composer.New(projectInfo, composer.WithModuleAlias("example.com/org/project")).Compose(filePath)
+	assert.NoError(t, err)
+	assert.Contains(t, output, "Package: main\nModule alias: ~ = example.com/org/project\n\n")
+	assert.Contains(t, output, "Imports:\n- ~/internal/sub\n- example.com/org/projectx/api\n")
+	assert.Contains(t, output, "Signature: (c *~/internal/sub.Client, a example.com/org/projectx/api.API)\n")
+	assert.Contains(t, output, "Struct: ~.Config\n")
+
+	t.Run("with minify", func(t *testing.T) {
+		output, err := composer.New(projectInfo, composer.WithModuleAlias("example.com/org/project"), composer.WithMinify()).Compose(filePath)
+		assert.NoError(t, err)
+		assert.Contains(t, output, "Module alias: ~ = example.com/org/project\nPackage aliases: api = example.com/org/projectx/api, sub = ~/internal/sub\n")
+		assert.Contains(t, output, "Signature: (c *sub.Client, a api.API)\n")
+	})
+
+	t.Run("module path not used", func(t *testing.T) {
+		output, err := composer.New(projectInfo, composer.WithModuleAlias("example.com/unrelated")).Compose(filePath)
+		assert.NoError(t, err)
+		assert.NotContains(t, output, "Module alias:")
+	})
+}
+
+func TestProjectComposer_Compose_MinifyWithBudget(t *testing.T) {
+	filePath := "/project/main.go"
+	projectInfo := parser.ProjectInfo{
+		filePath: {
+			PackageName: "main",
+			Functions: []*types.FunctionInfo{
+				{Name: "save", Params: []string{"u *example.com/project/models.User"}},
+				{Name: "load", Params: []string{"g example.com/project/groups.Group"}},
+			},
+		},
+	}
+
+	output, err := composer.New(projectInfo, composer.WithMinify()).Compose(filePath)
+	assert.NoError(t, err)
+	assert.Contains(t, output, "Package aliases: groups = example.com/project/groups, models = example.com/project/models\n")
+
+	// The legend only explains the aliases of the items left within the budget
+	output, err = composer.New(projectInfo, composer.WithMinify(), composer.WithBudget(len(output)-20)).Compose(filePath)
+	assert.NoError(t, err)
+	assert.Contains(t, output, "...1 items omitted\n")
+	assert.Contains(t, output, "Package aliases: ")
+	assert.Equal(t, 1, strings.Count(output, " = example.com/project/"), output)
+
+	output, err = composer.New(projectInfo, composer.WithMinify(), composer.WithBudget(80)).Compose(filePath)
+	assert.NoError(t, err)
+	assert.Contains(t, output, "...2 items omitted\n")
+	assert.NotContains(t, output, "Package aliases:")
+}
@@ -0,0 +1,77 @@
+package composer
+
+import (
+	"sort"
+	"strings"
+
+	ourtypes "github.com/vlad/ast2llm-go/internal/types"
+)
+
+// canonicalFileInfo returns a copy of fileInfo whose declaration lists are sorted by name and contain every
+// declaration only once across all lists, so that identical inputs always compose to identical output even
+// though the parser collects some of the lists from maps.
+// Local declarations win over used ones; among used items, the first list in the order structs, interfaces,
+// functions, global variables wins. Imports and diagnostics keep their source order.
+func canonicalFileInfo(fileInfo *ourtypes.FileInfo) *ourtypes.FileInfo {
+	canonical := *fileInfo
+	seen := make(map[string]bool)
+
+	canonical.Functions = canonicalList(fileInfo.Functions, seen, functionKey)
+	canonical.Methods = canonicalList(fileInfo.Methods, seen, functionKey)
+	canonical.GlobalVars = canonicalList(fileInfo.GlobalVars, seen, globalVarKey)
+	canonical.ConstGroups = canonicalList(fileInfo.ConstGroups, seen, constGroupKey)
+	canonical.Structs = canonicalList(fileInfo.Structs, seen, structKey)
+	canonical.Interfaces = canonicalList(fileInfo.Interfaces, seen, interfaceKey)
+	canonical.UsedImportedStructs = canonicalList(fileInfo.UsedImportedStructs, seen, structKey)
+	canonical.UsedImportedInterfaces = canonicalList(fileInfo.UsedImportedInterfaces, seen, interfaceKey)
+	canonical.UsedImportedFunctions = canonicalList(fileInfo.UsedImportedFunctions, seen, functionKey)
+	canonical.UsedImportedGlobalVars = canonicalList(fileInfo.UsedImportedGlobalVars, seen, globalVarKey)
+
+	// External types only back used items, they are not listed on their own.
+	canonical.ExternalStructs = canonicalList(fileInfo.ExternalStructs, make(map[string]bool), structKey)
+	canonical.ExternalInterfaces = canonicalList(fileInfo.ExternalInterfaces, make(map[string]bool), interfaceKey)
+	return &canonical
+}
+
+// canonicalList returns the items whose key has not been seen yet, sorted by key.
+// The keys of the returned items are added to seen.
+func canonicalList[T any](items []T, seen map[string]bool, key func(T) string) []T {
+	if items == nil {
+		return nil
+	}
+	result := make([]T, 0, len(items))
+	for _, item := range items {
+		k := key(item)
+		if k == "_" {
+			result = append(result, item) // Blank identifiers never collide
+			continue
+		}
+		if seen[k] {
+			continue
+		}
+		seen[k] = true
+		result = append(result, item)
+	}
+	sort.SliceStable(result, func(i, j int) bool { return key(result[i]) < key(result[j]) })
+	return result
+}
+
+// functionKey identifies a function, or a method by its receiver type and name, e.g. "MyStruct.String".
+func functionKey(fn *ourtypes.FunctionInfo) string {
+	if fn.Receiver == "" {
+		return fn.Name
+	}
+	return strings.TrimPrefix(fn.Receiver, "*") + "." + fn.Name
+}
+
+// constGroupKey identifies a const group by its type and first constant, as several groups may share a type.
+func constGroupKey(g *ourtypes.ConstGroup) string {
+	if len(g.Constants) == 0 {
+		return g.Type
+	}
+	return g.Type + "." + g.Constants[0].Name
+}
+
+func globalVarKey(gv *ourtypes.GlobalVarInfo) string { return gv.Name }
+func structKey(s *ourtypes.StructInfo) string        { return s.Name }
+func interfaceKey(i *ourtypes.InterfaceInfo) string  { return i.Name }
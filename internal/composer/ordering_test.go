@@ -0,0 +1,84 @@
+package composer_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vlad/ast2llm-go/internal/composer"
+	"github.com/vlad/ast2llm-go/internal/parser"
+	"github.com/vlad/ast2llm-go/internal/types"
+)
+
+func TestProjectComposer_Compose_StableOrdering(t *testing.T) {
+	filePath := "/project/main.go"
+	newProjectInfo := func(reversed bool) parser.ProjectInfo {
+		structs := []*types.StructInfo{{Name: "example.com/project.A"}, {Name: "example.com/project.B"}}
+		used := []*types.GlobalVarInfo{{Name: "example.com/project/config.X", Type: "int"}, {Name: "example.com/project/config.Y", Type: "int"}}
+		if reversed {
+			structs[0], structs[1] = structs[1], structs[0]
+			used[0], used[1] = used[1], used[0]
+		}
+		return parser.ProjectInfo{
+			filePath: {
+				PackageName:            "main",
+				Structs:                structs,
+				UsedImportedGlobalVars: used,
+				Methods: []*types.FunctionInfo{
+					{Name: "String", Receiver: "B"},
+					{Name: "String", Receiver: "*A"},
+				},
+			},
+		}
+	}
+
+	first, err := composer.New(newProjectInfo(false)).Compose(filePath)
+	require.NoError(t, err)
+	second, err := composer.New(newProjectInfo(true)).Compose(filePath)
+	require.NoError(t, err)
+	assert.Equal(t, first, second)
+	assert.Contains(t, first, "Struct: example.com/project.A\n  Struct: example.com/project.B\n")
+	assert.Contains(t, first, "Var: example.com/project/config.X int\n  Var: example.com/project/config.Y int\n")
+	assert.Contains(t, first, "Method: (*A) String")
+
+	firstJSON, err := composer.New(newProjectInfo(false)).ComposeJSON(filePath)
+	require.NoError(t, err)
+	secondJSON, err := composer.New(newProjectInfo(true)).ComposeJSON(filePath)
+	require.NoError(t, err)
+	assert.Equal(t, firstJSON, secondJSON)
+}
+
+func TestProjectComposer_Compose_Deduplication(t *testing.T) {
+	filePath := "/project/main.go"
+	projectInfo := parser.ProjectInfo{
+		filePath: {
+			PackageName: "main",
+			Structs:     []*types.StructInfo{{Name: "example.com/project.Config"}},
+			UsedImportedStructs: []*types.StructInfo{
+				{Name: "example.com/project.Config"},
+				{Name: "example.com/project/models.New"},
+			},
+			UsedImportedFunctions: []*types.FunctionInfo{
+				{Name: "example.com/project/models.New"},
+				{Name: "example.com/project/models.New"},
+			},
+		},
+	}
+
+	c := composer.New(projectInfo)
+	output, err := c.Compose(filePath)
+	require.NoError(t, err)
+	assert.Equal(t, 1, strings.Count(output, "example.com/project.Config"))
+	assert.Equal(t, 1, strings.Count(output, "models.New"))
+
+	composed, err := c.ComposeFile(filePath)
+	require.NoError(t, err)
+	assert.Len(t, composed.Structs, 1)
+	assert.Empty(t, composed.UsedStructs)
+	assert.Empty(t, composed.UsedFunctions)
+	assert.Equal(t, []string{"example.com/project/models.New"}, composed.Unresolved)
+
+	// The input is left untouched
+	assert.Len(t, projectInfo[filePath].UsedImportedFunctions, 2)
+}
@@ -2,127 +2,754 @@ package composer
 
 import (
 	"fmt"
+	"path/filepath"
+	"sort"
 	"strings"
+	"sync"
 
+	"github.com/vlad/ast2llm-go/internal/modinfo"
 	"github.com/vlad/ast2llm-go/internal/parser"
 	ourtypes "github.com/vlad/ast2llm-go/internal/types" // Alias ourtypes
 )
 
 // ProjectComposer tranform ProjectInfo to friendly representation for LLM
 type ProjectComposer struct {
-	projectInfo parser.ProjectInfo
+	projectInfo   parser.ProjectInfo
+	budget        int                 // Maximum number of characters of Compose output, 0 means unbounded
+	focusSymbol   string              // Symbol whose items are kept first when the budget is exceeded
+	includeBodies bool                // Whether function sources are printed (only for the focus symbol if one is set)
+	moduleInfo    *modinfo.ModuleInfo // go.mod of the project, used to show versions of third-party imports
+	positions     bool                // Whether declaration positions are printed
+	minify        bool                // Whether output is compacted, see WithMinify
+	moduleAlias   string              // Module path replaced by "~" in Compose output, see WithModuleAlias
+	verbosity     Verbosity           // Level of detail of Compose output
+	tokenizer     Tokenizer           // Tokenizer of TokenCount and TokenReport, nil for the default one
+
+	inlineFieldStructs bool                                          // Whether fields holding project structs list their fields, see WithInlineFieldStructs
+	schemaVersion      int                                           // Schema version of ComposeJSON output, see WithSchemaVersion
+	packageTokenBudget int                                           // Maximum number of tokens of every package in ComposeAll output, 0 means unbounded
+	migrations         []*ourtypes.MigrationSet                      // SQL migration directories of the project, see WithMigrations
+	projectStructs     map[string]*ourtypes.StructInfo               // Project structs by fully qualified name, set if fields are linked to them
+	interfaceUsages    map[string]*ourtypes.InterfaceUsage           // Functions taking or returning every interface, by interface name
+	deadExports        map[string]*ourtypes.DeadExport               // Exports nothing refers to, by position, see WithDeadExports
+	dropDeadExports    bool                                          // Whether the declarations of deadExports are left out
+	excludedSections   map[Section]bool                              // Sections left out of the output, see WithoutSections
+	maxFields          int                                           // Maximum number of fields listed per struct, 0 means unbounded
+	maxMethods         int                                           // Maximum number of methods listed per type, 0 means unbounded
+	expandSymbol       string                                        // Type listed with all its members, see WithExpandSymbol
+	packageDocs        func() (map[string]string, map[string]string) // Names and docs of the project packages, computed once, see packageDocs
+}
+
+// Option configures a ProjectComposer
+type Option func(*ProjectComposer)
+
+// WithBudget limits Compose output to maxChars characters, dropping the least relevant items first
+func WithBudget(maxChars int) Option {
+	return func(p *ProjectComposer) {
+		p.budget = maxChars
+	}
+}
+
+// WithFocusSymbol makes items matching symbol the most relevant ones. The symbol is a name, e.g. Config or
+// config.Config, or the ID of a symbol, e.g. example.com/app/config.Config
+func WithFocusSymbol(symbol string) Option {
+	return func(p *ProjectComposer) {
+		p.focusSymbol = symbol
+	}
+}
+
+// WithIncludeBodies prints the source of the focus symbol's functions, or of all functions without a focus symbol.
+// Sources are only available if the project was parsed with parser.WithFunctionBodies
+func WithIncludeBodies() Option {
+	return func(p *ProjectComposer) {
+		p.includeBodies = true
+	}
+}
+
+// WithModuleInfo lists the versions of the third-party modules imported by the composed file
+func WithModuleInfo(moduleInfo *modinfo.ModuleInfo) Option {
+	return func(p *ProjectComposer) {
+		p.moduleInfo = moduleInfo
+	}
+}
+
+// WithPositions prints the file:line:column position of every declaration that has one
+func WithPositions() Option {
+	return func(p *ProjectComposer) {
+		p.positions = true
+	}
+}
+
+// WithModuleAlias replaces modulePath with "~" in Compose output, e.g. ~/internal/sub.Type, and explains the
+// alias in a legend after the package name
+func WithModuleAlias(modulePath string) Option {
+	return func(p *ProjectComposer) {
+		p.moduleAlias = modulePath
+	}
+}
+
+// WithInlineFieldStructs lists the fields of the project struct a struct field holds, e.g. the fields of
+// models.User under an Owner *models.User field, so that it can be understood without looking up User.
+// Only one level is inlined and minified output is left as is
+func WithInlineFieldStructs() Option {
+	return func(p *ProjectComposer) {
+		p.inlineFieldStructs = true
+	}
+}
+
+// WithMinify compacts Compose output: comments are dropped, struct fields are listed on one line and
+// package paths are abbreviated to aliases explained in a legend after the package name
+func WithMinify() Option {
+	return func(p *ProjectComposer) {
+		p.minify = true
+	}
+}
+
+// WithSchemaVersion makes ComposeJSON produce an older schema version for clients that do not support the
+// current one, see ourtypes.SchemaVersion. ComposeJSON fails for versions it cannot produce
+func WithSchemaVersion(version int) Option {
+	return func(p *ProjectComposer) {
+		p.schemaVersion = version
+	}
 }
 
 // New creates a new ProjectComposer instance
-func New(projectInfo parser.ProjectInfo) *ProjectComposer {
-	return &ProjectComposer{
-		projectInfo: projectInfo,
+func New(projectInfo parser.ProjectInfo, opts ...Option) *ProjectComposer {
+	p := &ProjectComposer{
+		projectInfo:   projectInfo,
+		verbosity:     VerbosityFields,
+		schemaVersion: ComposedFileSchemaVersion,
 	}
+	for _, opt := range opts {
+		opt(p)
+	}
+	if p.inlineFieldStructs || p.positions {
+		p.projectStructs, _, _ = p.projectIndex()
+	}
+	p.interfaceUsages = parser.InterfaceUsages(projectInfo)
+	p.packageDocs = sync.OnceValues(func() (map[string]string, map[string]string) { return packageDocs(projectInfo) })
+	return p
+}
+
+// Item priorities used when the output has to fit into the budget, lower is more relevant
+const (
+	priorityDiagnostic = iota
+	priorityFocus
+	priorityRelated
+	priorityUsed
+	priorityLocal
+)
+
+// composedItem is a single rendered entry of a section
+type composedItem struct {
+	name      string // Name used to match the focus symbol
+	text      string
+	group     string // Header line written before the first item of a group, e.g. of the used items of a package
+	groupPath string // Package path given in the group header, abbreviated with the items by WithMinify
+	priority  int
+	omitted   bool
+}
+
+// composedSection is a titled group of items in Compose output
+type composedSection struct {
+	title      string
+	items      []*composedItem
+	blankAfter bool // Whether an empty line follows the section
+	fixed      bool // Whether the items stay in place when a Focus section is built
 }
 
 // Compose transforms the ProjectInfo into an LLM-friendly description for a given file path.
 func (p *ProjectComposer) Compose(filePath string) (string, error) {
-	fileInfo, ok := p.projectInfo[filePath]
+	rendered, err := p.render(filePath)
+	if err != nil {
+		return "", err
+	}
+
+	var builder strings.Builder
+	builder.WriteString(rendered.header)
+	for _, section := range rendered.sections {
+		builder.WriteString(section.String())
+	}
+	if rendered.omitted > 0 {
+		builder.WriteString(omittedMarker(rendered.omitted))
+	}
+	return builder.String(), nil
+}
+
+// renderedFile is the Compose output of a file before it is joined together
+type renderedFile struct {
+	header   string // File, package and legend lines followed by an empty line
+	sections []*composedSection
+	omitted  int // Number of items dropped to fit the budget
+}
+
+// render builds the header and sections of a file, applying the budget.
+func (p *ProjectComposer) render(filePath string) (*renderedFile, error) {
+	filePath, fileInfo, ok := p.lookupFile(filePath)
 	if !ok {
-		return "", fmt.Errorf("file info not found for path: %s", filePath)
+		return nil, fmt.Errorf("file info not found for path: %s", filePath)
 	}
 
 	var builder strings.Builder
 	builder.WriteString(fmt.Sprintf("--- File: %s ---\n", filePath))
 	builder.WriteString(fmt.Sprintf("Package: %s\n", fileInfo.PackageName))
+	if doc := firstParagraph(fileInfo.PackageDoc); doc != "" && p.showComments() {
+		builder.WriteString(fmt.Sprintf("Package Doc: %s\n", doc))
+	}
+	if fileInfo.Generated {
+		builder.WriteString("Generated: yes\n")
+	}
+	if fileInfo.HasCgo {
+		builder.WriteString("Cgo: yes\n")
+	}
+	if fileInfo.HasAsm {
+		builder.WriteString("Assembly: yes\n")
+	}
+
+	if p.dropDeadExports {
+		fileInfo = p.withoutDeadExports(fileInfo)
+	}
+	sections := p.buildSections(filePath, canonicalFileInfo(p.withoutExcludedSections(fileInfo)))
+	if p.focusSymbol != "" {
+		sections = withFocusSection(sections)
+	}
+	if p.moduleAlias != "" {
+		builder.WriteString(aliasModulePath(sections, p.moduleAlias))
+	}
+	var aliases *packageAliases
+	if p.minify {
+		aliases = abbreviatePackagePaths(sections)
+	}
+
+	rendered := &renderedFile{sections: sections}
+	if p.budget > 0 {
+		// The legend is built once the items are trimmed, its full length is reserved for it
+		rendered.omitted = p.applyBudget(sections, builder.Len()+len(aliases.legend())+len("\n"))
+	}
+	builder.WriteString(aliases.legend())
 	builder.WriteString("\n")
+	rendered.header = builder.String()
+	return rendered, nil
+}
 
-	if len(fileInfo.Imports) > 0 {
-		builder.WriteString("Imports:\n")
-		for _, imp := range fileInfo.Imports {
-			builder.WriteString(fmt.Sprintf("- %s\n", imp))
+// String renders the section with its title and group headers, skipping omitted items; a section without
+// items renders as "".
+func (s *composedSection) String() string {
+	var builder strings.Builder
+	group := ""
+	for _, item := range s.items {
+		if item.omitted {
+			continue
+		}
+		if builder.Len() == 0 {
+			builder.WriteString(s.title + ":\n")
+		}
+		if item.group != group {
+			builder.WriteString(item.group)
+			group = item.group
 		}
+		builder.WriteString(item.text)
+	}
+	if builder.Len() > 0 && s.blankAfter {
 		builder.WriteString("\n")
 	}
+	return builder.String()
+}
 
-	if len(fileInfo.Functions) > 0 {
-		builder.WriteString("Functions:\n")
-		for _, fn := range fileInfo.Functions {
-			p.FormatFunction(&builder, fn, "  ")
-		}
-		builder.WriteString("\n")
+// buildSections renders every section of the file into separate items.
+func (p *ProjectComposer) buildSections(filePath string, fileInfo *ourtypes.FileInfo) []*composedSection {
+	diagnostics := &composedSection{title: "Errors", blankAfter: true, fixed: true}
+	for _, d := range fileInfo.Diagnostics {
+		diagnostics.items = append(diagnostics.items, &composedItem{text: formatDiagnostic(d), priority: priorityDiagnostic})
 	}
 
-	if len(fileInfo.GlobalVars) > 0 {
-		builder.WriteString("Global Variables/Constants:\n")
-		for _, gv := range fileInfo.GlobalVars {
-			p.FormatGlobalVar(&builder, gv, "  ")
-		}
-		builder.WriteString("\n")
+	deadExports := &composedSection{title: "Unused Exports", blankAfter: true, fixed: true}
+	for _, e := range p.fileDeadExports(filePath) {
+		deadExports.items = append(deadExports.items, &composedItem{text: formatDeadExport(e), priority: priorityDiagnostic})
 	}
 
-	if len(fileInfo.Structs) > 0 {
-		builder.WriteString("Local Structs:\n")
-		for _, s := range fileInfo.Structs {
-			p.FormatStruct(&builder, s, "  ")
-		}
+	imports := &composedSection{title: "Imports", blankAfter: true, fixed: true}
+	for _, imp := range fileInfo.Imports {
+		imports.items = append(imports.items, p.newItem(imp.Path, fmt.Sprintf("- %s\n", imp), priorityLocal))
 	}
 
-	if len(fileInfo.Interfaces) > 0 {
-		builder.WriteString("Local Interfaces:\n")
-		for _, iface := range fileInfo.Interfaces {
-			p.FormatInterface(&builder, iface, "  ")
+	dependencies := &composedSection{title: "Dependencies", blankAfter: true, fixed: true}
+	for _, dep := range p.fileDependencies(fileInfo) {
+		line := fmt.Sprintf("- %s %s", dep.Path, dep.Version)
+		if dep.Replacement != "" {
+			line += " => " + dep.Replacement
 		}
+		dependencies.items = append(dependencies.items, p.newItem(dep.Path, line+"\n", priorityUsed))
 	}
 
-	if len(fileInfo.UsedImportedStructs) > 0 || len(fileInfo.UsedImportedFunctions) > 0 || len(fileInfo.UsedImportedGlobalVars) > 0 {
-		builder.WriteString("Used Items From Other Packages:\n")
-		// Create maps to look up all local structs, interfaces, and functions by their fully qualified names
-		projectStructsMap := make(map[string]*ourtypes.StructInfo)
-		projectInterfacesMap := make(map[string]*ourtypes.InterfaceInfo)
-		projectFunctionsMap := make(map[string]*ourtypes.FunctionInfo)
-		for _, info := range p.projectInfo {
-			for _, s := range info.Structs {
-				projectStructsMap[s.Name] = s
-			}
-			for _, i := range info.Interfaces {
-				projectInterfacesMap[i.Name] = i
-			}
-			for _, f := range info.Functions {
-				projectFunctionsMap[f.Name] = f
-			}
+	entryPoints := &composedSection{title: "Entry Points", blankAfter: true}
+	for _, e := range fileInfo.EntryPoints {
+		entryPoints.items = append(entryPoints.items, p.renderItem(e.Name, priorityLocal, func(b *strings.Builder) {
+			p.FormatEntryPoint(b, e, "  ")
+		}))
+	}
+
+	directives := &composedSection{title: "Directives", blankAfter: true}
+	for _, d := range fileInfo.Directives {
+		directives.items = append(directives.items, p.renderItem(d.Name, priorityLocal, func(b *strings.Builder) {
+			p.FormatDirective(b, d, "")
+		}))
+	}
+
+	functions := &composedSection{title: "Functions", blankAfter: true}
+	for _, fn := range fileInfo.Functions {
+		functions.items = append(functions.items, p.renderSymbol(fn.Name, fn.ID, priorityLocal, func(b *strings.Builder) {
+			p.FormatFunction(b, fn, "  ")
+		}))
+	}
+
+	methods := &composedSection{title: "Methods", blankAfter: true}
+	for _, m := range fileInfo.Methods {
+		methods.items = append(methods.items, p.renderSymbol(m.Name, m.ID, priorityLocal, func(b *strings.Builder) {
+			p.FormatFunction(b, m, "  ")
+		}))
+	}
+
+	globals := &composedSection{title: "Global Variables/Constants", blankAfter: true}
+	for _, gv := range fileInfo.GlobalVars {
+		globals.items = append(globals.items, p.renderSymbol(gv.Name, gv.ID, priorityLocal, func(b *strings.Builder) {
+			p.FormatGlobalVar(b, gv, "  ")
+		}))
+	}
+
+	enums := &composedSection{title: "Enums", blankAfter: true}
+	for _, g := range fileInfo.ConstGroups {
+		enums.items = append(enums.items, p.renderItem(g.Type, priorityLocal, func(b *strings.Builder) {
+			p.FormatConstGroup(b, g, "  ")
+		}))
+	}
+
+	structs := &composedSection{title: "Local Structs"}
+	for _, s := range fileInfo.Structs {
+		structs.items = append(structs.items, p.renderSymbol(s.Name, s.ID, priorityLocal, func(b *strings.Builder) {
+			p.FormatStruct(b, s, "  ")
+		}))
+	}
+
+	interfaces := &composedSection{title: "Local Interfaces"}
+	for _, iface := range fileInfo.Interfaces {
+		interfaces.items = append(interfaces.items, p.renderSymbol(iface.Name, iface.ID, priorityLocal, func(b *strings.Builder) {
+			p.FormatInterface(b, iface, "  ")
+		}))
+	}
+
+	database := &composedSection{title: "Database Surface", blankAfter: true}
+	database.items = p.buildDatabaseItems(fileInfo)
+
+	packageErrors := &composedSection{title: "Package Errors", blankAfter: true}
+	for _, e := range p.packageErrorValues(filePath) {
+		packageErrors.items = append(packageErrors.items, p.renderSymbol(e.Name, e.ID, priorityLocal, func(b *strings.Builder) {
+			p.FormatErrorInfo(b, e, "  ")
+		}))
+	}
+
+	inline := &composedSection{title: "Inline Types"}
+	for _, t := range fileInfo.InlineTypes {
+		inline.items = append(inline.items, p.renderItem(t.Name, priorityLocal, func(b *strings.Builder) {
+			p.FormatInlineType(b, t, "  ")
+		}))
+	}
+
+	custom := p.buildCustomSections(fileInfo)
+
+	used := &composedSection{title: "Used Items From Other Packages"}
+	used.items = p.buildUsedItems(fileInfo)
+
+	usedGlobals := &composedSection{title: "Used Global Variables/Constants From Other Packages"}
+	for _, gv := range fileInfo.UsedImportedGlobalVars {
+		usedGlobals.items = append(usedGlobals.items, p.renderSymbol(gv.Name, gv.ID, priorityUsed, func(b *strings.Builder) {
+			p.FormatGlobalVar(b, gv, "  ")
+		}))
+	}
+
+	sections := []*composedSection{diagnostics, deadExports, imports, dependencies, entryPoints, directives, functions, methods, globals, enums, structs, interfaces, packageErrors, database, inline}
+	sections = append(sections, custom...)
+	return append(sections, used, usedGlobals)
+}
+
+// buildCustomSections renders the items found by custom extractors into one section per CustomItem.Section,
+// in the order the sections first appear.
+func (p *ProjectComposer) buildCustomSections(fileInfo *ourtypes.FileInfo) []*composedSection {
+	var sections []*composedSection
+	byTitle := make(map[string]*composedSection)
+	for _, item := range fileInfo.CustomItems {
+		title := item.Section
+		if title == "" {
+			title = "Custom Items"
 		}
+		section, ok := byTitle[title]
+		if !ok {
+			section = &composedSection{title: title}
+			byTitle[title] = section
+			sections = append(sections, section)
+		}
+		section.items = append(section.items, p.renderItem(item.Name, priorityLocal, func(b *strings.Builder) {
+			p.FormatCustomItem(b, item, "  ")
+		}))
+	}
+	return sections
+}
 
-		processedItems := make(map[string]bool)
+// buildUsedItems renders the types and functions the file uses from other packages, resolving them against the
+// project, grouped by the package declaring them.
+func (p *ProjectComposer) buildUsedItems(fileInfo *ourtypes.FileInfo) []*composedItem {
+	projectStructsMap, projectInterfacesMap, projectFunctionsMap := p.projectIndex()
+	externalStructsMap, externalInterfacesMap := externalIndex(fileInfo)
 
-		for _, s := range fileInfo.UsedImportedStructs {
-			if processedItems[s.Name] {
-				continue
-			}
+	var items []*composedItem
+	var pkgPaths []string
+	add := func(name, id string, format func(*strings.Builder)) {
+		items = append(items, p.renderSymbol(name, id, priorityUsed, format))
+		if id == "" {
+			id = name
+		}
+		pkgPaths = append(pkgPaths, packageOf(id, fileInfo.Imports))
+	}
+
+	for _, s := range fileInfo.UsedImportedStructs {
+		add(s.Name, s.ID, func(b *strings.Builder) {
 			if detailedStruct, ok := projectStructsMap[s.Name]; ok {
-				p.FormatStruct(&builder, detailedStruct, "  ")
-				processedItems[s.Name] = true
+				p.formatUsedStruct(b, detailedStruct, s.UsedFields, usedIndent)
 			} else if detailedIface, ok := projectInterfacesMap[s.Name]; ok {
-				p.FormatInterface(&builder, detailedIface, "  ")
-				processedItems[s.Name] = true
+				p.FormatInterface(b, detailedIface, usedIndent)
 			} else if detailedFunc, ok := projectFunctionsMap[s.Name]; ok {
-				p.FormatFunction(&builder, detailedFunc, "  ")
-				processedItems[s.Name] = true
+				p.FormatFunction(b, detailedFunc, usedIndent)
+			} else if externalStruct, ok := externalStructsMap[s.Name]; ok {
+				p.formatUsedStruct(b, externalStruct, s.UsedFields, usedIndent)
+			} else if externalIface, ok := externalInterfacesMap[s.Name]; ok {
+				p.FormatInterface(b, externalIface, usedIndent)
 			} else {
-				builder.WriteString(fmt.Sprintf("- %s\n", s.Name))
-				processedItems[s.Name] = true
+				b.WriteString(fmt.Sprintf(usedIndent+"- %s\n", s.Name))
 			}
+		})
+	}
+	for _, i := range fileInfo.UsedImportedInterfaces {
+		add(i.Name, i.ID, func(b *strings.Builder) {
+			if detailedIface, ok := projectInterfacesMap[i.Name]; ok {
+				p.FormatInterface(b, detailedIface, usedIndent)
+			} else if externalIface, ok := externalInterfacesMap[i.Name]; ok {
+				p.FormatInterface(b, externalIface, usedIndent)
+			} else {
+				b.WriteString(fmt.Sprintf(usedIndent+"- %s\n", i.Name))
+			}
+		})
+	}
+	for _, f := range fileInfo.UsedImportedFunctions {
+		add(f.Name, f.ID, func(b *strings.Builder) {
+			p.FormatFunction(b, f, usedIndent)
+		})
+	}
+	return p.groupUsedItems(items, pkgPaths, fileInfo.Imports)
+}
+
+// lookupFile returns the file info of filePath and its path in the project info, which may differ by
+// unclean elements or, on case-insensitive file systems, by case.
+func (p *ProjectComposer) lookupFile(filePath string) (string, *ourtypes.FileInfo, bool) {
+	if fileInfo, ok := p.projectInfo[filePath]; ok {
+		return filePath, fileInfo, true
+	}
+	clean := filepath.Clean(filePath)
+	if fileInfo, ok := p.projectInfo[clean]; ok {
+		return clean, fileInfo, true
+	}
+	for path, fileInfo := range p.projectInfo {
+		if parser.SamePath(path, clean) {
+			return path, fileInfo, true
+		}
+	}
+	return filePath, nil, false
+}
+
+// packageErrorValues returns the sentinel errors and error types of the package of filePath, i.e. of the files
+// in the same directory with the same package name, ordered by file.
+func (p *ProjectComposer) packageErrorValues(filePath string) []*ourtypes.ErrorInfo {
+	fileInfo, ok := p.projectInfo[filePath]
+	if !ok {
+		return nil
+	}
+	paths := make([]string, 0)
+	for path, info := range p.projectInfo {
+		if filepath.Dir(path) == filepath.Dir(filePath) && info.PackageName == fileInfo.PackageName {
+			paths = append(paths, path)
+		}
+	}
+	sort.Strings(paths)
+
+	var errorValues []*ourtypes.ErrorInfo
+	for _, path := range paths {
+		errorValues = append(errorValues, p.projectInfo[path].ErrorValues...)
+	}
+	return errorValues
+}
+
+// fileDependencies returns the required modules providing the file's imports, in import order.
+func (p *ProjectComposer) fileDependencies(fileInfo *ourtypes.FileInfo) []*ComposedDependency {
+	if p.moduleInfo == nil {
+		return nil
+	}
+	var deps []*ComposedDependency
+	seen := make(map[string]bool)
+	for _, imp := range fileInfo.Imports {
+		r := p.moduleInfo.RequirementFor(imp.Path)
+		if r == nil || seen[r.Path] {
+			continue
+		}
+		seen[r.Path] = true
+		dep := &ComposedDependency{Path: r.Path, Version: r.Version}
+		if rep := p.moduleInfo.ReplaceFor(r); rep != nil {
+			dep.Replacement = strings.TrimSpace(rep.NewPath + " " + rep.NewVersion)
 		}
-		for _, f := range fileInfo.UsedImportedFunctions {
-			if processedItems[f.Name] {
-				continue
+		deps = append(deps, dep)
+	}
+	return deps
+}
+
+// projectIndex creates maps to look up all local structs, interfaces, and functions by their fully qualified names.
+func (p *ProjectComposer) projectIndex() (map[string]*ourtypes.StructInfo, map[string]*ourtypes.InterfaceInfo, map[string]*ourtypes.FunctionInfo) {
+	projectStructsMap := make(map[string]*ourtypes.StructInfo)
+	projectInterfacesMap := make(map[string]*ourtypes.InterfaceInfo)
+	projectFunctionsMap := make(map[string]*ourtypes.FunctionInfo)
+	for _, info := range p.projectInfo {
+		for _, s := range info.Structs {
+			projectStructsMap[s.Name] = s
+		}
+		for _, i := range info.Interfaces {
+			projectInterfacesMap[i.Name] = i
+		}
+		for _, f := range info.Functions {
+			projectFunctionsMap[f.Name] = f
+		}
+	}
+	return projectStructsMap, projectInterfacesMap, projectFunctionsMap
+}
+
+// firstParagraph returns the first paragraph of a doc comment on a single line
+func firstParagraph(doc string) string {
+	paragraph, _, _ := strings.Cut(strings.TrimSpace(doc), "\n\n")
+	return strings.Join(strings.Fields(paragraph), " ")
+}
+
+// formatDiagnostic renders a compiler error of the file as "- line:column: message (kind)".
+func formatDiagnostic(d *ourtypes.Diagnostic) string {
+	switch {
+	case d.Pos == nil:
+		return fmt.Sprintf("- %s (%s)\n", d.Message, d.Kind)
+	case d.Pos.Column == 0:
+		return fmt.Sprintf("- %d: %s (%s)\n", d.Pos.Line, d.Message, d.Kind)
+	default:
+		return fmt.Sprintf("- %d:%d: %s (%s)\n", d.Pos.Line, d.Pos.Column, d.Message, d.Kind)
+	}
+}
+
+// formatPosition writes the position line of a declaration if positions are enabled and known.
+func (p *ProjectComposer) formatPosition(builder *strings.Builder, pos *ourtypes.Position, indent string) {
+	if p.positions && pos != nil {
+		builder.WriteString(fmt.Sprintf("%s  Position: %s\n", indent, pos))
+	}
+}
+
+// externalIndex creates maps to look up the file's types declared outside the project by their fully qualified names.
+func externalIndex(fileInfo *ourtypes.FileInfo) (map[string]*ourtypes.StructInfo, map[string]*ourtypes.InterfaceInfo) {
+	externalStructsMap := make(map[string]*ourtypes.StructInfo, len(fileInfo.ExternalStructs))
+	for _, s := range fileInfo.ExternalStructs {
+		externalStructsMap[s.Name] = s
+	}
+	externalInterfacesMap := make(map[string]*ourtypes.InterfaceInfo, len(fileInfo.ExternalInterfaces))
+	for _, i := range fileInfo.ExternalInterfaces {
+		externalInterfacesMap[i.Name] = i
+	}
+	return externalStructsMap, externalInterfacesMap
+}
+
+// renderItem renders an item with the given formatting function.
+func (p *ProjectComposer) renderItem(name string, priority int, format func(*strings.Builder)) *composedItem {
+	var b strings.Builder
+	format(&b)
+	return p.newItem(name, b.String(), priority)
+}
+
+// renderSymbol renders the item of a declared symbol, which also matches the focus symbol by its ID, e.g.
+// "example.com/app/store.Config", so that symbols of the same name in different packages can be told apart.
+func (p *ProjectComposer) renderSymbol(name, id string, priority int, format func(*strings.Builder)) *composedItem {
+	item := p.renderItem(name, priority, format)
+	if id != "" && id == p.focusSymbol {
+		item.priority = priorityFocus
+	}
+	return item
+}
+
+// newItem creates an item, raising its priority if it matches the focus symbol.
+func (p *ProjectComposer) newItem(name, text string, priority int) *composedItem {
+	if p.matchesFocus(name) {
+		priority = priorityFocus
+	}
+	return &composedItem{name: name, text: text, priority: priority}
+}
+
+// matchesFocus reports whether name refers to the focus symbol.
+func (p *ProjectComposer) matchesFocus(name string) bool {
+	if p.focusSymbol == "" {
+		return false
+	}
+	return name == p.focusSymbol || strings.HasSuffix(name, "."+p.focusSymbol) || strings.HasSuffix(name, "/"+p.focusSymbol)
+}
+
+// withFocusSection moves the focus items and the items they refer to into a Focus section placed before the
+// declarations.
+// An item is referred to if its name, qualified by the package name for items of other packages, appears in
+// the text of a focus item. The sections are returned unchanged if no item matches the focus symbol.
+func withFocusSection(sections []*composedSection) []*composedSection {
+	var focusText strings.Builder
+	for _, section := range sections {
+		for _, item := range section.items {
+			if item.priority == priorityFocus {
+				focusText.WriteString(item.text)
 			}
-			p.FormatFunction(&builder, f, "  ")
-			processedItems[f.Name] = true
 		}
-		for _, gv := range fileInfo.UsedImportedGlobalVars {
-			if processedItems[gv.Name] {
-				continue
+	}
+	if focusText.Len() == 0 {
+		return sections
+	}
+
+	focus := &composedSection{title: "Focus", blankAfter: true}
+	var related []*composedItem
+	for _, section := range sections {
+		if section.fixed {
+			continue
+		}
+		kept := section.items[:0]
+		for _, item := range section.items {
+			switch {
+			case item.priority == priorityFocus:
+				focus.items = append(focus.items, item)
+			case refersTo(focusText.String(), item.name):
+				item.priority = priorityRelated
+				related = append(related, item)
+			default:
+				kept = append(kept, item)
 			}
-			p.FormatGlobalVar(&builder, gv, "  ")
-			processedItems[gv.Name] = true
 		}
+		section.items = kept
 	}
+	focus.items = groupItems(append(focus.items, related...))
 
-	return builder.String(), nil
+	// The Focus section goes right after the fixed sections.
+	at := 0
+	for at < len(sections) && sections[at].fixed {
+		at++
+	}
+	result := make([]*composedSection, 0, len(sections)+1)
+	result = append(result, sections[:at]...)
+	result = append(result, focus)
+	return append(result, sections[at:]...)
+}
+
+// refersTo reports whether text mentions name as a whole word. Fully qualified names are matched by
+// their last path element, e.g. models.User for example.com/project/models.User, as they appear in signatures.
+func refersTo(text, name string) bool {
+	if i := strings.LastIndex(name, "/"); i >= 0 {
+		name = name[i+1:]
+	}
+	if name == "" {
+		return false
+	}
+	for start := 0; ; {
+		i := strings.Index(text[start:], name)
+		if i < 0 {
+			return false
+		}
+		i += start
+		end := i + len(name)
+		if (i == 0 || !isIdentByte(text[i-1])) && (end == len(text) || !isIdentByte(text[end])) {
+			return true
+		}
+		start = i + 1
+	}
+}
+
+// isIdentByte reports whether c can be part of a Go identifier or qualified name.
+func isIdentByte(c byte) bool {
+	return c == '_' || c == '.' || c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= 0x80
+}
+
+// applyBudget marks the least relevant items as omitted until the output fits into the budget.
+// It returns the number of omitted items.
+func (p *ProjectComposer) applyBudget(sections []*composedSection, used int) int {
+	type candidate struct {
+		item    *composedItem
+		section *composedSection
+	}
+	// Groups are contiguous within a section, so their headers are written once per section
+	type sectionGroup struct {
+		section *composedSection
+		group   string
+	}
+
+	total := used
+	var candidates []candidate
+	for _, section := range sections {
+		if len(section.items) > 0 {
+			total += section.titleCost()
+		}
+		group := ""
+		for _, item := range section.items {
+			total += len(item.text)
+			if item.group != group {
+				total += len(item.group)
+				group = item.group
+			}
+			candidates = append(candidates, candidate{item: item, section: section})
+		}
+	}
+	if total <= p.budget {
+		return 0
+	}
+
+	// Reserve room for the marker, assuming the worst case of every item being omitted
+	remaining := p.budget - used - len(omittedMarker(len(candidates)))
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].item.priority < candidates[j].item.priority
+	})
+
+	shownSections := make(map[*composedSection]bool)
+	shownGroups := make(map[sectionGroup]bool)
+	omitted := 0
+	for _, c := range candidates {
+		cost := len(c.item.text)
+		if !shownSections[c.section] {
+			cost += c.section.titleCost()
+		}
+		group := sectionGroup{section: c.section, group: c.item.group}
+		if !shownGroups[group] {
+			cost += len(c.item.group)
+		}
+		if cost > remaining {
+			c.item.omitted = true
+			omitted++
+			continue
+		}
+		remaining -= cost
+		shownSections[c.section] = true
+		shownGroups[group] = true
+	}
+	return omitted
+}
+
+// titleCost returns the number of characters the section adds besides its items.
+func (s *composedSection) titleCost() int {
+	cost := len(s.title) + len(":\n")
+	if s.blankAfter {
+		cost++
+	}
+	return cost
+}
+
+// omittedMarker returns the line appended when items were dropped to fit the budget.
+func omittedMarker(n int) string {
+	return fmt.Sprintf("...%d items omitted\n", n)
 }
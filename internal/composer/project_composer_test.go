@@ -5,7 +5,9 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/vlad/ast2llm-go/internal/composer"
+	"github.com/vlad/ast2llm-go/internal/modinfo"
 	"github.com/vlad/ast2llm-go/internal/parser"
 	"github.com/vlad/ast2llm-go/internal/types"
 )
@@ -55,7 +57,7 @@ func TestProjectComposer_Compose_UnresolvedImport(t *testing.T) {
 	assert.NoError(t, err)
 
 	assert.Contains(t, output, "Used Items From Other Packages:")
-	assert.Contains(t, output, "- github.com/some/external/pkg.SomeType")
+	assert.Contains(t, output, "  Package pkg (github.com/some/external/pkg)\n    - pkg.SomeType\n")
 }
 
 func TestProjectComposer_Compose_DeduplicatesUsedItems(t *testing.T) {
@@ -87,6 +89,335 @@ func TestProjectComposer_Compose_DeduplicatesUsedItems(t *testing.T) {
 	assert.NoError(t, err)
 
 	// The function should only be listed once.
-	count := strings.Count(output, "Function: example.com/project/other.MyFunction")
+	count := strings.Count(output, "Function: other.MyFunction")
 	assert.Equal(t, 1, count, "The same used item should not be printed multiple times")
 }
+
+func TestProjectComposer_Compose_ImportAliases(t *testing.T) {
+	filePath := "/project/main.go"
+	projectInfo := parser.ProjectInfo{
+		filePath: {
+			PackageName: "main",
+			Imports: []*types.ImportInfo{
+				{Path: "context", Alias: "myctx"},
+				{Path: "embed", IsBlank: true},
+				{Path: "fmt"},
+				{Path: "math", IsDot: true},
+			},
+		},
+	}
+
+	output, err := composer.New(projectInfo).Compose(filePath)
+	require.NoError(t, err)
+	assert.Contains(t, output, "Imports:\n- context (as myctx)\n- embed (blank)\n- fmt\n- math (dot)\n")
+}
+
+func TestProjectComposer_Compose_Budget(t *testing.T) {
+	filePath := "/project/main.go"
+	projectInfo := parser.ProjectInfo{
+		filePath: {
+			PackageName: "main",
+			Imports:     []*types.ImportInfo{{Path: "fmt"}, {Path: "example.com/project/other"}},
+			Functions: []*types.FunctionInfo{
+				{Name: "main"},
+				{Name: "helper", Comment: strings.Repeat("long comment ", 20), Params: []string{"s string"}},
+			},
+			UsedImportedFunctions: []*types.FunctionInfo{
+				{Name: "example.com/project/other.Do", Params: []string{"n int"}, Returns: []string{"error"}},
+			},
+		},
+	}
+
+	unbounded, err := composer.New(projectInfo).Compose(filePath)
+	assert.NoError(t, err)
+
+	t.Run("fits into budget", func(t *testing.T) {
+		output, err := composer.New(projectInfo, composer.WithBudget(len(unbounded))).Compose(filePath)
+		assert.NoError(t, err)
+		assert.Equal(t, unbounded, output)
+	})
+
+	t.Run("drops local items before used ones", func(t *testing.T) {
+		budget := 200
+		output, err := composer.New(projectInfo, composer.WithBudget(budget)).Compose(filePath)
+		assert.NoError(t, err)
+		assert.LessOrEqual(t, len(output), budget)
+		assert.Contains(t, output, "Function: other.Do")
+		assert.NotContains(t, output, "Function: helper")
+		assert.Regexp(t, `\.\.\.\d+ items omitted\n$`, output)
+	})
+
+	t.Run("keeps focus symbol first", func(t *testing.T) {
+		budget := 400
+		output, err := composer.New(projectInfo, composer.WithBudget(budget), composer.WithFocusSymbol("helper")).Compose(filePath)
+		assert.NoError(t, err)
+		assert.LessOrEqual(t, len(output), budget)
+		assert.Contains(t, output, "Function: helper")
+		assert.Contains(t, output, "items omitted")
+	})
+}
+
+func TestProjectComposer_Compose_FocusSection(t *testing.T) {
+	filePath := "/project/main.go"
+	projectInfo := parser.ProjectInfo{
+		filePath: {
+			PackageName: "main",
+			Imports:     []*types.ImportInfo{{Path: "example.com/project/models"}},
+			Functions: []*types.FunctionInfo{
+				{Name: "main"},
+				{Name: "save", Params: []string{"u *models.User", "c Config"}, Returns: []string{"error"}},
+			},
+			Structs: []*types.StructInfo{
+				{Name: "Config"},
+				{Name: "Other"},
+			},
+			UsedImportedStructs: []*types.StructInfo{
+				{Name: "example.com/project/models.User"},
+				{Name: "example.com/project/models.Group"},
+			},
+		},
+	}
+
+	output, err := composer.New(projectInfo, composer.WithFocusSymbol("save")).Compose(filePath)
+	assert.NoError(t, err)
+
+	focusStart := strings.Index(output, "Focus:\n")
+	require.GreaterOrEqual(t, focusStart, 0, output)
+	focus := output[focusStart:strings.Index(output, "Functions:\n")]
+	assert.True(t, strings.HasPrefix(focus, "Focus:\n  Function: save\n"), focus)
+	assert.Contains(t, focus, "Struct: Config")
+	assert.Contains(t, focus, "  Package models (example.com/project/models)\n    - models.User\n")
+	assert.NotContains(t, focus, "Other")
+	assert.NotContains(t, focus, "Group")
+
+	// Focus items are moved, not repeated, and the imports stay in place.
+	assert.Equal(t, 1, strings.Count(output, "Function: save"))
+	assert.Equal(t, 1, strings.Count(output, "Struct: Config"))
+	assert.Less(t, strings.Index(output, "Imports:\n"), focusStart)
+
+	t.Run("unknown focus symbol", func(t *testing.T) {
+		output, err := composer.New(projectInfo, composer.WithFocusSymbol("missing")).Compose(filePath)
+		assert.NoError(t, err)
+		unfocused, err := composer.New(projectInfo).Compose(filePath)
+		assert.NoError(t, err)
+		assert.Equal(t, unfocused, output)
+	})
+
+	t.Run("focus by ID", func(t *testing.T) {
+		projectInfo := parser.ProjectInfo{
+			filePath: {
+				PackageName: "main",
+				Functions:   []*types.FunctionInfo{{Name: "main"}},
+				Structs:     []*types.StructInfo{{Name: "Config", ID: "example.com/project.Config"}},
+				UsedImportedStructs: []*types.StructInfo{
+					{Name: "models.Config", ID: "example.com/project/models.Config"},
+				},
+			},
+		}
+		output, err := composer.New(projectInfo, composer.WithFocusSymbol("example.com/project/models.Config")).Compose(filePath)
+		assert.NoError(t, err)
+		focusStart := strings.Index(output, "Focus:\n")
+		require.GreaterOrEqual(t, focusStart, 0, output)
+		focus := output[focusStart:strings.Index(output, "Functions:\n")]
+		assert.Contains(t, focus, "models.Config")
+		assert.NotContains(t, focus, "Struct: Config", "the struct of the same name in another package is not focused")
+	})
+}
+
+func TestProjectComposer_Compose_Diagnostics(t *testing.T) {
+	filePath := "/project/main.go"
+	projectInfo := parser.ProjectInfo{
+		filePath: {
+			PackageName: "main",
+			Imports:     []*types.ImportInfo{{Path: "fmt"}},
+			Functions:   []*types.FunctionInfo{{Name: "main", Comment: strings.Repeat("long comment ", 20)}},
+			Diagnostics: []*types.Diagnostic{
+				{Kind: "type", Message: "undefined: foo", Pos: &types.Position{File: filePath, Line: 4, Column: 2}},
+				{Kind: "syntax", Message: "expected ')'", Pos: &types.Position{File: filePath, Line: 7}},
+			},
+		},
+	}
+
+	output, err := composer.New(projectInfo).Compose(filePath)
+	assert.NoError(t, err)
+	assert.Contains(t, output, "Package: main\n\nErrors:\n- 4:2: undefined: foo (type)\n- 7: expected ')' (syntax)\n\nImports:\n")
+
+	// Errors are kept when the budget is tight.
+	output, err = composer.New(projectInfo, composer.WithBudget(150)).Compose(filePath)
+	assert.NoError(t, err)
+	assert.Contains(t, output, "- 4:2: undefined: foo (type)\n")
+	assert.NotContains(t, output, "Function: main")
+}
+
+func TestProjectComposer_Compose_Dependencies(t *testing.T) {
+	filePath := "/project/main.go"
+	projectInfo := parser.ProjectInfo{
+		filePath: {
+			PackageName: "main",
+			Imports: []*types.ImportInfo{
+				{Path: "fmt"},
+				{Path: "github.com/stretchr/testify/assert"},
+				{Path: "github.com/stretchr/testify/require"},
+				{Path: "golang.org/x/tools/go/packages"},
+				{Path: "example.com/project/internal/x"},
+			},
+		},
+	}
+	moduleInfo, err := modinfo.Parse("go.mod", []byte(`module example.com/project
+
+require (
+	github.com/stretchr/testify v1.10.0
+	golang.org/x/tools v0.34.0
+)
+
+replace golang.org/x/tools => ../tools
+`))
+	assert.NoError(t, err)
+
+	output, err := composer.New(projectInfo, composer.WithModuleInfo(moduleInfo)).Compose(filePath)
+	assert.NoError(t, err)
+	assert.Contains(t, output, "Dependencies:\n- github.com/stretchr/testify v1.10.0\n- golang.org/x/tools v0.34.0 => ../tools\n\n")
+
+	output, err = composer.New(projectInfo).Compose(filePath)
+	assert.NoError(t, err)
+	assert.NotContains(t, output, "Dependencies:")
+}
+
+func TestProjectComposer_Compose_ExternalTypes(t *testing.T) {
+	filePath := "/project/main.go"
+	projectInfo := parser.ProjectInfo{
+		filePath: {
+			PackageName: "main",
+			UsedImportedStructs: []*types.StructInfo{
+				{Name: "context.Context"},
+				{Name: "strings.Builder"},
+				{Name: "time.Duration"},
+			},
+			ExternalStructs: []*types.StructInfo{
+				{
+					Name: "strings.Builder",
+					Methods: []*types.StructMethod{
+						{Name: "Len", ReturnTypes: []string{"int"}, ReceiverIsPointer: true},
+					},
+				},
+			},
+			ExternalInterfaces: []*types.InterfaceInfo{
+				{
+					Name: "context.Context",
+					Methods: []*types.InterfaceMethod{
+						{Name: "Err", ReturnTypes: []string{"error"}},
+					},
+				},
+			},
+		},
+	}
+
+	output, err := composer.New(projectInfo).Compose(filePath)
+	assert.NoError(t, err)
+	assert.Contains(t, output, "  Package context\n    Interface: context.Context\n")
+	assert.Contains(t, output, "  Package strings\n    Struct: strings.Builder\n      Methods:\n        - (*Builder) Len() (int)\n")
+	assert.Contains(t, output, "  Package time\n    - time.Duration\n")
+}
+
+func TestProjectComposer_Compose_Generated(t *testing.T) {
+	filePath := "/project/api.pb.go"
+	projectInfo := parser.ProjectInfo{
+		filePath: {PackageName: "api", Generated: true},
+	}
+
+	output, err := composer.New(projectInfo).Compose(filePath)
+	assert.NoError(t, err)
+	assert.Equal(t, "--- File: /project/api.pb.go ---\nPackage: api\nGenerated: yes\n\n", output)
+}
+
+func TestProjectComposer_Compose_CgoAndAssembly(t *testing.T) {
+	projectInfo := parser.ProjectInfo{
+		"/project/native/add.go": {PackageName: "native", HasCgo: true},
+		"/project/asm/sum.go":    {PackageName: "asm", HasAsm: true},
+	}
+	c := composer.New(projectInfo)
+
+	output, err := c.Compose("/project/native/add.go")
+	assert.NoError(t, err)
+	assert.Equal(t, "--- File: /project/native/add.go ---\nPackage: native\nCgo: yes\n\n", output)
+
+	output, err = c.Compose("/project/asm/sum.go")
+	assert.NoError(t, err)
+	assert.Equal(t, "--- File: /project/asm/sum.go ---\nPackage: asm\nAssembly: yes\n\n", output)
+}
+
+func TestProjectComposer_Compose_PackageDoc(t *testing.T) {
+	filePath := "/project/store/store.go"
+	projectInfo := parser.ProjectInfo{
+		filePath: {PackageName: "store", PackageDoc: "Package store persists\nitems on disk.\n\nDetails follow."},
+	}
+
+	output, err := composer.New(projectInfo).Compose(filePath)
+	assert.NoError(t, err)
+	assert.Equal(t, "--- File: /project/store/store.go ---\nPackage: store\nPackage Doc: Package store persists items on disk.\n\n", output)
+
+	output, err = composer.New(projectInfo, composer.WithVerbosity(composer.VerbositySignatures)).Compose(filePath)
+	assert.NoError(t, err)
+	assert.NotContains(t, output, "Package Doc")
+}
+
+func TestProjectComposer_Compose_UsedImportedInterfaces(t *testing.T) {
+	filePath := "/project/main.go"
+	projectInfo := parser.ProjectInfo{
+		filePath: {
+			PackageName: "main",
+			UsedImportedInterfaces: []*types.InterfaceInfo{
+				{Name: "example.com/project/store.Store"},
+				{Name: "io.Reader"},
+			},
+		},
+		"/project/store/store.go": {
+			PackageName: "store",
+			Interfaces: []*types.InterfaceInfo{
+				{
+					Name:      "example.com/project/store.Store",
+					Comment:   "Store persists items.",
+					Methods:   []*types.InterfaceMethod{{Name: "Save", Parameters: []string{"string"}, ReturnTypes: []string{"error"}}},
+					Embeddeds: []string{"io.Closer"},
+				},
+			},
+		},
+	}
+
+	output, err := composer.New(projectInfo).Compose(filePath)
+	assert.NoError(t, err)
+	assert.Contains(t, output, "Used Items From Other Packages:\n  Package store (example.com/project/store)\n    Interface: store.Store\n")
+	assert.Contains(t, output, "- io.Closer")
+	assert.Contains(t, output, "- io.Reader\n")
+
+	composed, err := composer.New(projectInfo).ComposeFile(filePath)
+	assert.NoError(t, err)
+	if assert.Len(t, composed.UsedInterfaces, 1) {
+		assert.Equal(t, "example.com/project/store.Store", composed.UsedInterfaces[0].Name)
+	}
+	assert.Equal(t, []string{"io.Reader"}, composed.Unresolved)
+}
+
+func TestProjectComposer_Compose_Positions(t *testing.T) {
+	filePath := "/project/main.go"
+	projectInfo := parser.ProjectInfo{
+		filePath: {
+			PackageName: "main",
+			Functions: []*types.FunctionInfo{
+				{Name: "main", Pos: &types.Position{File: filePath, Line: 5, Column: 6}},
+			},
+			GlobalVars: []*types.GlobalVarInfo{
+				{Name: "Limit", Type: "int", Value: "1", IsConst: true, Pos: &types.Position{File: filePath, Line: 3, Column: 7}},
+			},
+		},
+	}
+
+	output, err := composer.New(projectInfo).Compose(filePath)
+	assert.NoError(t, err)
+	assert.NotContains(t, output, "Position:")
+
+	output, err = composer.New(projectInfo, composer.WithPositions()).Compose(filePath)
+	assert.NoError(t, err)
+	assert.Contains(t, output, "  Function: main\n    Position: /project/main.go:5:6\n")
+	assert.Contains(t, output, "  Const: Limit int = 1\n    Position: /project/main.go:3:7\n")
+}
@@ -0,0 +1,195 @@
+package composer
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/vlad/ast2llm-go/internal/tokenize"
+)
+
+// Score weights of SearchSymbols
+const (
+	scoreExactName = 10 // The query without spaces is the symbol name, ignoring case
+	scoreNameWord  = 3  // A query word is a word of the symbol name, e.g. "user" of NewUserStore
+	scoreNamePart  = 2  // A query word is part of the symbol name, e.g. "stor" of NewUserStore
+	scoreFuzzy     = 1  // The letters of a query word appear in order in the symbol name, e.g. "nus" of NewUserStore
+	scoreComment   = 1  // A query word is a word of the symbol's comment
+)
+
+// Symbol is a symbol declared in the project
+type Symbol struct {
+	Name      string // Name of the symbol, e.g. "example.com/app/store.Memory", "store.NewMemory" or "Memory.Save"
+	Kind      string // "function", "method", "struct", "interface", "variable", "constant" or "enum"
+	FilePath  string // File declaring the symbol
+	Comment   string // Doc comment of the symbol
+	Signature string // One-line summary, e.g. "func NewMemory(size int) -> (*Memory)"
+
+	shortName string                 // Name without package or receiver, matched by SearchSymbols
+	format    func(*strings.Builder) // Renders the symbol like Compose does
+}
+
+// SymbolMatch is a project symbol matching a search query
+type SymbolMatch struct {
+	*Symbol
+	Score float64 // Relevance, higher is better
+}
+
+// Symbols lists the functions, methods, types, variables, constants and enums declared in the project, sorted by
+// file and name.
+func (p *ProjectComposer) Symbols() []*Symbol {
+	var symbols []*Symbol
+	for filePath, info := range p.projectInfo {
+		add := func(name, shortName, kind, comment, signature string, format func(*strings.Builder)) {
+			symbols = append(symbols, &Symbol{
+				Name: name, Kind: kind, FilePath: filePath, Comment: comment, Signature: signature,
+				shortName: shortName, format: format,
+			})
+		}
+		for _, fn := range info.Functions {
+			add(qualifiedIn(info.PackageName, fn.Name), unqualified(fn.Name), "function", fn.Comment, summarizeFunction(fn),
+				func(b *strings.Builder) { p.FormatFunction(b, fn, "  ") })
+		}
+		for _, m := range info.Methods {
+			receiver := strings.TrimPrefix(m.Receiver, "*")
+			add(receiver+"."+m.Name, unqualified(m.Name), "method", m.Comment, strings.Replace(summarizeFunction(m), "func ", "func ("+m.Receiver+") ", 1),
+				func(b *strings.Builder) { p.FormatFunction(b, m, "  ") })
+		}
+		for _, s := range info.Structs {
+			add(s.Name, unqualified(s.Name), "struct", s.Comment, summarizeStruct(s), func(b *strings.Builder) { p.FormatStruct(b, s, "  ") })
+		}
+		for _, i := range info.Interfaces {
+			add(i.Name, unqualified(i.Name), "interface", i.Comment, summarizeInterface(i), func(b *strings.Builder) { p.FormatInterface(b, i, "  ") })
+		}
+		for _, gv := range info.GlobalVars {
+			kind, keyword := "variable", "var"
+			if gv.IsConst {
+				kind, keyword = "constant", "const"
+			}
+			add(qualifiedIn(info.PackageName, gv.Name), unqualified(gv.Name), kind, gv.Comment, keyword+" "+unqualified(gv.Name)+" "+gv.Type,
+				func(b *strings.Builder) { p.FormatGlobalVar(b, gv, "  ") })
+		}
+		for _, g := range info.ConstGroups {
+			add(g.Type, unqualified(g.Type), "enum", g.Comment, fmt.Sprintf("enum %s (%d values)", unqualified(g.Type), len(g.Constants)),
+				func(b *strings.Builder) { p.FormatConstGroup(b, g, "  ") })
+		}
+	}
+	sort.SliceStable(symbols, func(i, j int) bool {
+		if symbols[i].FilePath != symbols[j].FilePath {
+			return symbols[i].FilePath < symbols[j].FilePath
+		}
+		return symbols[i].Name < symbols[j].Name
+	})
+	return symbols
+}
+
+// SearchSymbols ranks the symbols declared in the project by how well their names, fuzzily, and their comments
+// match the words of query, and returns the best limit ones. Symbols matching no word are left out; ties are
+// broken by name and file.
+func (p *ProjectComposer) SearchSymbols(query string, limit int) []*SymbolMatch {
+	words := tokenize.Words(query)
+	if len(words) == 0 {
+		return nil
+	}
+	compact := strings.ToLower(strings.Join(strings.Fields(query), ""))
+
+	var matches []*SymbolMatch
+	for _, s := range p.Symbols() {
+		if score := symbolScore(words, compact, s.shortName, s.Comment); score > 0 {
+			matches = append(matches, &SymbolMatch{Symbol: s, Score: float64(score)})
+		}
+	}
+	SortMatches(matches)
+	if limit > 0 && len(matches) > limit {
+		matches = matches[:limit]
+	}
+	return matches
+}
+
+// SortMatches sorts matches by descending score, then by name and file.
+func SortMatches(matches []*SymbolMatch) {
+	sort.Slice(matches, func(i, j int) bool {
+		a, b := matches[i], matches[j]
+		if a.Score != b.Score {
+			return a.Score > b.Score
+		}
+		if a.Name != b.Name {
+			return a.Name < b.Name
+		}
+		return a.FilePath < b.FilePath
+	})
+}
+
+// ComposeSearch renders the best limit matches of query, see SearchSymbols, each under a numbered header with
+// its kind, score and file.
+func (p *ProjectComposer) ComposeSearch(query string, limit int) string {
+	matches := p.SearchSymbols(query, limit)
+	if len(matches) == 0 {
+		return fmt.Sprintf("No symbols match %q\n", query)
+	}
+	return ComposeMatches(fmt.Sprintf("Symbols matching %q:", query), matches)
+}
+
+// ComposeMatches renders matches below title, each under a numbered header with its kind, score and file.
+func ComposeMatches(title string, matches []*SymbolMatch) string {
+	var builder strings.Builder
+	builder.WriteString(title + "\n")
+	for i, m := range matches {
+		builder.WriteString(fmt.Sprintf("\n%d. %s (%s, score %.3g) in %s\n", i+1, m.Name, m.Kind, m.Score, m.FilePath))
+		m.format(&builder)
+	}
+	return builder.String()
+}
+
+// symbolScore scores a symbol name and its comment against the lower-case query words.
+func symbolScore(words []string, compact, name, comment string) int {
+	lowerName := strings.ToLower(name)
+	nameWords := make(map[string]bool)
+	for _, w := range tokenize.SplitIdentifier(name) {
+		nameWords[w] = true
+	}
+	commentWords := make(map[string]bool)
+	for _, w := range tokenize.Words(comment) {
+		commentWords[w] = true
+	}
+
+	score := 0
+	if compact == lowerName {
+		score += scoreExactName
+	}
+	for _, w := range words {
+		switch {
+		case nameWords[w]:
+			score += scoreNameWord
+		case strings.Contains(lowerName, w):
+			score += scoreNamePart
+		case len(w) >= 3 && isSubsequence(w, lowerName):
+			score += scoreFuzzy
+		}
+		if commentWords[w] {
+			score += scoreComment
+		}
+	}
+	return score
+}
+
+// qualifiedIn prefixes name with its package name unless it is qualified already.
+func qualifiedIn(packageName, name string) string {
+	if strings.Contains(name, ".") {
+		return name
+	}
+	return packageName + "." + name
+}
+
+// isSubsequence reports whether the letters of sub appear in s in the same order.
+func isSubsequence(sub, s string) bool {
+	for _, r := range s {
+		if len(sub) == 0 {
+			break
+		}
+		if r == rune(sub[0]) {
+			sub = sub[1:]
+		}
+	}
+	return len(sub) == 0
+}
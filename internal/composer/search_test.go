@@ -0,0 +1,108 @@
+package composer_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vlad/ast2llm-go/internal/composer"
+	"github.com/vlad/ast2llm-go/internal/parser"
+	"github.com/vlad/ast2llm-go/internal/types"
+)
+
+func searchProject() parser.ProjectInfo {
+	return parser.ProjectInfo{
+		"/app/store/store.go": {
+			PackageName: "store",
+			Functions: []*types.FunctionInfo{
+				{Name: "NewUserStore", Comment: "NewUserStore opens the user database.", Returns: []string{"*UserStore"}},
+				{Name: "openFile", Params: []string{"name string"}, Returns: []string{"error"}},
+			},
+			Methods: []*types.FunctionInfo{
+				{Name: "Save", Receiver: "*UserStore", Comment: "Save persists a user.", Params: []string{"u *User"}, Returns: []string{"error"}},
+			},
+			Structs: []*types.StructInfo{
+				{Name: "example.com/app/store.UserStore", Comment: "UserStore keeps users in memory."},
+			},
+		},
+		"/app/config/config.go": {
+			PackageName: "config",
+			Functions: []*types.FunctionInfo{
+				{Name: "ParseHTTPConfig", Comment: "ParseHTTPConfig reads the server settings from a file.", Returns: []string{"error"}},
+			},
+			GlobalVars: []*types.GlobalVarInfo{
+				{Name: "DefaultPort", Type: "int", IsConst: true},
+			},
+		},
+	}
+}
+
+func TestProjectComposer_SearchSymbols(t *testing.T) {
+	c := composer.New(searchProject())
+
+	names := func(matches []*composer.SymbolMatch) []string {
+		var names []string
+		for _, m := range matches {
+			names = append(names, m.Name)
+		}
+		return names
+	}
+
+	matches := c.SearchSymbols("user store", 0)
+	assert.Equal(t, []string{"example.com/app/store.UserStore", "store.NewUserStore", "UserStore.Save"}, names(matches))
+	assert.Equal(t, "struct", matches[0].Kind)
+	assert.Equal(t, "/app/store/store.go", matches[0].FilePath)
+	assert.Greater(t, matches[0].Score, matches[2].Score)
+
+	matches = c.SearchSymbols("http config", 1)
+	assert.Equal(t, []string{"config.ParseHTTPConfig"}, names(matches), "limit keeps the best matches")
+
+	matches = c.SearchSymbols("settings", 0)
+	assert.Equal(t, []string{"config.ParseHTTPConfig"}, names(matches), "comment keywords match")
+
+	matches = c.SearchSymbols("dflt", 0)
+	require.Len(t, matches, 1, "letters in order match fuzzily")
+	assert.Equal(t, "config.DefaultPort", matches[0].Name)
+	assert.Equal(t, "constant", matches[0].Kind)
+
+	matches = c.SearchSymbols("openfile", 0)
+	require.NotEmpty(t, matches)
+	assert.Equal(t, "store.openFile", matches[0].Name, "the whole query naming a symbol ranks it first")
+
+	assert.Empty(t, c.SearchSymbols("  ", 0))
+	assert.Empty(t, c.SearchSymbols("kafka", 0))
+}
+
+func TestProjectComposer_ComposeSearch(t *testing.T) {
+	c := composer.New(searchProject())
+
+	assert.Equal(t, `Symbols matching "save user":
+
+1. UserStore.Save (method, score 5) in /app/store/store.go
+  Method: (*UserStore) Save
+    Comment: Save persists a user.
+    Signature: (u *User) -> (error)
+`, c.ComposeSearch("save user", 1))
+
+	assert.Equal(t, "No symbols match \"kafka\"\n", c.ComposeSearch("kafka", 5))
+}
+
+func TestProjectComposer_Symbols(t *testing.T) {
+	symbols := composer.New(searchProject()).Symbols()
+
+	var names, signatures []string
+	for _, s := range symbols {
+		names = append(names, s.Name)
+		signatures = append(signatures, s.Signature)
+	}
+	assert.Equal(t, []string{
+		"config.DefaultPort", "config.ParseHTTPConfig",
+		"UserStore.Save", "example.com/app/store.UserStore", "store.NewUserStore", "store.openFile",
+	}, names, "sorted by file and name")
+	assert.Equal(t, []string{
+		"const DefaultPort int", "func ParseHTTPConfig() -> (error)",
+		"func (*UserStore) Save(u *User) -> (error)", "struct UserStore", "func NewUserStore() -> (*UserStore)", "func openFile(name string) -> (error)",
+	}, signatures)
+	assert.Equal(t, "Save persists a user.", symbols[2].Comment)
+	assert.Equal(t, "method", symbols[2].Kind)
+}
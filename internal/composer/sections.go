@@ -0,0 +1,59 @@
+package composer
+
+import (
+	ourtypes "github.com/vlad/ast2llm-go/internal/types"
+)
+
+// Section is a part of the composed context that can be left out, see WithoutSections
+type Section int
+
+const (
+	SectionImports     Section = iota // Imports of the file and the dependencies providing them
+	SectionFunctions                  // Functions and methods declared in the file
+	SectionGlobals                    // Global variables and constants declared in the file, enums included
+	SectionInterfaces                 // Interfaces declared in the file
+	SectionUsedImports                // Structs, interfaces, functions and variables used from other packages
+)
+
+// WithoutSections leaves the given sections out of Compose and ComposeFile output, so that clients with a small
+// context only get the parts they need. ComposeFile still includes them, as empty arrays
+func WithoutSections(sections ...Section) Option {
+	return func(p *ProjectComposer) {
+		if p.excludedSections == nil {
+			p.excludedSections = make(map[Section]bool)
+		}
+		for _, s := range sections {
+			p.excludedSections[s] = true
+		}
+	}
+}
+
+// withoutExcludedSections returns a copy of fileInfo without the items of the sections left out with
+// WithoutSections, or fileInfo itself if no section is.
+func (p *ProjectComposer) withoutExcludedSections(fileInfo *ourtypes.FileInfo) *ourtypes.FileInfo {
+	if len(p.excludedSections) == 0 {
+		return fileInfo
+	}
+	c := *fileInfo
+	if p.excludedSections[SectionImports] {
+		c.Imports = nil
+	}
+	if p.excludedSections[SectionFunctions] {
+		c.Functions = nil
+		c.Methods = nil
+	}
+	if p.excludedSections[SectionGlobals] {
+		c.GlobalVars = nil
+		c.ConstGroups = nil
+	}
+	if p.excludedSections[SectionInterfaces] {
+		c.Interfaces = nil
+	}
+	if p.excludedSections[SectionUsedImports] {
+		c.UsedImportedStructs = nil
+		c.UsedImportedInterfaces = nil
+		c.UsedImportedFunctions = nil
+		c.UsedImportedGlobalVars = nil
+	}
+	return &c
+}
@@ -0,0 +1,56 @@
+package composer_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vlad/ast2llm-go/internal/composer"
+	"github.com/vlad/ast2llm-go/internal/types"
+)
+
+func TestProjectComposer_WithoutSections(t *testing.T) {
+	filePath := "/project/main.go"
+	projectInfo := map[string]*types.FileInfo{
+		filePath: {
+			PackageName:            "main",
+			Imports:                []*types.ImportInfo{{Path: "example.com/project/models"}},
+			Functions:              []*types.FunctionInfo{{Name: "run"}},
+			Methods:                []*types.FunctionInfo{{Name: "Close", Receiver: "*Server"}},
+			GlobalVars:             []*types.GlobalVarInfo{{Name: "version", Type: "string"}},
+			Structs:                []*types.StructInfo{{Name: "Server"}},
+			Interfaces:             []*types.InterfaceInfo{{Name: "Runner"}},
+			UsedImportedStructs:    []*types.StructInfo{{Name: "example.com/project/models.User"}},
+			UsedImportedGlobalVars: []*types.GlobalVarInfo{{Name: "models.Admin", Type: "string"}},
+		},
+	}
+
+	output, err := composer.New(projectInfo).Compose(filePath)
+	require.NoError(t, err)
+	for _, title := range []string{"Imports:", "Functions:", "Methods:", "Global Variables/Constants:", "Local Interfaces:", "Used Items From Other Packages:"} {
+		assert.Contains(t, output, title)
+	}
+
+	all := []composer.Section{composer.SectionImports, composer.SectionFunctions, composer.SectionGlobals, composer.SectionInterfaces, composer.SectionUsedImports}
+	output, err = composer.New(projectInfo, composer.WithoutSections(all...)).Compose(filePath)
+	require.NoError(t, err)
+	for _, title := range []string{"Imports:", "Functions:", "Methods:", "Global Variables/Constants:", "Local Interfaces:", "Used Items From Other Packages:", "Used Global Variables/Constants From Other Packages:"} {
+		assert.NotContains(t, output, title)
+	}
+	assert.Contains(t, output, "Struct: Server", "sections without a toggle are kept")
+
+	output, err = composer.New(projectInfo, composer.WithoutSections(composer.SectionFunctions)).Compose(filePath)
+	require.NoError(t, err)
+	assert.NotContains(t, output, "Function: run")
+	assert.Contains(t, output, "Imports:")
+	assert.Contains(t, output, "Local Interfaces:")
+
+	composed, err := composer.New(projectInfo, composer.WithoutSections(composer.SectionImports, composer.SectionUsedImports)).ComposeFile(filePath)
+	require.NoError(t, err)
+	assert.Empty(t, composed.Imports)
+	assert.NotNil(t, composed.Imports, "sections stay in the JSON output as empty arrays")
+	assert.Empty(t, composed.UsedStructs)
+	assert.Empty(t, composed.UsedGlobalVars)
+	assert.Empty(t, composed.Unresolved)
+	assert.Len(t, composed.Functions, 1)
+}
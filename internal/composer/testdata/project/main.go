@@ -0,0 +1,24 @@
+// Command golden prints a stored item.
+package main
+
+import (
+	"fmt"
+
+	"example.com/golden/store"
+)
+
+// lookup reads the item stored under key from g.
+func lookup(g store.Getter, key string) (*store.Item, error) {
+	return g.Get(key)
+}
+
+func main() {
+	s := store.Open(store.DefaultCapacity)
+	s.Put(new(store.Item))
+	item, err := lookup(s, "")
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	fmt.Println(item)
+}
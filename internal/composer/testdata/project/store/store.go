@@ -0,0 +1,46 @@
+// Package store keeps items in memory.
+package store
+
+import "errors"
+
+// ErrNotFound is returned when an item is missing.
+var ErrNotFound = errors.New("not found")
+
+// DefaultCapacity is the capacity of a store opened without one.
+const DefaultCapacity = 16
+
+// Item is a stored value.
+type Item struct {
+	Key   string
+	Value []byte
+}
+
+// Getter reads items.
+type Getter interface {
+	// Get returns the item stored under key.
+	Get(key string) (*Item, error)
+}
+
+// Store is an in-memory Getter.
+type Store struct {
+	items map[string]*Item
+}
+
+// Open returns an empty store with room for capacity items.
+func Open(capacity int) *Store {
+	return &Store{items: make(map[string]*Item, capacity)}
+}
+
+// Get returns the item stored under key, or ErrNotFound.
+func (s *Store) Get(key string) (*Item, error) {
+	item, ok := s.items[key]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return item, nil
+}
+
+// Put stores item under its key.
+func (s *Store) Put(item *Item) {
+	s.items[item.Key] = item
+}
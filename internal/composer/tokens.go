@@ -0,0 +1,104 @@
+package composer
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/pkoukk/tiktoken-go"
+	tiktoken_loader "github.com/pkoukk/tiktoken-go-loader"
+)
+
+// Tokenizer counts the tokens a text takes up in a model's context
+type Tokenizer interface {
+	CountTokens(text string) int
+}
+
+// defaultEncoding is the BPE encoding used when no Tokenizer is set
+const defaultEncoding = "cl100k_base"
+
+// WithTokenizer sets the Tokenizer used by TokenCount and TokenReport, defaults to the cl100k_base encoding
+func WithTokenizer(t Tokenizer) Option {
+	return func(p *ProjectComposer) {
+		p.tokenizer = t
+	}
+}
+
+// bpeTokenizer counts tokens with a tiktoken-compatible BPE encoding
+type bpeTokenizer struct {
+	encoding *tiktoken.Tiktoken
+}
+
+// CountTokens returns the number of tokens of text, treating special tokens as plain text.
+func (t *bpeTokenizer) CountTokens(text string) int {
+	return len(t.encoding.EncodeOrdinary(text))
+}
+
+// loadDefaultTokenizer loads the default encoding from the ranks embedded in the binary, so no download is needed.
+var loadDefaultTokenizer = sync.OnceValues(func() (Tokenizer, error) {
+	tiktoken.SetBpeLoader(tiktoken_loader.NewOfflineLoader())
+	encoding, err := tiktoken.GetEncoding(defaultEncoding)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load %s encoding: %w", defaultEncoding, err)
+	}
+	return &bpeTokenizer{encoding: encoding}, nil
+})
+
+// SectionTokens is the share of one section in the tokens of Compose output
+type SectionTokens struct {
+	Section string `json:"section"` // Section title, or "Header" for the file, package and legend lines
+	Items   int    `json:"items"`   // Number of items shown in the section
+	Tokens  int    `json:"tokens"`  // Tokens of the section including its title
+}
+
+// TokenCount returns the number of tokens of Compose output for a given file path.
+func (p *ProjectComposer) TokenCount(filePath string) (int, error) {
+	tokenizer, err := p.getTokenizer()
+	if err != nil {
+		return 0, err
+	}
+	output, err := p.Compose(filePath)
+	if err != nil {
+		return 0, err
+	}
+	return tokenizer.CountTokens(output), nil
+}
+
+// TokenReport returns how many tokens each non-empty section of Compose output contributes for a given file path,
+// in output order. Sections are tokenized separately, so the sum may differ slightly from TokenCount.
+func (p *ProjectComposer) TokenReport(filePath string) ([]SectionTokens, error) {
+	tokenizer, err := p.getTokenizer()
+	if err != nil {
+		return nil, err
+	}
+	rendered, err := p.render(filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	report := []SectionTokens{{Section: "Header", Tokens: tokenizer.CountTokens(rendered.header)}}
+	for _, section := range rendered.sections {
+		text := section.String()
+		if text == "" {
+			continue
+		}
+		shown := 0
+		for _, item := range section.items {
+			if !item.omitted {
+				shown++
+			}
+		}
+		report = append(report, SectionTokens{Section: section.title, Items: shown, Tokens: tokenizer.CountTokens(text)})
+	}
+	if rendered.omitted > 0 {
+		report = append(report, SectionTokens{Section: "Omitted", Tokens: tokenizer.CountTokens(omittedMarker(rendered.omitted))})
+	}
+	return report, nil
+}
+
+// getTokenizer returns the configured Tokenizer or the default one.
+func (p *ProjectComposer) getTokenizer() (Tokenizer, error) {
+	if p.tokenizer != nil {
+		return p.tokenizer, nil
+	}
+	return loadDefaultTokenizer()
+}
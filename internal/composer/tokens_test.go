@@ -0,0 +1,73 @@
+package composer_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vlad/ast2llm-go/internal/composer"
+	"github.com/vlad/ast2llm-go/internal/parser"
+	"github.com/vlad/ast2llm-go/internal/types"
+)
+
+// lineTokenizer counts every line as one token
+type lineTokenizer struct{}
+
+func (lineTokenizer) CountTokens(text string) int { return strings.Count(text, "\n") }
+
+func newTokenProjectInfo() parser.ProjectInfo {
+	return parser.ProjectInfo{
+		"/project/main.go": {
+			PackageName: "main",
+			Imports:     []*types.ImportInfo{{Path: "fmt"}},
+			Functions:   []*types.FunctionInfo{{Name: "main"}, {Name: "run", Params: []string{"n int"}}},
+		},
+	}
+}
+
+func TestProjectComposer_TokenCount(t *testing.T) {
+	c := composer.New(newTokenProjectInfo(), composer.WithTokenizer(lineTokenizer{}))
+	output, err := c.Compose("/project/main.go")
+	require.NoError(t, err)
+
+	count, err := c.TokenCount("/project/main.go")
+	require.NoError(t, err)
+	assert.Equal(t, strings.Count(output, "\n"), count)
+
+	_, err = c.TokenCount("/project/missing.go")
+	assert.Error(t, err)
+
+	t.Run("default tokenizer", func(t *testing.T) {
+		count, err := composer.New(newTokenProjectInfo()).TokenCount("/project/main.go")
+		require.NoError(t, err)
+		assert.Greater(t, count, 10)
+		assert.Less(t, count, len(output))
+	})
+}
+
+func TestProjectComposer_TokenReport(t *testing.T) {
+	c := composer.New(newTokenProjectInfo(), composer.WithTokenizer(lineTokenizer{}))
+	report, err := c.TokenReport("/project/main.go")
+	require.NoError(t, err)
+	assert.Equal(t, []composer.SectionTokens{
+		{Section: "Header", Tokens: 3},
+		{Section: "Imports", Items: 1, Tokens: 3},
+		{Section: "Functions", Items: 2, Tokens: 6},
+	}, report)
+
+	count, err := c.TokenCount("/project/main.go")
+	require.NoError(t, err)
+	total := 0
+	for _, s := range report {
+		total += s.Tokens
+	}
+	assert.Equal(t, count, total)
+
+	t.Run("with budget", func(t *testing.T) {
+		c := composer.New(newTokenProjectInfo(), composer.WithTokenizer(lineTokenizer{}), composer.WithBudget(80))
+		report, err := c.TokenReport("/project/main.go")
+		require.NoError(t, err)
+		assert.Equal(t, "Omitted", report[len(report)-1].Section)
+	})
+}
@@ -0,0 +1,59 @@
+package composer
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+
+	ourtypes "github.com/vlad/ast2llm-go/internal/types"
+)
+
+// requiredTagKeys are the struct tag keys of validation libraries whose "required" option marks the fields
+// that must be set, e.g. validate:"required" of go-playground/validator or binding:"required" of gin
+var requiredTagKeys = []string{"validate", "binding"}
+
+// withUsedFields returns s with only the fields in used and the required ones, see requiredField, and the
+// number of fields left out, recording used on the copy so that JSON readers know fields are missing. s is
+// returned as is if used is empty or covers every field, see StructInfo.UsedFields.
+func withUsedFields(s *ourtypes.StructInfo, used []string) (*ourtypes.StructInfo, int) {
+	if len(used) == 0 {
+		return s, 0
+	}
+	fields := make([]*ourtypes.StructField, 0, len(used))
+	for _, f := range s.Fields {
+		if slices.Contains(used, f.Name) || requiredField(f.Tag) {
+			fields = append(fields, f)
+		}
+	}
+	if len(fields) == len(s.Fields) {
+		return s, 0
+	}
+	c := *s
+	c.Fields = fields
+	c.UsedFields = used
+	return &c, len(s.Fields) - len(fields)
+}
+
+// requiredField reports whether a struct tag marks its field as required, see requiredTagKeys.
+func requiredField(tag string) bool {
+	for _, key := range requiredTagKeys {
+		value, _ := reflect.StructTag(tag).Lookup(key)
+		for _, option := range strings.Split(value, ",") {
+			if option == "required" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// formatUsedStruct formats a struct the file uses from another package, with only the fields the file uses
+// if it records them, followed by the number of fields left out.
+func (p *ProjectComposer) formatUsedStruct(builder *strings.Builder, s *ourtypes.StructInfo, used []string, indent string) {
+	s, omitted := withUsedFields(s, used)
+	p.FormatStruct(builder, s, indent)
+	if omitted > 0 && p.showFields() {
+		builder.WriteString(fmt.Sprintf("%s  (%d more fields not set in this file)\n", indent, omitted))
+	}
+}
@@ -0,0 +1,58 @@
+package composer_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vlad/ast2llm-go/internal/composer"
+	"github.com/vlad/ast2llm-go/internal/parser"
+	"github.com/vlad/ast2llm-go/internal/types"
+)
+
+func TestProjectComposer_UsedFields(t *testing.T) {
+	projectInfo := parser.ProjectInfo{
+		"/app/config/config.go": {
+			PackageName: "config",
+			Structs: []*types.StructInfo{{
+				Name: "example.com/app/config.Config",
+				Fields: []*types.StructField{
+					{Name: "Host", Type: "string"},
+					{Name: "Port", Type: "int"},
+					{Name: "Timeout", Type: "int"},
+					{Name: "Token", Type: "string", Tag: `validate:"min=8,required"`},
+					{Name: "Debug", Type: "bool", Tag: `binding:"required_if=Port 0"`},
+				},
+			}},
+		},
+		"/app/main.go": {
+			PackageName: "main",
+			UsedImportedStructs: []*types.StructInfo{
+				{Name: "example.com/app/config.Config", UsedFields: []string{"Host", "Port"}},
+			},
+		},
+		"/app/other.go": {
+			PackageName:         "main",
+			UsedImportedStructs: []*types.StructInfo{{Name: "example.com/app/config.Config"}},
+		},
+	}
+	c := composer.New(projectInfo)
+
+	output, err := c.Compose("/app/main.go")
+	require.NoError(t, err)
+	assert.Contains(t, output, "        - Host string\n        - Port int\n        - Token string\n      (2 more fields not set in this file)\n")
+	assert.NotContains(t, output, "Timeout")
+	assert.NotContains(t, output, "Debug", "required_if is not required")
+
+	output, err = c.Compose("/app/other.go")
+	require.NoError(t, err)
+	assert.Contains(t, output, "Timeout", "structs without used fields are rendered whole")
+	assert.NotContains(t, output, "more fields")
+
+	composed, err := c.ComposeFile("/app/main.go")
+	require.NoError(t, err)
+	require.Len(t, composed.UsedStructs, 1)
+	assert.Len(t, composed.UsedStructs[0].Fields, 3)
+	assert.Equal(t, []string{"Host", "Port"}, composed.UsedStructs[0].UsedFields)
+	assert.Len(t, projectInfo["/app/config/config.go"].Structs[0].Fields, 5, "the project struct is not modified")
+}
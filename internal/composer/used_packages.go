@@ -0,0 +1,227 @@
+package composer
+
+import (
+	"fmt"
+	"go/token"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/vlad/ast2llm-go/internal/parser"
+	ourtypes "github.com/vlad/ast2llm-go/internal/types"
+)
+
+// usedIndent is the indentation of the items listed under a package header in the used items section
+const usedIndent = "    "
+
+// majorVersionPattern matches the last element of a major version suffixed module path, e.g. v2
+var majorVersionPattern = regexp.MustCompile(`^v[0-9]+$`)
+
+// usedPackage is a package the composed file uses items from
+type usedPackage struct {
+	path      string
+	name      string // Name the package is declared with, "" if unknown
+	alias     string // Name the composed file imports the package as, if it renames it
+	qualifier string // Name the items are qualified with under the package header, "" to keep the path
+	doc       string // First paragraph of the package doc comment, if the package belongs to the project
+	items     []*composedItem
+}
+
+// packageOf returns the package path of a symbol ID or fully qualified name, e.g. example.com/app/store for
+// example.com/app/store.Store.Get, or "" if it is not qualified. The longest of imports the name starts with
+// wins, so that paths with dots in their last element, e.g. gopkg.in/yaml.v3, are told apart from the name.
+func packageOf(name string, imports []*ourtypes.ImportInfo) string {
+	name, _, _ = strings.Cut(name, "[")
+	longest := ""
+	for _, imp := range imports {
+		if len(imp.Path) > len(longest) && strings.HasPrefix(name, imp.Path+".") {
+			longest = imp.Path
+		}
+	}
+	if longest != "" {
+		return longest
+	}
+	slash := strings.LastIndex(name, "/")
+	dot := strings.Index(name[slash+1:], ".")
+	if dot < 0 {
+		return ""
+	}
+	return name[:slash+1+dot]
+}
+
+// packageDocs returns the name and the first paragraph of the doc comment of every project package, by path.
+// The path of a package is taken from the IDs of its declarations, so packages declaring nothing are missing.
+func packageDocs(projectInfo parser.ProjectInfo) (map[string]string, map[string]string) {
+	names := make(map[string]string)
+	docs := make(map[string]string)
+	for _, info := range projectInfo {
+		pkgPath := ""
+		for _, id := range declarationIDs(info) {
+			if pkgPath = packageOf(id, nil); pkgPath != "" {
+				break
+			}
+		}
+		if pkgPath == "" {
+			continue
+		}
+		names[pkgPath] = info.PackageName
+		if doc := firstParagraph(info.PackageDoc); doc != "" && docs[pkgPath] == "" {
+			docs[pkgPath] = doc
+		}
+	}
+	return names, docs
+}
+
+// declarationIDs returns the IDs of the top-level declarations of a file.
+func declarationIDs(info *ourtypes.FileInfo) []string {
+	var ids []string
+	for _, fn := range info.Functions {
+		ids = append(ids, fn.ID)
+	}
+	for _, s := range info.Structs {
+		ids = append(ids, s.ID)
+	}
+	for _, i := range info.Interfaces {
+		ids = append(ids, i.ID)
+	}
+	for _, gv := range info.GlobalVars {
+		ids = append(ids, gv.ID)
+	}
+	return ids
+}
+
+// groupUsedItems groups the used items by the package declaring them, in the order the packages are first
+// used. Every group starts with a package header giving the path and doc of the package, and its items refer
+// to the package by the name imports gives it, or else by its name, instead of by path, unless another used
+// package has the same name.
+func (p *ProjectComposer) groupUsedItems(items []*composedItem, pkgPaths []string, imports []*ourtypes.ImportInfo) []*composedItem {
+	aliases := make(map[string]string)
+	for _, imp := range imports {
+		if imp.Alias != "" && imp.Alias != "_" && imp.Alias != "." {
+			aliases[imp.Path] = imp.Alias
+		}
+	}
+	names, docs := p.packageDocs()
+	var packages []*usedPackage
+	byPath := make(map[string]*usedPackage)
+	byName := make(map[string]int)
+	for i, item := range items {
+		pkg, ok := byPath[pkgPaths[i]]
+		if !ok {
+			pkgPath := pkgPaths[i]
+			pkg = &usedPackage{path: pkgPath, name: names[pkgPath], alias: aliases[pkgPath], doc: docs[pkgPath]}
+			if pkg.name == "" {
+				pkg.name = defaultPackageName(pkg.path)
+			}
+			pkg.qualifier = pkg.name
+			if pkg.alias != "" {
+				pkg.qualifier = pkg.alias
+			}
+			byName[pkg.qualifier]++
+			byPath[pkg.path] = pkg
+			packages = append(packages, pkg)
+		}
+		pkg.items = append(pkg.items, item)
+	}
+
+	grouped := make([]*composedItem, 0, len(items))
+	for _, pkg := range packages {
+		if byName[pkg.qualifier] > 1 {
+			pkg.qualifier = ""
+		}
+		header := p.packageHeader(pkg)
+		for _, item := range pkg.items {
+			item.group, item.groupPath = header, pkg.path
+			if pkg.qualifier != "" && pkg.qualifier != pkg.path {
+				item.text = qualifyByName(item.text, pkg.path, pkg.qualifier)
+			}
+			grouped = append(grouped, item)
+		}
+	}
+	return grouped
+}
+
+// packageHeader renders the header line of the items of a used package, e.g.
+// "  Package store (example.com/app/store): Package store persists items.", followed by the alias if the file
+// renames the package, e.g. "  Package store (example.com/app/store), imported as st".
+func (p *ProjectComposer) packageHeader(pkg *usedPackage) string {
+	if pkg.path == "" {
+		return ""
+	}
+	header := "  Package " + pkg.path
+	if pkg.qualifier != "" && pkg.name != "" && pkg.name != pkg.path {
+		header = fmt.Sprintf("  Package %s (%s)", pkg.name, pkg.path)
+	}
+	if pkg.alias != "" {
+		header += ", imported as " + pkg.alias
+	}
+	if pkg.doc != "" && p.showComments() {
+		header += ": " + pkg.doc
+	}
+	return header + "\n"
+}
+
+// defaultPackageName returns the last element of pkgPath if it is the name a package at that path is declared
+// with by convention, and "" if the name cannot be told from the path, e.g. for gopkg.in/yaml.v3 or
+// example.com/lib/v2.
+func defaultPackageName(pkgPath string) string {
+	name := path.Base(pkgPath)
+	if pkgPath == "" || !token.IsIdentifier(name) || majorVersionPattern.MatchString(name) {
+		return ""
+	}
+	return name
+}
+
+// qualifyByName replaces pkgPath with name wherever it qualifies an identifier in text, e.g.
+// example.com/app/store.Config becomes store.Config.
+func qualifyByName(text, pkgPath, name string) string {
+	prefix := pkgPath + "."
+	var b strings.Builder
+	last := 0
+	for start := 0; ; {
+		i := strings.Index(text[start:], prefix)
+		if i < 0 {
+			break
+		}
+		i += start
+		start = i + len(prefix)
+		if i > 0 && isPathByte(text[i-1]) {
+			continue
+		}
+		// The qualified identifier must not be the start of a longer path, e.g. example.com/app/store.v2/x
+		end := start
+		for end < len(text) && isPathByte(text[end]) && text[end] != '.' && text[end] != '/' {
+			end++
+		}
+		if end == start || (end < len(text) && text[end] == '/') {
+			continue
+		}
+		b.WriteString(text[last:i])
+		b.WriteString(name + ".")
+		last = start
+	}
+	b.WriteString(text[last:])
+	return b.String()
+}
+
+// groupItems reorders items so that the items of a group follow the first of them, keeping their order
+// otherwise. Items without a group keep their place relative to the groups.
+func groupItems(items []*composedItem) []*composedItem {
+	var order []string
+	byGroup := make(map[string][]*composedItem)
+	for _, item := range items {
+		key := item.group
+		if key == "" {
+			key = fmt.Sprintf("%p", item)
+		}
+		if _, ok := byGroup[key]; !ok {
+			order = append(order, key)
+		}
+		byGroup[key] = append(byGroup[key], item)
+	}
+	grouped := make([]*composedItem, 0, len(items))
+	for _, key := range order {
+		grouped = append(grouped, byGroup[key]...)
+	}
+	return grouped
+}
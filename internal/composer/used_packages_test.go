@@ -0,0 +1,117 @@
+package composer_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vlad/ast2llm-go/internal/composer"
+	"github.com/vlad/ast2llm-go/internal/parser"
+	"github.com/vlad/ast2llm-go/internal/types"
+)
+
+func TestProjectComposer_Compose_UsedItemsByPackage(t *testing.T) {
+	filePath := "/project/main.go"
+	projectInfo := parser.ProjectInfo{
+		filePath: {
+			PackageName: "main",
+			Imports:     []*types.ImportInfo{{Path: "example.com/project/models"}, {Path: "example.com/project/store"}, {Path: "gopkg.in/yaml.v3"}},
+			UsedImportedStructs: []*types.StructInfo{
+				{Name: "example.com/project/store.Config", ID: "example.com/project/store.Config"},
+				{Name: "gopkg.in/yaml.v3.Node", ID: "gopkg.in/yaml.v3.Node"},
+			},
+			UsedImportedFunctions: []*types.FunctionInfo{
+				{Name: "example.com/project/models.New", ID: "example.com/project/models.New", Returns: []string{"*example.com/project/models.User"}},
+				{Name: "example.com/project/store.Open", ID: "example.com/project/store.Open", Params: []string{"c example.com/project/store.Config"}},
+			},
+		},
+		"/project/store/store.go": {
+			PackageName: "store",
+			PackageDoc:  "Package store persists items.\n\nIt uses files.",
+			Structs:     []*types.StructInfo{{Name: "example.com/project/store.Config", ID: "example.com/project/store.Config"}},
+		},
+	}
+
+	output, err := composer.New(projectInfo).Compose(filePath)
+	require.NoError(t, err)
+	assert.Contains(t, output, `Used Items From Other Packages:
+  Package store (example.com/project/store): Package store persists items.
+    Struct: store.Config
+    Function: store.Open
+      Signature: (c store.Config)
+  Package gopkg.in/yaml.v3
+    - gopkg.in/yaml.v3.Node
+  Package models (example.com/project/models)
+    Function: models.New
+      Signature: () -> (*models.User)
+`, "items are grouped by package in the order the packages are first used")
+
+	output, err = composer.New(projectInfo, composer.WithMinify()).Compose(filePath)
+	require.NoError(t, err)
+	assert.Contains(t, output, "Package aliases: models = example.com/project/models, store = example.com/project/store, yaml.v3 = gopkg.in/yaml.v3\n")
+	assert.Contains(t, output, "  Package store\n    Struct: store.Config\n", "minified output leaves out package docs and paths explained by the legend")
+	assert.Contains(t, output, "  Package yaml.v3\n    - yaml.v3.Node\n")
+
+	output, err = composer.New(projectInfo, composer.WithModuleAlias("example.com/project")).Compose(filePath)
+	require.NoError(t, err)
+	assert.Contains(t, output, "  Package models (~/models)\n")
+}
+
+func TestProjectComposer_Compose_UsedItemsSameName(t *testing.T) {
+	filePath := "/project/main.go"
+	projectInfo := parser.ProjectInfo{
+		filePath: {
+			PackageName: "main",
+			UsedImportedStructs: []*types.StructInfo{
+				{Name: "example.com/project/models.User"},
+				{Name: "example.com/other/models.User"},
+			},
+		},
+	}
+
+	output, err := composer.New(projectInfo).Compose(filePath)
+	require.NoError(t, err)
+	assert.Contains(t, output, "  Package example.com/project/models\n    - example.com/project/models.User\n",
+		"packages of the same name keep their paths")
+	assert.Contains(t, output, "  Package example.com/other/models\n    - example.com/other/models.User\n")
+
+	output, err = composer.New(projectInfo, composer.WithMinify()).Compose(filePath)
+	require.NoError(t, err)
+	assert.Contains(t, output, "Package aliases: models = example.com/other/models, models2 = example.com/project/models\n")
+	assert.Contains(t, output, "  Package models2\n    - models2.User\n")
+	assert.Contains(t, output, "  Package models\n    - models.User\n")
+}
+
+func TestProjectComposer_Compose_UsedItemsImportAliases(t *testing.T) {
+	filePath := "/project/main.go"
+	projectInfo := parser.ProjectInfo{
+		filePath: {
+			PackageName: "main",
+			Imports: []*types.ImportInfo{
+				{Path: "example.com/project/store", Alias: "st"},
+				{Path: "example.com/project/models"},
+				{Path: "example.com/other/models", Alias: "othermodels"},
+				{Path: "gopkg.in/yaml.v3", Alias: "yaml"},
+			},
+			UsedImportedStructs: []*types.StructInfo{
+				{Name: "example.com/project/store.Config"},
+				{Name: "example.com/project/models.User"},
+				{Name: "example.com/other/models.User"},
+				{Name: "gopkg.in/yaml.v3.Node"},
+			},
+		},
+		"/project/store/store.go": {
+			PackageName: "store",
+			PackageDoc:  "Package store persists items.",
+			Structs:     []*types.StructInfo{{Name: "example.com/project/store.Config", ID: "example.com/project/store.Config"}},
+		},
+	}
+
+	output, err := composer.New(projectInfo).Compose(filePath)
+	require.NoError(t, err)
+	assert.Contains(t, output, "  Package store (example.com/project/store), imported as st: Package store persists items.\n    Struct: st.Config\n",
+		"items are qualified like in the file")
+	assert.Contains(t, output, "  Package models (example.com/project/models)\n    - models.User\n", "aliases resolve name clashes")
+	assert.Contains(t, output, "  Package models (example.com/other/models), imported as othermodels\n    - othermodels.User\n")
+	assert.Contains(t, output, "  Package gopkg.in/yaml.v3, imported as yaml\n    - yaml.Node\n")
+}
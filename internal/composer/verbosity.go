@@ -0,0 +1,61 @@
+package composer
+
+import "fmt"
+
+// Verbosity selects how much detail Compose prints for every declaration
+type Verbosity int
+
+const (
+	VerbositySignatures Verbosity = iota // Names, types and signatures only
+	VerbosityComments                    // Signatures and doc comments
+	VerbosityFields                      // Signatures, doc comments and struct fields (default)
+	VerbosityBodies                      // Everything including function sources
+)
+
+// verbosityNames holds the name of every Verbosity, indexed by its value
+var verbosityNames = []string{"signatures", "comments", "fields", "bodies"}
+
+// VerbosityNames lists the names accepted by ParseVerbosity, from the least to the most detailed
+func VerbosityNames() []string {
+	return append([]string(nil), verbosityNames...)
+}
+
+// ParseVerbosity converts a verbosity name (signatures, comments, fields or bodies) to a Verbosity
+func ParseVerbosity(name string) (Verbosity, error) {
+	for v, n := range verbosityNames {
+		if n == name {
+			return Verbosity(v), nil
+		}
+	}
+	return 0, fmt.Errorf("unknown verbosity: %s", name)
+}
+
+// String returns the name of the verbosity level
+func (v Verbosity) String() string {
+	if v >= 0 && int(v) < len(verbosityNames) {
+		return verbosityNames[v]
+	}
+	return fmt.Sprintf("Verbosity(%d)", int(v))
+}
+
+// WithVerbosity sets the level of detail of Compose output. VerbosityBodies implies WithIncludeBodies
+func WithVerbosity(v Verbosity) Option {
+	return func(p *ProjectComposer) {
+		p.verbosity = v
+	}
+}
+
+// showComments reports whether doc comments are printed
+func (p *ProjectComposer) showComments() bool {
+	return !p.minify && p.verbosity >= VerbosityComments
+}
+
+// showFields reports whether struct fields are printed
+func (p *ProjectComposer) showFields() bool {
+	return p.verbosity >= VerbosityFields
+}
+
+// showBodies reports whether function sources are printed
+func (p *ProjectComposer) showBodies() bool {
+	return p.includeBodies || p.verbosity >= VerbosityBodies
+}
@@ -0,0 +1,66 @@
+package composer_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vlad/ast2llm-go/internal/composer"
+	"github.com/vlad/ast2llm-go/internal/parser"
+	"github.com/vlad/ast2llm-go/internal/types"
+)
+
+func TestParseVerbosity(t *testing.T) {
+	for _, name := range composer.VerbosityNames() {
+		v, err := composer.ParseVerbosity(name)
+		require.NoError(t, err)
+		assert.Equal(t, name, v.String())
+	}
+
+	v, err := composer.ParseVerbosity("fields")
+	require.NoError(t, err)
+	assert.Equal(t, composer.VerbosityFields, v)
+
+	_, err = composer.ParseVerbosity("everything")
+	assert.Error(t, err)
+}
+
+func TestProjectComposer_Compose_Verbosity(t *testing.T) {
+	filePath := "/project/main.go"
+	projectInfo := parser.ProjectInfo{
+		filePath: {
+			PackageName: "main",
+			Functions: []*types.FunctionInfo{
+				{Name: "run", Comment: "run starts the app.", Params: []string{"c Config"}, Body: "func run(c Config) {\n}"},
+			},
+			Structs: []*types.StructInfo{
+				{Name: "Config", Comment: "Config holds the settings.", Fields: []*types.StructField{{Name: "Port", Type: "int"}}},
+			},
+		},
+	}
+
+	compose := func(opts ...composer.Option) string {
+		output, err := composer.New(projectInfo, opts...).Compose(filePath)
+		require.NoError(t, err)
+		return output
+	}
+
+	defaultOutput := compose()
+	assert.Equal(t, defaultOutput, compose(composer.WithVerbosity(composer.VerbosityFields)))
+
+	signatures := compose(composer.WithVerbosity(composer.VerbositySignatures))
+	assert.Contains(t, signatures, "Signature: (c Config)")
+	assert.NotContains(t, signatures, "Comment:")
+	assert.NotContains(t, signatures, "Fields:")
+
+	comments := compose(composer.WithVerbosity(composer.VerbosityComments))
+	assert.Contains(t, comments, "Comment: Config holds the settings.")
+	assert.NotContains(t, comments, "Fields:")
+
+	assert.Contains(t, defaultOutput, "Fields:\n      - Port int\n")
+	assert.NotContains(t, defaultOutput, "Source:")
+
+	bodies := compose(composer.WithVerbosity(composer.VerbosityBodies))
+	assert.Contains(t, bodies, "Source:\n      func run(c Config) {\n")
+	assert.Contains(t, bodies, "Fields:")
+}
@@ -0,0 +1,164 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/vlad/ast2llm-go/internal/composer"
+	"github.com/vlad/ast2llm-go/internal/parser"
+	"gopkg.in/yaml.v3"
+)
+
+// FileName is the name of the configuration file looked up in the project root
+const FileName = ".ast2llm.yaml"
+
+// Config represents the tool settings a project commits in its .ast2llm.yaml
+type Config struct {
+	Exclude    []string `yaml:"exclude"`      // Glob patterns of project-relative files to skip, e.g. "vendor/**"
+	Verbosity  string   `yaml:"verbosity"`    // Default level of detail: signatures, comments, fields or bodies
+	Budget     int      `yaml:"budget"`       // Default character budget of composed context, 0 means unbounded
+	MaxFields  int      `yaml:"max_fields"`   // Default number of fields listed per struct, 0 means unbounded
+	MaxMethods int      `yaml:"max_methods"`  // Default number of methods listed per type, 0 means unbounded
+	BuildTags  []string `yaml:"build_tags"`   // Build tags selecting the files of every package
+	GoCacheDir string   `yaml:"go_cache_dir"` // Build cache of the go command (GOCACHE), relative to the project root
+	Naming     string   `yaml:"naming"`       // How names are qualified: default, full, package or short
+	Vendor     bool     `yaml:"vendor"`       // Resolve dependencies from the vendor directory, like go build -mod=vendor
+	Offline    bool     `yaml:"offline"`      // Never let the go command download modules or toolchains
+	GoFlags    []string `yaml:"go_flags"`     // Additional flags of the go command, see allowedGoFlags
+	GoEnv      []string `yaml:"go_env"`       // Additional KEY=value environment variables, see allowedGoEnv
+}
+
+// allowedGoFlags are the go command flags go_flags may set. The configuration file comes with the checkout
+// and loading packages runs the compiler, so flags that could run other programs, e.g. -toolexec, are rejected.
+var allowedGoFlags = map[string]bool{"tags": true, "mod": true}
+
+// allowedGoEnv are the environment variables go_env may set, for the same reason as allowedGoFlags: variables
+// such as CC or GOFLAGS could run other programs.
+var allowedGoEnv = map[string]bool{"GOOS": true, "GOARCH": true, "CGO_ENABLED": true}
+
+// Load reads the .ast2llm.yaml file of projectPath. A project without one gets an empty Config.
+func Load(projectPath string) (*Config, error) {
+	cfg, err := LoadFile(filepath.Join(projectPath, FileName))
+	if errors.Is(err, os.ErrNotExist) {
+		return &Config{}, nil
+	}
+	return cfg, err
+}
+
+// LoadFile reads the configuration file at path. A relative go_cache_dir is resolved against its directory.
+func LoadFile(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	cfg, err := Parse(data)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.GoCacheDir != "" && !filepath.IsAbs(cfg.GoCacheDir) {
+		abs, err := filepath.Abs(filepath.Join(filepath.Dir(path), cfg.GoCacheDir))
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve go_cache_dir: %w", err)
+		}
+		cfg.GoCacheDir = abs
+	}
+	return cfg, nil
+}
+
+// Parse parses and validates the content of a configuration file. Unknown keys are rejected so that typos
+// do not go unnoticed.
+func Parse(data []byte) (*Config, error) {
+	cfg := &Config{}
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(cfg); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("failed to parse %s: %w", FileName, err)
+	}
+
+	if cfg.Verbosity != "" {
+		if _, err := composer.ParseVerbosity(cfg.Verbosity); err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", FileName, err)
+		}
+	}
+	if cfg.Naming != "" {
+		if _, err := parser.ParseNamingMode(cfg.Naming); err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", FileName, err)
+		}
+	}
+	if cfg.Budget < 0 {
+		return nil, fmt.Errorf("invalid %s: negative budget %d", FileName, cfg.Budget)
+	}
+	if cfg.MaxFields < 0 {
+		return nil, fmt.Errorf("invalid %s: negative max_fields %d", FileName, cfg.MaxFields)
+	}
+	if cfg.MaxMethods < 0 {
+		return nil, fmt.Errorf("invalid %s: negative max_methods %d", FileName, cfg.MaxMethods)
+	}
+	for _, flag := range cfg.GoFlags {
+		name, _, hasValue := strings.Cut(strings.TrimLeft(flag, "-"), "=")
+		if !strings.HasPrefix(flag, "-") || !hasValue || !allowedGoFlags[name] {
+			return nil, fmt.Errorf("invalid %s: go_flags entry %q is not allowed, only -tags=... and -mod=... are", FileName, flag)
+		}
+	}
+	for _, v := range cfg.GoEnv {
+		if key, _, _ := strings.Cut(v, "="); !allowedGoEnv[key] || !strings.Contains(v, "=") {
+			return nil, fmt.Errorf("invalid %s: go_env entry %q is not allowed, only GOOS, GOARCH and CGO_ENABLED can be set", FileName, v)
+		}
+	}
+	return cfg, nil
+}
+
+// ParserOptions returns the parser options applying the exclude patterns, build tags, cache directory, naming,
+// vendoring, offline mode and go command settings
+func (c *Config) ParserOptions() []parser.Option {
+	var opts []parser.Option
+	if len(c.Exclude) > 0 {
+		opts = append(opts, parser.WithExcludeGlobs(c.Exclude...))
+	}
+	if len(c.BuildTags) > 0 {
+		opts = append(opts, parser.WithBuildTags(c.BuildTags...))
+	}
+	if c.GoCacheDir != "" {
+		opts = append(opts, parser.WithGoCacheDir(c.GoCacheDir))
+	}
+	if mode, err := parser.ParseNamingMode(c.Naming); c.Naming != "" && err == nil {
+		opts = append(opts, parser.WithNaming(mode))
+	}
+	if c.Vendor {
+		opts = append(opts, parser.WithVendor())
+	}
+	if c.Offline {
+		opts = append(opts, parser.WithOffline())
+	}
+	if len(c.GoFlags) > 0 {
+		opts = append(opts, parser.WithGoFlags(c.GoFlags...))
+	}
+	if len(c.GoEnv) > 0 {
+		opts = append(opts, parser.WithGoEnv(c.GoEnv...))
+	}
+	return opts
+}
+
+// ComposerOptions returns the composer options applying the verbosity, budget and member limits
+func (c *Config) ComposerOptions() []composer.Option {
+	var opts []composer.Option
+	if v, err := composer.ParseVerbosity(c.Verbosity); err == nil {
+		opts = append(opts, composer.WithVerbosity(v))
+	}
+	if c.Budget > 0 {
+		opts = append(opts, composer.WithBudget(c.Budget))
+	}
+	if c.MaxFields > 0 {
+		opts = append(opts, composer.WithMaxFields(c.MaxFields))
+	}
+	if c.MaxMethods > 0 {
+		opts = append(opts, composer.WithMaxMethods(c.MaxMethods))
+	}
+	return opts
+}
@@ -0,0 +1,85 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoad(t *testing.T) {
+	root := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(root, FileName), []byte(`exclude:
+  - "vendor/**"
+  - "**/*_gen.go"
+verbosity: comments
+budget: 4000
+max_fields: 20
+max_methods: 10
+build_tags: [integration, linux]
+go_cache_dir: .cache/go
+naming: package
+vendor: true
+offline: true
+go_flags: [-mod=readonly, --tags=extra]
+go_env: [CGO_ENABLED=0]
+`), 0644))
+
+	cfg, err := Load(root)
+	require.NoError(t, err)
+	assert.Equal(t, &Config{
+		Exclude:    []string{"vendor/**", "**/*_gen.go"},
+		Verbosity:  "comments",
+		Budget:     4000,
+		MaxFields:  20,
+		MaxMethods: 10,
+		BuildTags:  []string{"integration", "linux"},
+		GoCacheDir: filepath.Join(root, ".cache", "go"),
+		Naming:     "package",
+		Vendor:     true,
+		Offline:    true,
+		GoFlags:    []string{"-mod=readonly", "--tags=extra"},
+		GoEnv:      []string{"CGO_ENABLED=0"},
+	}, cfg)
+	assert.Len(t, cfg.ParserOptions(), 8)
+	assert.Len(t, cfg.ComposerOptions(), 4)
+}
+
+func TestLoad_Missing(t *testing.T) {
+	cfg, err := Load(t.TempDir())
+	require.NoError(t, err)
+	assert.Equal(t, &Config{}, cfg)
+	assert.Empty(t, cfg.ParserOptions())
+	assert.Empty(t, cfg.ComposerOptions())
+
+	_, err = LoadFile(filepath.Join(t.TempDir(), "custom.yaml"))
+	assert.ErrorContains(t, err, "failed to read")
+}
+
+func TestParse_Invalid(t *testing.T) {
+	for name, content := range map[string]string{
+		"unknown key":       "exclud: [vendor/**]\n",
+		"old cache key":     "cache_dir: .cache/go\n",
+		"unknown verbosity": "verbosity: everything\n",
+		"negative budget":   "budget: -1\n",
+		"negative fields":   "max_fields: -1\n",
+		"negative methods":  "max_methods: -1\n",
+		"unknown naming":    "naming: long\n",
+		"malformed":         "exclude: [\n",
+		"toolexec flag":     "go_flags: [-toolexec=/tmp/x]\n",
+		"exec flag":         "go_flags: [-exec, /tmp/x]\n",
+		"flag without dash": "go_flags: [tags=x]\n",
+		"CC variable":       "go_env: [CC=/tmp/x]\n",
+		"GOFLAGS variable":  "go_env: [GOFLAGS=-toolexec=/tmp/x]\n",
+		"variable no value": "go_env: [GOOS]\n",
+	} {
+		_, err := Parse([]byte(content))
+		assert.Error(t, err, name)
+	}
+
+	cfg, err := Parse(nil)
+	require.NoError(t, err, "empty files are valid")
+	assert.Equal(t, &Config{}, cfg)
+}
@@ -0,0 +1,202 @@
+// Package embeddings ranks documents, such as the symbols of a project, by their semantic similarity to a
+// natural-language query. Documents are embedded by a pluggable backend and their vectors are kept in an Index
+// persisted on disk, so only new and changed documents are embedded again.
+package embeddings
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"io"
+	"math"
+	"net/http"
+	"strings"
+
+	"github.com/vlad/ast2llm-go/internal/tokenize"
+)
+
+// Embedder turns texts into vectors whose cosine similarity reflects how related the texts are
+type Embedder interface {
+	// Model identifies the vectors, an index built with one model cannot be queried with another
+	Model() string
+	// Embed returns one vector per text, in order
+	Embed(ctx context.Context, texts []string) ([][]float32, error)
+}
+
+// Backend names accepted by New
+const (
+	BackendLocal  = "local"  // Hashes words and character trigrams, needs no service, see NewLocal
+	BackendOpenAI = "openai" // Any OpenAI-compatible /embeddings endpoint, see NewOpenAI
+)
+
+// Defaults of the OpenAI-compatible backend
+const (
+	DefaultOpenAIURL   = "https://api.openai.com/v1"
+	DefaultOpenAIModel = "text-embedding-3-small"
+)
+
+// defaultLocalDims is the number of dimensions of the vectors of the local backend
+const defaultLocalDims = 512
+
+// New returns the embedder of backend. url and model default to DefaultOpenAIURL and DefaultOpenAIModel for
+// the openai backend and are ignored by the local one; apiKey may be empty for servers not requiring one.
+func New(backend, url, model, apiKey string) (Embedder, error) {
+	switch backend {
+	case BackendLocal:
+		return NewLocal(defaultLocalDims), nil
+	case BackendOpenAI:
+		if url == "" {
+			url = DefaultOpenAIURL
+		}
+		if model == "" {
+			model = DefaultOpenAIModel
+		}
+		return NewOpenAI(url, model, apiKey), nil
+	}
+	return nil, fmt.Errorf("unknown embeddings backend %q, expected %s or %s", backend, BackendLocal, BackendOpenAI)
+}
+
+// localEmbedder embeds texts by feature hashing, see NewLocal
+type localEmbedder struct {
+	dims int
+}
+
+// NewLocal returns an embedder running without any model or service: the words of a text, split at case
+// changes so identifiers match prose, and their character trigrams are hashed into a vector of dims
+// dimensions. It captures shared vocabulary rather than meaning, but is deterministic and free.
+func NewLocal(dims int) Embedder {
+	return &localEmbedder{dims: dims}
+}
+
+func (e *localEmbedder) Model() string {
+	return fmt.Sprintf("local-hash-%d", e.dims)
+}
+
+func (e *localEmbedder) Embed(ctx context.Context, texts []string) ([][]float32, error) {
+	vectors := make([][]float32, len(texts))
+	for i, text := range texts {
+		vector := make([]float32, e.dims)
+		for _, word := range tokenize.Words(text) {
+			e.add(vector, word, 1)
+			padded := "^" + word + "$"
+			for j := 0; j+3 <= len(padded); j++ {
+				e.add(vector, padded[j:j+3], 0.5)
+			}
+		}
+		vectors[i] = normalize(vector)
+	}
+	return vectors, nil
+}
+
+// add adds weight to the dimension feature hashes to, with the sign of another bit of its hash so that
+// collisions cancel out on average.
+func (e *localEmbedder) add(vector []float32, feature string, weight float32) {
+	h := fnv.New64a()
+	h.Write([]byte(feature))
+	sum := h.Sum64()
+	if sum>>63 == 1 {
+		weight = -weight
+	}
+	vector[sum%uint64(e.dims)] += weight
+}
+
+// normalize scales vector to unit length, leaving zero vectors as they are.
+func normalize(vector []float32) []float32 {
+	var sum float64
+	for _, x := range vector {
+		sum += float64(x) * float64(x)
+	}
+	if sum == 0 {
+		return vector
+	}
+	norm := float32(math.Sqrt(sum))
+	for i := range vector {
+		vector[i] /= norm
+	}
+	return vector
+}
+
+// openAIBatchSize is the number of texts sent per request to an OpenAI-compatible endpoint
+const openAIBatchSize = 100
+
+// openAIEmbedder calls an OpenAI-compatible embeddings endpoint, see NewOpenAI
+type openAIEmbedder struct {
+	url    string
+	model  string
+	apiKey string
+	client *http.Client
+}
+
+// NewOpenAI returns an embedder calling the /embeddings endpoint of the OpenAI-compatible API at url, e.g.
+// https://api.openai.com/v1 or the one of a local Ollama server, http://localhost:11434/v1, with model.
+func NewOpenAI(url, model, apiKey string) Embedder {
+	return &openAIEmbedder{url: strings.TrimSuffix(url, "/"), model: model, apiKey: apiKey, client: http.DefaultClient}
+}
+
+func (e *openAIEmbedder) Model() string {
+	return e.model
+}
+
+func (e *openAIEmbedder) Embed(ctx context.Context, texts []string) ([][]float32, error) {
+	vectors := make([][]float32, 0, len(texts))
+	for start := 0; start < len(texts); start += openAIBatchSize {
+		end := min(start+openAIBatchSize, len(texts))
+		batch, err := e.embedBatch(ctx, texts[start:end])
+		if err != nil {
+			return nil, err
+		}
+		vectors = append(vectors, batch...)
+	}
+	return vectors, nil
+}
+
+// embedBatch embeds texts with a single request.
+func (e *openAIEmbedder) embedBatch(ctx context.Context, texts []string) ([][]float32, error) {
+	body, err := json.Marshal(map[string]any{"model": e.model, "input": texts})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode embeddings request: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.url+"/embeddings", bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create embeddings request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if e.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+e.apiKey)
+	}
+
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("embeddings request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return nil, fmt.Errorf("embeddings request failed: %s: %s", resp.Status, strings.TrimSpace(string(message)))
+	}
+
+	var result struct {
+		Data []struct {
+			Index     int       `json:"index"`
+			Embedding []float32 `json:"embedding"`
+		} `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode embeddings response: %w", err)
+	}
+	vectors := make([][]float32, len(texts))
+	for _, d := range result.Data {
+		if d.Index < 0 || d.Index >= len(texts) {
+			return nil, fmt.Errorf("embeddings response has an embedding for input %d of %d", d.Index, len(texts))
+		}
+		vectors[d.Index] = d.Embedding
+	}
+	for i, v := range vectors {
+		if v == nil {
+			return nil, fmt.Errorf("embeddings response has no embedding for input %d", i)
+		}
+	}
+	return vectors, nil
+}
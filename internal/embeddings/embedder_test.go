@@ -0,0 +1,95 @@
+package embeddings
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNew(t *testing.T) {
+	e, err := New(BackendLocal, "", "", "")
+	require.NoError(t, err)
+	assert.Equal(t, "local-hash-512", e.Model())
+
+	e, err = New(BackendOpenAI, "", "", "")
+	require.NoError(t, err)
+	assert.Equal(t, DefaultOpenAIModel, e.Model())
+
+	_, err = New("bert", "", "", "")
+	assert.EqualError(t, err, `unknown embeddings backend "bert", expected local or openai`)
+}
+
+func TestLocalEmbedder(t *testing.T) {
+	e := NewLocal(256)
+	vectors, err := e.Embed(context.Background(), []string{
+		"retry uploads",
+		"func RetryUpload(ctx context.Context) error",
+		"func ParseConfig(path string) (*Config, error)",
+		"",
+	})
+	require.NoError(t, err)
+	require.Len(t, vectors, 4)
+	assert.Len(t, vectors[0], 256)
+	assert.InDelta(t, 1, cosine(vectors[0], vectors[0]), 1e-6, "vectors are normalized")
+	assert.Greater(t, cosine(vectors[0], vectors[1]), cosine(vectors[0], vectors[2]), "identifiers match the words they are made of")
+	assert.Equal(t, 0.0, cosine(vectors[0], vectors[3]))
+
+	again, err := e.Embed(context.Background(), []string{"retry uploads"})
+	require.NoError(t, err)
+	assert.Equal(t, vectors[0], again[0], "embeddings are deterministic")
+}
+
+func TestOpenAIEmbedder(t *testing.T) {
+	var requests []map[string]any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/embeddings", r.URL.Path)
+		assert.Equal(t, "Bearer secret", r.Header.Get("Authorization"))
+		var body map[string]any
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		requests = append(requests, body)
+
+		input := body["input"].([]any)
+		type item struct {
+			Index     int       `json:"index"`
+			Embedding []float32 `json:"embedding"`
+		}
+		var data []item
+		for i := len(input) - 1; i >= 0; i-- { // Out of order, as the API allows
+			data = append(data, item{Index: i, Embedding: []float32{float32(len(input[i].(string))), 1}})
+		}
+		require.NoError(t, json.NewEncoder(w).Encode(map[string]any{"data": data}))
+	}))
+	defer srv.Close()
+
+	e := NewOpenAI(srv.URL+"/v1/", "nomic-embed-text", "secret")
+	assert.Equal(t, "nomic-embed-text", e.Model())
+
+	texts := make([]string, openAIBatchSize+1)
+	for i := range texts {
+		texts[i] = "text"
+	}
+	texts[1] = "a"
+	vectors, err := e.Embed(context.Background(), texts)
+	require.NoError(t, err)
+	require.Len(t, vectors, len(texts))
+	assert.Equal(t, []float32{4, 1}, vectors[0])
+	assert.Equal(t, []float32{1, 1}, vectors[1], "embeddings are matched to inputs by index")
+	require.Len(t, requests, 2, "inputs are sent in batches")
+	assert.Equal(t, "nomic-embed-text", requests[0]["model"])
+	assert.Len(t, requests[1]["input"], 1)
+}
+
+func TestOpenAIEmbedder_Error(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"error": "invalid api key"}`, http.StatusUnauthorized)
+	}))
+	defer srv.Close()
+
+	_, err := NewOpenAI(srv.URL, "m", "").Embed(context.Background(), []string{"x"})
+	assert.EqualError(t, err, `embeddings request failed: 401 Unauthorized: {"error": "invalid api key"}`)
+}
@@ -0,0 +1,209 @@
+package embeddings
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Document is a text to rank, identified by ID
+type Document struct {
+	ID   string
+	Text string
+}
+
+// Result is a document ranked against a query
+type Result struct {
+	ID    string
+	Score float64 // Cosine similarity of the document and the query, higher is better
+}
+
+// Index holds the vectors of the documents of a project, embedded with one model
+type Index struct {
+	Model   string            `json:"model"`
+	Entries map[string]*Entry `json:"entries"` // Key: document ID
+}
+
+// Entry is the vector of a document, with the hash of the text it was computed from
+type Entry struct {
+	Hash   string    `json:"hash"`
+	Vector []float32 `json:"vector"`
+}
+
+// NewIndex returns an empty index of vectors embedded with model
+func NewIndex(model string) *Index {
+	return &Index{Model: model, Entries: make(map[string]*Entry)}
+}
+
+// IndexPath returns the file persisting the index of the project at projectPath for model, in dir
+func IndexPath(dir, projectPath, model string) string {
+	sum := sha256.Sum256([]byte(projectPath))
+	safeModel := strings.Map(func(r rune) rune {
+		if r == '/' || r == '\\' || r == ':' || r == ' ' {
+			return '_'
+		}
+		return r
+	}, model)
+	return filepath.Join(dir, hex.EncodeToString(sum[:8])+"-"+safeModel+".json")
+}
+
+// DefaultDir returns the directory indexes are persisted in by default, next to the build cache of the go
+// command in the user cache directory.
+func DefaultDir() (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to locate the user cache directory: %w", err)
+	}
+	return filepath.Join(dir, "ast2llm-go", "embeddings"), nil
+}
+
+// Load reads the index persisted at path. A missing file, or an index of another model, yields an empty index.
+func Load(path, model string) (*Index, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return NewIndex(model), nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read embeddings index: %w", err)
+	}
+	var index Index
+	if err := json.Unmarshal(data, &index); err != nil {
+		return nil, fmt.Errorf("failed to decode embeddings index %s: %w", path, err)
+	}
+	if index.Model != model || index.Entries == nil {
+		return NewIndex(model), nil
+	}
+	return &index, nil
+}
+
+// Save persists the index at path, creating its directory. The file is replaced atomically so concurrent
+// readers never see a partial index.
+func (ix *Index) Save(path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create embeddings directory: %w", err)
+	}
+	data, err := json.Marshal(ix)
+	if err != nil {
+		return fmt.Errorf("failed to encode embeddings index: %w", err)
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*")
+	if err != nil {
+		return fmt.Errorf("failed to write embeddings index: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write embeddings index: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write embeddings index: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to write embeddings index: %w", err)
+	}
+	return nil
+}
+
+// Update makes the index hold exactly the vectors of docs: documents that are new or whose text changed are
+// embedded with e, and entries of documents no longer listed are dropped. It returns how many documents were
+// embedded.
+func (ix *Index) Update(ctx context.Context, e Embedder, docs []Document) (int, error) {
+	if e.Model() != ix.Model {
+		return 0, fmt.Errorf("embeddings index of model %s cannot be updated with model %s", ix.Model, e.Model())
+	}
+
+	keep := make(map[string]bool, len(docs))
+	var pending []Document
+	var hashes []string
+	for _, doc := range docs {
+		keep[doc.ID] = true
+		hash := textHash(doc.Text)
+		if entry, ok := ix.Entries[doc.ID]; ok && entry.Hash == hash {
+			continue
+		}
+		pending = append(pending, doc)
+		hashes = append(hashes, hash)
+	}
+	for id := range ix.Entries {
+		if !keep[id] {
+			delete(ix.Entries, id)
+		}
+	}
+	if len(pending) == 0 {
+		return 0, nil
+	}
+
+	texts := make([]string, len(pending))
+	for i, doc := range pending {
+		texts[i] = doc.Text
+	}
+	vectors, err := e.Embed(ctx, texts)
+	if err != nil {
+		return 0, err
+	}
+	if len(vectors) != len(pending) {
+		return 0, fmt.Errorf("embedder returned %d vectors for %d texts", len(vectors), len(pending))
+	}
+	for i, doc := range pending {
+		ix.Entries[doc.ID] = &Entry{Hash: hashes[i], Vector: vectors[i]}
+	}
+	return len(pending), nil
+}
+
+// Rank embeds query with e and returns the limit entries most similar to it, best first, ties broken by ID.
+// A limit of 0 returns every entry.
+func (ix *Index) Rank(ctx context.Context, e Embedder, query string, limit int) ([]Result, error) {
+	vectors, err := e.Embed(ctx, []string{query})
+	if err != nil {
+		return nil, err
+	}
+	if len(vectors) != 1 {
+		return nil, fmt.Errorf("embedder returned %d vectors for 1 text", len(vectors))
+	}
+
+	results := make([]Result, 0, len(ix.Entries))
+	for id, entry := range ix.Entries {
+		results = append(results, Result{ID: id, Score: cosine(vectors[0], entry.Vector)})
+	}
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Score != results[j].Score {
+			return results[i].Score > results[j].Score
+		}
+		return results[i].ID < results[j].ID
+	})
+	if limit > 0 && len(results) > limit {
+		results = results[:limit]
+	}
+	return results, nil
+}
+
+// textHash identifies the text a vector was computed from.
+func textHash(text string) string {
+	sum := sha256.Sum256([]byte(text))
+	return hex.EncodeToString(sum[:16])
+}
+
+// cosine returns the cosine similarity of a and b, 0 if either is a zero vector or their lengths differ.
+func cosine(a, b []float32) float64 {
+	if len(a) != len(b) {
+		return 0
+	}
+	var dot, normA, normB float64
+	for i := range a {
+		dot += float64(a[i]) * float64(b[i])
+		normA += float64(a[i]) * float64(a[i])
+		normB += float64(b[i]) * float64(b[i])
+	}
+	if normA == 0 || normB == 0 {
+		return 0
+	}
+	return dot / math.Sqrt(normA*normB)
+}
@@ -0,0 +1,75 @@
+package embeddings
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// countingEmbedder records the texts it embeds
+type countingEmbedder struct {
+	Embedder
+	texts []string
+}
+
+func (e *countingEmbedder) Embed(ctx context.Context, texts []string) ([][]float32, error) {
+	e.texts = append(e.texts, texts...)
+	return e.Embedder.Embed(ctx, texts)
+}
+
+func TestIndex(t *testing.T) {
+	ctx := context.Background()
+	e := &countingEmbedder{Embedder: NewLocal(512)}
+	path := IndexPath(t.TempDir(), "/projects/app", e.Model())
+	assert.True(t, strings.HasSuffix(path, "-local-hash-512.json"))
+
+	index, err := Load(path, e.Model())
+	require.NoError(t, err)
+	assert.Empty(t, index.Entries, "a missing index is empty")
+
+	docs := []Document{
+		{ID: "upload", Text: "func RetryUpload(ctx context.Context) error\nRetryUpload uploads a file again after a failure."},
+		{ID: "config", Text: "func ParseConfig(path string) (*Config, error)\nParseConfig reads the settings file."},
+		{ID: "user", Text: "struct User\nUser is an account."},
+	}
+	embedded, err := index.Update(ctx, e, docs)
+	require.NoError(t, err)
+	assert.Equal(t, 3, embedded)
+	require.NoError(t, index.Save(path))
+
+	results, err := index.Rank(ctx, e, "retry the failed upload", 2)
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	assert.Equal(t, "upload", results[0].ID)
+	assert.Greater(t, results[0].Score, results[1].Score)
+
+	loaded, err := Load(path, e.Model())
+	require.NoError(t, err)
+	assert.Equal(t, index, loaded)
+
+	e.texts = nil
+	docs[1].Text += " Environment variables override it."
+	embedded, err = loaded.Update(ctx, e, docs[:2])
+	require.NoError(t, err)
+	assert.Equal(t, 1, embedded)
+	assert.Equal(t, []string{docs[1].Text}, e.texts, "only changed documents are embedded again")
+	assert.NotContains(t, loaded.Entries, "user", "removed documents are dropped")
+
+	other, err := Load(path, "text-embedding-3-small")
+	require.NoError(t, err)
+	assert.Empty(t, other.Entries, "an index of another model is not reused")
+	_, err = other.Update(ctx, e, docs)
+	assert.EqualError(t, err, "embeddings index of model text-embedding-3-small cannot be updated with model local-hash-512")
+}
+
+func TestLoad_Corrupt(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "index.json")
+	require.NoError(t, os.WriteFile(path, []byte("{"), 0644))
+	_, err := Load(path, "m")
+	assert.ErrorContains(t, err, "failed to decode embeddings index")
+}
@@ -0,0 +1,162 @@
+package git
+
+import (
+	"archive/tar"
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Root returns the top-level directory of the git repository containing dir
+func Root(dir string) (string, error) {
+	out, err := run(dir, "rev-parse", "--show-toplevel")
+	if err != nil {
+		return "", err
+	}
+	return filepath.Clean(strings.TrimSpace(string(out))), nil
+}
+
+// ChangedFiles returns the absolute paths of the files of the repository containing dir that differ between ref
+// and the work tree, sorted. Staged, unstaged, untracked and deleted files are all included.
+func ChangedFiles(dir, ref string) ([]string, error) {
+	root, err := Root(dir)
+	if err != nil {
+		return nil, err
+	}
+	if strings.HasPrefix(ref, "-") {
+		return nil, fmt.Errorf("invalid ref %q", ref)
+	}
+
+	diffed, err := run(root, "diff", "--name-only", "-z", ref, "--")
+	if err != nil {
+		return nil, err
+	}
+	untracked, err := run(root, "ls-files", "--others", "--exclude-standard", "-z")
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool)
+	var files []string
+	for _, name := range append(splitNUL(diffed), splitNUL(untracked)...) {
+		path := filepath.Join(root, filepath.FromSlash(name))
+		if !seen[path] {
+			seen[path] = true
+			files = append(files, path)
+		}
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+// ExportTree writes the files of the repository containing dir as of ref into dest, which must exist, and
+// returns the directory within dest corresponding to dir, e.g. dest/backend for the backend directory of a
+// repository. The work tree and the index are left untouched.
+func ExportTree(dir, ref, dest string) (string, error) {
+	if strings.HasPrefix(ref, "-") {
+		return "", fmt.Errorf("invalid ref %q", ref)
+	}
+	prefix, err := run(dir, "rev-parse", "--show-prefix")
+	if err != nil {
+		return "", err
+	}
+	root, err := Root(dir)
+	if err != nil {
+		return "", err
+	}
+	archive, err := run(root, "archive", "--format=tar", ref)
+	if err != nil {
+		return "", err
+	}
+
+	reader := tar.NewReader(bytes.NewReader(archive))
+	for {
+		header, err := reader.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return "", fmt.Errorf("failed to read archive of %s: %w", ref, err)
+		}
+		name := filepath.FromSlash(header.Name)
+		if !filepath.IsLocal(name) {
+			return "", fmt.Errorf("invalid path %q in archive of %s", header.Name, ref)
+		}
+		path := filepath.Join(dest, name)
+		switch header.Typeflag {
+		case tar.TypeDir:
+			err = os.MkdirAll(path, 0755)
+		case tar.TypeReg:
+			err = writeArchiveFile(path, reader, header.FileInfo().Mode().Perm())
+		case tar.TypeSymlink:
+			err = os.Symlink(header.Linkname, path)
+		}
+		if err != nil {
+			return "", fmt.Errorf("failed to export %s: %w", header.Name, err)
+		}
+	}
+	return filepath.Join(dest, filepath.FromSlash(strings.TrimSpace(string(prefix)))), nil
+}
+
+// Snapshot exports the repository containing dir as of ref into a new temporary directory, see ExportTree,
+// and returns the directory corresponding to dir along with a function removing the snapshot.
+func Snapshot(dir, ref string) (string, func(), error) {
+	dest, err := os.MkdirTemp("", "ast2llm-snapshot-")
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to create snapshot directory: %w", err)
+	}
+	cleanup := func() { os.RemoveAll(dest) }
+	snapshotDir, err := ExportTree(dir, ref, dest)
+	if err != nil {
+		cleanup()
+		return "", nil, err
+	}
+	return snapshotDir, cleanup, nil
+}
+
+// writeArchiveFile writes the content of a file of an archive to path, creating its directory.
+func writeArchiveFile(path string, content io.Reader, perm os.FileMode) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, perm)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(file, content); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// run executes git with args in dir and returns its standard output
+func run(dir string, args ...string) ([]byte, error) {
+	cmd := exec.Command("git", append([]string{"-C", dir}, args...)...)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("git %s: %s", args[0], msg)
+		}
+		return nil, fmt.Errorf("git %s: %w", args[0], err)
+	}
+	return out, nil
+}
+
+// splitNUL splits the NUL-terminated names printed by git's -z option
+func splitNUL(out []byte) []string {
+	var names []string
+	for _, name := range strings.Split(string(out), "\x00") {
+		if name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
@@ -0,0 +1,118 @@
+package git
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestRepo creates a git repository with a single commit of the given files
+func newTestRepo(t *testing.T, files map[string]string) string {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+	root, err := filepath.EvalSymlinks(t.TempDir())
+	require.NoError(t, err)
+	for name, content := range files {
+		writeFile(t, filepath.Join(root, name), content)
+	}
+	for _, args := range [][]string{
+		{"init", "-q"},
+		{"add", "-A"},
+		{"-c", "user.name=test", "-c", "user.email=test@example.com", "commit", "-q", "-m", "initial"},
+	} {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = root
+		out, err := cmd.CombinedOutput()
+		require.NoError(t, err, string(out))
+	}
+	return root
+}
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+}
+
+func TestChangedFiles(t *testing.T) {
+	root := newTestRepo(t, map[string]string{
+		"main.go":      "package main\n",
+		"util/util.go": "package util\n",
+		"old.go":       "package main\n",
+		"README.md":    "readme\n",
+	})
+
+	files, err := ChangedFiles(root, "HEAD")
+	require.NoError(t, err)
+	assert.Empty(t, files)
+
+	writeFile(t, filepath.Join(root, "util", "util.go"), "package util\n\nfunc Helper() {}\n")
+	writeFile(t, filepath.Join(root, "new", "new.go"), "package new\n")
+	require.NoError(t, os.Remove(filepath.Join(root, "old.go")))
+
+	files, err = ChangedFiles(filepath.Join(root, "util"), "HEAD")
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		filepath.Join(root, "new", "new.go"),
+		filepath.Join(root, "old.go"),
+		filepath.Join(root, "util", "util.go"),
+	}, files)
+
+	_, err = ChangedFiles(root, "no-such-ref")
+	assert.Error(t, err)
+	_, err = ChangedFiles(root, "--output=x")
+	assert.ErrorContains(t, err, "invalid ref")
+}
+
+func TestRoot_NotARepository(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+	_, err := Root(t.TempDir())
+	assert.Error(t, err)
+}
+
+func TestExportTree(t *testing.T) {
+	root := newTestRepo(t, map[string]string{
+		"go.mod":              "module example.com/app\n",
+		"backend/main.go":     "package main\n",
+		"backend/api/api.go":  "package api\n",
+		"frontend/index.html": "<html></html>\n",
+	})
+	writeFile(t, filepath.Join(root, "backend", "main.go"), "package main\n\nfunc main() {}\n")
+
+	dest := t.TempDir()
+	dir, err := ExportTree(filepath.Join(root, "backend"), "HEAD", dest)
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(dest, "backend"), dir)
+
+	content, err := os.ReadFile(filepath.Join(dir, "main.go"))
+	require.NoError(t, err)
+	assert.Equal(t, "package main\n", string(content), "files are exported as of the ref, not the work tree")
+	assert.FileExists(t, filepath.Join(dir, "api", "api.go"))
+	assert.FileExists(t, filepath.Join(dest, "go.mod"))
+
+	_, err = ExportTree(root, "no-such-ref", t.TempDir())
+	assert.Error(t, err)
+	_, err = ExportTree(root, "--output=x", t.TempDir())
+	assert.ErrorContains(t, err, "invalid ref")
+}
+
+func TestSnapshot(t *testing.T) {
+	root := newTestRepo(t, map[string]string{"main.go": "package main\n"})
+
+	dir, cleanup, err := Snapshot(root, "HEAD")
+	require.NoError(t, err)
+	assert.FileExists(t, filepath.Join(dir, "main.go"))
+	cleanup()
+	assert.NoDirExists(t, dir)
+
+	_, _, err = Snapshot(root, "no-such-ref")
+	assert.Error(t, err)
+}
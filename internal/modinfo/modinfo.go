@@ -0,0 +1,124 @@
+package modinfo
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/mod/modfile"
+)
+
+// Requirement represents a module required by the project
+type Requirement struct {
+	Path     string // Module path
+	Version  string // Required version
+	Indirect bool   // True if marked // indirect
+}
+
+// Replace represents a replace directive
+type Replace struct {
+	OldPath    string // Replaced module path
+	OldVersion string // Replaced version, empty if all versions are replaced
+	NewPath    string // Replacement module path or local directory
+	NewVersion string // Replacement version, empty for local directories
+}
+
+// ModuleInfo represents the parsed go.mod of a project, or the merged go.mod files of a go.work workspace
+type ModuleInfo struct {
+	Path      string         // Module path, empty for a workspace without a module at its root
+	GoVersion string         // Go version from the go directive
+	Requires  []*Requirement // Required modules
+	Replaces  []*Replace     // Replace directives
+	Modules   []string       // Paths of the workspace modules, empty outside a workspace
+}
+
+// NewModuleInfo creates a new ModuleInfo instance
+func NewModuleInfo() *ModuleInfo {
+	return &ModuleInfo{
+		Requires: make([]*Requirement, 0),
+		Replaces: make([]*Replace, 0),
+		Modules:  make([]string, 0),
+	}
+}
+
+// Load parses the go.mod file located in projectPath, or the go.work file with LoadWorkspace if there is one,
+// as the go command then works with all modules of the workspace.
+func Load(projectPath string) (*ModuleInfo, error) {
+	if _, err := os.Stat(filepath.Join(projectPath, "go.work")); err == nil {
+		return LoadWorkspace(projectPath)
+	}
+	goModPath := filepath.Join(projectPath, "go.mod")
+	data, err := os.ReadFile(goModPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read go.mod: %w", err)
+	}
+	return Parse(goModPath, data)
+}
+
+// Parse parses the content of a go.mod file. goModPath is only used in error messages.
+func Parse(goModPath string, data []byte) (*ModuleInfo, error) {
+	f, err := modfile.Parse(goModPath, data, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse go.mod: %w", err)
+	}
+
+	info := NewModuleInfo()
+	if f.Module != nil {
+		info.Path = f.Module.Mod.Path
+	}
+	if f.Go != nil {
+		info.GoVersion = f.Go.Version
+	}
+	for _, r := range f.Require {
+		info.Requires = append(info.Requires, &Requirement{
+			Path:     r.Mod.Path,
+			Version:  r.Mod.Version,
+			Indirect: r.Indirect,
+		})
+	}
+	for _, r := range f.Replace {
+		info.Replaces = append(info.Replaces, &Replace{
+			OldPath:    r.Old.Path,
+			OldVersion: r.Old.Version,
+			NewPath:    r.New.Path,
+			NewVersion: r.New.Version,
+		})
+	}
+	return info, nil
+}
+
+// Owns reports whether importPath belongs to the module or, in a workspace, to one of its modules.
+func (m *ModuleInfo) Owns(importPath string) bool {
+	for _, path := range append([]string{m.Path}, m.Modules...) {
+		if path != "" && (importPath == path || strings.HasPrefix(importPath, path+"/")) {
+			return true
+		}
+	}
+	return false
+}
+
+// RequirementFor returns the required module providing importPath, or nil for standard library
+// and project packages.
+func (m *ModuleInfo) RequirementFor(importPath string) *Requirement {
+	var best *Requirement
+	for _, r := range m.Requires {
+		if importPath != r.Path && !strings.HasPrefix(importPath, r.Path+"/") {
+			continue
+		}
+		if best == nil || len(r.Path) > len(best.Path) {
+			best = r
+		}
+	}
+	return best
+}
+
+// ReplaceFor returns the replace directive applying to the requirement, or nil.
+func (m *ModuleInfo) ReplaceFor(r *Requirement) *Replace {
+	for _, rep := range m.Replaces {
+		if rep.OldPath == r.Path && (rep.OldVersion == "" || rep.OldVersion == r.Version) {
+			return rep
+		}
+	}
+	return nil
+}
@@ -0,0 +1,71 @@
+package modinfo
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testGoMod = `module example.com/app
+
+go 1.22
+
+require (
+	github.com/stretchr/testify v1.10.0
+	golang.org/x/tools v0.34.0
+	github.com/davecgh/go-spew v1.1.1 // indirect
+)
+
+replace golang.org/x/tools => ../tools
+`
+
+func TestParse(t *testing.T) {
+	info, err := Parse("go.mod", []byte(testGoMod))
+	require.NoError(t, err)
+
+	assert.Equal(t, "example.com/app", info.Path)
+	assert.Equal(t, "1.22", info.GoVersion)
+	require.Len(t, info.Requires, 3)
+	assert.Equal(t, &Requirement{Path: "github.com/stretchr/testify", Version: "v1.10.0"}, info.Requires[0])
+	assert.True(t, info.Requires[2].Indirect)
+	require.Len(t, info.Replaces, 1)
+	assert.Equal(t, &Replace{OldPath: "golang.org/x/tools", NewPath: "../tools"}, info.Replaces[0])
+}
+
+func TestParse_Invalid(t *testing.T) {
+	_, err := Parse("go.mod", []byte("module"))
+	assert.ErrorContains(t, err, "failed to parse go.mod")
+}
+
+func TestLoad(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "go.mod"), []byte(testGoMod), 0644))
+
+	info, err := Load(dir)
+	require.NoError(t, err)
+	assert.Equal(t, "example.com/app", info.Path)
+
+	_, err = Load(filepath.Join(dir, "missing"))
+	assert.ErrorContains(t, err, "failed to read go.mod")
+}
+
+func TestModuleInfo_RequirementFor(t *testing.T) {
+	info, err := Parse("go.mod", []byte(testGoMod))
+	require.NoError(t, err)
+
+	r := info.RequirementFor("github.com/stretchr/testify/assert")
+	require.NotNil(t, r)
+	assert.Equal(t, "github.com/stretchr/testify", r.Path)
+
+	assert.Nil(t, info.RequirementFor("fmt"))
+	assert.Nil(t, info.RequirementFor("example.com/app/internal/x"))
+	assert.Nil(t, info.RequirementFor("github.com/stretchr/testifyx"))
+
+	tools := info.RequirementFor("golang.org/x/tools/go/packages")
+	require.NotNil(t, tools)
+	assert.Equal(t, &Replace{OldPath: "golang.org/x/tools", NewPath: "../tools"}, info.ReplaceFor(tools))
+	assert.Nil(t, info.ReplaceFor(r))
+}
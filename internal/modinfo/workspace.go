@@ -0,0 +1,128 @@
+package modinfo
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"golang.org/x/mod/modfile"
+	"golang.org/x/mod/semver"
+)
+
+// WorkspaceDirs returns the absolute directories of the modules used by the go.work file located in
+// projectPath, or nil if there is no go.work file.
+func WorkspaceDirs(projectPath string) ([]string, error) {
+	_, dirs, err := readWork(projectPath)
+	return dirs, err
+}
+
+// readWork parses the go.work file located in projectPath and resolves the directories of its modules.
+// It returns nil values if there is no go.work file.
+func readWork(projectPath string) (*modfile.WorkFile, []string, error) {
+	goWorkPath := filepath.Join(projectPath, "go.work")
+	data, err := os.ReadFile(goWorkPath)
+	if os.IsNotExist(err) {
+		return nil, nil, nil
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read go.work: %w", err)
+	}
+	work, err := modfile.ParseWork(goWorkPath, data, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to parse go.work: %w", err)
+	}
+
+	root, err := filepath.Abs(projectPath)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to resolve project path: %w", err)
+	}
+	dirs := make([]string, 0, len(work.Use))
+	for _, use := range work.Use {
+		dir := filepath.FromSlash(use.Path)
+		if !filepath.IsAbs(dir) {
+			dir = filepath.Join(root, dir)
+		}
+		dirs = append(dirs, filepath.Clean(dir))
+	}
+	return work, dirs, nil
+}
+
+// LoadWorkspace parses the go.work file located in projectPath and merges the go.mod files of its modules.
+// Requirements on other workspace modules are dropped, as the workspace provides them, and the highest
+// version wins when several modules require the same one. Replace directives of go.work come first, since
+// they override those of the modules. Path is only set if the workspace root is one of its modules.
+func LoadWorkspace(projectPath string) (*ModuleInfo, error) {
+	work, dirs, err := readWork(projectPath)
+	if err != nil {
+		return nil, err
+	}
+	if work == nil {
+		return nil, fmt.Errorf("failed to read go.work: no go.work in %s", projectPath)
+	}
+
+	info := NewModuleInfo()
+	if work.Go != nil {
+		info.GoVersion = work.Go.Version
+	}
+	for _, r := range work.Replace {
+		info.Replaces = append(info.Replaces, &Replace{
+			OldPath:    r.Old.Path,
+			OldVersion: r.Old.Version,
+			NewPath:    r.New.Path,
+			NewVersion: r.New.Version,
+		})
+	}
+
+	root, err := filepath.Abs(projectPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve project path: %w", err)
+	}
+	var members []*ModuleInfo
+	for _, dir := range dirs {
+		goModPath := filepath.Join(dir, "go.mod")
+		data, err := os.ReadFile(goModPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read go.mod of workspace module %s: %w", dir, err)
+		}
+		member, err := Parse(goModPath, data)
+		if err != nil {
+			return nil, err
+		}
+		if dir == root {
+			info.Path = member.Path
+		}
+		info.Modules = append(info.Modules, member.Path)
+		members = append(members, member)
+	}
+
+	required := make(map[string]*Requirement)
+	for _, member := range members {
+		for _, r := range member.Requires {
+			if info.isModule(r.Path) {
+				continue
+			}
+			if prev, ok := required[r.Path]; ok {
+				if semver.Compare(r.Version, prev.Version) > 0 {
+					prev.Version = r.Version
+				}
+				prev.Indirect = prev.Indirect && r.Indirect
+				continue
+			}
+			req := *r
+			required[r.Path] = &req
+			info.Requires = append(info.Requires, &req)
+		}
+		info.Replaces = append(info.Replaces, member.Replaces...)
+	}
+	return info, nil
+}
+
+// isModule reports whether path is one of the workspace modules
+func (m *ModuleInfo) isModule(path string) bool {
+	for _, module := range m.Modules {
+		if module == path {
+			return true
+		}
+	}
+	return false
+}
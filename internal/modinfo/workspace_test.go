@@ -0,0 +1,72 @@
+package modinfo
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vlad/ast2llm-go/internal/testutil"
+)
+
+// writeWorkspace creates a go.work workspace with an api and a service module requiring the api
+func writeWorkspace(t *testing.T) string {
+	root := t.TempDir()
+	files := map[string]string{
+		"go.work": "go 1.22\n\nuse (\n\t.\n\t./api\n\t./service\n)\n\nreplace example.com/lib => ../lib\n",
+		"go.mod":  "module example.com/tools\n\ngo 1.22\n",
+		"api/go.mod": `module example.com/api
+
+go 1.22
+
+require github.com/google/uuid v1.5.0
+`,
+		"service/go.mod": `module example.com/service
+
+go 1.22
+
+require (
+	example.com/api v0.0.0
+	github.com/google/uuid v1.6.0 // indirect
+)
+`,
+	}
+	testutil.WriteFiles(t, root, files)
+	return root
+}
+
+func TestWorkspaceDirs(t *testing.T) {
+	root := writeWorkspace(t)
+
+	dirs, err := WorkspaceDirs(root)
+	require.NoError(t, err)
+	assert.Equal(t, []string{root, filepath.Join(root, "api"), filepath.Join(root, "service")}, dirs)
+
+	dirs, err = WorkspaceDirs(filepath.Join(root, "api"))
+	require.NoError(t, err)
+	assert.Nil(t, dirs)
+}
+
+func TestLoad_Workspace(t *testing.T) {
+	info, err := Load(writeWorkspace(t))
+	require.NoError(t, err)
+
+	assert.Equal(t, "example.com/tools", info.Path)
+	assert.Equal(t, "1.22", info.GoVersion)
+	assert.Equal(t, []string{"example.com/tools", "example.com/api", "example.com/service"}, info.Modules)
+	assert.Equal(t, []*Requirement{{Path: "github.com/google/uuid", Version: "v1.6.0"}}, info.Requires)
+	assert.Equal(t, []*Replace{{OldPath: "example.com/lib", NewPath: "../lib"}}, info.Replaces)
+
+	assert.True(t, info.Owns("example.com/service/handlers"))
+	assert.True(t, info.Owns("example.com/tools"))
+	assert.False(t, info.Owns("example.com/apis"))
+	assert.Nil(t, info.RequirementFor("example.com/api"))
+}
+
+func TestLoadWorkspace_MissingModule(t *testing.T) {
+	root := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(root, "go.work"), []byte("go 1.22\n\nuse ./missing\n"), 0644))
+	_, err := LoadWorkspace(root)
+	assert.ErrorContains(t, err, "failed to read go.mod of workspace module")
+}
@@ -0,0 +1,140 @@
+package parser
+
+import (
+	gotypes "go/types"
+	"sort"
+	"strings"
+
+	ourtypes "github.com/vlad/ast2llm-go/internal/types"
+)
+
+// APISurface loads the project and returns the signature of every exported package-level declaration of its
+// packages other than main, and of every exported method of their exported types, keyed by fully qualified
+// name, e.g. "example.com/app/store.New" or "example.com/app/store.Store.Get". Signatures qualify types of
+// other packages by package name, e.g. "func New(ctx context.Context) *Store". Structs only list their
+// exported fields and constants include their value. Declarations of _test.go files are left out.
+func (p *ProjectParser) APISurface(projectPath string) (map[string]string, error) {
+	pkgs, err := p.loadPackages(projectPath, "./...")
+	if err != nil {
+		return nil, err
+	}
+
+	surface := make(map[string]string)
+	for _, pkg := range pkgs {
+		if pkg.Types == nil || pkg.Name == "main" || strings.HasSuffix(pkg.Name, "_test") {
+			continue
+		}
+		qualifier := func(other *gotypes.Package) string {
+			if other.Path() == pkg.PkgPath {
+				return ""
+			}
+			return other.Name()
+		}
+		scope := pkg.Types.Scope()
+		for _, name := range scope.Names() {
+			obj := scope.Lookup(name)
+			if !obj.Exported() || strings.HasSuffix(p.fset.Position(obj.Pos()).Filename, "_test.go") {
+				continue
+			}
+			key := pkg.PkgPath + "." + name
+			surface[key] = apiSignature(obj, qualifier)
+
+			typeName, ok := obj.(*gotypes.TypeName)
+			if !ok || typeName.IsAlias() {
+				continue
+			}
+			if named, ok := typeName.Type().(*gotypes.Named); ok {
+				for i := 0; i < named.NumMethods(); i++ {
+					if m := named.Method(i); m.Exported() {
+						surface[key+"."+m.Name()] = gotypes.ObjectString(m, qualifier)
+					}
+				}
+			}
+		}
+	}
+	return surface, nil
+}
+
+// apiSignature renders the declaration of an exported package-level object.
+func apiSignature(obj gotypes.Object, qualifier gotypes.Qualifier) string {
+	switch obj := obj.(type) {
+	case *gotypes.Const:
+		return gotypes.ObjectString(obj, qualifier) + " = " + obj.Val().ExactString()
+	case *gotypes.TypeName:
+		named, ok := obj.Type().(*gotypes.Named)
+		if obj.IsAlias() || !ok {
+			return gotypes.ObjectString(obj, qualifier)
+		}
+		var builder strings.Builder
+		builder.WriteString("type " + obj.Name())
+		if tparams := named.TypeParams(); tparams.Len() > 0 {
+			params := make([]string, 0, tparams.Len())
+			for i := 0; i < tparams.Len(); i++ {
+				tp := tparams.At(i)
+				params = append(params, tp.Obj().Name()+" "+gotypes.TypeString(tp.Constraint(), qualifier))
+			}
+			builder.WriteString("[" + strings.Join(params, ", ") + "]")
+		}
+		builder.WriteString(" ")
+		if structType, ok := named.Underlying().(*gotypes.Struct); ok {
+			builder.WriteString(exportedStructString(structType, qualifier))
+		} else {
+			builder.WriteString(gotypes.TypeString(named.Underlying(), qualifier))
+		}
+		return builder.String()
+	}
+	return gotypes.ObjectString(obj, qualifier)
+}
+
+// exportedStructString renders a struct type with its exported and embedded fields only, since the others are
+// not part of the API, e.g. "struct{Name string; Base}".
+func exportedStructString(structType *gotypes.Struct, qualifier gotypes.Qualifier) string {
+	var fields []string
+	for i := 0; i < structType.NumFields(); i++ {
+		field := structType.Field(i)
+		typeString := gotypes.TypeString(field.Type(), qualifier)
+		switch {
+		case field.Embedded():
+			fields = append(fields, typeString)
+		case field.Exported():
+			fields = append(fields, field.Name()+" "+typeString)
+		}
+	}
+	return "struct{" + strings.Join(fields, "; ") + "}"
+}
+
+// CompareProjects returns the differences between the API surfaces of two versions of a project, see
+// APISurface, sorted by name: the declarations only in oldPath are removed, the ones only in newPath are
+// added and the ones whose signature differs are changed. Both versions should have the same module path.
+func (p *ProjectParser) CompareProjects(oldPath, newPath string) ([]*ourtypes.APIChange, error) {
+	oldSurface, err := p.APISurface(oldPath)
+	if err != nil {
+		return nil, err
+	}
+	newSurface, err := p.APISurface(newPath)
+	if err != nil {
+		return nil, err
+	}
+	return DiffAPISurfaces(oldSurface, newSurface), nil
+}
+
+// DiffAPISurfaces compares two API surfaces returned by APISurface, see CompareProjects.
+func DiffAPISurfaces(oldSurface, newSurface map[string]string) []*ourtypes.APIChange {
+	changes := make([]*ourtypes.APIChange, 0)
+	for name, oldSig := range oldSurface {
+		newSig, ok := newSurface[name]
+		switch {
+		case !ok:
+			changes = append(changes, &ourtypes.APIChange{Kind: ourtypes.APIRemoved, Name: name, Old: oldSig})
+		case newSig != oldSig:
+			changes = append(changes, &ourtypes.APIChange{Kind: ourtypes.APIChanged, Name: name, Old: oldSig, New: newSig})
+		}
+	}
+	for name, newSig := range newSurface {
+		if _, ok := oldSurface[name]; !ok {
+			changes = append(changes, &ourtypes.APIChange{Kind: ourtypes.APIAdded, Name: name, New: newSig})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Name < changes[j].Name })
+	return changes
+}
@@ -0,0 +1,90 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	ourtypes "github.com/vlad/ast2llm-go/internal/types"
+)
+
+func TestProjectParser_APISurface(t *testing.T) {
+	projectPath := writeTestProject(t, map[string]string{
+		"store/store.go": `package store
+
+import "context"
+
+// Limit is the maximum size.
+const Limit = 10
+
+// Store holds values.
+type Store[K comparable] struct {
+	Name   string
+	values map[K]string
+}
+
+// Get returns a value.
+func (s *Store[K]) Get(ctx context.Context, key K) string { return s.values[key] }
+
+func (s *Store[K]) reset() {}
+
+// ID identifies a store.
+type ID = string
+
+func helper() {}
+`,
+		"store/store_test.go": `package store
+
+func TestHelper() {}
+`,
+		"main.go": `package main
+
+func Run() {}
+
+func main() {}
+`,
+	})
+
+	surface, err := New(WithTests()).APISurface(projectPath)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"example.com/testproject/store.Limit":     "const Limit untyped int = 10",
+		"example.com/testproject/store.Store":     "type Store[K comparable] struct{Name string}",
+		"example.com/testproject/store.Store.Get": "func (*Store[K]).Get(ctx context.Context, key K) string",
+		"example.com/testproject/store.ID":        "type ID = string",
+	}, surface)
+}
+
+func TestProjectParser_CompareProjects(t *testing.T) {
+	oldPath := writeTestProject(t, map[string]string{
+		"api/api.go": `package api
+
+func New() int { return 0 }
+
+func Close() {}
+
+type Config struct{ Port int }
+`,
+	})
+	newPath := writeTestProject(t, map[string]string{
+		"api/api.go": `package api
+
+func New(port int) (int, error) { return port, nil }
+
+func Open() {}
+
+type Config struct {
+	Port  int
+	cache bool
+}
+`,
+	})
+
+	changes, err := New().CompareProjects(oldPath, newPath)
+	require.NoError(t, err)
+	assert.Equal(t, []*ourtypes.APIChange{
+		{Kind: ourtypes.APIRemoved, Name: "example.com/testproject/api.Close", Old: "func Close()"},
+		{Kind: ourtypes.APIChanged, Name: "example.com/testproject/api.New", Old: "func New() int", New: "func New(port int) (int, error)"},
+		{Kind: ourtypes.APIAdded, Name: "example.com/testproject/api.Open", New: "func Open()"},
+	}, changes, "unexported fields are no API changes")
+}
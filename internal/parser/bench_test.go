@@ -0,0 +1,67 @@
+package parser
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// benchProjects are the sizes of the generated projects BenchmarkParseProject parses
+var benchProjects = []struct {
+	name     string
+	packages int // Packages of the project, each importing the previous one
+	files    int // Files per package
+	decls    int // Structs, each with a constructor and a method, per file
+}{
+	{name: "small", packages: 2, files: 3, decls: 5},
+	{name: "medium", packages: 10, files: 5, decls: 10},
+	{name: "large", packages: 30, files: 10, decls: 10},
+}
+
+// benchProjectFiles generates the files of a project of the given size. The files of every package but the
+// first use the types and functions of the package before it, so that resolving used imported items is measured.
+func benchProjectFiles(packages, files, decls int) map[string]string {
+	result := make(map[string]string, packages*files+1)
+	result["main.go"] = fmt.Sprintf("package main\n\nimport \"example.com/testproject/pkg%d\"\n\nfunc main() { pkg%d.NewItem0x0(0) }\n", packages-1, packages-1)
+	for p := 0; p < packages; p++ {
+		for f := 0; f < files; f++ {
+			var src strings.Builder
+			fmt.Fprintf(&src, "// Package pkg%d is generated for benchmarks.\npackage pkg%d\n\n", p, p)
+			if p > 0 {
+				fmt.Fprintf(&src, "import \"example.com/testproject/pkg%d\"\n\n", p-1)
+			}
+			for d := 0; d < decls; d++ {
+				name := fmt.Sprintf("Item%dx%d", f, d)
+				fmt.Fprintf(&src, "// %s holds generated fields.\ntype %s struct {\n\tID   int    `json:\"id\"`\n\tName string `json:\"name\"`\n", name, name)
+				if p > 0 {
+					fmt.Fprintf(&src, "\tParent *pkg%d.%s\n", p-1, name)
+				}
+				src.WriteString("}\n\n")
+				fmt.Fprintf(&src, "// New%s returns a %s with the given ID.\nfunc New%s(id int) *%s {\n", name, name, name, name)
+				if p > 0 {
+					fmt.Fprintf(&src, "\treturn &%s{ID: id, Parent: pkg%d.New%s(id)}\n}\n\n", name, p-1, name)
+				} else {
+					fmt.Fprintf(&src, "\treturn &%s{ID: id}\n}\n\n", name)
+				}
+				fmt.Fprintf(&src, "// Describe returns the name of the item.\nfunc (i *%s) Describe() string {\n\treturn i.Name\n}\n\n", name)
+			}
+			result[fmt.Sprintf("pkg%d/file%d.go", p, f)] = src.String()
+		}
+	}
+	return result
+}
+
+func BenchmarkParseProject(b *testing.B) {
+	for _, size := range benchProjects {
+		b.Run(size.name, func(b *testing.B) {
+			projectPath := writeTestProject(b, benchProjectFiles(size.packages, size.files, size.decls))
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if _, err := New().ParseProject(projectPath); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
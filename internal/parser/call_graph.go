@@ -0,0 +1,102 @@
+package parser
+
+import (
+	"go/ast"
+	gotypes "go/types"
+	"sort"
+
+	ourtypes "github.com/vlad/ast2llm-go/internal/types"
+)
+
+// BuildCallGraph loads the project and records which functions and methods call which.
+// Nodes are keyed by fully qualified names as produced by types.Func.FullName, e.g. "(*example.com/pkg.T).Method".
+// Calls made inside function literals are attributed to the enclosing declaration.
+func (p *ProjectParser) BuildCallGraph(projectPath string) (*ourtypes.CallGraph, error) {
+	pkgs, err := p.loadPackages(projectPath, "./...")
+	if err != nil {
+		return nil, err
+	}
+
+	graph := ourtypes.NewCallGraph()
+	edges := make(map[[2]string]bool)
+
+	for _, pkg := range pkgs {
+		for _, file := range pkg.Syntax {
+			for _, decl := range file.Decls {
+				funcDecl, ok := decl.(*ast.FuncDecl)
+				if !ok || funcDecl.Body == nil {
+					continue
+				}
+				callerObj, ok := pkg.TypesInfo.Defs[funcDecl.Name].(*gotypes.Func)
+				if !ok {
+					continue
+				}
+				caller := callGraphNode(graph, callerObj.FullName())
+
+				ast.Inspect(funcDecl.Body, func(n ast.Node) bool {
+					call, ok := n.(*ast.CallExpr)
+					if !ok {
+						return true
+					}
+					callee := calledFunc(call, pkg.TypesInfo)
+					if callee == nil {
+						return true
+					}
+					calleeName := callee.Origin().FullName()
+					edge := [2]string{caller.Name, calleeName}
+					if edges[edge] {
+						return true
+					}
+					edges[edge] = true
+					caller.Calls = append(caller.Calls, calleeName)
+					calleeNode := callGraphNode(graph, calleeName)
+					calleeNode.CalledBy = append(calleeNode.CalledBy, caller.Name)
+					return true
+				})
+			}
+		}
+	}
+
+	for _, node := range graph.Nodes {
+		sort.Strings(node.Calls)
+		sort.Strings(node.CalledBy)
+	}
+
+	return graph, nil
+}
+
+// callGraphNode returns the node for name, creating it if needed.
+func callGraphNode(graph *ourtypes.CallGraph, name string) *ourtypes.CallNode {
+	if node, ok := graph.Nodes[name]; ok {
+		return node
+	}
+	node := ourtypes.NewCallNode()
+	node.Name = name
+	graph.Nodes[name] = node
+	return node
+}
+
+// calledFunc returns the statically known function or method called by call, or nil.
+func calledFunc(call *ast.CallExpr, info *gotypes.Info) *gotypes.Func {
+	fun := ast.Unparen(call.Fun)
+	// Strip explicit instantiation, e.g. F[int](x)
+	switch f := fun.(type) {
+	case *ast.IndexExpr:
+		fun = f.X
+	case *ast.IndexListExpr:
+		fun = f.X
+	}
+
+	var ident *ast.Ident
+	switch f := fun.(type) {
+	case *ast.Ident:
+		ident = f
+	case *ast.SelectorExpr:
+		ident = f.Sel
+	default:
+		return nil
+	}
+
+	fn, _ := info.Uses[ident].(*gotypes.Func)
+	return fn
+}
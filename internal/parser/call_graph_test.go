@@ -0,0 +1,69 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProjectParser_BuildCallGraph(t *testing.T) {
+	projectPath := writeTestProject(t, map[string]string{
+		"store/store.go": `package store
+
+// Store keeps values.
+type Store struct{}
+
+// Put stores a value.
+func (s *Store) Put(v string) {
+	s.validate(v)
+}
+
+func (s *Store) validate(v string) {}
+
+// New creates a store.
+func New() *Store {
+	return &Store{}
+}
+`,
+		"main.go": `package main
+
+import (
+	"fmt"
+
+	"example.com/testproject/store"
+)
+
+func main() {
+	s := store.New()
+	s.Put("a")
+	s.Put("b")
+	func() {
+		fmt.Println("done")
+	}()
+}
+`,
+	})
+
+	p := New()
+	graph, err := p.BuildCallGraph(projectPath)
+	require.NoError(t, err)
+
+	mainNode := graph.Nodes["example.com/testproject.main"]
+	require.NotNil(t, mainNode)
+	assert.Equal(t, []string{
+		"(*example.com/testproject/store.Store).Put",
+		"example.com/testproject/store.New",
+		"fmt.Println",
+	}, mainNode.Calls)
+
+	putNode := graph.Nodes["(*example.com/testproject/store.Store).Put"]
+	require.NotNil(t, putNode)
+	assert.Equal(t, []string{"(*example.com/testproject/store.Store).validate"}, putNode.Calls)
+	assert.Equal(t, []string{"example.com/testproject.main"}, putNode.CalledBy)
+
+	validateNode := graph.Nodes["(*example.com/testproject/store.Store).validate"]
+	require.NotNil(t, validateNode)
+	assert.Empty(t, validateNode.Calls)
+	assert.Equal(t, []string{"(*example.com/testproject/store.Store).Put"}, validateNode.CalledBy)
+}
@@ -0,0 +1,103 @@
+package parser
+
+import (
+	"go/ast"
+	goparser "go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// cgoNote is reported for every file of a package using cgo, which is parsed syntax-only
+const cgoNote = "cgo package parsed syntax-only: items from C and other packages are not resolved and type errors are not reported"
+
+// withCgoFallback parses the packages of pkgs using cgo from their source files and type-checks them like
+// ParseProjectSyntax does. The go command otherwise hands over the files generated by cgo instead of the
+// sources, fails to load the package without a C compiler, or ignores the files importing "C" when cgo is disabled.
+func (p *ProjectParser) withCgoFallback(pkgs []*packages.Package) {
+	for _, pkg := range pkgs {
+		sources := p.cgoSources(pkg)
+		if sources == nil {
+			continue
+		}
+		pkg.Syntax = nil
+		pkg.Errors = nil
+		pkg.TypesInfo = nil
+		for _, path := range sources {
+			src, err := os.ReadFile(path)
+			if err != nil {
+				continue
+			}
+			p.recordContent(path, src)
+			file, err := goparser.ParseFile(p.fset, path, src, goparser.ParseComments)
+			if file == nil {
+				continue
+			}
+			pkg.Syntax = append(pkg.Syntax, file)
+			pkg.Errors = append(pkg.Errors, parseErrors(err)...)
+		}
+		pkg.GoFiles = sources
+		pkg.CompiledGoFiles = sources
+		checkSyntaxOnly(pkg, cgoNote)
+	}
+}
+
+// cgoSources returns the Go source files of pkg, including the ones ignored because cgo is disabled, if one of
+// them imports "C", and nil otherwise. Packages whose files were loaded as they are without errors are skipped
+// without reading their files.
+func (p *ProjectParser) cgoSources(pkg *packages.Package) []string {
+	usesCgo := false
+	sources := slices.Clone(pkg.GoFiles)
+	if !slices.Equal(pkg.GoFiles, pkg.CompiledGoFiles) || len(pkg.Errors) > 0 {
+		for _, path := range pkg.GoFiles {
+			usesCgo = usesCgo || fileImportsC(path)
+		}
+	}
+
+	ctxt := p.buildContext()
+	ctxt.CgoEnabled = true
+	for _, path := range pkg.IgnoredFiles {
+		if filepath.Ext(path) != ".go" || !fileImportsC(path) {
+			continue
+		}
+		if match, err := ctxt.MatchFile(filepath.Dir(path), filepath.Base(path)); err == nil && match {
+			sources = append(sources, path)
+			usesCgo = true
+		}
+	}
+	if !usesCgo {
+		return nil
+	}
+	return sources
+}
+
+// fileImportsC reports whether the Go file at path imports "C", reading only its imports.
+func fileImportsC(path string) bool {
+	file, err := goparser.ParseFile(token.NewFileSet(), path, nil, goparser.ImportsOnly)
+	return err == nil && importsC(file)
+}
+
+// importsC reports whether file imports "C", i.e. uses cgo.
+func importsC(file *ast.File) bool {
+	for _, imp := range file.Imports {
+		if path, err := strconv.Unquote(imp.Path.Value); err == nil && path == "C" {
+			return true
+		}
+	}
+	return false
+}
+
+// hasAsmFiles reports whether pkg has assembly files, which implement the functions it declares without a body.
+func hasAsmFiles(pkg *packages.Package) bool {
+	for _, path := range pkg.OtherFiles {
+		if strings.EqualFold(filepath.Ext(path), ".s") {
+			return true
+		}
+	}
+	return false
+}
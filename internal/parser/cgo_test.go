@@ -0,0 +1,87 @@
+package parser
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// cgoTestProject has a package mixing a cgo file with a pure Go one, so that it is loaded with or without
+// cgo, and a package implemented in assembly
+var cgoTestProject = map[string]string{
+	"native/native.go": `package native
+
+// Version is the version of the library.
+const Version = "1.0"
+`,
+	"native/add.go": `package native
+
+/*
+static int add(int a, int b) { return a + b; }
+*/
+import "C"
+
+// Add adds two numbers in C.
+func Add(a, b int) int {
+	return int(C.add(C.int(a), C.int(b)))
+}
+`,
+	"asm/sum.go": `package asm
+
+// Sum is implemented in assembly.
+func Sum(xs []int) int
+`,
+	"asm/sum.s": "#include \"textflag.h\"\n\nTEXT ·Sum(SB),NOSPLIT,$0\n\tRET\n",
+	"main.go": `package main
+
+import "example.com/testproject/native"
+
+func main() { native.Add(1, 2) }
+`,
+}
+
+func TestProjectParser_ParseProject_Cgo(t *testing.T) {
+	for _, cgoEnabled := range []string{"1", "0"} {
+		t.Run("CGO_ENABLED="+cgoEnabled, func(t *testing.T) {
+			t.Setenv("CGO_ENABLED", cgoEnabled)
+			projectPath := writeTestProject(t, cgoTestProject)
+
+			info, err := New().ParseProject(projectPath)
+			require.NoError(t, err)
+
+			addInfo := info[filepath.Join(projectPath, "native", "add.go")]
+			require.NotNil(t, addInfo, "the source of the cgo file is described, not the files cgo generates")
+			assert.True(t, addInfo.HasCgo)
+			require.Len(t, addInfo.Functions, 1)
+			assert.Equal(t, "Add", addInfo.Functions[0].Name)
+			assert.Equal(t, []string{"a int", "b int"}, addInfo.Functions[0].Params)
+			require.Len(t, addInfo.Diagnostics, 1)
+			assert.Equal(t, cgoNote, addInfo.Diagnostics[0].Message)
+
+			nativeInfo := info[filepath.Join(projectPath, "native", "native.go")]
+			require.NotNil(t, nativeInfo)
+			assert.False(t, nativeInfo.HasCgo)
+			assert.Len(t, nativeInfo.GlobalVars, 1)
+
+			for path := range info {
+				assert.Contains(t, path, projectPath)
+			}
+
+			mainInfo := info[filepath.Join(projectPath, "main.go")]
+			require.NotNil(t, mainInfo)
+			assert.False(t, mainInfo.HasCgo)
+			if cgoEnabled == "1" { // Without cgo, importers do not see the declarations of cgo files
+				require.Len(t, mainInfo.UsedImportedFunctions, 1)
+				assert.Equal(t, "example.com/testproject/native.Add", mainInfo.UsedImportedFunctions[0].Name)
+			}
+
+			sumInfo := info[filepath.Join(projectPath, "asm", "sum.go")]
+			require.NotNil(t, sumInfo)
+			assert.True(t, sumInfo.HasAsm)
+			assert.False(t, mainInfo.HasAsm)
+			assert.Empty(t, sumInfo.Diagnostics, "assembly packages are type-checked as usual")
+		})
+	}
+}
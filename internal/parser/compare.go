@@ -0,0 +1,98 @@
+package parser
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// CompareProjectInfo reports how got differs from want, one difference per line starting with the path of the
+// differing value, e.g. `["/app/main.go"].Functions[0].Name: "main" != "run"`, or "" if they are equal.
+// Nil and empty slices and maps are equal, as they are once serialized, and unexported fields are ignored.
+// It needs no testing library, so programs embedding the parser can check its output in their own tests.
+func CompareProjectInfo(want, got ProjectInfo) string {
+	var diffs []string
+	compareValues(reflect.ValueOf(want), reflect.ValueOf(got), "", &diffs)
+	return strings.Join(diffs, "\n")
+}
+
+// compareValues appends the differences between want and got, two values of the same type found at path, to diffs.
+func compareValues(want, got reflect.Value, path string, diffs *[]string) {
+	report := func(format string, args ...any) {
+		*diffs = append(*diffs, strings.TrimPrefix(path, ".")+": "+fmt.Sprintf(format, args...))
+	}
+
+	switch want.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		switch {
+		case want.IsNil() && got.IsNil():
+		case want.IsNil():
+			report("nil != %s", describeValue(got.Elem()))
+		case got.IsNil():
+			report("%s != nil", describeValue(want.Elem()))
+		case want.Elem().Type() != got.Elem().Type():
+			report("%s != %s", want.Elem().Type(), got.Elem().Type())
+		default:
+			compareValues(want.Elem(), got.Elem(), path, diffs)
+		}
+	case reflect.Slice, reflect.Array:
+		if want.Len() != got.Len() {
+			report("%d items != %d items", want.Len(), got.Len())
+			return
+		}
+		for i := 0; i < want.Len(); i++ {
+			compareValues(want.Index(i), got.Index(i), fmt.Sprintf("%s[%d]", path, i), diffs)
+		}
+	case reflect.Map:
+		for _, key := range mapKeys(want, got) {
+			keyPath := fmt.Sprintf("%s[%s]", path, describeValue(key))
+			wantValue, gotValue := want.MapIndex(key), got.MapIndex(key)
+			switch {
+			case !gotValue.IsValid():
+				*diffs = append(*diffs, keyPath+": missing")
+			case !wantValue.IsValid():
+				*diffs = append(*diffs, keyPath+": unexpected")
+			default:
+				compareValues(wantValue, gotValue, keyPath, diffs)
+			}
+		}
+	case reflect.Struct:
+		for i := 0; i < want.NumField(); i++ {
+			if want.Type().Field(i).IsExported() {
+				compareValues(want.Field(i), got.Field(i), path+"."+want.Type().Field(i).Name, diffs)
+			}
+		}
+	default:
+		if !reflect.DeepEqual(want.Interface(), got.Interface()) {
+			report("%s != %s", describeValue(want), describeValue(got))
+		}
+	}
+}
+
+// mapKeys returns the keys of two maps of the same type, each once, sorted by their printed form.
+func mapKeys(a, b reflect.Value) []reflect.Value {
+	seen := make(map[any]bool)
+	var keys []reflect.Value
+	for _, m := range []reflect.Value{a, b} {
+		for _, key := range m.MapKeys() {
+			if !seen[key.Interface()] {
+				seen[key.Interface()] = true
+				keys = append(keys, key)
+			}
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool { return fmt.Sprint(keys[i]) < fmt.Sprint(keys[j]) })
+	return keys
+}
+
+// describeValue prints a value in a difference: strings are quoted, composite values only named by their type.
+func describeValue(v reflect.Value) string {
+	switch v.Kind() {
+	case reflect.String:
+		return fmt.Sprintf("%q", v.String())
+	case reflect.Struct, reflect.Slice, reflect.Array, reflect.Map:
+		return v.Type().String()
+	}
+	return fmt.Sprint(v)
+}
@@ -0,0 +1,46 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	ourtypes "github.com/vlad/ast2llm-go/internal/types"
+)
+
+func TestCompareProjectInfo(t *testing.T) {
+	want := ProjectInfo{
+		"/app/main.go": {
+			PackageName: "main",
+			Imports:     []*ourtypes.ImportInfo{{Path: "fmt"}},
+			Functions:   []*ourtypes.FunctionInfo{{Name: "main", Params: []string{}}},
+		},
+		"/app/util.go": {PackageName: "main"},
+	}
+
+	same := ProjectInfo{
+		"/app/main.go": {
+			PackageName: "main",
+			Imports:     []*ourtypes.ImportInfo{{Path: "fmt"}},
+			Functions:   []*ourtypes.FunctionInfo{{Name: "main"}},
+			Structs:     []*ourtypes.StructInfo{},
+		},
+		"/app/util.go": {PackageName: "main"},
+	}
+	assert.Empty(t, CompareProjectInfo(want, same), "nil and empty slices are equal")
+
+	got := ProjectInfo{
+		"/app/main.go": {
+			PackageName: "main",
+			Imports:     []*ourtypes.ImportInfo{{Path: "fmt", Alias: "f"}, {Path: "os"}},
+			Functions:   []*ourtypes.FunctionInfo{{Name: "run"}},
+		},
+		"/app/new.go": {PackageName: "main"},
+	}
+	assert.Equal(t, `["/app/main.go"].Imports: 1 items != 2 items
+["/app/main.go"].Functions[0].Name: "main" != "run"
+["/app/new.go"]: unexpected
+["/app/util.go"]: missing`, CompareProjectInfo(want, got))
+
+	got["/app/main.go"].Imports = []*ourtypes.ImportInfo{nil}
+	assert.Contains(t, CompareProjectInfo(want, got), `["/app/main.go"].Imports[0]: types.ImportInfo != nil`)
+}
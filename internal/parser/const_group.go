@@ -0,0 +1,70 @@
+package parser
+
+import (
+	"go/ast"
+	"go/token"
+	gotypes "go/types"
+	"strings"
+
+	ourtypes "github.com/vlad/ast2llm-go/internal/types"
+	"golang.org/x/tools/go/packages"
+)
+
+// isIotaBlock reports whether genDecl is a const block whose values refer to iota.
+func isIotaBlock(genDecl *ast.GenDecl, pkg *packages.Package) bool {
+	if genDecl.Tok != token.CONST {
+		return false
+	}
+	iota := gotypes.Universe.Lookup("iota")
+	found := false
+	for _, spec := range genDecl.Specs {
+		valSpec, ok := spec.(*ast.ValueSpec)
+		if !ok {
+			continue
+		}
+		for _, value := range valSpec.Values {
+			ast.Inspect(value, func(n ast.Node) bool {
+				if ident, ok := n.(*ast.Ident); ok && pkg.TypesInfo.Uses[ident] == iota {
+					found = true
+				}
+				return !found
+			})
+		}
+	}
+	return found
+}
+
+// extractConstGroup extracts an iota const block as a whole, keeping the declared order of its constants.
+// The type of the group is the type of its first constant.
+func (p *ProjectParser) extractConstGroup(genDecl *ast.GenDecl, pkg *packages.Package) *ourtypes.ConstGroup {
+	group := ourtypes.NewConstGroup()
+	if genDecl.Doc != nil {
+		group.Comment = strings.TrimSpace(genDecl.Doc.Text())
+	}
+	group.Pos = p.position(genDecl.Pos())
+
+	for _, spec := range genDecl.Specs {
+		valSpec, ok := spec.(*ast.ValueSpec)
+		if !ok {
+			continue
+		}
+		for i, name := range valSpec.Names {
+			obj := pkg.TypesInfo.Defs[name]
+			if obj == nil || name.Name == "_" {
+				continue
+			}
+			if len(group.Constants) == 0 {
+				group.Type = obj.Type().String()
+				if named, ok := obj.Type().(*gotypes.Named); ok {
+					group.Underlying = named.Underlying().String()
+				}
+			}
+			c := p.extractGlobalVarInfo(obj, genDecl, valSpec, i, pkg)
+			if valSpec.Doc == nil {
+				c.Comment = "" // Only the group carries the block comment
+			}
+			group.Constants = append(group.Constants, c)
+		}
+	}
+	return group
+}
@@ -0,0 +1,74 @@
+package parser
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProjectParser_ConstGroups(t *testing.T) {
+	projectPath := writeTestProject(t, map[string]string{
+		"main.go": `package main
+
+// Color is a paint color.
+type Color int
+
+// Supported colors.
+const (
+	// Red is the default.
+	Red Color = iota
+	Green
+	_
+	Blue
+)
+
+const (
+	KB = 1 << (10 * (iota + 1))
+	MB
+)
+
+const (
+	Name    = "app"
+	Version = 2
+)
+
+func main() {}
+`,
+	})
+
+	p := New()
+	fileInfos, err := p.ParseProject(projectPath)
+	require.NoError(t, err)
+	fileInfo := fileInfos[filepath.Join(projectPath, "main.go")]
+	require.NotNil(t, fileInfo)
+
+	require.Len(t, fileInfo.ConstGroups, 2)
+	colors := fileInfo.ConstGroups[0]
+	assert.Equal(t, "example.com/testproject.Color", colors.Type)
+	assert.Equal(t, "int", colors.Underlying)
+	assert.Equal(t, "Supported colors.", colors.Comment)
+	require.NotNil(t, colors.Pos)
+	require.Len(t, colors.Constants, 3)
+	assert.Equal(t, "Red", colors.Constants[0].Name)
+	assert.Equal(t, "0", colors.Constants[0].Value)
+	assert.Equal(t, "Red is the default.", colors.Constants[0].Comment)
+	assert.Equal(t, "Green", colors.Constants[1].Name)
+	assert.Empty(t, colors.Constants[1].Comment)
+	assert.Equal(t, "Blue", colors.Constants[2].Name)
+	assert.Equal(t, "3", colors.Constants[2].Value)
+
+	sizes := fileInfo.ConstGroups[1]
+	assert.Equal(t, "untyped int", sizes.Type)
+	assert.Empty(t, sizes.Underlying)
+	require.Len(t, sizes.Constants, 2)
+	assert.Equal(t, "1048576", sizes.Constants[1].Value)
+
+	// Blocks without iota stay plain constants, grouped constants are not repeated
+	var names []string
+	for _, gv := range fileInfo.GlobalVars {
+		names = append(names, gv.Name)
+	}
+	assert.Equal(t, []string{"Name", "Version"}, names)
+}
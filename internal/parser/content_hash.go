@@ -0,0 +1,64 @@
+package parser
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"go/ast"
+	goparser "go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	ourtypes "github.com/vlad/ast2llm-go/internal/types"
+)
+
+// contentHash returns the hex SHA-256 of content, as reported in FileInfo.ContentHash
+func contentHash(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
+
+// recordContent remembers the hash of src, the content of the file at path about to be parsed, for the
+// FileInfo of the file, see setFileStamp.
+func (p *ProjectParser) recordContent(path string, src []byte) {
+	p.contentHashes.Store(path, contentHash(src))
+}
+
+// projectFileParser returns the ParseFile function of go/packages loading the packages of the project at root.
+// It parses like the default one and records the content of the files below root, see recordContent.
+func (p *ProjectParser) projectFileParser(root string) func(*token.FileSet, string, []byte) (*ast.File, error) {
+	root, _ = filepath.Abs(root)
+	prefix := root + string(filepath.Separator)
+	return func(fset *token.FileSet, filename string, src []byte) (*ast.File, error) {
+		if strings.HasPrefix(filename, prefix) {
+			p.recordContent(filename, src)
+		}
+		return goparser.ParseFile(fset, filename, src, goparser.AllErrors|goparser.ParseComments)
+	}
+}
+
+// setFileStamp records the hash of the parsed content and the modification time of the file at path in
+// fileInfo. The content is only read again if it was not recorded when parsed; the stamps are left empty if
+// the file cannot be read.
+func (p *ProjectParser) setFileStamp(fileInfo *ourtypes.FileInfo, path string) {
+	if hash, ok := p.contentHashes.Load(path); ok {
+		fileInfo.ContentHash = hash.(string)
+	} else if content, err := os.ReadFile(path); err == nil {
+		fileInfo.ContentHash = contentHash(content)
+	} else {
+		return
+	}
+	fileInfo.ModTime = fileModTime(path)
+}
+
+// fileModTime returns the modification time of the file at path in RFC 3339 format, or "" if it cannot be
+// read.
+func fileModTime(path string) string {
+	stat, err := os.Stat(path)
+	if err != nil {
+		return ""
+	}
+	return stat.ModTime().UTC().Format(time.RFC3339Nano)
+}
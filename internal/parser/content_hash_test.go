@@ -0,0 +1,58 @@
+package parser
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProjectParser_ContentHash(t *testing.T) {
+	source := "package main\n\nfunc main() {}\n"
+	projectPath := writeTestProject(t, map[string]string{"main.go": source})
+	mainPath := filepath.Join(projectPath, "main.go")
+	sum := sha256.Sum256([]byte(source))
+
+	info, err := New().ParseProject(projectPath)
+	require.NoError(t, err)
+	require.Contains(t, info, mainPath)
+	assert.Equal(t, hex.EncodeToString(sum[:]), info[mainPath].ContentHash)
+	modTime, err := time.Parse(time.RFC3339Nano, info[mainPath].ModTime)
+	require.NoError(t, err)
+	stat, err := os.Stat(mainPath)
+	require.NoError(t, err)
+	assert.True(t, stat.ModTime().Equal(modTime))
+
+	buffer := []byte("package main\n\nfunc main() { unsaved() }\n\nfunc unsaved() {}\n")
+	info, err = New().ParseSource(projectPath, "main.go", buffer)
+	require.NoError(t, err)
+	require.Contains(t, info, mainPath)
+	assert.Equal(t, contentHash(buffer), info[mainPath].ContentHash, "sources are hashed as given")
+	assert.Empty(t, info[mainPath].ModTime)
+}
+
+func TestProjectParser_ContentHash_DiskCache(t *testing.T) {
+	projectPath := writeTestProject(t, map[string]string{"main.go": "package main\n\nfunc main() {}\n"})
+	mainPath := filepath.Join(projectPath, "main.go")
+	cacheDir := t.TempDir()
+
+	p := New(WithDiskCache(cacheDir))
+	_, err := p.ParseProject(projectPath)
+	require.NoError(t, err)
+	_, recorded := p.contentHashes.Load(mainPath)
+	assert.True(t, recorded, "the parsed content is hashed")
+
+	touched := time.Date(2030, 1, 2, 3, 4, 5, 0, time.UTC)
+	require.NoError(t, os.Chtimes(mainPath, touched, touched))
+	info, err := New(WithDiskCache(cacheDir)).ParseProject(projectPath)
+	require.NoError(t, err)
+	stats, err := ReadDiskCacheStats(cacheDir)
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, stats.Hits, "the content is unchanged")
+	assert.Equal(t, touched.Format(time.RFC3339Nano), info[mainPath].ModTime, "modification times are not served from the cache")
+}
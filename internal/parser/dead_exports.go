@@ -0,0 +1,122 @@
+package parser
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	gotypes "go/types"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	ourtypes "github.com/vlad/ast2llm-go/internal/types"
+)
+
+// declaredExport is an exported package-level symbol together with the extent of its declaration
+type declaredExport struct {
+	export     *ourtypes.DeadExport
+	start, end token.Pos // Extent of the declaration, uses within it such as recursive calls do not count
+}
+
+// FindDeadExports loads the project and lists its exported package-level functions, types, variables and
+// constants that are referred to nowhere in the project but in their own declaration, sorted by position.
+// Methods are left out since they may be called through interfaces, and so are the declarations of generated,
+// excluded and _test.go files. References from _test.go files only count if tests are included, see
+// WithTests. Packages meant to be imported by other modules naturally have unreferenced exports.
+func (p *ProjectParser) FindDeadExports(projectPath string) ([]*ourtypes.DeadExport, error) {
+	root, err := filepath.Abs(projectPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve project path: %w", err)
+	}
+	pkgs, err := p.loadPackages(projectPath, "./...")
+	if err != nil {
+		return nil, err
+	}
+
+	// Objects are keyed by qualified name rather than identity, since a package compiled with its tests is a
+	// different package to the type checker than the one its importers see.
+	declared := make(map[string]*declaredExport)
+	for _, pkg := range pkgs {
+		for _, file := range pkg.Syntax {
+			path := p.fset.File(file.Pos()).Name()
+			if strings.HasSuffix(path, "_test.go") || ast.IsGenerated(file) || p.excluded(root, path) {
+				continue
+			}
+			for _, decl := range file.Decls {
+				p.addDeclaredExports(declared, decl, pkg.TypesInfo)
+			}
+		}
+	}
+
+	referenced := make(map[string]bool)
+	for _, pkg := range pkgs {
+		for ident, obj := range pkg.TypesInfo.Uses {
+			key := packageLevelKey(obj)
+			if key == "" || referenced[key] {
+				continue
+			}
+			if d, ok := declared[key]; ok && d.start <= ident.Pos() && ident.Pos() < d.end {
+				continue
+			}
+			referenced[key] = true
+		}
+	}
+
+	dead := make([]*ourtypes.DeadExport, 0)
+	for key, d := range declared {
+		if !referenced[key] {
+			dead = append(dead, d.export)
+		}
+	}
+	sort.Slice(dead, func(i, j int) bool { return positionBefore(dead[i].Pos, dead[j].Pos) })
+	return dead, nil
+}
+
+// addDeclaredExports adds the exported package-level symbols declared by decl to declared.
+func (p *ProjectParser) addDeclaredExports(declared map[string]*declaredExport, decl ast.Decl, info *gotypes.Info) {
+	add := func(ident *ast.Ident, kind string, node ast.Node) {
+		obj := info.Defs[ident]
+		key := packageLevelKey(obj)
+		if key == "" || !obj.Exported() {
+			return
+		}
+		declared[key] = &declaredExport{
+			export: &ourtypes.DeadExport{Name: key, Kind: kind, Pos: p.position(ident.Pos())},
+			start:  node.Pos(),
+			end:    node.End(),
+		}
+	}
+
+	switch decl := decl.(type) {
+	case *ast.FuncDecl:
+		if decl.Recv == nil {
+			add(decl.Name, "func", decl)
+		}
+	case *ast.GenDecl:
+		for _, spec := range decl.Specs {
+			switch spec := spec.(type) {
+			case *ast.TypeSpec:
+				add(spec.Name, "type", spec)
+			case *ast.ValueSpec:
+				kind := "var"
+				if decl.Tok == token.CONST {
+					kind = "const"
+				}
+				for _, name := range spec.Names {
+					add(name, kind, spec)
+				}
+			}
+		}
+	}
+}
+
+// packageLevelKey returns the qualified name of a package-level object, or "" for other objects.
+func packageLevelKey(obj gotypes.Object) string {
+	if fn, ok := obj.(*gotypes.Func); ok {
+		obj = fn.Origin()
+	}
+	if obj == nil || obj.Pkg() == nil || obj.Parent() != obj.Pkg().Scope() {
+		return ""
+	}
+	return obj.Pkg().Path() + "." + obj.Name()
+}
@@ -0,0 +1,82 @@
+package parser
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	ourtypes "github.com/vlad/ast2llm-go/internal/types"
+)
+
+func TestProjectParser_FindDeadExports(t *testing.T) {
+	projectPath := writeTestProject(t, map[string]string{
+		"store/store.go": `package store
+
+// Store holds values.
+type Store struct{ values map[string]string }
+
+// Unused is referred to by nobody.
+type Unused struct{}
+
+// Limit is used by main.
+const Limit = 10
+
+// Timeout and Retries are declared together.
+var Timeout, Retries = 1, 2
+
+// New is used by main.
+func New() *Store { return &Store{} }
+
+// Walk only calls itself.
+func Walk(n int) int {
+	if n == 0 {
+		return 0
+	}
+	return Walk(n - 1)
+}
+
+// helper is not exported.
+func helper() {}
+
+// Get is a method, which may be called through an interface.
+func (s *Store) Get(key string) string { return s.values[key] }
+
+// Generic is used with a type argument.
+func Generic[T any](v T) T { return v }
+`,
+		"store/store_test.go": `package store
+
+func UsedByTestsOnly() {}
+`,
+		"store/gen.go": `// Code generated by hand. DO NOT EDIT.
+
+package store
+
+func Generated() {}
+`,
+		"main.go": `package main
+
+import "example.com/testproject/store"
+
+func main() {
+	_ = store.New()
+	_ = store.Limit + store.Retries
+	_ = store.Generic(1)
+}
+`,
+	})
+	storePath := filepath.Join(projectPath, "store", "store.go")
+
+	dead, err := New().FindDeadExports(projectPath)
+	require.NoError(t, err)
+	assert.Equal(t, []*ourtypes.DeadExport{
+		{Name: "example.com/testproject/store.Unused", Kind: "type", Pos: &ourtypes.Position{File: storePath, Line: 7, Column: 6}},
+		{Name: "example.com/testproject/store.Timeout", Kind: "var", Pos: &ourtypes.Position{File: storePath, Line: 13, Column: 5}},
+		{Name: "example.com/testproject/store.Walk", Kind: "func", Pos: &ourtypes.Position{File: storePath, Line: 19, Column: 6}},
+	}, dead)
+
+	dead, err = New(WithTests()).FindDeadExports(projectPath)
+	require.NoError(t, err)
+	assert.Len(t, dead, 3, "declarations of _test.go files are left out")
+}
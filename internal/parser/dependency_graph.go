@@ -0,0 +1,74 @@
+package parser
+
+import (
+	"context"
+	"go/ast"
+	"sort"
+	"strconv"
+
+	ourtypes "github.com/vlad/ast2llm-go/internal/types"
+	"golang.org/x/tools/go/packages"
+)
+
+// BuildGraph loads the project and builds its package dependency graph.
+// Every project package becomes a node with its imports and the project packages importing it.
+func (p *ProjectParser) BuildGraph(projectPath string) (*ourtypes.DependencyGraph, error) {
+	return p.BuildGraphContext(context.Background(), projectPath)
+}
+
+// BuildGraphContext works like BuildGraph, stopping the go command loading the packages when ctx is done.
+func (p *ProjectParser) BuildGraphContext(ctx context.Context, projectPath string) (*ourtypes.DependencyGraph, error) {
+	pkgs, err := p.loadPackagesMode(ctx, loadMode, projectPath, nil, "./...")
+	if err != nil {
+		return nil, err
+	}
+
+	graph := ourtypes.NewDependencyGraph()
+	for _, pkg := range pkgs {
+		node := ourtypes.NewNode()
+		node.PkgPath = pkg.PkgPath
+		node.Files = append(node.Files, pkg.GoFiles...)
+
+		node.DependsOn = packageImports(pkg)
+
+		node.Doc = p.packageDoc(pkg)
+
+		for _, file := range pkg.Syntax {
+			for _, decl := range file.Decls {
+				if funcDecl, ok := decl.(*ast.FuncDecl); ok && funcDecl.Recv == nil && funcDecl.Name.IsExported() {
+					node.Functions = append(node.Functions, funcDecl.Name.Name)
+				}
+			}
+		}
+		sort.Strings(node.Functions)
+
+		graph.Nodes[pkg.PkgPath] = node
+	}
+
+	graph.LinkReverseDeps()
+
+	return graph, nil
+}
+
+// packageImports returns the sorted import paths of pkg. The import declarations of its files are used
+// in addition to pkg.Imports, which leaves out the edge closing an import cycle.
+func packageImports(pkg *packages.Package) []string {
+	seen := make(map[string]bool, len(pkg.Imports))
+	for imp := range pkg.Imports {
+		seen[imp] = true
+	}
+	for _, file := range pkg.Syntax {
+		for _, spec := range file.Imports {
+			if imp, err := strconv.Unquote(spec.Path.Value); err == nil && imp != "C" {
+				seen[imp] = true
+			}
+		}
+	}
+
+	imports := make([]string, 0, len(seen))
+	for imp := range seen {
+		imports = append(imports, imp)
+	}
+	sort.Strings(imports)
+	return imports
+}
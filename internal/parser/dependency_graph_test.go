@@ -0,0 +1,90 @@
+package parser
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProjectParser_BuildGraph(t *testing.T) {
+	projectPath := writeTestProject(t, map[string]string{
+		"db/db.go": `// Package db wraps the database.
+package db
+
+// Open opens the database.
+func Open() {}
+
+func internal() {}
+`,
+		"svc/svc.go": `package svc
+
+import "example.com/testproject/db"
+
+// Start starts the service.
+func Start() { db.Open() }
+`,
+		"main.go": `package main
+
+import (
+	"fmt"
+
+	"example.com/testproject/db"
+	"example.com/testproject/svc"
+)
+
+func main() {
+	db.Open()
+	svc.Start()
+	fmt.Println()
+}
+`,
+	})
+
+	p := New()
+	graph, err := p.BuildGraph(projectPath)
+	require.NoError(t, err)
+	require.Len(t, graph.Nodes, 3)
+
+	mainNode := graph.Nodes["example.com/testproject"]
+	require.NotNil(t, mainNode)
+	assert.Equal(t, []string{"example.com/testproject/db", "example.com/testproject/svc", "fmt"}, mainNode.DependsOn)
+	assert.Len(t, mainNode.Files, 1)
+
+	dbNode := graph.Nodes["example.com/testproject/db"]
+	require.NotNil(t, dbNode)
+	assert.Equal(t, []string{"Open"}, dbNode.Functions)
+	assert.Equal(t, "Package db wraps the database.", dbNode.Doc)
+	assert.Empty(t, mainNode.Doc)
+	assert.Equal(t, []string{"example.com/testproject", "example.com/testproject/svc"}, graph.ReverseDeps("example.com/testproject/db"))
+	assert.Equal(t, []string{"example.com/testproject"}, graph.ReverseDeps("example.com/testproject/svc"))
+}
+
+func TestProjectParser_BuildGraph_ImportCycle(t *testing.T) {
+	projectPath := writeTestProject(t, map[string]string{
+		"a/a.go": "package a\n\nimport \"example.com/testproject/b\"\n\nfunc A() { b.B() }\n",
+		"b/b.go": "package b\n\nimport \"example.com/testproject/a\"\n\nfunc B() { a.A() }\n",
+	})
+
+	graph, err := New().BuildGraph(projectPath)
+	require.NoError(t, err)
+
+	cycles := graph.DetectCycles()
+	require.Len(t, cycles, 1)
+	assert.Equal(t, []string{"example.com/testproject/a", "example.com/testproject/b"}, cycles[0].Packages)
+	assert.False(t, cycles[0].ViaInternal)
+}
+
+func TestProjectParser_BuildGraphContext_Cancelled(t *testing.T) {
+	projectPath := writeTestProject(t, map[string]string{"main.go": "package main\n\nfunc main() {}\n"})
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := New().BuildGraphContext(ctx, projectPath)
+	assert.ErrorIs(t, err, context.Canceled)
+
+	graph, err := New().BuildGraphContext(context.Background(), projectPath)
+	require.NoError(t, err)
+	assert.Contains(t, graph.Nodes, "example.com/testproject")
+}
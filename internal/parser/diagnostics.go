@@ -0,0 +1,64 @@
+package parser
+
+import (
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	ourtypes "github.com/vlad/ast2llm-go/internal/types"
+	"golang.org/x/tools/go/packages"
+)
+
+// fileDiagnostics returns the errors of pkg reported for the file at absolutePath.
+func fileDiagnostics(pkg *packages.Package, absolutePath string) []*ourtypes.Diagnostic {
+	diagnostics := make([]*ourtypes.Diagnostic, 0)
+	for _, err := range pkg.Errors {
+		pos := parseErrorPos(err.Pos)
+		if pos == nil || filepath.Clean(pos.File) != absolutePath {
+			continue
+		}
+		diagnostics = append(diagnostics, &ourtypes.Diagnostic{
+			Kind:    diagnosticKind(err.Kind),
+			Message: err.Msg,
+			Pos:     pos,
+		})
+	}
+	return diagnostics
+}
+
+// diagnosticKind names the kind of a packages.Error.
+func diagnosticKind(kind packages.ErrorKind) string {
+	switch kind {
+	case packages.ParseError:
+		return "syntax"
+	case packages.TypeError:
+		return "type"
+	default:
+		return "load"
+	}
+}
+
+// parseErrorPos parses the "file:line:column" or "file:line" position of a packages.Error.
+// It returns nil for errors without a position.
+func parseErrorPos(pos string) *ourtypes.Position {
+	parts := strings.Split(pos, ":")
+	// Trailing numeric elements are the line and column; the file name itself may contain colons.
+	var nums []int
+	for len(parts) > 1 && len(nums) < 2 {
+		n, err := strconv.Atoi(parts[len(parts)-1])
+		if err != nil {
+			break
+		}
+		nums = append([]int{n}, nums...)
+		parts = parts[:len(parts)-1]
+	}
+	if len(nums) == 0 {
+		return nil
+	}
+
+	position := &ourtypes.Position{File: strings.Join(parts, ":"), Line: nums[0]}
+	if len(nums) == 2 {
+		position.Column = nums[1]
+	}
+	return position
+}
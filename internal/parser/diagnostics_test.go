@@ -0,0 +1,65 @@
+package parser
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	ourtypes "github.com/vlad/ast2llm-go/internal/types"
+)
+
+func TestProjectParser_ParseProject_Diagnostics(t *testing.T) {
+	projectPath := writeTestProject(t, map[string]string{
+		"main.go": `package main
+
+func main() {
+	var n int = "text"
+	_ = n
+}
+`,
+		"ok.go": `package main
+
+func ok() {}
+`,
+		"broken/broken.go": `package broken
+
+func Broken( {
+`,
+	})
+
+	fileInfos, err := New().ParseProject(projectPath)
+	require.NoError(t, err)
+
+	mainInfo := fileInfos[filepath.Join(projectPath, "main.go")]
+	require.NotNil(t, mainInfo)
+	require.Len(t, mainInfo.Diagnostics, 1)
+	assert.Equal(t, "type", mainInfo.Diagnostics[0].Kind)
+	assert.Contains(t, mainInfo.Diagnostics[0].Message, "cannot use \"text\"")
+	assert.Equal(t, 4, mainInfo.Diagnostics[0].Pos.Line)
+	assert.Equal(t, 14, mainInfo.Diagnostics[0].Pos.Column)
+
+	assert.Empty(t, fileInfos[filepath.Join(projectPath, "ok.go")].Diagnostics)
+
+	brokenInfo := fileInfos[filepath.Join(projectPath, "broken", "broken.go")]
+	require.NotNil(t, brokenInfo)
+	require.NotEmpty(t, brokenInfo.Diagnostics)
+	assert.Equal(t, "syntax", brokenInfo.Diagnostics[0].Kind)
+	assert.Equal(t, 3, brokenInfo.Diagnostics[0].Pos.Line)
+}
+
+func TestParseErrorPos(t *testing.T) {
+	tests := []struct {
+		pos  string
+		want *ourtypes.Position
+	}{
+		{pos: "/p/main.go:4:14", want: &ourtypes.Position{File: "/p/main.go", Line: 4, Column: 14}},
+		{pos: "/p/main.go:4", want: &ourtypes.Position{File: "/p/main.go", Line: 4}},
+		{pos: "C:/p/main.go:4:2", want: &ourtypes.Position{File: "C:/p/main.go", Line: 4, Column: 2}},
+		{pos: "", want: nil},
+		{pos: "-", want: nil},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, parseErrorPos(tt.pos), tt.pos)
+	}
+}
@@ -0,0 +1,266 @@
+package parser
+
+import (
+	"bufio"
+	"fmt"
+	"go/ast"
+	gotypes "go/types"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ExtractDiffContext loads the project and returns the FileInfo fragments relevant to the top-level
+// declarations touched by unifiedDiff, collected like in ExtractSymbolContext, along with the names of those
+// declarations (pkg.Name or pkg.Type.Method). File paths of the diff may be relative to the project or to
+// any parent directory of it, such as the root of its git repository; changes to non-Go files are ignored.
+func (p *ProjectParser) ExtractDiffContext(projectPath, unifiedDiff string) (ProjectInfo, []string, error) {
+	changes, err := parseUnifiedDiff(unifiedDiff)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	pkgs, err := p.loadPackages(projectPath, "./...")
+	if err != nil {
+		return nil, nil, err
+	}
+	sc := newSymbolContext(p, pkgs)
+
+	var targets []gotypes.Object
+	var names []string
+	for _, path := range sortedKeys(changes) {
+		file := sc.findFile(path)
+		if file == nil {
+			continue
+		}
+		for _, obj := range sc.changedObjects(file, changes[path]) {
+			targets = append(targets, obj)
+			names = append(names, qualifiedObjectName(obj))
+		}
+	}
+	sc.expand(targets)
+
+	return sc.result(), names, nil
+}
+
+// parseUnifiedDiff returns the changed lines of every file of a unified diff, keyed by the path on the new
+// side. Added lines are reported with their new line number and removed lines with the number of the line
+// now following them. Deleted files are skipped.
+func parseUnifiedDiff(diff string) (map[string][]int, error) {
+	changes := make(map[string][]int)
+	var path string
+	var h hunk // Remaining lines of the current hunk
+
+	scanner := bufio.NewScanner(strings.NewReader(diff))
+	scanner.Buffer(make([]byte, 0, 64*1024), 10*1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if h.oldLeft == 0 && h.newLeft == 0 {
+			switch {
+			case strings.HasPrefix(line, "+++ "):
+				path = diffPath(strings.TrimPrefix(line, "+++ "))
+			case strings.HasPrefix(line, "@@ "):
+				var err error
+				if h, err = parseHunkHeader(line); err != nil {
+					return nil, err
+				}
+			}
+			// Anything else is a header line such as "diff --git", "index" or "---"
+			continue
+		}
+
+		switch {
+		case strings.HasPrefix(line, "+"):
+			if path != "" {
+				changes[path] = append(changes[path], h.newLine)
+			}
+			h.newLine++
+			h.newLeft--
+		case strings.HasPrefix(line, "-"):
+			if path != "" {
+				changes[path] = append(changes[path], h.newLine)
+			}
+			h.oldLeft--
+		case strings.HasPrefix(line, "\\"):
+			// "\ No newline at end of file"
+		default:
+			// Context line, whose leading space may have been stripped from empty lines
+			h.newLine++
+			h.oldLeft--
+			h.newLeft--
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read diff: %w", err)
+	}
+	return changes, nil
+}
+
+// hunk tracks the position within a hunk of a unified diff
+type hunk struct {
+	newLine int // Line number on the new side of the next line
+	oldLeft int // Old side lines not read yet
+	newLeft int // New side lines not read yet
+}
+
+// parseHunkHeader parses a hunk header such as "@@ -12,7 +12,9 @@ func main() {"
+func parseHunkHeader(header string) (hunk, error) {
+	fields := strings.Fields(header)
+	if len(fields) < 3 || !strings.HasPrefix(fields[1], "-") || !strings.HasPrefix(fields[2], "+") {
+		return hunk{}, fmt.Errorf("invalid hunk header: %s", header)
+	}
+	_, oldCount, err := parseHunkRange(fields[1][1:])
+	if err != nil {
+		return hunk{}, fmt.Errorf("invalid hunk header %q: %w", header, err)
+	}
+	newStart, newCount, err := parseHunkRange(fields[2][1:])
+	if err != nil {
+		return hunk{}, fmt.Errorf("invalid hunk header %q: %w", header, err)
+	}
+	return hunk{newLine: newStart, oldLeft: oldCount, newLeft: newCount}, nil
+}
+
+// parseHunkRange parses "start,count" or "start", where the count defaults to 1
+func parseHunkRange(r string) (int, int, error) {
+	startText, countText, hasCount := strings.Cut(r, ",")
+	start, err := strconv.Atoi(startText)
+	if err != nil {
+		return 0, 0, err
+	}
+	if !hasCount {
+		return start, 1, nil
+	}
+	count, err := strconv.Atoi(countText)
+	if err != nil {
+		return 0, 0, err
+	}
+	return start, count, nil
+}
+
+// diffPath strips the "b/" prefix and any trailing timestamp from a "+++" path, returning "" for /dev/null
+func diffPath(path string) string {
+	if i := strings.IndexByte(path, '\t'); i >= 0 {
+		path = path[:i]
+	}
+	if path == "/dev/null" {
+		return ""
+	}
+	return strings.TrimPrefix(path, "b/")
+}
+
+// findFile resolves a path of the diff to a loaded file: an absolute path is matched as is, a relative one
+// as the trailing part of the shortest loaded file path ending with it.
+func (sc *symbolContext) findFile(path string) *ast.File {
+	if !strings.HasSuffix(path, ".go") {
+		return nil
+	}
+	var bestPath string
+	var best *ast.File
+	for filePath, file := range sc.files {
+		slashed := filepath.ToSlash(filePath)
+		if slashed == path {
+			return file
+		}
+		if strings.HasSuffix(slashed, "/"+path) && (best == nil || len(filePath) < len(bestPath)) {
+			bestPath, best = filePath, file
+		}
+	}
+	return best
+}
+
+// changedObjects returns the objects declared by the top-level declarations of file spanning any of lines,
+// in source order.
+func (sc *symbolContext) changedObjects(file *ast.File, lines []int) []gotypes.Object {
+	pkg := sc.packageOf(file)
+	if pkg == nil {
+		return nil
+	}
+	// touched reports whether any of lines falls within n or its doc comment
+	touched := func(n ast.Node, doc *ast.CommentGroup) bool {
+		start, end := sc.p.fset.Position(n.Pos()).Line, sc.p.fset.Position(n.End()).Line
+		if doc != nil {
+			start = sc.p.fset.Position(doc.Pos()).Line
+		}
+		for _, line := range lines {
+			if line >= start && line <= end {
+				return true
+			}
+		}
+		return false
+	}
+
+	var objs []gotypes.Object
+	for _, decl := range file.Decls {
+		switch d := decl.(type) {
+		case *ast.FuncDecl:
+			if !touched(d, d.Doc) {
+				continue
+			}
+			if obj := pkg.TypesInfo.Defs[d.Name]; obj != nil {
+				objs = append(objs, obj)
+			}
+		case *ast.GenDecl:
+			if !touched(d, d.Doc) {
+				continue
+			}
+			for _, spec := range d.Specs {
+				// A lone spec counts as touched by changes to the doc comment or keyword line of its declaration
+				switch s := spec.(type) {
+				case *ast.TypeSpec:
+					if len(d.Specs) > 1 && !touched(s, s.Doc) {
+						continue
+					}
+					if obj := pkg.TypesInfo.Defs[s.Name]; obj != nil {
+						objs = append(objs, obj)
+					}
+				case *ast.ValueSpec:
+					if len(d.Specs) > 1 && !touched(s, s.Doc) {
+						continue
+					}
+					for _, name := range s.Names {
+						if obj := pkg.TypesInfo.Defs[name]; obj != nil && name.Name != "_" {
+							objs = append(objs, obj)
+						}
+					}
+				}
+			}
+		}
+	}
+	return objs
+}
+
+// qualifiedObjectName names a package-level object as pkg.Name and a method as pkg.Type.Method
+func qualifiedObjectName(obj gotypes.Object) string {
+	if obj.Pkg() == nil {
+		return memberName(obj)
+	}
+	return obj.Pkg().Name() + "." + memberName(obj)
+}
+
+// memberName returns the name of obj, prefixed with the name of the receiver type for methods, e.g. Type.Method
+func memberName(obj gotypes.Object) string {
+	name := obj.Name()
+	if fn, ok := obj.(*gotypes.Func); ok {
+		if recv := fn.Type().(*gotypes.Signature).Recv(); recv != nil {
+			recvType := recv.Type()
+			if ptr, ok := recvType.(*gotypes.Pointer); ok {
+				recvType = ptr.Elem()
+			}
+			if named, ok := recvType.(*gotypes.Named); ok {
+				name = named.Obj().Name() + "." + name
+			}
+		}
+	}
+	return name
+}
+
+// sortedKeys returns the keys of m in ascending order
+func sortedKeys(m map[string][]int) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
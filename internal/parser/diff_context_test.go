@@ -0,0 +1,82 @@
+package parser
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseUnifiedDiff(t *testing.T) {
+	diff := `diff --git a/models/user.go b/models/user.go
+index 1111111..2222222 100644
+--- a/models/user.go
++++ b/models/user.go
+@@ -9,4 +9,5 @@ type User struct {
+ // Rename changes the name.
+ func (u *User) Rename(name string) {
+-	u.Name = name
++	name = strings.TrimSpace(name)
++	u.Name = name
+ }
+--- service/service.go	2024-01-01 00:00:00
++++ service/service.go	2024-01-02 00:00:00
+@@ -12 +12 @@
+-func Count() int {
++func Count() int64 {
+diff --git a/old.go b/old.go
+deleted file mode 100644
+--- a/old.go
++++ /dev/null
+@@ -1,2 +0,0 @@
+-package old
+-
+`
+	changes, err := parseUnifiedDiff(diff)
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]int{
+		"models/user.go":     {11, 11, 12},
+		"service/service.go": {12, 12},
+	}, changes)
+
+	_, err = parseUnifiedDiff("+++ b/main.go\n@@ -a +1 @@\n")
+	assert.ErrorContains(t, err, "invalid hunk header")
+}
+
+func TestProjectParser_ExtractDiffContext(t *testing.T) {
+	projectPath := writeTestProject(t, symbolContextProject)
+	modelsPath := filepath.Join(projectPath, "models", "user.go")
+	servicePath := filepath.Join(projectPath, "service", "service.go")
+
+	t.Run("method body", func(t *testing.T) {
+		diff := "--- a/models/user.go\n+++ b/models/user.go\n@@ -11 +11 @@\n-\tu.Name = name\n+\tu.Name = name\n"
+		info, changed, err := New(WithSymbolDepth(0)).ExtractDiffContext(projectPath, diff)
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"models.User.Rename"}, changed)
+		require.Len(t, info[modelsPath].Methods, 1)
+		assert.Equal(t, "Rename", info[modelsPath].Methods[0].Name)
+		assert.ElementsMatch(t, []string{"Save"}, functionNames(info[servicePath]))
+	})
+
+	t.Run("type and function in a repository subdirectory", func(t *testing.T) {
+		dir := filepath.Base(projectPath)
+		diff := "--- a/" + dir + "/models/user.go\n+++ b/" + dir + "/models/user.go\n@@ -24,2 +24,2 @@\n-// Unrelated is never referenced by User.\n+// Unrelated is not referenced by User.\n type Unrelated struct{}\n" +
+			"--- a/" + dir + "/service/service.go\n+++ b/" + dir + "/service/service.go\n@@ -12,3 +12,3 @@\n func Count() int {\n-\treturn 0\n+\treturn 1\n }\n"
+		info, changed, err := New().ExtractDiffContext(projectPath, diff)
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"models.Unrelated", "service.Count"}, changed)
+		assert.ElementsMatch(t, []string{"example.com/testproject/models.Unrelated"}, structNames(info))
+		assert.ElementsMatch(t, []string{"Count"}, functionNames(info[servicePath]))
+	})
+
+	t.Run("no declaration touched", func(t *testing.T) {
+		diff := "--- a/README.md\n+++ b/README.md\n@@ -1 +1 @@\n-old\n+new\n"
+		info, changed, err := New().ExtractDiffContext(projectPath, diff)
+		require.NoError(t, err)
+		assert.Empty(t, changed)
+		assert.Empty(t, info)
+	})
+}
@@ -0,0 +1,83 @@
+package parser
+
+import (
+	"go/ast"
+	"strings"
+
+	ourtypes "github.com/vlad/ast2llm-go/internal/types"
+)
+
+// extractDirectives returns the directive comments of file in source order, e.g. //go:generate, //go:build,
+// //nolint:errcheck or //ast2llm:keep, with the top-level declaration they belong to.
+func (p *ProjectParser) extractDirectives(file *ast.File) []*ourtypes.Directive {
+	directives := make([]*ourtypes.Directive, 0)
+	for _, group := range file.Comments {
+		for _, c := range group.List {
+			name, args, ok := parseDirective(c.Text)
+			if !ok {
+				continue
+			}
+			directives = append(directives, &ourtypes.Directive{
+				Name:   name,
+				Args:   args,
+				Target: p.directiveTarget(file, c),
+				Pos:    p.position(c.Pos()),
+			})
+		}
+	}
+	return directives
+}
+
+// parseDirective splits a directive comment into its name and arguments, e.g. "//go:generate stringer -type=Color"
+// into "go:generate" and "stringer -type=Color". Like go/ast, directives are line comments without a space after
+// the slashes whose name is a lower-case word followed by a colon; //nolint is accepted on its own as well.
+func parseDirective(text string) (name, args string, ok bool) {
+	text, ok = strings.CutPrefix(text, "//")
+	if !ok {
+		return "", "", false
+	}
+	name, args, _ = strings.Cut(text, " ")
+	prefix, rest, hasColon := strings.Cut(name, ":")
+	switch {
+	case name == "nolint":
+	case !hasColon || !isDirectiveWord(prefix) || rest == "" || !isDirectiveWord(rest[:1]):
+		return "", "", false
+	}
+	return name, strings.TrimSpace(args), true
+}
+
+// isDirectiveWord reports whether s is made of lower-case letters and digits only.
+func isDirectiveWord(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if !('a' <= r && r <= 'z' || '0' <= r && r <= '9') {
+			return false
+		}
+	}
+	return true
+}
+
+// directiveTarget describes the top-level declaration a directive comment belongs to, e.g. "type Color", i.e.
+// the one documented by, containing or ending on the line of the comment. It is empty for file-level directives.
+func (p *ProjectParser) directiveTarget(file *ast.File, c *ast.Comment) string {
+	line := p.fset.Position(c.Pos()).Line
+	for _, decl := range file.Decls {
+		start := decl.Pos()
+		switch d := decl.(type) {
+		case *ast.FuncDecl:
+			if d.Doc != nil {
+				start = d.Doc.Pos()
+			}
+		case *ast.GenDecl:
+			if d.Doc != nil {
+				start = d.Doc.Pos()
+			}
+		}
+		if (start <= c.Pos() && c.Pos() < decl.End()) || p.fset.Position(decl.End()).Line == line {
+			return declOwner(decl)
+		}
+	}
+	return ""
+}
@@ -0,0 +1,74 @@
+package parser
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	ourtypes "github.com/vlad/ast2llm-go/internal/types"
+)
+
+func TestProjectParser_ParseProject_Directives(t *testing.T) {
+	projectPath := writeTestProject(t, map[string]string{
+		"color.go": `//go:build !windows
+
+// Package main prints colors.
+package main
+
+import "fmt"
+
+// Color is a color.
+//
+//go:generate stringer -type=Color
+type Color int
+
+// See http://example.com for details; TODO: not a directive either.
+//
+//ast2llm:keep
+func Print(c Color) {
+	fmt.Println(c) //nolint:errcheck // Printing never fails
+}
+
+var debug = false //nolint
+
+// go:generate is not a directive with a space
+func main() {}
+`,
+	})
+
+	fileInfos, err := New().ParseProject(projectPath)
+	require.NoError(t, err)
+	info := fileInfos[filepath.Join(projectPath, "color.go")]
+
+	var directives []ourtypes.Directive
+	for _, d := range info.Directives {
+		require.NotNil(t, d.Pos)
+		directives = append(directives, ourtypes.Directive{Name: d.Name, Args: d.Args, Target: d.Target})
+	}
+	assert.Equal(t, []ourtypes.Directive{
+		{Name: "go:build", Args: "!windows"},
+		{Name: "go:generate", Args: "stringer -type=Color", Target: "type Color"},
+		{Name: "ast2llm:keep", Target: "func Print"},
+		{Name: "nolint:errcheck", Args: "// Printing never fails", Target: "func Print"},
+		{Name: "nolint", Target: "var debug"},
+	}, directives)
+	assert.Equal(t, 10, info.Directives[1].Pos.Line)
+}
+
+func TestParseDirective(t *testing.T) {
+	for text, want := range map[string][2]string{
+		"//go:generate go run gen.go": {"go:generate", "go run gen.go"},
+		"//go:embed static/*":         {"go:embed", "static/*"},
+		"//nolint":                    {"nolint", ""},
+		"//lint:ignore SA1019 reason": {"lint:ignore", "SA1019 reason"},
+	} {
+		name, args, ok := parseDirective(text)
+		assert.True(t, ok, text)
+		assert.Equal(t, want, [2]string{name, args}, text)
+	}
+	for _, text := range []string{"// go:generate x", "//TODO: later", "//http://example.com", "//line a.go:1", "/*go:generate x*/", "//nolintx"} {
+		_, _, ok := parseDirective(text)
+		assert.False(t, ok, text)
+	}
+}
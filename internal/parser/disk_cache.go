@@ -0,0 +1,361 @@
+package parser
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"time"
+)
+
+// diskCacheVersion is incremented whenever the extraction changes, so that older entries are no longer used
+const diskCacheVersion = 15
+
+// diskCacheRoot replaces the project path in persisted entries, so that an entry written for a checkout in one
+// place, e.g. by CI, is used for an identical checkout elsewhere
+const diskCacheRoot = "@@ast2llm-project-root@@"
+
+// diskCacheEntry is the persisted result of ParseProject for one state of a project
+type diskCacheEntry struct {
+	Version int             `json:"version"`
+	Root    string          `json:"root"`    // Absolute project path the entry was written for
+	Options string          `json:"options"` // Fingerprint of the parser options, see optionsFingerprint
+	Created time.Time       `json:"created"`
+	Files   json.RawMessage `json:"files"` // ProjectInfo, with diskCacheRoot in place of Root
+}
+
+// diskCacheCounters are the lookup counters persisted next to the entries
+type diskCacheCounters struct {
+	Hits   int64 `json:"hits"`
+	Misses int64 `json:"misses"`
+}
+
+// DiskCacheStats describes the content and use of a persistent cache directory
+type DiskCacheStats struct {
+	Dir     string // Cache directory
+	Entries int    // Persisted project states
+	Bytes   int64  // Total size of the entries
+	Stale   int    // Entries whose project is gone or has changed since, see ClearDiskCache
+	Hits    int64  // ParseProject calls served from the cache
+	Misses  int64  // ParseProject calls that parsed the project and stored the result
+}
+
+// HitRate returns the share of ParseProject calls served from the cache, 0 before any use
+func (s *DiskCacheStats) HitRate() float64 {
+	if total := s.Hits + s.Misses; total > 0 {
+		return float64(s.Hits) / float64(total)
+	}
+	return 0
+}
+
+// WithDiskCache persists the results of ParseProject in dir. A project whose Go files, go.mod, go.sum and
+// go.work are unchanged since it was last parsed with the same options is read back instead of being parsed.
+// Entries are keyed by content rather than path, so a cache warmed in CI serves identical checkouts elsewhere.
+// Parsers with custom extractors, whose output cannot be fingerprinted, bypass the cache.
+func WithDiskCache(dir string) Option {
+	return func(p *ProjectParser) {
+		p.diskCacheDir = dir
+	}
+}
+
+// DefaultDiskCacheDir returns the directory of the persistent cache used by default, in the user cache directory.
+func DefaultDiskCacheDir() (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to locate the user cache directory: %w", err)
+	}
+	return filepath.Join(dir, "ast2llm-go", "parse"), nil
+}
+
+// ReadDiskCacheStats reports the size, staleness and hit counters of the persistent cache in dir.
+func ReadDiskCacheStats(dir string) (*DiskCacheStats, error) {
+	stats := &DiskCacheStats{Dir: dir}
+	counters, err := readDiskCacheCounters(dir)
+	if err != nil {
+		return nil, err
+	}
+	stats.Hits, stats.Misses = counters.Hits, counters.Misses
+
+	err = forEachDiskCacheEntry(dir, func(path string, size int64) error {
+		stats.Entries++
+		stats.Bytes += size
+		if diskCacheEntryStale(path) {
+			stats.Stale++
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return stats, nil
+}
+
+// ClearDiskCache removes the stale entries of the persistent cache in dir, those of projects that are gone or
+// have changed since they were stored, or every entry and the hit counters if all is set. It returns the
+// number of entries removed.
+func ClearDiskCache(dir string, all bool) (int, error) {
+	removed := 0
+	err := forEachDiskCacheEntry(dir, func(path string, _ int64) error {
+		if !all && !diskCacheEntryStale(path) {
+			return nil
+		}
+		if err := os.Remove(path); err != nil {
+			return fmt.Errorf("failed to remove cache entry: %w", err)
+		}
+		removed++
+		return nil
+	})
+	if err != nil {
+		return removed, err
+	}
+	if all {
+		if err := os.Remove(filepath.Join(dir, "stats.json")); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return removed, fmt.Errorf("failed to reset cache statistics: %w", err)
+		}
+	}
+	return removed, nil
+}
+
+// loadFromDiskCache returns the persisted result for the current state of the project at root, if any. The
+// key of that state is returned to store the result of a miss.
+func (p *ProjectParser) loadFromDiskCache(root string) (ProjectInfo, string, bool) {
+	if p.diskCacheDir == "" || len(p.extractors) > 0 {
+		return nil, "", false
+	}
+	key, err := diskCacheKey(root, p.optionsFingerprint())
+	if err != nil {
+		return nil, "", false
+	}
+
+	info, err := readDiskCacheEntry(diskCacheEntryPath(p.diskCacheDir, key), root)
+	p.countDiskCacheLookup(err == nil)
+	if err != nil {
+		return nil, key, false
+	}
+	// Entries are keyed by content, the modification times are those of the files at hand
+	for path, fileInfo := range info {
+		fileInfo.ModTime = fileModTime(path)
+	}
+	return info, key, true
+}
+
+// storeInDiskCache persists info as the result for the project state identified by key. Failures are ignored,
+// the cache only saves time.
+func (p *ProjectParser) storeInDiskCache(root, key string, info ProjectInfo) {
+	if key == "" {
+		return
+	}
+	// Modification times are left out, they differ between checkouts sharing an entry
+	stored := make(ProjectInfo, len(info))
+	for path, fileInfo := range info {
+		c := *fileInfo
+		c.ModTime = ""
+		stored[path] = &c
+	}
+	files, err := json.Marshal(stored)
+	if err != nil {
+		return
+	}
+	files = bytes.ReplaceAll(files, jsonEscapedPrefix(root), jsonEscapedPrefix(diskCacheRoot))
+	data, err := json.Marshal(&diskCacheEntry{
+		Version: diskCacheVersion,
+		Root:    root,
+		Options: p.optionsFingerprint(),
+		Created: time.Now().UTC(),
+		Files:   files,
+	})
+	if err != nil {
+		return
+	}
+	_ = writeFileAtomic(diskCacheEntryPath(p.diskCacheDir, key), data)
+}
+
+// optionsFingerprint identifies the parser options and environment affecting the result of ParseProject.
+func (p *ProjectParser) optionsFingerprint() string {
+	return fmt.Sprintf("go=%s goos=%s goarch=%s goflags=%s cgo=%s transitive=%d promoted=%t bodies=%t external=%t tests=%t examples=%t exclude=%q generated=%d values=%d inline=%d naming=%d tags=%q vendor=%t buildflags=%q env=%q offline=%t",
+		runtime.Version(), os.Getenv("GOOS"), os.Getenv("GOARCH"), os.Getenv("GOFLAGS"), os.Getenv("CGO_ENABLED"),
+		p.transitiveDepth, p.includePromoted, p.includeBodies, p.includeExternal, p.includeTests, p.includeExamples,
+		p.excludeGlobs, p.generatedMode, p.maxValueLength, p.inlineMinLines, p.naming, p.buildTags, p.vendor, p.goFlags, p.goEnv, p.offline)
+}
+
+// diskCacheKey hashes the options fingerprint with the paths, relative to root, and contents of the files
+// the packages of the project are loaded from.
+func diskCacheKey(root, options string) (string, error) {
+	var files []string
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		name := d.Name()
+		if d.IsDir() {
+			if path != root && (strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_") || name == "testdata") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if strings.HasSuffix(name, ".go") || name == "go.mod" || name == "go.sum" || name == "go.work" || name == "go.work.sum" {
+			files = append(files, path)
+		}
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+	sort.Strings(files)
+
+	h := sha256.New()
+	fmt.Fprintf(h, "v%d\n%s\n", diskCacheVersion, options)
+	for _, path := range files {
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return "", err
+		}
+		f, err := os.Open(path)
+		if err != nil {
+			return "", err
+		}
+		content := sha256.New()
+		_, err = io.Copy(content, f)
+		f.Close()
+		if err != nil {
+			return "", err
+		}
+		fmt.Fprintf(h, "%s %x\n", filepath.ToSlash(rel), content.Sum(nil))
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// readDiskCacheEntry reads the entry at path and returns its ProjectInfo with the paths moved to root.
+func readDiskCacheEntry(path, root string) (ProjectInfo, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var entry diskCacheEntry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return nil, err
+	}
+	if entry.Version != diskCacheVersion {
+		return nil, fmt.Errorf("cache entry of version %d", entry.Version)
+	}
+	files := bytes.ReplaceAll(entry.Files, jsonEscapedPrefix(diskCacheRoot), jsonEscapedPrefix(root))
+	var info ProjectInfo
+	if err := json.Unmarshal(files, &info); err != nil {
+		return nil, err
+	}
+	return info, nil
+}
+
+// diskCacheEntryStale reports whether the entry at path is unreadable, of another version, or no longer
+// matches the files of the project it was written for.
+func diskCacheEntryStale(path string) bool {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return true
+	}
+	var entry diskCacheEntry
+	if err := json.Unmarshal(data, &entry); err != nil || entry.Version != diskCacheVersion {
+		return true
+	}
+	key, err := diskCacheKey(entry.Root, entry.Options)
+	return err != nil || diskCacheEntryPath(filepath.Dir(filepath.Dir(path)), key) != path
+}
+
+// forEachDiskCacheEntry calls fn with the path and size of every entry in dir. A missing dir has no entries.
+func forEachDiskCacheEntry(dir string, fn func(path string, size int64) error) error {
+	entries, err := os.ReadDir(filepath.Join(dir, "entries"))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read cache directory: %w", err)
+	}
+	for _, e := range entries {
+		if e.IsDir() || filepath.Ext(e.Name()) != ".json" {
+			continue
+		}
+		info, err := e.Info()
+		if err != nil {
+			continue
+		}
+		if err := fn(filepath.Join(dir, "entries", e.Name()), info.Size()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// countDiskCacheLookup adds a hit or a miss to the persisted counters.
+func (p *ProjectParser) countDiskCacheLookup(hit bool) {
+	p.diskCacheMu.Lock()
+	defer p.diskCacheMu.Unlock()
+	counters, err := readDiskCacheCounters(p.diskCacheDir)
+	if err != nil {
+		counters = &diskCacheCounters{}
+	}
+	if hit {
+		counters.Hits++
+	} else {
+		counters.Misses++
+	}
+	if data, err := json.Marshal(counters); err == nil {
+		_ = writeFileAtomic(filepath.Join(p.diskCacheDir, "stats.json"), data)
+	}
+}
+
+// readDiskCacheCounters reads the counters persisted in dir, zero if there are none yet.
+func readDiskCacheCounters(dir string) (*diskCacheCounters, error) {
+	counters := &diskCacheCounters{}
+	data, err := os.ReadFile(filepath.Join(dir, "stats.json"))
+	if errors.Is(err, fs.ErrNotExist) {
+		return counters, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read cache statistics: %w", err)
+	}
+	if err := json.Unmarshal(data, counters); err != nil {
+		return nil, fmt.Errorf("failed to decode cache statistics: %w", err)
+	}
+	return counters, nil
+}
+
+// diskCacheEntryPath returns the file of the entry with key in dir.
+func diskCacheEntryPath(dir, key string) string {
+	return filepath.Join(dir, "entries", key+".json")
+}
+
+// jsonEscapedPrefix returns root followed by a path separator as it appears within JSON strings.
+func jsonEscapedPrefix(root string) []byte {
+	escaped, _ := json.Marshal(root + string(filepath.Separator))
+	return escaped[1 : len(escaped)-1]
+}
+
+// writeFileAtomic writes data to path through a temporary file, creating the directory, so that concurrent
+// readers never see a partial file.
+func writeFileAtomic(path string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
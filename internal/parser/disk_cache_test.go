@@ -0,0 +1,109 @@
+package parser
+
+import (
+	"go/ast"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	ourtypes "github.com/vlad/ast2llm-go/internal/types"
+	"golang.org/x/tools/go/packages"
+)
+
+func TestProjectParser_DiskCache(t *testing.T) {
+	projectPath := writeTestProject(t, map[string]string{
+		"main.go": `package main
+
+// Run starts the service.
+func Run() error { return nil }
+
+func main() {}
+`,
+	})
+	dir := t.TempDir()
+
+	parsed, err := New(WithDiskCache(dir)).ParseProject(projectPath)
+	require.NoError(t, err)
+	stats, err := ReadDiskCacheStats(dir)
+	require.NoError(t, err)
+	assert.Equal(t, 1, stats.Entries)
+	assert.Positive(t, stats.Bytes)
+	assert.Equal(t, int64(0), stats.Hits)
+	assert.Equal(t, int64(1), stats.Misses)
+
+	p := New(WithDiskCache(dir))
+	cached, err := p.ParseProject(projectPath)
+	require.NoError(t, err)
+	mainPath := filepath.Join(projectPath, "main.go")
+	require.Contains(t, cached, mainPath)
+	assert.Equal(t, parsed[mainPath].Functions[0].Name, cached[mainPath].Functions[0].Name)
+	assert.Equal(t, "Run starts the service.", cached[mainPath].Functions[0].Comment)
+	assert.Equal(t, mainPath, cached[mainPath].Functions[0].Pos.File)
+	assert.Equal(t, int64(0), p.Stats().PackagesLoaded, "a hit loads no packages")
+
+	// An identical checkout elsewhere is served from the same entry, with its own paths
+	copyPath := filepath.Join(t.TempDir(), "checkout")
+	require.NoError(t, os.CopyFS(copyPath, os.DirFS(projectPath)))
+	cached, err = New(WithDiskCache(dir)).ParseProject(copyPath)
+	require.NoError(t, err)
+	copyMain := filepath.Join(copyPath, "main.go")
+	require.Contains(t, cached, copyMain)
+	assert.Equal(t, copyMain, cached[copyMain].Functions[0].Pos.File)
+
+	stats, err = ReadDiskCacheStats(dir)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), stats.Hits)
+	assert.Equal(t, 1, stats.Entries)
+	assert.Equal(t, 0, stats.Stale)
+
+	// Other options are a different entry
+	_, err = New(WithDiskCache(dir), WithFunctionBodies()).ParseProject(projectPath)
+	require.NoError(t, err)
+
+	// A change makes the entries of the project stale
+	require.NoError(t, os.WriteFile(mainPath, []byte("package main\n\nfunc Stop() {}\n\nfunc main() {}\n"), 0644))
+	changed, err := New(WithDiskCache(dir)).ParseProject(projectPath)
+	require.NoError(t, err)
+	assert.Equal(t, "Stop", changed[mainPath].Functions[0].Name)
+
+	stats, err = ReadDiskCacheStats(dir)
+	require.NoError(t, err)
+	assert.Equal(t, 3, stats.Entries)
+	assert.Equal(t, 2, stats.Stale)
+	assert.InDelta(t, 0.4, stats.HitRate(), 1e-9)
+
+	removed, err := ClearDiskCache(dir, false)
+	require.NoError(t, err)
+	assert.Equal(t, 2, removed)
+	cached, err = New(WithDiskCache(dir)).ParseProject(projectPath)
+	require.NoError(t, err)
+	assert.Equal(t, "Stop", cached[mainPath].Functions[0].Name)
+
+	removed, err = ClearDiskCache(dir, true)
+	require.NoError(t, err)
+	assert.Equal(t, 1, removed)
+	stats, err = ReadDiskCacheStats(dir)
+	require.NoError(t, err)
+	assert.Equal(t, &DiskCacheStats{Dir: dir}, stats)
+}
+
+func TestProjectParser_DiskCache_Extractors(t *testing.T) {
+	projectPath := writeTestProject(t, map[string]string{"main.go": "package main\n\nfunc main() {}\n"})
+	dir := t.TempDir()
+	extractor := func(pkg *packages.Package, file *ast.File, info *ourtypes.FileInfo) error { return nil }
+
+	_, err := New(WithDiskCache(dir), WithExtractor(extractor)).ParseProject(projectPath)
+	require.NoError(t, err)
+	stats, err := ReadDiskCacheStats(dir)
+	require.NoError(t, err)
+	assert.Equal(t, 0, stats.Entries, "results of custom extractors are not cached")
+}
+
+func TestReadDiskCacheStats_Missing(t *testing.T) {
+	stats, err := ReadDiskCacheStats(filepath.Join(t.TempDir(), "missing"))
+	require.NoError(t, err)
+	assert.Equal(t, 0, stats.Entries)
+	assert.Equal(t, 0.0, stats.HitRate())
+}
@@ -0,0 +1,143 @@
+package parser
+
+import (
+	"go/ast"
+	"go/constant"
+	gotypes "go/types"
+	"sort"
+	"strings"
+
+	ourtypes "github.com/vlad/ast2llm-go/internal/types"
+	"golang.org/x/tools/go/packages"
+)
+
+// extractEntryPoints returns the entry points of file in source order: the main function of a main package,
+// the HTTP routes of file extracted by extractRoutes, cobra and urfave/cli commands, and gRPC services registered
+// with the Register<Service>Server functions generated by protoc-gen-go-grpc.
+func (p *ProjectParser) extractEntryPoints(file *ast.File, pkg *packages.Package, routes []*ourtypes.Route) []*ourtypes.EntryPoint {
+	entryPoints := make([]*ourtypes.EntryPoint, 0)
+	ast.Inspect(file, func(n ast.Node) bool {
+		var entry *ourtypes.EntryPoint
+		switch n := n.(type) {
+		case *ast.FuncDecl:
+			if n.Recv == nil && n.Name.Name == "main" && pkg.Name == "main" {
+				entry = &ourtypes.EntryPoint{Kind: ourtypes.EntryMain, Name: "main"}
+			}
+		case *ast.CallExpr:
+			entry = grpcEntryPoint(n)
+		case *ast.CompositeLit:
+			entry = cliEntryPoint(n, pkg.TypesInfo)
+		}
+		if entry != nil {
+			entry.Pos = p.position(n.Pos())
+			entryPoints = append(entryPoints, entry)
+		}
+		return true
+	})
+
+	for _, route := range routes {
+		entryPoints = append(entryPoints, &ourtypes.EntryPoint{
+			Kind:    ourtypes.EntryHTTP,
+			Name:    route.Path,
+			Handler: route.Handler,
+			Detail:  route.Method,
+			Pos:     route.Pos,
+		})
+	}
+	sort.SliceStable(entryPoints, func(i, j int) bool { return positionBefore(entryPoints[i].Pos, entryPoints[j].Pos) })
+	return entryPoints
+}
+
+// grpcEntryPoint recognizes the registration of a gRPC service, e.g. pb.RegisterGreeterServer(s, &server{}).
+func grpcEntryPoint(call *ast.CallExpr) *ourtypes.EntryPoint {
+	var name string
+	switch fun := call.Fun.(type) {
+	case *ast.Ident:
+		name = fun.Name
+	case *ast.SelectorExpr:
+		name = fun.Sel.Name
+	}
+	service, ok := strings.CutPrefix(name, "Register")
+	if !ok || len(call.Args) != 2 {
+		return nil
+	}
+	if service, ok = strings.CutSuffix(service, "Server"); !ok || service == "" {
+		return nil
+	}
+	return &ourtypes.EntryPoint{Kind: ourtypes.EntryGRPC, Name: service, Handler: handlerString(call.Args[1])}
+}
+
+// cliEntryPoint recognizes the declaration of a cobra.Command or a urfave/cli Command.
+func cliEntryPoint(lit *ast.CompositeLit, info *gotypes.Info) *ourtypes.EntryPoint {
+	sel, ok := lit.Type.(*ast.SelectorExpr)
+	if !ok || sel.Sel.Name != "Command" {
+		return nil
+	}
+	ident, ok := sel.X.(*ast.Ident)
+	if !ok {
+		return nil
+	}
+	pkgName, ok := info.Uses[ident].(*gotypes.PkgName)
+	if !ok {
+		return nil
+	}
+
+	var nameField, detailField string
+	var handlerFields []string
+	switch path := pkgName.Imported().Path(); {
+	case path == "github.com/spf13/cobra":
+		nameField, detailField, handlerFields = "Use", "Short", []string{"RunE", "Run"}
+	case path == "github.com/urfave/cli" || strings.HasPrefix(path, "github.com/urfave/cli/"):
+		nameField, detailField, handlerFields = "Name", "Usage", []string{"Action"}
+	default:
+		return nil
+	}
+
+	fields := make(map[string]ast.Expr)
+	for _, elt := range lit.Elts {
+		if kv, ok := elt.(*ast.KeyValueExpr); ok {
+			if key, ok := kv.Key.(*ast.Ident); ok {
+				fields[key.Name] = kv.Value
+			}
+		}
+	}
+	entry := &ourtypes.EntryPoint{Kind: ourtypes.EntryCLI}
+	if value, ok := constantString(fields[nameField], info); ok {
+		// cobra's Use starts with the command name, followed by its arguments
+		if name := strings.Fields(value); len(name) > 0 {
+			entry.Name = name[0]
+		}
+	}
+	if entry.Name == "" {
+		return nil
+	}
+	entry.Detail, _ = constantString(fields[detailField], info)
+	for _, field := range handlerFields {
+		if handler, ok := fields[field]; ok {
+			entry.Handler = handlerString(handler)
+			break
+		}
+	}
+	return entry
+}
+
+// constantString returns the value of a constant string expression.
+func constantString(expr ast.Expr, info *gotypes.Info) (string, bool) {
+	if expr == nil {
+		return "", false
+	}
+	tv, ok := info.Types[expr]
+	if !ok || tv.Value == nil || tv.Value.Kind() != constant.String {
+		return "", false
+	}
+	return constant.StringVal(tv.Value), true
+}
+
+// handlerString describes the expression handling an entry point, e.g. "api.listUsers", "&server{}" or
+// "func literal".
+func handlerString(expr ast.Expr) string {
+	if _, ok := ast.Unparen(expr).(*ast.FuncLit); ok {
+		return "func literal"
+	}
+	return gotypes.ExprString(expr)
+}
@@ -0,0 +1,95 @@
+package parser
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	ourtypes "github.com/vlad/ast2llm-go/internal/types"
+)
+
+func TestProjectParser_ParseProject_EntryPoints(t *testing.T) {
+	projectPath := writeTestProject(t, map[string]string{
+		"go.mod": `module example.com/testproject
+
+go 1.21
+
+require github.com/spf13/cobra v1.0.0
+
+replace github.com/spf13/cobra => ./third_party/cobra
+`,
+		"third_party/cobra/go.mod": "module github.com/spf13/cobra\n\ngo 1.21\n",
+		"third_party/cobra/command.go": `package cobra
+
+type Command struct {
+	Use   string
+	Short string
+	RunE  func(cmd *Command, args []string) error
+}
+`,
+		"pb/greeter.go": `package pb
+
+type GreeterServer interface{ SayHello() }
+
+func RegisterGreeterServer(s any, srv GreeterServer) {}
+`,
+		"main.go": `package main
+
+import (
+	"net/http"
+
+	"example.com/testproject/pb"
+	"github.com/spf13/cobra"
+)
+
+const usersRoute = "/users"
+
+type server struct{}
+
+func (server) SayHello() {}
+
+func listUsers(w http.ResponseWriter, r *http.Request) {}
+
+var serveCmd = &cobra.Command{
+	Use:   "serve [addr]",
+	Short: "Start the server",
+	RunE:  runServe,
+}
+
+func runServe(cmd *cobra.Command, args []string) error {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET "+usersRoute+"/{id}", listUsers)
+	mux.Handle("/static/", http.FileServer(http.Dir(".")))
+	http.HandleFunc("/health", func(w http.ResponseWriter, r *http.Request) {})
+	pb.RegisterGreeterServer(nil, &server{})
+	return nil
+}
+
+func main() {}
+`,
+		"lib/lib.go": `package lib
+
+func main() {}
+`,
+	})
+
+	fileInfos, err := New().ParseProject(projectPath)
+	require.NoError(t, err)
+	info := fileInfos[filepath.Join(projectPath, "main.go")]
+
+	for _, e := range info.EntryPoints {
+		require.NotNil(t, e.Pos, e.Name)
+		e.Pos = nil
+	}
+	assert.Equal(t, []*ourtypes.EntryPoint{
+		{Kind: ourtypes.EntryCLI, Name: "serve", Handler: "runServe", Detail: "Start the server"},
+		{Kind: ourtypes.EntryHTTP, Name: "/users/{id}", Handler: "listUsers", Detail: "GET"},
+		{Kind: ourtypes.EntryHTTP, Name: "/static/", Handler: `http.FileServer(http.Dir("."))`},
+		{Kind: ourtypes.EntryHTTP, Name: "/health", Handler: "func literal"},
+		{Kind: ourtypes.EntryGRPC, Name: "Greeter", Handler: "&server{}"},
+		{Kind: ourtypes.EntryMain, Name: "main"},
+	}, info.EntryPoints)
+
+	assert.Empty(t, fileInfos[filepath.Join(projectPath, "lib", "lib.go")].EntryPoints, "main functions of other packages are no entry points")
+}
@@ -0,0 +1,112 @@
+package parser
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/token"
+	gotypes "go/types"
+	"strconv"
+	"strings"
+
+	ourtypes "github.com/vlad/ast2llm-go/internal/types"
+	"golang.org/x/tools/go/packages"
+)
+
+// errorInterface is the type of the predeclared error interface
+var errorInterface = gotypes.Universe.Lookup("error").Type().Underlying().(*gotypes.Interface)
+
+// extractErrorValues returns the sentinel errors, i.e. package-level variables and constants implementing error,
+// and the named non-interface types implementing error declared in file, in source order.
+func (p *ProjectParser) extractErrorValues(file *ast.File, pkg *packages.Package) []*ourtypes.ErrorInfo {
+	errorValues := make([]*ourtypes.ErrorInfo, 0)
+	for _, decl := range file.Decls {
+		genDecl, ok := decl.(*ast.GenDecl)
+		if !ok {
+			continue
+		}
+		for _, spec := range genDecl.Specs {
+			switch spec := spec.(type) {
+			case *ast.ValueSpec:
+				for i, name := range spec.Names {
+					obj := pkg.TypesInfo.Defs[name]
+					if obj == nil || name.Name == "_" || !gotypes.Implements(obj.Type(), errorInterface) {
+						continue
+					}
+					info := &ourtypes.ErrorInfo{
+						Name:    obj.Pkg().Path() + "." + obj.Name(),
+						ID:      symbolID(obj),
+						Kind:    ourtypes.ErrorSentinel,
+						Type:    obj.Type().String(),
+						Comment: specComment(genDecl, spec.Doc),
+						Pos:     p.position(obj.Pos()),
+					}
+					if c, ok := obj.(*gotypes.Const); ok && c.Val().Kind() == constant.String {
+						info.Message = constant.StringVal(c.Val())
+					} else if len(spec.Values) == len(spec.Names) {
+						info.Message = errorMessage(spec.Values[i], pkg.TypesInfo)
+					}
+					errorValues = append(errorValues, info)
+				}
+			case *ast.TypeSpec:
+				typeName, ok := pkg.TypesInfo.Defs[spec.Name].(*gotypes.TypeName)
+				if !ok || genDecl.Tok != token.TYPE {
+					continue
+				}
+				if _, isInterface := typeName.Type().Underlying().(*gotypes.Interface); isInterface {
+					continue
+				}
+				byValue := gotypes.Implements(typeName.Type(), errorInterface)
+				if !byValue && !gotypes.Implements(gotypes.NewPointer(typeName.Type()), errorInterface) {
+					continue
+				}
+				errorValues = append(errorValues, &ourtypes.ErrorInfo{
+					Name:        typeName.Pkg().Path() + "." + typeName.Name(),
+					ID:          symbolID(typeName),
+					Kind:        ourtypes.ErrorType,
+					Type:        typeName.Type().Underlying().String(),
+					PointerOnly: !byValue,
+					Comment:     specComment(genDecl, spec.Doc),
+					Pos:         p.position(typeName.Pos()),
+				})
+			}
+		}
+	}
+	return errorValues
+}
+
+// specComment returns the doc comment of a spec, or of its declaration if the spec has none.
+func specComment(genDecl *ast.GenDecl, doc *ast.CommentGroup) string {
+	if doc == nil {
+		doc = genDecl.Doc
+	}
+	return strings.TrimSpace(doc.Text())
+}
+
+// errorMessage returns the message of an error created by errors.New or fmt.Errorf with a literal string, with
+// the verbs of fmt.Errorf left as is, or "" for other expressions.
+func errorMessage(expr ast.Expr, info *gotypes.Info) string {
+	call, ok := ast.Unparen(expr).(*ast.CallExpr)
+	if !ok || len(call.Args) == 0 {
+		return ""
+	}
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return ""
+	}
+	fn, ok := info.Uses[sel.Sel].(*gotypes.Func)
+	if !ok || fn.Pkg() == nil {
+		return ""
+	}
+	if name := fn.Pkg().Path() + "." + fn.Name(); name != "errors.New" && name != "fmt.Errorf" {
+		return ""
+	}
+	lit, ok := call.Args[0].(*ast.BasicLit)
+	if !ok || lit.Kind != token.STRING {
+		return ""
+	}
+	message, err := strconv.Unquote(lit.Value)
+	if err != nil {
+		return ""
+	}
+	return message
+}
@@ -0,0 +1,71 @@
+package parser
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	ourtypes "github.com/vlad/ast2llm-go/internal/types"
+)
+
+func TestProjectParser_ParseProject_ErrorValues(t *testing.T) {
+	projectPath := writeTestProject(t, map[string]string{
+		"store/errors.go": `package store
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrNotFound is returned when no record matches.
+var ErrNotFound = errors.New("not found")
+
+var (
+	errClosed   = fmt.Errorf("store %s", "closed")
+	ErrConflict = fmt.Errorf("conflicting version: %w", ErrNotFound)
+	ErrTimeout  error
+	Retries     = 3
+)
+
+type constError string
+
+func (e constError) Error() string { return string(e) }
+
+// ErrReadOnly is a constant sentinel.
+const ErrReadOnly = constError("read-only")
+
+// ValidationError reports an invalid field.
+type ValidationError struct {
+	Field string
+}
+
+func (e *ValidationError) Error() string { return "invalid " + e.Field }
+
+type Temporary interface {
+	error
+	Temporary() bool
+}
+`,
+	})
+
+	fileInfos, err := New().ParseProject(projectPath)
+	require.NoError(t, err)
+	info := fileInfos[filepath.Join(projectPath, "store", "errors.go")]
+
+	for _, e := range info.ErrorValues {
+		require.NotNil(t, e.Pos, e.Name)
+		assert.Equal(t, e.Name, e.ID)
+		e.Pos = nil
+		e.ID = ""
+	}
+	assert.Equal(t, []*ourtypes.ErrorInfo{
+		{Name: "example.com/testproject/store.ErrNotFound", Kind: ourtypes.ErrorSentinel, Type: "error", Message: "not found", Comment: "ErrNotFound is returned when no record matches."},
+		{Name: "example.com/testproject/store.errClosed", Kind: ourtypes.ErrorSentinel, Type: "error", Message: "store %s"},
+		{Name: "example.com/testproject/store.ErrConflict", Kind: ourtypes.ErrorSentinel, Type: "error", Message: "conflicting version: %w"},
+		{Name: "example.com/testproject/store.ErrTimeout", Kind: ourtypes.ErrorSentinel, Type: "error"},
+		{Name: "example.com/testproject/store.constError", Kind: ourtypes.ErrorType, Type: "string"},
+		{Name: "example.com/testproject/store.ErrReadOnly", Kind: ourtypes.ErrorSentinel, Type: "example.com/testproject/store.constError", Message: "read-only", Comment: "ErrReadOnly is a constant sentinel."},
+		{Name: "example.com/testproject/store.ValidationError", Kind: ourtypes.ErrorType, Type: "struct{Field string}", PointerOnly: true, Comment: "ValidationError reports an invalid field."},
+	}, info.ErrorValues, "interfaces and other variables are left out")
+}
@@ -0,0 +1,142 @@
+package parser
+
+import (
+	"bytes"
+	"go/ast"
+	"go/doc"
+	"go/format"
+	goparser "go/parser"
+	"go/printer"
+	"go/token"
+	gotypes "go/types"
+	"path/filepath"
+	"strings"
+	"unicode"
+	"weak"
+
+	ourtypes "github.com/vlad/ast2llm-go/internal/types"
+	"golang.org/x/tools/go/packages"
+)
+
+// packageExamples holds the examples found for a loaded package
+type packageExamples struct {
+	pkg      weak.Pointer[packages.Package] // Package the examples were collected for, held weakly not to keep its syntax alive
+	examples map[string][]*ourtypes.Example // Key: "Func", "Type" or "Type.Method"
+}
+
+// packageExamples returns the ExampleXxx functions of the _test.go files next to pkg, keyed by the symbol they
+// document. Examples of methods are listed under their type as well. Package examples are left out.
+func (p *ProjectParser) packageExamples(pkg *packages.Package) map[string][]*ourtypes.Example {
+	p.examplesMu.Lock()
+	defer p.examplesMu.Unlock()
+	if cached, ok := p.examples[pkg.ID]; ok && cached.pkg.Value() == pkg {
+		return cached.examples
+	}
+	examples := collectExamples(pkg)
+	p.examples[pkg.ID] = &packageExamples{pkg: weak.Make(pkg), examples: examples}
+	return examples
+}
+
+// collectExamples parses the _test.go files of the directory of pkg, both the internal and the external
+// test package, and extracts their examples. Test files are read from disk as pkg only has them with WithTests.
+func collectExamples(pkg *packages.Package) map[string][]*ourtypes.Example {
+	examples := make(map[string][]*ourtypes.Example)
+	if len(pkg.GoFiles) == 0 {
+		return examples
+	}
+	paths, err := filepath.Glob(filepath.Join(filepath.Dir(pkg.GoFiles[0]), "*_test.go"))
+	if err != nil {
+		return examples
+	}
+
+	fset := token.NewFileSet()
+	var files []*ast.File
+	for _, path := range paths {
+		file, err := goparser.ParseFile(fset, path, nil, goparser.ParseComments)
+		if err != nil {
+			continue // Broken test files only cost their examples
+		}
+		if name := file.Name.Name; name == pkg.Name || name == pkg.Name+"_test" {
+			files = append(files, file)
+		}
+	}
+
+	for _, ex := range doc.Examples(files...) {
+		symbol := exampleSymbol(ex.Name)
+		if symbol == "" {
+			continue
+		}
+		example := &ourtypes.Example{
+			Name:   "Example" + ex.Name,
+			Code:   exampleCode(fset, ex),
+			Output: strings.TrimSpace(ex.Output),
+		}
+		examples[symbol] = append(examples[symbol], example)
+		if typeName, _, ok := strings.Cut(symbol, "."); ok {
+			examples[typeName] = append(examples[typeName], example)
+		}
+	}
+	return examples
+}
+
+// exampleSymbol converts the name of an example without its Example prefix to the symbol it documents,
+// e.g. "Stack_Push_twice" to "Stack.Push". A trailing part starting with a lower-case letter is a suffix
+// distinguishing several examples of a symbol. Package examples return an empty string.
+func exampleSymbol(name string) string {
+	if i := strings.LastIndex(name, "_"); i >= 0 && i+1 < len(name) && unicode.IsLower(rune(name[i+1])) {
+		name = name[:i]
+	}
+	return strings.Replace(name, "_", ".", 1)
+}
+
+// exampleCode prints the body of an example like go doc does: without the enclosing braces, one level
+// less indented and without the trailing output comment.
+func exampleCode(fset *token.FileSet, ex *doc.Example) string {
+	var comments []*ast.CommentGroup
+	for _, group := range ex.Comments {
+		if group.Pos() >= ex.Code.Pos() && group.End() <= ex.Code.End() {
+			comments = append(comments, group)
+		}
+	}
+	if (ex.Output != "" || ex.EmptyOutput) && len(comments) > 0 {
+		comments = comments[:len(comments)-1]
+	}
+
+	var buf bytes.Buffer
+	if err := format.Node(&buf, fset, &printer.CommentedNode{Node: ex.Code, Comments: comments}); err != nil {
+		return ""
+	}
+	if _, ok := ex.Code.(*ast.BlockStmt); !ok {
+		return strings.TrimSpace(buf.String()) // Whole file examples
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) < 2 {
+		return ""
+	}
+	lines = lines[1 : len(lines)-1]
+	for i, line := range lines {
+		lines[i] = strings.TrimPrefix(line, "\t")
+	}
+	return strings.TrimSpace(strings.Join(lines, "\n"))
+}
+
+// exampleKey returns the key of funcDecl in the examples of its package, "Func" or "Type.Method".
+func exampleKey(funcDecl *ast.FuncDecl, pkg *packages.Package) string {
+	fn, ok := pkg.TypesInfo.Defs[funcDecl.Name].(*gotypes.Func)
+	if !ok {
+		return funcDecl.Name.Name
+	}
+	recv := fn.Type().(*gotypes.Signature).Recv()
+	if recv == nil {
+		return fn.Name()
+	}
+	recvType := recv.Type()
+	if ptr, ok := recvType.(*gotypes.Pointer); ok {
+		recvType = ptr.Elem()
+	}
+	if named, ok := recvType.(*gotypes.Named); ok {
+		return named.Obj().Name() + "." + fn.Name()
+	}
+	return fn.Name()
+}
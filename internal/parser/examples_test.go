@@ -0,0 +1,117 @@
+package parser
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	ourtypes "github.com/vlad/ast2llm-go/internal/types"
+)
+
+func TestProjectParser_ParseProject_Examples(t *testing.T) {
+	projectPath := writeTestProject(t, map[string]string{
+		"stack/stack.go": `package stack
+
+// Stack is a LIFO of ints.
+type Stack struct {
+	items []int
+}
+
+// New returns an empty stack.
+func New() *Stack { return &Stack{} }
+
+// Push adds v on top.
+func (s *Stack) Push(v int) { s.items = append(s.items, v) }
+
+// Len returns the number of items.
+func (s *Stack) Len() int { return len(s.items) }
+`,
+		"stack/example_test.go": `package stack_test
+
+import (
+	"fmt"
+
+	"example.com/testproject/stack"
+)
+
+func Example() {
+	fmt.Println("package example")
+}
+
+func ExampleNew() {
+	s := stack.New()
+	// A new stack is empty.
+	fmt.Println(s.Len())
+	// Output: 0
+}
+
+func ExampleStack_Push() {
+	s := stack.New()
+	s.Push(1)
+	s.Push(2)
+	fmt.Println(s.Len())
+	// Output:
+	// 2
+}
+
+func ExampleStack_Push_twice() {
+	s := stack.New()
+	s.Push(1)
+	s.Push(1)
+}
+`,
+	})
+	file := filepath.Join(projectPath, "stack", "stack.go")
+
+	t.Run("attached to functions, methods and types", func(t *testing.T) {
+		info, err := New(WithExamples()).ParseProject(projectPath)
+		require.NoError(t, err)
+		require.Contains(t, info, file)
+		fileInfo := info[file]
+
+		require.Len(t, fileInfo.Functions, 1)
+		assert.Equal(t, []*ourtypes.Example{{
+			Name:   "ExampleNew",
+			Code:   "s := stack.New()\n// A new stack is empty.\nfmt.Println(s.Len())",
+			Output: "0",
+		}}, fileInfo.Functions[0].Examples)
+
+		methodExamples := make(map[string][]string)
+		for _, m := range fileInfo.Methods {
+			for _, ex := range m.Examples {
+				methodExamples[m.Name] = append(methodExamples[m.Name], ex.Name)
+			}
+		}
+		assert.Equal(t, map[string][]string{"Push": {"ExampleStack_Push", "ExampleStack_Push_twice"}}, methodExamples)
+
+		require.Len(t, fileInfo.Structs, 1)
+		require.Len(t, fileInfo.Structs[0].Examples, 2)
+		assert.Equal(t, "ExampleStack_Push", fileInfo.Structs[0].Examples[0].Name)
+		assert.Equal(t, "s := stack.New()\ns.Push(1)\ns.Push(2)\nfmt.Println(s.Len())", fileInfo.Structs[0].Examples[0].Code)
+		assert.Equal(t, "2", fileInfo.Structs[0].Examples[0].Output)
+		assert.Empty(t, fileInfo.Structs[0].Examples[1].Output)
+	})
+
+	t.Run("left out by default", func(t *testing.T) {
+		info, err := New().ParseProject(projectPath)
+		require.NoError(t, err)
+		require.Contains(t, info, file)
+		assert.Nil(t, info[file].Functions[0].Examples)
+		assert.Nil(t, info[file].Structs[0].Examples)
+	})
+}
+
+func TestExampleSymbol(t *testing.T) {
+	for name, want := range map[string]string{
+		"":                 "",
+		"_basic":           "",
+		"New":              "New",
+		"New_empty":        "New",
+		"Stack_Push":       "Stack.Push",
+		"Stack_Push_twice": "Stack.Push",
+		"Stack_basic":      "Stack",
+	} {
+		assert.Equal(t, want, exampleSymbol(name), name)
+	}
+}
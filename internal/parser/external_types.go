@@ -0,0 +1,143 @@
+package parser
+
+import (
+	"go/ast"
+	gotypes "go/types"
+	"sort"
+
+	ourtypes "github.com/vlad/ast2llm-go/internal/types"
+	"golang.org/x/tools/go/packages"
+)
+
+// extractExternalTypes describes the struct and interface types used in the file that are declared outside of
+// the project. Their definitions come from the type information of the imported packages, so only exported
+// members are listed and comments are not available.
+func (p *ProjectParser) extractExternalTypes(file *ast.File, pkg *packages.Package, index *symbolIndex) ([]*ourtypes.StructInfo, []*ourtypes.InterfaceInfo) {
+	structs := make(map[string]*ourtypes.StructInfo)
+	interfaces := make(map[string]*ourtypes.InterfaceInfo)
+
+	ast.Inspect(file, func(n ast.Node) bool {
+		ident, ok := n.(*ast.Ident)
+		if !ok {
+			return true
+		}
+		typeName, ok := pkg.TypesInfo.Uses[ident].(*gotypes.TypeName)
+		if !ok || typeName.Pkg() == nil || index.packages[typeName.Pkg().Path()] {
+			return true
+		}
+		namedType, ok := gotypes.Unalias(typeName.Type()).(*gotypes.Named)
+		if !ok {
+			return true
+		}
+		name := namedTypeName(namedType)
+		if _, exists := structs[name]; exists {
+			return true
+		}
+		if _, exists := interfaces[name]; exists {
+			return true
+		}
+
+		switch underlying := namedType.Origin().Underlying().(type) {
+		case *gotypes.Struct:
+			structs[name] = externalStructInfo(namedType.Origin(), underlying)
+		case *gotypes.Interface:
+			interfaces[name] = externalInterfaceInfo(namedType.Origin(), underlying)
+		}
+		return true
+	})
+
+	structList := make([]*ourtypes.StructInfo, 0, len(structs))
+	for _, s := range structs {
+		structList = append(structList, s)
+	}
+	sort.Slice(structList, func(i, j int) bool { return structList[i].Name < structList[j].Name })
+
+	ifaceList := make([]*ourtypes.InterfaceInfo, 0, len(interfaces))
+	for _, i := range interfaces {
+		ifaceList = append(ifaceList, i)
+	}
+	sort.Slice(ifaceList, func(i, j int) bool { return ifaceList[i].Name < ifaceList[j].Name })
+
+	return structList, ifaceList
+}
+
+// externalStructInfo describes the exported fields and methods of a struct declared outside the project.
+func externalStructInfo(namedType *gotypes.Named, structType *gotypes.Struct) *ourtypes.StructInfo {
+	structInfo := ourtypes.NewStructInfo()
+	structInfo.Name = namedTypeName(namedType)
+	structInfo.ID = symbolID(namedType.Obj())
+	structInfo.TypeParams = typeParamsList(namedType.TypeParams())
+
+	for i := 0; i < structType.NumFields(); i++ {
+		fieldVar := structType.Field(i)
+		if !fieldVar.Exported() {
+			continue
+		}
+		field := ourtypes.NewStructField()
+		field.Name = fieldVar.Name()
+		field.Type = fieldVar.Type().String()
+		field.TypeRef = fieldTypeRef(fieldVar.Type())
+		field.Tag = structType.Tag(i)
+		structInfo.Fields = append(structInfo.Fields, field)
+	}
+
+	methodSet := gotypes.NewMethodSet(gotypes.NewPointer(namedType))
+	for i := 0; i < methodSet.Len(); i++ {
+		sel := methodSet.At(i)
+		methodObj, ok := sel.Obj().(*gotypes.Func)
+		if !ok || !methodObj.Exported() {
+			continue
+		}
+		method := ourtypes.NewStructMethod()
+		method.Name = methodObj.Name()
+		method.Parameters, method.ReturnTypes = signatureTypes(methodObj.Type().(*gotypes.Signature))
+		method.ReceiverIsPointer = isPointerReceiver(methodObj.Type().(*gotypes.Signature))
+		if len(sel.Index()) > 1 {
+			recvType := methodObj.Type().(*gotypes.Signature).Recv().Type()
+			if ptr, ok := recvType.(*gotypes.Pointer); ok {
+				recvType = ptr.Elem()
+			}
+			if recvNamed, ok := recvType.(*gotypes.Named); ok {
+				method.PromotedFrom = namedTypeName(recvNamed)
+			}
+		}
+		structInfo.Methods = append(structInfo.Methods, method)
+	}
+
+	return structInfo
+}
+
+// externalInterfaceInfo describes the exported methods of an interface declared outside the project,
+// including the methods of embedded interfaces.
+func externalInterfaceInfo(namedType *gotypes.Named, ifaceType *gotypes.Interface) *ourtypes.InterfaceInfo {
+	ifaceInfo := ourtypes.NewInterfaceInfo()
+	ifaceInfo.Name = namedTypeName(namedType)
+	ifaceInfo.ID = symbolID(namedType.Obj())
+	ifaceInfo.TypeParams = typeParamsList(namedType.TypeParams())
+
+	for i := 0; i < ifaceType.NumMethods(); i++ {
+		methodObj := ifaceType.Method(i)
+		if !methodObj.Exported() {
+			continue
+		}
+		method := ourtypes.NewInterfaceMethod()
+		method.Name = methodObj.Name()
+		method.Parameters, method.ReturnTypes = signatureTypes(methodObj.Type().(*gotypes.Signature))
+		ifaceInfo.Methods = append(ifaceInfo.Methods, method)
+	}
+
+	return ifaceInfo
+}
+
+// signatureTypes returns the parameter and result types of a signature.
+func signatureTypes(sig *gotypes.Signature) ([]string, []string) {
+	params := make([]string, 0, sig.Params().Len())
+	for i := 0; i < sig.Params().Len(); i++ {
+		params = append(params, paramTypeString(sig, i))
+	}
+	results := make([]string, 0, sig.Results().Len())
+	for i := 0; i < sig.Results().Len(); i++ {
+		results = append(results, sig.Results().At(i).Type().String())
+	}
+	return params, results
+}
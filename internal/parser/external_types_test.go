@@ -0,0 +1,66 @@
+package parser
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	ourtypes "github.com/vlad/ast2llm-go/internal/types"
+)
+
+func TestProjectParser_ParseProject_ExternalTypes(t *testing.T) {
+	projectPath := writeTestProject(t, map[string]string{
+		"main.go": `package main
+
+import (
+	"context"
+	"strings"
+
+	"example.com/testproject/models"
+)
+
+func run(ctx context.Context, b *strings.Builder, u models.User) {}
+
+func main() {}
+`,
+		"models/user.go": `package models
+
+type User struct {
+	Name string
+}
+`,
+	})
+	mainPath := filepath.Join(projectPath, "main.go")
+
+	fileInfos, err := New().ParseProject(projectPath)
+	require.NoError(t, err)
+	assert.Empty(t, fileInfos[mainPath].ExternalStructs)
+	assert.Empty(t, fileInfos[mainPath].ExternalInterfaces)
+
+	fileInfos, err = New(WithExternalTypes()).ParseProject(projectPath)
+	require.NoError(t, err)
+	info := fileInfos[mainPath]
+
+	// Project types are not external
+	require.Len(t, info.ExternalStructs, 1)
+	builder := info.ExternalStructs[0]
+	assert.Equal(t, "strings.Builder", builder.Name)
+	assert.Empty(t, builder.Fields, "unexported fields are skipped")
+	assert.Contains(t, builder.Methods, &ourtypes.StructMethod{
+		Name:              "WriteString",
+		Parameters:        []string{"string"},
+		ReturnTypes:       []string{"int", "error"},
+		ReceiverIsPointer: true,
+	})
+
+	require.Len(t, info.ExternalInterfaces, 1)
+	ctx := info.ExternalInterfaces[0]
+	assert.Equal(t, "context.Context", ctx.Name)
+	assert.Contains(t, ctx.Methods, &ourtypes.InterfaceMethod{
+		Name:        "Err",
+		Parameters:  []string{},
+		ReturnTypes: []string{"error"},
+	})
+	assert.Len(t, ctx.Methods, 4)
+}
@@ -0,0 +1,32 @@
+package parser
+
+import (
+	"go/ast"
+
+	ourtypes "github.com/vlad/ast2llm-go/internal/types"
+	"golang.org/x/tools/go/packages"
+)
+
+// Extractor pulls custom information out of a file, e.g. wire providers, gRPC service registrations or
+// annotations in comments. It receives the file's package, with its type information, and the FileInfo
+// extracted so far, and usually appends CustomItems to it; the composer lists them in a section per
+// CustomItem.Section.
+type Extractor func(pkg *packages.Package, file *ast.File, info *ourtypes.FileInfo) error
+
+// WithExtractor registers a custom extractor run on every extracted file after the built-in extraction.
+// Extractors run in the order they are registered. An error does not fail the parse: it is reported as an
+// "extractor" diagnostic of the file.
+func WithExtractor(extractor Extractor) Option {
+	return func(p *ProjectParser) {
+		p.extractors = append(p.extractors, extractor)
+	}
+}
+
+// runExtractors runs the custom extractors on a file, recording their errors as diagnostics.
+func (p *ProjectParser) runExtractors(pkg *packages.Package, file *ast.File, info *ourtypes.FileInfo) {
+	for _, extractor := range p.extractors {
+		if err := extractor(pkg, file, info); err != nil {
+			info.Diagnostics = append(info.Diagnostics, &ourtypes.Diagnostic{Kind: "extractor", Message: err.Error()})
+		}
+	}
+}
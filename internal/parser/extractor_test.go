@@ -0,0 +1,67 @@
+package parser
+
+import (
+	"errors"
+	"go/ast"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	ourtypes "github.com/vlad/ast2llm-go/internal/types"
+	"golang.org/x/tools/go/packages"
+)
+
+func TestProjectParser_ParseProject_WithExtractor(t *testing.T) {
+	projectPath := writeTestProject(t, map[string]string{
+		"main.go": `package main
+
+type Store struct{}
+
+// NewStore opens the store.
+//
+//+provider
+func NewStore() *Store { return &Store{} }
+
+func helper() {}
+
+func main() {}
+`,
+	})
+	mainPath := filepath.Join(projectPath, "main.go")
+
+	// providers lists the functions annotated with +provider and the type they return
+	providers := func(pkg *packages.Package, file *ast.File, info *ourtypes.FileInfo) error {
+		for _, decl := range file.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok || fn.Doc == nil || !strings.Contains(fn.Doc.Text(), "+provider") {
+				continue
+			}
+			result := pkg.TypesInfo.TypeOf(fn.Type.Results.List[0].Type)
+			info.CustomItems = append(info.CustomItems, &ourtypes.CustomItem{
+				Section: "Providers",
+				Name:    fn.Name.Name,
+				Details: []string{"provides " + result.String()},
+			})
+		}
+		return nil
+	}
+	failing := func(*packages.Package, *ast.File, *ourtypes.FileInfo) error {
+		return errors.New("annotations: malformed tag")
+	}
+
+	fileInfos, err := New(WithExtractor(providers), WithExtractor(failing)).ParseProject(projectPath)
+	require.NoError(t, err)
+	info := fileInfos[mainPath]
+	assert.Equal(t, []*ourtypes.CustomItem{
+		{Section: "Providers", Name: "NewStore", Details: []string{"provides *example.com/testproject.Store"}},
+	}, info.CustomItems)
+	assert.Contains(t, info.Diagnostics, &ourtypes.Diagnostic{Kind: "extractor", Message: "annotations: malformed tag"},
+		"errors are reported on the file instead of failing the parse")
+	assert.Len(t, info.Functions, 3, "built-in extraction is unaffected")
+
+	fileInfos, err = New().ParseProject(projectPath)
+	require.NoError(t, err)
+	assert.Empty(t, fileInfos[mainPath].CustomItems)
+}
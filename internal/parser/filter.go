@@ -0,0 +1,213 @@
+package parser
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"path"
+	"path/filepath"
+	"strings"
+
+	ourtypes "github.com/vlad/ast2llm-go/internal/types"
+	"golang.org/x/tools/go/packages"
+)
+
+// GeneratedMode controls how files with a "Code generated ... DO NOT EDIT." header are handled
+type GeneratedMode int
+
+const (
+	GeneratedInclude   GeneratedMode = iota // Extract generated files like any other file
+	GeneratedSkip                           // Leave generated files out of the result
+	GeneratedSummarize                      // Keep only the exported declarations of generated files, without comments
+)
+
+// WithExcludeGlobs leaves out the files matching any of the patterns. Patterns are matched against the
+// slash-separated path relative to the project root, "*" matches within a path element and "**" matches any
+// number of elements, e.g. "vendor/**" or "**/*_gen.go". Excluded files can still be resolved as used items.
+func WithExcludeGlobs(patterns ...string) Option {
+	return func(p *ProjectParser) {
+		p.excludeGlobs = append(p.excludeGlobs, patterns...)
+	}
+}
+
+// WithGeneratedFiles sets how generated files are handled, they are included by default
+func WithGeneratedFiles(mode GeneratedMode) Option {
+	return func(p *ProjectParser) {
+		p.generatedMode = mode
+	}
+}
+
+// validateExcludeGlobs reports the first malformed exclude pattern.
+func (p *ProjectParser) validateExcludeGlobs() error {
+	for _, pattern := range p.excludeGlobs {
+		for _, elem := range strings.Split(pattern, "/") {
+			if _, err := path.Match(elem, ""); err != nil {
+				return fmt.Errorf("invalid exclude pattern %q: %w", pattern, err)
+			}
+		}
+	}
+	return nil
+}
+
+// extractFile extracts the FileInfo of a file unless it is excluded. Generated files are handled according to
+// the configured mode. root is the absolute project path the exclude patterns are relative to, and pkgDoc the
+// packageDoc of pkg, computed once for all its files.
+func (p *ProjectParser) extractFile(root string, file *ast.File, pkg *packages.Package, pkgDoc string, index *symbolIndex) (*ourtypes.FileInfo, bool) {
+	absolutePath := p.fset.File(file.Pos()).Name()
+	if p.excluded(root, absolutePath) {
+		p.warn(Warning{Severity: SeverityInfo, Kind: WarningSkipped, Package: pkg.PkgPath, Pos: absolutePath, Message: "excluded by pattern"})
+		return nil, false
+	}
+
+	generated := ast.IsGenerated(file)
+	if generated && p.generatedMode == GeneratedSkip {
+		p.warn(Warning{Severity: SeverityInfo, Kind: WarningSkipped, Package: pkg.PkgPath, Pos: absolutePath, Message: "generated file"})
+		return nil, false
+	}
+
+	fileInfo := p.extractFileInfoForFile(file, pkg, pkgDoc, index)
+	p.recordWork(0, 1, 0, 0)
+	fileInfo.Generated = generated
+	fileInfo.HasCgo = importsC(file)
+	fileInfo.HasAsm = hasAsmFiles(pkg)
+	fileInfo.Diagnostics = fileDiagnostics(pkg, absolutePath)
+	p.setFileStamp(fileInfo, absolutePath)
+	if generated && p.generatedMode == GeneratedSummarize {
+		summarizeFileInfo(fileInfo)
+	}
+	p.runExtractors(pkg, file, fileInfo)
+	return p.newNamer(pkg).fileInfo(fileInfo), true
+}
+
+// excluded reports whether absolutePath matches one of the exclude patterns.
+func (p *ProjectParser) excluded(root, absolutePath string) bool {
+	if len(p.excludeGlobs) == 0 {
+		return false
+	}
+	rel, err := filepath.Rel(root, absolutePath)
+	if err != nil {
+		return false
+	}
+	rel = filepath.ToSlash(rel)
+	for _, pattern := range p.excludeGlobs {
+		if matchGlob(pattern, rel) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchGlob reports whether the slash-separated name matches pattern, where "**" matches any number of elements.
+func matchGlob(pattern, name string) bool {
+	return matchGlobElems(strings.Split(pattern, "/"), strings.Split(name, "/"))
+}
+
+func matchGlobElems(pattern, name []string) bool {
+	for len(pattern) > 0 {
+		if pattern[0] == "**" {
+			for i := 0; i <= len(name); i++ {
+				if matchGlobElems(pattern[1:], name[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+		if len(name) == 0 {
+			return false
+		}
+		if ok, _ := path.Match(pattern[0], name[0]); !ok {
+			return false
+		}
+		pattern, name = pattern[1:], name[1:]
+	}
+	return len(name) == 0
+}
+
+// summarizeFileInfo reduces info to its exported declarations, dropping comments, sources, inline types and used items.
+func summarizeFileInfo(info *ourtypes.FileInfo) {
+	functions := make([]*ourtypes.FunctionInfo, 0, len(info.Functions))
+	for _, fn := range info.Functions {
+		if token.IsExported(fn.Name) {
+			fn.Comment, fn.Body = "", ""
+			functions = append(functions, fn)
+		}
+	}
+	info.Functions = functions
+
+	methods := make([]*ourtypes.FunctionInfo, 0, len(info.Methods))
+	for _, m := range info.Methods {
+		if token.IsExported(m.Name) && token.IsExported(strings.TrimPrefix(m.Receiver, "*")) {
+			m.Comment, m.Body = "", ""
+			methods = append(methods, m)
+		}
+	}
+	info.Methods = methods
+
+	structs := make([]*ourtypes.StructInfo, 0, len(info.Structs))
+	for _, s := range info.Structs {
+		if !token.IsExported(unqualifiedName(s.Name)) {
+			continue
+		}
+		s.Comment = ""
+		fields := make([]*ourtypes.StructField, 0, len(s.Fields))
+		for _, f := range s.Fields {
+			if token.IsExported(f.Name) {
+				fields = append(fields, f)
+			}
+		}
+		s.Fields = fields
+		structMethods := make([]*ourtypes.StructMethod, 0, len(s.Methods))
+		for _, m := range s.Methods {
+			if token.IsExported(m.Name) {
+				m.Comment = ""
+				structMethods = append(structMethods, m)
+			}
+		}
+		s.Methods = structMethods
+		structs = append(structs, s)
+	}
+	info.Structs = structs
+
+	interfaces := make([]*ourtypes.InterfaceInfo, 0, len(info.Interfaces))
+	for _, iface := range info.Interfaces {
+		if token.IsExported(unqualifiedName(iface.Name)) {
+			iface.Comment = ""
+			for _, m := range iface.Methods {
+				m.Comment = ""
+			}
+			interfaces = append(interfaces, iface)
+		}
+	}
+	info.Interfaces = interfaces
+
+	globalVars := make([]*ourtypes.GlobalVarInfo, 0, len(info.GlobalVars))
+	for _, gv := range info.GlobalVars {
+		if token.IsExported(gv.Name) {
+			gv.Comment = ""
+			globalVars = append(globalVars, gv)
+		}
+	}
+	info.GlobalVars = globalVars
+
+	errorValues := make([]*ourtypes.ErrorInfo, 0, len(info.ErrorValues))
+	for _, e := range info.ErrorValues {
+		if token.IsExported(unqualifiedName(e.Name)) {
+			e.Comment = ""
+			errorValues = append(errorValues, e)
+		}
+	}
+	info.ErrorValues = errorValues
+
+	info.InlineTypes = make([]*ourtypes.InlineType, 0)
+	info.UsedImportedStructs = make([]*ourtypes.StructInfo, 0)
+	info.UsedImportedInterfaces = make([]*ourtypes.InterfaceInfo, 0)
+	info.UsedImportedFunctions = make([]*ourtypes.FunctionInfo, 0)
+	info.UsedImportedGlobalVars = make([]*ourtypes.GlobalVarInfo, 0)
+	info.ExternalStructs = make([]*ourtypes.StructInfo, 0)
+	info.ExternalInterfaces = make([]*ourtypes.InterfaceInfo, 0)
+}
+
+// unqualifiedName strips the package path from a fully qualified name.
+func unqualifiedName(name string) string {
+	return name[strings.LastIndex(name, ".")+1:]
+}
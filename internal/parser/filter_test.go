@@ -0,0 +1,128 @@
+package parser
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMatchGlob(t *testing.T) {
+	tests := []struct {
+		pattern string
+		name    string
+		want    bool
+	}{
+		{"vendor/**", "vendor/github.com/x/y.go", true},
+		{"vendor/**", "internal/vendor/y.go", false},
+		{"**/*_gen.go", "models_gen.go", true},
+		{"**/*_gen.go", "internal/models/models_gen.go", true},
+		{"**/*_gen.go", "internal/models/models.go", false},
+		{"internal/*/mock.go", "internal/db/mock.go", true},
+		{"internal/*/mock.go", "internal/db/sub/mock.go", false},
+		{"internal/**/mock.go", "internal/db/sub/mock.go", true},
+		{"main.go", "main.go", true},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, matchGlob(tt.pattern, tt.name), "%s ~ %s", tt.pattern, tt.name)
+	}
+}
+
+func TestProjectParser_ParseProject_ExcludeGlobs(t *testing.T) {
+	projectPath := writeTestProject(t, map[string]string{
+		"main.go": `package main
+
+import "example.com/testproject/models"
+
+func main() {
+	models.Reset()
+}
+`,
+		"models/models.go": `package models
+
+type User struct{}
+`,
+		"models/models_gen.go": `package models
+
+// Reset resets the models.
+func Reset() {}
+`,
+	})
+
+	fileInfos, err := New(WithExcludeGlobs("**/*_gen.go")).ParseProject(projectPath)
+	require.NoError(t, err)
+	assert.Len(t, fileInfos, 2)
+	assert.NotContains(t, fileInfos, filepath.Join(projectPath, "models", "models_gen.go"))
+
+	// Functions of excluded files are still resolved where they are used
+	mainInfo := fileInfos[filepath.Join(projectPath, "main.go")]
+	require.Len(t, mainInfo.UsedImportedFunctions, 1)
+	assert.Equal(t, "Reset resets the models.", mainInfo.UsedImportedFunctions[0].Comment)
+
+	_, err = New(WithExcludeGlobs("[")).ParseProject(projectPath)
+	assert.Error(t, err)
+}
+
+func TestProjectParser_ParseProject_GeneratedFiles(t *testing.T) {
+	projectPath := writeTestProject(t, map[string]string{
+		"main.go": `package main
+
+func main() {}
+`,
+		"api/api.pb.go": `// Code generated by protoc-gen-go. DO NOT EDIT.
+
+package api
+
+// Request is a request.
+type Request struct {
+	Name  string
+	state int
+}
+
+// GetName returns the name.
+func (r *Request) GetName() string { return r.Name }
+
+func (r *Request) reset() {}
+
+// NewRequest creates a request.
+func NewRequest() *Request { return &Request{} }
+
+func helper() {}
+
+var fileDescriptor = []byte{}
+`,
+	})
+	generatedPath := filepath.Join(projectPath, "api", "api.pb.go")
+
+	fileInfos, err := New().ParseProject(projectPath)
+	require.NoError(t, err)
+	require.Contains(t, fileInfos, generatedPath)
+	assert.True(t, fileInfos[generatedPath].Generated)
+	assert.False(t, fileInfos[filepath.Join(projectPath, "main.go")].Generated)
+	assert.Len(t, fileInfos[generatedPath].Functions, 2)
+
+	fileInfos, err = New(WithGeneratedFiles(GeneratedSkip)).ParseProject(projectPath)
+	require.NoError(t, err)
+	assert.NotContains(t, fileInfos, generatedPath)
+
+	fileInfos, err = New(WithGeneratedFiles(GeneratedSummarize)).ParseProject(projectPath)
+	require.NoError(t, err)
+	require.Contains(t, fileInfos, generatedPath)
+	info := fileInfos[generatedPath]
+	assert.True(t, info.Generated)
+
+	require.Len(t, info.Functions, 1)
+	assert.Equal(t, "NewRequest", info.Functions[0].Name)
+	assert.Empty(t, info.Functions[0].Comment)
+	require.Len(t, info.Methods, 1)
+	assert.Equal(t, "GetName", info.Methods[0].Name)
+	assert.Empty(t, info.GlobalVars)
+
+	require.Len(t, info.Structs, 1)
+	assert.Empty(t, info.Structs[0].Comment)
+	require.Len(t, info.Structs[0].Fields, 1)
+	assert.Equal(t, "Name", info.Structs[0].Fields[0].Name)
+	require.Len(t, info.Structs[0].Methods, 1)
+	assert.Equal(t, "GetName", info.Structs[0].Methods[0].Name)
+}
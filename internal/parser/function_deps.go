@@ -0,0 +1,107 @@
+package parser
+
+import (
+	"fmt"
+	"go/ast"
+	gotypes "go/types"
+	"sort"
+
+	ourtypes "github.com/vlad/ast2llm-go/internal/types"
+)
+
+// ExtractFunctionDeps loads the project and lists the types, functions, package-level variables and constants and
+// packages referenced inside the body of funcName. funcName is resolved like in ExtractSymbolContext, so it may
+// match several functions, e.g. a bare name declared in two packages; one FunctionDeps is returned for each.
+func (p *ProjectParser) ExtractFunctionDeps(projectPath, funcName string) ([]*ourtypes.FunctionDeps, error) {
+	pkgs, err := p.loadPackages(projectPath, "./...")
+	if err != nil {
+		return nil, err
+	}
+
+	sc := newSymbolContext(p, pkgs)
+	var result []*ourtypes.FunctionDeps
+	for _, obj := range sc.lookup(funcName) {
+		fn, ok := obj.(*gotypes.Func)
+		if !ok {
+			continue
+		}
+		path := p.fset.Position(fn.Pos()).Filename
+		file, ok := sc.files[path]
+		if !ok {
+			continue
+		}
+		funcDecl := findFuncDecl(file, sc.packageOf(file), fn)
+		if funcDecl == nil {
+			continue
+		}
+		result = append(result, functionDeps(fn, funcDecl, sc.packageOf(file).TypesInfo))
+	}
+
+	if len(result) == 0 {
+		return nil, fmt.Errorf("function %s not found in %s", funcName, projectPath)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Function < result[j].Function })
+	return result, nil
+}
+
+// functionDeps collects the objects used in the body of funcDecl.
+func functionDeps(fn *gotypes.Func, funcDecl *ast.FuncDecl, info *gotypes.Info) *ourtypes.FunctionDeps {
+	deps := ourtypes.NewFunctionDeps()
+	deps.Function = fn.FullName()
+	if funcDecl.Body == nil {
+		return deps
+	}
+
+	seen := make(map[string]bool)
+	add := func(list *[]string, kind, name string) {
+		if key := kind + " " + name; !seen[key] {
+			seen[key] = true
+			*list = append(*list, name)
+		}
+	}
+	addPackage := func(pkg *gotypes.Package) {
+		if pkg != nil && pkg != fn.Pkg() {
+			add(&deps.Packages, "package", pkg.Path())
+		}
+	}
+
+	ast.Inspect(funcDecl.Body, func(n ast.Node) bool {
+		ident, ok := n.(*ast.Ident)
+		if !ok {
+			return true
+		}
+		switch obj := info.Uses[ident].(type) {
+		case *gotypes.PkgName:
+			addPackage(obj.Imported())
+		case *gotypes.TypeName:
+			if obj.Pkg() == nil || obj.Parent() != obj.Pkg().Scope() {
+				return true // Predeclared, local type or type parameter
+			}
+			add(&deps.Types, "type", qualifiedName(obj))
+			addPackage(obj.Pkg())
+		case *gotypes.Func:
+			if obj.Pkg() == nil {
+				return true // Predeclared function such as error.Error
+			}
+			add(&deps.Functions, "func", obj.Origin().FullName())
+			addPackage(obj.Pkg())
+		case *gotypes.Var:
+			if obj.Pkg() != nil && obj.Parent() == obj.Pkg().Scope() {
+				add(&deps.GlobalVars, "var", qualifiedName(obj))
+				addPackage(obj.Pkg())
+			}
+		case *gotypes.Const:
+			if obj.Pkg() != nil && obj.Parent() == obj.Pkg().Scope() {
+				add(&deps.GlobalVars, "var", qualifiedName(obj))
+				addPackage(obj.Pkg())
+			}
+		}
+		return true
+	})
+
+	sort.Strings(deps.Types)
+	sort.Strings(deps.Functions)
+	sort.Strings(deps.GlobalVars)
+	sort.Strings(deps.Packages)
+	return deps
+}
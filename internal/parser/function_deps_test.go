@@ -0,0 +1,83 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProjectParser_ExtractFunctionDeps(t *testing.T) {
+	projectPath := writeTestProject(t, map[string]string{
+		"models/models.go": `package models
+
+const DefaultName = "anonymous"
+
+type User struct{ Name string }
+
+func (u *User) Rename(name string) { u.Name = name }
+
+func NewUser() *User { return &User{Name: DefaultName} }
+`,
+		"main.go": `package main
+
+import (
+	"fmt"
+	"strings"
+
+	"example.com/testproject/models"
+)
+
+var prefix = "user: "
+
+type local struct{ n int }
+
+func unused() {}
+
+func greet[T any](v T) string {
+	type inner struct{}
+	_ = inner{}
+	_ = local{n: 1}
+	u := models.NewUser()
+	u.Rename(strings.ToUpper(u.Name))
+	var err error
+	_ = err
+	return fmt.Sprint(prefix, u, v)
+}
+
+func main() { greet(1) }
+`,
+	})
+
+	deps, err := New().ExtractFunctionDeps(projectPath, "greet")
+	require.NoError(t, err)
+	require.Len(t, deps, 1)
+
+	d := deps[0]
+	assert.Equal(t, "example.com/testproject.greet", d.Function)
+	assert.Equal(t, []string{"example.com/testproject.local"}, d.Types)
+	assert.Equal(t, []string{
+		"(*example.com/testproject/models.User).Rename",
+		"example.com/testproject/models.NewUser",
+		"fmt.Sprint",
+		"strings.ToUpper",
+	}, d.Functions)
+	assert.Equal(t, []string{"example.com/testproject.prefix"}, d.GlobalVars)
+	assert.Equal(t, []string{"example.com/testproject/models", "fmt", "strings"}, d.Packages)
+
+	t.Run("method", func(t *testing.T) {
+		deps, err := New().ExtractFunctionDeps(projectPath, "User.Rename")
+		require.NoError(t, err)
+		require.Len(t, deps, 1)
+		assert.Equal(t, "(*example.com/testproject/models.User).Rename", deps[0].Function)
+		assert.Empty(t, deps[0].Functions)
+		assert.Empty(t, deps[0].Packages)
+	})
+
+	t.Run("not a function", func(t *testing.T) {
+		_, err := New().ExtractFunctionDeps(projectPath, "models.User")
+		assert.Error(t, err)
+		_, err = New().ExtractFunctionDeps(projectPath, "missing")
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,232 @@
+package parser
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	ourtypes "github.com/vlad/ast2llm-go/internal/types"
+	"golang.org/x/tools/go/packages"
+)
+
+// fileStamp identifies the on-disk version of a source file
+type fileStamp struct {
+	modTime time.Time
+	size    int64
+}
+
+// packageCache holds the extracted FileInfo of one package and the stamps of the files it was built from
+type packageCache struct {
+	imports []string                      // Import paths of the package
+	stamps  map[string]fileStamp          // Key: absolute file path
+	infos   map[string]*ourtypes.FileInfo // Key: absolute file path
+}
+
+// projectCache holds the cached packages of one project
+type projectCache struct {
+	packages map[string]*packageCache // Key: package path
+}
+
+// ParseProjectIncremental works like ParseProject but reuses results from previous calls for the same project.
+// Only packages containing one of changedFiles, or whose files changed on disk since the last call, are re-parsed
+// together with the project packages importing them. New files must be listed in changedFiles to be picked up.
+func (p *ProjectParser) ParseProjectIncremental(projectPath string, changedFiles []string) (_ ProjectInfo, err error) {
+	defer func(start time.Time) { p.recordParse(start, err) }(time.Now())
+
+	absPath, err := filepath.Abs(projectPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve project path: %w", err)
+	}
+
+	p.cacheMu.Lock()
+	defer p.cacheMu.Unlock()
+
+	pc, ok := p.cache[absPath]
+	if !ok {
+		return p.parseProjectIntoCache(absPath)
+	}
+
+	changed, known := pc.changedPackages(absPath, changedFiles)
+	if !known {
+		// A file outside of every cached package was reported, so package boundaries may have moved.
+		return p.parseProjectIntoCache(absPath)
+	}
+	if len(changed) == 0 {
+		p.recordWork(0, 0, len(pc.packages), 0)
+		return pc.projectInfo(), nil
+	}
+
+	// Dependents are re-extracted too since their used imported items may have changed.
+	affected := pc.withReverseDeps(changed)
+	p.recordWork(0, 0, len(pc.packages)-len(affected), len(affected))
+
+	// Direct project imports of the affected packages are loaded to resolve the items they use.
+	load := make(map[string]bool, len(affected))
+	for pkgPath := range affected {
+		load[pkgPath] = true
+		if cached, ok := pc.packages[pkgPath]; ok {
+			for _, imp := range cached.imports {
+				if _, isProjectPkg := pc.packages[imp]; isProjectPkg {
+					load[imp] = true
+				}
+			}
+		}
+	}
+	patterns := make([]string, 0, len(load))
+	for pkgPath := range load {
+		patterns = append(patterns, pkgPath)
+	}
+
+	pkgs, err := p.loadPackages(absPath, patterns...)
+	if err != nil {
+		return nil, err
+	}
+
+	for pkgPath := range affected {
+		delete(pc.packages, pkgPath)
+	}
+	index := p.buildSymbolIndex(pkgs)
+	var mu sync.Mutex
+	p.forEachPackage(pkgs, func(pkg *packages.Package) {
+		if !affected[pkg.PkgPath] {
+			return
+		}
+		entry := p.extractPackageCache(absPath, pkg, index)
+		mu.Lock()
+		pc.packages[pkg.PkgPath] = entry
+		mu.Unlock()
+	})
+
+	return pc.projectInfo(), nil
+}
+
+// parseProjectIntoCache fully parses the project and replaces its cache entry.
+func (p *ProjectParser) parseProjectIntoCache(absPath string) (ProjectInfo, error) {
+	pkgs, err := p.loadPackages(absPath, "./...")
+	if err != nil {
+		return nil, err
+	}
+
+	pc := &projectCache{packages: make(map[string]*packageCache)}
+	index := p.buildSymbolIndex(pkgs)
+	var mu sync.Mutex
+	p.forEachPackage(pkgs, func(pkg *packages.Package) {
+		entry := p.extractPackageCache(absPath, pkg, index)
+		mu.Lock()
+		pc.packages[pkg.PkgPath] = entry
+		mu.Unlock()
+	})
+	p.cache[absPath] = pc
+	p.recordWork(0, 0, 0, len(pc.packages))
+
+	return pc.projectInfo(), nil
+}
+
+// extractPackageCache extracts FileInfo for every included file of pkg and records the stamps of all its files,
+// then releases pkg.
+func (p *ProjectParser) extractPackageCache(absPath string, pkg *packages.Package, index *symbolIndex) *packageCache {
+	entry := &packageCache{
+		imports: make([]string, 0, len(pkg.Imports)),
+		stamps:  make(map[string]fileStamp),
+		infos:   make(map[string]*ourtypes.FileInfo),
+	}
+	for imp := range pkg.Imports {
+		entry.imports = append(entry.imports, imp)
+	}
+	doc := p.packageDoc(pkg)
+	for _, file := range pkg.Syntax {
+		absolutePath := p.fset.File(file.Pos()).Name()
+		if fileInfo, ok := p.extractFile(absPath, file, pkg, doc, index); ok {
+			entry.infos[absolutePath] = fileInfo
+		}
+		if stamp, err := statFile(absolutePath); err == nil {
+			entry.stamps[absolutePath] = stamp
+		}
+	}
+	p.releasePackage(pkg)
+	return entry
+}
+
+// changedPackages returns the cached packages with changed files. The second result is false when one of
+// changedFiles does not belong to any cached package.
+func (pc *projectCache) changedPackages(absPath string, changedFiles []string) (map[string]bool, bool) {
+	changed := make(map[string]bool)
+
+	for _, f := range changedFiles {
+		if !filepath.IsAbs(f) {
+			f = filepath.Join(absPath, f)
+		}
+		f = filepath.Clean(f)
+
+		found := false
+		for pkgPath, entry := range pc.packages {
+			if _, ok := entry.stamps[f]; ok {
+				changed[pkgPath] = true
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, false
+		}
+	}
+
+	for pkgPath, entry := range pc.packages {
+		if changed[pkgPath] {
+			continue
+		}
+		for f, stamp := range entry.stamps {
+			if current, err := statFile(f); err != nil || !current.equal(stamp) {
+				changed[pkgPath] = true
+				break
+			}
+		}
+	}
+
+	return changed, true
+}
+
+// withReverseDeps returns changed extended with every cached package that directly imports one of them.
+func (pc *projectCache) withReverseDeps(changed map[string]bool) map[string]bool {
+	affected := make(map[string]bool, len(changed))
+	for pkgPath, entry := range pc.packages {
+		if changed[pkgPath] {
+			affected[pkgPath] = true
+			continue
+		}
+		for _, imp := range entry.imports {
+			if changed[imp] {
+				affected[pkgPath] = true
+				break
+			}
+		}
+	}
+	return affected
+}
+
+// projectInfo assembles a ProjectInfo from the cached packages.
+func (pc *projectCache) projectInfo() ProjectInfo {
+	fileInfos := make(ProjectInfo)
+	for _, entry := range pc.packages {
+		for path, info := range entry.infos {
+			fileInfos[path] = info
+		}
+	}
+	return fileInfos
+}
+
+// equal reports whether two stamps describe the same file version.
+func (s fileStamp) equal(other fileStamp) bool {
+	return s.size == other.size && s.modTime.Equal(other.modTime)
+}
+
+// statFile returns the current stamp of a file.
+func statFile(path string) (fileStamp, error) {
+	st, err := os.Stat(path)
+	if err != nil {
+		return fileStamp{}, err
+	}
+	return fileStamp{modTime: st.ModTime(), size: st.Size()}, nil
+}
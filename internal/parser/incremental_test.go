@@ -0,0 +1,100 @@
+package parser
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vlad/ast2llm-go/internal/testutil"
+)
+
+// writeTestProject creates a module named example.com/testproject with the given files and returns its path.
+func writeTestProject(t testing.TB, files map[string]string) string {
+	t.Helper()
+	return testutil.WriteModule(t, "example.com/testproject", files)
+}
+
+func TestProjectParser_ParseProjectIncremental(t *testing.T) {
+	projectPath := writeTestProject(t, map[string]string{
+		"pkg1/types.go": `package pkg1
+
+// Data struct
+type Data struct {
+	Value string
+}
+`,
+		"pkg2/consumer.go": `package pkg2
+
+import "example.com/testproject/pkg1"
+
+// ProcessData processes data.
+func ProcessData(d pkg1.Data) string {
+	return d.Value
+}
+`,
+		"pkg3/other.go": `package pkg3
+
+// Other is unrelated to the other packages.
+func Other() {}
+`,
+	})
+
+	p := New()
+
+	first, err := p.ParseProjectIncremental(projectPath, nil)
+	require.NoError(t, err)
+	require.Len(t, first, 3)
+
+	typesPath := filepath.Join(projectPath, "pkg1", "types.go")
+	consumerPath := filepath.Join(projectPath, "pkg2", "consumer.go")
+	otherPath := filepath.Join(projectPath, "pkg3", "other.go")
+
+	// Nothing changed: every FileInfo is served from the cache.
+	second, err := p.ParseProjectIncremental(projectPath, nil)
+	require.NoError(t, err)
+	for path, info := range first {
+		assert.Same(t, info, second[path], "expected cached FileInfo for %s", path)
+	}
+
+	err = os.WriteFile(typesPath, []byte(`package pkg1
+
+// Data struct
+type Data struct {
+	Value string
+	Count int
+}
+`), 0644)
+	require.NoError(t, err)
+
+	third, err := p.ParseProjectIncremental(projectPath, []string{"pkg1/types.go"})
+	require.NoError(t, err)
+	require.Len(t, third, 3)
+
+	require.Len(t, third[typesPath].Structs, 1)
+	assert.Len(t, third[typesPath].Structs[0].Fields, 2)
+
+	// The dependent package is re-extracted, the unrelated one is reused.
+	assert.NotSame(t, first[consumerPath], third[consumerPath])
+	assert.Same(t, first[otherPath], third[otherPath])
+}
+
+func TestProjectParser_ParseProjectIncremental_NewFile(t *testing.T) {
+	projectPath := writeTestProject(t, map[string]string{
+		"main.go": "package main\n\nfunc main() {}\n",
+	})
+
+	p := New()
+
+	_, err := p.ParseProjectIncremental(projectPath, nil)
+	require.NoError(t, err)
+
+	err = os.WriteFile(filepath.Join(projectPath, "util.go"), []byte("package main\n\nfunc helper() {}\n"), 0644)
+	require.NoError(t, err)
+
+	info, err := p.ParseProjectIncremental(projectPath, []string{"util.go"})
+	require.NoError(t, err)
+	assert.Len(t, info, 2)
+	assert.Contains(t, info, filepath.Join(projectPath, "util.go"))
+}
@@ -0,0 +1,174 @@
+package parser
+
+import (
+	"fmt"
+	"go/ast"
+	gotypes "go/types"
+	"path/filepath"
+	"strings"
+
+	ourtypes "github.com/vlad/ast2llm-go/internal/types"
+	"golang.org/x/tools/go/packages"
+)
+
+// DefaultInlineMinLines is the number of lines from which anonymous structs and function literals are extracted
+// unless WithInlineMinLines sets another one
+const DefaultInlineMinLines = 5
+
+// WithInlineMinLines sets how many source lines an anonymous struct or function literal has to span to be
+// extracted as an inline type, defaults to DefaultInlineMinLines. 0 leaves all of them out.
+func WithInlineMinLines(n int) Option {
+	return func(p *ProjectParser) {
+		p.inlineMinLines = n
+	}
+}
+
+// extractInlineTypes returns the anonymous structs and function literals of file spanning at least the configured
+// number of lines, in source order. Structs nested in an extracted struct are part of its field types; function
+// literals are searched for further inline types.
+func (p *ProjectParser) extractInlineTypes(file *ast.File, pkg *packages.Package) []*ourtypes.InlineType {
+	inlineTypes := make([]*ourtypes.InlineType, 0)
+	if p.inlineMinLines <= 0 {
+		return inlineTypes
+	}
+
+	for _, decl := range file.Decls {
+		owner := declOwner(decl)
+		var stack []ast.Node // Ancestors of the inspected node within decl
+		ast.Inspect(decl, func(n ast.Node) bool {
+			if n == nil {
+				stack = stack[:len(stack)-1]
+				return true
+			}
+
+			var inlineType *ourtypes.InlineType
+			switch node := n.(type) {
+			case *ast.StructType:
+				if _, named := parentNode(stack, 1).(*ast.TypeSpec); named || p.lines(node) < p.inlineMinLines {
+					break
+				}
+				inlineType = &ourtypes.InlineType{Kind: ourtypes.InlineStruct, Fields: make([]*ourtypes.StructField, 0)}
+				if structType, ok := pkg.TypesInfo.TypeOf(node).(*gotypes.Struct); ok {
+					for i := 0; i < structType.NumFields(); i++ {
+						fieldVar := structType.Field(i)
+						field := ourtypes.NewStructField()
+						field.Name = fieldVar.Name()
+						field.Type = fieldVar.Type().String()
+						field.TypeRef = fieldTypeRef(fieldVar.Type())
+						inlineType.Fields = append(inlineType.Fields, field)
+					}
+				}
+			case *ast.FuncLit:
+				if p.lines(node) < p.inlineMinLines {
+					break
+				}
+				inlineType = &ourtypes.InlineType{Kind: ourtypes.InlineFunc}
+				if sig, ok := pkg.TypesInfo.TypeOf(node).(*gotypes.Signature); ok {
+					inlineType.Signature = strings.TrimPrefix(sig.String(), "func")
+				}
+			}
+
+			if inlineType != nil {
+				position := p.fset.Position(n.Pos())
+				label := "anonymous struct"
+				if inlineType.Kind == ourtypes.InlineFunc {
+					label = "func literal"
+				}
+				inlineType.Name = fmt.Sprintf("%s:%d %s", filepath.Base(position.Filename), position.Line, label)
+				inlineType.Context = owner
+				if binding := inlineBinding(n, stack); binding != "" && binding != owner {
+					inlineType.Context += ", " + binding
+				}
+				inlineType.Lines = p.lines(n)
+				inlineType.Pos = p.position(n.Pos())
+				inlineTypes = append(inlineTypes, inlineType)
+				if inlineType.Kind == ourtypes.InlineStruct {
+					return false
+				}
+			}
+			stack = append(stack, n)
+			return true
+		})
+	}
+	return inlineTypes
+}
+
+// lines returns the number of source lines node spans.
+func (p *ProjectParser) lines(node ast.Node) int {
+	return p.fset.Position(node.End()).Line - p.fset.Position(node.Pos()).Line + 1
+}
+
+// declOwner describes a top-level declaration, e.g. "func main", "func (*Server).Run" or "var handlers".
+func declOwner(decl ast.Decl) string {
+	switch d := decl.(type) {
+	case *ast.FuncDecl:
+		return "func " + funcDeclName(d)
+	case *ast.GenDecl:
+		if len(d.Specs) == 0 {
+			return d.Tok.String()
+		}
+		switch spec := d.Specs[0].(type) {
+		case *ast.TypeSpec:
+			return "type " + spec.Name.Name
+		case *ast.ValueSpec:
+			return d.Tok.String() + " " + spec.Names[0].Name
+		}
+		return d.Tok.String()
+	}
+	return ""
+}
+
+// inlineBinding describes what node is bound to from its ancestors, e.g. "field Options", "parameter fn",
+// "var handler" or "argument of http.HandleFunc", or returns "" if it is used otherwise. Composite literals and
+// slice, array, map, pointer and channel types around node are looked through.
+func inlineBinding(node ast.Node, stack []ast.Node) string {
+	depth := 1
+	for isWrapper(parentNode(stack, depth)) {
+		node = parentNode(stack, depth)
+		depth++
+	}
+
+	switch parent := parentNode(stack, depth).(type) {
+	case *ast.Field:
+		if len(parent.Names) == 0 {
+			return ""
+		}
+		if _, inStruct := parentNode(stack, depth+2).(*ast.StructType); inStruct {
+			return "field " + parent.Names[0].Name
+		}
+		return "parameter " + parent.Names[0].Name
+	case *ast.ValueSpec:
+		for i, value := range parent.Values {
+			if value == node && i < len(parent.Names) {
+				return "var " + parent.Names[i].Name
+			}
+		}
+		return "var " + parent.Names[0].Name
+	case *ast.AssignStmt:
+		for i, value := range parent.Rhs {
+			if value == node && i < len(parent.Lhs) {
+				return "var " + gotypes.ExprString(parent.Lhs[i])
+			}
+		}
+	case *ast.CallExpr:
+		return "argument of " + gotypes.ExprString(parent.Fun)
+	}
+	return ""
+}
+
+// isWrapper reports whether node is a composite literal or a type built around an inline type.
+func isWrapper(node ast.Node) bool {
+	switch node.(type) {
+	case *ast.CompositeLit, *ast.ArrayType, *ast.MapType, *ast.StarExpr, *ast.ChanType:
+		return true
+	}
+	return false
+}
+
+// parentNode returns the n-th ancestor on stack, 1 being the parent, or nil if there is none.
+func parentNode(stack []ast.Node, n int) ast.Node {
+	if len(stack) < n {
+		return nil
+	}
+	return stack[len(stack)-n]
+}
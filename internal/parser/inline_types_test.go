@@ -0,0 +1,91 @@
+package parser
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	ourtypes "github.com/vlad/ast2llm-go/internal/types"
+)
+
+func TestProjectParser_ParseProject_InlineTypes(t *testing.T) {
+	projectPath := writeTestProject(t, map[string]string{
+		"main.go": `package main
+
+import "sort"
+
+type Config struct {
+	Name    string
+	Options struct {
+		Verbose bool
+		Retries int
+		Labels  map[string]string
+	}
+	Small struct{ On bool }
+}
+
+var routes = []struct {
+	Path    string
+	Handler func() error
+	Method  string
+}{}
+
+func main() {
+	handler := func(name string) error {
+		if name == "" {
+			return nil
+		}
+		return nil
+	}
+	_ = handler
+
+	names := []string{"b", "a"}
+	sort.Slice(names, func(i, j int) bool {
+		a := names[i]
+		b := names[j]
+		return a < b
+	})
+
+	short := func() {}
+	_ = short
+}
+`,
+	})
+	mainPath := filepath.Join(projectPath, "main.go")
+
+	fileInfos, err := New().ParseProject(projectPath)
+	require.NoError(t, err)
+	inlineTypes := fileInfos[mainPath].InlineTypes
+	require.Len(t, inlineTypes, 4)
+
+	assert.Equal(t, "main.go:7 anonymous struct", inlineTypes[0].Name)
+	assert.Equal(t, ourtypes.InlineStruct, inlineTypes[0].Kind)
+	assert.Equal(t, "type Config, field Options", inlineTypes[0].Context)
+	assert.Equal(t, 5, inlineTypes[0].Lines)
+	assert.Equal(t, &ourtypes.Position{File: mainPath, Line: 7, Column: 10}, inlineTypes[0].Pos)
+	require.Len(t, inlineTypes[0].Fields, 3)
+	assert.Equal(t, "Labels", inlineTypes[0].Fields[2].Name)
+	assert.Equal(t, "map[string]string", inlineTypes[0].Fields[2].Type)
+
+	assert.Equal(t, "main.go:15 anonymous struct", inlineTypes[1].Name)
+	assert.Equal(t, "var routes", inlineTypes[1].Context, "the binding is the owner itself")
+
+	assert.Equal(t, "main.go:22 func literal", inlineTypes[2].Name)
+	assert.Equal(t, ourtypes.InlineFunc, inlineTypes[2].Kind)
+	assert.Equal(t, "func main, var handler", inlineTypes[2].Context)
+	assert.Equal(t, "(name string) error", inlineTypes[2].Signature)
+	assert.Empty(t, inlineTypes[2].Fields)
+
+	assert.Equal(t, "main.go:31 func literal", inlineTypes[3].Name)
+	assert.Equal(t, "func main, argument of sort.Slice", inlineTypes[3].Context)
+	assert.Equal(t, "(i int, j int) bool", inlineTypes[3].Signature)
+
+	fileInfos, err = New(WithInlineMinLines(1)).ParseProject(projectPath)
+	require.NoError(t, err)
+	assert.Len(t, fileInfos[mainPath].InlineTypes, 6)
+
+	fileInfos, err = New(WithInlineMinLines(0)).ParseProject(projectPath)
+	require.NoError(t, err)
+	assert.Empty(t, fileInfos[mainPath].InlineTypes)
+}
@@ -0,0 +1,139 @@
+package parser
+
+import (
+	"fmt"
+	gotypes "go/types"
+	"strings"
+
+	ourtypes "github.com/vlad/ast2llm-go/internal/types"
+)
+
+// CheckInterfaceImpl loads the project and reports whether the named type typeName implements the interface
+// interfaceName, listing every interface method it lacks or declares with another signature. The type must be
+// declared in the project; the interface may also come from another package, e.g. io.Reader. Both names are
+// resolved like in ExtractSymbolContext, except that interfaces of other packages need their package name, or
+// their import path if the project does not import that package yet.
+func (p *ProjectParser) CheckInterfaceImpl(projectPath, typeName, interfaceName string) (*ourtypes.InterfaceCheck, error) {
+	pkgs, err := p.loadPackages(projectPath, "./...")
+	if err != nil {
+		return nil, err
+	}
+	sc := newSymbolContext(p, pkgs)
+
+	var named *gotypes.Named
+	for _, obj := range sc.lookup(typeName) {
+		if tn, ok := obj.(*gotypes.TypeName); ok {
+			if n, ok := tn.Type().(*gotypes.Named); ok {
+				if _, isIface := n.Underlying().(*gotypes.Interface); !isIface {
+					named = n
+					break
+				}
+			}
+		}
+	}
+	if named == nil {
+		return nil, fmt.Errorf("type %s not found in %s", typeName, projectPath)
+	}
+
+	candidates := append(sc.lookup(interfaceName), sc.lookupImported(interfaceName)...)
+	if len(candidates) == 0 {
+		candidates = p.lookupInPackage(projectPath, interfaceName)
+	}
+	var ifaceNamed *gotypes.Named
+	for _, obj := range candidates {
+		if tn, ok := obj.(*gotypes.TypeName); ok {
+			if n, ok := tn.Type().(*gotypes.Named); ok && gotypes.IsInterface(n) {
+				ifaceNamed = n
+				break
+			}
+		}
+	}
+	if ifaceNamed == nil {
+		return nil, fmt.Errorf("interface %s not found in %s or its imports", interfaceName, projectPath)
+	}
+	iface := ifaceNamed.Underlying().(*gotypes.Interface)
+
+	check := &ourtypes.InterfaceCheck{
+		Type:              namedTypeName(named),
+		Interface:         namedTypeName(ifaceNamed),
+		Problems:          make([]*ourtypes.MethodProblem, 0),
+		PointerImplements: gotypes.Implements(gotypes.NewPointer(named), iface),
+	}
+	missing, _ := gotypes.MissingMethod(named, iface, true)
+	check.Implements = missing == nil
+	if check.Implements {
+		return check, nil
+	}
+
+	qualifier := gotypes.RelativeTo(named.Obj().Pkg())
+	valueMethods := gotypes.NewMethodSet(named)
+	for i := 0; i < iface.NumMethods(); i++ {
+		want := iface.Method(i)
+		problem := &ourtypes.MethodProblem{
+			Method: want.Name(),
+			Want:   strings.TrimPrefix(gotypes.TypeString(want.Type(), qualifier), "func"),
+		}
+
+		obj, _, _ := gotypes.LookupFieldOrMethod(named, true, want.Pkg(), want.Name())
+		have, ok := obj.(*gotypes.Func)
+		switch {
+		case !ok:
+			problem.Kind = ourtypes.ProblemMissing
+		case !gotypes.Identical(have.Type(), want.Type()): // Receivers are ignored
+			problem.Kind = ourtypes.ProblemWrongSignature
+			problem.Have = strings.TrimPrefix(gotypes.TypeString(have.Type(), qualifier), "func")
+		case valueMethods.Lookup(want.Pkg(), want.Name()) == nil:
+			problem.Kind = ourtypes.ProblemPointerReceiver
+			problem.Have = problem.Want
+		default:
+			continue
+		}
+		check.Problems = append(check.Problems, problem)
+	}
+	return check, nil
+}
+
+// lookupImported resolves symbolName to package-level objects of the packages imported by the project that
+// are not part of it. Only pkg.Name and fully qualified names match, as bare names are often ambiguous there.
+func (sc *symbolContext) lookupImported(symbolName string) []gotypes.Object {
+	var found []gotypes.Object
+	seen := make(map[*gotypes.Package]bool)
+	for _, pkg := range sc.pkgs {
+		if pkg.Types == nil {
+			continue
+		}
+		for _, imp := range pkg.Types.Imports() {
+			if seen[imp] {
+				continue
+			}
+			seen[imp] = true
+			if _, ok := sc.pkgs[imp.Path()]; ok {
+				continue
+			}
+			for _, name := range imp.Scope().Names() {
+				if symbolName == imp.Name()+"."+name || symbolName == imp.Path()+"."+name {
+					found = append(found, imp.Scope().Lookup(name))
+				}
+			}
+		}
+	}
+	return found
+}
+
+// lookupInPackage loads the package of a fully qualified name such as io.Reader or example.com/lib.Store and
+// returns the object it names, or nil. Types of a package loaded this way are distinct from the ones the project
+// was type-checked with, so it is only used for packages the project does not import.
+func (p *ProjectParser) lookupInPackage(projectPath, qualifiedName string) []gotypes.Object {
+	idx := strings.LastIndex(qualifiedName, ".")
+	if idx <= 0 {
+		return nil
+	}
+	pkgs, err := p.loadPackages(projectPath, qualifiedName[:idx])
+	if err != nil || len(pkgs) != 1 || pkgs[0].Types == nil {
+		return nil
+	}
+	if obj := pkgs[0].Types.Scope().Lookup(qualifiedName[idx+1:]); obj != nil {
+		return []gotypes.Object{obj}
+	}
+	return nil
+}
@@ -0,0 +1,88 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	ourtypes "github.com/vlad/ast2llm-go/internal/types"
+)
+
+func TestProjectParser_CheckInterfaceImpl(t *testing.T) {
+	projectPath := writeTestProject(t, map[string]string{
+		"store/store.go": `package store
+
+import "io"
+
+// Store persists items.
+type Store interface {
+	Get(key string) (string, error)
+	Put(key, value string) error
+	Close() error
+}
+
+// Memory keeps items in memory.
+type Memory struct {
+	items map[string]string
+}
+
+func (m *Memory) Get(key string) (string, error) { return m.items[key], nil }
+
+func (m Memory) Put(key string, value int) error { return nil }
+
+// Closer implements io.Closer through its pointer.
+type Closer struct{}
+
+func (c *Closer) Close() error { return nil }
+
+// Embedded gets Close from an embedded pointer.
+type Embedded struct {
+	*Closer
+}
+
+var _ io.Closer = (*Closer)(nil)
+`,
+	})
+	p := New()
+
+	t.Run("missing and mismatched methods", func(t *testing.T) {
+		check, err := p.CheckInterfaceImpl(projectPath, "Memory", "store.Store")
+		require.NoError(t, err)
+
+		assert.Equal(t, "example.com/testproject/store.Memory", check.Type)
+		assert.Equal(t, "example.com/testproject/store.Store", check.Interface)
+		assert.False(t, check.Implements)
+		assert.False(t, check.PointerImplements)
+		assert.Equal(t, []*ourtypes.MethodProblem{
+			{Method: "Close", Kind: ourtypes.ProblemMissing, Want: "() error"},
+			{Method: "Get", Kind: ourtypes.ProblemPointerReceiver, Want: "(key string) (string, error)", Have: "(key string) (string, error)"},
+			{Method: "Put", Kind: ourtypes.ProblemWrongSignature, Want: "(key string, value string) error", Have: "(key string, value int) error"},
+		}, check.Problems)
+	})
+
+	t.Run("imported interface implemented by pointer", func(t *testing.T) {
+		check, err := p.CheckInterfaceImpl(projectPath, "Closer", "io.Closer")
+		require.NoError(t, err)
+
+		assert.Equal(t, "io.Closer", check.Interface)
+		assert.False(t, check.Implements)
+		assert.True(t, check.PointerImplements)
+		assert.Equal(t, []*ourtypes.MethodProblem{
+			{Method: "Close", Kind: ourtypes.ProblemPointerReceiver, Want: "() error", Have: "() error"},
+		}, check.Problems)
+	})
+
+	t.Run("method promoted through an embedded pointer", func(t *testing.T) {
+		check, err := p.CheckInterfaceImpl(projectPath, "Embedded", "io.Closer")
+		require.NoError(t, err)
+		assert.True(t, check.Implements)
+		assert.Empty(t, check.Problems)
+	})
+
+	t.Run("unknown names", func(t *testing.T) {
+		_, err := p.CheckInterfaceImpl(projectPath, "Missing", "io.Closer")
+		assert.ErrorContains(t, err, "type Missing not found")
+		_, err = p.CheckInterfaceImpl(projectPath, "Memory", "Closer")
+		assert.ErrorContains(t, err, "interface Closer not found")
+	})
+}
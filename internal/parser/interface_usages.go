@@ -0,0 +1,62 @@
+package parser
+
+import (
+	"sort"
+	"strings"
+
+	ourtypes "github.com/vlad/ast2llm-go/internal/types"
+)
+
+// InterfaceUsages returns, for every interface taken or returned by a function or method of the project, the
+// functions and methods doing so, keyed by the interface name. Interfaces declared outside the project, e.g.
+// io.Reader, are included.
+func InterfaceUsages(info ProjectInfo) map[string]*ourtypes.InterfaceUsage {
+	usages := make(map[string]*ourtypes.InterfaceUsage)
+	usage := func(name string) *ourtypes.InterfaceUsage {
+		u, ok := usages[name]
+		if !ok {
+			u = &ourtypes.InterfaceUsage{Interface: name}
+			usages[name] = u
+		}
+		return u
+	}
+	for _, fileInfo := range info {
+		for _, functions := range [][]*ourtypes.FunctionInfo{fileInfo.Functions, fileInfo.Methods} {
+			for _, fn := range functions {
+				label := functionLabel(fileInfo.PackageName, fn)
+				for _, name := range fn.ParamInterfaces {
+					u := usage(name)
+					u.AcceptedBy = append(u.AcceptedBy, label)
+				}
+				for _, name := range fn.ReturnInterfaces {
+					u := usage(name)
+					u.ReturnedBy = append(u.ReturnedBy, label)
+				}
+			}
+		}
+	}
+	for _, u := range usages {
+		sort.Strings(u.AcceptedBy)
+		sort.Strings(u.ReturnedBy)
+	}
+	return usages
+}
+
+// functionLabel names a function or method of package pkgName the way it is referred to from other packages,
+// e.g. "store.New" or "(*store.Cache).Wrap". Names qualified by the parser's naming mode are kept.
+func functionLabel(pkgName string, fn *ourtypes.FunctionInfo) string {
+	if fn.Receiver == "" {
+		if strings.Contains(fn.Name, ".") {
+			return fn.Name
+		}
+		return pkgName + "." + fn.Name
+	}
+	recv, pointer := strings.CutPrefix(fn.Receiver, "*")
+	if !strings.Contains(recv, ".") {
+		recv = pkgName + "." + recv
+	}
+	if pointer {
+		recv = "*" + recv
+	}
+	return "(" + recv + ")." + fn.Name
+}
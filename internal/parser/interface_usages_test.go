@@ -0,0 +1,78 @@
+package parser
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	ourtypes "github.com/vlad/ast2llm-go/internal/types"
+)
+
+func TestInterfaceUsages(t *testing.T) {
+	projectPath := writeTestProject(t, map[string]string{
+		"store/store.go": `package store
+
+import "io"
+
+// Store persists values.
+type Store interface {
+	Get(key string) (string, error)
+}
+
+// Memory is an in-memory Store.
+type Memory struct{}
+
+func (m *Memory) Get(key string) (string, error) { return "", nil }
+
+// New returns an empty store.
+func New() Store { return &Memory{} }
+
+// Load fills the stores from r.
+func Load(r io.Reader, stores []Store, byName map[string]*Store) error { return nil }
+
+// Wrap wraps s.
+func (m Memory) Wrap(s Store) (Store, error) { return s, nil }
+`,
+		"main.go": `package main
+
+import "example.com/testproject/store"
+
+func run(s store.Store, ch <-chan store.Store) {}
+
+func main() { run(store.New(), nil) }
+`,
+	})
+
+	info, err := New().ParseProject(projectPath)
+	require.NoError(t, err)
+
+	load := info[filepath.Join(projectPath, "store", "store.go")].Functions[1]
+	assert.Equal(t, []string{"io.Reader", "example.com/testproject/store.Store"}, load.ParamInterfaces, "duplicates are listed once")
+	assert.Empty(t, load.ReturnInterfaces, "error is left out")
+
+	usages := InterfaceUsages(info)
+	assert.Equal(t, &ourtypes.InterfaceUsage{
+		Interface:  "example.com/testproject/store.Store",
+		AcceptedBy: []string{"(store.Memory).Wrap", "main.run", "store.Load"},
+		ReturnedBy: []string{"(store.Memory).Wrap", "store.New"},
+	}, usages["example.com/testproject/store.Store"])
+	assert.Equal(t, []string{"store.Load"}, usages["io.Reader"].AcceptedBy)
+	assert.NotContains(t, usages, "error")
+}
+
+func TestFunctionLabel(t *testing.T) {
+	tests := []struct {
+		fn       *ourtypes.FunctionInfo
+		expected string
+	}{
+		{&ourtypes.FunctionInfo{Name: "New"}, "store.New"},
+		{&ourtypes.FunctionInfo{Name: "store.New"}, "store.New"},
+		{&ourtypes.FunctionInfo{Name: "Get", Receiver: "*Memory"}, "(*store.Memory).Get"},
+		{&ourtypes.FunctionInfo{Name: "Get", Receiver: "Memory"}, "(store.Memory).Get"},
+		{&ourtypes.FunctionInfo{Name: "Get", Receiver: "*example.com/p/store.Memory"}, "(*example.com/p/store.Memory).Get"},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, functionLabel("store", tt.fn))
+	}
+}
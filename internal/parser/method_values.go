@@ -0,0 +1,37 @@
+package parser
+
+import (
+	"go/ast"
+	gotypes "go/types"
+)
+
+// methodValueReceivers returns the named receiver types of the method values and method expressions file uses
+// without calling them, e.g. Server for mux.HandleFunc("/", srv.Health) or (*Server).Health, so that the type
+// declaring the method is described even when the file never names it.
+func methodValueReceivers(file *ast.File, info *gotypes.Info) []*gotypes.Named {
+	called := make(map[ast.Expr]bool)
+	var receivers []*gotypes.Named
+	ast.Inspect(file, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.CallExpr:
+			called[ast.Unparen(n.Fun)] = true
+		case *ast.SelectorExpr:
+			if called[n] {
+				return true
+			}
+			selection, ok := info.Selections[n]
+			if !ok || (selection.Kind() != gotypes.MethodVal && selection.Kind() != gotypes.MethodExpr) {
+				return true
+			}
+			recv := selection.Recv()
+			if ptr, ok := recv.(*gotypes.Pointer); ok {
+				recv = ptr.Elem()
+			}
+			if named, ok := gotypes.Unalias(recv).(*gotypes.Named); ok {
+				receivers = append(receivers, named)
+			}
+		}
+		return true
+	})
+	return receivers
+}
@@ -0,0 +1,81 @@
+package parser
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProjectParser_ParseProject_FunctionAndMethodValues(t *testing.T) {
+	projectPath := writeTestProject(t, map[string]string{
+		"handlers/handlers.go": `package handlers
+
+// Health reports that the service is up.
+func Health() string { return "ok" }
+
+// Index serves the home page.
+func Index() string { return "index" }
+
+// Users serves the users.
+type Users struct{}
+
+// NewUsers returns the users handler.
+func NewUsers() *Users { return &Users{} }
+
+// List lists the users.
+func (u *Users) List() string { return "" }
+
+// Cache caches responses.
+type Cache struct{}
+
+// NewCache returns a cache.
+func NewCache() *Cache { return &Cache{} }
+
+// Flush empties the cache.
+func (c *Cache) Flush() {}
+`,
+		"main.go": `package main
+
+import "example.com/testproject/handlers"
+
+type server struct {
+	health func() string
+}
+
+func route(path string, handler func() string) {}
+
+func main() {
+	srv := server{}
+	srv.health = handlers.Health
+	route("/", handlers.Index)
+	users := handlers.NewUsers()
+	route("/users", users.List)
+	handlers.NewCache().Flush()
+}
+`,
+	})
+
+	info, err := New().ParseProject(projectPath)
+	require.NoError(t, err)
+	mainInfo := info[filepath.Join(projectPath, "main.go")]
+	require.NotNil(t, mainInfo)
+
+	var functions []string
+	for _, fn := range mainInfo.UsedImportedFunctions {
+		functions = append(functions, fn.Name)
+	}
+	assert.ElementsMatch(t, []string{
+		"example.com/testproject/handlers.Health",
+		"example.com/testproject/handlers.Index",
+		"example.com/testproject/handlers.NewUsers",
+		"example.com/testproject/handlers.NewCache",
+	}, functions, "functions used as values are listed once, like called ones")
+
+	var structs []string
+	for _, s := range mainInfo.UsedImportedStructs {
+		structs = append(structs, s.Name)
+	}
+	assert.Equal(t, []string{"example.com/testproject/handlers.Users"}, structs, "receivers of method values are used, not those of called methods")
+}
@@ -0,0 +1,117 @@
+package parser
+
+import (
+	"bufio"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	ourtypes "github.com/vlad/ast2llm-go/internal/types"
+)
+
+// migrationFile matches the names of versioned migration files, e.g. "0001_init.up.sql" or "20240101120000_users.sql"
+var migrationFile = regexp.MustCompile(`^(?:V)?\d+[_.-].*\.sql$`)
+
+// FindMigrations returns the directories of projectPath holding SQL migrations, sorted by directory: directories
+// whose name contains "migration" and holds .sql files, and directories of versioned .sql files, e.g.
+// "0001_init.up.sql". Hidden directories, vendor, node_modules and testdata are skipped.
+func FindMigrations(projectPath string) ([]*ourtypes.MigrationSet, error) {
+	root, err := filepath.Abs(projectPath)
+	if err != nil {
+		return nil, err
+	}
+
+	var sets []*ourtypes.MigrationSet
+	err = filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() {
+			return nil
+		}
+		if name := d.Name(); path != root && (strings.HasPrefix(name, ".") || name == "vendor" || name == "node_modules" || name == "testdata") {
+			return filepath.SkipDir
+		}
+		if set := migrationSet(path); set != nil {
+			rel, err := filepath.Rel(root, path)
+			if err != nil {
+				return err
+			}
+			set.Dir = filepath.ToSlash(rel)
+			sets = append(sets, set)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(sets, func(i, j int) bool { return sets[i].Dir < sets[j].Dir })
+	return sets, nil
+}
+
+// migrationSet returns the migrations of dir, or nil if it holds none.
+func migrationSet(dir string) *ourtypes.MigrationSet {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil
+	}
+	named := strings.Contains(strings.ToLower(filepath.Base(dir)), "migration")
+	set := &ourtypes.MigrationSet{Files: make([]string, 0)}
+	versioned, upDown, atlas := 0, false, false
+	for _, entry := range entries {
+		name := entry.Name()
+		if name == "atlas.sum" {
+			atlas = true
+		}
+		if entry.IsDir() || !strings.HasSuffix(name, ".sql") {
+			continue
+		}
+		if migrationFile.MatchString(name) {
+			versioned++
+		} else if !named {
+			continue
+		}
+		upDown = upDown || strings.HasSuffix(name, ".up.sql") || strings.HasSuffix(name, ".down.sql")
+		set.Files = append(set.Files, name)
+	}
+	if len(set.Files) == 0 || (!named && versioned == 0) {
+		return nil
+	}
+	sort.Strings(set.Files)
+
+	switch {
+	case atlas:
+		set.Tool = "atlas"
+	case upDown:
+		set.Tool = "golang-migrate"
+	default:
+		set.Tool = migrationTool(filepath.Join(dir, set.Files[0]))
+	}
+	return set
+}
+
+// migrationTool recognizes the tool of a migration file by its annotations, e.g. "-- +goose Up", or returns "".
+func migrationTool(path string) string {
+	f, err := os.Open(path)
+	if err != nil {
+		return ""
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		switch {
+		case strings.HasPrefix(line, "-- +goose"):
+			return "goose"
+		case strings.HasPrefix(line, "-- +migrate"):
+			return "sql-migrate"
+		case line != "" && !strings.HasPrefix(line, "--"):
+			return ""
+		}
+	}
+	return ""
+}
@@ -0,0 +1,38 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vlad/ast2llm-go/internal/testutil"
+	ourtypes "github.com/vlad/ast2llm-go/internal/types"
+)
+
+func TestFindMigrations(t *testing.T) {
+	root := t.TempDir()
+	files := map[string]string{
+		"db/migrations/000002_orders.up.sql":    "CREATE TABLE orders (id int);",
+		"db/migrations/000002_orders.down.sql":  "DROP TABLE orders;",
+		"db/migrations/000001_init.up.sql":      "CREATE TABLE users (id int);",
+		"db/migrations/README.md":               "How to migrate",
+		"sql/20240101120000_users.sql":          "-- +goose Up\nCREATE TABLE users (id int);\n",
+		"internal/store/migrations/schema.sql":  "-- +migrate Up\nCREATE TABLE items (id int);\n",
+		"queries/users.sql":                     "SELECT * FROM users;",
+		"vendor/example.com/m/0001_init.up.sql": "CREATE TABLE vendored (id int);",
+		".git/migrations/0001_init.up.sql":      "",
+	}
+	testutil.WriteFiles(t, root, files)
+
+	sets, err := FindMigrations(root)
+	require.NoError(t, err)
+	assert.Equal(t, []*ourtypes.MigrationSet{
+		{Dir: "db/migrations", Tool: "golang-migrate", Files: []string{"000001_init.up.sql", "000002_orders.down.sql", "000002_orders.up.sql"}},
+		{Dir: "internal/store/migrations", Tool: "sql-migrate", Files: []string{"schema.sql"}},
+		{Dir: "sql", Tool: "goose", Files: []string{"20240101120000_users.sql"}},
+	}, sets)
+
+	sets, err = FindMigrations(t.TempDir())
+	require.NoError(t, err)
+	assert.Empty(t, sets)
+}
@@ -0,0 +1,280 @@
+package parser
+
+import (
+	"fmt"
+	gotypes "go/types"
+	"strings"
+
+	ourtypes "github.com/vlad/ast2llm-go/internal/types"
+	"golang.org/x/tools/go/packages"
+)
+
+// NamingMode selects how names of declarations and types are qualified in the extracted information
+type NamingMode int
+
+const (
+	NamingDefault          NamingMode = iota // Types fully qualified, the file's own functions and variables unqualified
+	NamingFullyQualified                     // Everything qualified by its package path, e.g. example.com/project/models.User
+	NamingPackageQualified                   // Everything qualified by its package name, e.g. models.User
+	NamingShort                              // Nothing qualified, e.g. User
+)
+
+// namingModeNames holds the name of every NamingMode accepted by ParseNamingMode, indexed by its value
+var namingModeNames = []string{"default", "full", "package", "short"}
+
+// NamingModeNames lists the names accepted by ParseNamingMode
+func NamingModeNames() []string {
+	return append([]string(nil), namingModeNames...)
+}
+
+// ParseNamingMode converts a naming mode name (default, full, package or short) to a NamingMode
+func ParseNamingMode(name string) (NamingMode, error) {
+	for m, n := range namingModeNames {
+		if n == name {
+			return NamingMode(m), nil
+		}
+	}
+	return 0, fmt.Errorf("unknown naming mode: %s", name)
+}
+
+// String returns the name of the naming mode
+func (m NamingMode) String() string {
+	if m >= 0 && int(m) < len(namingModeNames) {
+		return namingModeNames[m]
+	}
+	return fmt.Sprintf("NamingMode(%d)", int(m))
+}
+
+// WithNaming qualifies every extracted name, type and signature the same way, see NamingMode. Short names are
+// the most compact but can be ambiguous: types of different packages with the same name cannot be told apart
+// but by their IDs, which are the same in every mode
+func WithNaming(mode NamingMode) Option {
+	return func(p *ProjectParser) {
+		p.naming = mode
+	}
+}
+
+// namer rewrites the names of a package's declarations and the types they refer to according to a NamingMode
+type namer struct {
+	mode    NamingMode
+	pkgPath string            // Path of the package whose own declarations are unqualified by default
+	pkgName string            // Name of that package
+	names   map[string]string // Key: path of pkg or one of the packages it depends on; value: package name
+}
+
+// newNamer returns the namer for the declarations of pkg, or nil if names are left as extracted.
+func (p *ProjectParser) newNamer(pkg *packages.Package) *namer {
+	if p.naming == NamingDefault || pkg == nil || pkg.Types == nil {
+		return nil
+	}
+	n := &namer{mode: p.naming, pkgPath: pkg.Types.Path(), pkgName: pkg.Types.Name(), names: make(map[string]string)}
+	var visit func(pkg *gotypes.Package)
+	visit = func(pkg *gotypes.Package) {
+		if _, ok := n.names[pkg.Path()]; ok {
+			return
+		}
+		n.names[pkg.Path()] = pkg.Name()
+		for _, imp := range pkg.Imports() {
+			visit(imp)
+		}
+	}
+	visit(pkg.Types)
+	return n
+}
+
+// typeString rewrites every qualified name in s, a name or a type such as "map[string]*example.com/x/models.User".
+// Only the paths of known packages are rewritten, so file paths and values are left alone.
+func (n *namer) typeString(s string) string {
+	if n == nil || n.mode == NamingFullyQualified {
+		return s
+	}
+	var b strings.Builder
+	last := 0
+	for start := 0; start < len(s); {
+		if !isPathByte(s[start]) {
+			start++
+			continue
+		}
+		end := start
+		for end < len(s) && isPathByte(s[end]) {
+			end++
+		}
+		if dot := strings.LastIndex(s[start:end], "."); dot > 0 {
+			if name, ok := n.names[s[start:start+dot]]; ok {
+				b.WriteString(s[last:start])
+				if n.mode == NamingPackageQualified {
+					b.WriteString(name + ".")
+				}
+				last = start + dot + 1
+			}
+		}
+		start = end
+	}
+	b.WriteString(s[last:])
+	return b.String()
+}
+
+// typeStrings rewrites every name of list into a new slice.
+func (n *namer) typeStrings(list []string) []string {
+	if list == nil {
+		return nil
+	}
+	result := make([]string, 0, len(list))
+	for _, s := range list {
+		result = append(result, n.typeString(s))
+	}
+	return result
+}
+
+// local qualifies the unqualified name of a declaration of the namer's package, e.g. a function or a receiver
+// type such as "*Server".
+func (n *namer) local(name string) string {
+	if n == nil || n.mode == NamingShort || name == "" {
+		return name
+	}
+	prefix := n.pkgName
+	if n.mode == NamingFullyQualified {
+		prefix = n.pkgPath
+	}
+	if strings.HasPrefix(name, "*") {
+		return "*" + prefix + "." + name[1:]
+	}
+	return prefix + "." + name
+}
+
+// isPathByte reports whether c can be part of a package path or a qualified name.
+func isPathByte(c byte) bool {
+	return c == '_' || c == '.' || c == '-' || c == '~' || c == '/' || c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
+
+// fileInfo returns a copy of info with every name rewritten. Items are copied as well, since some of them,
+// such as used functions, are shared between files.
+func (n *namer) fileInfo(info *ourtypes.FileInfo) *ourtypes.FileInfo {
+	if n == nil {
+		return info
+	}
+	c := *info
+	c.Functions = renameAll(info.Functions, func(fn *ourtypes.FunctionInfo) *ourtypes.FunctionInfo { return n.function(fn, true) })
+	c.Methods = renameAll(info.Methods, func(fn *ourtypes.FunctionInfo) *ourtypes.FunctionInfo { return n.function(fn, true) })
+	c.Structs = renameAll(info.Structs, n.structInfo)
+	c.Interfaces = renameAll(info.Interfaces, n.interfaceInfo)
+	c.GlobalVars = renameAll(info.GlobalVars, func(gv *ourtypes.GlobalVarInfo) *ourtypes.GlobalVarInfo { return n.globalVar(gv, true) })
+	c.ConstGroups = renameAll(info.ConstGroups, n.constGroup)
+	c.InlineTypes = renameAll(info.InlineTypes, n.inlineType)
+	c.ErrorValues = renameAll(info.ErrorValues, n.errorInfo)
+	c.UsedImportedStructs = renameAll(info.UsedImportedStructs, n.structInfo)
+	c.UsedImportedInterfaces = renameAll(info.UsedImportedInterfaces, n.interfaceInfo)
+	c.UsedImportedFunctions = renameAll(info.UsedImportedFunctions, func(fn *ourtypes.FunctionInfo) *ourtypes.FunctionInfo { return n.function(fn, false) })
+	c.UsedImportedGlobalVars = renameAll(info.UsedImportedGlobalVars, func(gv *ourtypes.GlobalVarInfo) *ourtypes.GlobalVarInfo { return n.globalVar(gv, false) })
+	c.ExternalStructs = renameAll(info.ExternalStructs, n.structInfo)
+	c.ExternalInterfaces = renameAll(info.ExternalInterfaces, n.interfaceInfo)
+	return &c
+}
+
+// renameAll returns the renamed copies of items, keeping nil slices nil.
+func renameAll[T any](items []T, rename func(T) T) []T {
+	if items == nil {
+		return nil
+	}
+	result := make([]T, 0, len(items))
+	for _, item := range items {
+		result = append(result, rename(item))
+	}
+	return result
+}
+
+// function renames a function or method. Names of declarations of the namer's package are unqualified if local
+// is set and qualified otherwise; method names are never qualified, their receiver is.
+func (n *namer) function(fn *ourtypes.FunctionInfo, local bool) *ourtypes.FunctionInfo {
+	c := *fn
+	switch {
+	case fn.Receiver != "":
+		c.Receiver = n.local(fn.Receiver)
+	case local:
+		c.Name = n.local(fn.Name)
+	default:
+		c.Name = n.typeString(fn.Name)
+	}
+	c.TypeParams = n.typeStrings(fn.TypeParams)
+	c.Params = n.typeStrings(fn.Params)
+	c.Returns = n.typeStrings(fn.Returns)
+	c.ParamInterfaces = n.typeStrings(fn.ParamInterfaces)
+	c.ReturnInterfaces = n.typeStrings(fn.ReturnInterfaces)
+	return &c
+}
+
+// structInfo renames a struct along with its fields and methods.
+func (n *namer) structInfo(s *ourtypes.StructInfo) *ourtypes.StructInfo {
+	c := *s
+	c.Name = n.typeString(s.Name)
+	c.TypeParams = n.typeStrings(s.TypeParams)
+	c.Fields = renameAll(s.Fields, n.structField)
+	c.Methods = renameAll(s.Methods, func(m *ourtypes.StructMethod) *ourtypes.StructMethod {
+		cm := *m
+		cm.Parameters = n.typeStrings(m.Parameters)
+		cm.ReturnTypes = n.typeStrings(m.ReturnTypes)
+		cm.PromotedFrom = n.typeString(m.PromotedFrom)
+		return &cm
+	})
+	return &c
+}
+
+// structField renames the type of a field and the struct it refers to.
+func (n *namer) structField(f *ourtypes.StructField) *ourtypes.StructField {
+	c := *f
+	c.Type = n.typeString(f.Type)
+	c.TypeRef = n.typeString(f.TypeRef)
+	c.PromotedFrom = n.typeString(f.PromotedFrom)
+	return &c
+}
+
+// interfaceInfo renames an interface along with its methods and embedded interfaces.
+func (n *namer) interfaceInfo(i *ourtypes.InterfaceInfo) *ourtypes.InterfaceInfo {
+	c := *i
+	c.Name = n.typeString(i.Name)
+	c.TypeParams = n.typeStrings(i.TypeParams)
+	c.Embeddeds = n.typeStrings(i.Embeddeds)
+	c.Methods = renameAll(i.Methods, func(m *ourtypes.InterfaceMethod) *ourtypes.InterfaceMethod {
+		cm := *m
+		cm.Parameters = n.typeStrings(m.Parameters)
+		cm.ReturnTypes = n.typeStrings(m.ReturnTypes)
+		return &cm
+	})
+	return &c
+}
+
+// globalVar renames a variable or constant like function renames functions. Values are left as written.
+func (n *namer) globalVar(gv *ourtypes.GlobalVarInfo, local bool) *ourtypes.GlobalVarInfo {
+	c := *gv
+	if local {
+		c.Name = n.local(gv.Name)
+	} else {
+		c.Name = n.typeString(gv.Name)
+	}
+	c.Type = n.typeString(gv.Type)
+	return &c
+}
+
+// constGroup renames the type of a const group and its constants.
+func (n *namer) constGroup(g *ourtypes.ConstGroup) *ourtypes.ConstGroup {
+	c := *g
+	c.Type = n.typeString(g.Type)
+	c.Constants = renameAll(g.Constants, func(gv *ourtypes.GlobalVarInfo) *ourtypes.GlobalVarInfo { return n.globalVar(gv, true) })
+	return &c
+}
+
+// inlineType renames the field types or the signature of an inline type.
+func (n *namer) inlineType(t *ourtypes.InlineType) *ourtypes.InlineType {
+	c := *t
+	c.Fields = renameAll(t.Fields, n.structField)
+	c.Signature = n.typeString(t.Signature)
+	return &c
+}
+
+// errorInfo renames an error value or error type along with its type.
+func (n *namer) errorInfo(e *ourtypes.ErrorInfo) *ourtypes.ErrorInfo {
+	c := *e
+	c.Name = n.typeString(e.Name)
+	c.Type = n.typeString(e.Type)
+	return &c
+}
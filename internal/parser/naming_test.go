@@ -0,0 +1,122 @@
+package parser
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProjectParser_ParseProject_Naming(t *testing.T) {
+	projectPath := writeTestProject(t, map[string]string{
+		"models/v2/user.go": `package models
+
+type User struct {
+	Name string
+}
+
+func NewUser(name string) *User { return &User{Name: name} }
+`,
+		"main.go": `package main
+
+import (
+	"io"
+
+	"example.com/testproject/models/v2"
+)
+
+type Server struct {
+	Owner *models.User
+	Input io.Reader
+}
+
+func (s *Server) Serve(r io.Reader) error { return nil }
+
+var Default = models.NewUser("admin")
+
+func Run(s *Server) {}
+
+func main() {}
+`,
+	})
+	mainPath := filepath.Join(projectPath, "main.go")
+
+	type names struct {
+		Struct, Owner, Input, Receiver, Params, Func, Var, VarType, UsedStruct, UsedFunc string
+	}
+	collect := func(mode NamingMode) names {
+		fileInfos, err := New(WithNaming(mode)).ParseProject(projectPath)
+		require.NoError(t, err)
+		info := fileInfos[mainPath]
+		require.Len(t, info.Structs, 1)
+		require.Len(t, info.Methods, 1)
+		require.Len(t, info.Functions, 2)
+		require.Len(t, info.GlobalVars, 1)
+		require.Len(t, info.UsedImportedStructs, 1)
+		require.Len(t, info.UsedImportedFunctions, 1)
+		return names{
+			Struct:     info.Structs[0].Name,
+			Owner:      info.Structs[0].Fields[0].Type,
+			Input:      info.Structs[0].Fields[1].Type,
+			Receiver:   info.Methods[0].Receiver,
+			Params:     info.Methods[0].Params[0],
+			Func:       info.Functions[0].Name,
+			Var:        info.GlobalVars[0].Name,
+			VarType:    info.GlobalVars[0].Type,
+			UsedStruct: info.UsedImportedStructs[0].Name,
+			UsedFunc:   info.UsedImportedFunctions[0].Name,
+		}
+	}
+
+	assert.Equal(t, names{
+		Struct: "example.com/testproject.Server", Owner: "*example.com/testproject/models/v2.User", Input: "io.Reader",
+		Receiver: "*Server", Params: "r io.Reader", Func: "Run", Var: "Default",
+		VarType: "*example.com/testproject/models/v2.User", UsedStruct: "example.com/testproject/models/v2.User",
+		UsedFunc: "example.com/testproject/models/v2.NewUser",
+	}, collect(NamingDefault), "default leaves the file's own functions and variables unqualified")
+	assert.Equal(t, names{
+		Struct: "example.com/testproject.Server", Owner: "*example.com/testproject/models/v2.User", Input: "io.Reader",
+		Receiver: "*example.com/testproject.Server", Params: "r io.Reader", Func: "example.com/testproject.Run",
+		Var: "example.com/testproject.Default", VarType: "*example.com/testproject/models/v2.User",
+		UsedStruct: "example.com/testproject/models/v2.User", UsedFunc: "example.com/testproject/models/v2.NewUser",
+	}, collect(NamingFullyQualified))
+	assert.Equal(t, names{
+		Struct: "main.Server", Owner: "*models.User", Input: "io.Reader", Receiver: "*main.Server",
+		Params: "r io.Reader", Func: "main.Run", Var: "main.Default", VarType: "*models.User",
+		UsedStruct: "models.User", UsedFunc: "models.NewUser",
+	}, collect(NamingPackageQualified), "package names are used, not the last path element")
+	assert.Equal(t, names{
+		Struct: "Server", Owner: "*User", Input: "Reader", Receiver: "*Server", Params: "r Reader",
+		Func: "Run", Var: "Default", VarType: "*User", UsedStruct: "User", UsedFunc: "NewUser",
+	}, collect(NamingShort))
+}
+
+func TestProjectParser_ExtractSymbolContext_Naming(t *testing.T) {
+	projectPath := writeTestProject(t, map[string]string{
+		"models/user.go": `package models
+
+type User struct {
+	Name string
+}
+`,
+		"main.go": `package main
+
+import "example.com/testproject/models"
+
+func Greet(u *models.User) string { return u.Name }
+
+func main() {}
+`,
+	})
+
+	info, err := New(WithNaming(NamingPackageQualified)).ExtractSymbolContext(projectPath, "models.User")
+	require.NoError(t, err)
+	mainInfo := info[filepath.Join(projectPath, "main.go")]
+	require.NotNil(t, mainInfo)
+	require.Len(t, mainInfo.Functions, 1)
+	assert.Equal(t, "main.Greet", mainInfo.Functions[0].Name)
+	assert.Equal(t, []string{"u *models.User"}, mainInfo.Functions[0].Params)
+	require.Len(t, info[filepath.Join(projectPath, "models", "user.go")].Structs, 1)
+	assert.Equal(t, "models.User", info[filepath.Join(projectPath, "models", "user.go")].Structs[0].Name)
+}
@@ -0,0 +1,232 @@
+package parser
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/build"
+	"go/importer"
+	goparser "go/parser"
+	"go/scanner"
+	gotypes "go/types"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// noModuleNote is reported for every file parsed without a module
+const noModuleNote = "no go.mod found: parsed without a module, items from other packages of the project are not resolved"
+
+// inModule reports whether dir or one of its parents holds a go.mod or go.work file, i.e. whether the
+// go command can load the packages of dir.
+func inModule(dir string) bool {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return false
+	}
+	for {
+		for _, name := range []string{"go.mod", "go.work"} {
+			if _, err := os.Stat(filepath.Join(dir, name)); err == nil {
+				return true
+			}
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return false
+		}
+		dir = parent
+	}
+}
+
+// loadWithoutModule is the fallback of loadPackages for a plain directory of Go files, which the go command
+// refuses to load. Every directory below projectPath is parsed with go/parser and type-checked on its own:
+// standard library imports are resolved, other imports are not, so used items of other project packages stay
+// unknown. The resulting packages have every file carry a load diagnostic saying so. Directories the go
+// command ignores, such as hidden, vendor and testdata directories, are skipped. The files of overlay, keyed by
+// absolute path, are read from their given contents instead of the disk.
+func (p *ProjectParser) loadWithoutModule(projectPath string, overlay map[string][]byte) ([]*packages.Package, error) {
+	root, err := filepath.Abs(projectPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve project path: %w", err)
+	}
+	p.warn(Warning{Severity: SeverityWarning, Kind: WarningLoad, Pos: root, Message: "no go.mod found, parsing its files without a module"})
+
+	imp := importer.ForCompiler(p.fset, "gc", nil)
+	var pkgs []*packages.Package
+	err = filepath.WalkDir(root, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() {
+			return nil
+		}
+		name := d.Name()
+		if path != root && (strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_") || name == "vendor" || name == "testdata") {
+			return filepath.SkipDir
+		}
+		dirPkgs, err := p.loadDirWithoutModule(root, path, imp, overlay)
+		if err != nil {
+			return err
+		}
+		pkgs = append(pkgs, dirPkgs...)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to load packages: %w", err)
+	}
+	if len(pkgs) == 0 {
+		return nil, fmt.Errorf("no packages found in %s", projectPath)
+	}
+	return pkgs, nil
+}
+
+// loadDirWithoutModule parses and type-checks the Go files of dir matching the build context, one package
+// per package clause. Packages are named by their directory, e.g. "project/sub" for root/sub. Files of overlay
+// in dir are added to those on disk.
+func (p *ProjectParser) loadDirWithoutModule(root, dir string, imp gotypes.Importer, overlay map[string][]byte) ([]*packages.Package, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			names = append(names, entry.Name())
+		}
+	}
+	for path := range overlay {
+		if filepath.Dir(path) == dir {
+			if _, err := os.Stat(path); err != nil {
+				names = append(names, filepath.Base(path))
+			}
+		}
+	}
+	sort.Strings(names)
+
+	rel, err := filepath.Rel(filepath.Dir(root), dir)
+	if err != nil {
+		return nil, err
+	}
+	pkgPath := filepath.ToSlash(rel)
+
+	ctxt := p.buildContext()
+	ctxt.OpenFile = func(path string) (io.ReadCloser, error) {
+		if content, ok := overlay[path]; ok {
+			return io.NopCloser(bytes.NewReader(content)), nil
+		}
+		return os.Open(path)
+	}
+	byName := make(map[string]*packages.Package)
+	var pkgNames []string
+	for _, name := range names {
+		if filepath.Ext(name) != ".go" || (!p.includeTests && strings.HasSuffix(name, "_test.go")) {
+			continue
+		}
+		if match, err := ctxt.MatchFile(dir, name); err != nil || !match {
+			continue
+		}
+
+		path := filepath.Join(dir, name)
+		src, ok := overlay[path]
+		if !ok {
+			if src, err = os.ReadFile(path); err != nil {
+				continue
+			}
+		}
+		p.recordContent(path, src)
+		file, err := goparser.ParseFile(p.fset, path, src, goparser.ParseComments)
+		if file == nil {
+			continue
+		}
+
+		pkg, ok := byName[file.Name.Name]
+		if !ok {
+			pkg = &packages.Package{
+				ID:      pkgPath,
+				Name:    file.Name.Name,
+				PkgPath: pkgPath,
+				Fset:    p.fset,
+				Imports: make(map[string]*packages.Package),
+			}
+			if strings.HasSuffix(pkg.Name, "_test") {
+				pkg.ID += "_test"
+				pkg.PkgPath += "_test"
+			}
+			byName[pkg.Name] = pkg
+			pkgNames = append(pkgNames, pkg.Name)
+		}
+		pkg.GoFiles = append(pkg.GoFiles, path)
+		pkg.CompiledGoFiles = append(pkg.CompiledGoFiles, path)
+		pkg.Syntax = append(pkg.Syntax, file)
+		pkg.Errors = append(pkg.Errors, parseErrors(err)...)
+		pkg.Errors = append(pkg.Errors, packages.Error{Pos: path + ":1:1", Msg: noModuleNote, Kind: packages.UnknownError})
+	}
+
+	sort.Strings(pkgNames)
+	pkgs := make([]*packages.Package, 0, len(pkgNames))
+	for _, name := range pkgNames {
+		pkg := byName[name]
+		checkWithoutModule(pkg, imp)
+		pkgs = append(pkgs, pkg)
+	}
+	return pkgs, nil
+}
+
+// buildContext returns the build context selecting the files of packages loaded without a module
+func (p *ProjectParser) buildContext() *build.Context {
+	ctxt := build.Default
+	ctxt.BuildTags = append(append([]string(nil), ctxt.BuildTags...), p.buildTags...)
+	return &ctxt
+}
+
+// checkWithoutModule type-checks the files of pkg, recording type errors, such as unresolved imports,
+// instead of giving up on them.
+func checkWithoutModule(pkg *packages.Package, imp gotypes.Importer) {
+	pkg.TypesInfo = &gotypes.Info{
+		Types:      make(map[ast.Expr]gotypes.TypeAndValue),
+		Defs:       make(map[*ast.Ident]gotypes.Object),
+		Uses:       make(map[*ast.Ident]gotypes.Object),
+		Implicits:  make(map[ast.Node]gotypes.Object),
+		Instances:  make(map[*ast.Ident]gotypes.Instance),
+		Scopes:     make(map[ast.Node]*gotypes.Scope),
+		Selections: make(map[*ast.SelectorExpr]*gotypes.Selection),
+	}
+	conf := &gotypes.Config{
+		Importer: imp,
+		Error: func(err error) {
+			if typeErr, ok := err.(gotypes.Error); ok {
+				pkg.Errors = append(pkg.Errors, packages.Error{
+					Pos:  typeErr.Fset.Position(typeErr.Pos).String(),
+					Msg:  typeErr.Msg,
+					Kind: packages.TypeError,
+				})
+			}
+		},
+	}
+	pkg.Types, _ = conf.Check(pkg.PkgPath, pkg.Fset, pkg.Syntax, pkg.TypesInfo)
+	for _, imported := range pkg.Types.Imports() {
+		pkg.Imports[imported.Path()] = &packages.Package{
+			ID:      imported.Path(),
+			Name:    imported.Name(),
+			PkgPath: imported.Path(),
+			Types:   imported,
+		}
+	}
+}
+
+// parseErrors converts the error returned by go/parser to package errors.
+func parseErrors(err error) []packages.Error {
+	list, ok := err.(scanner.ErrorList)
+	if !ok {
+		return nil
+	}
+	errs := make([]packages.Error, 0, len(list))
+	for _, e := range list {
+		errs = append(errs, packages.Error{Pos: e.Pos.String(), Msg: e.Msg, Kind: packages.ParseError})
+	}
+	return errs
+}
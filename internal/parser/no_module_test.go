@@ -0,0 +1,108 @@
+package parser
+
+import (
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vlad/ast2llm-go/internal/testutil"
+	ourtypes "github.com/vlad/ast2llm-go/internal/types"
+)
+
+func TestProjectParser_ParseProject_WithoutModule(t *testing.T) {
+	root := filepath.Join(t.TempDir(), "scripts")
+	files := map[string]string{
+		"main.go": `package main
+
+import (
+	"fmt"
+
+	"scripts/greet"
+)
+
+// Greeter says hello.
+type Greeter struct {
+	Name string
+}
+
+// Greet prints the greeting.
+func (g *Greeter) Greet() { fmt.Println(greet.Hello(g.Name)) }
+
+func main() {}
+`,
+		"main_test.go":      "package main\n",
+		"greet/greet.go":    "package greet\n\n// Hello builds a greeting.\nfunc Hello(name string) string { return \"hello \" + name }\n",
+		"testdata/skip.go":  "package skip\n",
+		".hidden/hidden.go": "package hidden\n",
+	}
+	testutil.WriteFiles(t, root, files)
+	require.False(t, inModule(root), "temporary directory is inside a module")
+
+	info, err := New().ParseProject(root)
+	require.NoError(t, err)
+
+	mainFile := filepath.Join(root, "main.go")
+	greetFile := filepath.Join(root, "greet", "greet.go")
+	assert.ElementsMatch(t, []string{mainFile, greetFile}, slices.Collect(maps.Keys(info)))
+
+	fileInfo := info[mainFile]
+	require.Len(t, fileInfo.Structs, 1)
+	assert.Equal(t, "scripts.Greeter", fileInfo.Structs[0].Name)
+	assert.Equal(t, "Greeter says hello.", fileInfo.Structs[0].Comment)
+	require.Len(t, fileInfo.Methods, 1)
+	assert.Equal(t, "*Greeter", fileInfo.Methods[0].Receiver)
+	assert.Empty(t, fileInfo.UsedImportedFunctions, "other project packages are not resolved")
+
+	require.NotEmpty(t, fileInfo.Diagnostics)
+	assert.Equal(t, &ourtypes.Diagnostic{Kind: "load", Message: noModuleNote, Pos: &ourtypes.Position{File: mainFile, Line: 1, Column: 1}}, fileInfo.Diagnostics[0])
+	var typeErrors []string
+	for _, d := range fileInfo.Diagnostics {
+		if d.Kind == "type" {
+			typeErrors = append(typeErrors, d.Message)
+		}
+	}
+	require.NotEmpty(t, typeErrors)
+	assert.Contains(t, typeErrors[0], `"scripts/greet"`)
+
+	require.Len(t, info[greetFile].Functions, 1)
+	assert.Equal(t, "Hello", info[greetFile].Functions[0].Name)
+}
+
+func TestProjectParser_ParseProject_WithoutModule_SyntaxError(t *testing.T) {
+	root := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(root, "broken.go"), []byte("package broken\n\nfunc Broken( {\n"), 0644))
+
+	info, err := New().ParseProject(root)
+	require.NoError(t, err)
+	require.Contains(t, info, filepath.Join(root, "broken.go"))
+	var kinds []string
+	for _, d := range info[filepath.Join(root, "broken.go")].Diagnostics {
+		kinds = append(kinds, d.Kind)
+	}
+	assert.Contains(t, kinds, "syntax")
+}
+
+func TestProjectParser_ParseProject_WithoutModule_UnresolvedTypes(t *testing.T) {
+	root := filepath.Join(t.TempDir(), "tool")
+	require.NoError(t, os.MkdirAll(root, 0755))
+	mainFile := filepath.Join(root, "main.go")
+	require.NoError(t, os.WriteFile(mainFile, []byte(`package main
+
+import "example.com/lib"
+
+func Connect(c lib.Client, opts ...lib.Option) *lib.Conn { return nil }
+
+func main() {}
+`), 0644))
+
+	info, err := New().ParseProject(root)
+	require.NoError(t, err)
+	require.Len(t, info[mainFile].Functions, 2)
+	connect := info[mainFile].Functions[0]
+	assert.Equal(t, []string{"c lib.Client", "opts ...lib.Option"}, connect.Params, "unresolved types are rendered from the source")
+	assert.Equal(t, []string{"*lib.Conn"}, connect.Returns)
+}
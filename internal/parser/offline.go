@@ -0,0 +1,73 @@
+package parser
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// offlineEnv is the environment of the go command in offline mode: modules missing from the module cache are
+// not downloaded and the go directive of the module does not trigger a toolchain download
+var offlineEnv = []string{"GOPROXY=off", "GOTOOLCHAIN=local"}
+
+// WithGoFlags adds flags to the go command loading the packages, e.g. "-mod=readonly". They come after the
+// flags set by other options, such as -tags, and win over the GOFLAGS environment variable.
+func WithGoFlags(flags ...string) Option {
+	return func(p *ProjectParser) {
+		p.goFlags = append(p.goFlags, flags...)
+	}
+}
+
+// WithGoEnv adds KEY=value variables to the environment of the go command loading the packages, e.g.
+// "GOPROXY=off" or "GOFLAGS=-mod=readonly", overriding those of the process.
+func WithGoEnv(vars ...string) Option {
+	return func(p *ProjectParser) {
+		p.goEnv = append(p.goEnv, vars...)
+	}
+}
+
+// WithOffline keeps the go command loading the packages from touching the network, for editor sessions where
+// a parse must not stall on a module proxy: modules are only read from the module cache or the vendor
+// directory (GOPROXY=off), go.mod and go.sum are left unchanged (-mod=readonly) and no toolchain is
+// downloaded (GOTOOLCHAIN=local). A project importing packages of modules that are not available fails to
+// parse with an error listing them, instead of being described with unresolved types.
+func WithOffline() Option {
+	return func(p *ProjectParser) {
+		p.offline = true
+	}
+}
+
+// missingImports returns the import paths the go command could not load, each with its error, for the
+// packages reachable from pkgs. Packages of missing modules have errors but no name.
+func missingImports(pkgs []*packages.Package) map[string]string {
+	missing := make(map[string]string)
+	packages.Visit(pkgs, nil, func(pkg *packages.Package) {
+		if pkg.Name != "" || len(pkg.Errors) == 0 {
+			return
+		}
+		msg := pkg.Errors[0].Msg
+		// The go command prefixes the message with the position of the import, which only names one of the
+		// importing files
+		if pos, rest, ok := strings.Cut(msg, ": "); ok && strings.Contains(pos, ".go:") {
+			msg = rest
+		}
+		missing[pkg.ID] = strings.SplitN(msg, "\n", 2)[0]
+	})
+	return missing
+}
+
+// missingImportsError describes the imports missing in offline mode, sorted by path.
+func missingImportsError(missing map[string]string) error {
+	paths := make([]string, 0, len(missing))
+	for path := range missing {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	var b strings.Builder
+	for _, path := range paths {
+		fmt.Fprintf(&b, "\n\t%s: %s", path, missing[path])
+	}
+	return fmt.Errorf("offline: %d imported packages could not be loaded from the module cache, run go mod download to fetch them:%s", len(paths), b.String())
+}
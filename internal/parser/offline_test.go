@@ -0,0 +1,67 @@
+package parser
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vlad/ast2llm-go/internal/testutil"
+)
+
+func TestProjectParser_ParseProject_Offline(t *testing.T) {
+	// Keeps the parse without WithOffline from reaching the network
+	t.Setenv("GOPROXY", "off")
+	t.Setenv("GOFLAGS", "")
+	projectPath := testutil.WriteModule(t, "example.com/testproject", map[string]string{
+		"go.mod":  "module example.com/testproject\n\ngo 1.21\n\nrequire example.com/missing v1.0.0\n",
+		"go.sum":  "example.com/missing v1.0.0 h1:AAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA=\nexample.com/missing v1.0.0/go.mod h1:AAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA=\n",
+		"main.go": "package main\n\nimport (\n\t\"fmt\"\n\n\t\"example.com/missing\"\n)\n\nfunc main() { fmt.Println(missing.Value) }\n",
+	})
+
+	_, err := New(WithOffline()).ParseProject(projectPath)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "1 imported packages could not be loaded")
+	assert.Contains(t, err.Error(), "example.com/missing: ")
+	assert.NotContains(t, err.Error(), "main.go", "the position of the import is left out")
+
+	info, err := New().ParseProject(projectPath)
+	require.NoError(t, err, "without WithOffline, missing imports only leave types unresolved")
+	assert.Contains(t, info, filepath.Join(projectPath, "main.go"))
+
+	t.Run("vendored", func(t *testing.T) {
+		info, err := New(WithOffline()).ParseProject(writeVendoredProject(t))
+		require.NoError(t, err)
+		assert.Len(t, info, 1)
+	})
+}
+
+func TestProjectParser_ParseProject_GoFlagsAndEnv(t *testing.T) {
+	projectPath := writeTestProject(t, map[string]string{
+		"main.go":  "package main\n\nfunc main() {}\n",
+		"extra.go": "//go:build extra\n\npackage main\n\n// Extra is only built with the extra tag.\nfunc Extra() {}\n",
+	})
+	extra := filepath.Join(projectPath, "extra.go")
+
+	for name, p := range map[string]*ProjectParser{
+		"flags": New(WithGoFlags("-tags=extra")),
+		"env":   New(WithGoEnv("GOFLAGS=-tags=extra")),
+	} {
+		t.Run(name, func(t *testing.T) {
+			info, err := p.ParseProject(projectPath)
+			require.NoError(t, err)
+			assert.Contains(t, info, extra)
+		})
+	}
+
+	info, err := New().ParseProject(projectPath)
+	require.NoError(t, err)
+	assert.NotContains(t, info, extra)
+}
+
+func TestProjectParser_OptionsFingerprint_GoFlagsAndEnv(t *testing.T) {
+	base := New().optionsFingerprint()
+	assert.NotEqual(t, base, New(WithGoFlags("-tags=extra")).optionsFingerprint())
+	assert.NotEqual(t, base, New(WithGoEnv("GOFLAGS=-tags=extra")).optionsFingerprint())
+	assert.NotEqual(t, base, New(WithOffline()).optionsFingerprint())
+}
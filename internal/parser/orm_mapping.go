@@ -0,0 +1,201 @@
+package parser
+
+import (
+	"go/ast"
+	"go/token"
+	gotypes "go/types"
+	"reflect"
+	"strings"
+	"unicode"
+
+	ourtypes "github.com/vlad/ast2llm-go/internal/types"
+	"golang.org/x/tools/go/packages"
+)
+
+// Base models embedded by ORM structs
+const (
+	gormModel    = "gorm.io/gorm.Model"
+	bunBaseModel = "github.com/uptrace/bun.BaseModel"
+)
+
+// mapORMTable sets the ORM, table and columns of a struct mapped by gorm, bun or sqlx, recognized by the
+// gorm, bun or db tags of its fields or by an embedded gorm.Model or bun.BaseModel. Column names come from the
+// tags or, for untagged fields, from the naming convention of the ORM: snake_case for gorm and bun, lower case
+// for sqlx. The table is taken from a TableName method returning a constant for gorm and from the table of
+// the embedded BaseModel for bun, and otherwise named after the struct in plural snake_case; sqlx structs
+// have no table.
+func mapORMTable(structInfo *ourtypes.StructInfo, namedType *gotypes.Named, structType *gotypes.Struct, pkg *packages.Package) {
+	embedded := make(map[string]string)
+	for i := 0; i < structType.NumFields(); i++ {
+		if field := structType.Field(i); field.Embedded() {
+			embedded[field.Name()] = embeddedTypeName(field.Type())
+		}
+	}
+
+	structInfo.ORM = structORM(structInfo.Fields, embedded)
+	if structInfo.ORM == "" {
+		return
+	}
+
+	for _, field := range structInfo.Fields {
+		if embeddedType, ok := embedded[field.Name]; ok && field.PromotedFrom == "" {
+			if tag, ok := reflect.StructTag(field.Tag).Lookup("bun"); ok && embeddedType == bunBaseModel {
+				for _, option := range strings.Split(tag, ",") {
+					if table, ok := strings.CutPrefix(option, "table:"); ok {
+						structInfo.Table = table
+					}
+				}
+			}
+			continue
+		}
+		if token.IsExported(field.Name) {
+			field.Column = ormColumn(structInfo.ORM, field)
+		}
+	}
+
+	switch structInfo.ORM {
+	case "gorm":
+		if table := tableNameMethod(namedType, pkg); table != "" {
+			structInfo.Table = table
+		} else {
+			structInfo.Table = pluralTableName(snakeCase(namedType.Obj().Name()))
+		}
+	case "bun":
+		if structInfo.Table == "" {
+			structInfo.Table = pluralTableName(snakeCase(namedType.Obj().Name()))
+		}
+	}
+}
+
+// structORM returns the ORM mapping a struct, by order of precedence, or "" if none does.
+func structORM(fields []*ourtypes.StructField, embedded map[string]string) string {
+	var gorm, bun, sqlx bool
+	for _, embeddedType := range embedded {
+		gorm = gorm || embeddedType == gormModel
+		bun = bun || embeddedType == bunBaseModel
+	}
+	for _, field := range fields {
+		tag := reflect.StructTag(field.Tag)
+		_, hasGorm := tag.Lookup("gorm")
+		_, hasBun := tag.Lookup("bun")
+		_, hasDB := tag.Lookup("db")
+		gorm, bun, sqlx = gorm || hasGorm, bun || hasBun, sqlx || hasDB
+	}
+	switch {
+	case gorm:
+		return "gorm"
+	case bun:
+		return "bun"
+	case sqlx:
+		return "sqlx"
+	}
+	return ""
+}
+
+// ormColumn returns the column a field maps to with orm, or "" if the field is ignored.
+func ormColumn(orm string, field *ourtypes.StructField) string {
+	tag := reflect.StructTag(field.Tag)
+	switch orm {
+	case "gorm":
+		value := tag.Get("gorm")
+		for _, option := range strings.Split(value, ";") {
+			option = strings.TrimSpace(option)
+			if option == "-" || strings.HasPrefix(option, "-:") || option == "embedded" {
+				return ""
+			}
+			if column, ok := strings.CutPrefix(option, "column:"); ok {
+				return column
+			}
+		}
+		return snakeCase(field.Name)
+	case "bun":
+		name, _, _ := strings.Cut(tag.Get("bun"), ",")
+		switch {
+		case name == "-":
+			return ""
+		case name != "" && !strings.Contains(name, ":"):
+			return name
+		}
+		return snakeCase(field.Name)
+	case "sqlx":
+		name, _, _ := strings.Cut(tag.Get("db"), ",")
+		switch name {
+		case "-":
+			return ""
+		case "":
+			return strings.ToLower(field.Name)
+		}
+		return name
+	}
+	return ""
+}
+
+// tableNameMethod returns the constant a TableName method of namedType returns, or "" if it has no such method.
+func tableNameMethod(namedType *gotypes.Named, pkg *packages.Package) string {
+	var method *gotypes.Func
+	for i := 0; i < namedType.NumMethods(); i++ {
+		if m := namedType.Method(i); m.Name() == "TableName" {
+			method = m
+		}
+	}
+	if method == nil || pkg == nil {
+		return ""
+	}
+	for _, file := range pkg.Syntax {
+		for _, decl := range file.Decls {
+			funcDecl, ok := decl.(*ast.FuncDecl)
+			if !ok || funcDecl.Body == nil || pkg.TypesInfo.Defs[funcDecl.Name] != method || len(funcDecl.Body.List) != 1 {
+				continue
+			}
+			if ret, ok := funcDecl.Body.List[0].(*ast.ReturnStmt); ok && len(ret.Results) == 1 {
+				table, _ := constantString(ret.Results[0], pkg.TypesInfo)
+				return table
+			}
+		}
+	}
+	return ""
+}
+
+// embeddedTypeName returns the fully qualified name of an embedded type, through a pointer.
+func embeddedTypeName(t gotypes.Type) string {
+	if ptr, ok := t.(*gotypes.Pointer); ok {
+		t = ptr.Elem()
+	}
+	if named, ok := t.(*gotypes.Named); ok {
+		return namedTypeName(named)
+	}
+	return t.String()
+}
+
+// snakeCase converts a Go identifier to snake_case like gorm and bun do, e.g. "UserID" to "user_id" and
+// "HTTPServer" to "http_server".
+func snakeCase(name string) string {
+	runes := []rune(name)
+	var builder strings.Builder
+	for i, r := range runes {
+		if i > 0 && unicode.IsUpper(r) {
+			prev := runes[i-1]
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) || (unicode.IsUpper(prev) && nextLower) {
+				builder.WriteByte('_')
+			}
+		}
+		builder.WriteRune(unicode.ToLower(r))
+	}
+	return builder.String()
+}
+
+// pluralTableName returns the English plural of a snake_case name, e.g. "user" to "users" and "order_entry" to
+// "order_entries", covering regular nouns only.
+func pluralTableName(name string) string {
+	switch {
+	case name == "":
+		return name
+	case strings.HasSuffix(name, "y") && len(name) > 1 && !strings.ContainsRune("aeiou", rune(name[len(name)-2])):
+		return name[:len(name)-1] + "ies"
+	case strings.HasSuffix(name, "s"), strings.HasSuffix(name, "x"), strings.HasSuffix(name, "z"),
+		strings.HasSuffix(name, "ch"), strings.HasSuffix(name, "sh"):
+		return name + "es"
+	}
+	return name + "s"
+}
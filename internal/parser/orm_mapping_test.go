@@ -0,0 +1,102 @@
+package parser
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	ourtypes "github.com/vlad/ast2llm-go/internal/types"
+)
+
+func TestProjectParser_ParseProject_ORMMapping(t *testing.T) {
+	projectPath := writeTestProject(t, map[string]string{
+		"go.mod": `module example.com/testproject
+
+go 1.21
+
+require github.com/uptrace/bun v1.0.0
+
+replace github.com/uptrace/bun => ./third_party/bun
+`,
+		"third_party/bun/go.mod": "module github.com/uptrace/bun\n\ngo 1.21\n",
+		"third_party/bun/bun.go": "package bun\n\ntype BaseModel struct{}\n",
+		"models/models.go": `package models
+
+import "github.com/uptrace/bun"
+
+type User struct {
+	ID        int    ` + "`gorm:\"primaryKey\"`" + `
+	UserName  string ` + "`json:\"name\" gorm:\"column:login;not null\"`" + `
+	HTTPToken string
+	Cache     string ` + "`gorm:\"-\"`" + `
+	internal  int
+}
+
+func (User) TableName() string { return "accounts" }
+
+type OrderEntry struct {
+	ID int ` + "`gorm:\"primaryKey\"`" + `
+}
+
+type Book struct {
+	bun.BaseModel ` + "`bun:\"table:library_books,alias:b\"`" + `
+	ID            int    ` + "`bun:\",pk,autoincrement\"`" + `
+	Title         string ` + "`bun:\"book_title\"`" + `
+	Draft         bool   ` + "`bun:\"-\"`" + `
+}
+
+type Row struct {
+	ID    int    ` + "`db:\"row_id\"`" + `
+	Label string
+}
+
+type Plain struct {
+	Name string ` + "`json:\"name\"`" + `
+}
+`,
+	})
+
+	fileInfos, err := New().ParseProject(projectPath)
+	require.NoError(t, err)
+	structs := make(map[string]*ourtypes.StructInfo)
+	for _, s := range fileInfos[filepath.Join(projectPath, "models", "models.go")].Structs {
+		structs[s.Name] = s
+	}
+	columns := func(s *ourtypes.StructInfo) map[string]string {
+		result := make(map[string]string)
+		for _, f := range s.Fields {
+			result[f.Name] = f.Column
+		}
+		return result
+	}
+
+	user := structs["example.com/testproject/models.User"]
+	assert.Equal(t, "gorm", user.ORM)
+	assert.Equal(t, "accounts", user.Table, "the TableName method overrides the convention")
+	assert.Equal(t, map[string]string{"ID": "id", "UserName": "login", "HTTPToken": "http_token", "Cache": "", "internal": ""}, columns(user))
+	assert.Equal(t, `json:"name" gorm:"column:login;not null"`, user.Fields[1].Tag)
+
+	entry := structs["example.com/testproject/models.OrderEntry"]
+	assert.Equal(t, "order_entries", entry.Table)
+
+	book := structs["example.com/testproject/models.Book"]
+	assert.Equal(t, "bun", book.ORM)
+	assert.Equal(t, "library_books", book.Table)
+	assert.Equal(t, map[string]string{"BaseModel": "", "ID": "id", "Title": "book_title", "Draft": ""}, columns(book))
+
+	row := structs["example.com/testproject/models.Row"]
+	assert.Equal(t, "sqlx", row.ORM)
+	assert.Empty(t, row.Table)
+	assert.Equal(t, map[string]string{"ID": "row_id", "Label": "label"}, columns(row))
+
+	plain := structs["example.com/testproject/models.Plain"]
+	assert.Empty(t, plain.ORM)
+	assert.Empty(t, plain.Fields[0].Column)
+}
+
+func TestSnakeCase(t *testing.T) {
+	for name, want := range map[string]string{"ID": "id", "UserID": "user_id", "HTTPServer": "http_server", "CreatedAt": "created_at", "Address2": "address2"} {
+		assert.Equal(t, want, snakeCase(name), name)
+	}
+}
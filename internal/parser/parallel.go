@@ -0,0 +1,36 @@
+package parser
+
+import (
+	"sync"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// forEachPackage calls fn for every package with syntax, running up to the configured number of workers at once.
+// fn must be safe for concurrent use.
+func (p *ProjectParser) forEachPackage(pkgs []*packages.Package, fn func(pkg *packages.Package)) {
+	workers := p.workers
+	if workers < 1 {
+		workers = 1
+	}
+
+	queue := make(chan *packages.Package)
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for pkg := range queue {
+				fn(pkg)
+			}
+		}()
+	}
+
+	for _, pkg := range pkgs {
+		if len(pkg.Syntax) > 0 {
+			queue <- pkg
+		}
+	}
+	close(queue)
+	wg.Wait()
+}
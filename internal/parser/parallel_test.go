@@ -0,0 +1,70 @@
+package parser
+
+import (
+	"go/ast"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/tools/go/packages"
+)
+
+func TestProjectParser_forEachPackage(t *testing.T) {
+	var pkgs []*packages.Package
+	for _, path := range []string{"a", "b", "c", "d", "e"} {
+		pkgs = append(pkgs, &packages.Package{PkgPath: path, Syntax: make([]*ast.File, 1)})
+	}
+	pkgs = append(pkgs, &packages.Package{PkgPath: "nosyntax"})
+
+	for _, workers := range []int{0, 1, 3, 10} {
+		var mu sync.Mutex
+		visited := make(map[string]int)
+		New(WithWorkers(workers)).forEachPackage(pkgs, func(pkg *packages.Package) {
+			mu.Lock()
+			visited[pkg.PkgPath]++
+			mu.Unlock()
+		})
+		assert.Equal(t, map[string]int{"a": 1, "b": 1, "c": 1, "d": 1, "e": 1}, visited, "workers=%d", workers)
+	}
+}
+
+func TestProjectParser_ParseProject_Workers(t *testing.T) {
+	projectPath := writeTestProject(t, map[string]string{
+		"main.go": `package main
+
+import (
+	"example.com/testproject/a"
+	"example.com/testproject/b"
+)
+
+func main() {
+	a.Run(b.Default)
+}
+`,
+		"a/a.go": `package a
+
+import "example.com/testproject/b"
+
+// Run runs the config.
+func Run(c b.Config) {}
+`,
+		"b/b.go": `package b
+
+// Config configures a.
+type Config struct {
+	Name string
+}
+
+var Default = Config{Name: "default"}
+`,
+	})
+
+	serial, err := New(WithWorkers(1)).ParseProject(projectPath)
+	require.NoError(t, err)
+	parallel, err := New(WithWorkers(4)).ParseProject(projectPath)
+	require.NoError(t, err)
+
+	assert.Len(t, serial, 3)
+	assert.Equal(t, serial, parallel)
+}
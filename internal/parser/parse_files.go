@@ -0,0 +1,112 @@
+package parser
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/vlad/ast2llm-go/internal/modinfo"
+	"golang.org/x/tools/go/packages"
+)
+
+// ParseFiles works like ParseProject but only loads the packages containing files, plus the project packages
+// they import so that used items are described in full. The result only holds the given files.
+// Relative file paths are resolved against projectPath.
+func (p *ProjectParser) ParseFiles(projectPath string, files []string) (ProjectInfo, error) {
+	return p.parseFiles(projectPath, files, nil)
+}
+
+// parseFiles implements ParseFiles, reading the files of overlay, keyed by absolute path, from their given
+// contents instead of the disk.
+func (p *ProjectParser) parseFiles(projectPath string, files []string, overlay map[string][]byte) (_ ProjectInfo, err error) {
+	defer func(start time.Time) { p.recordParse(start, err) }(time.Now())
+
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no files to parse")
+	}
+
+	root, err := filepath.Abs(projectPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve project path: %w", err)
+	}
+
+	wanted := make(map[string]bool, len(files))
+	patterns := make([]string, 0, len(files))
+	for _, f := range files {
+		f, err := ResolveFilePath(root, f)
+		if err != nil {
+			return nil, err
+		}
+		wanted[f] = true
+		patterns = append(patterns, "file="+f)
+	}
+
+	pkgs, err := p.loadPackagesMode(context.Background(), loadMode, root, overlay, patterns...)
+	if err != nil {
+		return nil, err
+	}
+
+	indexed := pkgs
+	if deps := projectImports(root, pkgs); len(deps) > 0 {
+		depPkgs, err := p.loadPackages(root, deps...)
+		if err != nil {
+			return nil, err
+		}
+		indexed = append(append([]*packages.Package(nil), pkgs...), depPkgs...)
+	}
+
+	index := p.buildSymbolIndex(indexed)
+	fileInfos := make(ProjectInfo, len(files))
+	var mu sync.Mutex
+	p.forEachPackage(pkgs, func(pkg *packages.Package) {
+		doc := p.packageDoc(pkg)
+		for _, file := range pkg.Syntax {
+			path := p.fset.File(file.Pos()).Name()
+			if !wanted[path] {
+				continue
+			}
+			if fileInfo, ok := p.extractFile(root, file, pkg, doc, index); ok {
+				if content, ok := overlay[path]; ok {
+					fileInfo.ContentHash, fileInfo.ModTime = contentHash(content), ""
+				}
+				mu.Lock()
+				fileInfos[path] = fileInfo
+				mu.Unlock()
+			}
+		}
+		p.releasePackage(pkg)
+	})
+
+	return fileInfos, nil
+}
+
+// projectImports returns the imports of pkgs that belong to the module at root, or to the modules of the
+// workspace at root, and are not loaded yet. It returns nil if root has no readable go.mod or go.work.
+func projectImports(root string, pkgs []*packages.Package) []string {
+	moduleInfo, err := modinfo.Load(root)
+	if err != nil {
+		return nil
+	}
+
+	loaded := make(map[string]bool, len(pkgs))
+	for _, pkg := range pkgs {
+		loaded[pkg.PkgPath] = true
+	}
+
+	var deps []string
+	seen := make(map[string]bool)
+	for _, pkg := range pkgs {
+		for imp := range pkg.Imports {
+			if loaded[imp] || seen[imp] {
+				continue
+			}
+			if moduleInfo.Owns(imp) {
+				seen[imp] = true
+				deps = append(deps, imp)
+			}
+		}
+	}
+	return deps
+}
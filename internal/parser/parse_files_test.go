@@ -0,0 +1,60 @@
+package parser
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProjectParser_ParseFiles(t *testing.T) {
+	projectPath := writeTestProject(t, map[string]string{
+		"main.go": `package main
+
+import "example.com/testproject/util"
+
+func main() { util.Helper() }
+`,
+		"other.go": `package main
+
+func other() {}
+`,
+		"util/helpers.go": `package util
+
+// Helper helps.
+func Helper() {}
+`,
+		"unrelated/unrelated.go": `package unrelated
+
+func Unrelated() {}
+`,
+	})
+
+	p := New()
+	fileInfos, err := p.ParseFiles(projectPath, []string{"main.go"})
+	require.NoError(t, err)
+
+	mainPath := filepath.Join(projectPath, "main.go")
+	require.Len(t, fileInfos, 1)
+	require.Contains(t, fileInfos, mainPath)
+
+	// Used items from other project packages are still described.
+	used := fileInfos[mainPath].UsedImportedFunctions
+	require.Len(t, used, 1)
+	assert.Equal(t, "example.com/testproject/util.Helper", used[0].Name)
+	assert.Equal(t, "Helper helps.", used[0].Comment)
+
+	t.Run("absolute paths and several packages", func(t *testing.T) {
+		unrelatedPath := filepath.Join(projectPath, "unrelated", "unrelated.go")
+		fileInfos, err := p.ParseFiles(projectPath, []string{mainPath, unrelatedPath})
+		require.NoError(t, err)
+		assert.Len(t, fileInfos, 2)
+		assert.Contains(t, fileInfos, unrelatedPath)
+	})
+
+	t.Run("no files", func(t *testing.T) {
+		_, err := p.ParseFiles(projectPath, nil)
+		assert.Error(t, err)
+	})
+}
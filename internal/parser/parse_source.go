@@ -0,0 +1,38 @@
+package parser
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// sourceFileName is the name ParseSource gives a source without a file path
+const sourceFileName = "source.go"
+
+// ParseSource works like ParseFiles for a single file whose content is src instead of what is on disk, such
+// as an unsaved editor buffer; the file does not need to exist. filePath is resolved against projectPath,
+// whose other files and packages are loaded from disk to resolve the items the source uses. Without
+// projectPath, the source is parsed on its own in a temporary directory outside any module: standard library
+// imports are resolved, other imports are not. The result only holds the file.
+func (p *ProjectParser) ParseSource(projectPath, filePath string, src []byte) (ProjectInfo, error) {
+	if filePath == "" {
+		filePath = sourceFileName
+	}
+	if filepath.Ext(filePath) != ".go" {
+		return nil, fmt.Errorf("source file %s is not a .go file", filePath)
+	}
+	if projectPath == "" {
+		dir, err := os.MkdirTemp("", "ast2llm-source-")
+		if err != nil {
+			return nil, fmt.Errorf("failed to create source directory: %w", err)
+		}
+		defer os.RemoveAll(dir)
+		projectPath, filePath = dir, filepath.Base(filePath)
+	}
+
+	path, err := ResolveFilePath(projectPath, filePath)
+	if err != nil {
+		return nil, err
+	}
+	return p.parseFiles(projectPath, []string{path}, map[string][]byte{path: src})
+}
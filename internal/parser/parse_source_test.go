@@ -0,0 +1,81 @@
+package parser
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProjectParser_ParseSource(t *testing.T) {
+	projectPath := writeTestProject(t, map[string]string{
+		"main.go": "package main\n\nfunc main() {}\n",
+		"util/helpers.go": `package util
+
+// Helper helps.
+func Helper() {}
+`,
+	})
+	src := []byte(`package main
+
+import "example.com/testproject/util"
+
+func main() { util.Helper() }
+
+func unsaved() {}
+`)
+	p := New()
+
+	t.Run("unsaved buffer", func(t *testing.T) {
+		info, err := p.ParseSource(projectPath, "main.go", src)
+		require.NoError(t, err)
+		require.Len(t, info, 1)
+		mainPath := filepath.Join(projectPath, "main.go")
+		assert.Equal(t, []string{"main", "unsaved"}, functionNames(info[mainPath]))
+		assert.Empty(t, info[mainPath].Diagnostics)
+
+		used := info[mainPath].UsedImportedFunctions
+		require.Len(t, used, 1, "items of other project packages are resolved")
+		assert.Equal(t, "Helper helps.", used[0].Comment)
+
+		onDisk, err := os.ReadFile(mainPath)
+		require.NoError(t, err)
+		assert.Equal(t, "package main\n\nfunc main() {}\n", string(onDisk))
+	})
+
+	t.Run("new file", func(t *testing.T) {
+		info, err := p.ParseSource(projectPath, "util/new.go", []byte("package util\n\n// New is not saved yet.\nfunc New() { Helper() }\n"))
+		require.NoError(t, err)
+		newPath := filepath.Join(projectPath, "util", "new.go")
+		assert.Equal(t, []string{"New"}, functionNames(info[newPath]))
+		assert.Empty(t, info[newPath].Diagnostics, "the other files of the package are loaded")
+	})
+
+	t.Run("without project", func(t *testing.T) {
+		info, err := p.ParseSource("", "", []byte("package scratch\n\nimport \"strings\"\n\nfunc Upper(s string) string { return strings.ToUpper(s) }\n"))
+		require.NoError(t, err)
+		require.Len(t, info, 1)
+		for path, fileInfo := range info {
+			assert.Equal(t, sourceFileName, filepath.Base(path))
+			require.Len(t, fileInfo.Functions, 1)
+			assert.Equal(t, "Upper", fileInfo.Functions[0].Name)
+			assert.Equal(t, "string", fileInfo.Functions[0].Returns[0])
+		}
+	})
+
+	t.Run("project without module", func(t *testing.T) {
+		dir := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "a.go"), []byte("package scratch\n\nfunc A() {}\n"), 0644))
+		info, err := p.ParseSource(dir, "b.go", []byte("package scratch\n\nfunc B() { A() }\n"))
+		require.NoError(t, err)
+		require.Len(t, info, 1)
+		assert.Equal(t, []string{"B"}, functionNames(info[filepath.Join(dir, "b.go")]))
+	})
+
+	t.Run("not a go file", func(t *testing.T) {
+		_, err := p.ParseSource(projectPath, "README.md", src)
+		assert.ErrorContains(t, err, "not a .go file")
+	})
+}
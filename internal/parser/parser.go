@@ -0,0 +1,40 @@
+package parser
+
+import (
+	"context"
+
+	ourtypes "github.com/vlad/ast2llm-go/internal/types"
+)
+
+// Parser is the parsing functionality the MCP tools and prompts, project sessions, the gRPC server and
+// ForDiff rely on. ProjectParser is the only implementation.
+type Parser interface {
+	// ParseProject parses every package of the project
+	ParseProject(projectPath string) (ProjectInfo, error)
+	// ParseProjectWithProgress parses every package of the project, reporting its progress to progress
+	ParseProjectWithProgress(projectPath string, progress ProgressFunc) (ProjectInfo, error)
+	// ParseProjectSyntax parses every package of the project without type-checking it against its imports
+	ParseProjectSyntax(projectPath string) (ProjectInfo, error)
+	// ParseSource parses a file of the project from the given source instead of its content on disk
+	ParseSource(projectPath, filePath string, src []byte) (ProjectInfo, error)
+	// ParseProjectIncremental re-parses the packages of the changed files, reusing the previous result
+	ParseProjectIncremental(projectPath string, changedFiles []string) (ProjectInfo, error)
+	// BuildGraphContext builds the package dependency graph of the project, giving up when ctx is done
+	BuildGraphContext(ctx context.Context, projectPath string) (*ourtypes.DependencyGraph, error)
+	// FindStructUsages lists where a struct is constructed and where its fields are written
+	FindStructUsages(projectPath, structName string) (string, []*ourtypes.StructUsage, error)
+	// ListMethods lists the methods declared on a type across the files of its package
+	ListMethods(projectPath, typeName string) (string, []*ourtypes.FunctionInfo, error)
+	// FindDeadExports lists the exported package-level symbols nothing in the project refers to
+	FindDeadExports(projectPath string) ([]*ourtypes.DeadExport, error)
+	// CompareProjects lists the differences between the exported API surfaces of two versions of a project
+	CompareProjects(oldPath, newPath string) ([]*ourtypes.APIChange, error)
+	// CheckInterfaceImpl reports whether a type implements an interface and which methods prevent it
+	CheckInterfaceImpl(projectPath, typeName, interfaceName string) (*ourtypes.InterfaceCheck, error)
+	// ExtractSymbolContext returns the files declaring a symbol and the types it references
+	ExtractSymbolContext(projectPath, symbolName string) (ProjectInfo, error)
+	// ExtractDiffContext returns the context of the declarations a unified diff touches, and their names
+	ExtractDiffContext(projectPath, unifiedDiff string) (ProjectInfo, []string, error)
+}
+
+var _ Parser = (*ProjectParser)(nil)
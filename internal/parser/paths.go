@@ -0,0 +1,48 @@
+package parser
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ResolveFilePath returns the absolute, cleaned path of filePath, which may be absolute or relative to
+// projectPath and may use slashes on any platform.
+func ResolveFilePath(projectPath, filePath string) (string, error) {
+	filePath = filepath.FromSlash(filePath)
+	if filepath.IsAbs(filePath) {
+		return filepath.Clean(filePath), nil
+	}
+	root, err := filepath.Abs(projectPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve project path: %w", err)
+	}
+	return filepath.Join(root, filePath), nil
+}
+
+// SamePath reports whether a and b name the same file: they are equal, or they differ only in case and the
+// file system, being case-insensitive like the default ones of Windows and macOS, resolves both to one file.
+func SamePath(a, b string) bool {
+	if a == b {
+		return true
+	}
+	if !strings.EqualFold(a, b) {
+		return false
+	}
+	infoA, err := os.Stat(a)
+	if err != nil {
+		return false
+	}
+	infoB, err := os.Stat(b)
+	return err == nil && os.SameFile(infoA, infoB)
+}
+
+// HasPathPrefix reports whether path is below the directory dir, comparing them like SamePath.
+func HasPathPrefix(path, dir string) bool {
+	prefix := dir + string(filepath.Separator)
+	if len(path) <= len(prefix) {
+		return false
+	}
+	return strings.HasPrefix(path, prefix) || SamePath(path[:len(dir)], dir) && path[len(dir)] == filepath.Separator
+}
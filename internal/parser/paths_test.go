@@ -0,0 +1,54 @@
+package parser
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveFilePath(t *testing.T) {
+	root := t.TempDir()
+	tests := []struct {
+		name     string
+		filePath string
+		want     string
+	}{
+		{"relative", "main.go", filepath.Join(root, "main.go")},
+		{"relative with slashes", "pkg/sub/file.go", filepath.Join(root, "pkg", "sub", "file.go")},
+		{"relative with dots", "./pkg/../main.go", filepath.Join(root, "main.go")},
+		{"absolute", filepath.Join(root, "pkg", "file.go"), filepath.Join(root, "pkg", "file.go")},
+		{"absolute with slashes", filepath.ToSlash(filepath.Join(root, "main.go")), filepath.Join(root, "main.go")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ResolveFilePath(root, tt.filePath)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestSamePath(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "Main.go")
+	require.NoError(t, os.WriteFile(path, []byte("package main\n"), 0644))
+	lower := filepath.Join(dir, "main.go")
+
+	// Whether the names match depends on the file system the test runs on
+	_, err := os.Stat(lower)
+	caseInsensitive := err == nil
+
+	assert.True(t, SamePath(path, path))
+	assert.Equal(t, caseInsensitive, SamePath(path, lower))
+	assert.False(t, SamePath(path, filepath.Join(dir, "other.go")))
+	assert.False(t, SamePath(filepath.Join(dir, "missing.go"), filepath.Join(dir, "MISSING.go")), "missing files are only equal to themselves")
+
+	assert.True(t, HasPathPrefix(path, dir))
+	assert.Equal(t, caseInsensitive, HasPathPrefix(path, strings.ToUpper(dir)))
+	assert.False(t, HasPathPrefix(dir, dir))
+	assert.False(t, HasPathPrefix(dir+"2"+string(filepath.Separator)+"main.go", dir))
+}
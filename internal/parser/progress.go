@@ -0,0 +1,43 @@
+package parser
+
+import "golang.org/x/tools/go/packages"
+
+// Progress describes how far ParseProjectWithProgress has come
+type Progress struct {
+	PackagesLoaded int // Number of loaded packages
+	FilesProcessed int // Number of files extracted so far
+	TotalFiles     int // Number of files to extract
+}
+
+// ProgressFunc receives the progress of a parse. Calls are never concurrent.
+type ProgressFunc func(Progress)
+
+// progressTracker counts the extracted files and reports them to a ProgressFunc
+type progressTracker struct {
+	progress ProgressFunc
+	current  Progress
+}
+
+// newProgressTracker reports the loaded packages and returns a tracker for extracting their files.
+// The tracker does nothing if progress is nil.
+func newProgressTracker(pkgs []*packages.Package, progress ProgressFunc) *progressTracker {
+	t := &progressTracker{progress: progress, current: Progress{PackagesLoaded: len(pkgs)}}
+	for _, pkg := range pkgs {
+		t.current.TotalFiles += len(pkg.Syntax)
+	}
+	t.report()
+	return t
+}
+
+// done records that files more files were extracted. Callers must serialize calls.
+func (t *progressTracker) done(files int) {
+	t.current.FilesProcessed += files
+	t.report()
+}
+
+// report passes the current progress to the ProgressFunc, if any
+func (t *progressTracker) report() {
+	if t.progress != nil {
+		t.progress(t.current)
+	}
+}
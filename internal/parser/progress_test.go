@@ -0,0 +1,31 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProjectParser_ParseProjectWithProgress(t *testing.T) {
+	projectPath := writeTestProject(t, map[string]string{
+		"main.go":       "package main\n\nfunc main() {}\n",
+		"util/a.go":     "package util\n\nfunc A() {}\n",
+		"util/b.go":     "package util\n\nfunc B() {}\n",
+		"store/repo.go": "package store\n\ntype Repo struct{}\n",
+	})
+
+	var updates []Progress
+	info, err := New(WithWorkers(2)).ParseProjectWithProgress(projectPath, func(progress Progress) {
+		updates = append(updates, progress)
+	})
+	require.NoError(t, err)
+	assert.Len(t, info, 4)
+
+	require.Len(t, updates, 4, "one update after loading and one per package")
+	assert.Equal(t, Progress{PackagesLoaded: 3, FilesProcessed: 0, TotalFiles: 4}, updates[0])
+	assert.Equal(t, Progress{PackagesLoaded: 3, FilesProcessed: 4, TotalFiles: 4}, updates[len(updates)-1])
+	for i := 1; i < len(updates); i++ {
+		assert.Greater(t, updates[i].FilesProcessed, updates[i-1].FilesProcessed)
+	}
+}
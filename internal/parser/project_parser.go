@@ -2,13 +2,20 @@ package parser
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"go/ast"
 	"go/printer"
 	"go/token"
 	gotypes "go/types" // Alias go/types to avoid conflict
-	"log"
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strconv"
 	"strings"
+	"sync"
+	"time"
 
 	ourtypes "github.com/vlad/ast2llm-go/internal/types" // Alias our types
 	"golang.org/x/tools/go/packages"
@@ -19,63 +26,363 @@ type ProjectInfo = map[string]*ourtypes.FileInfo
 
 // ProjectParser handles parsing of Go projects using go/packages and go/types
 type ProjectParser struct {
-	fset *token.FileSet
+	fset            *token.FileSet
+	symbolDepth     int           // How many levels of referenced types ExtractSymbolContext follows
+	transitiveDepth int           // How many levels of types referenced by used imported types are added to them
+	includePromoted bool          // Whether struct info includes fields and methods promoted from embedded types
+	includeBodies   bool          // Whether function info includes the function source
+	includeExternal bool          // Whether used types declared outside the project are described
+	includeTests    bool          // Whether _test.go files and external test packages are parsed
+	excludeGlobs    []string      // Patterns of project-relative file paths left out of the result
+	generatedMode   GeneratedMode // How files with a generated code header are handled
+	workers         int           // Number of packages extracted concurrently
+	maxValueLength  int           // Maximum length of rendered variable and constant values, 0 means no limit
+	includeExamples bool          // Whether functions and types include the Example functions documenting them
+	inlineMinLines  int           // Number of lines from which anonymous structs and function literals are extracted
+	naming          NamingMode    // How extracted names are qualified
+	extractors      []Extractor   // Custom extractors run on every extracted file, see WithExtractor
+	buildTags       []string      // Build tags selecting the files of a package, in addition to the default ones
+	goCacheDir      string        // Build cache of the go command loading the packages, empty for its default
+	vendor          bool          // Whether dependencies are resolved from the vendor directory, see WithVendor
+	goFlags         []string      // Additional flags of the go command loading the packages
+	goEnv           []string      // Additional KEY=value environment variables of the go command
+	offline         bool          // Whether the go command is kept from the network, see WithOffline
+	warnings        WarningFunc   // Receives the warnings found while parsing, nil to log them
+	diskCacheDir    string        // Directory ParseProject results are persisted in, empty for none
+
+	cacheMu sync.Mutex
+	cache   map[string]*projectCache // Key: absolute project path
+
+	diskCacheMu sync.Mutex // Serializes the updates of the persisted hit counters
+
+	contentHashes sync.Map // Hash of the content of the project files, by absolute path, as last parsed
+
+	examplesMu sync.Mutex
+	examples   map[string]*packageExamples // Key: package ID
+
+	statsMu sync.Mutex
+	stats   Stats
+
+	warningsMu sync.Mutex // Serializes the calls to warnings
+}
+
+// Option configures a ProjectParser
+type Option func(*ProjectParser)
+
+// WithSymbolDepth sets how many levels of referenced types ExtractSymbolContext includes
+func WithSymbolDepth(depth int) Option {
+	return func(p *ProjectParser) {
+		p.symbolDepth = depth
+	}
+}
+
+// WithTransitiveDepth makes the used imported types of a file include the types their fields and method
+// signatures reference, following references through project types up to depth levels. 0, the default, only
+// includes the types the file uses directly.
+func WithTransitiveDepth(depth int) Option {
+	return func(p *ProjectParser) {
+		p.transitiveDepth = depth
+	}
+}
+
+// WithPromotedMembers makes struct info include the fields and methods promoted from embedded types
+func WithPromotedMembers() Option {
+	return func(p *ProjectParser) {
+		p.includePromoted = true
+	}
+}
+
+// WithFunctionBodies makes function and method info include their source code
+func WithFunctionBodies() Option {
+	return func(p *ProjectParser) {
+		p.includeBodies = true
+	}
+}
+
+// WithExternalTypes makes file info describe the used types declared outside the project,
+// e.g. in the standard library or third-party modules
+func WithExternalTypes() Option {
+	return func(p *ProjectParser) {
+		p.includeExternal = true
+	}
+}
+
+// WithTests makes the parser include _test.go files and external test packages
+func WithTests() Option {
+	return func(p *ProjectParser) {
+		p.includeTests = true
+	}
+}
+
+// WithExamples makes function and type info include the ExampleXxx functions of the package's _test.go files
+// that document them, whether or not WithTests is set
+func WithExamples() Option {
+	return func(p *ProjectParser) {
+		p.includeExamples = true
+	}
+}
+
+// WithBuildTags makes the parser select the files of every package with the given build tags set, like
+// go build -tags
+func WithBuildTags(tags ...string) Option {
+	return func(p *ProjectParser) {
+		p.buildTags = append(p.buildTags, tags...)
+	}
+}
+
+// WithGoCacheDir makes the go command loading the packages use dir as its build cache (GOCACHE)
+func WithGoCacheDir(dir string) Option {
+	return func(p *ProjectParser) {
+		p.goCacheDir = dir
+	}
+}
+
+// WithWorkers sets how many packages are extracted concurrently, defaults to GOMAXPROCS
+func WithWorkers(n int) Option {
+	return func(p *ProjectParser) {
+		p.workers = n
+	}
 }
 
 // New creates a new ProjectParser instance
-func New() *ProjectParser {
-	return &ProjectParser{
-		fset: token.NewFileSet(),
+func New(opts ...Option) *ProjectParser {
+	p := &ProjectParser{
+		fset:           token.NewFileSet(),
+		symbolDepth:    2,
+		workers:        runtime.GOMAXPROCS(0),
+		maxValueLength: defaultMaxValueLength,
+		inlineMinLines: DefaultInlineMinLines,
+		cache:          make(map[string]*projectCache),
+		examples:       make(map[string]*packageExamples),
+	}
+	for _, opt := range opts {
+		opt(p)
 	}
+	return p
 }
 
 // ParseProject loads a Go project and extracts detailed information for all Go files within it.
 // It returns a map where keys are absolute file paths and values are their corresponding FileInfo.
 func (p *ProjectParser) ParseProject(projectPath string) (ProjectInfo, error) {
+	return p.ParseProjectWithProgress(projectPath, nil)
+}
+
+// ParseProjectWithProgress works like ParseProject and calls progress once the packages are loaded and
+// after every extracted package. progress may be nil. Results read from the disk cache report no progress.
+func (p *ProjectParser) ParseProjectWithProgress(projectPath string, progress ProgressFunc) (_ ProjectInfo, err error) {
+	defer func(start time.Time) { p.recordParse(start, err) }(time.Now())
+
+	root, err := filepath.Abs(projectPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve project path: %w", err)
+	}
+	cached, cacheKey, ok := p.loadFromDiskCache(root)
+	if ok {
+		return cached, nil
+	}
+
+	pkgs, err := p.loadPackages(projectPath, "./...")
+	if err != nil {
+		return nil, err
+	}
+
+	index := p.buildSymbolIndex(pkgs)
+	fileInfos := make(ProjectInfo)
+	var mu sync.Mutex
+	tracker := newProgressTracker(pkgs, progress)
+
+	p.forEachPackage(pkgs, func(pkg *packages.Package) {
+		pkgInfos := make(ProjectInfo, len(pkg.Syntax))
+		doc := p.packageDoc(pkg)
+		for _, file := range pkg.Syntax {
+			if fileInfo, ok := p.extractFile(root, file, pkg, doc, index); ok {
+				pkgInfos[p.fset.File(file.Pos()).Name()] = fileInfo
+			}
+		}
+
+		files := len(pkg.Syntax)
+		p.releasePackage(pkg)
+
+		mu.Lock()
+		defer mu.Unlock()
+		for path, info := range pkgInfos {
+			fileInfos[path] = info
+		}
+		tracker.done(files)
+	})
+
+	p.storeInDiskCache(root, cacheKey, fileInfos)
+	return fileInfos, nil
+}
+
+// loadMode is the mode packages are loaded with to be extracted
+const loadMode = packages.LoadSyntax | packages.LoadTypes | packages.LoadImports | packages.LoadFiles
+
+// loadPackages loads the packages matching patterns relative to projectPath and logs their errors.
+// In a go.work workspace, "./..." matches the packages of all its modules. Outside a module, patterns are
+// ignored and every package below projectPath is parsed by loadWithoutModule.
+func (p *ProjectParser) loadPackages(projectPath string, patterns ...string) ([]*packages.Package, error) {
+	return p.loadPackagesMode(context.Background(), loadMode, projectPath, nil, patterns...)
+}
+
+// loadPackagesMode works like loadPackages, loading the information of the packages that mode asks for.
+// Packages loaded outside a module are always parsed and type-checked. The go command is stopped when ctx is done.
+func (p *ProjectParser) loadPackagesMode(ctx context.Context, mode packages.LoadMode, projectPath string, overlay map[string][]byte, patterns ...string) ([]*packages.Package, error) {
+	if err := p.validateExcludeGlobs(); err != nil {
+		return nil, err
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("failed to load packages: %w", err)
+	}
+	if !inModule(projectPath) {
+		pkgs, err := p.loadWithoutModule(projectPath, overlay)
+		p.recordWork(len(pkgs), 0, 0, 0)
+		return pkgs, err
+	}
+
 	cfg := &packages.Config{
-		Mode: packages.LoadSyntax | packages.LoadTypes | packages.LoadImports | packages.LoadFiles,
-		Fset: p.fset,
-		Dir:  projectPath,
+		Context: ctx,
+		Mode:    mode,
+		Fset:    p.fset,
+		Dir:     projectPath,
+		Tests:   p.includeTests,
+		Overlay: overlay,
+	}
+	cfg.ParseFile = p.projectFileParser(projectPath)
+	if len(p.buildTags) > 0 {
+		cfg.BuildFlags = []string{"-tags=" + strings.Join(p.buildTags, ",")}
+	}
+	vendor := p.vendor || vendored(projectPath)
+	if vendor {
+		cfg.BuildFlags = append(cfg.BuildFlags, "-mod=vendor")
+	} else if p.offline {
+		cfg.BuildFlags = append(cfg.BuildFlags, "-mod=readonly")
+	}
+	cfg.BuildFlags = append(cfg.BuildFlags, p.goFlags...)
+	var env []string
+	if p.goCacheDir != "" {
+		env = append(env, "GOCACHE="+p.goCacheDir)
+	}
+	if p.offline {
+		env = append(env, offlineEnv...)
+	}
+	env = append(env, p.goEnv...)
+	if len(env) > 0 {
+		cfg.Env = append(os.Environ(), env...)
 	}
 
-	pkgs, err := packages.Load(cfg, "./...")
+	pkgs, err := packages.Load(cfg, workspacePatterns(projectPath, patterns)...)
+	if err == nil {
+		err = ctx.Err()
+	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to load packages: %w", err)
 	}
 
+	if p.includeTests {
+		pkgs = withoutRedundantTestVariants(pkgs)
+	}
+
 	if len(pkgs) == 0 {
 		return nil, fmt.Errorf("no packages found in %s", projectPath)
 	}
+	p.recordWork(len(pkgs), 0, 0, 0)
+	if p.offline {
+		if missing := missingImports(pkgs); len(missing) > 0 {
+			return nil, missingImportsError(missing)
+		}
+	}
 
-	fileInfos := make(ProjectInfo)
+	// Packages with errors are still processed, with partial results
+	for _, pkg := range pkgs {
+		for _, err := range pkg.Errors {
+			p.warn(packageWarning(pkg.PkgPath, err))
+		}
+	}
 
+	if mode&packages.NeedSyntax != 0 {
+		p.withCgoFallback(pkgs)
+	}
+	return pkgs, nil
+}
+
+// withoutRedundantTestVariants drops the packages whose files are all covered by another loaded package when
+// loading with tests: a package compiled with its _test.go files replaces the plain package, and generated test
+// mains are skipped.
+func withoutRedundantTestVariants(pkgs []*packages.Package) []*packages.Package {
+	testVariants := make(map[string]bool)
 	for _, pkg := range pkgs {
-		if len(pkg.Errors) > 0 {
-			for _, err := range pkg.Errors {
-				log.Printf("Package error in %s: %v", pkg.PkgPath, err)
-			}
-			// Decide whether to return an error or continue with partial results
-			// For now, let's continue processing even with package errors, but log them.
+		if strings.HasSuffix(pkg.ID, " ["+pkg.PkgPath+".test]") {
+			testVariants[pkg.PkgPath] = true
 		}
+	}
 
-		for _, file := range pkg.Syntax {
-			absolutePath := p.fset.File(file.Pos()).Name()
-			fileInfo := p.extractFileInfoForFile(file, pkg, pkgs)
-			fileInfos[absolutePath] = fileInfo
+	result := make([]*packages.Package, 0, len(pkgs))
+	for _, pkg := range pkgs {
+		if pkg.ID == pkg.PkgPath && testVariants[pkg.PkgPath] {
+			continue // Replaced by its test variant
+		}
+		if strings.HasSuffix(pkg.ID, ".test") && pkg.Name == "main" {
+			continue // Generated test main
 		}
+		result = append(result, pkg)
 	}
+	return result
+}
 
-	return fileInfos, nil
+// packageDoc returns the package doc comment of pkg, taken from doc.go if it has one and otherwise from the
+// first file, by name, that has one.
+func (p *ProjectParser) packageDoc(pkg *packages.Package) string {
+	var doc *ast.CommentGroup
+	var docFile string
+	for _, file := range pkg.Syntax {
+		if file.Doc == nil {
+			continue
+		}
+		name := filepath.Base(p.fset.File(file.Pos()).Name())
+		if name == "doc.go" {
+			return strings.TrimSpace(file.Doc.Text())
+		}
+		if doc == nil || name < docFile {
+			doc, docFile = file.Doc, name
+		}
+	}
+	if doc == nil {
+		return ""
+	}
+	return strings.TrimSpace(doc.Text())
+}
+
+// importInfo describes an import spec with its alias.
+func importInfo(imp *ast.ImportSpec) *ourtypes.ImportInfo {
+	// Import paths can be raw strings too
+	path, err := strconv.Unquote(imp.Path.Value)
+	if err != nil {
+		path = imp.Path.Value
+	}
+	info := &ourtypes.ImportInfo{Path: path}
+	if imp.Name != nil {
+		switch imp.Name.Name {
+		case "_":
+			info.IsBlank = true
+		case ".":
+			info.IsDot = true
+		default:
+			info.Alias = imp.Name.Name
+		}
+	}
+	return info
 }
 
 // extractFileInfoForFile extracts detailed information for a single AST file within a package.
-func (p *ProjectParser) extractFileInfoForFile(file *ast.File, pkg *packages.Package, projectPkgs []*packages.Package) *ourtypes.FileInfo {
+func (p *ProjectParser) extractFileInfoForFile(file *ast.File, pkg *packages.Package, pkgDoc string, index *symbolIndex) *ourtypes.FileInfo {
 	fileInfo := ourtypes.NewFileInfo()
 	fileInfo.PackageName = file.Name.Name
+	fileInfo.PackageDoc = pkgDoc
 
 	// Extract imports specific to this file
 	for _, imp := range file.Imports {
-		fileInfo.Imports = append(fileInfo.Imports, strings.Trim(imp.Path.Value, "\""))
+		fileInfo.Imports = append(fileInfo.Imports, importInfo(imp))
 	}
 
 	// Extract functions and detailed struct info from this file
@@ -85,6 +392,10 @@ func (p *ProjectParser) extractFileInfoForFile(file *ast.File, pkg *packages.Pac
 	// Iterate over the AST nodes of the current file to find declarations
 	ast.Inspect(file, func(n ast.Node) bool {
 		if genDecl, ok := n.(*ast.GenDecl); ok {
+			if isIotaBlock(genDecl, pkg) {
+				fileInfo.ConstGroups = append(fileInfo.ConstGroups, p.extractConstGroup(genDecl, pkg))
+				return false
+			}
 			for _, spec := range genDecl.Specs {
 				if typeSpec, isTypeSpec := spec.(*ast.TypeSpec); isTypeSpec {
 					// Check if this typeSpec corresponds to a named type that is a struct
@@ -112,10 +423,11 @@ func (p *ProjectParser) extractFileInfoForFile(file *ast.File, pkg *packages.Pac
 				}
 			}
 		} else if funcDecl, ok := n.(*ast.FuncDecl); ok {
-			// Only top-level (non-method) functions
-			if funcDecl.Recv == nil {
-				fnInfo := p.extractFunctionInfo(funcDecl, pkg)
+			fnInfo := p.extractFunctionInfo(funcDecl, pkg)
+			if fnInfo.Receiver == "" {
 				fileInfo.Functions = append(fileInfo.Functions, fnInfo)
+			} else {
+				fileInfo.Methods = append(fileInfo.Methods, fnInfo)
 			}
 		}
 		return true
@@ -131,91 +443,70 @@ func (p *ProjectParser) extractFileInfoForFile(file *ast.File, pkg *packages.Pac
 		fileInfo.Interfaces = append(fileInfo.Interfaces, iInfo)
 	}
 
-	// Extract used imported structs from this file
-	fileInfo.UsedImportedStructs = p.extractUsedImportedStructInfoFromFile(file, pkg)
+	fileInfo.InlineTypes = p.extractInlineTypes(file, pkg)
+	fileInfo.Directives = p.extractDirectives(file)
+	fileInfo.ErrorValues = p.extractErrorValues(file, pkg)
+	fileInfo.Routes = p.extractRoutes(file, pkg)
+	fileInfo.SQLQueries = p.extractSQLQueries(file, pkg)
+	fileInfo.EntryPoints = p.extractEntryPoints(file, pkg, fileInfo.Routes)
+
+	// Extract used imported structs and interfaces from this file
+	fileInfo.UsedImportedStructs, fileInfo.UsedImportedInterfaces = p.extractUsedImportedStructInfoFromFile(file, pkg, index)
+	setUsedFields(fileInfo.UsedImportedStructs, file, pkg.TypesInfo, pkg.Types)
 
 	// Collect used imported functions (by fully qualified name)
-	fileInfo.UsedImportedFunctions = p.extractUsedImportedFunctions(file, pkg, projectPkgs)
+	fileInfo.UsedImportedFunctions = p.extractUsedImportedFunctions(file, pkg, index)
 
 	// Collect used imported global vars (by fully qualified name)
-	fileInfo.UsedImportedGlobalVars = p.extractUsedImportedGlobalVars(file, pkg, projectPkgs)
+	fileInfo.UsedImportedGlobalVars = p.extractUsedImportedGlobalVars(file, pkg, index)
+
+	if p.includeExternal {
+		fileInfo.ExternalStructs, fileInfo.ExternalInterfaces = p.extractExternalTypes(file, pkg, index)
+	}
 
 	return fileInfo
 }
 
 // extractUsedImportedGlobalVars extracts detailed information about imported global variables used in the file.
-func (p *ProjectParser) extractUsedImportedGlobalVars(file *ast.File, pkg *packages.Package, projectPkgs []*packages.Package) []*ourtypes.GlobalVarInfo {
+func (p *ProjectParser) extractUsedImportedGlobalVars(file *ast.File, pkg *packages.Package, index *symbolIndex) []*ourtypes.GlobalVarInfo {
 	usedVars := make(map[string]*ourtypes.GlobalVarInfo)
 
 	ast.Inspect(file, func(n ast.Node) bool {
-		if selExpr, ok := n.(*ast.SelectorExpr); ok {
-			if obj := pkg.TypesInfo.Uses[selExpr.Sel]; obj != nil {
-				// We are looking for either a variable or a constant.
-				_, isVar := obj.(*gotypes.Var)
-				_, isConst := obj.(*gotypes.Const)
-
-				if isVar || isConst {
-					if obj.Pkg() != nil && obj.Pkg() != pkg.Types { // Check if it's from another package
-						varName := obj.Pkg().Path() + "." + obj.Name()
-						if _, exists := usedVars[varName]; !exists {
-							var foundVar *ourtypes.GlobalVarInfo
-							// Search for original declaration in project packages
-							for _, pPkg := range projectPkgs {
-								if pPkg.PkgPath != obj.Pkg().Path() {
-									continue
-								}
-
-								for _, fAst := range pPkg.Syntax {
-									ast.Inspect(fAst, func(node ast.Node) bool {
-										if genDecl, ok := node.(*ast.GenDecl); ok {
-											for _, spec := range genDecl.Specs {
-												if valSpec, ok := spec.(*ast.ValueSpec); ok {
-													for i, name := range valSpec.Names {
-														if name.Name == obj.Name() {
-															if defObj := pPkg.TypesInfo.Defs[name]; defObj != nil {
-																foundVar = p.extractGlobalVarInfo(defObj, genDecl, valSpec, i, pPkg)
-																// We need to set the fully qualified name
-																foundVar.Name = varName
-																return false // stop inner inspect
-															}
-														}
-													}
-												}
-											}
-										}
-										return foundVar == nil // continue if not found
-									})
-									if foundVar != nil {
-										break
-									}
-								}
-								if foundVar != nil {
-									break
-								}
-							}
+		selExpr, ok := n.(*ast.SelectorExpr)
+		if !ok {
+			return true
+		}
+		obj := pkg.TypesInfo.Uses[selExpr.Sel]
+		if obj == nil || obj.Pkg() == nil || obj.Pkg() == pkg.Types { // Only variables and constants from other packages
+			return true
+		}
+		_, isVar := obj.(*gotypes.Var)
+		cnst, isConst := obj.(*gotypes.Const)
+		if !isVar && !isConst {
+			return true
+		}
 
-							if foundVar != nil {
-								usedVars[varName] = foundVar
-							} else {
-								// Fallback for stdlib or not found
-								if cnst, isConst := obj.(*gotypes.Const); isConst {
-									usedVars[varName] = &ourtypes.GlobalVarInfo{
-										Name:    varName,
-										Type:    cnst.Type().String(),
-										Value:   cnst.Val().String(),
-										IsConst: true,
-									}
-								} else {
-									usedVars[varName] = &ourtypes.GlobalVarInfo{
-										Name:    varName,
-										Type:    obj.Type().String(),
-										IsConst: false,
-									}
-								}
-							}
-						}
-					}
-				}
+		varName := qualifiedName(obj)
+		if _, exists := usedVars[varName]; exists {
+			return true
+		}
+		if foundVar, ok := index.globalVars[varName]; ok {
+			usedVars[varName] = foundVar
+		} else if isConst {
+			// Fallback for stdlib or not found
+			usedVars[varName] = &ourtypes.GlobalVarInfo{
+				Name:    varName,
+				ID:      symbolID(obj),
+				Type:    cnst.Type().String(),
+				Value:   cnst.Val().String(),
+				IsConst: true,
+			}
+		} else {
+			usedVars[varName] = &ourtypes.GlobalVarInfo{
+				Name:    varName,
+				ID:      symbolID(obj),
+				Type:    obj.Type().String(),
+				IsConst: false,
 			}
 		}
 		return true
@@ -228,98 +519,105 @@ func (p *ProjectParser) extractUsedImportedGlobalVars(file *ast.File, pkg *packa
 	return result
 }
 
-// extractUsedImportedFunctions extracts detailed information about imported functions used in the file.
-func (p *ProjectParser) extractUsedImportedFunctions(file *ast.File, pkg *packages.Package, projectPkgs []*packages.Package) []*ourtypes.FunctionInfo {
+// extractUsedImportedFunctions extracts detailed information about imported functions used in the file, whether
+// they are called or used as values, e.g. passed as a handler or assigned to a field. Functions the file calls
+// carry the first of its calls that fits on a line, see callSite.
+func (p *ProjectParser) extractUsedImportedFunctions(file *ast.File, pkg *packages.Package, index *symbolIndex) []*ourtypes.FunctionInfo {
 	var usedImportedFunctions []*ourtypes.FunctionInfo
+	var names []string
+	seen := make(map[string]bool)
+	calls := make(map[*ast.SelectorExpr]*ast.CallExpr) // Calls by the selector of the called function
+	sites := make(map[string]string)                   // Call sites by qualified function name
 	ast.Inspect(file, func(n ast.Node) bool {
-		call, ok := n.(*ast.CallExpr)
+		if call, ok := n.(*ast.CallExpr); ok {
+			if sel := calledSelector(call); sel != nil {
+				calls[sel] = call
+			}
+			return true
+		}
+		sel, ok := n.(*ast.SelectorExpr)
 		if !ok {
 			return true
 		}
-		switch fun := call.Fun.(type) {
-		case *ast.SelectorExpr:
-			if _, ok := fun.X.(*ast.Ident); ok {
-				obj := pkg.TypesInfo.Uses[fun.Sel]
-				if obj != nil {
-					if fn, ok := obj.(*gotypes.Func); ok {
-						// Only functions from other packages
-						if fn.Pkg() != nil && fn.Pkg().Path() != pkg.PkgPath {
-							fqName := fn.String()
-							found := false
-							for _, pkg2 := range projectPkgs {
-								for _, fileAst := range pkg2.Syntax {
-									ast.Inspect(fileAst, func(n ast.Node) bool {
-										funcDecl, ok := n.(*ast.FuncDecl)
-										if !ok || funcDecl.Recv != nil {
-											return true
-										}
-										obj2 := pkg2.TypesInfo.Defs[funcDecl.Name]
-										if obj2 == nil {
-											return true
-										}
-										if fn2, ok := obj2.(*gotypes.Func); ok && fn2.String() == fqName {
-											params := []string{}
-											if funcDecl.Type.Params != nil {
-												for _, field := range funcDecl.Type.Params.List {
-													typeStr := pkg2.TypesInfo.TypeOf(field.Type).String()
-													for _, name := range field.Names {
-														params = append(params, name.Name+" "+typeStr)
-													}
-													if len(field.Names) == 0 {
-														params = append(params, typeStr)
-													}
-												}
-											}
-											returns := []string{}
-											if funcDecl.Type.Results != nil {
-												for _, field := range funcDecl.Type.Results.List {
-													typeStr := pkg2.TypesInfo.TypeOf(field.Type).String()
-													for range field.Names {
-														returns = append(returns, typeStr)
-													}
-													if len(field.Names) == 0 {
-														returns = append(returns, typeStr)
-													}
-												}
-											}
-											comment := ""
-											if funcDecl.Doc != nil {
-												comment = strings.TrimSpace(funcDecl.Doc.Text())
-											}
-											usedImportedFunctions = append(usedImportedFunctions, &ourtypes.FunctionInfo{
-												Name:    fn2.Pkg().Path() + "." + fn2.Name(),
-												Comment: comment,
-												Params:  params,
-												Returns: returns,
-											})
-											found = true
-											return false
-										}
-										return true
-									})
-									if found {
-										break
-									}
-								}
-								if found {
-									break
-								}
-							}
-						}
-					}
-				}
-			}
+		if _, ok := sel.X.(*ast.Ident); !ok {
+			return true
+		}
+		// Only package-level functions from other packages, not methods such as db.Close
+		fn, ok := pkg.TypesInfo.Uses[sel.Sel].(*gotypes.Func)
+		if !ok || fn.Pkg() == nil || fn.Pkg().Path() == pkg.PkgPath || fn.Type().(*gotypes.Signature).Recv() != nil {
+			return true
+		}
+		name := qualifiedName(fn.Origin())
+		fnInfo, ok := index.functions[name]
+		if !ok {
+			return true
+		}
+		if !seen[name] {
+			seen[name] = true
+			usedImportedFunctions = append(usedImportedFunctions, fnInfo)
+			names = append(names, name)
+		}
+		if call := calls[sel]; call != nil && sites[name] == "" {
+			sites[name] = callSite(call, pkg)
 		}
 		return true
 	})
+
+	// Index entries are shared between files, the call sites go to copies
+	for i, fnInfo := range usedImportedFunctions {
+		if site := sites[names[i]]; site != "" {
+			c := *fnInfo
+			c.CallSite = site
+			usedImportedFunctions[i] = &c
+		}
+	}
 	return usedImportedFunctions
 }
 
-// extractFunctionInfo extracts detailed information about a function.
+// maxCallSiteLength is the length above which calls are not used as call sites
+const maxCallSiteLength = 120
+
+// calledSelector returns the selector naming the function call calls, e.g. store.Open in store.Open(cfg) or
+// store.Get[int](key), or nil if the called function is not a selector.
+func calledSelector(call *ast.CallExpr) *ast.SelectorExpr {
+	fun := ast.Unparen(call.Fun)
+	switch f := fun.(type) {
+	case *ast.IndexExpr:
+		fun = f.X
+	case *ast.IndexListExpr:
+		fun = f.X
+	}
+	sel, _ := fun.(*ast.SelectorExpr)
+	return sel
+}
+
+// callSite returns the source of call as written, e.g. store.Open(ctx, cfg), or "" if it does not fit on a line
+// of maxCallSiteLength characters, e.g. because it passes a function literal.
+func callSite(call *ast.CallExpr, pkg *packages.Package) string {
+	site := printExpr(call, pkg)
+	if site == "" || strings.Contains(site, "\n") || len(site) > maxCallSiteLength {
+		return ""
+	}
+	return site
+}
+
+// extractFunctionInfo extracts detailed information about a function or method.
 func (p *ProjectParser) extractFunctionInfo(funcDecl *ast.FuncDecl, pkg *packages.Package) *ourtypes.FunctionInfo {
 	fnInfo := ourtypes.NewFunctionInfo()
 	fnInfo.Name = funcDecl.Name.Name
 	fnInfo.Comment = ""
+	fnInfo.Pos = p.position(funcDecl.Name.Pos())
+	if fn, ok := pkg.TypesInfo.Defs[funcDecl.Name].(*gotypes.Func); ok {
+		fnInfo.ID = symbolID(fn)
+		sig := fn.Type().(*gotypes.Signature)
+		fnInfo.TypeParams = typeParamsList(sig.TypeParams())
+		// Receiver type relative to its package, e.g. "*MyStruct"
+		if recv := sig.Recv(); recv != nil {
+			fnInfo.Receiver = gotypes.TypeString(recv.Type(), gotypes.RelativeTo(pkg.Types))
+		}
+		fnInfo.ParamInterfaces = tupleInterfaces(sig.Params())
+		fnInfo.ReturnInterfaces = tupleInterfaces(sig.Results())
+	}
 	// Extract comment
 	if funcDecl.Doc != nil {
 		fnInfo.Comment = strings.TrimSpace(funcDecl.Doc.Text())
@@ -327,7 +625,7 @@ func (p *ProjectParser) extractFunctionInfo(funcDecl *ast.FuncDecl, pkg *package
 	// Extract parameters
 	if funcDecl.Type.Params != nil {
 		for _, field := range funcDecl.Type.Params.List {
-			typeStr := pkg.TypesInfo.TypeOf(field.Type).String()
+			typeStr := typeExprString(field.Type, pkg.TypesInfo)
 			for _, name := range field.Names {
 				fnInfo.Params = append(fnInfo.Params, name.Name+" "+typeStr)
 			}
@@ -340,10 +638,10 @@ func (p *ProjectParser) extractFunctionInfo(funcDecl *ast.FuncDecl, pkg *package
 	// Extract return types
 	if funcDecl.Type.Results != nil {
 		for _, field := range funcDecl.Type.Results.List {
-			typeStr := pkg.TypesInfo.TypeOf(field.Type).String()
+			typeStr := typeExprString(field.Type, pkg.TypesInfo)
 			// Named return values
-			for range field.Names {
-				fnInfo.Returns = append(fnInfo.Returns, typeStr)
+			for _, name := range field.Names {
+				fnInfo.Returns = append(fnInfo.Returns, name.Name+" "+typeStr)
 			}
 			// Anonymous return value
 			if len(field.Names) == 0 {
@@ -351,13 +649,123 @@ func (p *ProjectParser) extractFunctionInfo(funcDecl *ast.FuncDecl, pkg *package
 			}
 		}
 	}
+	if p.includeBodies {
+		fnInfo.Body = printFuncDecl(funcDecl, pkg)
+	}
+	if p.includeExamples {
+		fnInfo.Examples = p.packageExamples(pkg)[exampleKey(funcDecl, pkg)]
+	}
 	return fnInfo
 }
 
+// printFuncDecl returns the source of a function declaration, including the comments inside it but not its doc comment.
+func printFuncDecl(funcDecl *ast.FuncDecl, pkg *packages.Package) string {
+	decl := *funcDecl
+	decl.Doc = nil
+
+	var node any = &decl
+	for _, file := range pkg.Syntax {
+		if file.Pos() <= funcDecl.Pos() && funcDecl.End() <= file.End() {
+			node = &printer.CommentedNode{Node: &decl, Comments: file.Comments}
+			break
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := printer.Fprint(&buf, pkg.Fset, node); err != nil {
+		return ""
+	}
+	return buf.String()
+}
+
+// position resolves pos against the parser's file set, returning nil for unknown positions.
+func (p *ProjectParser) position(pos token.Pos) *ourtypes.Position {
+	if !pos.IsValid() {
+		return nil
+	}
+	position := p.fset.Position(pos)
+	return &ourtypes.Position{File: position.Filename, Line: position.Line, Column: position.Column}
+}
+
+// namedTypeName returns the fully qualified name of a named type without type parameters or arguments.
+func namedTypeName(namedType *gotypes.Named) string {
+	obj := namedType.Origin().Obj()
+	if obj.Pkg() == nil {
+		return obj.Name()
+	}
+	return obj.Pkg().Path() + "." + obj.Name()
+}
+
+// fieldTypeRef returns the fully qualified name of the named struct type held by a field of type t, looking
+// through pointers, slices, arrays, map values and channels, or "" if it holds no named struct.
+func fieldTypeRef(t gotypes.Type) string {
+	named, ok := heldType(t).(*gotypes.Named)
+	if !ok {
+		return ""
+	}
+	if _, ok := named.Underlying().(*gotypes.Struct); !ok {
+		return ""
+	}
+	return namedTypeName(named)
+}
+
+// tupleInterfaces returns the fully qualified names of the named interfaces held by the parameters or
+// results of a signature, without duplicates. The error interface is left out, since most functions return it.
+func tupleInterfaces(tuple *gotypes.Tuple) []string {
+	names := make([]string, 0)
+	for i := 0; i < tuple.Len(); i++ {
+		named, ok := heldType(tuple.At(i).Type()).(*gotypes.Named)
+		if !ok || named.Obj().Pkg() == nil {
+			continue
+		}
+		if _, ok := named.Underlying().(*gotypes.Interface); !ok {
+			continue
+		}
+		if name := namedTypeName(named); !slices.Contains(names, name) {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// heldType returns the type held by a value of type t, looking through pointers, slices, arrays, map values
+// and channels.
+func heldType(t gotypes.Type) gotypes.Type {
+	for {
+		switch u := gotypes.Unalias(t).(type) {
+		case *gotypes.Pointer:
+			t = u.Elem()
+		case *gotypes.Slice:
+			t = u.Elem()
+		case *gotypes.Array:
+			t = u.Elem()
+		case *gotypes.Map:
+			t = u.Elem()
+		case *gotypes.Chan:
+			t = u.Elem()
+		default:
+			return u
+		}
+	}
+}
+
+// typeParamsList renders type parameters with their constraints, e.g. "T comparable".
+func typeParamsList(tparams *gotypes.TypeParamList) []string {
+	result := make([]string, 0, tparams.Len())
+	for i := 0; i < tparams.Len(); i++ {
+		tp := tparams.At(i)
+		result = append(result, tp.Obj().Name()+" "+tp.Constraint().String())
+	}
+	return result
+}
+
 // extractDetailedStructInfo extracts comprehensive details about a struct
 func (p *ProjectParser) extractDetailedStructInfo(obj gotypes.Object, namedType *gotypes.Named, structType *gotypes.Struct, pkg *packages.Package, targetFile *ast.File) *ourtypes.StructInfo {
 	structInfo := ourtypes.NewStructInfo()
-	structInfo.Name = namedType.String() // Use the fully qualified name
+	structInfo.Name = namedTypeName(namedType) // Use the fully qualified name
+	structInfo.ID = symbolID(obj)
+	structInfo.TypeParams = typeParamsList(namedType.TypeParams())
+	structInfo.Pos = p.position(obj.Pos())
 
 	// Extract struct comment (requires traversing AST nodes directly within the target file)
 	structComment := ""
@@ -387,6 +795,8 @@ func (p *ProjectParser) extractDetailedStructInfo(obj gotypes.Object, namedType
 		field := ourtypes.NewStructField()
 		field.Name = fieldName
 		field.Type = fieldTypeName
+		field.TypeRef = fieldTypeRef(fieldVar.Type())
+		field.Tag = structType.Tag(i)
 		structInfo.Fields = append(structInfo.Fields, field)
 	}
 
@@ -395,19 +805,9 @@ func (p *ProjectParser) extractDetailedStructInfo(obj gotypes.Object, namedType
 		methodObj := namedType.Method(i)
 		sig := methodObj.Type().(*gotypes.Signature)
 
-		params := []string{}
-		if sig.Params() != nil {
-			for j := 0; j < sig.Params().Len(); j++ {
-				params = append(params, sig.Params().At(j).Type().String())
-			}
-		}
+		params := namedParams(sig)
 
-		results := []string{}
-		if sig.Results() != nil {
-			for j := 0; j < sig.Results().Len(); j++ {
-				results = append(results, sig.Results().At(j).Type().String())
-			}
-		}
+		results := namedResults(sig)
 
 		// Method comments also require mapping back to AST if not available directly from types.Object
 		methodComment := ""
@@ -427,16 +827,208 @@ func (p *ProjectParser) extractDetailedStructInfo(obj gotypes.Object, namedType
 		method.Comment = methodComment
 		method.Parameters = params
 		method.ReturnTypes = results
+		method.ReceiverIsPointer = isPointerReceiver(sig)
 		structInfo.Methods = append(structInfo.Methods, method)
 	}
 
+	if p.includePromoted {
+		structInfo.Fields = append(structInfo.Fields, p.extractPromotedFields(structType, pkg)...)
+		structInfo.Methods = append(structInfo.Methods, p.extractPromotedMethods(namedType, pkg)...)
+	}
+	if p.includeExamples {
+		structInfo.Examples = p.packageExamples(pkg)[obj.Name()]
+	}
+	mapORMTable(structInfo, namedType, structType, pkg)
+
 	return structInfo
 }
 
+// namedParams returns the parameters of a signature as "name type", like the parameters of functions,
+// or just the type for unnamed parameters.
+func namedParams(sig *gotypes.Signature) []string {
+	params := sig.Params()
+	result := make([]string, 0, params.Len())
+	for i := 0; i < params.Len(); i++ {
+		param := params.At(i)
+		if param.Name() == "" {
+			result = append(result, paramTypeString(sig, i))
+		} else {
+			result = append(result, param.Name()+" "+paramTypeString(sig, i))
+		}
+	}
+	return result
+}
+
+// namedResults returns the results of a signature like namedParams does for its parameters, e.g. "n int"
+// for a named result.
+func namedResults(sig *gotypes.Signature) []string {
+	results := sig.Results()
+	result := make([]string, 0, results.Len())
+	for i := 0; i < results.Len(); i++ {
+		if name := results.At(i).Name(); name != "" {
+			result = append(result, name+" "+results.At(i).Type().String())
+		} else {
+			result = append(result, results.At(i).Type().String())
+		}
+	}
+	return result
+}
+
+// paramTypeString renders the type of the i-th parameter of sig, as ...T for a variadic parameter of type []T.
+func paramTypeString(sig *gotypes.Signature, i int) string {
+	t := sig.Params().At(i).Type()
+	if sig.Variadic() && i == sig.Params().Len()-1 {
+		if slice, ok := t.(*gotypes.Slice); ok {
+			return "..." + slice.Elem().String()
+		}
+	}
+	return t.String()
+}
+
+// typeExprString renders a type expression in canonical Go syntax, from its type if type-checking resolved it
+// and from the source otherwise, e.g. for types of unresolved imports. Variadic parameters keep their ... prefix.
+func typeExprString(expr ast.Expr, info *gotypes.Info) string {
+	if ellipsis, ok := expr.(*ast.Ellipsis); ok {
+		return "..." + typeExprString(ellipsis.Elt, info)
+	}
+	if t := info.TypeOf(expr); t != nil {
+		if basic, ok := t.(*gotypes.Basic); !ok || basic.Kind() != gotypes.Invalid {
+			return t.String()
+		}
+	}
+	return gotypes.ExprString(expr)
+}
+
+// isPointerReceiver reports whether the method signature has a pointer receiver.
+func isPointerReceiver(sig *gotypes.Signature) bool {
+	if sig.Recv() == nil {
+		return false
+	}
+	_, ok := sig.Recv().Type().(*gotypes.Pointer)
+	return ok
+}
+
+// extractPromotedFields returns the fields promoted from embedded structs, applying Go's shadowing rules:
+// a field at a shallower depth hides deeper ones and fields with the same name at the same depth cancel out.
+func (p *ProjectParser) extractPromotedFields(structType *gotypes.Struct, pkg *packages.Package) []*ourtypes.StructField {
+	type embeddedStruct struct {
+		structType *gotypes.Struct
+		origin     string // Fully qualified name of the embedded type
+	}
+
+	seen := make(map[string]bool)
+	visited := make(map[*gotypes.Named]bool)
+	embeddedOf := func(t gotypes.Type) *embeddedStruct {
+		if ptr, ok := t.(*gotypes.Pointer); ok {
+			t = ptr.Elem()
+		}
+		namedType, ok := t.(*gotypes.Named)
+		if !ok || visited[namedType] {
+			return nil
+		}
+		visited[namedType] = true
+		if s, ok := namedType.Underlying().(*gotypes.Struct); ok {
+			return &embeddedStruct{structType: s, origin: namedTypeName(namedType)}
+		}
+		return nil
+	}
+
+	var current []*embeddedStruct
+	for i := 0; i < structType.NumFields(); i++ {
+		field := structType.Field(i)
+		seen[field.Name()] = true
+		if field.Embedded() {
+			if e := embeddedOf(field.Type()); e != nil {
+				current = append(current, e)
+			}
+		}
+	}
+
+	var promoted []*ourtypes.StructField
+	for len(current) > 0 {
+		counts := make(map[string]int)
+		var candidates []*ourtypes.StructField
+		var next []*embeddedStruct
+
+		for _, e := range current {
+			for i := 0; i < e.structType.NumFields(); i++ {
+				fieldVar := e.structType.Field(i)
+				if seen[fieldVar.Name()] || (!fieldVar.Exported() && fieldVar.Pkg() != pkg.Types) {
+					continue
+				}
+				counts[fieldVar.Name()]++
+				field := ourtypes.NewStructField()
+				field.Name = fieldVar.Name()
+				field.Type = fieldVar.Type().String()
+				field.TypeRef = fieldTypeRef(fieldVar.Type())
+				field.Tag = e.structType.Tag(i)
+				field.PromotedFrom = e.origin
+				candidates = append(candidates, field)
+				if fieldVar.Embedded() {
+					if nested := embeddedOf(fieldVar.Type()); nested != nil {
+						next = append(next, nested)
+					}
+				}
+			}
+		}
+
+		for _, field := range candidates {
+			if counts[field.Name] == 1 {
+				promoted = append(promoted, field)
+			}
+		}
+		for name := range counts {
+			seen[name] = true
+		}
+		current = next
+	}
+
+	return promoted
+}
+
+// extractPromotedMethods returns the methods promoted from embedded types into the method set of *namedType.
+func (p *ProjectParser) extractPromotedMethods(namedType *gotypes.Named, pkg *packages.Package) []*ourtypes.StructMethod {
+	var promoted []*ourtypes.StructMethod
+
+	methodSet := gotypes.NewMethodSet(gotypes.NewPointer(namedType))
+	for i := 0; i < methodSet.Len(); i++ {
+		sel := methodSet.At(i)
+		if len(sel.Index()) <= 1 {
+			continue // Declared directly on the type
+		}
+		methodObj, ok := sel.Obj().(*gotypes.Func)
+		if !ok || (!methodObj.Exported() && methodObj.Pkg() != pkg.Types) {
+			continue
+		}
+		sig := methodObj.Type().(*gotypes.Signature)
+
+		method := ourtypes.NewStructMethod()
+		method.Name = methodObj.Name()
+		method.Parameters = namedParams(sig)
+		method.ReturnTypes = namedResults(sig)
+		method.ReceiverIsPointer = isPointerReceiver(sig)
+		recvType := sig.Recv().Type()
+		if ptr, ok := recvType.(*gotypes.Pointer); ok {
+			recvType = ptr.Elem()
+		}
+		if recvNamed, ok := recvType.(*gotypes.Named); ok {
+			method.PromotedFrom = namedTypeName(recvNamed)
+		} else {
+			method.PromotedFrom = recvType.String()
+		}
+		promoted = append(promoted, method)
+	}
+
+	return promoted
+}
+
 // extractDetailedInterfaceInfo extracts comprehensive details about an interface
 func (p *ProjectParser) extractDetailedInterfaceInfo(obj gotypes.Object, namedType *gotypes.Named, ifaceType *gotypes.Interface, pkg *packages.Package, targetFile *ast.File) *ourtypes.InterfaceInfo {
 	ifaceInfo := ourtypes.NewInterfaceInfo()
-	ifaceInfo.Name = namedType.String() // Use the fully qualified name
+	ifaceInfo.Name = namedTypeName(namedType) // Use the fully qualified name
+	ifaceInfo.ID = symbolID(obj)
+	ifaceInfo.TypeParams = typeParamsList(namedType.TypeParams())
+	ifaceInfo.Pos = p.position(obj.Pos())
 
 	// Extract interface comment (requires traversing AST nodes directly within the target file)
 	ifaceComment := ""
@@ -464,19 +1056,9 @@ func (p *ProjectParser) extractDetailedInterfaceInfo(obj gotypes.Object, namedTy
 		methodObj := ifaceType.ExplicitMethod(i)
 		sig := methodObj.Type().(*gotypes.Signature)
 
-		params := []string{}
-		if sig.Params() != nil {
-			for j := 0; j < sig.Params().Len(); j++ {
-				params = append(params, sig.Params().At(j).Type().String())
-			}
-		}
+		params := namedParams(sig)
 
-		results := []string{}
-		if sig.Results() != nil {
-			for j := 0; j < sig.Results().Len(); j++ {
-				results = append(results, sig.Results().At(j).Type().String())
-			}
-		}
+		results := namedResults(sig)
 
 		// Method comments also require mapping back to AST if not available directly from types.Object
 		methodComment := ""
@@ -505,13 +1087,42 @@ func (p *ProjectParser) extractDetailedInterfaceInfo(obj gotypes.Object, namedTy
 		emb := ifaceType.EmbeddedType(i)
 		ifaceInfo.Embeddeds = append(ifaceInfo.Embeddeds, emb.String())
 	}
+	if p.includeExamples {
+		ifaceInfo.Examples = p.packageExamples(pkg)[obj.Name()]
+	}
 
 	return ifaceInfo
 }
 
-// extractUsedImportedStructInfoFromFile extracts names of structs imported from other packages and used in the current file.
-func (p *ProjectParser) extractUsedImportedStructInfoFromFile(file *ast.File, pkg *packages.Package) []*ourtypes.StructInfo {
+// extractUsedImportedStructInfoFromFile extracts names of types imported from other packages and used in the current file,
+// including the receivers of the method values it uses, see methodValueReceivers.
+// Interfaces are returned separately from structs and other named types. With a transitive depth set, the types
+// referenced by used project types are included as well, see WithTransitiveDepth.
+func (p *ProjectParser) extractUsedImportedStructInfoFromFile(file *ast.File, pkg *packages.Package, index *symbolIndex) ([]*ourtypes.StructInfo, []*ourtypes.InterfaceInfo) {
 	usedImportedStructs := make(map[string]*ourtypes.StructInfo)
+	usedImportedInterfaces := make(map[string]*ourtypes.InterfaceInfo)
+	var recorded []*gotypes.Named // In the order they were recorded
+
+	// recordType reports whether namedType was recorded for the first time
+	recordType := func(namedType *gotypes.Named) bool {
+		if namedType.Obj().Pkg() == nil || namedType.Obj().Pkg() == pkg.Types { // Check if it's from another package
+			return false
+		}
+		typeName := namedTypeName(namedType) // Full qualified name (e.g., "context.Context")
+		if _, ok := namedType.Underlying().(*gotypes.Interface); ok {
+			if _, exists := usedImportedInterfaces[typeName]; exists {
+				return false
+			}
+			usedImportedInterfaces[typeName] = &ourtypes.InterfaceInfo{Name: typeName, ID: symbolID(namedType.Obj())}
+		} else {
+			if _, exists := usedImportedStructs[typeName]; exists {
+				return false
+			}
+			usedImportedStructs[typeName] = &ourtypes.StructInfo{Name: typeName, ID: symbolID(namedType.Obj())}
+		}
+		recorded = append(recorded, namedType)
+		return true
+	}
 
 	ast.Inspect(file, func(n ast.Node) bool {
 		var typeExpr ast.Expr
@@ -544,12 +1155,7 @@ func (p *ProjectParser) extractUsedImportedStructInfoFromFile(file *ast.File, pk
 		case *ast.Ident: // Check for direct identifier usage that might refer to an imported type
 			if obj := pkg.TypesInfo.Uses[node]; obj != nil {
 				if namedType, ok := obj.Type().(*gotypes.Named); ok {
-					if namedType.Obj().Pkg() != nil && namedType.Obj().Pkg() != pkg.Types { // Check if it's from another package
-						structName := namedType.String() // Full qualified name (e.g., "context.Context")
-						if _, exists := usedImportedStructs[structName]; !exists {
-							usedImportedStructs[structName] = &ourtypes.StructInfo{Name: structName}
-						}
-					}
+					recordType(namedType)
 				}
 			}
 			return true
@@ -569,23 +1175,43 @@ func (p *ProjectParser) extractUsedImportedStructInfoFromFile(file *ast.File, pk
 		if selExpr, ok := typeExpr.(*ast.SelectorExpr); ok {
 			if obj := pkg.TypesInfo.Uses[selExpr.Sel]; obj != nil { // Check if the selector refers to a type
 				if namedType, ok := obj.Type().(*gotypes.Named); ok {
-					if namedType.Obj().Pkg() != nil && namedType.Obj().Pkg() != pkg.Types { // Check if it's from another package
-						structName := namedType.String() // Full qualified name (e.g., "context.Context")
-						if _, exists := usedImportedStructs[structName]; !exists {
-							usedImportedStructs[structName] = &ourtypes.StructInfo{Name: structName}
-						}
-					}
+					recordType(namedType)
 				}
 			}
 		}
 		return true
 	})
+	for _, namedType := range methodValueReceivers(file, pkg.TypesInfo) {
+		recordType(namedType)
+	}
 
-	result := make([]*ourtypes.StructInfo, 0, len(usedImportedStructs))
+	// Breadth-first walk over the types referenced by used project types, one level per iteration. Types
+	// recorded before are not visited again, so reference cycles end the walk.
+	frontier := recorded
+	for depth := 0; depth < p.transitiveDepth && len(frontier) > 0; depth++ {
+		var next []*gotypes.Named
+		for _, namedType := range frontier {
+			if !index.packages[namedType.Obj().Pkg().Path()] {
+				continue // Types declared outside the project are not expanded
+			}
+			for _, ref := range referencedTypeNames(namedType.Obj()) {
+				if refType, ok := ref.Type().(*gotypes.Named); ok && recordType(refType) {
+					next = append(next, refType)
+				}
+			}
+		}
+		frontier = next
+	}
+
+	structs := make([]*ourtypes.StructInfo, 0, len(usedImportedStructs))
 	for _, s := range usedImportedStructs {
-		result = append(result, s)
+		structs = append(structs, s)
 	}
-	return result
+	interfaces := make([]*ourtypes.InterfaceInfo, 0, len(usedImportedInterfaces))
+	for _, i := range usedImportedInterfaces {
+		interfaces = append(interfaces, i)
+	}
+	return structs, interfaces
 }
 
 // extractGlobalVarInfo extracts information about a global variable or constant.
@@ -602,24 +1228,23 @@ func (p *ProjectParser) extractGlobalVarInfo(obj gotypes.Object, genDecl *ast.Ge
 
 	if c, ok := obj.(*gotypes.Const); ok {
 		isConst = true
-		value = c.Val().String()
+		value = p.truncateValue(c.Val().String())
 	} else if _, ok := obj.(*gotypes.Var); ok {
-		// For vars, try to get the value from the AST if available
-		if specIndex < len(valSpec.Values) {
-			valueExpr := valSpec.Values[specIndex]
-
-			var buf bytes.Buffer
-			if err := printer.Fprint(&buf, pkg.Fset, valueExpr); err == nil {
-				value = buf.String()
-			}
+		// For vars, render the value from the AST if available
+		if len(valSpec.Values) == len(valSpec.Names) {
+			value = p.renderVarValue(valSpec.Values[specIndex], pkg)
+		} else if len(valSpec.Values) > 0 {
+			value = computedValue // Multiple values returned by a single call
 		}
 	}
 
 	varInfo := ourtypes.NewGlobalVarInfo()
 	varInfo.Name = obj.Name()
+	varInfo.ID = symbolID(obj)
 	varInfo.Comment = strings.TrimSpace(comment)
 	varInfo.Type = obj.Type().String()
 	varInfo.Value = value
 	varInfo.IsConst = isConst
+	varInfo.Pos = p.position(obj.Pos())
 	return varInfo
 }
@@ -1,15 +1,13 @@
 package parser
 
 import (
-	"fmt"
-	"os"
-	"os/exec"
 	"path/filepath"
 	"sort"
 	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	ourtypes "github.com/vlad/ast2llm-go/internal/types" // Alias ourtypes
 )
 
@@ -51,7 +49,7 @@ func main(){
 			expectedFileInfos: map[string]*ourtypes.FileInfo{
 				"/testproject/main.go": {
 					PackageName: "main",
-					Imports:     []string{"fmt"},
+					Imports:     []*ourtypes.ImportInfo{{Path: "fmt"}},
 					Functions:   []*ourtypes.FunctionInfo{{Name: "main"}},
 					Structs: []*ourtypes.StructInfo{
 						{
@@ -101,7 +99,7 @@ func ProcessData(d pkg1.Data) {
 			expectedFileInfos: map[string]*ourtypes.FileInfo{
 				"/testproject/pkg1/types.go": {
 					PackageName: "pkg1",
-					Imports:     []string{},
+					Imports:     []*ourtypes.ImportInfo{},
 					Functions:   []*ourtypes.FunctionInfo{},
 					Structs: []*ourtypes.StructInfo{
 						{
@@ -117,7 +115,7 @@ func ProcessData(d pkg1.Data) {
 				},
 				"/testproject/pkg2/consumer.go": {
 					PackageName: "pkg2",
-					Imports:     []string{"fmt", "example.com/testproject/pkg1"},
+					Imports:     []*ourtypes.ImportInfo{{Path: "fmt"}, {Path: "example.com/testproject/pkg1"}},
 					Functions:   []*ourtypes.FunctionInfo{{Name: "ProcessData"}},
 					Structs:     []*ourtypes.StructInfo{},
 					UsedImportedStructs: []*ourtypes.StructInfo{
@@ -134,7 +132,7 @@ func ProcessData(d pkg1.Data) {
 			expectedFileInfos: map[string]*ourtypes.FileInfo{
 				"/testproject/empty.go": {
 					PackageName:         "empty",
-					Imports:             []string{},
+					Imports:             []*ourtypes.ImportInfo{},
 					Functions:           []*ourtypes.FunctionInfo{},
 					Structs:             []*ourtypes.StructInfo{},
 					UsedImportedStructs: []*ourtypes.StructInfo{},
@@ -164,7 +162,7 @@ type Writer interface {
 			expectedFileInfos: map[string]*ourtypes.FileInfo{
 				"/testproject/main.go": {
 					PackageName: "main",
-					Imports:     []string{"io"},
+					Imports:     []*ourtypes.ImportInfo{{Path: "io"}},
 					Functions:   []*ourtypes.FunctionInfo{},
 					Structs: []*ourtypes.StructInfo{
 						{
@@ -178,7 +176,8 @@ type Writer interface {
 							Methods: []*ourtypes.StructMethod{},
 						},
 					},
-					UsedImportedStructs: []*ourtypes.StructInfo{
+					UsedImportedStructs: []*ourtypes.StructInfo{},
+					UsedImportedInterfaces: []*ourtypes.InterfaceInfo{
 						{Name: "io.Reader"},
 					},
 				},
@@ -205,7 +204,7 @@ type DerivedIface interface {
 			expectedFileInfos: map[string]*ourtypes.FileInfo{
 				"/testproject/iface.go": {
 					PackageName: "iface",
-					Imports:     []string{},
+					Imports:     []*ourtypes.ImportInfo{},
 					Functions:   []*ourtypes.FunctionInfo{},
 					Structs:     []*ourtypes.StructInfo{},
 					Interfaces: []*ourtypes.InterfaceInfo{
@@ -247,31 +246,7 @@ type DerivedIface interface {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 
-			tmpDir := t.TempDir()
-			projectPath := filepath.Join(tmpDir, "testproject") // Ensure it's a sub-directory
-			err := os.MkdirAll(projectPath, 0755)
-			assert.NoError(t, err)
-
-			// Create go.mod file with a proper module path
-			err = os.WriteFile(filepath.Join(projectPath, "go.mod"), []byte(fmt.Sprintf("module %s\ngo 1.21", "example.com/testproject")), 0644)
-			assert.NoError(t, err, "failed to write go.mod")
-
-			// Write project files
-			for filePath, content := range tt.projectFiles {
-				absPath := filepath.Join(projectPath, filePath)
-				err = os.MkdirAll(filepath.Dir(absPath), 0755)
-				assert.NoError(t, err)
-				err = os.WriteFile(absPath, []byte(content), 0644)
-				assert.NoError(t, err)
-			}
-
-			// Run go mod tidy to resolve dependencies
-			cmd := exec.Command("go", "mod", "tidy")
-			cmd.Dir = projectPath
-			cmd.Stderr = os.Stderr // Capture stderr for debugging
-			cmd.Stdout = os.Stdout // Capture stdout for debugging
-			err = cmd.Run()
-			assert.NoError(t, err, "go mod tidy failed for project: %s", projectPath)
+			projectPath := writeTestProject(t, tt.projectFiles)
 
 			fileInfos, err := p.ParseProject(projectPath)
 			if tt.wantErr {
@@ -292,9 +267,6 @@ type DerivedIface interface {
 
 				assert.Equal(t, expectedInfo.PackageName, actualInfo.PackageName, "Package name mismatch for %s", actualAbsolutePath)
 
-				// Sort imports and functions for consistent comparison
-				sort.Strings(expectedInfo.Imports)
-				sort.Strings(actualInfo.Imports)
 				assert.ElementsMatch(t, expectedInfo.Imports, actualInfo.Imports, "Imports mismatch for %s", actualAbsolutePath)
 
 				// Compare function names only for compatibility
@@ -366,7 +338,578 @@ type DerivedIface interface {
 				sort.Strings(expectedUsedStructNames)
 				sort.Strings(actualUsedStructNames)
 				assert.Equal(t, expectedUsedStructNames, actualUsedStructNames, "Used imported struct names mismatch for %s", actualAbsolutePath)
+
+				// Compare UsedImportedInterfaces (only name is populated)
+				expectedUsedIfaceNames := make([]string, 0, len(expectedInfo.UsedImportedInterfaces))
+				for _, i := range expectedInfo.UsedImportedInterfaces {
+					expectedUsedIfaceNames = append(expectedUsedIfaceNames, i.Name)
+				}
+
+				actualUsedIfaceNames := make([]string, 0, len(actualInfo.UsedImportedInterfaces))
+				for _, i := range actualInfo.UsedImportedInterfaces {
+					actualUsedIfaceNames = append(actualUsedIfaceNames, i.Name)
+				}
+
+				sort.Strings(expectedUsedIfaceNames)
+				sort.Strings(actualUsedIfaceNames)
+				assert.Equal(t, expectedUsedIfaceNames, actualUsedIfaceNames, "Used imported interface names mismatch for %s", actualAbsolutePath)
 			}
 		})
 	}
 }
+
+func TestProjectParser_ParseProject_Methods(t *testing.T) {
+	projectPath := writeTestProject(t, map[string]string{
+		"main.go": `package main
+
+// Counter counts.
+type Counter struct {
+	n int
+}
+
+// Inc increments the counter.
+func (c *Counter) Inc(by int) {
+	c.n += by
+}
+
+// Value returns the current value.
+func (c Counter) Value() int {
+	return c.n
+}
+
+func main() {}
+`,
+	})
+
+	p := New()
+	fileInfos, err := p.ParseProject(projectPath)
+	require.NoError(t, err)
+
+	info := fileInfos[filepath.Join(projectPath, "main.go")]
+	require.NotNil(t, info)
+
+	require.Len(t, info.Functions, 1)
+	assert.Equal(t, "main", info.Functions[0].Name)
+
+	require.Len(t, info.Methods, 2)
+	assert.Equal(t, "Inc", info.Methods[0].Name)
+	assert.Equal(t, "*Counter", info.Methods[0].Receiver)
+	assert.Equal(t, "Inc increments the counter.", info.Methods[0].Comment)
+	assert.Equal(t, []string{"by int"}, info.Methods[0].Params)
+	assert.Equal(t, "Value", info.Methods[1].Name)
+	assert.Equal(t, "Counter", info.Methods[1].Receiver)
+	assert.Equal(t, []string{"int"}, info.Methods[1].Returns)
+
+	require.Len(t, info.Structs, 1)
+	pointerReceivers := make(map[string]bool)
+	params := make(map[string][]string)
+	for _, m := range info.Structs[0].Methods {
+		pointerReceivers[m.Name] = m.ReceiverIsPointer
+		params[m.Name] = m.Parameters
+	}
+	assert.Equal(t, map[string]bool{"Inc": true, "Value": false}, pointerReceivers)
+	assert.Equal(t, []string{"by int"}, params["Inc"], "struct methods keep parameter names")
+	assert.Empty(t, params["Value"])
+}
+
+func TestProjectParser_ParseProject_FunctionBodies(t *testing.T) {
+	projectPath := writeTestProject(t, map[string]string{
+		"main.go": `package main
+
+// Add adds numbers.
+func Add(a, b int) int {
+	// sum them
+	return a + b
+}
+
+func main() {}
+`,
+	})
+	mainPath := filepath.Join(projectPath, "main.go")
+
+	fileInfos, err := New().ParseProject(projectPath)
+	require.NoError(t, err)
+	assert.Empty(t, fileInfos[mainPath].Functions[0].Body)
+
+	fileInfos, err = New(WithFunctionBodies()).ParseProject(projectPath)
+	require.NoError(t, err)
+	assert.Equal(t, "func Add(a, b int) int {\n\t// sum them\n\treturn a + b\n}", fileInfos[mainPath].Functions[0].Body)
+}
+
+func TestProjectParser_ParseProject_PromotedMembers(t *testing.T) {
+	projectPath := writeTestProject(t, map[string]string{
+		"main.go": `package main
+
+// Base has an ID.
+type Base struct {
+	ID   int
+	Name string
+}
+
+// Describe describes the value.
+func (b *Base) Describe() string { return "" }
+
+// Derived embeds Base.
+type Derived struct {
+	Base
+	Name string
+}
+
+func main() {}
+`,
+	})
+	mainPath := filepath.Join(projectPath, "main.go")
+
+	findStruct := func(info *ourtypes.FileInfo, name string) *ourtypes.StructInfo {
+		for _, s := range info.Structs {
+			if s.Name == name {
+				return s
+			}
+		}
+		return nil
+	}
+
+	t.Run("disabled by default", func(t *testing.T) {
+		fileInfos, err := New().ParseProject(projectPath)
+		require.NoError(t, err)
+		derived := findStruct(fileInfos[mainPath], "example.com/testproject.Derived")
+		require.NotNil(t, derived)
+		assert.Len(t, derived.Fields, 2)
+		assert.Empty(t, derived.Methods)
+	})
+
+	t.Run("enabled", func(t *testing.T) {
+		fileInfos, err := New(WithPromotedMembers()).ParseProject(projectPath)
+		require.NoError(t, err)
+		derived := findStruct(fileInfos[mainPath], "example.com/testproject.Derived")
+		require.NotNil(t, derived)
+
+		// Name is shadowed by Derived.Name, so only ID is promoted
+		require.Len(t, derived.Fields, 3)
+		assert.Equal(t, "ID", derived.Fields[2].Name)
+		assert.Equal(t, "example.com/testproject.Base", derived.Fields[2].PromotedFrom)
+
+		require.Len(t, derived.Methods, 1)
+		assert.Equal(t, "Describe", derived.Methods[0].Name)
+		assert.Equal(t, []string{"string"}, derived.Methods[0].ReturnTypes)
+		assert.Equal(t, "example.com/testproject.Base", derived.Methods[0].PromotedFrom)
+	})
+}
+
+func TestProjectParser_ParseProject_Generics(t *testing.T) {
+	projectPath := writeTestProject(t, map[string]string{
+		"box/box.go": `package box
+
+// Box holds a value.
+type Box[T comparable, U any] struct {
+	Key   T
+	Value U
+}
+
+// Getter returns values.
+type Getter[T any] interface {
+	Get() T
+}
+
+// Map transforms a slice.
+func Map[S ~[]E, E any, R any](s S, f func(E) R) []R {
+	return nil
+}
+`,
+		"main.go": `package main
+
+import "example.com/testproject/box"
+
+func main() {
+	_ = box.Box[string, int]{}
+}
+`,
+	})
+
+	p := New()
+	fileInfos, err := p.ParseProject(projectPath)
+	require.NoError(t, err)
+
+	boxInfo := fileInfos[filepath.Join(projectPath, "box", "box.go")]
+	require.NotNil(t, boxInfo)
+
+	require.Len(t, boxInfo.Structs, 1)
+	assert.Equal(t, "example.com/testproject/box.Box", boxInfo.Structs[0].Name)
+	assert.Equal(t, []string{"T comparable", "U any"}, boxInfo.Structs[0].TypeParams)
+	assert.Equal(t, "T", boxInfo.Structs[0].Fields[0].Type)
+
+	require.Len(t, boxInfo.Interfaces, 1)
+	assert.Equal(t, "example.com/testproject/box.Getter", boxInfo.Interfaces[0].Name)
+	assert.Equal(t, []string{"T any"}, boxInfo.Interfaces[0].TypeParams)
+
+	require.Len(t, boxInfo.Functions, 1)
+	assert.Equal(t, []string{"S ~[]E", "E any", "R any"}, boxInfo.Functions[0].TypeParams)
+
+	mainInfo := fileInfos[filepath.Join(projectPath, "main.go")]
+	require.NotNil(t, mainInfo)
+	require.Len(t, mainInfo.UsedImportedStructs, 1)
+	assert.Equal(t, "example.com/testproject/box.Box", mainInfo.UsedImportedStructs[0].Name)
+}
+
+func TestProjectParser_ParseProject_Tests(t *testing.T) {
+	projectPath := writeTestProject(t, map[string]string{
+		"calc/calc.go": `package calc
+
+// Add adds numbers.
+func Add(a, b int) int { return a + b }
+`,
+		"calc/calc_internal_test.go": `package calc
+
+import "testing"
+
+func TestAdd(t *testing.T) {
+	_ = Add(1, 2)
+}
+`,
+		"calc/calc_test.go": `package calc_test
+
+import (
+	"testing"
+
+	"example.com/testproject/calc"
+)
+
+func TestAddExternal(t *testing.T) {
+	_ = calc.Add(1, 2)
+}
+`,
+	})
+	calcPath := filepath.Join(projectPath, "calc", "calc.go")
+	internalTestPath := filepath.Join(projectPath, "calc", "calc_internal_test.go")
+	externalTestPath := filepath.Join(projectPath, "calc", "calc_test.go")
+
+	fileInfos, err := New().ParseProject(projectPath)
+	require.NoError(t, err)
+	assert.Len(t, fileInfos, 1)
+	assert.Contains(t, fileInfos, calcPath)
+
+	fileInfos, err = New(WithTests()).ParseProject(projectPath)
+	require.NoError(t, err)
+	assert.Len(t, fileInfos, 3)
+	require.Contains(t, fileInfos, calcPath)
+	require.Contains(t, fileInfos, internalTestPath)
+	require.Contains(t, fileInfos, externalTestPath)
+
+	assert.Equal(t, "Add", fileInfos[calcPath].Functions[0].Name)
+	assert.Equal(t, "TestAdd", fileInfos[internalTestPath].Functions[0].Name)
+	assert.Equal(t, "calc_test", fileInfos[externalTestPath].PackageName)
+	require.Len(t, fileInfos[externalTestPath].UsedImportedFunctions, 1)
+	assert.Equal(t, "example.com/testproject/calc.Add", fileInfos[externalTestPath].UsedImportedFunctions[0].Name)
+}
+
+func TestProjectParser_ParseProject_Positions(t *testing.T) {
+	projectPath := writeTestProject(t, map[string]string{
+		"main.go": `package main
+
+// Limit is a limit.
+const Limit = 1
+
+type Config struct{}
+
+type Runner interface {
+	Run()
+}
+
+func (c *Config) Run() {}
+
+func main() {}
+`,
+	})
+	mainPath := filepath.Join(projectPath, "main.go")
+
+	fileInfos, err := New().ParseProject(projectPath)
+	require.NoError(t, err)
+	info := fileInfos[mainPath]
+
+	assert.Equal(t, &ourtypes.Position{File: mainPath, Line: 4, Column: 7}, info.GlobalVars[0].Pos)
+	assert.Equal(t, &ourtypes.Position{File: mainPath, Line: 6, Column: 6}, info.Structs[0].Pos)
+	assert.Equal(t, &ourtypes.Position{File: mainPath, Line: 8, Column: 6}, info.Interfaces[0].Pos)
+	assert.Equal(t, &ourtypes.Position{File: mainPath, Line: 12, Column: 18}, info.Methods[0].Pos)
+	assert.Equal(t, &ourtypes.Position{File: mainPath, Line: 14, Column: 6}, info.Functions[0].Pos)
+}
+
+func TestProjectParser_ParseProject_PackageDoc(t *testing.T) {
+	projectPath := writeTestProject(t, map[string]string{
+		"store/a.go":   "// Package store is described in a.go.\npackage store\n",
+		"store/doc.go": "// Package store persists items.\npackage store\n",
+		"store/b.go":   "package store\n\nfunc Open() {}\n",
+		"util/z.go":    "// Package util is described in z.go.\npackage util\n",
+		"util/y.go":    "// Package util is described in y.go.\npackage util\n",
+		"main.go":      "package main\n\nfunc main() {}\n",
+	})
+
+	fileInfos, err := New().ParseProject(projectPath)
+	require.NoError(t, err)
+
+	assert.Equal(t, "Package store persists items.", fileInfos[filepath.Join(projectPath, "store", "b.go")].PackageDoc, "doc.go wins")
+	assert.Equal(t, "Package util is described in y.go.", fileInfos[filepath.Join(projectPath, "util", "z.go")].PackageDoc)
+	assert.Empty(t, fileInfos[filepath.Join(projectPath, "main.go")].PackageDoc)
+}
+
+func TestProjectParser_ParseProject_ImportAliases(t *testing.T) {
+	projectPath := writeTestProject(t, map[string]string{
+		"main.go": `package main
+
+import (
+	myctx "context"
+	_ "embed"
+	"fmt"
+	. "math"
+	` + "`strings`" + `
+)
+
+func main() { fmt.Println(myctx.Background(), Pi, strings.ToUpper("a")) }
+`,
+	})
+
+	fileInfos, err := New().ParseProject(projectPath)
+	require.NoError(t, err)
+	assert.Equal(t, []*ourtypes.ImportInfo{
+		{Path: "context", Alias: "myctx"},
+		{Path: "embed", IsBlank: true},
+		{Path: "fmt"},
+		{Path: "math", IsDot: true},
+		{Path: "strings"},
+	}, fileInfos[filepath.Join(projectPath, "main.go")].Imports)
+}
+
+func TestProjectParser_ParseProject_CallSites(t *testing.T) {
+	projectPath := writeTestProject(t, map[string]string{
+		"store/store.go": "package store\n\nfunc Open(name string, retries int) error { return nil }\n\nfunc Get[T any](key string) T { var v T; return v }\n\nfunc Watch(fn func()) {}\n",
+		"main.go": `package main
+
+import "example.com/testproject/store"
+
+var open = store.Open
+
+func main() {
+	store.Watch(func() {
+		_ = store.Get[int]("a")
+	})
+	_ = store.Open("db", 3)
+	_ = store.Open("other", 1)
+	_ = store.Get[int]("b")
+}
+`,
+	})
+
+	fileInfos, err := New().ParseProject(projectPath)
+	require.NoError(t, err)
+	sites := make(map[string]string)
+	for _, fn := range fileInfos[filepath.Join(projectPath, "main.go")].UsedImportedFunctions {
+		sites[fn.Name] = fn.CallSite
+	}
+	assert.Equal(t, map[string]string{
+		"example.com/testproject/store.Open":  `store.Open("db", 3)`,
+		"example.com/testproject/store.Get":   `store.Get[int]("a")`,
+		"example.com/testproject/store.Watch": "",
+	}, sites, "the first call fitting on a line is kept, whether or not the function is first used as a value")
+
+	for _, fn := range fileInfos[filepath.Join(projectPath, "store", "store.go")].Functions {
+		assert.Empty(t, fn.CallSite, "declarations are not changed")
+	}
+}
+
+func TestProjectParser_ParseProject_MethodsSharingFunctionNames(t *testing.T) {
+	projectPath := writeTestProject(t, map[string]string{
+		"store/store.go": "package store\n\ntype DB struct{}\n\nfunc Open() *DB { return &DB{} }\n\nfunc (db *DB) Close() error { return nil }\n\nfunc Close() error { return nil }\n",
+		"a/a.go":         "package a\n\nimport \"example.com/testproject/store\"\n\nfunc Run() {\n\tdb := store.Open()\n\t_ = db.Close()\n}\n",
+		"b/b.go":         "package b\n\nimport \"example.com/testproject/store\"\n\nfunc Run() {\n\tdb := store.Open()\n\t_ = db.Close()\n\t_ = store.Close()\n}\n",
+	})
+
+	fileInfos, err := New().ParseProject(projectPath)
+	require.NoError(t, err)
+	sites := func(file string) map[string]string {
+		m := make(map[string]string)
+		for _, fn := range fileInfos[filepath.Join(projectPath, file)].UsedImportedFunctions {
+			m[fn.Name] = fn.CallSite
+		}
+		return m
+	}
+	assert.Equal(t, map[string]string{"example.com/testproject/store.Open": "store.Open()"}, sites("a/a.go"), "a method call is not a use of the package function")
+	assert.Equal(t, map[string]string{
+		"example.com/testproject/store.Open":  "store.Open()",
+		"example.com/testproject/store.Close": "store.Close()",
+	}, sites("b/b.go"))
+}
+
+func TestProjectParser_ParseProject_BuildTags(t *testing.T) {
+	projectPath := writeTestProject(t, map[string]string{
+		"store/store.go":       "package store\n\nfunc Open() {}\n",
+		"store/integration.go": "//go:build integration\n\npackage store\n\nfunc Seed() {}\n",
+	})
+	integrationPath := filepath.Join(projectPath, "store", "integration.go")
+
+	fileInfos, err := New().ParseProject(projectPath)
+	require.NoError(t, err)
+	assert.NotContains(t, fileInfos, integrationPath)
+
+	fileInfos, err = New(WithBuildTags("integration"), WithGoCacheDir(t.TempDir())).ParseProject(projectPath)
+	require.NoError(t, err)
+	require.Contains(t, fileInfos, integrationPath)
+	assert.Equal(t, "Seed", fileInfos[integrationPath].Functions[0].Name)
+}
+
+func TestProjectParser_ParseProject_TransitiveDepth(t *testing.T) {
+	projectPath := writeTestProject(t, map[string]string{
+		"models/user.go": `package models
+
+import (
+	"time"
+
+	"example.com/testproject/geo"
+)
+
+type User struct {
+	Address *geo.Address
+	Created time.Time
+}
+`,
+		"geo/geo.go": `package geo
+
+type Address struct {
+	Location Point
+}
+
+type Point struct {
+	Near []*Address
+	Grid Grid
+}
+
+type Grid interface {
+	Cell(p Point) int
+}
+`,
+		"main.go": `package main
+
+import "example.com/testproject/models"
+
+var current models.User
+
+func main() {}
+`,
+	})
+	mainPath := filepath.Join(projectPath, "main.go")
+
+	usedTypes := func(opts ...Option) []string {
+		fileInfos, err := New(opts...).ParseProject(projectPath)
+		require.NoError(t, err)
+		var names []string
+		for _, s := range fileInfos[mainPath].UsedImportedStructs {
+			names = append(names, s.Name)
+		}
+		for _, i := range fileInfos[mainPath].UsedImportedInterfaces {
+			names = append(names, i.Name)
+		}
+		return names
+	}
+
+	assert.ElementsMatch(t, []string{"example.com/testproject/models.User"}, usedTypes())
+	assert.ElementsMatch(t, []string{
+		"example.com/testproject/models.User",
+		"example.com/testproject/geo.Address",
+		"time.Time",
+	}, usedTypes(WithTransitiveDepth(1)))
+	assert.ElementsMatch(t, []string{
+		"example.com/testproject/models.User",
+		"example.com/testproject/geo.Address",
+		"time.Time",
+		"example.com/testproject/geo.Point",
+	}, usedTypes(WithTransitiveDepth(2)), "types outside the project are not expanded")
+	assert.ElementsMatch(t, []string{
+		"example.com/testproject/models.User",
+		"example.com/testproject/geo.Address",
+		"time.Time",
+		"example.com/testproject/geo.Point",
+		"example.com/testproject/geo.Grid",
+	}, usedTypes(WithTransitiveDepth(10)), "reference cycles end the walk")
+}
+
+func TestProjectParser_ParseProject_FieldTypeRef(t *testing.T) {
+	projectPath := writeTestProject(t, map[string]string{
+		"models/user.go": `package models
+
+import "time"
+
+type User struct {
+	Name    string
+	Manager *User
+	Reports map[string][]*User
+	Created time.Time
+	Notify  func(User)
+}
+`,
+	})
+
+	fileInfos, err := New().ParseProject(projectPath)
+	require.NoError(t, err)
+	refs := make(map[string]string)
+	for _, f := range fileInfos[filepath.Join(projectPath, "models", "user.go")].Structs[0].Fields {
+		refs[f.Name] = f.TypeRef
+	}
+	assert.Equal(t, map[string]string{
+		"Name":    "",
+		"Manager": "example.com/testproject/models.User",
+		"Reports": "example.com/testproject/models.User",
+		"Created": "time.Time",
+		"Notify":  "",
+	}, refs)
+}
+
+func TestProjectParser_ParseProject_TypeSyntax(t *testing.T) {
+	projectPath := writeTestProject(t, map[string]string{
+		"main.go": `package main
+
+import "io"
+
+type Pipe struct {
+	In      chan<- int
+	Out     <-chan []string
+	OnWrite func(w io.Writer, args ...any) (int, error)
+	Done    map[string]func() <-chan struct{}
+}
+
+func (p *Pipe) Logf(format string, args ...any) (n int, err error) { return }
+
+type Logger interface {
+	Log(level int, args ...string) (ok bool)
+}
+
+func Printf(format string, args ...any) (n int, err error) { return }
+
+func main() {}
+`,
+	})
+	mainPath := filepath.Join(projectPath, "main.go")
+
+	fileInfos, err := New().ParseProject(projectPath)
+	require.NoError(t, err)
+	info := fileInfos[mainPath]
+
+	fieldTypes := make(map[string]string)
+	for _, f := range info.Structs[0].Fields {
+		fieldTypes[f.Name] = f.Type
+	}
+	assert.Equal(t, map[string]string{
+		"In":      "chan<- int",
+		"Out":     "<-chan []string",
+		"OnWrite": "func(w io.Writer, args ...any) (int, error)",
+		"Done":    "map[string]func() <-chan struct{}",
+	}, fieldTypes)
+
+	assert.Equal(t, []string{"format string", "args ...any"}, info.Structs[0].Methods[0].Parameters)
+	assert.Equal(t, []string{"format string", "args ...any"}, info.Methods[0].Params)
+	assert.Equal(t, []string{"level int", "args ...string"}, info.Interfaces[0].Methods[0].Parameters)
+
+	assert.Equal(t, []string{"format string", "args ...any"}, info.Functions[0].Params)
+
+	assert.Equal(t, []string{"n int", "err error"}, info.Structs[0].Methods[0].ReturnTypes, "named results keep their names")
+	assert.Equal(t, []string{"n int", "err error"}, info.Methods[0].Returns)
+	assert.Equal(t, []string{"ok bool"}, info.Interfaces[0].Methods[0].ReturnTypes)
+	assert.Equal(t, []string{"n int", "err error"}, info.Functions[0].Returns)
+}
@@ -0,0 +1,15 @@
+package parser
+
+import "golang.org/x/tools/go/packages"
+
+// releasePackage drops the syntax trees and type-checking results of pkg once its files are extracted, so that
+// parsing a large project holds them only for the packages still to extract instead of for the whole parse.
+// pkg.Types is kept since the objects of the packages importing pkg refer to it.
+func (p *ProjectParser) releasePackage(pkg *packages.Package) {
+	pkg.Syntax = nil
+	pkg.TypesInfo = nil
+
+	p.examplesMu.Lock()
+	defer p.examplesMu.Unlock()
+	delete(p.examples, pkg.ID)
+}
@@ -0,0 +1,38 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProjectParser_ReleasePackage(t *testing.T) {
+	projectPath := writeTestProject(t, map[string]string{
+		"greet/greet.go": "package greet\n\n// Hello greets.\nfunc Hello() string { return \"hello\" }\n",
+		"greet/example_test.go": "package greet_test\n\nimport \"example.com/testproject/greet\"\n\n" +
+			"func ExampleHello() {\n\tgreet.Hello()\n}\n",
+		"main.go": "package main\n\nimport \"example.com/testproject/greet\"\n\nfunc main() { greet.Hello() }\n",
+	})
+
+	p := New(WithExamples())
+	pkgs, err := p.loadPackages(projectPath, "./...")
+	require.NoError(t, err)
+	index := p.buildSymbolIndex(pkgs)
+	for _, pkg := range pkgs {
+		for _, file := range pkg.Syntax {
+			_, ok := p.extractFile(projectPath, file, pkg, p.packageDoc(pkg), index)
+			require.True(t, ok)
+		}
+		p.releasePackage(pkg)
+		assert.Nil(t, pkg.Syntax)
+		assert.Nil(t, pkg.TypesInfo)
+		assert.NotNil(t, pkg.Types, "types stay available to the importing packages")
+	}
+	assert.Empty(t, p.examples)
+
+	info, err := p.ParseProject(projectPath)
+	require.NoError(t, err)
+	assert.Len(t, info, 2)
+	assert.Empty(t, p.examples, "a parse keeps no package alive")
+}
@@ -0,0 +1,303 @@
+package parser
+
+import (
+	"go/ast"
+	gotypes "go/types"
+	"sort"
+	"strings"
+
+	ourtypes "github.com/vlad/ast2llm-go/internal/types"
+	"golang.org/x/tools/go/packages"
+)
+
+// routerFramework describes how an HTTP router registers its handlers
+type routerFramework struct {
+	name        string          // Name of the framework, e.g. "chi"
+	path        string          // Module path, major versions are matched as well, e.g. "github.com/go-chi/chi/v5"
+	verbs       bool            // Whether handlers are registered per method, e.g. r.Get("/users", list)
+	any         map[string]bool // Functions registering a path for every method, e.g. "HandleFunc" or "Any"
+	methodFirst map[string]bool // Functions taking the method before the path, e.g. "Handle" of gin
+	handlerArg  int             // Index of the handler after the path, or -1 for the last argument
+	group       string          // Method returning a router for a path prefix, e.g. "Group"
+	route       string          // Method calling a function with a router for a path prefix, e.g. "Route" of chi
+}
+
+// routerFrameworks are the HTTP routers whose routes are extracted
+var routerFrameworks = []*routerFramework{
+	{name: "net/http", path: "net/http", any: names("Handle", "HandleFunc"), handlerArg: -1},
+	{name: "gorilla/mux", path: "github.com/gorilla/mux", any: names("Handle", "HandleFunc"), handlerArg: -1},
+	{name: "chi", path: "github.com/go-chi/chi", verbs: true, any: names("Handle", "HandleFunc"), methodFirst: names("Method", "MethodFunc"), handlerArg: -1, route: "Route"},
+	{name: "gin", path: "github.com/gin-gonic/gin", verbs: true, any: names("Any"), methodFirst: names("Handle"), handlerArg: -1, group: "Group"},
+	{name: "echo", path: "github.com/labstack/echo", verbs: true, any: names("Any"), methodFirst: names("Add"), handlerArg: 0, group: "Group"},
+	{name: "fiber", path: "github.com/gofiber/fiber", verbs: true, any: names("All"), handlerArg: -1, group: "Group"},
+	{name: "httprouter", path: "github.com/julienschmidt/httprouter", verbs: true, methodFirst: names("Handle", "Handler", "HandlerFunc"), handlerArg: -1},
+}
+
+// httpMethods are the names of the per-method registration functions of HTTP routers, by upper-case method
+var httpMethods = map[string]string{
+	"Get": "GET", "Head": "HEAD", "Post": "POST", "Put": "PUT", "Patch": "PATCH", "Delete": "DELETE",
+	"Options": "OPTIONS", "Connect": "CONNECT", "Trace": "TRACE",
+	"GET": "GET", "HEAD": "HEAD", "POST": "POST", "PUT": "PUT", "PATCH": "PATCH", "DELETE": "DELETE",
+	"OPTIONS": "OPTIONS", "CONNECT": "CONNECT", "TRACE": "TRACE",
+}
+
+// names returns a set of names.
+func names(list ...string) map[string]bool {
+	set := make(map[string]bool, len(list))
+	for _, name := range list {
+		set[name] = true
+	}
+	return set
+}
+
+// Routes returns the HTTP routes of every file of the project, sorted by path, method and position.
+func Routes(info ProjectInfo) []*ourtypes.Route {
+	var routes []*ourtypes.Route
+	for _, fileInfo := range info {
+		routes = append(routes, fileInfo.Routes...)
+	}
+	sort.SliceStable(routes, func(i, j int) bool {
+		a, b := routes[i], routes[j]
+		if a.Path != b.Path {
+			return a.Path < b.Path
+		}
+		if a.Method != b.Method {
+			return a.Method < b.Method
+		}
+		return positionBefore(a.Pos, b.Pos)
+	})
+	return routes
+}
+
+// routeExtractor extracts the routes of a file, following the path prefixes of router groups
+type routeExtractor struct {
+	p        *ProjectParser
+	info     *gotypes.Info
+	prefixes map[gotypes.Object]string // Path prefix of router variables, e.g. of api in api := r.Group("/api")
+	methods  map[*ast.CallExpr]string  // Methods set by a gorilla/mux Methods call on a registration
+	routes   []*ourtypes.Route
+}
+
+// extractRoutes returns the HTTP routes registered in file with one of the routerFrameworks, in source order.
+// The path of a route includes the prefixes of the groups it is registered on, e.g. "/api/users" for
+// api.GET("/users", list) after api := r.Group("/api"). Paths must be constant strings.
+func (p *ProjectParser) extractRoutes(file *ast.File, pkg *packages.Package) []*ourtypes.Route {
+	e := &routeExtractor{
+		p:        p,
+		info:     pkg.TypesInfo,
+		prefixes: make(map[gotypes.Object]string),
+		methods:  make(map[*ast.CallExpr]string),
+		routes:   make([]*ourtypes.Route, 0),
+	}
+	ast.Inspect(file, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.AssignStmt:
+			if len(n.Lhs) == len(n.Rhs) {
+				for i, lhs := range n.Lhs {
+					e.assign(lhs, n.Rhs[i])
+				}
+			}
+		case *ast.ValueSpec:
+			if len(n.Names) == len(n.Values) {
+				for i, name := range n.Names {
+					e.assign(name, n.Values[i])
+				}
+			}
+		case *ast.CallExpr:
+			e.call(n)
+		}
+		return true
+	})
+	return e.routes
+}
+
+// assign records the path prefix of a variable assigned a router group.
+func (e *routeExtractor) assign(lhs, rhs ast.Expr) {
+	ident, ok := lhs.(*ast.Ident)
+	if !ok {
+		return
+	}
+	obj := e.info.Defs[ident]
+	if obj == nil {
+		obj = e.info.Uses[ident]
+	}
+	if prefix := e.prefix(rhs); obj != nil && prefix != "" {
+		e.prefixes[obj] = prefix
+	}
+}
+
+// call records the route registered by a call, if any.
+func (e *routeExtractor) call(call *ast.CallExpr) {
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return
+	}
+	name := sel.Sel.Name
+
+	// gorilla/mux sets methods on the route returned by the registration, e.g. r.HandleFunc(...).Methods("GET")
+	if name == "Methods" {
+		if inner, ok := ast.Unparen(sel.X).(*ast.CallExpr); ok {
+			var methods []string
+			for _, arg := range call.Args {
+				if method, ok := constantString(arg, e.info); ok {
+					methods = append(methods, strings.ToUpper(method))
+				}
+			}
+			e.methods[inner] = strings.Join(methods, ",")
+		}
+		return
+	}
+
+	framework := e.framework(sel.X)
+	if framework == nil {
+		return
+	}
+
+	if name == framework.route && len(call.Args) == 2 {
+		// The router passed to the function of chi's Route has the prefix of the route
+		path, ok := constantString(call.Args[0], e.info)
+		lit, isLit := ast.Unparen(call.Args[1]).(*ast.FuncLit)
+		if ok && isLit && len(lit.Type.Params.List) == 1 && len(lit.Type.Params.List[0].Names) == 1 {
+			if obj := e.info.Defs[lit.Type.Params.List[0].Names[0]]; obj != nil {
+				e.prefixes[obj] = joinRoute(e.prefix(sel.X), path)
+			}
+		}
+		return
+	}
+
+	args := call.Args
+	method := ""
+	switch {
+	case framework.methodFirst[name] && len(args) >= 3:
+		m, ok := constantString(args[0], e.info)
+		if !ok {
+			return
+		}
+		method, args = strings.ToUpper(m), args[1:]
+	case framework.verbs && httpMethods[name] != "":
+		method = httpMethods[name]
+	case framework.any[name]:
+	default:
+		return
+	}
+	if len(args) < 2 {
+		return
+	}
+	path, ok := constantString(args[0], e.info)
+	if !ok {
+		return
+	}
+	if framework.name == "net/http" || framework.name == "chi" {
+		// Go 1.22 patterns may start with a method, e.g. "GET /users/{id}"
+		if m, rest, found := strings.Cut(path, " "); found && method == "" && strings.ToUpper(m) == m && !strings.Contains(m, "/") {
+			method, path = m, strings.TrimSpace(rest)
+		}
+	}
+	if m := e.methods[call]; m != "" && method == "" {
+		method = m
+	}
+
+	handler := args[len(args)-1]
+	if framework.handlerArg >= 0 && framework.handlerArg+1 < len(args) {
+		handler = args[framework.handlerArg+1]
+	}
+	e.routes = append(e.routes, &ourtypes.Route{
+		Method:    method,
+		Path:      joinRoute(e.prefix(sel.X), path),
+		Handler:   handlerString(handler),
+		Framework: framework.name,
+		Pos:       e.p.position(call.Pos()),
+	})
+}
+
+// framework returns the router framework of the receiver of a registration, i.e. of the package it names or of
+// its type, or nil if it is not a router.
+func (e *routeExtractor) framework(expr ast.Expr) *routerFramework {
+	var path string
+	if ident, ok := ast.Unparen(expr).(*ast.Ident); ok {
+		if pkgName, ok := e.info.Uses[ident].(*gotypes.PkgName); ok {
+			path = pkgName.Imported().Path()
+		}
+	}
+	if tv, ok := e.info.Types[expr]; ok && path == "" {
+		t := tv.Type
+		if ptr, ok := t.(*gotypes.Pointer); ok {
+			t = ptr.Elem()
+		}
+		if named, ok := t.(*gotypes.Named); ok && named.Obj().Pkg() != nil {
+			path = named.Obj().Pkg().Path()
+		}
+	}
+	if path == "" {
+		return nil
+	}
+	for _, framework := range routerFrameworks {
+		if path == framework.path || isMajorVersion(strings.TrimPrefix(path, framework.path+"/")) {
+			return framework
+		}
+	}
+	return nil
+}
+
+// prefix returns the path prefix of a router expression: of a variable assigned a group, of a group created in
+// place, e.g. r.Group("/api"), or of the router of a call returning one, e.g. r.With(auth).
+func (e *routeExtractor) prefix(expr ast.Expr) string {
+	switch expr := ast.Unparen(expr).(type) {
+	case *ast.Ident:
+		return e.prefixes[e.info.Uses[expr]]
+	case *ast.StarExpr:
+		return e.prefix(expr.X)
+	case *ast.UnaryExpr:
+		return e.prefix(expr.X)
+	case *ast.CallExpr:
+		sel, ok := expr.Fun.(*ast.SelectorExpr)
+		if !ok {
+			return ""
+		}
+		base := e.prefix(sel.X)
+		if framework := e.framework(sel.X); framework != nil && sel.Sel.Name == framework.group && len(expr.Args) > 0 {
+			if path, ok := constantString(expr.Args[0], e.info); ok {
+				return joinRoute(base, path)
+			}
+		}
+		return base
+	}
+	return ""
+}
+
+// positionBefore reports whether a comes before b, ordering by file, line and column; unknown positions come last.
+func positionBefore(a, b *ourtypes.Position) bool {
+	switch {
+	case a == nil || b == nil:
+		return a != nil
+	case a.File != b.File:
+		return a.File < b.File
+	case a.Line != b.Line:
+		return a.Line < b.Line
+	}
+	return a.Column < b.Column
+}
+
+// isMajorVersion reports whether s is a major version suffix of a module path, e.g. "v5".
+func isMajorVersion(s string) bool {
+	if len(s) < 2 || s[0] != 'v' {
+		return false
+	}
+	for _, c := range s[1:] {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// joinRoute appends path to the path prefix of a group.
+func joinRoute(prefix, path string) string {
+	if prefix == "" {
+		return path
+	}
+	if path == "" {
+		return prefix
+	}
+	return strings.TrimSuffix(prefix, "/") + "/" + strings.TrimPrefix(path, "/")
+}
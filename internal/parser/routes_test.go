@@ -0,0 +1,173 @@
+package parser
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	ourtypes "github.com/vlad/ast2llm-go/internal/types"
+)
+
+func TestProjectParser_ParseProject_Routes(t *testing.T) {
+	projectPath := writeTestProject(t, map[string]string{
+		"go.mod": `module example.com/testproject
+
+go 1.21
+
+require (
+	github.com/gin-gonic/gin v1.0.0
+	github.com/go-chi/chi/v5 v5.0.0
+	github.com/gorilla/mux v1.0.0
+	github.com/labstack/echo/v4 v4.0.0
+)
+
+replace (
+	github.com/gin-gonic/gin => ./third_party/gin
+	github.com/go-chi/chi/v5 => ./third_party/chi
+	github.com/gorilla/mux => ./third_party/mux
+	github.com/labstack/echo/v4 => ./third_party/echo
+)
+`,
+		"third_party/chi/go.mod": "module github.com/go-chi/chi/v5\n\ngo 1.21\n",
+		"third_party/chi/chi.go": `package chi
+
+import "net/http"
+
+type Router interface {
+	Get(pattern string, h http.HandlerFunc)
+	Post(pattern string, h http.HandlerFunc)
+	Route(pattern string, fn func(r Router)) Router
+	With(middlewares ...func(http.Handler) http.Handler) Router
+	Method(method, pattern string, h http.Handler)
+}
+
+func NewRouter() Router { return nil }
+`,
+		"third_party/gin/go.mod": "module github.com/gin-gonic/gin\n\ngo 1.21\n",
+		"third_party/gin/gin.go": `package gin
+
+type Context struct{}
+
+type HandlerFunc func(*Context)
+
+type RouterGroup struct{}
+
+func (g *RouterGroup) Group(path string, handlers ...HandlerFunc) *RouterGroup { return g }
+func (g *RouterGroup) GET(path string, handlers ...HandlerFunc)                 {}
+func (g *RouterGroup) Handle(method, path string, handlers ...HandlerFunc)      {}
+
+type Engine struct{ RouterGroup }
+
+func Default() *Engine { return nil }
+`,
+		"third_party/echo/go.mod": "module github.com/labstack/echo/v4\n\ngo 1.21\n",
+		"third_party/echo/echo.go": `package echo
+
+type Context interface{}
+
+type HandlerFunc func(Context) error
+
+type MiddlewareFunc func(HandlerFunc) HandlerFunc
+
+type Echo struct{}
+
+func New() *Echo { return nil }
+
+func (e *Echo) POST(path string, h HandlerFunc, m ...MiddlewareFunc) {}
+func (e *Echo) Any(path string, h HandlerFunc, m ...MiddlewareFunc)  {}
+`,
+		"third_party/mux/go.mod": "module github.com/gorilla/mux\n\ngo 1.21\n",
+		"third_party/mux/mux.go": `package mux
+
+import "net/http"
+
+type Route struct{}
+
+func (r *Route) Methods(methods ...string) *Route { return r }
+
+type Router struct{}
+
+func NewRouter() *Router { return nil }
+
+func (r *Router) HandleFunc(path string, f func(http.ResponseWriter, *http.Request)) *Route { return nil }
+`,
+		"main.go": `package main
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-chi/chi/v5"
+	"github.com/gorilla/mux"
+	"github.com/labstack/echo/v4"
+)
+
+func listUsers(w http.ResponseWriter, r *http.Request) {}
+
+func getUser(c *gin.Context) {}
+
+func createOrder(c echo.Context) error { return nil }
+
+func logged(next echo.HandlerFunc) echo.HandlerFunc { return next }
+
+func auth(next http.Handler) http.Handler { return next }
+
+func main() {
+	http.HandleFunc("/health", func(w http.ResponseWriter, r *http.Request) {})
+	serveMux := http.NewServeMux()
+	serveMux.Handle("POST /users", http.HandlerFunc(listUsers))
+
+	r := chi.NewRouter()
+	r.Route("/api", func(r chi.Router) {
+		r.Get("/users", listUsers)
+		r.With(auth).Post("/users", listUsers)
+		r.Method("delete", "/users/{id}", http.HandlerFunc(listUsers))
+	})
+
+	engine := gin.Default()
+	v1 := engine.Group("/v1")
+	v1.GET("/users/:id", getUser)
+	engine.Group("/admin").Handle("PUT", "/users/:id", getUser)
+
+	e := echo.New()
+	e.POST("/orders", createOrder, logged)
+	e.Any("/ping", createOrder)
+
+	m := mux.NewRouter()
+	m.HandleFunc("/items", listUsers).Methods("GET", "head")
+
+	var pattern string
+	http.HandleFunc(pattern, listUsers)
+}
+`,
+	})
+
+	fileInfos, err := New().ParseProject(projectPath)
+	require.NoError(t, err)
+	info := fileInfos[filepath.Join(projectPath, "main.go")]
+
+	for _, r := range info.Routes {
+		require.NotNil(t, r.Pos, r.Path)
+		r.Pos = nil
+	}
+	assert.Equal(t, []*ourtypes.Route{
+		{Path: "/health", Handler: "func literal", Framework: "net/http"},
+		{Method: "POST", Path: "/users", Handler: "http.HandlerFunc(listUsers)", Framework: "net/http"},
+		{Method: "GET", Path: "/api/users", Handler: "listUsers", Framework: "chi"},
+		{Method: "POST", Path: "/api/users", Handler: "listUsers", Framework: "chi"},
+		{Method: "DELETE", Path: "/api/users/{id}", Handler: "http.HandlerFunc(listUsers)", Framework: "chi"},
+		{Method: "GET", Path: "/v1/users/:id", Handler: "getUser", Framework: "gin"},
+		{Method: "PUT", Path: "/admin/users/:id", Handler: "getUser", Framework: "gin"},
+		{Method: "POST", Path: "/orders", Handler: "createOrder", Framework: "echo"},
+		{Path: "/ping", Handler: "createOrder", Framework: "echo"},
+		{Method: "GET,HEAD", Path: "/items", Handler: "listUsers", Framework: "gorilla/mux"},
+	}, info.Routes)
+
+	assert.Equal(t, "/v1/users/:id", info.EntryPoints[6].Name, "routes are entry points too")
+
+	routes := Routes(fileInfos)
+	require.Len(t, routes, 10)
+	assert.Equal(t, []string{"/admin/users/:id", "/api/users", "/api/users"}, []string{routes[0].Path, routes[1].Path, routes[2].Path})
+	assert.Equal(t, "GET", routes[1].Method)
+}
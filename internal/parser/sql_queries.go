@@ -0,0 +1,242 @@
+package parser
+
+import (
+	"go/ast"
+	"go/token"
+	gotypes "go/types"
+	"regexp"
+	"strings"
+
+	ourtypes "github.com/vlad/ast2llm-go/internal/types"
+	"golang.org/x/tools/go/packages"
+)
+
+// sqlPackages are the database packages whose functions and methods take SQL queries, e.g. db.QueryContext
+var sqlPackages = []string{"database/sql", "github.com/jmoiron/sqlx", "github.com/jackc/pgx"}
+
+// squirrelPackage is the path of the squirrel query builder
+const squirrelPackage = "github.com/Masterminds/squirrel"
+
+var (
+	// sqlStatement matches the keywords starting an SQL statement and the clauses confirming it is one, e.g. a
+	// column list and FROM after SELECT, so that prose such as "Select a user from the list" is not taken for SQL
+	sqlStatement = regexp.MustCompile(`(?is)^(?:` +
+		`SELECT\s+(?:DISTINCT\s+)?[^\s,]+(?:\s*,\s*[^\s,]+)*\s+FROM\b|` +
+		`SELECT\b.*[*,=()?$].*\bFROM\b|SELECT\b.*\bFROM\b.*(?:[*=()?$]|\b(?:WHERE|JOIN|ORDER\s+BY|GROUP\s+BY|LIMIT)\b)|` +
+		`INSERT\s+INTO\s+\S+\s*(?:\(|VALUES\b|SELECT\b|DEFAULT\b)|` +
+		`UPDATE\s+\S+\s+SET\s+\S+\s*=|` +
+		`DELETE\s+FROM\s+\S+\s*(?:$|;|WHERE\b|USING\b|RETURNING\b)|` +
+		`WITH\b.*\bAS\s*\(.*\bSELECT\b|` +
+		`(?:CREATE|ALTER|DROP)\s+(?:OR\s+REPLACE\s+)?(?:UNIQUE\s+)?(?:TABLE|INDEX|VIEW|SCHEMA|SEQUENCE|TYPE|FUNCTION|TRIGGER)\s|` +
+		`TRUNCATE\s+(?:TABLE\s+)?\S+\s*(?:$|;|CASCADE\b|RESTART\b))`)
+	// sqlTables matches the table names following FROM, JOIN, INTO, UPDATE and TABLE
+	sqlTables = regexp.MustCompile("(?i)\\b(?:FROM|JOIN|INTO|UPDATE|TABLE(?:\\s+IF\\s+(?:NOT\\s+)?EXISTS)?|TRUNCATE)\\s+(?:ONLY\\s+)?([A-Za-z_][\\w.]*|\"[^\"]+\"|`[^`]+`)")
+	// sqlcName matches the comment sqlc puts before a query, e.g. "-- name: GetAuthor :one"
+	sqlcName = regexp.MustCompile(`^--\s*name:\s*(\w+)`)
+)
+
+// sqlKeywords are words following FROM or JOIN that are no table names
+var sqlKeywords = map[string]bool{"SELECT": true, "LATERAL": true, "UNNEST": true, "VALUES": true}
+
+// extractSQLQueries returns the SQL statements of file in source order: string constants and variables holding
+// SQL, recognized by their first keywords, with the names sqlc gives them, literal queries passed to
+// database/sql, sqlx and pgx, and statements built with squirrel.
+func (p *ProjectParser) extractSQLQueries(file *ast.File, pkg *packages.Package) []*ourtypes.SQLQuery {
+	queries := make([]*ourtypes.SQLQuery, 0)
+	inChain := make(map[*ast.CallExpr]bool)
+	ast.Inspect(file, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.ValueSpec:
+			if len(n.Values) == len(n.Names) {
+				for i, name := range n.Names {
+					queries = p.appendNamedQuery(queries, name, n.Values[i], pkg.TypesInfo)
+				}
+			}
+		case *ast.AssignStmt:
+			if len(n.Rhs) == len(n.Lhs) {
+				for i, lhs := range n.Lhs {
+					if name, ok := lhs.(*ast.Ident); ok {
+						queries = p.appendNamedQuery(queries, name, n.Rhs[i], pkg.TypesInfo)
+					}
+				}
+			}
+		case *ast.CallExpr:
+			if inChain[n] {
+				break
+			}
+			if q := squirrelQuery(n, pkg.TypesInfo, inChain); q != nil {
+				q.Pos = p.position(n.Pos())
+				queries = append(queries, q)
+			} else if q := callQuery(n, pkg.TypesInfo); q != nil {
+				q.Pos = p.position(n.Pos())
+				queries = append(queries, q)
+			}
+		}
+		return true
+	})
+	return queries
+}
+
+// appendNamedQuery appends the query of a constant or variable name assigned value if value is a constant SQL
+// statement.
+func (p *ProjectParser) appendNamedQuery(queries []*ourtypes.SQLQuery, name *ast.Ident, value ast.Expr, info *gotypes.Info) []*ourtypes.SQLQuery {
+	text, ok := constantString(value, info)
+	if !ok {
+		return queries
+	}
+	q := newSQLQuery(text)
+	if q == nil {
+		return queries
+	}
+	if q.Name == "" {
+		q.Name, q.Source = name.Name, "constant"
+	}
+	q.Pos = p.position(name.Pos())
+	return append(queries, q)
+}
+
+// newSQLQuery parses text as an SQL statement, or returns nil if it is none. Queries annotated for sqlc get
+// their name and the "sqlc" source, others have no source yet.
+func newSQLQuery(text string) *ourtypes.SQLQuery {
+	q := &ourtypes.SQLQuery{}
+	// Skip leading comments, taking the name of sqlc queries
+	text = strings.TrimSpace(text)
+	for strings.HasPrefix(text, "--") {
+		line, rest, _ := strings.Cut(text, "\n")
+		if m := sqlcName.FindStringSubmatch(line); m != nil {
+			q.Name, q.Source = m[1], "sqlc"
+		}
+		text = strings.TrimSpace(rest)
+	}
+	text = strings.Join(strings.Fields(text), " ")
+	if !sqlStatement.MatchString(text) {
+		return nil
+	}
+
+	keyword, _, _ := strings.Cut(text, " ")
+	q.Statement = strings.ToUpper(keyword)
+	q.Query = text
+	q.Tables = sqlTableNames(text)
+	return q
+}
+
+// sqlTableNames returns the tables a statement refers to, without quotes and duplicates.
+func sqlTableNames(query string) []string {
+	var tables []string
+	seen := make(map[string]bool)
+	for _, m := range sqlTables.FindAllStringSubmatch(query, -1) {
+		table := strings.Trim(m[1], "\"`")
+		key := strings.ToLower(table)
+		if sqlKeywords[strings.ToUpper(table)] || seen[key] {
+			continue
+		}
+		seen[key] = true
+		tables = append(tables, table)
+	}
+	return tables
+}
+
+// callQuery recognizes a literal SQL query passed to a function or method of the sqlPackages. Queries passed
+// by name are left out, since their constant is listed already.
+func callQuery(call *ast.CallExpr, info *gotypes.Info) *ourtypes.SQLQuery {
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return nil
+	}
+	fn, ok := info.Uses[sel.Sel].(*gotypes.Func)
+	if !ok || fn.Pkg() == nil || !inPackages(fn.Pkg().Path(), sqlPackages) {
+		return nil
+	}
+	for _, arg := range call.Args {
+		switch ast.Unparen(arg).(type) {
+		case *ast.Ident, *ast.SelectorExpr:
+			continue
+		}
+		text, ok := constantString(arg, info)
+		if !ok {
+			continue
+		}
+		if q := newSQLQuery(text); q != nil {
+			q.Source = "call"
+			return q
+		}
+	}
+	return nil
+}
+
+// squirrelQuery recognizes a statement built with squirrel, e.g. sq.Select("id").From("users").Where(...),
+// starting from the outermost call of the chain. The calls of the chain are added to inChain so that they
+// are not taken for statements of their own.
+func squirrelQuery(call *ast.CallExpr, info *gotypes.Info, inChain map[*ast.CallExpr]bool) *ourtypes.SQLQuery {
+	tv, ok := info.Types[call]
+	if !ok || !isSquirrelType(tv.Type) {
+		return nil
+	}
+
+	// Walk the chain from the outermost call down to the first one
+	var chain []*ast.CallExpr
+	for expr := ast.Expr(call); ; {
+		c, ok := ast.Unparen(expr).(*ast.CallExpr)
+		if !ok {
+			break
+		}
+		sel, ok := c.Fun.(*ast.SelectorExpr)
+		if !ok {
+			break
+		}
+		chain = append(chain, c)
+		inChain[c] = true
+		expr = sel.X
+	}
+
+	q := &ourtypes.SQLQuery{Source: "squirrel"}
+	var tables []string
+	for i := len(chain) - 1; i >= 0; i-- {
+		c := chain[i]
+		name := c.Fun.(*ast.SelectorExpr).Sel.Name
+		var table string
+		if len(c.Args) > 0 {
+			table, _ = constantString(c.Args[0], info)
+		}
+		switch {
+		case name == "Select":
+			q.Statement = "SELECT"
+		case name == "Insert" || name == "Update" || name == "Delete":
+			q.Statement = strings.ToUpper(name)
+			tables = append(tables, table)
+		case name == "From" || name == "Into" || strings.HasSuffix(name, "Join"):
+			// Joins may hold an alias and a condition, e.g. "orders o ON o.user_id = u.id"
+			if fields := strings.Fields(table); len(fields) > 0 {
+				tables = append(tables, fields[0])
+			}
+		}
+	}
+	if q.Statement == "" {
+		return nil
+	}
+	for _, table := range tables {
+		if table != "" {
+			q.Tables = append(q.Tables, table)
+		}
+	}
+	return q
+}
+
+// isSquirrelType reports whether t is a builder type of squirrel.
+func isSquirrelType(t gotypes.Type) bool {
+	if ptr, ok := t.(*gotypes.Pointer); ok {
+		t = ptr.Elem()
+	}
+	named, ok := t.(*gotypes.Named)
+	return ok && named.Obj().Pkg() != nil && named.Obj().Pkg().Path() == squirrelPackage && token.IsExported(named.Obj().Name())
+}
+
+// inPackages reports whether path is one of the packages or a package below one of them.
+func inPackages(path string, packages []string) bool {
+	for _, pkg := range packages {
+		if path == pkg || strings.HasPrefix(path, pkg+"/") {
+			return true
+		}
+	}
+	return false
+}
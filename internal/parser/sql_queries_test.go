@@ -0,0 +1,102 @@
+package parser
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	ourtypes "github.com/vlad/ast2llm-go/internal/types"
+)
+
+func TestProjectParser_ParseProject_SQLQueries(t *testing.T) {
+	projectPath := writeTestProject(t, map[string]string{
+		"go.mod": `module example.com/testproject
+
+go 1.21
+
+require github.com/Masterminds/squirrel v1.0.0
+
+replace github.com/Masterminds/squirrel => ./third_party/squirrel
+`,
+		"third_party/squirrel/go.mod": "module github.com/Masterminds/squirrel\n\ngo 1.21\n",
+		"third_party/squirrel/squirrel.go": `package squirrel
+
+type SelectBuilder struct{}
+
+func Select(columns ...string) SelectBuilder                          { return SelectBuilder{} }
+func (b SelectBuilder) From(from string) SelectBuilder                { return b }
+func (b SelectBuilder) LeftJoin(join string, rest ...any) SelectBuilder { return b }
+func (b SelectBuilder) Where(pred any, args ...any) SelectBuilder     { return b }
+
+type InsertBuilder struct{}
+
+func Insert(into string) InsertBuilder                       { return InsertBuilder{} }
+func (b InsertBuilder) Columns(columns ...string) InsertBuilder { return b }
+`,
+		"store/store.go": `package store
+
+import (
+	"context"
+	"database/sql"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+// getUser selects a user by id.
+const getUser = ` + "`" + `
+	SELECT id, name
+	FROM users u
+	JOIN teams t ON t.id = u.team_id
+	WHERE u.id = $1` + "`" + `
+
+const createAuthor = ` + "`" + `-- name: CreateAuthor :one
+INSERT INTO authors (name) VALUES ($1) RETURNING id` + "`" + `
+
+const greeting = "Select a user from the list"
+
+func Load(ctx context.Context, db *sql.DB) error {
+	row := db.QueryRowContext(ctx, getUser, 1)
+	_ = row
+	_, err := db.ExecContext(ctx, "UPDATE users SET name = $1 WHERE id = $2", "x", 1)
+	query := "DELETE FROM sessions WHERE expires_at < now()"
+	_, _ = db.ExecContext(ctx, query)
+	_ = sq.Select("id").From("orders o").LeftJoin("items i ON i.order_id = o.id").Where("o.id = ?", 1)
+	_ = sq.Insert("audit").Columns("event")
+	return err
+}
+`,
+	})
+
+	fileInfos, err := New().ParseProject(projectPath)
+	require.NoError(t, err)
+	info := fileInfos[filepath.Join(projectPath, "store", "store.go")]
+
+	for _, q := range info.SQLQueries {
+		require.NotNil(t, q.Pos, q.Query)
+		q.Pos = nil
+	}
+	assert.Equal(t, []*ourtypes.SQLQuery{
+		{Name: "getUser", Statement: "SELECT", Tables: []string{"users", "teams"}, Query: "SELECT id, name FROM users u JOIN teams t ON t.id = u.team_id WHERE u.id = $1", Source: "constant"},
+		{Name: "CreateAuthor", Statement: "INSERT", Tables: []string{"authors"}, Query: "INSERT INTO authors (name) VALUES ($1) RETURNING id", Source: "sqlc"},
+		{Statement: "UPDATE", Tables: []string{"users"}, Query: "UPDATE users SET name = $1 WHERE id = $2", Source: "call"},
+		{Name: "query", Statement: "DELETE", Tables: []string{"sessions"}, Query: "DELETE FROM sessions WHERE expires_at < now()", Source: "constant"},
+		{Statement: "SELECT", Tables: []string{"orders", "items"}, Source: "squirrel"},
+		{Statement: "INSERT", Tables: []string{"audit"}, Source: "squirrel"},
+	}, info.SQLQueries)
+}
+
+func TestNewSQLQuery(t *testing.T) {
+	for _, text := range []string{"Select a user", "Select a user from the list", "update the record set", "delete from the list now", "WITH love", "DROP it"} {
+		assert.Nil(t, newSQLQuery(text), text)
+	}
+
+	q := newSQLQuery("WITH recent AS (SELECT * FROM orders) SELECT * FROM recent JOIN LATERAL (SELECT 1) x ON true")
+	require.NotNil(t, q)
+	assert.Equal(t, "WITH", q.Statement)
+	assert.Equal(t, []string{"orders", "recent"}, q.Tables)
+
+	q = newSQLQuery(`CREATE TABLE IF NOT EXISTS "users" (id serial)`)
+	require.NotNil(t, q)
+	assert.Equal(t, []string{"users"}, q.Tables)
+}
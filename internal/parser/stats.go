@@ -0,0 +1,55 @@
+package parser
+
+import "time"
+
+// Stats holds counters of the work a ProjectParser has done since it was created
+type Stats struct {
+	Parses           int64   `json:"parses"`             // Calls of ParseProject, ParseFiles and ParseProjectIncremental
+	ParseErrors      int64   `json:"parse_errors"`       // Parses that returned an error
+	ParseSeconds     float64 `json:"parse_seconds"`      // Total duration of all parses
+	LastParseSeconds float64 `json:"last_parse_seconds"` // Duration of the latest parse
+	MaxParseSeconds  float64 `json:"max_parse_seconds"`  // Duration of the slowest parse
+	PackagesLoaded   int64   `json:"packages_loaded"`    // Packages loaded by any operation
+	FilesExtracted   int64   `json:"files_extracted"`    // Files whose FileInfo was extracted
+	CacheHits        int64   `json:"cache_hits"`         // Packages ParseProjectIncremental reused from its cache
+	CacheMisses      int64   `json:"cache_misses"`       // Packages ParseProjectIncremental extracted again
+}
+
+// CacheHitRate returns the share of packages ParseProjectIncremental served from its cache, 0 before any use
+func (s Stats) CacheHitRate() float64 {
+	if total := s.CacheHits + s.CacheMisses; total > 0 {
+		return float64(s.CacheHits) / float64(total)
+	}
+	return 0
+}
+
+// Stats returns a snapshot of the counters of the parser
+func (p *ProjectParser) Stats() Stats {
+	p.statsMu.Lock()
+	defer p.statsMu.Unlock()
+	return p.stats
+}
+
+// recordParse counts a parse that started at start and returned err
+func (p *ProjectParser) recordParse(start time.Time, err error) {
+	seconds := time.Since(start).Seconds()
+	p.statsMu.Lock()
+	defer p.statsMu.Unlock()
+	p.stats.Parses++
+	if err != nil {
+		p.stats.ParseErrors++
+	}
+	p.stats.ParseSeconds += seconds
+	p.stats.LastParseSeconds = seconds
+	p.stats.MaxParseSeconds = max(p.stats.MaxParseSeconds, seconds)
+}
+
+// recordWork adds loaded packages, extracted files and incremental cache lookups to the counters
+func (p *ProjectParser) recordWork(packages, files, cacheHits, cacheMisses int) {
+	p.statsMu.Lock()
+	defer p.statsMu.Unlock()
+	p.stats.PackagesLoaded += int64(packages)
+	p.stats.FilesExtracted += int64(files)
+	p.stats.CacheHits += int64(cacheHits)
+	p.stats.CacheMisses += int64(cacheMisses)
+}
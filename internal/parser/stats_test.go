@@ -0,0 +1,42 @@
+package parser
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProjectParser_Stats(t *testing.T) {
+	projectPath := writeTestProject(t, map[string]string{
+		"main.go":   "package main\n\nimport \"example.com/testproject/util\"\n\nfunc main() { util.A() }\n",
+		"util/a.go": "package util\n\nfunc A() {}\n",
+		"api/b.go":  "package api\n\nfunc B() {}\n",
+	})
+	p := New()
+	assert.Equal(t, Stats{}, p.Stats())
+
+	_, err := p.ParseProject(projectPath)
+	require.NoError(t, err)
+	stats := p.Stats()
+	assert.Equal(t, int64(1), stats.Parses)
+	assert.Equal(t, int64(3), stats.PackagesLoaded)
+	assert.Equal(t, int64(3), stats.FilesExtracted)
+	assert.Greater(t, stats.ParseSeconds, 0.0)
+	assert.Equal(t, stats.ParseSeconds, stats.MaxParseSeconds)
+
+	_, err = p.ParseProjectIncremental(projectPath, nil)
+	require.NoError(t, err)
+	_, err = p.ParseProjectIncremental(projectPath, []string{"util/a.go"})
+	require.NoError(t, err)
+	stats = p.Stats()
+	assert.Equal(t, int64(3), stats.Parses)
+	assert.Equal(t, int64(1), stats.CacheHits, "api is reused")
+	assert.Equal(t, int64(5), stats.CacheMisses, "full parse, then util and its importer main")
+	assert.InDelta(t, 1.0/6, stats.CacheHitRate(), 1e-9)
+
+	_, err = p.ParseProject(filepath.Join(t.TempDir(), "missing"))
+	assert.Error(t, err)
+	assert.Equal(t, int64(1), p.Stats().ParseErrors)
+}
@@ -0,0 +1,106 @@
+package parser
+
+import (
+	"go/ast"
+	gotypes "go/types"
+	"sort"
+
+	ourtypes "github.com/vlad/ast2llm-go/internal/types"
+)
+
+// setUsedFields records on the used imported structs of a file the fields the file sets or selects, for the
+// structs it builds with keyed composite literals, see usedStructFields.
+func setUsedFields(structs []*ourtypes.StructInfo, file *ast.File, info *gotypes.Info, pkg *gotypes.Package) {
+	fields := usedStructFields(file, info, pkg)
+	for _, s := range structs {
+		s.UsedFields = fields[s.Name]
+	}
+}
+
+// usedStructFields returns, by qualified type name, the fields file sets in keyed composite literals of the
+// structs of other packages or selects on their values, sorted. Only structs built with a keyed literal are
+// listed: the file may need every field of the other ones, and an unkeyed literal sets all of them.
+func usedStructFields(file *ast.File, info *gotypes.Info, pkg *gotypes.Package) map[string][]string {
+	keyed := make(map[string]map[string]bool)
+	unkeyed := make(map[string]bool)
+	selected := make(map[string]map[string]bool)
+	add := func(m map[string]map[string]bool, typeName, field string) {
+		if m[typeName] == nil {
+			m[typeName] = make(map[string]bool)
+		}
+		if field != "" {
+			m[typeName][field] = true
+		}
+	}
+
+	ast.Inspect(file, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.CompositeLit:
+			typeName, ok := importedStructName(info.TypeOf(n), pkg)
+			if !ok {
+				return true
+			}
+			if len(n.Elts) > 0 {
+				if _, isKeyed := n.Elts[0].(*ast.KeyValueExpr); !isKeyed {
+					unkeyed[typeName] = true
+					return true
+				}
+			}
+			add(keyed, typeName, "")
+			for _, elt := range n.Elts {
+				kv, ok := elt.(*ast.KeyValueExpr)
+				if !ok { // Mixed keyed and positional elements do not type check, but are parsed anyway
+					continue
+				}
+				if key, ok := kv.Key.(*ast.Ident); ok {
+					add(keyed, typeName, key.Name)
+				}
+			}
+		case *ast.SelectorExpr:
+			selection, ok := info.Selections[n]
+			if !ok || selection.Kind() != gotypes.FieldVal {
+				return true
+			}
+			if typeName, ok := importedStructName(selection.Recv(), pkg); ok {
+				add(selected, typeName, n.Sel.Name)
+			}
+		}
+		return true
+	})
+
+	result := make(map[string][]string, len(keyed))
+	for typeName, fields := range keyed {
+		if unkeyed[typeName] {
+			continue
+		}
+		for field := range selected[typeName] {
+			fields[field] = true
+		}
+		if len(fields) == 0 {
+			continue
+		}
+		names := make([]string, 0, len(fields))
+		for field := range fields {
+			names = append(names, field)
+		}
+		sort.Strings(names)
+		result[typeName] = names
+	}
+	return result
+}
+
+// importedStructName returns the qualified name of t, or of the type t points to, if it is a named struct
+// type declared outside pkg.
+func importedStructName(t gotypes.Type, pkg *gotypes.Package) (string, bool) {
+	if ptr, ok := t.(*gotypes.Pointer); ok {
+		t = ptr.Elem()
+	}
+	named, ok := gotypes.Unalias(t).(*gotypes.Named)
+	if !ok || named.Obj().Pkg() == nil || named.Obj().Pkg() == pkg {
+		return "", false
+	}
+	if _, ok := named.Underlying().(*gotypes.Struct); !ok {
+		return "", false
+	}
+	return namedTypeName(named), true
+}
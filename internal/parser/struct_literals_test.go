@@ -0,0 +1,83 @@
+package parser
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProjectParser_ParseProject_UsedFields(t *testing.T) {
+	projectPath := writeTestProject(t, map[string]string{
+		"config/config.go": `package config
+
+type Config struct {
+	Host    string
+	Port    int
+	Timeout int
+	Token   string ` + "`validate:\"required\"`" + `
+	Debug   bool
+}
+
+type Point struct{ X, Y int }
+
+type Other struct{ A, B int }
+
+type Plain struct{ A, B int }
+`,
+		"main.go": `package main
+
+import "example.com/testproject/config"
+
+func main() {
+	cfg := &config.Config{Host: "localhost", Port: 8080}
+	if cfg.Debug {
+		return
+	}
+	_ = []config.Point{{X: 1}, {1, 2}}
+	var o config.Other
+	_ = o.A
+	_ = config.Other{}
+	var pl config.Plain
+	_ = pl.B
+}
+`,
+	})
+
+	info, err := New().ParseProject(projectPath)
+	require.NoError(t, err)
+	mainInfo := info[filepath.Join(projectPath, "main.go")]
+	require.NotNil(t, mainInfo)
+
+	used := make(map[string][]string)
+	for _, s := range mainInfo.UsedImportedStructs {
+		used[s.Name] = s.UsedFields
+	}
+	require.Len(t, used, 4)
+	assert.Equal(t, []string{"Debug", "Host", "Port"}, used["example.com/testproject/config.Config"], "set and selected fields")
+	assert.Nil(t, used["example.com/testproject/config.Point"], "an unkeyed literal sets every field")
+	assert.Equal(t, []string{"A"}, used["example.com/testproject/config.Other"], "fields selected on a struct built with an empty literal")
+	assert.Nil(t, used["example.com/testproject/config.Plain"], "structs never built with a literal keep every field")
+}
+
+func TestProjectParser_ParseProject_UsedFieldsMixedLiteral(t *testing.T) {
+	projectPath := writeTestProject(t, map[string]string{
+		"config/config.go": "package config\n\ntype Config struct {\n\tName string\n\tSize int\n}\n",
+		"main.go": `package main
+
+import "example.com/testproject/config"
+
+var cfg = config.Config{Name: "x", 5}
+
+func main() {}
+`,
+	})
+
+	info, err := New().ParseProject(projectPath)
+	require.NoError(t, err, "a literal mixing keyed and positional elements is reported, not fatal")
+	mainInfo := info[filepath.Join(projectPath, "main.go")]
+	require.NotNil(t, mainInfo)
+	require.Len(t, mainInfo.UsedImportedStructs, 1)
+	assert.Equal(t, []string{"Name"}, mainInfo.UsedImportedStructs[0].UsedFields)
+}
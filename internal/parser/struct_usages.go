@@ -0,0 +1,150 @@
+package parser
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	gotypes "go/types"
+	"sort"
+
+	ourtypes "github.com/vlad/ast2llm-go/internal/types"
+	"golang.org/x/tools/go/packages"
+)
+
+// FindStructUsages loads the project and lists where the struct structName is constructed with a composite
+// literal and where its fields are assigned, including through embedding structs, sorted by position.
+// structName is resolved like in ExtractSymbolContext. The fully qualified name of the struct is returned too.
+func (p *ProjectParser) FindStructUsages(projectPath, structName string) (string, []*ourtypes.StructUsage, error) {
+	pkgs, err := p.loadPackages(projectPath, "./...")
+	if err != nil {
+		return "", nil, err
+	}
+
+	sc := newSymbolContext(p, pkgs)
+	var target *gotypes.Named
+	for _, obj := range sc.lookup(structName) {
+		if typeName, ok := obj.(*gotypes.TypeName); ok {
+			if named, ok := typeName.Type().(*gotypes.Named); ok {
+				if _, ok := named.Underlying().(*gotypes.Struct); ok {
+					target = named
+					break
+				}
+			}
+		}
+	}
+	if target == nil {
+		return "", nil, fmt.Errorf("struct %s not found in %s", structName, projectPath)
+	}
+
+	usages := make([]*ourtypes.StructUsage, 0)
+	for _, pkg := range pkgs {
+		for _, file := range pkg.Syntax {
+			for _, decl := range file.Decls {
+				function := ""
+				if funcDecl, ok := decl.(*ast.FuncDecl); ok {
+					function = funcDeclName(funcDecl)
+				}
+				usages = append(usages, p.structUsagesIn(decl, function, target, pkg)...)
+			}
+		}
+	}
+
+	sort.SliceStable(usages, func(i, j int) bool {
+		a, b := usages[i].Pos, usages[j].Pos
+		if a.File != b.File {
+			return a.File < b.File
+		}
+		if a.Line != b.Line {
+			return a.Line < b.Line
+		}
+		return a.Column < b.Column
+	})
+	return namedTypeName(target), usages, nil
+}
+
+// structUsagesIn returns the constructions of target and writes to its fields within node
+func (p *ProjectParser) structUsagesIn(node ast.Node, function string, target *gotypes.Named, pkg *packages.Package) []*ourtypes.StructUsage {
+	var usages []*ourtypes.StructUsage
+	addWrite := func(lhs ast.Expr) {
+		if sel, field := writtenField(lhs, target, pkg.TypesInfo); field != nil {
+			usages = append(usages, &ourtypes.StructUsage{
+				Kind:     ourtypes.UsageWrite,
+				Field:    field.Name(),
+				Function: function,
+				Pos:      p.position(sel.Sel.Pos()),
+			})
+		}
+	}
+
+	ast.Inspect(node, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.CompositeLit:
+			if isNamedType(pkg.TypesInfo.TypeOf(n), target) {
+				usages = append(usages, &ourtypes.StructUsage{
+					Kind:     ourtypes.UsageConstruct,
+					Function: function,
+					Pos:      p.position(n.Pos()),
+				})
+			}
+		case *ast.AssignStmt:
+			if n.Tok != token.DEFINE {
+				for _, lhs := range n.Lhs {
+					addWrite(lhs)
+				}
+			}
+		case *ast.IncDecStmt:
+			addWrite(n.X)
+		}
+		return true
+	})
+	return usages
+}
+
+// writtenField returns the selector and the field of target assigned by lhs, e.g. s.Name, s.Items[i] or
+// (*s).Tags[k]; the field may be promoted to lhs from an embedded target.
+func writtenField(lhs ast.Expr, target *gotypes.Named, info *gotypes.Info) (*ast.SelectorExpr, *gotypes.Var) {
+	for {
+		switch e := lhs.(type) {
+		case *ast.ParenExpr:
+			lhs = e.X
+			continue
+		case *ast.IndexExpr:
+			lhs = e.X
+			continue
+		}
+		break
+	}
+	sel, ok := lhs.(*ast.SelectorExpr)
+	if !ok {
+		return nil, nil
+	}
+	selection, ok := info.Selections[sel]
+	if !ok || selection.Kind() != gotypes.FieldVal {
+		return nil, nil
+	}
+	field, ok := selection.Obj().(*gotypes.Var)
+	if !ok {
+		return nil, nil
+	}
+	structType := target.Origin().Underlying().(*gotypes.Struct)
+	for i := 0; i < structType.NumFields(); i++ {
+		if structType.Field(i) == field.Origin() {
+			return sel, field
+		}
+	}
+	return nil, nil
+}
+
+// isNamedType reports whether typ is target or an instantiation of it
+func isNamedType(typ gotypes.Type, target *gotypes.Named) bool {
+	named, ok := typ.(*gotypes.Named)
+	return ok && named.Origin() == target.Origin()
+}
+
+// funcDeclName names a function as Name and a method as (Recv).Name, e.g. (*Store).Add
+func funcDeclName(funcDecl *ast.FuncDecl) string {
+	if funcDecl.Recv == nil || len(funcDecl.Recv.List) == 0 {
+		return funcDecl.Name.Name
+	}
+	return fmt.Sprintf("(%s).%s", gotypes.ExprString(funcDecl.Recv.List[0].Type), funcDecl.Name.Name)
+}
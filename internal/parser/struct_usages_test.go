@@ -0,0 +1,85 @@
+package parser
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	ourtypes "github.com/vlad/ast2llm-go/internal/types"
+)
+
+func TestProjectParser_FindStructUsages(t *testing.T) {
+	projectPath := writeTestProject(t, map[string]string{
+		"store/store.go": `package store
+
+// Store holds items.
+type Store struct {
+	Name  string
+	Items []string
+	Count int
+}
+
+var Default = Store{Name: "default"}
+
+// New creates a store.
+func New(name string) *Store {
+	return &Store{Name: name}
+}
+
+// Add appends an item.
+func (s *Store) Add(item string) {
+	s.Items = append(s.Items, item)
+	s.Count++
+}
+`,
+		"main.go": `package main
+
+import "example.com/testproject/store"
+
+type Named struct {
+	store.Store
+	Name string
+}
+
+func main() {
+	s := store.New("x")
+	s.Items[0] = "y"
+	n := Named{}
+	n.Count = 1
+	n.Name = "shadowed"
+	stores := []store.Store{{}}
+	_ = stores
+}
+`,
+	})
+	mainPath := filepath.Join(projectPath, "main.go")
+	storePath := filepath.Join(projectPath, "store", "store.go")
+
+	name, usages, err := New().FindStructUsages(projectPath, "store.Store")
+	require.NoError(t, err)
+	assert.Equal(t, "example.com/testproject/store.Store", name)
+
+	type usage struct {
+		Kind, Field, Function, File string
+		Line                        int
+	}
+	var got []usage
+	for _, u := range usages {
+		got = append(got, usage{u.Kind, u.Field, u.Function, u.Pos.File, u.Pos.Line})
+	}
+	assert.Equal(t, []usage{
+		{ourtypes.UsageWrite, "Items", "main", mainPath, 12},
+		{ourtypes.UsageWrite, "Count", "main", mainPath, 14},
+		{ourtypes.UsageConstruct, "", "main", mainPath, 16},
+		{ourtypes.UsageConstruct, "", "", storePath, 10},
+		{ourtypes.UsageConstruct, "", "New", storePath, 14},
+		{ourtypes.UsageWrite, "Items", "(*Store).Add", storePath, 19},
+		{ourtypes.UsageWrite, "Count", "(*Store).Add", storePath, 20},
+	}, got)
+
+	t.Run("not a struct", func(t *testing.T) {
+		_, _, err := New().FindStructUsages(projectPath, "store.New")
+		assert.ErrorContains(t, err, "struct store.New not found")
+	})
+}
@@ -0,0 +1,329 @@
+package parser
+
+import (
+	"fmt"
+	"go/ast"
+	gotypes "go/types"
+	"strings"
+
+	ourtypes "github.com/vlad/ast2llm-go/internal/types"
+	"golang.org/x/tools/go/packages"
+)
+
+// ExtractSymbolContext loads the project and returns only the FileInfo fragments relevant to symbolName:
+// its definition (with methods for types), the project types it references up to the configured depth and
+// the functions referring to it. symbolName may be a bare name, pkg.Name, a fully qualified name or Type.Method.
+func (p *ProjectParser) ExtractSymbolContext(projectPath, symbolName string) (ProjectInfo, error) {
+	pkgs, err := p.loadPackages(projectPath, "./...")
+	if err != nil {
+		return nil, err
+	}
+
+	sc := newSymbolContext(p, pkgs)
+	targets := sc.lookup(symbolName)
+	if len(targets) == 0 {
+		return nil, fmt.Errorf("symbol %s not found in %s", symbolName, projectPath)
+	}
+	sc.expand(targets)
+
+	return sc.result(), nil
+}
+
+// expand adds the definitions of targets, the project types they reference up to the configured depth
+// and the functions referring to them.
+func (sc *symbolContext) expand(targets []gotypes.Object) {
+	frontier := make([]gotypes.Object, 0, len(targets))
+	for _, obj := range targets {
+		if sc.addDefinition(obj) {
+			frontier = append(frontier, obj)
+		}
+	}
+
+	// Breadth-first walk over referenced types, one level per iteration
+	for depth := 0; depth < sc.p.symbolDepth && len(frontier) > 0; depth++ {
+		var next []gotypes.Object
+		for _, obj := range frontier {
+			for _, ref := range referencedTypeNames(obj) {
+				if sc.addDefinition(ref) {
+					next = append(next, ref)
+				}
+			}
+		}
+		frontier = next
+	}
+
+	for _, obj := range targets {
+		sc.addReferrers(obj)
+	}
+}
+
+// symbolContext accumulates the fragments collected by ExtractSymbolContext
+type symbolContext struct {
+	p         *ProjectParser
+	pkgs      map[string]*packages.Package // Key: package path
+	files     map[string]*ast.File         // Key: absolute file path
+	fragments ProjectInfo
+	added     map[gotypes.Object]bool
+}
+
+// newSymbolContext indexes the loaded packages and their files.
+func newSymbolContext(p *ProjectParser, pkgs []*packages.Package) *symbolContext {
+	sc := &symbolContext{
+		p:         p,
+		pkgs:      make(map[string]*packages.Package, len(pkgs)),
+		files:     make(map[string]*ast.File),
+		fragments: make(ProjectInfo),
+		added:     make(map[gotypes.Object]bool),
+	}
+	for _, pkg := range pkgs {
+		sc.pkgs[pkg.PkgPath] = pkg
+		for _, file := range pkg.Syntax {
+			sc.files[p.fset.File(file.Pos()).Name()] = file
+		}
+	}
+	return sc
+}
+
+// lookup resolves symbolName to the package-level objects or methods it names.
+func (sc *symbolContext) lookup(symbolName string) []gotypes.Object {
+	var found []gotypes.Object
+	for _, pkg := range sc.pkgs {
+		if pkg.Types == nil {
+			continue
+		}
+		scope := pkg.Types.Scope()
+		for _, name := range scope.Names() {
+			if symbolName == name || symbolName == pkg.Name+"."+name || symbolName == pkg.PkgPath+"."+name {
+				found = append(found, scope.Lookup(name))
+			}
+		}
+	}
+	if len(found) > 0 {
+		return found
+	}
+
+	// Fall back to Type.Method
+	idx := strings.LastIndex(symbolName, ".")
+	if idx <= 0 {
+		return nil
+	}
+	methodName := symbolName[idx+1:]
+	for _, obj := range sc.lookup(symbolName[:idx]) {
+		if named, ok := obj.Type().(*gotypes.Named); ok {
+			for i := 0; i < named.NumMethods(); i++ {
+				if named.Method(i).Name() == methodName {
+					found = append(found, named.Method(i))
+				}
+			}
+		}
+	}
+	return found
+}
+
+// fragment returns the FileInfo fragment for the given file, creating it if needed.
+func (sc *symbolContext) fragment(path string, file *ast.File) *ourtypes.FileInfo {
+	if info, ok := sc.fragments[path]; ok {
+		return info
+	}
+	info := ourtypes.NewFileInfo()
+	info.PackageName = file.Name.Name
+	sc.fragments[path] = info
+	return info
+}
+
+// addDefinition adds the definition of a project object to the fragments.
+// It returns false if the object was already added or is not defined in the project.
+func (sc *symbolContext) addDefinition(obj gotypes.Object) bool {
+	if sc.added[obj] || obj.Pkg() == nil {
+		return false
+	}
+	pkg, ok := sc.pkgs[obj.Pkg().Path()]
+	if !ok {
+		return false
+	}
+	path := sc.p.fset.Position(obj.Pos()).Filename
+	file, ok := sc.files[path]
+	if !ok {
+		return false
+	}
+	sc.added[obj] = true
+	info := sc.fragment(path, file)
+
+	switch o := obj.(type) {
+	case *gotypes.TypeName:
+		if namedType, ok := o.Type().(*gotypes.Named); ok {
+			if structType, ok := namedType.Underlying().(*gotypes.Struct); ok {
+				info.Structs = append(info.Structs, sc.p.extractDetailedStructInfo(o, namedType, structType, pkg, file))
+			} else if ifaceType, ok := namedType.Underlying().(*gotypes.Interface); ok {
+				info.Interfaces = append(info.Interfaces, sc.p.extractDetailedInterfaceInfo(o, namedType, ifaceType, pkg, file))
+			}
+		}
+	case *gotypes.Func:
+		if funcDecl := findFuncDecl(file, pkg, o); funcDecl != nil {
+			sc.appendFunction(info, funcDecl, pkg)
+		}
+	case *gotypes.Var, *gotypes.Const:
+		ast.Inspect(file, func(n ast.Node) bool {
+			genDecl, ok := n.(*ast.GenDecl)
+			if !ok {
+				return true
+			}
+			for _, spec := range genDecl.Specs {
+				if valSpec, ok := spec.(*ast.ValueSpec); ok {
+					for i, name := range valSpec.Names {
+						if pkg.TypesInfo.Defs[name] == obj {
+							info.GlobalVars = append(info.GlobalVars, sc.p.extractGlobalVarInfo(obj, genDecl, valSpec, i, pkg))
+							return false
+						}
+					}
+				}
+			}
+			return false
+		})
+	}
+	return true
+}
+
+// addReferrers adds every top-level function or method whose signature or body refers to obj.
+func (sc *symbolContext) addReferrers(obj gotypes.Object) {
+	for path, file := range sc.files {
+		pkg := sc.packageOf(file)
+		if pkg == nil {
+			continue
+		}
+		for _, decl := range file.Decls {
+			funcDecl, ok := decl.(*ast.FuncDecl)
+			if !ok || funcDecl.Body == nil {
+				continue
+			}
+			defObj := pkg.TypesInfo.Defs[funcDecl.Name]
+			if defObj == nil || sc.added[defObj] {
+				continue
+			}
+
+			// The receiver is skipped so that methods are not reported as referrers of their own type
+			refers := false
+			inspectRefs := func(n ast.Node) bool {
+				if ident, ok := n.(*ast.Ident); ok && pkg.TypesInfo.Uses[ident] == obj {
+					refers = true
+				}
+				return !refers
+			}
+			ast.Inspect(funcDecl.Type, inspectRefs)
+			ast.Inspect(funcDecl.Body, inspectRefs)
+			if !refers {
+				continue
+			}
+
+			sc.added[defObj] = true
+			info := sc.fragment(path, file)
+			sc.appendFunction(info, funcDecl, pkg)
+		}
+	}
+}
+
+// packageOf returns the loaded package containing file.
+func (sc *symbolContext) packageOf(file *ast.File) *packages.Package {
+	for _, pkg := range sc.pkgs {
+		for _, f := range pkg.Syntax {
+			if f == file {
+				return pkg
+			}
+		}
+	}
+	return nil
+}
+
+// result returns the collected fragments, named according to the configured naming mode.
+func (sc *symbolContext) result() ProjectInfo {
+	for path, info := range sc.fragments {
+		sc.fragments[path] = sc.p.newNamer(sc.packageOf(sc.files[path])).fileInfo(info)
+	}
+	return sc.fragments
+}
+
+// appendFunction adds the function or method declared by funcDecl to info.
+func (sc *symbolContext) appendFunction(info *ourtypes.FileInfo, funcDecl *ast.FuncDecl, pkg *packages.Package) {
+	fnInfo := sc.p.extractFunctionInfo(funcDecl, pkg)
+	if fnInfo.Receiver == "" {
+		info.Functions = append(info.Functions, fnInfo)
+	} else {
+		info.Methods = append(info.Methods, fnInfo)
+	}
+}
+
+// findFuncDecl returns the declaration of fn within file.
+func findFuncDecl(file *ast.File, pkg *packages.Package, fn *gotypes.Func) *ast.FuncDecl {
+	for _, decl := range file.Decls {
+		if funcDecl, ok := decl.(*ast.FuncDecl); ok && pkg.TypesInfo.Defs[funcDecl.Name] == fn {
+			return funcDecl
+		}
+	}
+	return nil
+}
+
+// referencedTypeNames returns the named types directly referenced by obj's type, fields and method signatures.
+func referencedTypeNames(obj gotypes.Object) []gotypes.Object {
+	seen := make(map[*gotypes.TypeName]bool)
+	var refs []gotypes.Object
+
+	var visit func(t gotypes.Type)
+	visit = func(t gotypes.Type) {
+		switch t := t.(type) {
+		case *gotypes.Named:
+			if !seen[t.Obj()] && t.Obj() != obj {
+				seen[t.Obj()] = true
+				refs = append(refs, t.Obj())
+			}
+			if args := t.TypeArgs(); args != nil {
+				for i := 0; i < args.Len(); i++ {
+					visit(args.At(i))
+				}
+			}
+		case *gotypes.Alias:
+			visit(gotypes.Unalias(t))
+		case *gotypes.Pointer:
+			visit(t.Elem())
+		case *gotypes.Slice:
+			visit(t.Elem())
+		case *gotypes.Array:
+			visit(t.Elem())
+		case *gotypes.Chan:
+			visit(t.Elem())
+		case *gotypes.Map:
+			visit(t.Key())
+			visit(t.Elem())
+		case *gotypes.Signature:
+			visit(t.Params())
+			visit(t.Results())
+		case *gotypes.Tuple:
+			for i := 0; i < t.Len(); i++ {
+				visit(t.At(i).Type())
+			}
+		case *gotypes.Struct:
+			for i := 0; i < t.NumFields(); i++ {
+				visit(t.Field(i).Type())
+			}
+		case *gotypes.Interface:
+			for i := 0; i < t.NumExplicitMethods(); i++ {
+				visit(t.ExplicitMethod(i).Type())
+			}
+			for i := 0; i < t.NumEmbeddeds(); i++ {
+				visit(t.EmbeddedType(i))
+			}
+		}
+	}
+
+	if typeName, ok := obj.(*gotypes.TypeName); ok {
+		visit(typeName.Type().Underlying())
+		if namedType, ok := typeName.Type().(*gotypes.Named); ok {
+			for i := 0; i < namedType.NumMethods(); i++ {
+				visit(namedType.Method(i).Type())
+			}
+		}
+	} else {
+		visit(obj.Type())
+	}
+
+	return refs
+}
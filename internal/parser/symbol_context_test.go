@@ -0,0 +1,124 @@
+package parser
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	ourtypes "github.com/vlad/ast2llm-go/internal/types"
+)
+
+var symbolContextProject = map[string]string{
+	"models/user.go": `package models
+
+// User is an account.
+type User struct {
+	Name    string
+	Address *Address
+}
+
+// Rename changes the name.
+func (u *User) Rename(name string) {
+	u.Name = name
+}
+
+// Address is a postal address.
+type Address struct {
+	Geo Geo
+}
+
+// Geo is a coordinate.
+type Geo struct {
+	Lat, Lng float64
+}
+
+// Unrelated is never referenced by User.
+type Unrelated struct{}
+`,
+	"service/service.go": `package service
+
+import "example.com/testproject/models"
+
+// Save stores the user.
+func Save(u *models.User) error {
+	u.Rename("saved")
+	return nil
+}
+
+// Count is not related to users.
+func Count() int {
+	return 0
+}
+`,
+}
+
+func structNames(info ProjectInfo) []string {
+	var names []string
+	for _, fi := range info {
+		for _, s := range fi.Structs {
+			names = append(names, s.Name)
+		}
+	}
+	return names
+}
+
+func functionNames(fis ...*ourtypes.FileInfo) []string {
+	var names []string
+	for _, fi := range fis {
+		if fi == nil {
+			continue
+		}
+		for _, fn := range fi.Functions {
+			names = append(names, fn.Name)
+		}
+	}
+	return names
+}
+
+func TestProjectParser_ExtractSymbolContext(t *testing.T) {
+	projectPath := writeTestProject(t, symbolContextProject)
+	modelsPath := filepath.Join(projectPath, "models", "user.go")
+	servicePath := filepath.Join(projectPath, "service", "service.go")
+
+	t.Run("type with depth 1", func(t *testing.T) {
+		p := New(WithSymbolDepth(1))
+		info, err := p.ExtractSymbolContext(projectPath, "models.User")
+		require.NoError(t, err)
+
+		assert.ElementsMatch(t, []string{
+			"example.com/testproject/models.User",
+			"example.com/testproject/models.Address",
+		}, structNames(info))
+		assert.ElementsMatch(t, []string{"Save"}, functionNames(info[servicePath]))
+	})
+
+	t.Run("type with default depth", func(t *testing.T) {
+		p := New()
+		info, err := p.ExtractSymbolContext(projectPath, "example.com/testproject/models.User")
+		require.NoError(t, err)
+
+		assert.ElementsMatch(t, []string{
+			"example.com/testproject/models.User",
+			"example.com/testproject/models.Address",
+			"example.com/testproject/models.Geo",
+		}, structNames(info))
+	})
+
+	t.Run("method", func(t *testing.T) {
+		p := New(WithSymbolDepth(0))
+		info, err := p.ExtractSymbolContext(projectPath, "User.Rename")
+		require.NoError(t, err)
+
+		require.Len(t, info[modelsPath].Methods, 1)
+		assert.Equal(t, "Rename", info[modelsPath].Methods[0].Name)
+		assert.Equal(t, "*User", info[modelsPath].Methods[0].Receiver)
+		assert.ElementsMatch(t, []string{"Save"}, functionNames(info[servicePath]))
+	})
+
+	t.Run("unknown symbol", func(t *testing.T) {
+		p := New()
+		_, err := p.ExtractSymbolContext(projectPath, "DoesNotExist")
+		assert.ErrorContains(t, err, "symbol DoesNotExist not found")
+	})
+}
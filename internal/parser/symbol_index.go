@@ -0,0 +1,82 @@
+package parser
+
+import (
+	"go/ast"
+	gotypes "go/types"
+
+	ourtypes "github.com/vlad/ast2llm-go/internal/types"
+	"golang.org/x/tools/go/packages"
+)
+
+// symbolIndex holds the package-level functions and variables of the loaded project packages,
+// so that the items a file uses from other packages are looked up instead of searched for
+type symbolIndex struct {
+	packages   map[string]bool                    // Paths of the indexed packages
+	functions  map[string]*ourtypes.FunctionInfo  // Key: fully qualified name, e.g. "example.com/pkg.Func"
+	globalVars map[string]*ourtypes.GlobalVarInfo // Key: fully qualified name, e.g. "example.com/pkg.Var"
+}
+
+// buildSymbolIndex indexes the top-level declarations of pkgs in a single pass over their files.
+func (p *ProjectParser) buildSymbolIndex(pkgs []*packages.Package) *symbolIndex {
+	idx := &symbolIndex{
+		packages:   make(map[string]bool, len(pkgs)),
+		functions:  make(map[string]*ourtypes.FunctionInfo),
+		globalVars: make(map[string]*ourtypes.GlobalVarInfo),
+	}
+
+	for _, pkg := range pkgs {
+		idx.packages[pkg.PkgPath] = true
+		if pkg.TypesInfo == nil {
+			continue
+		}
+		for _, file := range pkg.Syntax {
+			for _, decl := range file.Decls {
+				switch d := decl.(type) {
+				case *ast.FuncDecl:
+					if d.Recv != nil {
+						continue
+					}
+					if fn, ok := pkg.TypesInfo.Defs[d.Name].(*gotypes.Func); ok {
+						fnInfo := p.extractFunctionInfo(d, pkg)
+						fnInfo.Name = qualifiedName(fn)
+						idx.functions[fnInfo.Name] = fnInfo
+					}
+				case *ast.GenDecl:
+					for _, spec := range d.Specs {
+						valSpec, ok := spec.(*ast.ValueSpec)
+						if !ok {
+							continue
+						}
+						for i, name := range valSpec.Names {
+							if obj := pkg.TypesInfo.Defs[name]; obj != nil {
+								varInfo := p.extractGlobalVarInfo(obj, d, valSpec, i, pkg)
+								varInfo.Name = qualifiedName(obj)
+								idx.globalVars[varInfo.Name] = varInfo
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+
+	return idx
+}
+
+// qualifiedName returns the fully qualified name of a package-level object.
+func qualifiedName(obj gotypes.Object) string {
+	return obj.Pkg().Path() + "." + obj.Name()
+}
+
+// symbolID returns the stable identifier of a package-level object or method, its package path followed by
+// its member name, e.g. "example.com/app/store.Store.Get". Objects without a package have no identifier.
+// Identifiers are accepted wherever symbols are looked up by name, see symbolContext.lookup.
+func symbolID(obj gotypes.Object) string {
+	if obj == nil || obj.Pkg() == nil {
+		return ""
+	}
+	if fn, ok := obj.(*gotypes.Func); ok {
+		obj = fn.Origin()
+	}
+	return obj.Pkg().Path() + "." + memberName(obj)
+}
@@ -0,0 +1,100 @@
+package parser
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProjectParser_buildSymbolIndex(t *testing.T) {
+	projectPath := writeTestProject(t, map[string]string{
+		"main.go": `package main
+
+func main() {}
+`,
+		"util/util.go": `package util
+
+// Limit is the maximum size.
+const Limit = 10
+
+var Name = "util"
+
+// Double doubles n.
+func Double(n int) int {
+	Name := "shadowed"
+	_ = Name
+	return n * 2
+}
+
+type T struct{}
+
+func (T) Method() {}
+`,
+	})
+
+	p := New()
+	pkgs, err := p.loadPackages(projectPath, "./...")
+	require.NoError(t, err)
+	index := p.buildSymbolIndex(pkgs)
+
+	assert.True(t, index.packages["example.com/testproject"])
+	assert.True(t, index.packages["example.com/testproject/util"])
+
+	require.Contains(t, index.functions, "example.com/testproject/util.Double")
+	double := index.functions["example.com/testproject/util.Double"]
+	assert.Equal(t, "Double doubles n.", double.Comment)
+	assert.Equal(t, []string{"n int"}, double.Params)
+	assert.Equal(t, []string{"int"}, double.Returns)
+	assert.NotContains(t, index.functions, "example.com/testproject/util.Method", "methods are not indexed")
+
+	require.Contains(t, index.globalVars, "example.com/testproject/util.Limit")
+	assert.True(t, index.globalVars["example.com/testproject/util.Limit"].IsConst)
+	require.Contains(t, index.globalVars, "example.com/testproject/util.Name")
+	assert.Equal(t, `"util"`, index.globalVars["example.com/testproject/util.Name"].Value, "local variables do not shadow globals")
+}
+
+func TestProjectParser_ParseProject_SymbolIDs(t *testing.T) {
+	projectPath := writeTestProject(t, map[string]string{
+		"api/config.go": `package api
+
+type Config struct{ Addr string }
+
+type Handler interface{ Serve() }
+
+var Default Config
+
+func New() *Config { return &Default }
+`,
+		"store/config.go": `package store
+
+type Config struct{ DSN string }
+
+type Cache[K comparable] struct{}
+
+func (c *Cache[K]) Get(key K) {}
+`,
+	})
+
+	fileInfos, err := New(WithNaming(NamingShort)).ParseProject(projectPath)
+	require.NoError(t, err)
+
+	api := fileInfos[filepath.Join(projectPath, "api", "config.go")]
+	require.Len(t, api.Structs, 1)
+	assert.Equal(t, "Config", api.Structs[0].Name)
+	assert.Equal(t, "example.com/testproject/api.Config", api.Structs[0].ID)
+	require.Len(t, api.Interfaces, 1)
+	assert.Equal(t, "example.com/testproject/api.Handler", api.Interfaces[0].ID)
+	require.Len(t, api.GlobalVars, 1)
+	assert.Equal(t, "example.com/testproject/api.Default", api.GlobalVars[0].ID)
+	require.Len(t, api.Functions, 1)
+	assert.Equal(t, "example.com/testproject/api.New", api.Functions[0].ID)
+
+	store := fileInfos[filepath.Join(projectPath, "store", "config.go")]
+	require.Len(t, store.Structs, 2)
+	ids := []string{store.Structs[0].ID, store.Structs[1].ID}
+	assert.ElementsMatch(t, []string{"example.com/testproject/store.Config", "example.com/testproject/store.Cache"}, ids, "structs of the same name differ by ID")
+	require.Len(t, store.Methods, 1)
+	assert.Equal(t, "example.com/testproject/store.Cache.Get", store.Methods[0].ID, "methods are identified through their receiver type")
+}
@@ -0,0 +1,153 @@
+package parser
+
+import (
+	"context"
+	"fmt"
+	"go/ast"
+	"go/token"
+	gotypes "go/types"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// syntaxOnlyNote is reported for every file parsed by ParseProjectSyntax
+const syntaxOnlyNote = "syntax-only parse: items from other packages are not resolved and type errors are not reported"
+
+// ParseProjectSyntax works like ParseProject but only parses the files of the project, without loading and
+// type-checking the packages they import, which makes it much faster on large projects. It fits uses such as
+// listing imports or inventorying declarations: every package is type-checked on its own, so types from other
+// packages keep their names but are otherwise unknown, e.g. imported interfaces are taken for structs, and the
+// types of variables initialized by calls to other packages are invalid. Type errors are not reported, every
+// file carries a load diagnostic saying so instead. Results are not cached. Projects outside a module are
+// parsed like ParseProject does.
+func (p *ProjectParser) ParseProjectSyntax(projectPath string) (_ ProjectInfo, err error) {
+	defer func(start time.Time) { p.recordParse(start, err) }(time.Now())
+
+	root, err := filepath.Abs(projectPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve project path: %w", err)
+	}
+	pkgs, err := p.loadPackagesMode(context.Background(), packages.NeedName|packages.NeedFiles|packages.NeedCompiledGoFiles|packages.NeedSyntax, projectPath, nil, "./...")
+	if err != nil {
+		return nil, err
+	}
+	p.forEachPackage(pkgs, func(pkg *packages.Package) {
+		if pkg.TypesInfo == nil {
+			checkSyntaxOnly(pkg, syntaxOnlyNote)
+		}
+	})
+
+	index := p.buildSymbolIndex(pkgs)
+	fileInfos := make(ProjectInfo)
+	var mu sync.Mutex
+	p.forEachPackage(pkgs, func(pkg *packages.Package) {
+		pkgInfos := make(ProjectInfo, len(pkg.Syntax))
+		doc := p.packageDoc(pkg)
+		for _, file := range pkg.Syntax {
+			if fileInfo, ok := p.extractFile(root, file, pkg, doc, index); ok {
+				pkgInfos[p.fset.File(file.Pos()).Name()] = fileInfo
+			}
+		}
+		p.releasePackage(pkg)
+
+		mu.Lock()
+		defer mu.Unlock()
+		for path, info := range pkgInfos {
+			fileInfos[path] = info
+		}
+	})
+	return fileInfos, nil
+}
+
+// checkSyntaxOnly type-checks the files of pkg against stubs of the packages they import, see stubImporter,
+// ignoring type errors. Every file gets a load error with note, saying why the package was only parsed.
+func checkSyntaxOnly(pkg *packages.Package, note string) {
+	pkg.TypesInfo = &gotypes.Info{
+		Types:      make(map[ast.Expr]gotypes.TypeAndValue),
+		Defs:       make(map[*ast.Ident]gotypes.Object),
+		Uses:       make(map[*ast.Ident]gotypes.Object),
+		Implicits:  make(map[ast.Node]gotypes.Object),
+		Instances:  make(map[*ast.Ident]gotypes.Instance),
+		Scopes:     make(map[ast.Node]*gotypes.Scope),
+		Selections: make(map[*ast.SelectorExpr]*gotypes.Selection),
+	}
+	conf := &gotypes.Config{
+		Importer: newStubImporter(pkg.Syntax),
+		Error:    func(error) {},
+	}
+	pkg.Types, _ = conf.Check(pkg.PkgPath, pkg.Fset, pkg.Syntax, pkg.TypesInfo)
+	for _, file := range pkg.Syntax {
+		pkg.Errors = append(pkg.Errors, packages.Error{Pos: pkg.Fset.File(file.Pos()).Name() + ":1:1", Msg: note, Kind: packages.UnknownError})
+	}
+}
+
+// stubImporter imports every package as a stub declaring, as an empty struct type, every exported name the
+// files of the importing package select from it, e.g. Client for http.Client. Declarations referring to
+// them keep their qualified names without the imported package being loaded.
+type stubImporter struct {
+	selected map[string]map[string]bool // Selected names by import path
+}
+
+// newStubImporter collects the names files select from each of their imports.
+func newStubImporter(files []*ast.File) *stubImporter {
+	s := &stubImporter{selected: make(map[string]map[string]bool)}
+	for _, file := range files {
+		paths := make(map[string]string) // Import path by the name the file refers to the package with
+		for _, imp := range file.Imports {
+			path, err := strconv.Unquote(imp.Path.Value)
+			if err != nil {
+				continue
+			}
+			name := guessPackageName(path)
+			if imp.Name != nil {
+				name = imp.Name.Name
+			}
+			paths[name] = path
+			if s.selected[path] == nil {
+				s.selected[path] = make(map[string]bool)
+			}
+		}
+		ast.Inspect(file, func(n ast.Node) bool {
+			if sel, ok := n.(*ast.SelectorExpr); ok {
+				if x, ok := sel.X.(*ast.Ident); ok && paths[x.Name] != "" && sel.Sel.IsExported() {
+					s.selected[paths[x.Name]][sel.Sel.Name] = true
+				}
+			}
+			return true
+		})
+	}
+	return s
+}
+
+// Import returns the stub of the package at path.
+func (s *stubImporter) Import(path string) (*gotypes.Package, error) {
+	pkg := gotypes.NewPackage(path, guessPackageName(path))
+	for name := range s.selected[path] {
+		obj := gotypes.NewTypeName(token.NoPos, pkg, name, nil)
+		gotypes.NewNamed(obj, gotypes.NewStruct(nil, nil), nil)
+		pkg.Scope().Insert(obj)
+	}
+	pkg.MarkComplete()
+	return pkg, nil
+}
+
+// guessPackageName returns the name the package at an import path is most likely declared with: the last
+// element of the path without a major version or a go- prefix or -go suffix, e.g. yaml for gopkg.in/yaml.v3,
+// chi for github.com/go-chi/chi/v5 and tiktoken for github.com/pkoukk/tiktoken-go.
+func guessPackageName(path string) string {
+	elems := strings.Split(path, "/")
+	name := elems[len(elems)-1]
+	if len(elems) > 1 && isMajorVersion(name) {
+		name = elems[len(elems)-2]
+	}
+	if i := strings.LastIndex(name, ".v"); i > 0 && isMajorVersion(name[i+1:]) {
+		name = name[:i]
+	}
+	name = strings.TrimSuffix(strings.TrimPrefix(name, "go-"), "-go")
+	return strings.NewReplacer("-", "", ".", "").Replace(name)
+}
@@ -0,0 +1,90 @@
+package parser
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	ourtypes "github.com/vlad/ast2llm-go/internal/types"
+)
+
+func TestProjectParser_ParseProjectSyntax(t *testing.T) {
+	projectPath := writeTestProject(t, map[string]string{
+		// gopkg.in/yaml.v3 is replaced by a local stub, so that no module is downloaded
+		"go.mod":                   "module example.com/testproject\n\ngo 1.21\n\nrequire gopkg.in/yaml.v3 v3.0.1\n\nreplace gopkg.in/yaml.v3 => ./third_party/yaml\n",
+		"third_party/yaml/go.mod":  "module gopkg.in/yaml.v3\n\ngo 1.21\n",
+		"third_party/yaml/yaml.go": "package yaml\n\n// Node is a YAML node.\ntype Node struct{}\n",
+		"main.go": `package main
+
+import (
+	"net/http"
+
+	"example.com/testproject/models"
+	yaml "gopkg.in/yaml.v3"
+)
+
+// Server serves users.
+type Server struct {
+	Client *http.Client
+	Owner  models.User
+	Doc    yaml.Node
+}
+
+// Handle handles a request.
+func (s *Server) Handle(w http.ResponseWriter, r *http.Request) error { return nil }
+
+func main() {}
+`,
+		"models/user.go": `package models
+
+// User is a user.
+type User struct {
+	Name string
+	Age  int
+}
+`,
+	})
+
+	fileInfos, err := New().ParseProjectSyntax(projectPath)
+	require.NoError(t, err)
+
+	main := fileInfos[filepath.Join(projectPath, "main.go")]
+	require.NotNil(t, main)
+	assert.Equal(t, []string{"net/http", "example.com/testproject/models", "gopkg.in/yaml.v3"}, ourtypes.ImportPaths(main.Imports))
+	require.Len(t, main.Structs, 1)
+	server := main.Structs[0]
+	assert.Equal(t, "Server serves users.", server.Comment)
+	require.Len(t, server.Fields, 3)
+	assert.Equal(t, "*net/http.Client", server.Fields[0].Type, "imported types keep their names")
+	assert.Equal(t, "example.com/testproject/models.User", server.Fields[1].Type)
+	assert.Equal(t, "gopkg.in/yaml.v3.Node", server.Fields[2].Type)
+	require.Len(t, main.Methods, 1)
+	assert.Equal(t, []string{"w net/http.ResponseWriter", "r *net/http.Request"}, main.Methods[0].Params)
+
+	var usedNames []string
+	for _, s := range main.UsedImportedStructs {
+		usedNames = append(usedNames, s.Name)
+	}
+	assert.Contains(t, usedNames, "example.com/testproject/models.User")
+
+	assert.Equal(t, []*ourtypes.Diagnostic{{Kind: "load", Message: syntaxOnlyNote, Pos: &ourtypes.Position{File: filepath.Join(projectPath, "main.go"), Line: 1, Column: 1}}}, main.Diagnostics, "type errors are not reported")
+
+	user := fileInfos[filepath.Join(projectPath, "models", "user.go")]
+	require.NotNil(t, user)
+	require.Len(t, user.Structs, 1)
+	assert.Len(t, user.Structs[0].Fields, 2)
+}
+
+func TestGuessPackageName(t *testing.T) {
+	for path, want := range map[string]string{
+		"fmt":                             "fmt",
+		"net/http":                        "http",
+		"gopkg.in/yaml.v3":                "yaml",
+		"github.com/go-chi/chi/v5":        "chi",
+		"github.com/pkoukk/tiktoken-go":   "tiktoken",
+		"github.com/mark3labs/mcp-go/mcp": "mcp",
+	} {
+		assert.Equal(t, want, guessPackageName(path), path)
+	}
+}
@@ -0,0 +1,71 @@
+package parser
+
+import (
+	"fmt"
+	"go/ast"
+	gotypes "go/types"
+	"sort"
+	"strings"
+
+	ourtypes "github.com/vlad/ast2llm-go/internal/types"
+)
+
+// ListMethods loads the project and returns every method declared on the named type typeName, whichever file of
+// its package declares it, sorted by position. Methods promoted from embedded types are not included.
+// typeName is resolved like in ExtractSymbolContext and must not be an interface; a name matching types of several
+// packages is an error listing their qualified names. The fully qualified name of the type is returned too.
+func (p *ProjectParser) ListMethods(projectPath, typeName string) (string, []*ourtypes.FunctionInfo, error) {
+	pkgs, err := p.loadPackages(projectPath, "./...")
+	if err != nil {
+		return "", nil, err
+	}
+
+	sc := newSymbolContext(p, pkgs)
+	matches := make(map[string]*gotypes.Named)
+	for _, obj := range sc.lookup(typeName) {
+		if tn, ok := obj.(*gotypes.TypeName); ok {
+			if named, ok := tn.Type().(*gotypes.Named); ok && !gotypes.IsInterface(named) {
+				matches[namedTypeName(named)] = named
+			}
+		}
+	}
+	if len(matches) == 0 {
+		return "", nil, fmt.Errorf("type %s not found in %s", typeName, projectPath)
+	}
+	if len(matches) > 1 {
+		names := make([]string, 0, len(matches))
+		for name := range matches {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		return "", nil, fmt.Errorf("type %s is ambiguous in %s, use one of %s", typeName, projectPath, strings.Join(names, ", "))
+	}
+	var target *gotypes.Named
+	for _, named := range matches {
+		target = named
+	}
+
+	declared := make(map[gotypes.Object]bool, target.NumMethods())
+	for i := 0; i < target.NumMethods(); i++ {
+		declared[target.Method(i)] = true
+	}
+
+	methods := make([]*ourtypes.FunctionInfo, 0, len(declared))
+	pkg := sc.pkgs[target.Obj().Pkg().Path()]
+	for _, file := range pkg.Syntax {
+		for _, decl := range file.Decls {
+			if funcDecl, ok := decl.(*ast.FuncDecl); ok && funcDecl.Recv != nil && declared[pkg.TypesInfo.Defs[funcDecl.Name]] {
+				methods = append(methods, p.extractFunctionInfo(funcDecl, pkg))
+			}
+		}
+	}
+
+	sort.SliceStable(methods, func(i, j int) bool {
+		a, b := methods[i].Pos, methods[j].Pos
+		if a.File != b.File {
+			return a.File < b.File
+		}
+		return a.Line < b.Line
+	})
+	return namedTypeName(target), methods, nil
+}
@@ -0,0 +1,84 @@
+package parser
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProjectParser_ListMethods(t *testing.T) {
+	projectPath := writeTestProject(t, map[string]string{
+		"store/store.go": `package store
+
+// Memory keeps items in memory.
+type Memory struct {
+	Base
+	items map[string]string
+}
+
+// Get returns the item stored under key.
+func (m *Memory) Get(key string) (string, error) { return m.items[key], nil }
+
+// Base is embedded by Memory.
+type Base struct{}
+
+func (Base) ID() int { return 0 }
+
+// Store persists items.
+type Store interface {
+	Get(key string) (string, error)
+}
+`,
+		"store/memory_put.go": `package store
+
+func (m *Memory) Put(key, value string) { m.items[key] = value }
+
+func (m Memory) Len() int { return len(m.items) }
+`,
+	})
+	putPath := filepath.Join(projectPath, "store", "memory_put.go")
+	storePath := filepath.Join(projectPath, "store", "store.go")
+
+	name, methods, err := New().ListMethods(projectPath, "store.Memory")
+	require.NoError(t, err)
+	assert.Equal(t, "example.com/testproject/store.Memory", name)
+
+	var listed []string
+	for _, m := range methods {
+		listed = append(listed, "("+m.Receiver+") "+m.Name+" "+filepath.Base(m.Pos.File))
+	}
+	assert.Equal(t, []string{"(*Memory) Put memory_put.go", "(Memory) Len memory_put.go", "(*Memory) Get store.go"}, listed,
+		"sorted by position, without promoted methods")
+	assert.Equal(t, putPath, methods[0].Pos.File)
+	assert.Equal(t, storePath, methods[2].Pos.File)
+	assert.Equal(t, "Get returns the item stored under key.", methods[2].Comment)
+	assert.Equal(t, []string{"key string"}, methods[2].Params)
+	assert.Equal(t, []string{"string", "error"}, methods[2].Returns)
+
+	_, methods, err = New().ListMethods(projectPath, "Base")
+	require.NoError(t, err)
+	require.Len(t, methods, 1)
+	assert.Equal(t, "ID", methods[0].Name)
+
+	_, _, err = New().ListMethods(projectPath, "Store")
+	assert.ErrorContains(t, err, "type Store not found")
+}
+
+func TestProjectParser_ListMethods_Ambiguous(t *testing.T) {
+	projectPath := writeTestProject(t, map[string]string{
+		"store/store.go": "package store\n\ntype Client struct{}\n\nfunc (Client) Save() {}\n",
+		"api/api.go":     "package api\n\ntype Client struct{}\n\nfunc (Client) Get() {}\n",
+	})
+
+	_, _, err := New().ListMethods(projectPath, "Client")
+	assert.ErrorContains(t, err, "type Client is ambiguous")
+	assert.ErrorContains(t, err, "use one of example.com/testproject/api.Client, example.com/testproject/store.Client")
+
+	name, methods, err := New().ListMethods(projectPath, "store.Client")
+	require.NoError(t, err)
+	assert.Equal(t, "example.com/testproject/store.Client", name)
+	require.Len(t, methods, 1)
+	assert.Equal(t, "Save", methods[0].Name)
+}
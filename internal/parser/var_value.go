@@ -0,0 +1,86 @@
+package parser
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/printer"
+	"go/token"
+	"unicode/utf8"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// defaultMaxValueLength is the default limit of rendered global variable and constant values
+const defaultMaxValueLength = 80
+
+// computedValue marks a variable initialized from an expression that is not constant, e.g. a function call
+const computedValue = "<computed>"
+
+// WithMaxValueLength sets how many characters of a global variable or constant value are kept before it is
+// truncated, 0 means no limit
+func WithMaxValueLength(n int) Option {
+	return func(p *ProjectParser) {
+		p.maxValueLength = n
+	}
+}
+
+// renderVarValue renders the initializer of a global variable.
+// Constant expressions are printed as written, composite literals are summarized by their type and number of
+// elements, e.g. "[]string{...} (3 elements)", and any other expression is rendered as computedValue.
+func (p *ProjectParser) renderVarValue(expr ast.Expr, pkg *packages.Package) string {
+	if tv, ok := pkg.TypesInfo.Types[expr]; ok && tv.Value != nil {
+		return p.truncateValue(printExpr(expr, pkg))
+	}
+
+	prefix := ""
+	if unary, ok := expr.(*ast.UnaryExpr); ok && unary.Op == token.AND {
+		prefix = "&"
+		expr = unary.X
+	}
+	lit, ok := ast.Unparen(expr).(*ast.CompositeLit)
+	if !ok {
+		return computedValue
+	}
+
+	typeName := ""
+	if lit.Type != nil {
+		typeName = printExpr(lit.Type, pkg)
+	} else if tv, ok := pkg.TypesInfo.Types[lit]; ok {
+		typeName = tv.Type.String()
+	}
+	if len(lit.Elts) == 0 {
+		return p.truncateValue(prefix + typeName + "{}")
+	}
+	return p.truncateValue(fmt.Sprintf("%s%s{...} (%d %s)", prefix, typeName, len(lit.Elts), pluralize(len(lit.Elts), "element")))
+}
+
+// truncateValue shortens value to the configured maximum length in bytes, marking the cut with "...". The cut
+// is moved back to the start of a rune, so that multi-byte characters are not split.
+func (p *ProjectParser) truncateValue(value string) string {
+	if p.maxValueLength <= 0 || len(value) <= p.maxValueLength {
+		return value
+	}
+	cut := p.maxValueLength
+	for cut > 0 && !utf8.RuneStart(value[cut]) {
+		cut--
+	}
+	return value[:cut] + "..."
+}
+
+// printExpr returns the source of expr, or "" if it cannot be printed.
+func printExpr(expr ast.Expr, pkg *packages.Package) string {
+	var buf bytes.Buffer
+	if err := printer.Fprint(&buf, pkg.Fset, expr); err != nil {
+		return ""
+	}
+	return buf.String()
+}
+
+// pluralize appends "s" to noun unless n is 1.
+func pluralize(n int, noun string) string {
+	if n == 1 {
+		return noun
+	}
+	return noun + "s"
+}
@@ -0,0 +1,92 @@
+package parser
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProjectParser_GlobalVarValues(t *testing.T) {
+	projectPath := writeTestProject(t, map[string]string{
+		"main.go": `package main
+
+import "errors"
+
+type Config struct {
+	Name string
+	Port int
+}
+
+const Greeting = "hello"
+
+var (
+	Limit      = 1 << 10
+	Name       = "app"
+	Names      = []string{"a", "b", "c"}
+	Ports      = map[string]int{"http": 80}
+	Empty      = []int{}
+	Default    = &Config{Name: "default", Port: 8080}
+	ErrMissing = errors.New("missing")
+	Handler    = func() {}
+	Copy       = Name
+	Long       = "` + strings.Repeat("x", 100) + `"
+	First, Second = pair()
+)
+
+func pair() (int, int) { return 1, 2 }
+
+func main() {}
+`,
+	})
+
+	values := func(p *ProjectParser) map[string]string {
+		fileInfos, err := p.ParseProject(projectPath)
+		require.NoError(t, err)
+		fileInfo := fileInfos[filepath.Join(projectPath, "main.go")]
+		require.NotNil(t, fileInfo)
+		result := make(map[string]string)
+		for _, gv := range fileInfo.GlobalVars {
+			result[gv.Name] = gv.Value
+		}
+		return result
+	}
+
+	got := values(New())
+	assert.Equal(t, `"hello"`, got["Greeting"])
+	assert.Equal(t, "1 << 10", got["Limit"])
+	assert.Equal(t, `"app"`, got["Name"])
+	assert.Equal(t, "[]string{...} (3 elements)", got["Names"])
+	assert.Equal(t, "map[string]int{...} (1 element)", got["Ports"])
+	assert.Equal(t, "[]int{}", got["Empty"])
+	assert.Equal(t, "&Config{...} (2 elements)", got["Default"])
+	assert.Equal(t, "<computed>", got["ErrMissing"])
+	assert.Equal(t, "<computed>", got["Handler"])
+	assert.Equal(t, "<computed>", got["Copy"])
+	assert.Equal(t, "<computed>", got["First"])
+	assert.Equal(t, "<computed>", got["Second"])
+	assert.Equal(t, `"`+strings.Repeat("x", 79)+"...", got["Long"])
+
+	t.Run("custom limit", func(t *testing.T) {
+		got := values(New(WithMaxValueLength(10)))
+		assert.Equal(t, "[]string{....", got["Names"])
+		assert.Equal(t, `"hello"`, got["Greeting"])
+	})
+
+	t.Run("no limit", func(t *testing.T) {
+		got := values(New(WithMaxValueLength(0)))
+		assert.Equal(t, `"`+strings.Repeat("x", 100)+`"`, got["Long"])
+	})
+}
+
+func TestProjectParser_TruncateValue(t *testing.T) {
+	p := New(WithMaxValueLength(3))
+	assert.Equal(t, `"h...`, p.truncateValue(`"héllo"`), "the cut falls inside é")
+	assert.Equal(t, `"...`, p.truncateValue(`"日本語"`), "the cut falls inside 日")
+	assert.True(t, utf8.ValidString(p.truncateValue(`"日本語"`)))
+	assert.Equal(t, `"ab...`, p.truncateValue(`"abcdef"`))
+	assert.Equal(t, `"a"`, p.truncateValue(`"a"`))
+}
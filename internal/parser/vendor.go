@@ -0,0 +1,37 @@
+package parser
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// WithVendor makes the go command loading the packages resolve dependencies from the vendor directory of the
+// module (-mod=vendor) instead of the module cache, so that environments without access to a module proxy
+// still get their types. It is applied without the option to projects with a vendor/modules.txt.
+func WithVendor() Option {
+	return func(p *ProjectParser) {
+		p.vendor = true
+	}
+}
+
+// vendored reports whether the module or workspace projectPath belongs to has a vendor directory made by
+// go mod vendor or go work vendor.
+func vendored(projectPath string) bool {
+	dir, err := filepath.Abs(projectPath)
+	if err != nil {
+		return false
+	}
+	for {
+		for _, name := range []string{"go.work", "go.mod"} {
+			if _, err := os.Stat(filepath.Join(dir, name)); err == nil {
+				_, err := os.Stat(filepath.Join(dir, "vendor", "modules.txt"))
+				return err == nil
+			}
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return false
+		}
+		dir = parent
+	}
+}
@@ -0,0 +1,60 @@
+package parser
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vlad/ast2llm-go/internal/testutil"
+)
+
+// writeVendoredProject writes a module depending on example.com/dep, which is only available from its vendor
+// directory.
+func writeVendoredProject(t *testing.T) string {
+	t.Helper()
+	return testutil.WriteModule(t, "example.com/testproject", map[string]string{
+		"go.mod":                        "module example.com/testproject\n\ngo 1.21\n\nrequire example.com/dep v1.0.0\n",
+		"vendor/modules.txt":            "# example.com/dep v1.0.0\n## explicit\nexample.com/dep\n",
+		"vendor/example.com/dep/dep.go": "package dep\n\n// Client talks to the service.\ntype Client struct {\n\tAddr string\n}\n",
+		"vendor/example.com/dep/go.mod": "module example.com/dep\n",
+		"main.go":                       "package main\n\nimport \"example.com/dep\"\n\nvar client = dep.Client{Addr: \"localhost\"}\n\nfunc main() {}\n",
+	})
+}
+
+func TestProjectParser_ParseProject_Vendor(t *testing.T) {
+	// Without -mod=vendor, the go command would try to download the dependency
+	t.Setenv("GOFLAGS", "-mod=mod")
+	t.Setenv("GOPROXY", "off")
+	projectPath := writeVendoredProject(t)
+
+	for name, p := range map[string]*ProjectParser{
+		"detected": New(WithExternalTypes()),
+		"forced":   New(WithExternalTypes(), WithVendor()),
+	} {
+		t.Run(name, func(t *testing.T) {
+			info, err := p.ParseProject(projectPath)
+			require.NoError(t, err)
+			require.Len(t, info, 1, "vendored packages are not part of the project")
+
+			mainInfo := info[filepath.Join(projectPath, "main.go")]
+			require.NotNil(t, mainInfo)
+			assert.Empty(t, mainInfo.Diagnostics)
+			require.Len(t, mainInfo.ExternalStructs, 1)
+			assert.Equal(t, "example.com/dep.Client", mainInfo.ExternalStructs[0].Name)
+			require.Len(t, mainInfo.ExternalStructs[0].Fields, 1)
+			assert.Equal(t, "Addr", mainInfo.ExternalStructs[0].Fields[0].Name)
+		})
+	}
+}
+
+func TestVendored(t *testing.T) {
+	projectPath := writeVendoredProject(t)
+	assert.True(t, vendored(projectPath))
+	assert.True(t, vendored(filepath.Join(projectPath, "vendor", "example.com")), "subdirectories belong to the module")
+
+	require.NoError(t, os.Remove(filepath.Join(projectPath, "vendor", "modules.txt")))
+	assert.False(t, vendored(projectPath))
+	assert.False(t, vendored(t.TempDir()))
+}
@@ -0,0 +1,110 @@
+package parser
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// Severity ranks the warnings reported while parsing
+type Severity int
+
+const (
+	SeverityInfo    Severity = iota // Nothing is lost, e.g. a file skipped by an exclude pattern
+	SeverityWarning                 // The result is incomplete, e.g. an import could not be resolved
+	SeverityError                   // A file or package could not be loaded properly, e.g. it has syntax errors
+)
+
+// severityNames holds the name of every Severity accepted by ParseSeverity, indexed by its value
+var severityNames = []string{"info", "warning", "error"}
+
+// ParseSeverity converts a severity name (info, warning or error) to a Severity
+func ParseSeverity(name string) (Severity, error) {
+	for s, n := range severityNames {
+		if n == name {
+			return Severity(s), nil
+		}
+	}
+	return 0, fmt.Errorf("unknown severity: %s", name)
+}
+
+// String returns the name of the severity
+func (s Severity) String() string {
+	if s >= 0 && int(s) < len(severityNames) {
+		return severityNames[s]
+	}
+	return fmt.Sprintf("Severity(%d)", int(s))
+}
+
+// Kinds of warnings
+const (
+	WarningSkipped = "skipped" // A file was left out of the result, by an exclude pattern or as generated code
+	WarningImport  = "import"  // An imported package could not be loaded
+	WarningType    = "type"    // A type error
+	WarningSyntax  = "syntax"  // A syntax error
+	WarningLoad    = "load"    // The go command reported a problem with a package, or could not be used
+)
+
+// Warning is a problem found while parsing a project
+type Warning struct {
+	Severity Severity
+	Kind     string // One of the Warning kinds, e.g. WarningImport
+	Package  string // Path of the package concerned, empty if unknown
+	Pos      string // Position concerned as file:line:column, or the file path; empty if unknown
+	Message  string
+}
+
+// String formats the warning as a log line, e.g. "warning: import: main.go:3:8: could not import ..."
+func (w Warning) String() string {
+	parts := []string{w.Severity.String(), w.Kind}
+	if w.Pos != "" {
+		parts = append(parts, w.Pos)
+	} else if w.Package != "" {
+		parts = append(parts, w.Package)
+	}
+	return strings.Join(append(parts, w.Message), ": ")
+}
+
+// WarningFunc receives the warnings of parses as they are found. Calls are never concurrent.
+type WarningFunc func(Warning)
+
+// WithWarnings streams the warnings found while parsing to fn, e.g. to show them live under a progress bar,
+// instead of logging them. Without it, warnings and errors are logged and informational warnings dropped.
+func WithWarnings(fn WarningFunc) Option {
+	return func(p *ProjectParser) {
+		p.warnings = fn
+	}
+}
+
+// warn reports w to the WarningFunc of the parser, or logs it if there is none.
+func (p *ProjectParser) warn(w Warning) {
+	if p.warnings == nil {
+		if w.Severity > SeverityInfo {
+			log.Print(w)
+		}
+		return
+	}
+	p.warningsMu.Lock()
+	defer p.warningsMu.Unlock()
+	p.warnings(w)
+}
+
+// packageWarning converts an error of the package at pkgPath to a warning. Imports that cannot be resolved,
+// which the type checker reports as type errors, get their own kind.
+func packageWarning(pkgPath string, err packages.Error) Warning {
+	w := Warning{Severity: SeverityWarning, Kind: WarningLoad, Package: pkgPath, Pos: err.Pos, Message: err.Msg}
+	switch err.Kind {
+	case packages.ListError:
+		w.Severity = SeverityError
+	case packages.ParseError:
+		w.Severity, w.Kind = SeverityError, WarningSyntax
+	case packages.TypeError:
+		w.Kind = WarningType
+		if strings.HasPrefix(err.Msg, "could not import ") {
+			w.Kind = WarningImport
+		}
+	}
+	return w
+}
@@ -0,0 +1,81 @@
+package parser
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// collectWarnings returns a parser option collecting the warnings it reports in warnings
+func collectWarnings(warnings *[]Warning) Option {
+	return WithWarnings(func(w Warning) { *warnings = append(*warnings, w) })
+}
+
+func TestProjectParser_Warnings(t *testing.T) {
+	projectPath := writeTestProject(t, map[string]string{
+		"main.go":      "package main\n\nfunc main() { var unused int }\n",
+		"broken/a.go":  "package broken\n\nfunc A( {}\n",
+		"gen/gen.go":   "// Code generated by hand. DO NOT EDIT.\n\npackage gen\n",
+		"skip/skip.go": "package skip\n",
+	})
+
+	var warnings []Warning
+	_, err := New(collectWarnings(&warnings), WithExcludeGlobs("skip/**"), WithGeneratedFiles(GeneratedSkip)).ParseProject(projectPath)
+	require.NoError(t, err)
+
+	byKind := make(map[string][]Warning)
+	for _, w := range warnings {
+		byKind[w.Kind] = append(byKind[w.Kind], w)
+	}
+	require.Len(t, byKind[WarningType], 1)
+	assert.Equal(t, SeverityWarning, byKind[WarningType][0].Severity)
+	assert.Equal(t, "example.com/testproject", byKind[WarningType][0].Package)
+	assert.Equal(t, filepath.Join(projectPath, "main.go")+":3:19", byKind[WarningType][0].Pos)
+	assert.Contains(t, byKind[WarningType][0].Message, "declared and not used")
+
+	require.NotEmpty(t, byKind[WarningSyntax])
+	assert.Equal(t, SeverityError, byKind[WarningSyntax][0].Severity)
+	assert.Equal(t, "example.com/testproject/broken", byKind[WarningSyntax][0].Package)
+
+	require.Len(t, byKind[WarningSkipped], 2)
+	skipped := map[string]string{}
+	for _, w := range byKind[WarningSkipped] {
+		assert.Equal(t, SeverityInfo, w.Severity)
+		skipped[w.Pos] = w.Message
+	}
+	assert.Equal(t, map[string]string{
+		filepath.Join(projectPath, "skip", "skip.go"): "excluded by pattern",
+		filepath.Join(projectPath, "gen", "gen.go"):   "generated file",
+	}, skipped)
+
+	t.Run("without module", func(t *testing.T) {
+		dir := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "main.go"), []byte("package main\n\nfunc main() {}\n"), 0644))
+		var warnings []Warning
+		_, err := New(collectWarnings(&warnings)).ParseProject(dir)
+		require.NoError(t, err)
+		require.Len(t, warnings, 1)
+		assert.Equal(t, Warning{Severity: SeverityWarning, Kind: WarningLoad, Pos: dir, Message: "no go.mod found, parsing its files without a module"}, warnings[0])
+	})
+}
+
+func TestWarning_String(t *testing.T) {
+	assert.Equal(t, "warning: import: main.go:3:8: could not import x",
+		Warning{Severity: SeverityWarning, Kind: WarningImport, Package: "example.com/app", Pos: "main.go:3:8", Message: "could not import x"}.String())
+	assert.Equal(t, "error: load: example.com/app: no Go files",
+		Warning{Severity: SeverityError, Kind: WarningLoad, Package: "example.com/app", Message: "no Go files"}.String())
+}
+
+func TestParseSeverity(t *testing.T) {
+	for _, name := range []string{"info", "warning", "error"} {
+		s, err := ParseSeverity(name)
+		require.NoError(t, err)
+		assert.Equal(t, name, s.String())
+	}
+	_, err := ParseSeverity("fatal")
+	assert.EqualError(t, err, "unknown severity: fatal")
+	assert.Equal(t, "Severity(7)", Severity(7).String())
+}
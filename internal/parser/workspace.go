@@ -0,0 +1,37 @@
+package parser
+
+import (
+	"path/filepath"
+
+	"github.com/vlad/ast2llm-go/internal/modinfo"
+)
+
+// workspacePatterns repeats the "./..." pattern for every module of the go.work workspace at projectPath,
+// as in workspace mode it only matches the module at the workspace root, if any. Other patterns and
+// projects without a go.work file are returned unchanged.
+func workspacePatterns(projectPath string, patterns []string) []string {
+	dirs, err := modinfo.WorkspaceDirs(projectPath)
+	if err != nil || len(dirs) == 0 {
+		return patterns
+	}
+	root, err := filepath.Abs(projectPath)
+	if err != nil {
+		return patterns
+	}
+
+	result := make([]string, 0, len(patterns)+len(dirs))
+	for _, pattern := range patterns {
+		if pattern != "./..." {
+			result = append(result, pattern)
+			continue
+		}
+		for _, dir := range dirs {
+			rel, err := filepath.Rel(root, dir)
+			if err != nil {
+				continue
+			}
+			result = append(result, "./"+filepath.ToSlash(filepath.Join(rel, "...")))
+		}
+	}
+	return result
+}
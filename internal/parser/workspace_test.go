@@ -0,0 +1,59 @@
+package parser
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vlad/ast2llm-go/internal/testutil"
+)
+
+func TestProjectParser_ParseProject_Workspace(t *testing.T) {
+	t.Setenv("GOFLAGS", "") // -mod=mod is rejected in workspace mode
+	root := t.TempDir()
+	files := map[string]string{
+		"go.work":    "go 1.21\n\nuse (\n\t./api\n\t./service\n)\n",
+		"api/go.mod": "module example.com/api\n\ngo 1.21\n",
+		"api/user.go": `package api
+
+// User is an account.
+type User struct {
+	Name string
+}
+
+// NewUser creates a user.
+func NewUser(name string) *User { return &User{Name: name} }
+`,
+		"service/go.mod": "module example.com/service\n\ngo 1.21\n\nrequire example.com/api v0.0.0\n",
+		"service/main.go": `package main
+
+import "example.com/api"
+
+func main() {
+	_ = api.NewUser("gopher")
+}
+`,
+	}
+	testutil.WriteFiles(t, root, files)
+
+	info, err := New().ParseProject(root)
+	require.NoError(t, err)
+
+	userFile := filepath.Join(root, "api", "user.go")
+	mainFile := filepath.Join(root, "service", "main.go")
+	require.Contains(t, info, userFile)
+	require.Contains(t, info, mainFile)
+	require.Len(t, info[mainFile].UsedImportedFunctions, 1)
+	assert.Equal(t, "example.com/api.NewUser", info[mainFile].UsedImportedFunctions[0].Name)
+	assert.Equal(t, "NewUser creates a user.", info[mainFile].UsedImportedFunctions[0].Comment)
+}
+
+func TestWorkspacePatterns(t *testing.T) {
+	root := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(root, "go.work"), []byte("go 1.21\n\nuse (\n\t.\n\t./modules/a\n)\n"), 0644))
+
+	assert.Equal(t, []string{"./...", "./modules/a/...", "fmt"}, workspacePatterns(root, []string{"./...", "fmt"}))
+	assert.Equal(t, []string{"./..."}, workspacePatterns(t.TempDir(), []string{"./..."}))
+}
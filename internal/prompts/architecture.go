@@ -0,0 +1,59 @@
+package prompts
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/vlad/ast2llm-go/internal/composer"
+	"github.com/vlad/ast2llm-go/internal/parser"
+)
+
+// ArchitecturePromptArgs defines arguments for the architecture prompt
+type ArchitecturePromptArgs struct {
+	ProjectPath string `json:"projectPath" jsonschema:"required,description=Path to the Go project"`
+}
+
+// NewArchitecturePrompt returns the mcp.Prompt for explaining the architecture of a project
+func NewArchitecturePrompt() mcp.Prompt {
+	return mcp.NewPrompt("architecture",
+		mcp.WithPromptDescription("Explain the architecture of a Go project from its package dependency graph"),
+		mcp.WithArgument("projectPath",
+			mcp.RequiredArgument(),
+			mcp.ArgumentDescription("Path to the Go project"),
+		),
+	)
+}
+
+// ArchitecturePromptHandler returns a handler for the architecture prompt
+func ArchitecturePromptHandler(p parser.Parser) func(context.Context, mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+	return func(ctx context.Context, request mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+		projectPath := request.Params.Arguments["projectPath"]
+		if projectPath == "" {
+			return nil, fmt.Errorf("projectPath is required")
+		}
+
+		graph, err := p.BuildGraphContext(ctx, projectPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to build dependency graph: %v", err)
+		}
+		fileInfos, err := p.ParseProject(projectPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse project: %v", err)
+		}
+		overview := composer.New(fileInfos).ComposeArchitecture(graph)
+
+		messages := []mcp.PromptMessage{
+			mcp.NewPromptMessage(
+				"system",
+				mcp.NewTextContent("You are a Go software architect. Explain the architecture of the provided project: its layers and the responsibility of each package, how the packages depend on each other, the main entry points and abstractions, and any structural problems such as cycles or packages doing too much."),
+			),
+			mcp.NewPromptMessage(
+				"user",
+				mcp.NewTextContent("Here are the packages of the project in dependency order, with their doc comments, project imports and exported API:\n\n"+overview),
+			),
+		}
+
+		return mcp.NewGetPromptResult("Explain the architecture of a Go project from its package dependency graph", messages), nil
+	}
+}
@@ -0,0 +1,65 @@
+package prompts
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vlad/ast2llm-go/internal/parser"
+)
+
+func TestNewArchitecturePrompt(t *testing.T) {
+	prompt := NewArchitecturePrompt()
+
+	assert.Equal(t, "architecture", prompt.Name)
+	require.Len(t, prompt.Arguments, 1)
+	assert.Equal(t, "projectPath", prompt.Arguments[0].Name)
+	assert.True(t, prompt.Arguments[0].Required)
+}
+
+func TestArchitecturePromptHandler(t *testing.T) {
+	root := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(root, "go.mod"), []byte("module testproject\ngo 1.21\n"), 0644))
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "store"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "store", "store.go"), []byte(`// Package store persists users.
+package store
+
+// Store keeps users
+type Store struct{}
+
+// Open opens the store
+func Open() *Store { return &Store{} }
+`), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "main.go"), []byte(`package main
+
+import "testproject/store"
+
+func main() { store.Open() }
+`), 0644))
+
+	handler := ArchitecturePromptHandler(parser.New())
+
+	t.Run("valid request", func(t *testing.T) {
+		result, err := handler(context.Background(), mcp.GetPromptRequest{
+			Params: mcp.GetPromptParams{Arguments: map[string]string{"projectPath": root}},
+		})
+		require.NoError(t, err)
+		require.Len(t, result.Messages, 2)
+		assert.Equal(t, mcp.Role("system"), result.Messages[0].Role)
+
+		overview := result.Messages[1].Content.(mcp.TextContent).Text
+		assert.Contains(t, overview, "Package: testproject/store\n  Doc: Package store persists users.\n  Imported by: testproject\n")
+		assert.Contains(t, overview, "    - struct Store\n    - func Open() -> (*testproject/store.Store)\n")
+		assert.Contains(t, overview, "Package: testproject\n  Imports: testproject/store\n")
+	})
+
+	t.Run("missing required args", func(t *testing.T) {
+		_, err := handler(context.Background(), mcp.GetPromptRequest{})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "projectPath is required")
+	})
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
+	"github.com/vlad/ast2llm-go/internal/composer"
 	"github.com/vlad/ast2llm-go/internal/parser"
 )
 
@@ -35,7 +36,7 @@ func NewEnhancePrompt() mcp.Prompt {
 }
 
 // EnhancePromptHandler returns a handler for the enhance prompt
-func EnhancePromptHandler(p *parser.ProjectParser) func(context.Context, mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+func EnhancePromptHandler(p parser.Parser) func(context.Context, mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
 	return func(ctx context.Context, request mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
 		projectPath := request.Params.Arguments["projectPath"]
 		focusSymbol := request.Params.Arguments["focusSymbol"]
@@ -50,20 +51,9 @@ func EnhancePromptHandler(p *parser.ProjectParser) func(context.Context, mcp.Get
 			return nil, fmt.Errorf("failed to parse project: %v", err)
 		}
 
-		// Convert map to slice for consistent JSON output
-		var fileInfosSlice []interface{}
-		for filePath, fi := range fileInfos {
-			// Include file path in the JSON for context
-			fileInfoMap := map[string]interface{}{
-				"filePath": filePath,
-				"fileInfo": fi,
-			}
-			fileInfosSlice = append(fileInfosSlice, fileInfoMap)
-		}
-
-		projectInfoJSON, err := json.MarshalIndent(fileInfosSlice, "", "  ")
+		projectText, err := projectContext(fileInfos, focusSymbol, minify)
 		if err != nil {
-			return nil, fmt.Errorf("failed to marshal project info: %v", err)
+			return nil, err
 		}
 
 		messages := []mcp.PromptMessage{
@@ -73,14 +63,14 @@ func EnhancePromptHandler(p *parser.ProjectParser) func(context.Context, mcp.Get
 			),
 			mcp.NewPromptMessage(
 				"user",
-				mcp.NewTextContent("Here is the project structure and parsed AST information:\n\n```json\n"+string(projectInfoJSON)+"\n```"),
+				mcp.NewTextContent("Here is the project structure and parsed AST information:\n\n"+projectText),
 			),
 		}
 
 		// Check if any fileInfo has content
 		hasContent := false
 		for _, fi := range fileInfos {
-			if fi.PackageName != "" || len(fi.Imports) > 0 || len(fi.Functions) > 0 || len(fi.Structs) > 0 || len(fi.UsedImportedStructs) > 0 {
+			if fi.PackageName != "" || len(fi.Imports) > 0 || len(fi.Functions) > 0 || len(fi.Structs) > 0 || len(fi.UsedImportedStructs) > 0 || len(fi.UsedImportedInterfaces) > 0 {
 				hasContent = true
 				break
 			}
@@ -100,13 +90,6 @@ func EnhancePromptHandler(p *parser.ProjectParser) func(context.Context, mcp.Get
 			))
 		}
 
-		if minify {
-			messages = append(messages, mcp.NewPromptMessage(
-				"user",
-				mcp.NewTextContent("Please remove all comments and format the code to be more concise."),
-			))
-		}
-
 		desc := "Enhance Go project code with better documentation and error handling"
 		if desc == "" {
 			desc = "stub description"
@@ -116,8 +99,38 @@ func EnhancePromptHandler(p *parser.ProjectParser) func(context.Context, mcp.Get
 	}
 }
 
+// projectContext renders the parsed project for the enhance prompt: as indented JSON, or when minify is set as
+// the minified Compose output of every file, without comments and with package paths abbreviated.
+func projectContext(fileInfos parser.ProjectInfo, focusSymbol string, minify bool) (string, error) {
+	if minify {
+		opts := []composer.Option{composer.WithMinify()}
+		if focusSymbol != "" {
+			opts = append(opts, composer.WithFocusSymbol(focusSymbol))
+		}
+		return composeFiles(fileInfos, opts...)
+	}
+
+	// Convert map to slice for consistent JSON output
+	var fileInfosSlice []interface{}
+	for filePath, fi := range fileInfos {
+		// Include file path in the JSON for context
+		fileInfoMap := map[string]interface{}{
+			"filePath": filePath,
+			"fileInfo": fi,
+		}
+		fileInfosSlice = append(fileInfosSlice, fileInfoMap)
+	}
+	projectInfoJSON, err := json.MarshalIndent(fileInfosSlice, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal project info: %v", err)
+	}
+	return "```json\n" + string(projectInfoJSON) + "\n```", nil
+}
+
 // RegisterPrompts registers all prompts with the MCP server
-func RegisterPrompts(s *server.MCPServer, p *parser.ProjectParser) error {
+func RegisterPrompts(s *server.MCPServer, p parser.Parser) error {
 	s.AddPrompt(NewEnhancePrompt(), EnhancePromptHandler(p))
+	s.AddPrompt(NewRefactorPrompt(), RefactorPromptHandler(p))
+	s.AddPrompt(NewArchitecturePrompt(), ArchitecturePromptHandler(p))
 	return nil
 }
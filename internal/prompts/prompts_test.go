@@ -149,11 +149,16 @@ func TestEnhancePromptHandler(t *testing.T) {
 				assert.Contains(t, textContent.Text, tt.args["focusSymbol"])
 			}
 
-			// Check for minify message if applicable
+			// Minified context is composed without comments instead of asking the LLM to drop them
 			if tt.args["minify"] == "true" {
-				// The minify message is the last one added if minify is true
-				lastMsg := result.Messages[len(result.Messages)-1]
-				assert.Contains(t, lastMsg.Content.(mcp.TextContent).Text, "remove all comments and format the code to be more concise.")
+				assert.Contains(t, textContent.Text, "Struct: testproject.MyStruct\n")
+				assert.NotContains(t, textContent.Text, "MyStruct is a struct")
+				assert.NotContains(t, textContent.Text, "```json")
+				for _, msg := range result.Messages {
+					assert.NotContains(t, msg.Content.(mcp.TextContent).Text, "remove all comments")
+				}
+			} else {
+				assert.Contains(t, textContent.Text, "MyStruct is a struct")
 			}
 
 		})
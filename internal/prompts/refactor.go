@@ -0,0 +1,146 @@
+package prompts
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/vlad/ast2llm-go/internal/composer"
+	"github.com/vlad/ast2llm-go/internal/parser"
+)
+
+// Refactoring goals supported by the refactor prompt
+const (
+	GoalRename           = "rename"
+	GoalExtractInterface = "extract-interface"
+	GoalSplitFunction    = "split-function"
+)
+
+// refactorGoals maps every supported goal to the instructions sent for it
+var refactorGoals = map[string]string{
+	GoalRename:           "Rename the target symbol and update every reference to it. Keep behavior and exported API otherwise unchanged.",
+	GoalExtractInterface: "Extract an interface describing the methods of the target type that its callers rely on, and make the callers depend on the interface instead of the concrete type.",
+	GoalSplitFunction:    "Split the target function into smaller, well-named functions. Keep its signature and behavior unchanged.",
+}
+
+// RefactorGoals returns the supported refactoring goals, sorted
+func RefactorGoals() []string {
+	goals := make([]string, 0, len(refactorGoals))
+	for goal := range refactorGoals {
+		goals = append(goals, goal)
+	}
+	sort.Strings(goals)
+	return goals
+}
+
+// RefactorPromptArgs defines arguments for the refactor prompt
+type RefactorPromptArgs struct {
+	ProjectPath  string `json:"projectPath" jsonschema:"required,description=Path to the Go project"`
+	TargetSymbol string `json:"targetSymbol" jsonschema:"required,description=Symbol to refactor"`
+	Goal         string `json:"goal" jsonschema:"required,description=Refactoring goal"`
+	NewName      string `json:"newName" jsonschema:"description=New name of the symbol when renaming"`
+	Constraints  string `json:"constraints" jsonschema:"description=Additional constraints the refactoring must respect"`
+}
+
+// NewRefactorPrompt returns the mcp.Prompt for refactoring a single symbol
+func NewRefactorPrompt() mcp.Prompt {
+	return mcp.NewPrompt("refactor",
+		mcp.WithPromptDescription("Refactor a Go symbol with a minimal diff"),
+		mcp.WithArgument("projectPath",
+			mcp.RequiredArgument(),
+			mcp.ArgumentDescription("Path to the Go project"),
+		),
+		mcp.WithArgument("targetSymbol",
+			mcp.RequiredArgument(),
+			mcp.ArgumentDescription("Symbol to refactor, e.g. Name, pkg.Name or Type.Method"),
+		),
+		mcp.WithArgument("goal",
+			mcp.RequiredArgument(),
+			mcp.ArgumentDescription("Refactoring goal: "+strings.Join(RefactorGoals(), ", ")),
+		),
+		mcp.WithArgument("newName",
+			mcp.ArgumentDescription("New name of the symbol when renaming"),
+		),
+		mcp.WithArgument("constraints",
+			mcp.ArgumentDescription("Additional constraints the refactoring must respect"),
+		),
+	)
+}
+
+// RefactorPromptHandler returns a handler for the refactor prompt
+func RefactorPromptHandler(p parser.Parser) func(context.Context, mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+	return func(ctx context.Context, request mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+		projectPath := request.Params.Arguments["projectPath"]
+		targetSymbol := request.Params.Arguments["targetSymbol"]
+		goal := request.Params.Arguments["goal"]
+		newName := request.Params.Arguments["newName"]
+		constraints := request.Params.Arguments["constraints"]
+
+		if projectPath == "" {
+			return nil, fmt.Errorf("projectPath is required")
+		}
+		if targetSymbol == "" {
+			return nil, fmt.Errorf("targetSymbol is required")
+		}
+		instructions, ok := refactorGoals[goal]
+		if !ok {
+			return nil, fmt.Errorf("unsupported goal %q, expected one of %s", goal, strings.Join(RefactorGoals(), ", "))
+		}
+
+		fragments, err := p.ExtractSymbolContext(projectPath, targetSymbol)
+		if err != nil {
+			return nil, fmt.Errorf("failed to extract symbol context: %v", err)
+		}
+		symbolContext, err := composeFiles(fragments, composer.WithFocusSymbol(targetSymbol))
+		if err != nil {
+			return nil, err
+		}
+
+		task := fmt.Sprintf("Goal: %s\nTarget symbol: %s\n%s", goal, targetSymbol, instructions)
+		if goal == GoalRename && newName != "" {
+			task += fmt.Sprintf("\nNew name: %s", newName)
+		}
+		if constraints != "" {
+			task += "\nConstraints: " + constraints
+		}
+
+		messages := []mcp.PromptMessage{
+			mcp.NewPromptMessage(
+				"system",
+				mcp.NewTextContent("You are a Go refactoring assistant. Answer with a minimal unified diff that performs the requested refactoring and nothing else: do not reformat, reorder or otherwise touch unrelated code."),
+			),
+			mcp.NewPromptMessage(
+				"user",
+				mcp.NewTextContent("Here is the context of the target symbol, its referenced types and its callers:\n\n"+symbolContext),
+			),
+			mcp.NewPromptMessage(
+				"user",
+				mcp.NewTextContent(task),
+			),
+		}
+
+		return mcp.NewGetPromptResult("Refactor a Go symbol with a minimal diff", messages), nil
+	}
+}
+
+// composeFiles composes every file of fileInfos with opts, sorted by path
+func composeFiles(fileInfos parser.ProjectInfo, opts ...composer.Option) (string, error) {
+	paths := make([]string, 0, len(fileInfos))
+	for path := range fileInfos {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	c := composer.New(fileInfos, opts...)
+	var builder strings.Builder
+	for _, path := range paths {
+		text, err := c.Compose(path)
+		if err != nil {
+			return "", fmt.Errorf("failed to compose %s: %v", path, err)
+		}
+		builder.WriteString(text + "\n")
+	}
+	return builder.String(), nil
+}
@@ -0,0 +1,113 @@
+package prompts
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vlad/ast2llm-go/internal/parser"
+)
+
+func TestNewRefactorPrompt(t *testing.T) {
+	prompt := NewRefactorPrompt()
+
+	assert.Equal(t, "refactor", prompt.Name)
+	required := map[string]bool{}
+	for _, arg := range prompt.Arguments {
+		required[arg.Name] = arg.Required
+	}
+	assert.Equal(t, map[string]bool{
+		"projectPath":  true,
+		"targetSymbol": true,
+		"goal":         true,
+		"newName":      false,
+		"constraints":  false,
+	}, required)
+	assert.Equal(t, []string{GoalExtractInterface, GoalRename, GoalSplitFunction}, RefactorGoals())
+}
+
+func TestRefactorPromptHandler(t *testing.T) {
+	root := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(root, "go.mod"), []byte("module testproject\ngo 1.21\n"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "main.go"), []byte(`package main
+
+// Store keeps items
+type Store struct {
+	items []string
+}
+
+// Add adds an item
+func (s *Store) Add(item string) { s.items = append(s.items, item) }
+
+func main() {
+	s := &Store{}
+	s.Add("x")
+}
+`), 0644))
+
+	handler := RefactorPromptHandler(parser.New())
+
+	tests := []struct {
+		name        string
+		args        map[string]string
+		errContains string
+		wantTask    []string
+	}{
+		{
+			name:     "rename",
+			args:     map[string]string{"projectPath": root, "targetSymbol": "Store", "goal": GoalRename, "newName": "Repository"},
+			wantTask: []string{"Goal: rename", "Target symbol: Store", "New name: Repository"},
+		},
+		{
+			name:     "extract interface with constraints",
+			args:     map[string]string{"projectPath": root, "targetSymbol": "Store", "goal": GoalExtractInterface, "constraints": "keep Store exported"},
+			wantTask: []string{"Goal: extract-interface", "Constraints: keep Store exported"},
+		},
+		{
+			name:        "missing target symbol",
+			args:        map[string]string{"projectPath": root, "goal": GoalRename},
+			errContains: "targetSymbol is required",
+		},
+		{
+			name:        "unsupported goal",
+			args:        map[string]string{"projectPath": root, "targetSymbol": "Store", "goal": "inline"},
+			errContains: "unsupported goal",
+		},
+		{
+			name:        "unknown symbol",
+			args:        map[string]string{"projectPath": root, "targetSymbol": "Missing", "goal": GoalRename},
+			errContains: "not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := handler(context.Background(), mcp.GetPromptRequest{
+				Params: mcp.GetPromptParams{Arguments: tt.args},
+			})
+			if tt.errContains != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errContains)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, result.Messages, 3)
+
+			assert.Equal(t, mcp.Role("system"), result.Messages[0].Role)
+			assert.Contains(t, result.Messages[0].Content.(mcp.TextContent).Text, "minimal unified diff")
+
+			symbolContext := result.Messages[1].Content.(mcp.TextContent).Text
+			assert.Contains(t, symbolContext, "Store")
+			assert.Contains(t, symbolContext, "main.go")
+
+			task := result.Messages[2].Content.(mcp.TextContent).Text
+			for _, want := range tt.wantTask {
+				assert.Contains(t, task, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,1436 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.12
+// 	protoc        (unknown)
+// source: internal/rpc/ast2llmpb/ast2llm.proto
+
+package ast2llmpb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Format int32
+
+const (
+	Format_FORMAT_UNSPECIFIED Format = 0
+	Format_FORMAT_TEXT        Format = 1
+	Format_FORMAT_JSON        Format = 2
+)
+
+// Enum value maps for Format.
+var (
+	Format_name = map[int32]string{
+		0: "FORMAT_UNSPECIFIED",
+		1: "FORMAT_TEXT",
+		2: "FORMAT_JSON",
+	}
+	Format_value = map[string]int32{
+		"FORMAT_UNSPECIFIED": 0,
+		"FORMAT_TEXT":        1,
+		"FORMAT_JSON":        2,
+	}
+)
+
+func (x Format) Enum() *Format {
+	p := new(Format)
+	*p = x
+	return p
+}
+
+func (x Format) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Format) Descriptor() protoreflect.EnumDescriptor {
+	return file_internal_rpc_ast2llmpb_ast2llm_proto_enumTypes[0].Descriptor()
+}
+
+func (Format) Type() protoreflect.EnumType {
+	return &file_internal_rpc_ast2llmpb_ast2llm_proto_enumTypes[0]
+}
+
+func (x Format) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Format.Descriptor instead.
+func (Format) EnumDescriptor() ([]byte, []int) {
+	return file_internal_rpc_ast2llmpb_ast2llm_proto_rawDescGZIP(), []int{0}
+}
+
+type ParseProjectRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProjectPath   string                 `protobuf:"bytes,1,opt,name=project_path,json=projectPath,proto3" json:"project_path,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ParseProjectRequest) Reset() {
+	*x = ParseProjectRequest{}
+	mi := &file_internal_rpc_ast2llmpb_ast2llm_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ParseProjectRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ParseProjectRequest) ProtoMessage() {}
+
+func (x *ParseProjectRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_rpc_ast2llmpb_ast2llm_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ParseProjectRequest.ProtoReflect.Descriptor instead.
+func (*ParseProjectRequest) Descriptor() ([]byte, []int) {
+	return file_internal_rpc_ast2llmpb_ast2llm_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ParseProjectRequest) GetProjectPath() string {
+	if x != nil {
+		return x.ProjectPath
+	}
+	return ""
+}
+
+type ParseProjectResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Files         map[string]*FileInfo   `protobuf:"bytes,1,rep,name=files,proto3" json:"files,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ParseProjectResponse) Reset() {
+	*x = ParseProjectResponse{}
+	mi := &file_internal_rpc_ast2llmpb_ast2llm_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ParseProjectResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ParseProjectResponse) ProtoMessage() {}
+
+func (x *ParseProjectResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_rpc_ast2llmpb_ast2llm_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ParseProjectResponse.ProtoReflect.Descriptor instead.
+func (*ParseProjectResponse) Descriptor() ([]byte, []int) {
+	return file_internal_rpc_ast2llmpb_ast2llm_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ParseProjectResponse) GetFiles() map[string]*FileInfo {
+	if x != nil {
+		return x.Files
+	}
+	return nil
+}
+
+type ComposeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProjectPath   string                 `protobuf:"bytes,1,opt,name=project_path,json=projectPath,proto3" json:"project_path,omitempty"`
+	FilePath      string                 `protobuf:"bytes,2,opt,name=file_path,json=filePath,proto3" json:"file_path,omitempty"`
+	Format        Format                 `protobuf:"varint,3,opt,name=format,proto3,enum=ast2llm.v1.Format" json:"format,omitempty"`
+	FocusSymbol   string                 `protobuf:"bytes,4,opt,name=focus_symbol,json=focusSymbol,proto3" json:"focus_symbol,omitempty"`
+	Budget        int32                  `protobuf:"varint,5,opt,name=budget,proto3" json:"budget,omitempty"`
+	Positions     bool                   `protobuf:"varint,6,opt,name=positions,proto3" json:"positions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ComposeRequest) Reset() {
+	*x = ComposeRequest{}
+	mi := &file_internal_rpc_ast2llmpb_ast2llm_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ComposeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ComposeRequest) ProtoMessage() {}
+
+func (x *ComposeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_rpc_ast2llmpb_ast2llm_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ComposeRequest.ProtoReflect.Descriptor instead.
+func (*ComposeRequest) Descriptor() ([]byte, []int) {
+	return file_internal_rpc_ast2llmpb_ast2llm_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ComposeRequest) GetProjectPath() string {
+	if x != nil {
+		return x.ProjectPath
+	}
+	return ""
+}
+
+func (x *ComposeRequest) GetFilePath() string {
+	if x != nil {
+		return x.FilePath
+	}
+	return ""
+}
+
+func (x *ComposeRequest) GetFormat() Format {
+	if x != nil {
+		return x.Format
+	}
+	return Format_FORMAT_UNSPECIFIED
+}
+
+func (x *ComposeRequest) GetFocusSymbol() string {
+	if x != nil {
+		return x.FocusSymbol
+	}
+	return ""
+}
+
+func (x *ComposeRequest) GetBudget() int32 {
+	if x != nil {
+		return x.Budget
+	}
+	return 0
+}
+
+func (x *ComposeRequest) GetPositions() bool {
+	if x != nil {
+		return x.Positions
+	}
+	return false
+}
+
+type ComposeResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Context       string                 `protobuf:"bytes,1,opt,name=context,proto3" json:"context,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ComposeResponse) Reset() {
+	*x = ComposeResponse{}
+	mi := &file_internal_rpc_ast2llmpb_ast2llm_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ComposeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ComposeResponse) ProtoMessage() {}
+
+func (x *ComposeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_rpc_ast2llmpb_ast2llm_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ComposeResponse.ProtoReflect.Descriptor instead.
+func (*ComposeResponse) Descriptor() ([]byte, []int) {
+	return file_internal_rpc_ast2llmpb_ast2llm_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ComposeResponse) GetContext() string {
+	if x != nil {
+		return x.Context
+	}
+	return ""
+}
+
+type BuildGraphRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProjectPath   string                 `protobuf:"bytes,1,opt,name=project_path,json=projectPath,proto3" json:"project_path,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BuildGraphRequest) Reset() {
+	*x = BuildGraphRequest{}
+	mi := &file_internal_rpc_ast2llmpb_ast2llm_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BuildGraphRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BuildGraphRequest) ProtoMessage() {}
+
+func (x *BuildGraphRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_rpc_ast2llmpb_ast2llm_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BuildGraphRequest.ProtoReflect.Descriptor instead.
+func (*BuildGraphRequest) Descriptor() ([]byte, []int) {
+	return file_internal_rpc_ast2llmpb_ast2llm_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *BuildGraphRequest) GetProjectPath() string {
+	if x != nil {
+		return x.ProjectPath
+	}
+	return ""
+}
+
+type BuildGraphResponse struct {
+	state         protoimpl.MessageState  `protogen:"open.v1"`
+	Nodes         map[string]*PackageNode `protobuf:"bytes,1,rep,name=nodes,proto3" json:"nodes,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BuildGraphResponse) Reset() {
+	*x = BuildGraphResponse{}
+	mi := &file_internal_rpc_ast2llmpb_ast2llm_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BuildGraphResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BuildGraphResponse) ProtoMessage() {}
+
+func (x *BuildGraphResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_rpc_ast2llmpb_ast2llm_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BuildGraphResponse.ProtoReflect.Descriptor instead.
+func (*BuildGraphResponse) Descriptor() ([]byte, []int) {
+	return file_internal_rpc_ast2llmpb_ast2llm_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *BuildGraphResponse) GetNodes() map[string]*PackageNode {
+	if x != nil {
+		return x.Nodes
+	}
+	return nil
+}
+
+type PackageNode struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PkgPath       string                 `protobuf:"bytes,1,opt,name=pkg_path,json=pkgPath,proto3" json:"pkg_path,omitempty"`
+	Functions     []string               `protobuf:"bytes,2,rep,name=functions,proto3" json:"functions,omitempty"`
+	DependsOn     []string               `protobuf:"bytes,3,rep,name=depends_on,json=dependsOn,proto3" json:"depends_on,omitempty"`
+	DependedOnBy  []string               `protobuf:"bytes,4,rep,name=depended_on_by,json=dependedOnBy,proto3" json:"depended_on_by,omitempty"`
+	Files         []string               `protobuf:"bytes,5,rep,name=files,proto3" json:"files,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PackageNode) Reset() {
+	*x = PackageNode{}
+	mi := &file_internal_rpc_ast2llmpb_ast2llm_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PackageNode) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PackageNode) ProtoMessage() {}
+
+func (x *PackageNode) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_rpc_ast2llmpb_ast2llm_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PackageNode.ProtoReflect.Descriptor instead.
+func (*PackageNode) Descriptor() ([]byte, []int) {
+	return file_internal_rpc_ast2llmpb_ast2llm_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *PackageNode) GetPkgPath() string {
+	if x != nil {
+		return x.PkgPath
+	}
+	return ""
+}
+
+func (x *PackageNode) GetFunctions() []string {
+	if x != nil {
+		return x.Functions
+	}
+	return nil
+}
+
+func (x *PackageNode) GetDependsOn() []string {
+	if x != nil {
+		return x.DependsOn
+	}
+	return nil
+}
+
+func (x *PackageNode) GetDependedOnBy() []string {
+	if x != nil {
+		return x.DependedOnBy
+	}
+	return nil
+}
+
+func (x *PackageNode) GetFiles() []string {
+	if x != nil {
+		return x.Files
+	}
+	return nil
+}
+
+type Position struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	File          string                 `protobuf:"bytes,1,opt,name=file,proto3" json:"file,omitempty"`
+	Line          int32                  `protobuf:"varint,2,opt,name=line,proto3" json:"line,omitempty"`
+	Column        int32                  `protobuf:"varint,3,opt,name=column,proto3" json:"column,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Position) Reset() {
+	*x = Position{}
+	mi := &file_internal_rpc_ast2llmpb_ast2llm_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Position) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Position) ProtoMessage() {}
+
+func (x *Position) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_rpc_ast2llmpb_ast2llm_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Position.ProtoReflect.Descriptor instead.
+func (*Position) Descriptor() ([]byte, []int) {
+	return file_internal_rpc_ast2llmpb_ast2llm_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *Position) GetFile() string {
+	if x != nil {
+		return x.File
+	}
+	return ""
+}
+
+func (x *Position) GetLine() int32 {
+	if x != nil {
+		return x.Line
+	}
+	return 0
+}
+
+func (x *Position) GetColumn() int32 {
+	if x != nil {
+		return x.Column
+	}
+	return 0
+}
+
+type FileInfo struct {
+	state                  protoimpl.MessageState `protogen:"open.v1"`
+	PackageName            string                 `protobuf:"bytes,1,opt,name=package_name,json=packageName,proto3" json:"package_name,omitempty"`
+	Generated              bool                   `protobuf:"varint,2,opt,name=generated,proto3" json:"generated,omitempty"`
+	Imports                []string               `protobuf:"bytes,3,rep,name=imports,proto3" json:"imports,omitempty"`
+	Functions              []*FunctionInfo        `protobuf:"bytes,4,rep,name=functions,proto3" json:"functions,omitempty"`
+	Methods                []*FunctionInfo        `protobuf:"bytes,5,rep,name=methods,proto3" json:"methods,omitempty"`
+	Structs                []*StructInfo          `protobuf:"bytes,6,rep,name=structs,proto3" json:"structs,omitempty"`
+	Interfaces             []*InterfaceInfo       `protobuf:"bytes,7,rep,name=interfaces,proto3" json:"interfaces,omitempty"`
+	GlobalVars             []*GlobalVarInfo       `protobuf:"bytes,8,rep,name=global_vars,json=globalVars,proto3" json:"global_vars,omitempty"`
+	UsedImportedStructs    []*StructInfo          `protobuf:"bytes,9,rep,name=used_imported_structs,json=usedImportedStructs,proto3" json:"used_imported_structs,omitempty"`
+	UsedImportedInterfaces []*InterfaceInfo       `protobuf:"bytes,10,rep,name=used_imported_interfaces,json=usedImportedInterfaces,proto3" json:"used_imported_interfaces,omitempty"`
+	UsedImportedFunctions  []*FunctionInfo        `protobuf:"bytes,11,rep,name=used_imported_functions,json=usedImportedFunctions,proto3" json:"used_imported_functions,omitempty"`
+	UsedImportedGlobalVars []*GlobalVarInfo       `protobuf:"bytes,12,rep,name=used_imported_global_vars,json=usedImportedGlobalVars,proto3" json:"used_imported_global_vars,omitempty"`
+	ExternalStructs        []*StructInfo          `protobuf:"bytes,13,rep,name=external_structs,json=externalStructs,proto3" json:"external_structs,omitempty"`
+	ExternalInterfaces     []*InterfaceInfo       `protobuf:"bytes,14,rep,name=external_interfaces,json=externalInterfaces,proto3" json:"external_interfaces,omitempty"`
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *FileInfo) Reset() {
+	*x = FileInfo{}
+	mi := &file_internal_rpc_ast2llmpb_ast2llm_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FileInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FileInfo) ProtoMessage() {}
+
+func (x *FileInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_rpc_ast2llmpb_ast2llm_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FileInfo.ProtoReflect.Descriptor instead.
+func (*FileInfo) Descriptor() ([]byte, []int) {
+	return file_internal_rpc_ast2llmpb_ast2llm_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *FileInfo) GetPackageName() string {
+	if x != nil {
+		return x.PackageName
+	}
+	return ""
+}
+
+func (x *FileInfo) GetGenerated() bool {
+	if x != nil {
+		return x.Generated
+	}
+	return false
+}
+
+func (x *FileInfo) GetImports() []string {
+	if x != nil {
+		return x.Imports
+	}
+	return nil
+}
+
+func (x *FileInfo) GetFunctions() []*FunctionInfo {
+	if x != nil {
+		return x.Functions
+	}
+	return nil
+}
+
+func (x *FileInfo) GetMethods() []*FunctionInfo {
+	if x != nil {
+		return x.Methods
+	}
+	return nil
+}
+
+func (x *FileInfo) GetStructs() []*StructInfo {
+	if x != nil {
+		return x.Structs
+	}
+	return nil
+}
+
+func (x *FileInfo) GetInterfaces() []*InterfaceInfo {
+	if x != nil {
+		return x.Interfaces
+	}
+	return nil
+}
+
+func (x *FileInfo) GetGlobalVars() []*GlobalVarInfo {
+	if x != nil {
+		return x.GlobalVars
+	}
+	return nil
+}
+
+func (x *FileInfo) GetUsedImportedStructs() []*StructInfo {
+	if x != nil {
+		return x.UsedImportedStructs
+	}
+	return nil
+}
+
+func (x *FileInfo) GetUsedImportedInterfaces() []*InterfaceInfo {
+	if x != nil {
+		return x.UsedImportedInterfaces
+	}
+	return nil
+}
+
+func (x *FileInfo) GetUsedImportedFunctions() []*FunctionInfo {
+	if x != nil {
+		return x.UsedImportedFunctions
+	}
+	return nil
+}
+
+func (x *FileInfo) GetUsedImportedGlobalVars() []*GlobalVarInfo {
+	if x != nil {
+		return x.UsedImportedGlobalVars
+	}
+	return nil
+}
+
+func (x *FileInfo) GetExternalStructs() []*StructInfo {
+	if x != nil {
+		return x.ExternalStructs
+	}
+	return nil
+}
+
+func (x *FileInfo) GetExternalInterfaces() []*InterfaceInfo {
+	if x != nil {
+		return x.ExternalInterfaces
+	}
+	return nil
+}
+
+type FunctionInfo struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Receiver      string                 `protobuf:"bytes,2,opt,name=receiver,proto3" json:"receiver,omitempty"`
+	Comment       string                 `protobuf:"bytes,3,opt,name=comment,proto3" json:"comment,omitempty"`
+	TypeParams    []string               `protobuf:"bytes,4,rep,name=type_params,json=typeParams,proto3" json:"type_params,omitempty"`
+	Params        []string               `protobuf:"bytes,5,rep,name=params,proto3" json:"params,omitempty"`
+	Returns       []string               `protobuf:"bytes,6,rep,name=returns,proto3" json:"returns,omitempty"`
+	Body          string                 `protobuf:"bytes,7,opt,name=body,proto3" json:"body,omitempty"`
+	Pos           *Position              `protobuf:"bytes,8,opt,name=pos,proto3" json:"pos,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FunctionInfo) Reset() {
+	*x = FunctionInfo{}
+	mi := &file_internal_rpc_ast2llmpb_ast2llm_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FunctionInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FunctionInfo) ProtoMessage() {}
+
+func (x *FunctionInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_rpc_ast2llmpb_ast2llm_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FunctionInfo.ProtoReflect.Descriptor instead.
+func (*FunctionInfo) Descriptor() ([]byte, []int) {
+	return file_internal_rpc_ast2llmpb_ast2llm_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *FunctionInfo) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *FunctionInfo) GetReceiver() string {
+	if x != nil {
+		return x.Receiver
+	}
+	return ""
+}
+
+func (x *FunctionInfo) GetComment() string {
+	if x != nil {
+		return x.Comment
+	}
+	return ""
+}
+
+func (x *FunctionInfo) GetTypeParams() []string {
+	if x != nil {
+		return x.TypeParams
+	}
+	return nil
+}
+
+func (x *FunctionInfo) GetParams() []string {
+	if x != nil {
+		return x.Params
+	}
+	return nil
+}
+
+func (x *FunctionInfo) GetReturns() []string {
+	if x != nil {
+		return x.Returns
+	}
+	return nil
+}
+
+func (x *FunctionInfo) GetBody() string {
+	if x != nil {
+		return x.Body
+	}
+	return ""
+}
+
+func (x *FunctionInfo) GetPos() *Position {
+	if x != nil {
+		return x.Pos
+	}
+	return nil
+}
+
+type StructField struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Type          string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	PromotedFrom  string                 `protobuf:"bytes,3,opt,name=promoted_from,json=promotedFrom,proto3" json:"promoted_from,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StructField) Reset() {
+	*x = StructField{}
+	mi := &file_internal_rpc_ast2llmpb_ast2llm_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StructField) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StructField) ProtoMessage() {}
+
+func (x *StructField) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_rpc_ast2llmpb_ast2llm_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StructField.ProtoReflect.Descriptor instead.
+func (*StructField) Descriptor() ([]byte, []int) {
+	return file_internal_rpc_ast2llmpb_ast2llm_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *StructField) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *StructField) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *StructField) GetPromotedFrom() string {
+	if x != nil {
+		return x.PromotedFrom
+	}
+	return ""
+}
+
+type StructMethod struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Comment       string                 `protobuf:"bytes,2,opt,name=comment,proto3" json:"comment,omitempty"`
+	Parameters    []string               `protobuf:"bytes,3,rep,name=parameters,proto3" json:"parameters,omitempty"`
+	ReturnTypes   []string               `protobuf:"bytes,4,rep,name=return_types,json=returnTypes,proto3" json:"return_types,omitempty"`
+	PromotedFrom  string                 `protobuf:"bytes,5,opt,name=promoted_from,json=promotedFrom,proto3" json:"promoted_from,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StructMethod) Reset() {
+	*x = StructMethod{}
+	mi := &file_internal_rpc_ast2llmpb_ast2llm_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StructMethod) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StructMethod) ProtoMessage() {}
+
+func (x *StructMethod) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_rpc_ast2llmpb_ast2llm_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StructMethod.ProtoReflect.Descriptor instead.
+func (*StructMethod) Descriptor() ([]byte, []int) {
+	return file_internal_rpc_ast2llmpb_ast2llm_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *StructMethod) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *StructMethod) GetComment() string {
+	if x != nil {
+		return x.Comment
+	}
+	return ""
+}
+
+func (x *StructMethod) GetParameters() []string {
+	if x != nil {
+		return x.Parameters
+	}
+	return nil
+}
+
+func (x *StructMethod) GetReturnTypes() []string {
+	if x != nil {
+		return x.ReturnTypes
+	}
+	return nil
+}
+
+func (x *StructMethod) GetPromotedFrom() string {
+	if x != nil {
+		return x.PromotedFrom
+	}
+	return ""
+}
+
+type StructInfo struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Comment       string                 `protobuf:"bytes,2,opt,name=comment,proto3" json:"comment,omitempty"`
+	TypeParams    []string               `protobuf:"bytes,3,rep,name=type_params,json=typeParams,proto3" json:"type_params,omitempty"`
+	Fields        []*StructField         `protobuf:"bytes,4,rep,name=fields,proto3" json:"fields,omitempty"`
+	Methods       []*StructMethod        `protobuf:"bytes,5,rep,name=methods,proto3" json:"methods,omitempty"`
+	Pos           *Position              `protobuf:"bytes,6,opt,name=pos,proto3" json:"pos,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StructInfo) Reset() {
+	*x = StructInfo{}
+	mi := &file_internal_rpc_ast2llmpb_ast2llm_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StructInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StructInfo) ProtoMessage() {}
+
+func (x *StructInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_rpc_ast2llmpb_ast2llm_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StructInfo.ProtoReflect.Descriptor instead.
+func (*StructInfo) Descriptor() ([]byte, []int) {
+	return file_internal_rpc_ast2llmpb_ast2llm_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *StructInfo) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *StructInfo) GetComment() string {
+	if x != nil {
+		return x.Comment
+	}
+	return ""
+}
+
+func (x *StructInfo) GetTypeParams() []string {
+	if x != nil {
+		return x.TypeParams
+	}
+	return nil
+}
+
+func (x *StructInfo) GetFields() []*StructField {
+	if x != nil {
+		return x.Fields
+	}
+	return nil
+}
+
+func (x *StructInfo) GetMethods() []*StructMethod {
+	if x != nil {
+		return x.Methods
+	}
+	return nil
+}
+
+func (x *StructInfo) GetPos() *Position {
+	if x != nil {
+		return x.Pos
+	}
+	return nil
+}
+
+type InterfaceMethod struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Comment       string                 `protobuf:"bytes,2,opt,name=comment,proto3" json:"comment,omitempty"`
+	Parameters    []string               `protobuf:"bytes,3,rep,name=parameters,proto3" json:"parameters,omitempty"`
+	ReturnTypes   []string               `protobuf:"bytes,4,rep,name=return_types,json=returnTypes,proto3" json:"return_types,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InterfaceMethod) Reset() {
+	*x = InterfaceMethod{}
+	mi := &file_internal_rpc_ast2llmpb_ast2llm_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InterfaceMethod) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InterfaceMethod) ProtoMessage() {}
+
+func (x *InterfaceMethod) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_rpc_ast2llmpb_ast2llm_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InterfaceMethod.ProtoReflect.Descriptor instead.
+func (*InterfaceMethod) Descriptor() ([]byte, []int) {
+	return file_internal_rpc_ast2llmpb_ast2llm_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *InterfaceMethod) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *InterfaceMethod) GetComment() string {
+	if x != nil {
+		return x.Comment
+	}
+	return ""
+}
+
+func (x *InterfaceMethod) GetParameters() []string {
+	if x != nil {
+		return x.Parameters
+	}
+	return nil
+}
+
+func (x *InterfaceMethod) GetReturnTypes() []string {
+	if x != nil {
+		return x.ReturnTypes
+	}
+	return nil
+}
+
+type InterfaceInfo struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Comment       string                 `protobuf:"bytes,2,opt,name=comment,proto3" json:"comment,omitempty"`
+	TypeParams    []string               `protobuf:"bytes,3,rep,name=type_params,json=typeParams,proto3" json:"type_params,omitempty"`
+	Methods       []*InterfaceMethod     `protobuf:"bytes,4,rep,name=methods,proto3" json:"methods,omitempty"`
+	Embeddeds     []string               `protobuf:"bytes,5,rep,name=embeddeds,proto3" json:"embeddeds,omitempty"`
+	Pos           *Position              `protobuf:"bytes,6,opt,name=pos,proto3" json:"pos,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InterfaceInfo) Reset() {
+	*x = InterfaceInfo{}
+	mi := &file_internal_rpc_ast2llmpb_ast2llm_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InterfaceInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InterfaceInfo) ProtoMessage() {}
+
+func (x *InterfaceInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_rpc_ast2llmpb_ast2llm_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InterfaceInfo.ProtoReflect.Descriptor instead.
+func (*InterfaceInfo) Descriptor() ([]byte, []int) {
+	return file_internal_rpc_ast2llmpb_ast2llm_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *InterfaceInfo) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *InterfaceInfo) GetComment() string {
+	if x != nil {
+		return x.Comment
+	}
+	return ""
+}
+
+func (x *InterfaceInfo) GetTypeParams() []string {
+	if x != nil {
+		return x.TypeParams
+	}
+	return nil
+}
+
+func (x *InterfaceInfo) GetMethods() []*InterfaceMethod {
+	if x != nil {
+		return x.Methods
+	}
+	return nil
+}
+
+func (x *InterfaceInfo) GetEmbeddeds() []string {
+	if x != nil {
+		return x.Embeddeds
+	}
+	return nil
+}
+
+func (x *InterfaceInfo) GetPos() *Position {
+	if x != nil {
+		return x.Pos
+	}
+	return nil
+}
+
+type GlobalVarInfo struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Comment       string                 `protobuf:"bytes,2,opt,name=comment,proto3" json:"comment,omitempty"`
+	Type          string                 `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"`
+	Value         string                 `protobuf:"bytes,4,opt,name=value,proto3" json:"value,omitempty"`
+	IsConst       bool                   `protobuf:"varint,5,opt,name=is_const,json=isConst,proto3" json:"is_const,omitempty"`
+	Pos           *Position              `protobuf:"bytes,6,opt,name=pos,proto3" json:"pos,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GlobalVarInfo) Reset() {
+	*x = GlobalVarInfo{}
+	mi := &file_internal_rpc_ast2llmpb_ast2llm_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GlobalVarInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GlobalVarInfo) ProtoMessage() {}
+
+func (x *GlobalVarInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_rpc_ast2llmpb_ast2llm_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GlobalVarInfo.ProtoReflect.Descriptor instead.
+func (*GlobalVarInfo) Descriptor() ([]byte, []int) {
+	return file_internal_rpc_ast2llmpb_ast2llm_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *GlobalVarInfo) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *GlobalVarInfo) GetComment() string {
+	if x != nil {
+		return x.Comment
+	}
+	return ""
+}
+
+func (x *GlobalVarInfo) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *GlobalVarInfo) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *GlobalVarInfo) GetIsConst() bool {
+	if x != nil {
+		return x.IsConst
+	}
+	return false
+}
+
+func (x *GlobalVarInfo) GetPos() *Position {
+	if x != nil {
+		return x.Pos
+	}
+	return nil
+}
+
+var File_internal_rpc_ast2llmpb_ast2llm_proto protoreflect.FileDescriptor
+
+const file_internal_rpc_ast2llmpb_ast2llm_proto_rawDesc = "" +
+	"\n" +
+	"$internal/rpc/ast2llmpb/ast2llm.proto\x12\n" +
+	"ast2llm.v1\"8\n" +
+	"\x13ParseProjectRequest\x12!\n" +
+	"\fproject_path\x18\x01 \x01(\tR\vprojectPath\"\xa9\x01\n" +
+	"\x14ParseProjectResponse\x12A\n" +
+	"\x05files\x18\x01 \x03(\v2+.ast2llm.v1.ParseProjectResponse.FilesEntryR\x05files\x1aN\n" +
+	"\n" +
+	"FilesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12*\n" +
+	"\x05value\x18\x02 \x01(\v2\x14.ast2llm.v1.FileInfoR\x05value:\x028\x01\"\xd5\x01\n" +
+	"\x0eComposeRequest\x12!\n" +
+	"\fproject_path\x18\x01 \x01(\tR\vprojectPath\x12\x1b\n" +
+	"\tfile_path\x18\x02 \x01(\tR\bfilePath\x12*\n" +
+	"\x06format\x18\x03 \x01(\x0e2\x12.ast2llm.v1.FormatR\x06format\x12!\n" +
+	"\ffocus_symbol\x18\x04 \x01(\tR\vfocusSymbol\x12\x16\n" +
+	"\x06budget\x18\x05 \x01(\x05R\x06budget\x12\x1c\n" +
+	"\tpositions\x18\x06 \x01(\bR\tpositions\"+\n" +
+	"\x0fComposeResponse\x12\x18\n" +
+	"\acontext\x18\x01 \x01(\tR\acontext\"6\n" +
+	"\x11BuildGraphRequest\x12!\n" +
+	"\fproject_path\x18\x01 \x01(\tR\vprojectPath\"\xa8\x01\n" +
+	"\x12BuildGraphResponse\x12?\n" +
+	"\x05nodes\x18\x01 \x03(\v2).ast2llm.v1.BuildGraphResponse.NodesEntryR\x05nodes\x1aQ\n" +
+	"\n" +
+	"NodesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12-\n" +
+	"\x05value\x18\x02 \x01(\v2\x17.ast2llm.v1.PackageNodeR\x05value:\x028\x01\"\xa1\x01\n" +
+	"\vPackageNode\x12\x19\n" +
+	"\bpkg_path\x18\x01 \x01(\tR\apkgPath\x12\x1c\n" +
+	"\tfunctions\x18\x02 \x03(\tR\tfunctions\x12\x1d\n" +
+	"\n" +
+	"depends_on\x18\x03 \x03(\tR\tdependsOn\x12$\n" +
+	"\x0edepended_on_by\x18\x04 \x03(\tR\fdependedOnBy\x12\x14\n" +
+	"\x05files\x18\x05 \x03(\tR\x05files\"J\n" +
+	"\bPosition\x12\x12\n" +
+	"\x04file\x18\x01 \x01(\tR\x04file\x12\x12\n" +
+	"\x04line\x18\x02 \x01(\x05R\x04line\x12\x16\n" +
+	"\x06column\x18\x03 \x01(\x05R\x06column\"\xd2\x06\n" +
+	"\bFileInfo\x12!\n" +
+	"\fpackage_name\x18\x01 \x01(\tR\vpackageName\x12\x1c\n" +
+	"\tgenerated\x18\x02 \x01(\bR\tgenerated\x12\x18\n" +
+	"\aimports\x18\x03 \x03(\tR\aimports\x126\n" +
+	"\tfunctions\x18\x04 \x03(\v2\x18.ast2llm.v1.FunctionInfoR\tfunctions\x122\n" +
+	"\amethods\x18\x05 \x03(\v2\x18.ast2llm.v1.FunctionInfoR\amethods\x120\n" +
+	"\astructs\x18\x06 \x03(\v2\x16.ast2llm.v1.StructInfoR\astructs\x129\n" +
+	"\n" +
+	"interfaces\x18\a \x03(\v2\x19.ast2llm.v1.InterfaceInfoR\n" +
+	"interfaces\x12:\n" +
+	"\vglobal_vars\x18\b \x03(\v2\x19.ast2llm.v1.GlobalVarInfoR\n" +
+	"globalVars\x12J\n" +
+	"\x15used_imported_structs\x18\t \x03(\v2\x16.ast2llm.v1.StructInfoR\x13usedImportedStructs\x12S\n" +
+	"\x18used_imported_interfaces\x18\n" +
+	" \x03(\v2\x19.ast2llm.v1.InterfaceInfoR\x16usedImportedInterfaces\x12P\n" +
+	"\x17used_imported_functions\x18\v \x03(\v2\x18.ast2llm.v1.FunctionInfoR\x15usedImportedFunctions\x12T\n" +
+	"\x19used_imported_global_vars\x18\f \x03(\v2\x19.ast2llm.v1.GlobalVarInfoR\x16usedImportedGlobalVars\x12A\n" +
+	"\x10external_structs\x18\r \x03(\v2\x16.ast2llm.v1.StructInfoR\x0fexternalStructs\x12J\n" +
+	"\x13external_interfaces\x18\x0e \x03(\v2\x19.ast2llm.v1.InterfaceInfoR\x12externalInterfaces\"\xe7\x01\n" +
+	"\fFunctionInfo\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1a\n" +
+	"\breceiver\x18\x02 \x01(\tR\breceiver\x12\x18\n" +
+	"\acomment\x18\x03 \x01(\tR\acomment\x12\x1f\n" +
+	"\vtype_params\x18\x04 \x03(\tR\n" +
+	"typeParams\x12\x16\n" +
+	"\x06params\x18\x05 \x03(\tR\x06params\x12\x18\n" +
+	"\areturns\x18\x06 \x03(\tR\areturns\x12\x12\n" +
+	"\x04body\x18\a \x01(\tR\x04body\x12&\n" +
+	"\x03pos\x18\b \x01(\v2\x14.ast2llm.v1.PositionR\x03pos\"Z\n" +
+	"\vStructField\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x12#\n" +
+	"\rpromoted_from\x18\x03 \x01(\tR\fpromotedFrom\"\xa4\x01\n" +
+	"\fStructMethod\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x18\n" +
+	"\acomment\x18\x02 \x01(\tR\acomment\x12\x1e\n" +
+	"\n" +
+	"parameters\x18\x03 \x03(\tR\n" +
+	"parameters\x12!\n" +
+	"\freturn_types\x18\x04 \x03(\tR\vreturnTypes\x12#\n" +
+	"\rpromoted_from\x18\x05 \x01(\tR\fpromotedFrom\"\xe8\x01\n" +
+	"\n" +
+	"StructInfo\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x18\n" +
+	"\acomment\x18\x02 \x01(\tR\acomment\x12\x1f\n" +
+	"\vtype_params\x18\x03 \x03(\tR\n" +
+	"typeParams\x12/\n" +
+	"\x06fields\x18\x04 \x03(\v2\x17.ast2llm.v1.StructFieldR\x06fields\x122\n" +
+	"\amethods\x18\x05 \x03(\v2\x18.ast2llm.v1.StructMethodR\amethods\x12&\n" +
+	"\x03pos\x18\x06 \x01(\v2\x14.ast2llm.v1.PositionR\x03pos\"\x82\x01\n" +
+	"\x0fInterfaceMethod\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x18\n" +
+	"\acomment\x18\x02 \x01(\tR\acomment\x12\x1e\n" +
+	"\n" +
+	"parameters\x18\x03 \x03(\tR\n" +
+	"parameters\x12!\n" +
+	"\freturn_types\x18\x04 \x03(\tR\vreturnTypes\"\xdb\x01\n" +
+	"\rInterfaceInfo\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x18\n" +
+	"\acomment\x18\x02 \x01(\tR\acomment\x12\x1f\n" +
+	"\vtype_params\x18\x03 \x03(\tR\n" +
+	"typeParams\x125\n" +
+	"\amethods\x18\x04 \x03(\v2\x1b.ast2llm.v1.InterfaceMethodR\amethods\x12\x1c\n" +
+	"\tembeddeds\x18\x05 \x03(\tR\tembeddeds\x12&\n" +
+	"\x03pos\x18\x06 \x01(\v2\x14.ast2llm.v1.PositionR\x03pos\"\xaa\x01\n" +
+	"\rGlobalVarInfo\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x18\n" +
+	"\acomment\x18\x02 \x01(\tR\acomment\x12\x12\n" +
+	"\x04type\x18\x03 \x01(\tR\x04type\x12\x14\n" +
+	"\x05value\x18\x04 \x01(\tR\x05value\x12\x19\n" +
+	"\bis_const\x18\x05 \x01(\bR\aisConst\x12&\n" +
+	"\x03pos\x18\x06 \x01(\v2\x14.ast2llm.v1.PositionR\x03pos*B\n" +
+	"\x06Format\x12\x16\n" +
+	"\x12FORMAT_UNSPECIFIED\x10\x00\x12\x0f\n" +
+	"\vFORMAT_TEXT\x10\x01\x12\x0f\n" +
+	"\vFORMAT_JSON\x10\x022\xed\x01\n" +
+	"\aAst2LLM\x12Q\n" +
+	"\fParseProject\x12\x1f.ast2llm.v1.ParseProjectRequest\x1a .ast2llm.v1.ParseProjectResponse\x12B\n" +
+	"\aCompose\x12\x1a.ast2llm.v1.ComposeRequest\x1a\x1b.ast2llm.v1.ComposeResponse\x12K\n" +
+	"\n" +
+	"BuildGraph\x12\x1d.ast2llm.v1.BuildGraphRequest\x1a\x1e.ast2llm.v1.BuildGraphResponseB3Z1github.com/vlad/ast2llm-go/internal/rpc/ast2llmpbb\x06proto3"
+
+var (
+	file_internal_rpc_ast2llmpb_ast2llm_proto_rawDescOnce sync.Once
+	file_internal_rpc_ast2llmpb_ast2llm_proto_rawDescData []byte
+)
+
+func file_internal_rpc_ast2llmpb_ast2llm_proto_rawDescGZIP() []byte {
+	file_internal_rpc_ast2llmpb_ast2llm_proto_rawDescOnce.Do(func() {
+		file_internal_rpc_ast2llmpb_ast2llm_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_internal_rpc_ast2llmpb_ast2llm_proto_rawDesc), len(file_internal_rpc_ast2llmpb_ast2llm_proto_rawDesc)))
+	})
+	return file_internal_rpc_ast2llmpb_ast2llm_proto_rawDescData
+}
+
+var file_internal_rpc_ast2llmpb_ast2llm_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_internal_rpc_ast2llmpb_ast2llm_proto_msgTypes = make([]protoimpl.MessageInfo, 18)
+var file_internal_rpc_ast2llmpb_ast2llm_proto_goTypes = []any{
+	(Format)(0),                  // 0: ast2llm.v1.Format
+	(*ParseProjectRequest)(nil),  // 1: ast2llm.v1.ParseProjectRequest
+	(*ParseProjectResponse)(nil), // 2: ast2llm.v1.ParseProjectResponse
+	(*ComposeRequest)(nil),       // 3: ast2llm.v1.ComposeRequest
+	(*ComposeResponse)(nil),      // 4: ast2llm.v1.ComposeResponse
+	(*BuildGraphRequest)(nil),    // 5: ast2llm.v1.BuildGraphRequest
+	(*BuildGraphResponse)(nil),   // 6: ast2llm.v1.BuildGraphResponse
+	(*PackageNode)(nil),          // 7: ast2llm.v1.PackageNode
+	(*Position)(nil),             // 8: ast2llm.v1.Position
+	(*FileInfo)(nil),             // 9: ast2llm.v1.FileInfo
+	(*FunctionInfo)(nil),         // 10: ast2llm.v1.FunctionInfo
+	(*StructField)(nil),          // 11: ast2llm.v1.StructField
+	(*StructMethod)(nil),         // 12: ast2llm.v1.StructMethod
+	(*StructInfo)(nil),           // 13: ast2llm.v1.StructInfo
+	(*InterfaceMethod)(nil),      // 14: ast2llm.v1.InterfaceMethod
+	(*InterfaceInfo)(nil),        // 15: ast2llm.v1.InterfaceInfo
+	(*GlobalVarInfo)(nil),        // 16: ast2llm.v1.GlobalVarInfo
+	nil,                          // 17: ast2llm.v1.ParseProjectResponse.FilesEntry
+	nil,                          // 18: ast2llm.v1.BuildGraphResponse.NodesEntry
+}
+var file_internal_rpc_ast2llmpb_ast2llm_proto_depIdxs = []int32{
+	17, // 0: ast2llm.v1.ParseProjectResponse.files:type_name -> ast2llm.v1.ParseProjectResponse.FilesEntry
+	0,  // 1: ast2llm.v1.ComposeRequest.format:type_name -> ast2llm.v1.Format
+	18, // 2: ast2llm.v1.BuildGraphResponse.nodes:type_name -> ast2llm.v1.BuildGraphResponse.NodesEntry
+	10, // 3: ast2llm.v1.FileInfo.functions:type_name -> ast2llm.v1.FunctionInfo
+	10, // 4: ast2llm.v1.FileInfo.methods:type_name -> ast2llm.v1.FunctionInfo
+	13, // 5: ast2llm.v1.FileInfo.structs:type_name -> ast2llm.v1.StructInfo
+	15, // 6: ast2llm.v1.FileInfo.interfaces:type_name -> ast2llm.v1.InterfaceInfo
+	16, // 7: ast2llm.v1.FileInfo.global_vars:type_name -> ast2llm.v1.GlobalVarInfo
+	13, // 8: ast2llm.v1.FileInfo.used_imported_structs:type_name -> ast2llm.v1.StructInfo
+	15, // 9: ast2llm.v1.FileInfo.used_imported_interfaces:type_name -> ast2llm.v1.InterfaceInfo
+	10, // 10: ast2llm.v1.FileInfo.used_imported_functions:type_name -> ast2llm.v1.FunctionInfo
+	16, // 11: ast2llm.v1.FileInfo.used_imported_global_vars:type_name -> ast2llm.v1.GlobalVarInfo
+	13, // 12: ast2llm.v1.FileInfo.external_structs:type_name -> ast2llm.v1.StructInfo
+	15, // 13: ast2llm.v1.FileInfo.external_interfaces:type_name -> ast2llm.v1.InterfaceInfo
+	8,  // 14: ast2llm.v1.FunctionInfo.pos:type_name -> ast2llm.v1.Position
+	11, // 15: ast2llm.v1.StructInfo.fields:type_name -> ast2llm.v1.StructField
+	12, // 16: ast2llm.v1.StructInfo.methods:type_name -> ast2llm.v1.StructMethod
+	8,  // 17: ast2llm.v1.StructInfo.pos:type_name -> ast2llm.v1.Position
+	14, // 18: ast2llm.v1.InterfaceInfo.methods:type_name -> ast2llm.v1.InterfaceMethod
+	8,  // 19: ast2llm.v1.InterfaceInfo.pos:type_name -> ast2llm.v1.Position
+	8,  // 20: ast2llm.v1.GlobalVarInfo.pos:type_name -> ast2llm.v1.Position
+	9,  // 21: ast2llm.v1.ParseProjectResponse.FilesEntry.value:type_name -> ast2llm.v1.FileInfo
+	7,  // 22: ast2llm.v1.BuildGraphResponse.NodesEntry.value:type_name -> ast2llm.v1.PackageNode
+	1,  // 23: ast2llm.v1.Ast2LLM.ParseProject:input_type -> ast2llm.v1.ParseProjectRequest
+	3,  // 24: ast2llm.v1.Ast2LLM.Compose:input_type -> ast2llm.v1.ComposeRequest
+	5,  // 25: ast2llm.v1.Ast2LLM.BuildGraph:input_type -> ast2llm.v1.BuildGraphRequest
+	2,  // 26: ast2llm.v1.Ast2LLM.ParseProject:output_type -> ast2llm.v1.ParseProjectResponse
+	4,  // 27: ast2llm.v1.Ast2LLM.Compose:output_type -> ast2llm.v1.ComposeResponse
+	6,  // 28: ast2llm.v1.Ast2LLM.BuildGraph:output_type -> ast2llm.v1.BuildGraphResponse
+	26, // [26:29] is the sub-list for method output_type
+	23, // [23:26] is the sub-list for method input_type
+	23, // [23:23] is the sub-list for extension type_name
+	23, // [23:23] is the sub-list for extension extendee
+	0,  // [0:23] is the sub-list for field type_name
+}
+
+func init() { file_internal_rpc_ast2llmpb_ast2llm_proto_init() }
+func file_internal_rpc_ast2llmpb_ast2llm_proto_init() {
+	if File_internal_rpc_ast2llmpb_ast2llm_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_internal_rpc_ast2llmpb_ast2llm_proto_rawDesc), len(file_internal_rpc_ast2llmpb_ast2llm_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   18,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_internal_rpc_ast2llmpb_ast2llm_proto_goTypes,
+		DependencyIndexes: file_internal_rpc_ast2llmpb_ast2llm_proto_depIdxs,
+		EnumInfos:         file_internal_rpc_ast2llmpb_ast2llm_proto_enumTypes,
+		MessageInfos:      file_internal_rpc_ast2llmpb_ast2llm_proto_msgTypes,
+	}.Build()
+	File_internal_rpc_ast2llmpb_ast2llm_proto = out.File
+	file_internal_rpc_ast2llmpb_ast2llm_proto_goTypes = nil
+	file_internal_rpc_ast2llmpb_ast2llm_proto_depIdxs = nil
+}
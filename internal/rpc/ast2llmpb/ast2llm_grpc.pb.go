@@ -0,0 +1,197 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: internal/rpc/ast2llmpb/ast2llm.proto
+
+package ast2llmpb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	Ast2LLM_ParseProject_FullMethodName = "/ast2llm.v1.Ast2LLM/ParseProject"
+	Ast2LLM_Compose_FullMethodName      = "/ast2llm.v1.Ast2LLM/Compose"
+	Ast2LLM_BuildGraph_FullMethodName   = "/ast2llm.v1.Ast2LLM/BuildGraph"
+)
+
+// Ast2LLMClient is the client API for Ast2LLM service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type Ast2LLMClient interface {
+	ParseProject(ctx context.Context, in *ParseProjectRequest, opts ...grpc.CallOption) (*ParseProjectResponse, error)
+	Compose(ctx context.Context, in *ComposeRequest, opts ...grpc.CallOption) (*ComposeResponse, error)
+	BuildGraph(ctx context.Context, in *BuildGraphRequest, opts ...grpc.CallOption) (*BuildGraphResponse, error)
+}
+
+type ast2LLMClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewAst2LLMClient(cc grpc.ClientConnInterface) Ast2LLMClient {
+	return &ast2LLMClient{cc}
+}
+
+func (c *ast2LLMClient) ParseProject(ctx context.Context, in *ParseProjectRequest, opts ...grpc.CallOption) (*ParseProjectResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ParseProjectResponse)
+	err := c.cc.Invoke(ctx, Ast2LLM_ParseProject_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ast2LLMClient) Compose(ctx context.Context, in *ComposeRequest, opts ...grpc.CallOption) (*ComposeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ComposeResponse)
+	err := c.cc.Invoke(ctx, Ast2LLM_Compose_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ast2LLMClient) BuildGraph(ctx context.Context, in *BuildGraphRequest, opts ...grpc.CallOption) (*BuildGraphResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BuildGraphResponse)
+	err := c.cc.Invoke(ctx, Ast2LLM_BuildGraph_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Ast2LLMServer is the server API for Ast2LLM service.
+// All implementations must embed UnimplementedAst2LLMServer
+// for forward compatibility.
+type Ast2LLMServer interface {
+	ParseProject(context.Context, *ParseProjectRequest) (*ParseProjectResponse, error)
+	Compose(context.Context, *ComposeRequest) (*ComposeResponse, error)
+	BuildGraph(context.Context, *BuildGraphRequest) (*BuildGraphResponse, error)
+	mustEmbedUnimplementedAst2LLMServer()
+}
+
+// UnimplementedAst2LLMServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedAst2LLMServer struct{}
+
+func (UnimplementedAst2LLMServer) ParseProject(context.Context, *ParseProjectRequest) (*ParseProjectResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ParseProject not implemented")
+}
+func (UnimplementedAst2LLMServer) Compose(context.Context, *ComposeRequest) (*ComposeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Compose not implemented")
+}
+func (UnimplementedAst2LLMServer) BuildGraph(context.Context, *BuildGraphRequest) (*BuildGraphResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BuildGraph not implemented")
+}
+func (UnimplementedAst2LLMServer) mustEmbedUnimplementedAst2LLMServer() {}
+func (UnimplementedAst2LLMServer) testEmbeddedByValue()                 {}
+
+// UnsafeAst2LLMServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to Ast2LLMServer will
+// result in compilation errors.
+type UnsafeAst2LLMServer interface {
+	mustEmbedUnimplementedAst2LLMServer()
+}
+
+func RegisterAst2LLMServer(s grpc.ServiceRegistrar, srv Ast2LLMServer) {
+	// If the following call pancis, it indicates UnimplementedAst2LLMServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&Ast2LLM_ServiceDesc, srv)
+}
+
+func _Ast2LLM_ParseProject_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ParseProjectRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(Ast2LLMServer).ParseProject(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Ast2LLM_ParseProject_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(Ast2LLMServer).ParseProject(ctx, req.(*ParseProjectRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Ast2LLM_Compose_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ComposeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(Ast2LLMServer).Compose(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Ast2LLM_Compose_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(Ast2LLMServer).Compose(ctx, req.(*ComposeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Ast2LLM_BuildGraph_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BuildGraphRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(Ast2LLMServer).BuildGraph(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Ast2LLM_BuildGraph_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(Ast2LLMServer).BuildGraph(ctx, req.(*BuildGraphRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Ast2LLM_ServiceDesc is the grpc.ServiceDesc for Ast2LLM service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Ast2LLM_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "ast2llm.v1.Ast2LLM",
+	HandlerType: (*Ast2LLMServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ParseProject",
+			Handler:    _Ast2LLM_ParseProject_Handler,
+		},
+		{
+			MethodName: "Compose",
+			Handler:    _Ast2LLM_Compose_Handler,
+		},
+		{
+			MethodName: "BuildGraph",
+			Handler:    _Ast2LLM_BuildGraph_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "internal/rpc/ast2llmpb/ast2llm.proto",
+}
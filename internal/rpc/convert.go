@@ -0,0 +1,123 @@
+package rpc
+
+import (
+	"github.com/vlad/ast2llm-go/internal/rpc/ast2llmpb"
+	ourtypes "github.com/vlad/ast2llm-go/internal/types"
+)
+
+// fileInfoToProto converts a FileInfo to its protobuf message.
+func fileInfoToProto(info *ourtypes.FileInfo) *ast2llmpb.FileInfo {
+	return &ast2llmpb.FileInfo{
+		PackageName:            info.PackageName,
+		Generated:              info.Generated,
+		Imports:                ourtypes.ImportPaths(info.Imports),
+		Functions:              convertAll(info.Functions, functionInfoToProto),
+		Methods:                convertAll(info.Methods, functionInfoToProto),
+		Structs:                convertAll(info.Structs, structInfoToProto),
+		Interfaces:             convertAll(info.Interfaces, interfaceInfoToProto),
+		GlobalVars:             convertAll(withGroupedConstants(info), globalVarInfoToProto),
+		UsedImportedStructs:    convertAll(info.UsedImportedStructs, structInfoToProto),
+		UsedImportedInterfaces: convertAll(info.UsedImportedInterfaces, interfaceInfoToProto),
+		UsedImportedFunctions:  convertAll(info.UsedImportedFunctions, functionInfoToProto),
+		UsedImportedGlobalVars: convertAll(info.UsedImportedGlobalVars, globalVarInfoToProto),
+		ExternalStructs:        convertAll(info.ExternalStructs, structInfoToProto),
+		ExternalInterfaces:     convertAll(info.ExternalInterfaces, interfaceInfoToProto),
+	}
+}
+
+// functionInfoToProto converts a FunctionInfo to its protobuf message.
+func functionInfoToProto(fn *ourtypes.FunctionInfo) *ast2llmpb.FunctionInfo {
+	return &ast2llmpb.FunctionInfo{
+		Name:       fn.Name,
+		Receiver:   fn.Receiver,
+		Comment:    fn.Comment,
+		TypeParams: fn.TypeParams,
+		Params:     fn.Params,
+		Returns:    fn.Returns,
+		Body:       fn.Body,
+		Pos:        positionToProto(fn.Pos),
+	}
+}
+
+// structInfoToProto converts a StructInfo to its protobuf message.
+func structInfoToProto(s *ourtypes.StructInfo) *ast2llmpb.StructInfo {
+	return &ast2llmpb.StructInfo{
+		Name:       s.Name,
+		Comment:    s.Comment,
+		TypeParams: s.TypeParams,
+		Fields: convertAll(s.Fields, func(f *ourtypes.StructField) *ast2llmpb.StructField {
+			return &ast2llmpb.StructField{Name: f.Name, Type: f.Type, PromotedFrom: f.PromotedFrom}
+		}),
+		Methods: convertAll(s.Methods, func(m *ourtypes.StructMethod) *ast2llmpb.StructMethod {
+			return &ast2llmpb.StructMethod{
+				Name:         m.Name,
+				Comment:      m.Comment,
+				Parameters:   m.Parameters,
+				ReturnTypes:  m.ReturnTypes,
+				PromotedFrom: m.PromotedFrom,
+			}
+		}),
+		Pos: positionToProto(s.Pos),
+	}
+}
+
+// interfaceInfoToProto converts an InterfaceInfo to its protobuf message.
+func interfaceInfoToProto(iface *ourtypes.InterfaceInfo) *ast2llmpb.InterfaceInfo {
+	return &ast2llmpb.InterfaceInfo{
+		Name:       iface.Name,
+		Comment:    iface.Comment,
+		TypeParams: iface.TypeParams,
+		Methods: convertAll(iface.Methods, func(m *ourtypes.InterfaceMethod) *ast2llmpb.InterfaceMethod {
+			return &ast2llmpb.InterfaceMethod{
+				Name:        m.Name,
+				Comment:     m.Comment,
+				Parameters:  m.Parameters,
+				ReturnTypes: m.ReturnTypes,
+			}
+		}),
+		Embeddeds: iface.Embeddeds,
+		Pos:       positionToProto(iface.Pos),
+	}
+}
+
+// globalVarInfoToProto converts a GlobalVarInfo to its protobuf message.
+func globalVarInfoToProto(gv *ourtypes.GlobalVarInfo) *ast2llmpb.GlobalVarInfo {
+	return &ast2llmpb.GlobalVarInfo{
+		Name:    gv.Name,
+		Comment: gv.Comment,
+		Type:    gv.Type,
+		Value:   gv.Value,
+		IsConst: gv.IsConst,
+		Pos:     positionToProto(gv.Pos),
+	}
+}
+
+// positionToProto converts a Position to its protobuf message, keeping nil for unknown positions.
+func positionToProto(pos *ourtypes.Position) *ast2llmpb.Position {
+	if pos == nil {
+		return nil
+	}
+	return &ast2llmpb.Position{File: pos.File, Line: int32(pos.Line), Column: int32(pos.Column)}
+}
+
+// convertAll converts every item of a slice.
+func convertAll[T, R any](items []T, convert func(T) R) []R {
+	result := make([]R, 0, len(items))
+	for _, item := range items {
+		result = append(result, convert(item))
+	}
+	return result
+}
+
+// withGroupedConstants returns the global variables of the file followed by the constants of its const groups,
+// which the protobuf message has no separate field for.
+func withGroupedConstants(info *ourtypes.FileInfo) []*ourtypes.GlobalVarInfo {
+	if len(info.ConstGroups) == 0 {
+		return info.GlobalVars
+	}
+	globalVars := append([]*ourtypes.GlobalVarInfo(nil), info.GlobalVars...)
+	for _, g := range info.ConstGroups {
+		globalVars = append(globalVars, g.Constants...)
+	}
+	return globalVars
+}
@@ -0,0 +1,119 @@
+package rpc
+
+import (
+	"context"
+
+	"github.com/vlad/ast2llm-go/internal/composer"
+	"github.com/vlad/ast2llm-go/internal/modinfo"
+	"github.com/vlad/ast2llm-go/internal/parser"
+	"github.com/vlad/ast2llm-go/internal/rpc/ast2llmpb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Server implements the Ast2LLM gRPC service on top of a Parser
+type Server struct {
+	ast2llmpb.UnimplementedAst2LLMServer
+
+	parser parser.Parser
+}
+
+// NewServer creates a new Server instance
+func NewServer(p parser.Parser) *Server {
+	return &Server{parser: p}
+}
+
+// Register registers the service with a gRPC server
+func (s *Server) Register(gs *grpc.Server) {
+	ast2llmpb.RegisterAst2LLMServer(gs, s)
+}
+
+// ParseProject returns the extracted information of every Go file of a project.
+func (s *Server) ParseProject(ctx context.Context, req *ast2llmpb.ParseProjectRequest) (*ast2llmpb.ParseProjectResponse, error) {
+	if req.GetProjectPath() == "" {
+		return nil, status.Error(codes.InvalidArgument, "project_path is required")
+	}
+
+	projectInfo, err := s.parser.ParseProject(req.GetProjectPath())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to parse project: %v", err)
+	}
+
+	resp := &ast2llmpb.ParseProjectResponse{Files: make(map[string]*ast2llmpb.FileInfo, len(projectInfo))}
+	for path, info := range projectInfo {
+		resp.Files[path] = fileInfoToProto(info)
+	}
+	return resp, nil
+}
+
+// Compose returns the LLM-friendly context of a single file of a project.
+func (s *Server) Compose(ctx context.Context, req *ast2llmpb.ComposeRequest) (*ast2llmpb.ComposeResponse, error) {
+	if req.GetProjectPath() == "" || req.GetFilePath() == "" {
+		return nil, status.Error(codes.InvalidArgument, "project_path and file_path are required")
+	}
+
+	projectInfo, err := s.parser.ParseProject(req.GetProjectPath())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to parse project: %v", err)
+	}
+
+	var opts []composer.Option
+	if req.GetFocusSymbol() != "" {
+		opts = append(opts, composer.WithFocusSymbol(req.GetFocusSymbol()))
+	}
+	if req.GetBudget() > 0 {
+		opts = append(opts, composer.WithBudget(int(req.GetBudget())))
+	}
+	if req.GetPositions() {
+		opts = append(opts, composer.WithPositions())
+	}
+	if moduleInfo, err := modinfo.Load(req.GetProjectPath()); err == nil {
+		opts = append(opts, composer.WithModuleInfo(moduleInfo))
+	}
+	projectComposer := composer.New(projectInfo, opts...)
+
+	fullFilePath, err := parser.ResolveFilePath(req.GetProjectPath(), req.GetFilePath())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid project_path: %v", err)
+	}
+
+	var composed string
+	switch req.GetFormat() {
+	case ast2llmpb.Format_FORMAT_JSON:
+		composed, err = projectComposer.ComposeJSON(fullFilePath)
+	case ast2llmpb.Format_FORMAT_UNSPECIFIED, ast2llmpb.Format_FORMAT_TEXT:
+		composed, err = projectComposer.Compose(fullFilePath)
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "unsupported format: %v", req.GetFormat())
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.NotFound, "failed to compose project info: %v", err)
+	}
+
+	return &ast2llmpb.ComposeResponse{Context: composed}, nil
+}
+
+// BuildGraph returns the package dependency graph of a project.
+func (s *Server) BuildGraph(ctx context.Context, req *ast2llmpb.BuildGraphRequest) (*ast2llmpb.BuildGraphResponse, error) {
+	if req.GetProjectPath() == "" {
+		return nil, status.Error(codes.InvalidArgument, "project_path is required")
+	}
+
+	graph, err := s.parser.BuildGraphContext(ctx, req.GetProjectPath())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to build graph: %v", err)
+	}
+
+	resp := &ast2llmpb.BuildGraphResponse{Nodes: make(map[string]*ast2llmpb.PackageNode, len(graph.Nodes))}
+	for pkgPath, node := range graph.Nodes {
+		resp.Nodes[pkgPath] = &ast2llmpb.PackageNode{
+			PkgPath:      node.PkgPath,
+			Functions:    node.Functions,
+			DependsOn:    node.DependsOn,
+			DependedOnBy: node.DependedOnBy,
+			Files:        node.Files,
+		}
+	}
+	return resp, nil
+}
@@ -0,0 +1,126 @@
+package rpc
+
+import (
+	"context"
+	"net"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vlad/ast2llm-go/internal/parser"
+	"github.com/vlad/ast2llm-go/internal/rpc/ast2llmpb"
+	"github.com/vlad/ast2llm-go/internal/testutil"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// newTestClient starts the service on an in-memory listener and returns a client connected to it.
+func newTestClient(t *testing.T) ast2llmpb.Ast2LLMClient {
+	lis := bufconn.Listen(1 << 20)
+	gs := grpc.NewServer()
+	NewServer(parser.New()).Register(gs)
+	go func() {
+		_ = gs.Serve(lis)
+	}()
+	t.Cleanup(gs.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	return ast2llmpb.NewAst2LLMClient(conn)
+}
+
+// writeTestProject writes a small two-package project and returns its path.
+func writeTestProject(t *testing.T) string {
+	return testutil.WriteModule(t, "example.com/testproject", map[string]string{
+		"main.go": `package main
+
+import "example.com/testproject/models"
+
+// main runs the app.
+func main() {
+	_ = models.User{}
+}
+`,
+		"models/user.go": `package models
+
+// User is a user.
+type User struct {
+	Name string
+}
+`,
+	})
+}
+
+func TestServer_ParseProject(t *testing.T) {
+	client := newTestClient(t)
+	projectPath := writeTestProject(t)
+
+	resp, err := client.ParseProject(context.Background(), &ast2llmpb.ParseProjectRequest{ProjectPath: projectPath})
+	require.NoError(t, err)
+	require.Len(t, resp.GetFiles(), 2)
+
+	mainInfo := resp.GetFiles()[filepath.Join(projectPath, "main.go")]
+	require.NotNil(t, mainInfo)
+	assert.Equal(t, "main", mainInfo.GetPackageName())
+	assert.Equal(t, []string{"example.com/testproject/models"}, mainInfo.GetImports())
+	require.Len(t, mainInfo.GetFunctions(), 1)
+	assert.Equal(t, "main runs the app.", mainInfo.GetFunctions()[0].GetComment())
+	assert.Equal(t, int32(6), mainInfo.GetFunctions()[0].GetPos().GetLine())
+
+	userInfo := resp.GetFiles()[filepath.Join(projectPath, "models", "user.go")]
+	require.NotNil(t, userInfo)
+	require.Len(t, userInfo.GetStructs(), 1)
+	assert.Equal(t, "example.com/testproject/models.User", userInfo.GetStructs()[0].GetName())
+	assert.Equal(t, "Name", userInfo.GetStructs()[0].GetFields()[0].GetName())
+
+	_, err = client.ParseProject(context.Background(), &ast2llmpb.ParseProjectRequest{})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestServer_Compose(t *testing.T) {
+	client := newTestClient(t)
+	projectPath := writeTestProject(t)
+
+	resp, err := client.Compose(context.Background(), &ast2llmpb.ComposeRequest{
+		ProjectPath: projectPath,
+		FilePath:    "main.go",
+	})
+	require.NoError(t, err)
+	assert.Contains(t, resp.GetContext(), "Package: main\n")
+	assert.Contains(t, resp.GetContext(), "Package models (example.com/testproject/models)\n    Struct: models.User")
+
+	resp, err = client.Compose(context.Background(), &ast2llmpb.ComposeRequest{
+		ProjectPath: projectPath,
+		FilePath:    "main.go",
+		Format:      ast2llmpb.Format_FORMAT_JSON,
+	})
+	require.NoError(t, err)
+	assert.Contains(t, resp.GetContext(), `"package": "main"`)
+
+	_, err = client.Compose(context.Background(), &ast2llmpb.ComposeRequest{
+		ProjectPath: projectPath,
+		FilePath:    "missing.go",
+	})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestServer_BuildGraph(t *testing.T) {
+	client := newTestClient(t)
+	projectPath := writeTestProject(t)
+
+	resp, err := client.BuildGraph(context.Background(), &ast2llmpb.BuildGraphRequest{ProjectPath: projectPath})
+	require.NoError(t, err)
+	require.Contains(t, resp.GetNodes(), "example.com/testproject/models")
+	assert.Equal(t, []string{"example.com/testproject"}, resp.GetNodes()["example.com/testproject/models"].GetDependedOnBy())
+}
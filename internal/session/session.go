@@ -0,0 +1,271 @@
+package session
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/vlad/ast2llm-go/internal/parser"
+)
+
+// Manager keeps the open project sessions of a server
+type Manager struct {
+	parser parser.Parser
+
+	mu       sync.Mutex
+	sessions map[string]*Session // Key: session ID
+	nextID   int
+}
+
+// Session holds the parsed state of one project and keeps it up to date by watching the project files.
+// The project is re-parsed incrementally on the first request after a change, so requests on an
+// unchanged project return the cached ProjectInfo without touching the disk.
+type Session struct {
+	ID   string // Handle returned to clients
+	Root string // Absolute project path
+
+	parser  parser.Parser
+	watcher *fsnotify.Watcher
+
+	mu      sync.Mutex
+	info    parser.ProjectInfo
+	changed map[string]bool // Files changed since info was built
+	done    chan struct{}
+}
+
+// NewManager creates a Manager whose sessions parse projects with p
+func NewManager(p parser.Parser) *Manager {
+	return &Manager{
+		parser:   p,
+		sessions: make(map[string]*Session),
+	}
+}
+
+// Open parses the project and starts watching it. Opening a project that already has a session returns that session.
+func (m *Manager) Open(projectPath string) (*Session, error) {
+	absPath, err := filepath.Abs(projectPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve project path: %w", err)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, s := range m.sessions {
+		if s.Root == absPath {
+			return s, nil
+		}
+	}
+
+	m.nextID++
+	s, err := open(fmt.Sprintf("session-%d", m.nextID), absPath, m.parser)
+	if err != nil {
+		return nil, err
+	}
+	m.sessions[s.ID] = s
+	return s, nil
+}
+
+// Get returns the session with the given ID
+func (m *Manager) Get(id string) (*Session, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	s, ok := m.sessions[id]
+	return s, ok
+}
+
+// Lookup returns the open session of the project at projectPath
+func (m *Manager) Lookup(projectPath string) (*Session, bool) {
+	absPath, err := filepath.Abs(projectPath)
+	if err != nil {
+		return nil, false
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, s := range m.sessions {
+		if s.Root == absPath {
+			return s, true
+		}
+	}
+	return nil, false
+}
+
+// LookupFile returns the open session whose project contains the file at filePath.
+// If projects are nested, the innermost one wins.
+func (m *Manager) LookupFile(filePath string) (*Session, bool) {
+	absPath, err := filepath.Abs(filePath)
+	if err != nil {
+		return nil, false
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var found *Session
+	for _, s := range m.sessions {
+		if parser.HasPathPrefix(absPath, s.Root) && (found == nil || len(s.Root) > len(found.Root)) {
+			found = s
+		}
+	}
+	return found, found != nil
+}
+
+// Close stops watching the project of the session and forgets it
+func (m *Manager) Close(id string) error {
+	m.mu.Lock()
+	s, ok := m.sessions[id]
+	delete(m.sessions, id)
+	m.mu.Unlock()
+
+	if !ok {
+		return fmt.Errorf("unknown session: %s", id)
+	}
+	return s.close()
+}
+
+// CloseAll closes every open session
+func (m *Manager) CloseAll() {
+	m.mu.Lock()
+	sessions := m.sessions
+	m.sessions = make(map[string]*Session)
+	m.mu.Unlock()
+
+	for _, s := range sessions {
+		_ = s.close()
+	}
+}
+
+// open creates a session for the project at absPath, parsing it once to warm the cache.
+func open(id, absPath string, p parser.Parser) (*Session, error) {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create watcher: %w", err)
+	}
+	if err := addWatchDirs(watcher, absPath); err != nil {
+		watcher.Close()
+		return nil, err
+	}
+
+	info, err := p.ParseProjectIncremental(absPath, nil)
+	if err != nil {
+		watcher.Close()
+		return nil, fmt.Errorf("failed to parse project: %w", err)
+	}
+
+	s := &Session{
+		ID:      id,
+		Root:    absPath,
+		parser:  p,
+		watcher: watcher,
+		info:    info,
+		changed: make(map[string]bool),
+		done:    make(chan struct{}),
+	}
+	go s.watch()
+	return s, nil
+}
+
+// ProjectInfo returns the parsed project, re-parsing the packages affected by changes since the last call.
+func (s *Session) ProjectInfo() (parser.ProjectInfo, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(s.changed) == 0 && s.info != nil {
+		return s.info, nil
+	}
+
+	files := make([]string, 0, len(s.changed))
+	for f := range s.changed {
+		files = append(files, f)
+	}
+	sort.Strings(files)
+
+	info, err := s.parser.ParseProjectIncremental(s.Root, files)
+	if err != nil {
+		// Keep the changes pending so the next call retries them.
+		s.info = nil
+		return nil, fmt.Errorf("failed to parse project: %w", err)
+	}
+	s.info = info
+	s.changed = make(map[string]bool)
+	return info, nil
+}
+
+// watch records changed source files until the session is closed.
+func (s *Session) watch() {
+	for {
+		select {
+		case <-s.done:
+			return
+		case event, ok := <-s.watcher.Events:
+			if !ok {
+				return
+			}
+			if event.Has(fsnotify.Create) {
+				if st, err := os.Stat(event.Name); err == nil && st.IsDir() {
+					if err := addWatchDirs(s.watcher, event.Name); err != nil {
+						log.Printf("Error watching %s: %v", event.Name, err)
+					}
+					s.invalidate(event.Name)
+					continue
+				}
+			}
+			if isSourceFile(event.Name) {
+				s.invalidate(event.Name)
+			}
+		case err, ok := <-s.watcher.Errors:
+			if !ok {
+				return
+			}
+			log.Printf("Watcher error in %s: %v", s.Root, err)
+		}
+	}
+}
+
+// invalidate marks path as changed so the next ProjectInfo call re-parses it.
+func (s *Session) invalidate(path string) {
+	s.mu.Lock()
+	s.changed[path] = true
+	s.mu.Unlock()
+}
+
+// close stops the watcher goroutine.
+func (s *Session) close() error {
+	close(s.done)
+	return s.watcher.Close()
+}
+
+// isSourceFile reports whether a change to path can affect the parsed project.
+func isSourceFile(path string) bool {
+	base := filepath.Base(path)
+	switch base {
+	case "go.mod", "go.sum", "go.work", "go.work.sum":
+		return true
+	}
+	return filepath.Ext(base) == ".go"
+}
+
+// addWatchDirs registers root and all its subdirectories, skipping hidden, vendor and testdata directories.
+func addWatchDirs(watcher *fsnotify.Watcher, root string) error {
+	return filepath.WalkDir(root, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() {
+			return nil
+		}
+		name := d.Name()
+		if path != root && (strings.HasPrefix(name, ".") || name == "vendor" || name == "testdata") {
+			return filepath.SkipDir
+		}
+		if err := watcher.Add(path); err != nil {
+			return fmt.Errorf("failed to watch %s: %w", path, err)
+		}
+		return nil
+	})
+}
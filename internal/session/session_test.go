@@ -0,0 +1,91 @@
+package session
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vlad/ast2llm-go/internal/parser"
+	"github.com/vlad/ast2llm-go/internal/testutil"
+)
+
+func TestManager_OpenLookupClose(t *testing.T) {
+	root := testutil.WriteModule(t, "example.com/testproject", map[string]string{
+		"main.go": "package main\n\nfunc main() {}\n",
+	})
+	m := NewManager(parser.New())
+	defer m.CloseAll()
+
+	s, err := m.Open(root)
+	require.NoError(t, err)
+	assert.Equal(t, root, s.Root)
+
+	again, err := m.Open(root)
+	require.NoError(t, err)
+	assert.Same(t, s, again, "opening the same project twice should reuse the session")
+
+	got, ok := m.Get(s.ID)
+	require.True(t, ok)
+	assert.Same(t, s, got)
+
+	got, ok = m.Lookup(filepath.Join(root, "."))
+	require.True(t, ok)
+	assert.Same(t, s, got)
+
+	got, ok = m.LookupFile(filepath.Join(root, "main.go"))
+	require.True(t, ok)
+	assert.Same(t, s, got)
+	_, ok = m.LookupFile(root + "x/main.go")
+	assert.False(t, ok, "a sibling directory sharing the prefix is not part of the project")
+
+	require.NoError(t, m.Close(s.ID))
+	_, ok = m.Get(s.ID)
+	assert.False(t, ok)
+	assert.Error(t, m.Close(s.ID))
+}
+
+func TestManager_OpenInvalidProject(t *testing.T) {
+	m := NewManager(parser.New())
+	_, err := m.Open(filepath.Join(t.TempDir(), "missing"))
+	assert.Error(t, err)
+}
+
+func TestSession_ProjectInfo(t *testing.T) {
+	root := testutil.WriteModule(t, "example.com/testproject", map[string]string{
+		"main.go":         "package main\n\nimport \"example.com/testproject/util\"\n\nfunc main() { util.Helper() }\n",
+		"util/helpers.go": "package util\n\nfunc Helper() {}\n",
+	})
+	m := NewManager(parser.New())
+	defer m.CloseAll()
+
+	s, err := m.Open(root)
+	require.NoError(t, err)
+
+	info, err := s.ProjectInfo()
+	require.NoError(t, err)
+	require.Contains(t, info, filepath.Join(root, "util", "helpers.go"))
+
+	cached, err := s.ProjectInfo()
+	require.NoError(t, err)
+	assert.Equal(t, info, cached, "an unchanged project should be served from the session")
+
+	t.Run("modified file", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(filepath.Join(root, "util", "helpers.go"), []byte("package util\n\nfunc Helper() {}\n\nfunc Other() {}\n"), 0644))
+		require.Eventually(t, func() bool {
+			info, err := s.ProjectInfo()
+			return err == nil && len(info[filepath.Join(root, "util", "helpers.go")].Functions) == 2
+		}, 5*time.Second, 50*time.Millisecond)
+	})
+
+	t.Run("new package", func(t *testing.T) {
+		require.NoError(t, os.MkdirAll(filepath.Join(root, "extra"), 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(root, "extra", "extra.go"), []byte("package extra\n\nfunc Extra() {}\n"), 0644))
+		require.Eventually(t, func() bool {
+			info, err := s.ProjectInfo()
+			return err == nil && info[filepath.Join(root, "extra", "extra.go")] != nil
+		}, 5*time.Second, 50*time.Millisecond)
+	})
+}
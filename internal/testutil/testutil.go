@@ -0,0 +1,34 @@
+// Package testutil writes the Go projects the tests of the other packages parse.
+package testutil
+
+import (
+	"os"
+	"path"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// WriteFiles writes files, slash-separated paths relative to dir mapped to their content, creating the
+// directories they need.
+func WriteFiles(t testing.TB, dir string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		absPath := filepath.Join(dir, filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(absPath), 0755))
+		require.NoError(t, os.WriteFile(absPath, []byte(content), 0644))
+	}
+}
+
+// WriteModule writes a module named modulePath with files into a new temporary directory named after its
+// last element, and returns the path of the directory. A go.mod in files replaces the one written for the module.
+// The go command is not run: a module requiring others must list them in its go.mod, e.g. with a replace
+// directive pointing into the module, so that tests need no network.
+func WriteModule(t testing.TB, modulePath string, files map[string]string) string {
+	t.Helper()
+	root := filepath.Join(t.TempDir(), path.Base(modulePath))
+	WriteFiles(t, root, map[string]string{"go.mod": "module " + modulePath + "\n\ngo 1.21\n"})
+	WriteFiles(t, root, files)
+	return root
+}
@@ -0,0 +1,40 @@
+// Package tokenize splits Go identifiers and the text around them into words, the same way for keyword search
+// and for the local embeddings.
+package tokenize
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Words splits text into lower-case words of letters and digits, splitting identifiers at case changes too.
+func Words(text string) []string {
+	var words []string
+	for _, field := range strings.FieldsFunc(text, func(r rune) bool { return !unicode.IsLetter(r) && !unicode.IsDigit(r) }) {
+		words = append(words, SplitIdentifier(field)...)
+	}
+	return words
+}
+
+// SplitIdentifier splits an identifier into its lower-case words, e.g. "parseHTTPRequest" into "parse", "http"
+// and "request".
+func SplitIdentifier(name string) []string {
+	runes := []rune(name)
+	var words []string
+	start := 0
+	for i := 1; i <= len(runes); i++ {
+		boundary := i == len(runes) || runes[i] == '_'
+		if !boundary && unicode.IsUpper(runes[i]) {
+			// A new word starts at an upper-case letter following a lower-case one, or preceding one in an acronym
+			boundary = unicode.IsLower(runes[i-1]) || (i+1 < len(runes) && unicode.IsLower(runes[i+1]) && unicode.IsUpper(runes[i-1]))
+		}
+		if !boundary {
+			continue
+		}
+		if word := strings.Trim(string(runes[start:i]), "_"); word != "" {
+			words = append(words, strings.ToLower(word))
+		}
+		start = i
+	}
+	return words
+}
@@ -0,0 +1,18 @@
+package tokenize
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWords(t *testing.T) {
+	assert.Equal(t, []string{"parse", "http", "request", "of", "user", "id"}, Words("parseHTTPRequest of user_id"))
+	assert.Equal(t, []string{"retry", "uploads", "2", "times"}, Words("// Retry uploads, 2 times."))
+}
+
+func TestSplitIdentifier(t *testing.T) {
+	assert.Equal(t, []string{"parse", "http", "request"}, SplitIdentifier("parseHTTPRequest"))
+	assert.Equal(t, []string{"user", "id"}, SplitIdentifier("_user_ID"))
+	assert.Equal(t, []string{"url"}, SplitIdentifier("URL"))
+}
@@ -0,0 +1,57 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/vlad/ast2llm-go/internal/composer"
+	"github.com/vlad/ast2llm-go/internal/git"
+	"github.com/vlad/ast2llm-go/internal/parser"
+)
+
+// NewCompareAPITool returns the mcp.Tool for diffing the exported API of two versions of a project
+func NewCompareAPITool() mcp.Tool {
+	return mcp.NewTool("compare_api",
+		mcp.WithDescription("Report how the exported API of a Go project changed since an older version: the functions, types, methods, variables and constants removed, added or whose signature changed. Useful to find the callers to update"),
+		mcp.WithString("projectPath",
+			mcp.Required(),
+			mcp.Description("Path to the current version of the Go project"),
+		),
+		mcp.WithString("base",
+			mcp.Required(),
+			mcp.Description("Older version to compare with: a directory holding it or a git ref of the project's repository, e.g. main or v1.2.0"),
+		),
+	)
+}
+
+// CompareAPIToolHandler returns a handler for the compare_api tool
+func CompareAPIToolHandler(p parser.Parser) func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		projectPath, err := request.RequireString("projectPath")
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		base, err := request.RequireString("base")
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		oldPath := base
+		if info, err := os.Stat(base); err != nil || !info.IsDir() {
+			snapshot, cleanup, err := git.Snapshot(projectPath, base)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("base is neither a directory nor a git ref: %v", err)), nil
+			}
+			defer cleanup()
+			oldPath = snapshot
+		}
+
+		changes, err := p.CompareProjects(oldPath, projectPath)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("failed to compare APIs: %v", err)), nil
+		}
+		return mcp.NewToolResultText(composer.FormatAPIChanges(changes)), nil
+	}
+}
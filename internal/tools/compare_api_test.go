@@ -0,0 +1,71 @@
+package tools
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vlad/ast2llm-go/internal/parser"
+)
+
+func TestNewCompareAPITool(t *testing.T) {
+	tool := NewCompareAPITool()
+	assert.Equal(t, "compare_api", tool.Name)
+	assert.ElementsMatch(t, []string{"projectPath", "base"}, tool.InputSchema.Required)
+}
+
+func TestCompareAPIToolHandler(t *testing.T) {
+	writeProject := func(dir, source string) {
+		require.NoError(t, os.MkdirAll(filepath.Join(dir, "api"), 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module example.com/app\ngo 1.22\n"), 0644))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "api", "api.go"), []byte(source), 0644))
+	}
+	oldPath, newPath := t.TempDir(), t.TempDir()
+	writeProject(oldPath, "package api\n\nfunc New() int { return 0 }\n")
+	writeProject(newPath, "package api\n\nfunc New(port int) int { return port }\n")
+	handler := CompareAPIToolHandler(parser.New())
+
+	call := func(args map[string]any) *mcp.CallToolResult {
+		result, err := handler(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: args}})
+		require.NoError(t, err)
+		return result
+	}
+
+	result := call(map[string]any{"projectPath": newPath, "base": oldPath})
+	require.False(t, result.IsError, result.Content)
+	assert.Equal(t, `--- API Changes ---
+
+Changed:
+- example.com/app/api.New
+  Old: func New() int
+  New: func New(port int) int
+`, result.Content[0].(mcp.TextContent).Text)
+
+	if _, err := exec.LookPath("git"); err == nil {
+		for _, args := range [][]string{
+			{"init", "-q"},
+			{"add", "-A"},
+			{"-c", "user.name=test", "-c", "user.email=test@example.com", "commit", "-q", "-m", "initial"},
+		} {
+			cmd := exec.Command("git", args...)
+			cmd.Dir = newPath
+			out, err := cmd.CombinedOutput()
+			require.NoError(t, err, string(out))
+		}
+		require.NoError(t, os.WriteFile(filepath.Join(newPath, "api", "api.go"), []byte("package api\n\nfunc New(port int) int { return port }\n\nfunc Close() {}\n"), 0644))
+
+		result = call(map[string]any{"projectPath": newPath, "base": "HEAD"})
+		require.False(t, result.IsError, result.Content)
+		assert.Equal(t, "--- API Changes ---\n\nAdded:\n- example.com/app/api.Close: func Close()\n", result.Content[0].(mcp.TextContent).Text)
+	}
+
+	result = call(map[string]any{"projectPath": newPath, "base": "no-such-ref"})
+	assert.True(t, result.IsError)
+	result = call(map[string]any{"projectPath": newPath})
+	assert.True(t, result.IsError)
+}
@@ -0,0 +1,75 @@
+package tools
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/base64"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// Values of the compress argument of the tools declared with withCompressArgument. Only compressions of the
+// standard library are supported, zstd would need a third-party module
+const compressGzip = "gzip"
+
+// compressThreshold is the size in bytes under which responses are not compressed, since base64 eats most of
+// the saving of short texts
+const compressThreshold = 1024
+
+// encodingMetaKey is the key of the result metadata naming the encoding of the compressed content
+const encodingMetaKey = "encoding"
+
+// withCompressArgument declares the compress argument of a tool whose handler is wrapped with compressible
+func withCompressArgument() mcp.ToolOption {
+	return mcp.WithString("compress",
+		mcp.Description(fmt.Sprintf("Compress the response for clients able to decompress it: gzip returns the first content as base64 of the gzip-compressed text and sets %q to \"gzip+base64\" in the result metadata. Responses under %d bytes are left uncompressed", encodingMetaKey, compressThreshold)),
+		mcp.Enum(compressGzip),
+	)
+}
+
+// compressible wraps the handler of a tool declared with withCompressArgument, compressing the first text
+// content of successful results as the compress argument asks.
+func compressible(handler server.ToolHandlerFunc) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		compress := request.GetString("compress", "")
+		if compress != "" && compress != compressGzip {
+			return mcp.NewToolResultError(fmt.Sprintf("unsupported compress: %s", compress)), nil
+		}
+
+		result, err := handler(ctx, request)
+		if err != nil || result == nil || result.IsError || compress == "" || len(result.Content) == 0 {
+			return result, err
+		}
+		text, ok := result.Content[0].(mcp.TextContent)
+		if !ok || len(text.Text) < compressThreshold {
+			return result, nil
+		}
+		encoded, err := gzipBase64(text.Text)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("failed to compress response: %v", err)), nil
+		}
+		text.Text = encoded
+		result.Content[0] = text
+		if result.Meta == nil {
+			result.Meta = make(map[string]any)
+		}
+		result.Meta[encodingMetaKey] = compressGzip + "+base64"
+		return result, nil
+	}
+}
+
+// gzipBase64 returns the base64 encoding of text compressed with gzip.
+func gzipBase64(text string) (string, error) {
+	var buf bytes.Buffer
+	w := gzip.NewWriter(&buf)
+	if _, err := w.Write([]byte(text)); err != nil {
+		return "", err
+	}
+	if err := w.Close(); err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(buf.Bytes()), nil
+}
@@ -0,0 +1,69 @@
+package tools
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/base64"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// gunzipBase64 reverses gzipBase64
+func gunzipBase64(t *testing.T, encoded string) string {
+	t.Helper()
+	compressed, err := base64.StdEncoding.DecodeString(encoded)
+	require.NoError(t, err)
+	r, err := gzip.NewReader(bytes.NewReader(compressed))
+	require.NoError(t, err)
+	text, err := io.ReadAll(r)
+	require.NoError(t, err)
+	return string(text)
+}
+
+func TestCompressible(t *testing.T) {
+	long := strings.Repeat("Function: Handle(w http.ResponseWriter, r *http.Request)\n", 100)
+	handler := compressible(func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		text := request.GetString("text", "")
+		if text == "fail" {
+			return mcp.NewToolResultError("failed"), nil
+		}
+		result := mcp.NewToolResultText(text)
+		result.Content = append(result.Content, mcp.NewTextContent("note"))
+		return result, nil
+	})
+	call := func(args map[string]any) *mcp.CallToolResult {
+		result, err := handler(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: args}})
+		require.NoError(t, err)
+		return result
+	}
+
+	result := call(map[string]any{"text": long, "compress": "gzip"})
+	require.False(t, result.IsError)
+	assert.Equal(t, "gzip+base64", result.Meta["encoding"])
+	encoded := result.Content[0].(mcp.TextContent).Text
+	assert.Less(t, len(encoded), len(long))
+	assert.Equal(t, long, gunzipBase64(t, encoded))
+	assert.Equal(t, "note", result.Content[1].(mcp.TextContent).Text, "only the first content is compressed")
+
+	result = call(map[string]any{"text": long})
+	assert.Equal(t, long, result.Content[0].(mcp.TextContent).Text)
+	assert.Nil(t, result.Meta)
+
+	result = call(map[string]any{"text": "short", "compress": "gzip"})
+	assert.Equal(t, "short", result.Content[0].(mcp.TextContent).Text, "short responses are not compressed")
+	assert.Nil(t, result.Meta)
+
+	result = call(map[string]any{"text": "fail", "compress": "gzip"})
+	assert.True(t, result.IsError)
+	assert.Equal(t, "failed", result.Content[0].(mcp.TextContent).Text)
+
+	result = call(map[string]any{"text": long, "compress": "zstd"})
+	assert.True(t, result.IsError)
+	assert.Contains(t, result.Content[0].(mcp.TextContent).Text, "unsupported compress: zstd")
+}
@@ -0,0 +1,56 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/vlad/ast2llm-go/internal/parser"
+	ourtypes "github.com/vlad/ast2llm-go/internal/types"
+)
+
+// NewDeadExportsTool returns the mcp.Tool for finding the exports nothing in a project refers to
+func NewDeadExportsTool() mcp.Tool {
+	return mcp.NewTool("find_dead_exports",
+		mcp.WithDescription("List the exported package-level functions, types, variables and constants of a Go project that nothing in the project refers to, candidates for removal or unexporting. Methods are left out, since they may be called through interfaces"),
+		mcp.WithString("projectPath",
+			mcp.Required(),
+			mcp.Description("Path to the Go project"),
+		),
+	)
+}
+
+// DeadExportsToolHandler returns a handler for the find_dead_exports tool
+func DeadExportsToolHandler(p parser.Parser) func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		projectPath, err := request.RequireString("projectPath")
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		exports, err := p.FindDeadExports(projectPath)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("failed to find dead exports: %v", err)), nil
+		}
+		return mcp.NewToolResultText(formatDeadExports(projectPath, exports)), nil
+	}
+}
+
+// formatDeadExports renders unused exports one per line as kind, name and position relative to the project,
+// e.g. "func example.com/app/store.Reset (store/store.go:12)".
+func formatDeadExports(projectPath string, exports []*ourtypes.DeadExport) string {
+	if len(exports) == 0 {
+		return "No unused exports found\n"
+	}
+	var builder strings.Builder
+	builder.WriteString(fmt.Sprintf("Unused exports (%d):\n", len(exports)))
+	for _, e := range exports {
+		builder.WriteString(fmt.Sprintf("  %s %s", e.Kind, e.Name))
+		if e.Pos != nil {
+			builder.WriteString(fmt.Sprintf(" (%s:%d)", relativePaths(projectPath, []string{e.Pos.File})[0], e.Pos.Line))
+		}
+		builder.WriteString("\n")
+	}
+	return builder.String()
+}
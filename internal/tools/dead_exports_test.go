@@ -0,0 +1,52 @@
+package tools
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vlad/ast2llm-go/internal/parser"
+)
+
+func TestNewDeadExportsTool(t *testing.T) {
+	tool := NewDeadExportsTool()
+	assert.Equal(t, "find_dead_exports", tool.Name)
+	assert.ElementsMatch(t, []string{"projectPath"}, tool.InputSchema.Required)
+}
+
+func TestDeadExportsToolHandler(t *testing.T) {
+	root := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(root, "go.mod"), []byte("module example.com/app\ngo 1.22\n"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "main.go"), []byte(`package main
+
+// Version is printed by main.
+const Version = "1.0"
+
+// Reset is never called.
+func Reset() {}
+
+func main() { println(Version) }
+`), 0644))
+	handler := DeadExportsToolHandler(parser.New())
+
+	call := func(args map[string]any) *mcp.CallToolResult {
+		result, err := handler(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: args}})
+		require.NoError(t, err)
+		return result
+	}
+
+	result := call(map[string]any{"projectPath": root})
+	require.False(t, result.IsError, result.Content)
+	assert.Equal(t, "Unused exports (1):\n  func example.com/app.Reset (main.go:7)\n", result.Content[0].(mcp.TextContent).Text)
+
+	result = call(map[string]any{})
+	assert.True(t, result.IsError)
+}
+
+func TestFormatDeadExports(t *testing.T) {
+	assert.Equal(t, "No unused exports found\n", formatDeadExports("/project", nil))
+}
@@ -0,0 +1,135 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/vlad/ast2llm-go/internal/parser"
+	ourtypes "github.com/vlad/ast2llm-go/internal/types"
+)
+
+// Output formats supported by the get_dependency_graph tool
+const (
+	graphFormatDOT  = "dot"
+	graphFormatJSON = "json"
+)
+
+// NewDependencyGraphTool returns the mcp.Tool for building the package dependency graph
+func NewDependencyGraphTool() mcp.Tool {
+	return mcp.NewTool("get_dependency_graph",
+		mcp.WithDescription("Build the package dependency graph of a Go project"),
+		mcp.WithString("projectPath",
+			mcp.Required(),
+			mcp.Description("Path to the Go project"),
+		),
+		mcp.WithString("root",
+			mcp.Description("Package path to start from; only packages it imports directly or transitively are included"),
+		),
+		mcp.WithNumber("depth",
+			mcp.Description("Maximum number of imports to follow from root (0 means unlimited)"),
+		),
+		mcp.WithString("include",
+			mcp.Description("Comma-separated package path patterns to keep, e.g. \"example.com/app/internal/...\""),
+		),
+		mcp.WithString("exclude",
+			mcp.Description("Comma-separated package path patterns to drop"),
+		),
+		mcp.WithString("format",
+			mcp.Description("Output format: json (default) or dot"),
+			mcp.Enum(graphFormatJSON, graphFormatDOT),
+		),
+		mcp.WithNumber("schemaVersion",
+			mcp.Description(fmt.Sprintf("Schema version of the json output, %d to %d (default %d). Version 1 names fields like Go fields, e.g. DependsOn", ourtypes.MinSchemaVersion, ourtypes.SchemaVersion, ourtypes.SchemaVersion)),
+		),
+	)
+}
+
+// DependencyGraphToolHandler returns a handler for the get_dependency_graph tool
+func DependencyGraphToolHandler(p parser.Parser) func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		projectPath, err := request.RequireString("projectPath")
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		format := request.GetString("format", graphFormatJSON)
+		if format != graphFormatJSON && format != graphFormatDOT {
+			return mcp.NewToolResultError(fmt.Sprintf("unsupported format: %s", format)), nil
+		}
+		include := splitPatterns(request.GetString("include", ""))
+		exclude := splitPatterns(request.GetString("exclude", ""))
+		for _, pattern := range append(include, exclude...) {
+			if _, err := path.Match(pattern, ""); err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("invalid pattern %q: %v", pattern, err)), nil
+			}
+		}
+
+		graph, err := p.BuildGraphContext(ctx, projectPath)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("failed to build dependency graph: %v", err)), nil
+		}
+
+		if root := request.GetString("root", ""); root != "" {
+			graph = graph.Subgraph(root, request.GetInt("depth", 0))
+			if graph == nil {
+				return mcp.NewToolResultError(fmt.Sprintf("package not found in project: %s", root)), nil
+			}
+		}
+		if len(include) > 0 || len(exclude) > 0 {
+			graph = graph.Filter(func(pkgPath string) bool {
+				return (len(include) == 0 || matchAnyPackage(include, pkgPath)) && !matchAnyPackage(exclude, pkgPath)
+			})
+		}
+
+		if format == graphFormatDOT {
+			return mcp.NewToolResultText(graph.ToDOT()), nil
+		}
+		return graphJSONResult(graph, request.GetInt("schemaVersion", ourtypes.SchemaVersion))
+	}
+}
+
+// graphJSONResult renders the graph as indented JSON in the given schema version
+func graphJSONResult(graph *ourtypes.DependencyGraph, schemaVersion int) (*mcp.CallToolResult, error) {
+	versioned, err := ourtypes.ForSchemaVersion(graph, schemaVersion)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	data, err := json.MarshalIndent(versioned, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to marshal dependency graph: %v", err)), nil
+	}
+	return mcp.NewToolResultText(string(data)), nil
+}
+
+// splitPatterns splits a comma-separated pattern list, dropping empty entries
+func splitPatterns(list string) []string {
+	var patterns []string
+	for _, pattern := range strings.Split(list, ",") {
+		if pattern = strings.TrimSpace(pattern); pattern != "" {
+			patterns = append(patterns, pattern)
+		}
+	}
+	return patterns
+}
+
+// matchAnyPackage reports whether pkgPath matches one of the patterns.
+// A pattern ending in "/..." matches the package and everything below it, like in go list;
+// other patterns are matched with path.Match.
+func matchAnyPackage(patterns []string, pkgPath string) bool {
+	for _, pattern := range patterns {
+		if prefix, ok := strings.CutSuffix(pattern, "/..."); ok {
+			if pkgPath == prefix || strings.HasPrefix(pkgPath, prefix+"/") {
+				return true
+			}
+			continue
+		}
+		if matched, _ := path.Match(pattern, pkgPath); matched {
+			return true
+		}
+	}
+	return false
+}
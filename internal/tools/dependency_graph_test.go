@@ -0,0 +1,108 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vlad/ast2llm-go/internal/parser"
+	"github.com/vlad/ast2llm-go/internal/testutil"
+)
+
+// writeGraphProject creates a project where main imports a, a imports b and b imports c.
+func writeGraphProject(t *testing.T) string {
+	t.Helper()
+	return testutil.WriteModule(t, "example.com/graph", map[string]string{
+		"main.go": "package main\n\nimport \"example.com/graph/a\"\n\nfunc main() { a.A() }\n",
+		"a/a.go":  "package a\n\nimport \"example.com/graph/b\"\n\nfunc A() { b.B() }\n",
+		"b/b.go":  "package b\n\nimport \"example.com/graph/c\"\n\nfunc B() { c.C() }\n",
+		"c/c.go":  "package c\n\nfunc C() {}\n",
+	})
+}
+
+func TestNewDependencyGraphTool(t *testing.T) {
+	tool := NewDependencyGraphTool()
+	assert.Equal(t, "get_dependency_graph", tool.Name)
+
+	b, err := json.Marshal(tool)
+	require.NoError(t, err)
+	for _, arg := range []string{"projectPath", "root", "depth", "include", "exclude", "format", "schemaVersion"} {
+		assert.Contains(t, string(b), arg)
+	}
+}
+
+func TestDependencyGraphToolHandler(t *testing.T) {
+	projectPath := writeGraphProject(t)
+	handler := DependencyGraphToolHandler(parser.New())
+
+	call := func(args map[string]any) *mcp.CallToolResult {
+		args["projectPath"] = projectPath
+		result, err := handler(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: args}})
+		require.NoError(t, err)
+		return result
+	}
+	nodes := func(result *mcp.CallToolResult) []string {
+		require.False(t, result.IsError, result.Content)
+		var graph struct{ Nodes map[string]any }
+		require.NoError(t, json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &graph))
+		var paths []string
+		for path := range graph.Nodes {
+			paths = append(paths, path)
+		}
+		return paths
+	}
+
+	t.Run("whole project", func(t *testing.T) {
+		assert.ElementsMatch(t, []string{"example.com/graph", "example.com/graph/a", "example.com/graph/b", "example.com/graph/c"}, nodes(call(map[string]any{})))
+	})
+
+	t.Run("root and depth", func(t *testing.T) {
+		result := call(map[string]any{"root": "example.com/graph/a", "depth": 1})
+		assert.ElementsMatch(t, []string{"example.com/graph/a", "example.com/graph/b"}, nodes(result))
+	})
+
+	t.Run("include and exclude", func(t *testing.T) {
+		result := call(map[string]any{"include": "example.com/graph/...", "exclude": "example.com/graph/b, example.com/graph"})
+		assert.ElementsMatch(t, []string{"example.com/graph/a", "example.com/graph/c"}, nodes(result))
+	})
+
+	t.Run("dot", func(t *testing.T) {
+		result := call(map[string]any{"root": "example.com/graph/b", "format": "dot"})
+		require.False(t, result.IsError)
+		text := result.Content[0].(mcp.TextContent).Text
+		assert.Contains(t, text, "digraph dependencies {")
+		assert.Contains(t, text, `"example.com/graph/b" -> "example.com/graph/c";`)
+		assert.NotContains(t, text, `"example.com/graph/a"`)
+	})
+
+	t.Run("schema version", func(t *testing.T) {
+		result := call(map[string]any{"root": "example.com/graph/c"})
+		require.False(t, result.IsError)
+		assert.Contains(t, result.Content[0].(mcp.TextContent).Text, `"pkg_path": "example.com/graph/c"`)
+
+		result = call(map[string]any{"root": "example.com/graph/c", "schemaVersion": 1})
+		require.False(t, result.IsError)
+		assert.Contains(t, result.Content[0].(mcp.TextContent).Text, `"PkgPath": "example.com/graph/c"`)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		assert.True(t, call(map[string]any{"root": "example.com/missing"}).IsError)
+		assert.True(t, call(map[string]any{"schemaVersion": 9}).IsError)
+		assert.True(t, call(map[string]any{"format": "svg"}).IsError)
+		assert.True(t, call(map[string]any{"include": "[bad"}).IsError)
+	})
+}
+
+func TestDependencyGraphToolHandler_Cancelled(t *testing.T) {
+	projectPath := writeGraphProject(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	result, err := DependencyGraphToolHandler(parser.New())(ctx, mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]any{"projectPath": projectPath}}})
+	require.NoError(t, err)
+	require.True(t, result.IsError)
+	assert.Contains(t, result.Content[0].(mcp.TextContent).Text, context.Canceled.Error())
+}
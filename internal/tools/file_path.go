@@ -0,0 +1,121 @@
+package tools
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/vlad/ast2llm-go/internal/parser"
+)
+
+// maxSuggestions is the number of similar files listed when a requested file is not found
+const maxSuggestions = 5
+
+// resolveProjectFile returns the path projectInfo keys the requested file under. filePath may be absolute,
+// relative to projectPath, or the end of a path such as a basename, e.g. main.go or models/user.go, as long as
+// a single file matches. Errors list the candidates of an ambiguous name, or files with similar names.
+func resolveProjectFile(projectInfo parser.ProjectInfo, projectPath, filePath string) (string, error) {
+	fullPath, err := parser.ResolveFilePath(projectPath, filePath)
+	if err != nil {
+		return "", err
+	}
+	if _, ok := projectInfo[fullPath]; ok {
+		return fullPath, nil
+	}
+	for path := range projectInfo {
+		if parser.SamePath(path, fullPath) {
+			return path, nil
+		}
+	}
+
+	root, err := filepath.Abs(projectPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve project path: %w", err)
+	}
+	if !filepath.IsAbs(filepath.FromSlash(filePath)) {
+		suffix := string(filepath.Separator) + filepath.Clean(filepath.FromSlash(filePath))
+		var matches []string
+		for path := range projectInfo {
+			if strings.HasSuffix(path, suffix) {
+				matches = append(matches, path)
+			}
+		}
+		switch len(matches) {
+		case 0:
+		case 1:
+			return matches[0], nil
+		default:
+			sort.Strings(matches)
+			return "", fmt.Errorf("file path %s is ambiguous, it matches %s", filePath, strings.Join(relativePaths(root, matches), ", "))
+		}
+	}
+
+	if suggestions := similarFiles(projectInfo, root, fullPath); len(suggestions) > 0 {
+		return "", fmt.Errorf("file not found in project: %s, did you mean %s?", filePath, strings.Join(suggestions, ", "))
+	}
+	return "", fmt.Errorf("file not found in project: %s", filePath)
+}
+
+// similarFiles returns up to maxSuggestions files of the project, relative to root, whose names are close to
+// the name of fullPath, closest first.
+func similarFiles(projectInfo parser.ProjectInfo, root, fullPath string) []string {
+	name := strings.ToLower(filepath.Base(fullPath))
+	type candidate struct {
+		path     string
+		distance int
+	}
+	var candidates []candidate
+	for path := range projectInfo {
+		base := strings.ToLower(filepath.Base(path))
+		distance := editDistance(name, base)
+		if distance <= max(2, len(name)/3) || strings.Contains(base, strings.TrimSuffix(name, ".go")) {
+			candidates = append(candidates, candidate{path: path, distance: distance})
+		}
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		if candidates[i].distance != candidates[j].distance {
+			return candidates[i].distance < candidates[j].distance
+		}
+		return candidates[i].path < candidates[j].path
+	})
+
+	paths := make([]string, 0, maxSuggestions)
+	for i := 0; i < len(candidates) && i < maxSuggestions; i++ {
+		paths = append(paths, candidates[i].path)
+	}
+	return relativePaths(root, paths)
+}
+
+// relativePaths returns paths relative to root with slashes, in the order given. Paths outside root stay absolute.
+func relativePaths(root string, paths []string) []string {
+	rel := make([]string, len(paths))
+	for i, path := range paths {
+		rel[i] = path
+		if r, err := filepath.Rel(root, path); err == nil && !strings.HasPrefix(r, "..") {
+			rel[i] = filepath.ToSlash(r)
+		}
+	}
+	return rel
+}
+
+// editDistance returns the Levenshtein distance of a and b
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(b)]
+}
@@ -0,0 +1,62 @@
+package tools
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vlad/ast2llm-go/internal/parser"
+)
+
+func TestResolveProjectFile(t *testing.T) {
+	root := filepath.Join(t.TempDir(), "app")
+	path := func(rel string) string { return filepath.Join(root, filepath.FromSlash(rel)) }
+	projectInfo := parser.ProjectInfo{
+		path("main.go"):             {},
+		path("models/user.go"):      {},
+		path("models/user_test.go"): {},
+		path("cmd/a/main.go"):       {},
+		path("cmd/b/main.go"):       {},
+		path("service/service.go"):  {},
+	}
+
+	tests := []struct {
+		filePath string
+		want     string
+		wantErr  string
+	}{
+		{filePath: "main.go", want: path("main.go")},
+		{filePath: path("models/user.go"), want: path("models/user.go")},
+		{filePath: "models/user.go", want: path("models/user.go")},
+		{filePath: "user.go", want: path("models/user.go")},
+		{filePath: "a/main.go", want: path("cmd/a/main.go")},
+		{filePath: "service.go", want: path("service/service.go")},
+		{filePath: "cmd/main.go", wantErr: "file not found in project: cmd/main.go, did you mean cmd/a/main.go, cmd/b/main.go, main.go?"},
+		{filePath: "usr.go", wantErr: "file not found in project: usr.go, did you mean models/user.go?"},
+		{filePath: "config.go", wantErr: "file not found in project: config.go"},
+		{filePath: path("other/main.go"), wantErr: "file not found in project: " + path("other/main.go") + ", did you mean cmd/a/main.go, cmd/b/main.go, main.go?"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.filePath, func(t *testing.T) {
+			got, err := resolveProjectFile(projectInfo, root, tt.filePath)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	delete(projectInfo, path("main.go"))
+	_, err := resolveProjectFile(projectInfo, root, "main.go")
+	assert.EqualError(t, err, "file path main.go is ambiguous, it matches cmd/a/main.go, cmd/b/main.go")
+}
+
+func TestEditDistance(t *testing.T) {
+	assert.Equal(t, 0, editDistance("main.go", "main.go"))
+	assert.Equal(t, 1, editDistance("usr.go", "user.go"))
+	assert.Equal(t, 3, editDistance("kitten", "sitting"))
+	assert.Equal(t, 4, editDistance("", "abcd"))
+}
@@ -0,0 +1,86 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/vlad/ast2llm-go/internal/parser"
+	ourtypes "github.com/vlad/ast2llm-go/internal/types"
+)
+
+// NewInterfaceImplTool returns the mcp.Tool for checking whether a type implements an interface
+func NewInterfaceImplTool() mcp.Tool {
+	return mcp.NewTool("check_interface_impl",
+		mcp.WithDescription("Check whether a Go type implements an interface and list the methods that are missing or have the wrong signature"),
+		mcp.WithString("projectPath",
+			mcp.Required(),
+			mcp.Description("Path to the Go project"),
+		),
+		mcp.WithString("typeName",
+			mcp.Required(),
+			mcp.Description("Type declared in the project, e.g. Memory, store.Memory or its ID example.com/app/store.Memory"),
+		),
+		mcp.WithString("interfaceName",
+			mcp.Required(),
+			mcp.Description("Interface declared in the project or an imported package, e.g. store.Store, io.Closer or its ID example.com/app/store.Store"),
+		),
+	)
+}
+
+// InterfaceImplToolHandler returns a handler for the check_interface_impl tool
+func InterfaceImplToolHandler(p parser.Parser) func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		projectPath, err := request.RequireString("projectPath")
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		typeName, err := request.RequireString("typeName")
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		interfaceName, err := request.RequireString("interfaceName")
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		check, err := p.CheckInterfaceImpl(projectPath, typeName, interfaceName)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("failed to check interface implementation: %v", err)), nil
+		}
+		return mcp.NewToolResultText(formatInterfaceCheck(check)), nil
+	}
+}
+
+// formatInterfaceCheck renders the verdict for the type and its pointer followed by one line per problem
+func formatInterfaceCheck(check *ourtypes.InterfaceCheck) string {
+	var builder strings.Builder
+	builder.WriteString(fmt.Sprintf("Type: %s\nInterface: %s\n", check.Type, check.Interface))
+	builder.WriteString(fmt.Sprintf("Implemented by %s: %s\n", check.Type, yesNo(check.Implements)))
+	builder.WriteString(fmt.Sprintf("Implemented by *%s: %s\n", check.Type, yesNo(check.PointerImplements)))
+	if len(check.Problems) == 0 {
+		return builder.String()
+	}
+
+	builder.WriteString("Problems:\n")
+	for _, problem := range check.Problems {
+		switch problem.Kind {
+		case ourtypes.ProblemMissing:
+			builder.WriteString(fmt.Sprintf("  - %s: missing, want %s\n", problem.Method, problem.Want))
+		case ourtypes.ProblemWrongSignature:
+			builder.WriteString(fmt.Sprintf("  - %s: wrong signature, want %s, have %s\n", problem.Method, problem.Want, problem.Have))
+		case ourtypes.ProblemPointerReceiver:
+			builder.WriteString(fmt.Sprintf("  - %s: pointer receiver, only *%s has it\n", problem.Method, check.Type))
+		}
+	}
+	return builder.String()
+}
+
+// yesNo renders a boolean for the LLM
+func yesNo(b bool) string {
+	if b {
+		return "yes"
+	}
+	return "no"
+}
@@ -0,0 +1,69 @@
+package tools
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vlad/ast2llm-go/internal/parser"
+	ourtypes "github.com/vlad/ast2llm-go/internal/types"
+)
+
+func TestNewInterfaceImplTool(t *testing.T) {
+	tool := NewInterfaceImplTool()
+	assert.Equal(t, "check_interface_impl", tool.Name)
+	assert.ElementsMatch(t, []string{"projectPath", "typeName", "interfaceName"}, tool.InputSchema.Required)
+}
+
+func TestInterfaceImplToolHandler(t *testing.T) {
+	root := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(root, "go.mod"), []byte("module example.com/impl\ngo 1.21\n"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "main.go"), []byte(`package main
+
+type File struct{}
+
+func (f *File) Close() error { return nil }
+
+func (f File) Read(p []byte) int { return 0 }
+
+func main() {}
+`), 0644))
+	handler := InterfaceImplToolHandler(parser.New())
+
+	call := func(args map[string]any) *mcp.CallToolResult {
+		result, err := handler(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: args}})
+		require.NoError(t, err)
+		return result
+	}
+
+	result := call(map[string]any{"projectPath": root, "typeName": "File", "interfaceName": "io.ReadCloser"})
+	require.False(t, result.IsError, result.Content)
+	assert.Equal(t, `Type: example.com/impl.File
+Interface: io.ReadCloser
+Implemented by example.com/impl.File: no
+Implemented by *example.com/impl.File: no
+Problems:
+  - Close: pointer receiver, only *example.com/impl.File has it
+  - Read: wrong signature, want (p []byte) (n int, err error), have (p []byte) int
+`, result.Content[0].(mcp.TextContent).Text)
+
+	result = call(map[string]any{"projectPath": root, "typeName": "File", "interfaceName": "io.Closer"})
+	require.False(t, result.IsError, result.Content)
+	assert.Contains(t, result.Content[0].(mcp.TextContent).Text, "Implemented by *example.com/impl.File: yes\n")
+
+	result = call(map[string]any{"projectPath": root, "typeName": "File"})
+	assert.True(t, result.IsError)
+}
+
+func TestFormatInterfaceCheck_Missing(t *testing.T) {
+	check := &ourtypes.InterfaceCheck{
+		Type:      "example.com/app.T",
+		Interface: "example.com/app.I",
+		Problems:  []*ourtypes.MethodProblem{{Method: "Run", Kind: ourtypes.ProblemMissing, Want: "(ctx context.Context) error"}},
+	}
+	assert.Contains(t, formatInterfaceCheck(check), "  - Run: missing, want (ctx context.Context) error\n")
+}
@@ -0,0 +1,49 @@
+package tools
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// Keys of the result metadata of the tools declared with withKnownHashArgument
+const (
+	contextHashMetaKey = "contextHash" // Hash of the composed context, to pass as knownHash on the next call
+	notModifiedMetaKey = "notModified" // True if the context matched knownHash and was not sent
+)
+
+// withKnownHashArgument declares the knownHash argument of a tool whose results are built with
+// knownHashResult
+func withKnownHashArgument() mcp.ToolOption {
+	return mcp.WithString("knownHash",
+		mcp.Description("contextHash from the metadata of a previous result for the same arguments. If the context has not changed since, it is not sent again: the result only says so and sets \"notModified\" in its metadata. The hash covers the whole context, including the items the file uses from other files"),
+	)
+}
+
+// contextHash returns the hex SHA-256 of a composed context.
+func contextHash(context string) string {
+	sum := sha256.Sum256([]byte(context))
+	return hex.EncodeToString(sum[:])
+}
+
+// knownHashResult returns the result of a tool declared with withKnownHashArgument for the composed context:
+// a short note if it matches the knownHash argument, the result of build otherwise. Both carry the hash of
+// the context in their metadata.
+func knownHashResult(request mcp.CallToolRequest, context string, build func() *mcp.CallToolResult) *mcp.CallToolResult {
+	hash := contextHash(context)
+	var result *mcp.CallToolResult
+	if request.GetString("knownHash", "") == hash {
+		result = mcp.NewToolResultText("Context not modified since knownHash")
+		result.Meta = map[string]any{notModifiedMetaKey: true}
+	} else {
+		result = build()
+	}
+	if !result.IsError {
+		if result.Meta == nil {
+			result.Meta = make(map[string]any)
+		}
+		result.Meta[contextHashMetaKey] = hash
+	}
+	return result
+}
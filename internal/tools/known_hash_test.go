@@ -0,0 +1,52 @@
+package tools
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vlad/ast2llm-go/internal/parser"
+)
+
+func TestParseGoToolHandler_KnownHash(t *testing.T) {
+	handler := ParseGoToolHandler(parser.New(), nil)
+	projectPath := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(projectPath, "go.mod"), []byte("module example.com/testproject_hash\ngo 1.21\n"), 0644))
+	mainPath := filepath.Join(projectPath, "main.go")
+	require.NoError(t, os.WriteFile(mainPath, []byte("package main\n\nfunc main() {}\n"), 0644))
+
+	call := func(args map[string]any) *mcp.CallToolResult {
+		args["projectPath"] = projectPath
+		args["filePath"] = "main.go"
+		result, err := handler(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: args}})
+		require.NoError(t, err)
+		require.False(t, result.IsError, result.Content)
+		return result
+	}
+
+	result := call(map[string]any{})
+	text := result.Content[0].(mcp.TextContent).Text
+	hash, ok := result.Meta[contextHashMetaKey].(string)
+	require.True(t, ok)
+	assert.Equal(t, contextHash(text), hash)
+	assert.Nil(t, result.Meta[notModifiedMetaKey])
+
+	result = call(map[string]any{"knownHash": hash})
+	assert.Equal(t, true, result.Meta[notModifiedMetaKey])
+	assert.Equal(t, hash, result.Meta[contextHashMetaKey])
+	assert.NotContains(t, result.Content[0].(mcp.TextContent).Text, "func main")
+
+	result = call(map[string]any{"knownHash": "stale"})
+	assert.Equal(t, text, result.Content[0].(mcp.TextContent).Text)
+	assert.Nil(t, result.Meta[notModifiedMetaKey])
+
+	require.NoError(t, os.WriteFile(mainPath, []byte("package main\n\nfunc main() {}\n\nfunc added() {}\n"), 0644))
+	result = call(map[string]any{"knownHash": hash})
+	assert.Nil(t, result.Meta[notModifiedMetaKey], "edits change the hash")
+	assert.NotEqual(t, hash, result.Meta[contextHashMetaKey])
+	assert.Contains(t, result.Content[0].(mcp.TextContent).Text, "added")
+}
@@ -0,0 +1,77 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/vlad/ast2llm-go/internal/parser"
+	"github.com/vlad/ast2llm-go/internal/session"
+	ourtypes "github.com/vlad/ast2llm-go/internal/types"
+)
+
+// NewListRoutesTool returns the mcp.Tool for listing the HTTP routes of a project
+func NewListRoutesTool() mcp.Tool {
+	return mcp.NewTool("list_routes",
+		mcp.WithDescription("List the routing table of a Go web project: every HTTP route registered with net/http, chi, gin, echo, gorilla/mux, fiber or httprouter, with its method, full path, handler and position"),
+		mcp.WithString("projectPath",
+			mcp.Required(),
+			mcp.Description("Path to the Go project"),
+		),
+		mcp.WithString("pathPrefix",
+			mcp.Description("Only list the routes whose path starts with this prefix, e.g. /api"),
+		),
+	)
+}
+
+// ListRoutesToolHandler returns a handler for the list_routes tool.
+func ListRoutesToolHandler(p parser.Parser, sessions *session.Manager) func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		projectPath, err := request.RequireString("projectPath")
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		pathPrefix := request.GetString("pathPrefix", "")
+
+		projectInfo, err := loadProjectInfo(ctx, request, p, sessions, projectPath)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("failed to parse project: %v", err)), nil
+		}
+
+		var routes []*ourtypes.Route
+		for _, route := range parser.Routes(projectInfo) {
+			if strings.HasPrefix(route.Path, pathPrefix) {
+				routes = append(routes, route)
+			}
+		}
+		return mcp.NewToolResultText(formatRoutes(projectPath, routes)), nil
+	}
+}
+
+// formatRoutes renders routes one per line as method, path, handler, framework and position relative to the
+// project, e.g. "GET /users/{id} -> getUser (chi, api/routes.go:12)".
+func formatRoutes(projectPath string, routes []*ourtypes.Route) string {
+	if len(routes) == 0 {
+		return "No routes found\n"
+	}
+	var builder strings.Builder
+	builder.WriteString(fmt.Sprintf("Routes (%d):\n", len(routes)))
+	for _, route := range routes {
+		method := route.Method
+		if method == "" {
+			method = "ANY"
+		}
+		builder.WriteString(fmt.Sprintf("  %s %s -> %s (%s", method, route.Path, route.Handler, route.Framework))
+		if route.Pos != nil {
+			file := route.Pos.File
+			if rel, err := filepath.Rel(projectPath, file); err == nil && !strings.HasPrefix(rel, "..") {
+				file = filepath.ToSlash(rel)
+			}
+			builder.WriteString(fmt.Sprintf(", %s:%d", file, route.Pos.Line))
+		}
+		builder.WriteString(")\n")
+	}
+	return builder.String()
+}
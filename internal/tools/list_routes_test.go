@@ -0,0 +1,61 @@
+package tools
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vlad/ast2llm-go/internal/parser"
+)
+
+func TestNewListRoutesTool(t *testing.T) {
+	tool := NewListRoutesTool()
+	assert.Equal(t, "list_routes", tool.Name)
+	assert.ElementsMatch(t, []string{"projectPath"}, tool.InputSchema.Required)
+}
+
+func TestListRoutesToolHandler(t *testing.T) {
+	root := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(root, "go.mod"), []byte("module example.com/web\ngo 1.22\n"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "main.go"), []byte(`package main
+
+import "net/http"
+
+func getUser(w http.ResponseWriter, r *http.Request) {}
+
+func main() {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/users/{id}", getUser)
+	mux.Handle("/static/", http.FileServer(http.Dir(".")))
+}
+`), 0644))
+	handler := ListRoutesToolHandler(parser.New(), nil)
+
+	call := func(args map[string]any) *mcp.CallToolResult {
+		result, err := handler(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: args}})
+		require.NoError(t, err)
+		return result
+	}
+
+	result := call(map[string]any{"projectPath": root})
+	require.False(t, result.IsError, result.Content)
+	assert.Equal(t, `Routes (2):
+  GET /api/users/{id} -> getUser (net/http, main.go:9)
+  ANY /static/ -> http.FileServer(http.Dir(".")) (net/http, main.go:10)
+`, result.Content[0].(mcp.TextContent).Text)
+
+	result = call(map[string]any{"projectPath": root, "pathPrefix": "/api"})
+	require.False(t, result.IsError, result.Content)
+	assert.Equal(t, "Routes (1):\n  GET /api/users/{id} -> getUser (net/http, main.go:9)\n", result.Content[0].(mcp.TextContent).Text)
+
+	result = call(map[string]any{"projectPath": root, "pathPrefix": "/admin"})
+	require.False(t, result.IsError, result.Content)
+	assert.Equal(t, "No routes found\n", result.Content[0].(mcp.TextContent).Text)
+
+	result = call(map[string]any{})
+	assert.True(t, result.IsError)
+}
@@ -0,0 +1,115 @@
+package tools
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// defaultChunkSize is the number of characters of a parse_go response above which the output is split into
+// chunks, small enough for clients that truncate large MCP messages
+const defaultChunkSize = 100000
+
+// withChunkingArguments declares the chunkSize and continuation arguments of the tools splitting long output,
+// see chunkedResult
+func withChunkingArguments() mcp.ToolOption {
+	return func(t *mcp.Tool) {
+		mcp.WithNumber("chunkSize",
+			mcp.Description(fmt.Sprintf("Maximum number of characters of a response (default %d, 0 for no limit). Longer output is split into chunks, the response then ends with a continuation token for the next one", defaultChunkSize)),
+		)(t)
+		mcp.WithString("continuation",
+			mcp.Description("Continuation token returned with the previous chunk of the output; the other arguments must not change"),
+		)(t)
+	}
+}
+
+// chunkedResult returns the chunk of output the chunkSize and continuation arguments of a tool declared with
+// withChunkingArguments ask for, or an error result if the continuation token is invalid.
+func chunkedResult(request mcp.CallToolRequest, output string) *mcp.CallToolResult {
+	chunk, next, err := paginate(output, request.GetString("continuation", ""), request.GetInt("chunkSize", defaultChunkSize))
+	if err != nil {
+		return mcp.NewToolResultError(err.Error())
+	}
+	return chunkResult(chunk, next)
+}
+
+// paginate returns the chunk of output starting where token points to, or at the start if token is empty,
+// together with the token of the next chunk, empty for the last one. Chunks end after a line break if one is
+// within chunkSize characters; chunkSize 0 or less returns the whole output. Tokens carry a hash of output
+// so that a token is refused once the output it was created for changed, e.g. because a file was edited.
+func paginate(output, token string, chunkSize int) (chunk, next string, err error) {
+	hash := outputHash(output)
+	offset := 0
+	if token != "" {
+		offset, err = parseContinuation(token, hash)
+		if err != nil {
+			return "", "", err
+		}
+		if offset > len(output) {
+			return "", "", fmt.Errorf("invalid continuation token")
+		}
+	}
+
+	rest := output[offset:]
+	if chunkSize <= 0 || len(rest) <= chunkSize {
+		return rest, "", nil
+	}
+	end := chunkSize
+	if i := strings.LastIndexByte(rest[:end], '\n'); i >= 0 {
+		end = i + 1
+	} else {
+		for end > 0 && !utf8.RuneStart(rest[end]) {
+			end--
+		}
+		if end == 0 { // chunkSize is shorter than the first character
+			_, end = utf8.DecodeRuneInString(rest)
+		}
+	}
+	return rest[:end], formatContinuation(offset+end, hash), nil
+}
+
+// outputHash returns a short hash of a composed output identifying it in continuation tokens.
+func outputHash(output string) string {
+	sum := sha256.Sum256([]byte(output))
+	return hex.EncodeToString(sum[:8])
+}
+
+// formatContinuation encodes the offset of the next chunk of the output with the given hash as a token.
+func formatContinuation(offset int, hash string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.Itoa(offset) + ":" + hash))
+}
+
+// parseContinuation returns the offset a token created by formatContinuation points to, checking that it was
+// created for the output with the given hash.
+func parseContinuation(token, hash string) (int, error) {
+	decoded, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return 0, fmt.Errorf("invalid continuation token")
+	}
+	offsetText, tokenHash, ok := strings.Cut(string(decoded), ":")
+	offset, err := strconv.Atoi(offsetText)
+	if !ok || err != nil || offset < 0 {
+		return 0, fmt.Errorf("invalid continuation token")
+	}
+	if tokenHash != hash {
+		return 0, fmt.Errorf("continuation token is stale: the output changed since it was returned, call again without it")
+	}
+	return offset, nil
+}
+
+// chunkResult returns a tool result holding a chunk of output, followed by a note with the token of the next
+// chunk if there is one.
+func chunkResult(chunk, next string) *mcp.CallToolResult {
+	result := mcp.NewToolResultText(chunk)
+	if next != "" {
+		result.Content = append(result.Content, mcp.NewTextContent(fmt.Sprintf(
+			"Output truncated, call again with the same arguments and continuation %q for the next part", next)))
+	}
+	return result
+}
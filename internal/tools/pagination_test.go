@@ -0,0 +1,123 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vlad/ast2llm-go/internal/parser"
+)
+
+func TestPaginate(t *testing.T) {
+	output := "line one\nline two\nline three\n"
+
+	chunk, next, err := paginate(output, "", 0)
+	require.NoError(t, err)
+	assert.Equal(t, output, chunk)
+	assert.Empty(t, next)
+
+	chunk, next, err = paginate(output, "", 20)
+	require.NoError(t, err)
+	assert.Equal(t, "line one\nline two\n", chunk, "chunks end after a line break")
+	require.NotEmpty(t, next)
+
+	chunk, next, err = paginate(output, next, 20)
+	require.NoError(t, err)
+	assert.Equal(t, "line three\n", chunk)
+	assert.Empty(t, next)
+
+	t.Run("long lines", func(t *testing.T) {
+		var parts []string
+		token := ""
+		for {
+			chunk, next, err := paginate("héllo wörld", token, 2)
+			require.NoError(t, err)
+			parts = append(parts, chunk)
+			if next == "" {
+				break
+			}
+			token = next
+		}
+		assert.Equal(t, "héllo wörld", strings.Join(parts, ""))
+		assert.Equal(t, "h", parts[0], "chunks do not split characters")
+
+		chunk, _, err := paginate("éa", "", 1)
+		require.NoError(t, err)
+		assert.Equal(t, "é", chunk, "chunks hold at least one character")
+	})
+
+	t.Run("stale token", func(t *testing.T) {
+		_, next, err := paginate(output, "", 10)
+		require.NoError(t, err)
+		_, _, err = paginate(output+"line four\n", next, 10)
+		assert.ErrorContains(t, err, "stale")
+	})
+
+	t.Run("invalid token", func(t *testing.T) {
+		_, _, err := paginate(output, "not a token!", 10)
+		assert.ErrorContains(t, err, "invalid continuation token")
+		_, _, err = paginate(output, formatContinuation(1000, outputHash(output)), 10)
+		assert.ErrorContains(t, err, "invalid continuation token")
+	})
+}
+
+func TestParseGoToolHandler_Continuation(t *testing.T) {
+	handler := ParseGoToolHandler(parser.New(), nil)
+
+	projectPath := filepath.Join(t.TempDir(), "testproject_chunks")
+	require.NoError(t, os.MkdirAll(projectPath, 0755))
+	var source strings.Builder
+	source.WriteString("package main\n\nfunc main() {}\n")
+	for i := 0; i < 50; i++ {
+		source.WriteString(fmt.Sprintf("\n// Handler%d handles request %d.\nfunc Handler%d(id int) error { return nil }\n", i, i, i))
+	}
+	require.NoError(t, os.WriteFile(filepath.Join(projectPath, "main.go"), []byte(source.String()), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(projectPath, "go.mod"), []byte("module example.com/testproject_chunks\ngo 1.21\n"), 0644))
+
+	call := func(args map[string]any) *mcp.CallToolResult {
+		args["projectPath"] = projectPath
+		args["filePath"] = "main.go"
+		result, err := handler(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: args}})
+		require.NoError(t, err)
+		return result
+	}
+
+	result := call(map[string]any{})
+	require.False(t, result.IsError, result.Content)
+	require.Len(t, result.Content, 1, "small outputs are not split")
+	full := result.Content[0].(mcp.TextContent).Text
+
+	var joined strings.Builder
+	token := ""
+	for calls := 0; ; calls++ {
+		require.Less(t, calls, 100)
+		result := call(map[string]any{"chunkSize": 500, "continuation": token})
+		require.False(t, result.IsError, result.Content)
+		chunk := result.Content[0].(mcp.TextContent).Text
+		assert.LessOrEqual(t, len(chunk), 500)
+		joined.WriteString(chunk)
+		if len(result.Content) == 1 {
+			break
+		}
+		note := result.Content[1].(mcp.TextContent).Text
+		assert.Contains(t, note, "continuation")
+		token = note[strings.Index(note, `"`)+1 : strings.LastIndex(note, `"`)]
+	}
+	assert.Equal(t, full, joined.String())
+
+	result = call(map[string]any{"chunkSize": 500, "continuation": "bogus"})
+	assert.True(t, result.IsError)
+}
+
+func TestWithChunkingArguments(t *testing.T) {
+	for _, tool := range []mcp.Tool{NewParseGoTool(), NewParseSourceTool()} {
+		assert.Contains(t, tool.InputSchema.Properties, "chunkSize", tool.Name)
+		assert.Contains(t, tool.InputSchema.Properties, "continuation", tool.Name)
+	}
+}
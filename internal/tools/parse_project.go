@@ -0,0 +1,79 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/vlad/ast2llm-go/internal/composer"
+	"github.com/vlad/ast2llm-go/internal/config"
+	"github.com/vlad/ast2llm-go/internal/modinfo"
+	"github.com/vlad/ast2llm-go/internal/parser"
+	"github.com/vlad/ast2llm-go/internal/session"
+)
+
+// NewParseProjectTool returns the mcp.Tool for describing a whole project
+func NewParseProjectTool() mcp.Tool {
+	return mcp.NewTool("parse_project",
+		mcp.WithDescription("Describe a whole Go project in one document: its entry points, then every package with its doc comment, files, key types (the ones other packages use most first) and exported functions"),
+		mcp.WithString("projectPath",
+			mcp.Required(),
+			mcp.Description("Path to the Go project"),
+		),
+		mcp.WithNumber("packageTokenBudget",
+			mcp.Description("Maximum number of tokens of each package, dropping its least used types and its functions first (default: unbounded)"),
+		),
+		mcp.WithString("verbosity",
+			mcp.Description("Level of detail of the key types: signatures, comments, fields (default, unless the project's .ast2llm.yaml sets another) or bodies"),
+			mcp.Enum(composer.VerbosityNames()...),
+		),
+		withCompressArgument(),
+	)
+}
+
+// ParseProjectToolHandler returns a handler for the parse_project tool.
+func ParseProjectToolHandler(p parser.Parser, sessions *session.Manager) func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return compressible(func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		projectPath, err := request.RequireString("projectPath")
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		budget := request.GetInt("packageTokenBudget", 0)
+		if budget < 0 {
+			return mcp.NewToolResultError(fmt.Sprintf("packageTokenBudget must not be negative, got %d", budget)), nil
+		}
+
+		cfg, err := config.Load(projectPath)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		opts := cfg.ComposerOptions()
+		if name := request.GetString("verbosity", ""); name != "" {
+			verbosity, err := composer.ParseVerbosity(name)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			opts = append(opts, composer.WithVerbosity(verbosity))
+		}
+		if budget > 0 {
+			opts = append(opts, composer.WithPackageTokenBudget(budget))
+		}
+		if migrations, err := parser.FindMigrations(projectPath); err == nil && len(migrations) > 0 {
+			opts = append(opts, composer.WithMigrations(migrations))
+		}
+		if moduleInfo, err := modinfo.Load(projectPath); err == nil {
+			opts = append(opts, composer.WithModuleInfo(moduleInfo))
+		}
+
+		projectInfo, err := loadProjectInfo(ctx, request, p, sessions, projectPath)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("failed to parse project: %v", err)), nil
+		}
+
+		text, err := composer.New(projectInfo, opts...).ComposeAll()
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("failed to compose project info: %v", err)), nil
+		}
+		return mcp.NewToolResultText(text), nil
+	})
+}
@@ -0,0 +1,57 @@
+package tools
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vlad/ast2llm-go/internal/parser"
+)
+
+func TestNewParseProjectTool(t *testing.T) {
+	tool := NewParseProjectTool()
+	assert.Equal(t, "parse_project", tool.Name)
+	assert.ElementsMatch(t, []string{"projectPath"}, tool.InputSchema.Required)
+}
+
+func TestParseProjectToolHandler(t *testing.T) {
+	root := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(root, "go.mod"), []byte("module example.com/whole\ngo 1.21\n"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "main.go"), []byte(`package main
+
+// Config holds the settings.
+type Config struct {
+	Path string
+}
+
+func main() {}
+`), 0644))
+	handler := ParseProjectToolHandler(parser.New(), nil)
+
+	call := func(args map[string]any) *mcp.CallToolResult {
+		result, err := handler(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: args}})
+		require.NoError(t, err)
+		return result
+	}
+
+	result := call(map[string]any{"projectPath": root})
+	require.False(t, result.IsError, result.Content)
+	text := result.Content[0].(mcp.TextContent).Text
+	assert.Contains(t, text, "Module: example.com/whole\n")
+	assert.Contains(t, text, "Entry points:\n  - .: Main: func main in main.go\n")
+	assert.Contains(t, text, "Package: main (.)\n  Files: main.go\n  Key Types:\n")
+	assert.Contains(t, text, "Comment: Config holds the settings.")
+
+	result = call(map[string]any{"projectPath": root, "packageTokenBudget": -1})
+	assert.True(t, result.IsError)
+
+	result = call(map[string]any{"projectPath": root, "verbosity": "everything"})
+	assert.True(t, result.IsError)
+
+	result = call(map[string]any{})
+	assert.True(t, result.IsError)
+}
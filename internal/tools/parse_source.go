@@ -0,0 +1,123 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"maps"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/vlad/ast2llm-go/internal/composer"
+	"github.com/vlad/ast2llm-go/internal/config"
+	"github.com/vlad/ast2llm-go/internal/modinfo"
+	"github.com/vlad/ast2llm-go/internal/parser"
+	"github.com/vlad/ast2llm-go/internal/session"
+	ourtypes "github.com/vlad/ast2llm-go/internal/types"
+)
+
+// NewParseSourceTool returns the mcp.Tool for parsing Go source content
+func NewParseSourceTool() mcp.Tool {
+	return mcp.NewTool("parse_source",
+		mcp.WithDescription("Parse Go source content, such as an unsaved editor buffer, instead of a file on disk, and return the same information as parse_go"),
+		mcp.WithString("source",
+			mcp.Required(),
+			mcp.Description("Go source of the file"),
+		),
+		mcp.WithString("projectPath",
+			mcp.Description("Path to the Go project the file belongs to, whose packages resolve the items the source uses. Without it, the source is parsed on its own and only standard library imports are resolved"),
+		),
+		mcp.WithString("filePath",
+			mcp.Description("Path of the file, absolute or relative to the project; it does not need to exist (default source.go)"),
+		),
+		mcp.WithString("format",
+			mcp.Description("Output format: text (default) or json"),
+			mcp.Enum(formatText, formatJSON),
+		),
+		mcp.WithNumber("schemaVersion",
+			mcp.Description(fmt.Sprintf("Schema version of the json output, %d to %d (default %d)", ourtypes.MinSchemaVersion, ourtypes.SchemaVersion, ourtypes.SchemaVersion)),
+		),
+		mcp.WithString("verbosity",
+			mcp.Description("Level of detail of the text output: signatures, comments, fields (default, unless the project's .ast2llm.yaml sets another) or bodies"),
+			mcp.Enum(composer.VerbosityNames()...),
+		),
+		withChunkingArguments(),
+		withCompressArgument(),
+	)
+}
+
+// ParseSourceToolHandler returns a handler for the parse_source tool.
+// The source is always parsed anew; the rest of its project is served from its open session in sessions, if
+// any, and parsed otherwise. sessions may be nil.
+func ParseSourceToolHandler(p parser.Parser, sessions *session.Manager) func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return compressible(func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		source, err := request.RequireString("source")
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		projectPath := request.GetString("projectPath", "")
+		filePath := request.GetString("filePath", "")
+
+		format := request.GetString("format", formatText)
+		if format != formatText && format != formatJSON {
+			return mcp.NewToolResultError(fmt.Sprintf("unsupported format: %s", format)), nil
+		}
+
+		var opts []composer.Option
+		if projectPath != "" {
+			cfg, err := config.Load(projectPath)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			opts = cfg.ComposerOptions()
+			if moduleInfo, err := modinfo.Load(projectPath); err == nil {
+				opts = append(opts, composer.WithModuleInfo(moduleInfo))
+			}
+		}
+		if name := request.GetString("verbosity", ""); name != "" {
+			verbosity, err := composer.ParseVerbosity(name)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			opts = append(opts, composer.WithVerbosity(verbosity))
+		}
+		if format == formatJSON {
+			opts = append(opts, composer.WithSchemaVersion(request.GetInt("schemaVersion", ourtypes.SchemaVersion)))
+		}
+
+		sourceInfo, err := p.ParseSource(projectPath, filePath, []byte(source))
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("failed to parse source: %v", err)), nil
+		}
+		// The result only holds the parsed file
+		var fullFilePath string
+		for path := range sourceInfo {
+			fullFilePath = path
+		}
+		if fullFilePath == "" {
+			return mcp.NewToolResultError("the source could not be parsed as a Go file"), nil
+		}
+
+		// The other files of the project describe the project items the source uses
+		projectInfo := sourceInfo
+		if projectPath != "" {
+			diskInfo, err := loadProjectInfo(ctx, request, p, sessions, projectPath)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to parse project: %v", err)), nil
+			}
+			projectInfo = maps.Clone(diskInfo)
+			maps.Copy(projectInfo, sourceInfo)
+		}
+		projectComposer := composer.New(projectInfo, opts...)
+
+		var info string
+		if format == formatJSON {
+			info, err = projectComposer.ComposeJSON(fullFilePath)
+		} else {
+			info, err = projectComposer.Compose(fullFilePath)
+		}
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("failed to compose source info: %v", err)), nil
+		}
+
+		return chunkedResult(request, info), nil
+	})
+}
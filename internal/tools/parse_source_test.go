@@ -0,0 +1,69 @@
+package tools
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vlad/ast2llm-go/internal/parser"
+)
+
+func TestNewParseSourceTool(t *testing.T) {
+	tool := NewParseSourceTool()
+	assert.Equal(t, "parse_source", tool.Name)
+	assert.ElementsMatch(t, []string{"source"}, tool.InputSchema.Required)
+}
+
+func TestParseSourceToolHandler(t *testing.T) {
+	root := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(root, "go.mod"), []byte("module example.com/buffer\ngo 1.21\n"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "main.go"), []byte("package main\n\nfunc main() {}\n"), 0644))
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "config"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "config", "config.go"), []byte(`package config
+
+// Config holds the settings.
+type Config struct {
+	Path string
+}
+`), 0644))
+	handler := ParseSourceToolHandler(parser.New(), nil)
+
+	call := func(args map[string]any) *mcp.CallToolResult {
+		result, err := handler(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: args}})
+		require.NoError(t, err)
+		return result
+	}
+
+	source := `package main
+
+import "example.com/buffer/config"
+
+// load is not saved yet.
+func load() config.Config { return config.Config{} }
+
+func main() { load() }
+`
+	result := call(map[string]any{"projectPath": root, "filePath": "main.go", "source": source})
+	require.False(t, result.IsError, result.Content)
+	text := result.Content[0].(mcp.TextContent).Text
+	assert.Contains(t, text, "load is not saved yet.")
+	assert.Contains(t, text, "Path string", "types of other project packages are resolved")
+
+	result = call(map[string]any{"projectPath": root, "filePath": "main.go", "source": source, "format": "json"})
+	require.False(t, result.IsError, result.Content)
+	assert.Contains(t, result.Content[0].(mcp.TextContent).Text, `"name": "load"`)
+
+	result = call(map[string]any{"source": "package scratch\n\n// Scratch is a scratch buffer.\nfunc Scratch() {}\n"})
+	require.False(t, result.IsError, result.Content)
+	assert.Contains(t, result.Content[0].(mcp.TextContent).Text, "Scratch is a scratch buffer.")
+
+	result = call(map[string]any{"source": source, "format": "xml"})
+	assert.True(t, result.IsError)
+
+	result = call(map[string]any{"projectPath": root})
+	assert.True(t, result.IsError)
+}
@@ -0,0 +1,34 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/vlad/ast2llm-go/internal/parser"
+)
+
+// progressReporter returns a parser.ProgressFunc sending the progress of a parse as MCP progress
+// notifications, counting processed files, or nil if the client did not ask for progress.
+// Notifications are best effort: failing to send one does not fail the tool call.
+func progressReporter(ctx context.Context, request mcp.CallToolRequest) parser.ProgressFunc {
+	if request.Params.Meta == nil || request.Params.Meta.ProgressToken == nil {
+		return nil
+	}
+	srv := server.ServerFromContext(ctx)
+	if srv == nil {
+		return nil
+	}
+
+	token := request.Params.Meta.ProgressToken
+	return func(progress parser.Progress) {
+		_ = srv.SendNotificationToClient(ctx, "notifications/progress", map[string]any{
+			"progressToken": token,
+			"progress":      progress.FilesProcessed,
+			"total":         progress.TotalFiles,
+			"message": fmt.Sprintf("%d packages loaded, %d/%d files processed",
+				progress.PackagesLoaded, progress.FilesProcessed, progress.TotalFiles),
+		})
+	}
+}
@@ -0,0 +1,61 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vlad/ast2llm-go/internal/parser"
+)
+
+// notificationSession is an initialized client session collecting the notifications sent to it
+type notificationSession struct {
+	notifications chan mcp.JSONRPCNotification
+}
+
+func (s *notificationSession) Initialize()       {}
+func (s *notificationSession) Initialized() bool { return true }
+func (s *notificationSession) SessionID() string { return "test" }
+func (s *notificationSession) NotificationChannel() chan<- mcp.JSONRPCNotification {
+	return s.notifications
+}
+
+func TestParseGoToolHandler_Progress(t *testing.T) {
+	root := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(root, "go.mod"), []byte("module example.com/progress\ngo 1.21\n"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "main.go"), []byte("package main\n\nfunc main() {}\n"), 0644))
+
+	s := server.NewMCPServer("test", "1.0.0", server.WithToolCapabilities(false))
+	s.AddTool(NewParseGoTool(), ParseGoToolHandler(parser.New(), nil))
+	session := &notificationSession{notifications: make(chan mcp.JSONRPCNotification, 10)}
+	ctx := s.WithContext(context.Background(), session)
+
+	call := func(meta string) mcp.JSONRPCMessage {
+		args, err := json.Marshal(map[string]any{"projectPath": root, "filePath": "main.go"})
+		require.NoError(t, err)
+		return s.HandleMessage(ctx, json.RawMessage(`{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"parse_go","arguments":`+string(args)+meta+`}}`))
+	}
+
+	response := call(`,"_meta":{"progressToken":"parse-1"}`)
+	require.IsType(t, mcp.JSONRPCResponse{}, response)
+	close(session.notifications)
+
+	var params []map[string]any
+	for n := range session.notifications {
+		assert.Equal(t, "notifications/progress", n.Method)
+		params = append(params, n.Params.AdditionalFields)
+	}
+	require.Len(t, params, 2)
+	assert.Equal(t, map[string]any{"progressToken": "parse-1", "progress": 0, "total": 1, "message": "1 packages loaded, 0/1 files processed"}, params[0])
+	assert.Equal(t, map[string]any{"progressToken": "parse-1", "progress": 1, "total": 1, "message": "1 packages loaded, 1/1 files processed"}, params[1])
+
+	session.notifications = make(chan mcp.JSONRPCNotification, 10)
+	call("")
+	assert.Empty(t, session.notifications, "no progress without a progress token")
+}
@@ -0,0 +1,113 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/vlad/ast2llm-go/internal/composer"
+	"github.com/vlad/ast2llm-go/internal/embeddings"
+	"github.com/vlad/ast2llm-go/internal/parser"
+	"github.com/vlad/ast2llm-go/internal/session"
+)
+
+// NewRankContextTool returns the mcp.Tool for ranking the symbols of a project against a task
+func NewRankContextTool() mcp.Tool {
+	return mcp.NewTool("rank_context",
+		mcp.WithDescription("Return the functions, methods, types, variables and constants of a Go project most relevant to a natural-language task description, ranked by the similarity of the embeddings of the task and of their names, signatures and comments"),
+		mcp.WithString("projectPath",
+			mcp.Required(),
+			mcp.Description("Path to the Go project"),
+		),
+		mcp.WithString("task",
+			mcp.Required(),
+			mcp.Description("What needs to be done, e.g. \"retry failed uploads with exponential backoff\""),
+		),
+		mcp.WithNumber("limit",
+			mcp.Description(fmt.Sprintf("Maximum number of symbols to return (default %d)", defaultSearchLimit)),
+		),
+	)
+}
+
+// RankContextToolHandler returns a handler for the rank_context tool. The embeddings index of every project is
+// persisted in dir and only symbols that are new or changed since the last call are embedded with e.
+func RankContextToolHandler(p parser.Parser, sessions *session.Manager, e embeddings.Embedder, dir string) func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		projectPath, err := request.RequireString("projectPath")
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		task, err := request.RequireString("task")
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		if strings.TrimSpace(task) == "" {
+			return mcp.NewToolResultError("task must not be empty"), nil
+		}
+		limit := request.GetInt("limit", defaultSearchLimit)
+		if limit <= 0 {
+			return mcp.NewToolResultError(fmt.Sprintf("limit must be positive, got %d", limit)), nil
+		}
+
+		projectInfo, err := loadProjectInfo(ctx, request, p, sessions, projectPath)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("failed to parse project: %v", err)), nil
+		}
+
+		symbols := composer.New(projectInfo, composer.WithPositions()).Symbols()
+		if len(symbols) == 0 {
+			return mcp.NewToolResultText("No symbols found in the project\n"), nil
+		}
+		matches, err := rankSymbols(ctx, symbols, e, embeddings.IndexPath(dir, projectPath, e.Model()), task, limit)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("failed to rank symbols: %v", err)), nil
+		}
+		return mcp.NewToolResultText(composer.ComposeMatches(fmt.Sprintf("Symbols relevant to %q:", task), matches)), nil
+	}
+}
+
+// rankSymbols brings the index persisted at indexPath up to date with symbols and returns the limit symbols
+// most similar to task.
+func rankSymbols(ctx context.Context, symbols []*composer.Symbol, e embeddings.Embedder, indexPath, task string, limit int) ([]*composer.SymbolMatch, error) {
+	index, err := embeddings.Load(indexPath, e.Model())
+	if err != nil {
+		return nil, err
+	}
+
+	byID := make(map[string]*composer.Symbol, len(symbols))
+	docs := make([]embeddings.Document, 0, len(symbols))
+	for _, s := range symbols {
+		id := s.FilePath + "#" + s.Kind + " " + s.Name
+		byID[id] = s
+		docs = append(docs, embeddings.Document{ID: id, Text: symbolText(s)})
+	}
+	embedded, err := index.Update(ctx, e, docs)
+	if err != nil {
+		return nil, err
+	}
+	if embedded > 0 {
+		if err := index.Save(indexPath); err != nil {
+			return nil, err
+		}
+	}
+
+	results, err := index.Rank(ctx, e, task, limit)
+	if err != nil {
+		return nil, err
+	}
+	matches := make([]*composer.SymbolMatch, 0, len(results))
+	for _, r := range results {
+		matches = append(matches, &composer.SymbolMatch{Symbol: byID[r.ID], Score: r.Score})
+	}
+	return matches, nil
+}
+
+// symbolText is the text embedded for a symbol: its kind and name, signature and comment
+func symbolText(s *composer.Symbol) string {
+	text := s.Kind + " " + s.Name + "\n" + s.Signature
+	if s.Comment != "" {
+		text += "\n" + s.Comment
+	}
+	return text
+}
@@ -0,0 +1,62 @@
+package tools
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vlad/ast2llm-go/internal/embeddings"
+	"github.com/vlad/ast2llm-go/internal/parser"
+)
+
+func TestNewRankContextTool(t *testing.T) {
+	tool := NewRankContextTool()
+	assert.Equal(t, "rank_context", tool.Name)
+	assert.ElementsMatch(t, []string{"projectPath", "task"}, tool.InputSchema.Required)
+}
+
+func TestRankContextToolHandler(t *testing.T) {
+	root := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(root, "go.mod"), []byte("module example.com/rank\ngo 1.21\n"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "main.go"), []byte(`package main
+
+// RetryUpload uploads the file again after a failure.
+func RetryUpload(path string) error { return nil }
+
+// ParseConfig reads the settings file.
+func ParseConfig(path string) error { return nil }
+
+func main() {}
+`), 0644))
+	dir := t.TempDir()
+	e := embeddings.NewLocal(512)
+	handler := RankContextToolHandler(parser.New(), nil, e, dir)
+
+	call := func(args map[string]any) *mcp.CallToolResult {
+		result, err := handler(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: args}})
+		require.NoError(t, err)
+		return result
+	}
+
+	result := call(map[string]any{"projectPath": root, "task": "retry failed uploads", "limit": 1})
+	require.False(t, result.IsError, result.Content)
+	text := result.Content[0].(mcp.TextContent).Text
+	assert.Contains(t, text, "Symbols relevant to \"retry failed uploads\":\n\n1. main.RetryUpload (function, score ")
+	assert.Contains(t, text, "    Comment: RetryUpload uploads the file again after a failure.\n")
+	assert.NotContains(t, text, "ParseConfig")
+	assert.FileExists(t, embeddings.IndexPath(dir, root, e.Model()), "the index is persisted")
+
+	result = call(map[string]any{"projectPath": root, "task": "read settings"})
+	require.False(t, result.IsError, result.Content)
+	assert.Contains(t, result.Content[0].(mcp.TextContent).Text, "1. main.ParseConfig (function, score ")
+
+	result = call(map[string]any{"projectPath": root, "task": " "})
+	assert.True(t, result.IsError)
+
+	result = call(map[string]any{"projectPath": root, "task": "retry", "limit": -1})
+	assert.True(t, result.IsError)
+}
@@ -0,0 +1,143 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/vlad/ast2llm-go/internal/composer"
+	"github.com/vlad/ast2llm-go/internal/modinfo"
+	"github.com/vlad/ast2llm-go/internal/session"
+)
+
+// resourceURIPrefix starts the URI of a file resource, followed by the absolute file path,
+// e.g. ast2llm://project/home/me/app/main.go
+const resourceURIPrefix = "ast2llm://project"
+
+// ProjectResources publishes the composed context of the files of open project sessions as MCP resources.
+// Files of an open project can be read through the resource template even if they were created after
+// the project was opened and are therefore not listed.
+type ProjectResources struct {
+	server   *server.MCPServer
+	sessions *session.Manager
+
+	mu   sync.Mutex
+	uris map[string][]string // Key: session ID
+}
+
+// NewProjectResources registers the file resource template with s and returns a ProjectResources that
+// lists the files of the sessions passed to Add.
+func NewProjectResources(s *server.MCPServer, sessions *session.Manager) *ProjectResources {
+	r := &ProjectResources{
+		server:   s,
+		sessions: sessions,
+		uris:     make(map[string][]string),
+	}
+	s.AddResourceTemplate(
+		mcp.NewResourceTemplate(resourceURIPrefix+"/{+path}", "Go file context",
+			mcp.WithTemplateDescription("Composed context of a Go file of a project opened with open_project"),
+			mcp.WithTemplateMIMEType("text/plain"),
+		),
+		r.ReadResource,
+	)
+	return r
+}
+
+// Add lists every Go file of the session as a resource.
+func (r *ProjectResources) Add(sess *session.Session) error {
+	info, err := sess.ProjectInfo()
+	if err != nil {
+		return err
+	}
+	paths := make([]string, 0, len(info))
+	for path := range info {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.uris[sess.ID]; ok {
+		return nil // Already listed
+	}
+	uris := make([]string, 0, len(paths))
+	for _, path := range paths {
+		relPath, err := filepath.Rel(sess.Root, path)
+		if err != nil {
+			relPath = path
+		}
+		uri := resourceURI(path)
+		r.server.AddResource(
+			mcp.NewResource(uri, filepath.ToSlash(relPath),
+				mcp.WithResourceDescription(fmt.Sprintf("Composed context of %s", relPath)),
+				mcp.WithMIMEType("text/plain"),
+			),
+			r.ReadResource,
+		)
+		uris = append(uris, uri)
+	}
+	r.uris[sess.ID] = uris
+	return nil
+}
+
+// Remove stops listing the files of the session with the given ID.
+func (r *ProjectResources) Remove(id string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, uri := range r.uris[id] {
+		r.server.RemoveResource(uri)
+	}
+	delete(r.uris, id)
+}
+
+// ReadResource composes the context of the file a resource URI refers to.
+func (r *ProjectResources) ReadResource(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+	uri := request.Params.URI
+	filePath, ok := resourceFilePath(uri)
+	if !ok {
+		return nil, fmt.Errorf("invalid resource URI: %s", uri)
+	}
+	sess, ok := r.sessions.LookupFile(filePath)
+	if !ok {
+		return nil, fmt.Errorf("no open project contains %s, call open_project first", filePath)
+	}
+	info, err := sess.ProjectInfo()
+	if err != nil {
+		return nil, err
+	}
+
+	var opts []composer.Option
+	if moduleInfo, err := modinfo.Load(sess.Root); err == nil {
+		opts = append(opts, composer.WithModuleInfo(moduleInfo))
+	}
+	text, err := composer.New(info, opts...).Compose(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compose project info: %w", err)
+	}
+	return []mcp.ResourceContents{
+		mcp.TextResourceContents{URI: uri, MIMEType: "text/plain", Text: text},
+	}, nil
+}
+
+// resourceURI returns the resource URI of the file at the absolute path filePath, with slashes on every
+// platform, e.g. ast2llm://project/C:/Users/me/app/main.go on Windows.
+func resourceURI(filePath string) string {
+	return resourceURIPrefix + "/" + strings.TrimPrefix(filepath.ToSlash(filePath), "/")
+}
+
+// resourceFilePath returns the absolute file path a resource URI refers to.
+func resourceFilePath(uri string) (string, bool) {
+	path, ok := strings.CutPrefix(uri, resourceURIPrefix+"/")
+	if !ok || path == "" {
+		return "", false
+	}
+	if path = filepath.FromSlash(path); filepath.IsAbs(path) {
+		return path, true // Path with a volume name
+	}
+	return string(filepath.Separator) + path, true
+}
@@ -0,0 +1,106 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vlad/ast2llm-go/internal/parser"
+	"github.com/vlad/ast2llm-go/internal/session"
+)
+
+func TestProjectResources(t *testing.T) {
+	projectPath := writeGraphProject(t)
+	s := server.NewMCPServer("Test Server", "1.0.0")
+	sessions := session.NewManager(parser.New())
+	defer sessions.CloseAll()
+	resources := NewProjectResources(s, sessions)
+
+	// handle sends a JSON-RPC request to the server and returns the result
+	handle := func(method string, params any) map[string]any {
+		request, err := json.Marshal(map[string]any{"jsonrpc": "2.0", "id": 1, "method": method, "params": params})
+		require.NoError(t, err)
+		response := s.HandleMessage(context.Background(), request)
+		data, err := json.Marshal(response)
+		require.NoError(t, err)
+		var decoded map[string]any
+		require.NoError(t, json.Unmarshal(data, &decoded))
+		require.Nil(t, decoded["error"], string(data))
+		return decoded["result"].(map[string]any)
+	}
+	listURIs := func() []string {
+		var uris []string
+		for _, r := range handle("resources/list", map[string]any{})["resources"].([]any) {
+			uris = append(uris, r.(map[string]any)["uri"].(string))
+		}
+		return uris
+	}
+
+	assert.Empty(t, listURIs())
+
+	open := OpenProjectToolHandler(sessions, resources)
+	result, err := open(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]any{"projectPath": projectPath}}})
+	require.NoError(t, err)
+	require.False(t, result.IsError, result.Content)
+	id := result.Content[0].(mcp.TextContent).Text
+
+	mainURI := resourceURI(filepath.Join(projectPath, "main.go"))
+	assert.Len(t, listURIs(), 4)
+	assert.Contains(t, listURIs(), mainURI)
+
+	contents := handle("resources/read", map[string]any{"uri": mainURI})["contents"].([]any)
+	require.Len(t, contents, 1)
+	assert.Contains(t, contents[0].(map[string]any)["text"], "Function: main")
+
+	t.Run("file created after opening", func(t *testing.T) {
+		newFile := filepath.Join(projectPath, "extra.go")
+		require.NoError(t, os.WriteFile(newFile, []byte("package main\n\nfunc extra() {}\n"), 0644))
+		sess, ok := sessions.Get(id)
+		require.True(t, ok)
+		require.Eventually(t, func() bool {
+			info, err := sess.ProjectInfo()
+			return err == nil && info[newFile] != nil
+		}, 5*time.Second, 100*time.Millisecond)
+
+		contents, err := resources.ReadResource(context.Background(), mcp.ReadResourceRequest{Params: mcp.ReadResourceParams{URI: resourceURI(newFile)}})
+		require.NoError(t, err)
+		assert.Contains(t, contents[0].(mcp.TextResourceContents).Text, "Function: extra")
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		_, err := resources.ReadResource(context.Background(), mcp.ReadResourceRequest{Params: mcp.ReadResourceParams{URI: "file:///main.go"}})
+		assert.Error(t, err)
+		_, err = resources.ReadResource(context.Background(), mcp.ReadResourceRequest{Params: mcp.ReadResourceParams{URI: resourceURI(filepath.Join(t.TempDir(), "main.go"))}})
+		assert.Error(t, err)
+	})
+
+	closeProject := CloseProjectToolHandler(sessions, resources)
+	result, err = closeProject(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]any{"session": id}}})
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+	assert.Empty(t, listURIs())
+}
+
+func TestResourceURI(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "pkg", "main.go")
+	uri := resourceURI(path)
+	assert.Equal(t, "ast2llm://project/"+strings.TrimPrefix(filepath.ToSlash(path), "/"), uri)
+	assert.NotContains(t, uri, `\`)
+
+	got, ok := resourceFilePath(uri)
+	require.True(t, ok)
+	assert.Equal(t, path, got)
+
+	_, ok = resourceFilePath("ast2llm://project/")
+	assert.False(t, ok)
+	_, ok = resourceFilePath("file:///main.go")
+	assert.False(t, ok)
+}
@@ -0,0 +1,61 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/vlad/ast2llm-go/internal/composer"
+	"github.com/vlad/ast2llm-go/internal/parser"
+	"github.com/vlad/ast2llm-go/internal/session"
+)
+
+// defaultSearchLimit is the number of symbols search_symbols returns unless the request sets limit
+const defaultSearchLimit = 5
+
+// NewSearchSymbolsTool returns the mcp.Tool for searching the symbols of a project
+func NewSearchSymbolsTool() mcp.Tool {
+	return mcp.NewTool("search_symbols",
+		mcp.WithDescription("Find the functions, methods, types, variables and constants of a Go project relevant to a free-text query, ranked by fuzzy name match and comment keywords, and return the declarations of the best ones"),
+		mcp.WithString("projectPath",
+			mcp.Required(),
+			mcp.Description("Path to the Go project"),
+		),
+		mcp.WithString("query",
+			mcp.Required(),
+			mcp.Description("Words or identifiers to look for, e.g. \"parse config file\" or \"NewStore\""),
+		),
+		mcp.WithNumber("limit",
+			mcp.Description(fmt.Sprintf("Maximum number of symbols to return (default %d)", defaultSearchLimit)),
+		),
+	)
+}
+
+// SearchSymbolsToolHandler returns a handler for the search_symbols tool.
+func SearchSymbolsToolHandler(p parser.Parser, sessions *session.Manager) func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		projectPath, err := request.RequireString("projectPath")
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		query, err := request.RequireString("query")
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		if strings.TrimSpace(query) == "" {
+			return mcp.NewToolResultError("query must not be empty"), nil
+		}
+		limit := request.GetInt("limit", defaultSearchLimit)
+		if limit <= 0 {
+			return mcp.NewToolResultError(fmt.Sprintf("limit must be positive, got %d", limit)), nil
+		}
+
+		projectInfo, err := loadProjectInfo(ctx, request, p, sessions, projectPath)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("failed to parse project: %v", err)), nil
+		}
+
+		return mcp.NewToolResultText(composer.New(projectInfo, composer.WithPositions()).ComposeSearch(query, limit)), nil
+	}
+}
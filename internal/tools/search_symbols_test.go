@@ -0,0 +1,62 @@
+package tools
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vlad/ast2llm-go/internal/parser"
+)
+
+func TestNewSearchSymbolsTool(t *testing.T) {
+	tool := NewSearchSymbolsTool()
+	assert.Equal(t, "search_symbols", tool.Name)
+	assert.ElementsMatch(t, []string{"projectPath", "query"}, tool.InputSchema.Required)
+}
+
+func TestSearchSymbolsToolHandler(t *testing.T) {
+	root := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(root, "go.mod"), []byte("module example.com/search\ngo 1.21\n"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "main.go"), []byte(`package main
+
+// LoadConfig reads the settings file.
+func LoadConfig(path string) error { return nil }
+
+func main() {}
+`), 0644))
+	handler := SearchSymbolsToolHandler(parser.New(), nil)
+
+	call := func(args map[string]any) *mcp.CallToolResult {
+		result, err := handler(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: args}})
+		require.NoError(t, err)
+		return result
+	}
+
+	result := call(map[string]any{"projectPath": root, "query": "load settings"})
+	require.False(t, result.IsError, result.Content)
+	assert.Equal(t, `Symbols matching "load settings":
+
+1. main.LoadConfig (function, score 5) in `+filepath.Join(root, "main.go")+`
+  Function: LoadConfig
+    Position: `+filepath.Join(root, "main.go")+`:4:6
+    Comment: LoadConfig reads the settings file.
+    Signature: (path string) -> (error)
+`, result.Content[0].(mcp.TextContent).Text)
+
+	result = call(map[string]any{"projectPath": root, "query": "kafka", "limit": 3})
+	require.False(t, result.IsError, result.Content)
+	assert.Equal(t, "No symbols match \"kafka\"\n", result.Content[0].(mcp.TextContent).Text)
+
+	result = call(map[string]any{"projectPath": root, "query": " "})
+	assert.True(t, result.IsError)
+
+	result = call(map[string]any{"projectPath": root, "query": "load", "limit": 0})
+	assert.True(t, result.IsError)
+
+	result = call(map[string]any{"query": "load"})
+	assert.True(t, result.IsError)
+}
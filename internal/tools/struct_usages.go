@@ -0,0 +1,70 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/vlad/ast2llm-go/internal/parser"
+	ourtypes "github.com/vlad/ast2llm-go/internal/types"
+)
+
+// NewStructUsagesTool returns the mcp.Tool for finding where a struct is constructed and mutated
+func NewStructUsagesTool() mcp.Tool {
+	return mcp.NewTool("find_struct_usages",
+		mcp.WithDescription("List where a struct of a Go project is constructed with a composite literal and where its fields are written"),
+		mcp.WithString("projectPath",
+			mcp.Required(),
+			mcp.Description("Path to the Go project"),
+		),
+		mcp.WithString("structName",
+			mcp.Required(),
+			mcp.Description("Struct to look up, e.g. User, models.User or its ID example.com/app/models.User"),
+		),
+	)
+}
+
+// StructUsagesToolHandler returns a handler for the find_struct_usages tool
+func StructUsagesToolHandler(p parser.Parser) func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		projectPath, err := request.RequireString("projectPath")
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		structName, err := request.RequireString("structName")
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		name, usages, err := p.FindStructUsages(projectPath, structName)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("failed to find struct usages: %v", err)), nil
+		}
+		return mcp.NewToolResultText(formatStructUsages(name, usages)), nil
+	}
+}
+
+// formatStructUsages renders the construction sites and the field writes of a struct as two lists
+func formatStructUsages(name string, usages []*ourtypes.StructUsage) string {
+	var constructions, writes strings.Builder
+	for _, u := range usages {
+		location := u.Pos.String()
+		if u.Function != "" {
+			location += " in " + u.Function
+		}
+		switch u.Kind {
+		case ourtypes.UsageConstruct:
+			constructions.WriteString(fmt.Sprintf("  - %s\n", location))
+		case ourtypes.UsageWrite:
+			writes.WriteString(fmt.Sprintf("  - %s at %s\n", u.Field, location))
+		}
+	}
+	if constructions.Len() == 0 {
+		constructions.WriteString("  (none)\n")
+	}
+	if writes.Len() == 0 {
+		writes.WriteString("  (none)\n")
+	}
+	return fmt.Sprintf("Struct: %s\nConstructed:\n%sField writes:\n%s", name, constructions.String(), writes.String())
+}
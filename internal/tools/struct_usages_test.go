@@ -0,0 +1,60 @@
+package tools
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vlad/ast2llm-go/internal/parser"
+	ourtypes "github.com/vlad/ast2llm-go/internal/types"
+)
+
+func TestNewStructUsagesTool(t *testing.T) {
+	tool := NewStructUsagesTool()
+	assert.Equal(t, "find_struct_usages", tool.Name)
+	assert.ElementsMatch(t, []string{"projectPath", "structName"}, tool.InputSchema.Required)
+}
+
+func TestStructUsagesToolHandler(t *testing.T) {
+	root := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(root, "go.mod"), []byte("module example.com/usages\ngo 1.21\n"), 0644))
+	mainPath := filepath.Join(root, "main.go")
+	require.NoError(t, os.WriteFile(mainPath, []byte(`package main
+
+type Config struct {
+	Port int
+}
+
+func main() {
+	c := Config{}
+	c.Port = 8080
+}
+`), 0644))
+	handler := StructUsagesToolHandler(parser.New())
+
+	call := func(args map[string]any) *mcp.CallToolResult {
+		result, err := handler(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: args}})
+		require.NoError(t, err)
+		return result
+	}
+
+	result := call(map[string]any{"projectPath": root, "structName": "Config"})
+	require.False(t, result.IsError, result.Content)
+	assert.Equal(t, "Struct: example.com/usages.Config\n"+
+		"Constructed:\n  - "+mainPath+":8:7 in main\n"+
+		"Field writes:\n  - Port at "+mainPath+":9:4 in main\n", result.Content[0].(mcp.TextContent).Text)
+
+	result = call(map[string]any{"projectPath": root, "structName": "Missing"})
+	assert.True(t, result.IsError)
+	result = call(map[string]any{"projectPath": root})
+	assert.True(t, result.IsError)
+}
+
+func TestFormatStructUsages_None(t *testing.T) {
+	assert.Equal(t, "Struct: example.com/app.Empty\nConstructed:\n  (none)\nField writes:\n  (none)\n",
+		formatStructUsages("example.com/app.Empty", []*ourtypes.StructUsage{}))
+}
@@ -7,9 +7,39 @@ import (
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
 	"github.com/vlad/ast2llm-go/internal/composer"
+	"github.com/vlad/ast2llm-go/internal/config"
+	"github.com/vlad/ast2llm-go/internal/embeddings"
+	"github.com/vlad/ast2llm-go/internal/modinfo"
 	"github.com/vlad/ast2llm-go/internal/parser"
+	"github.com/vlad/ast2llm-go/internal/session"
+	ourtypes "github.com/vlad/ast2llm-go/internal/types"
 )
 
+// Output formats supported by the parse_go tool
+const (
+	formatText = "text"
+	formatJSON = "json"
+)
+
+// Values of the deadExports argument of the parse_go tool
+const (
+	deadExportsList = "list"
+	deadExportsDrop = "drop"
+)
+
+// sectionToggles maps the boolean arguments of the parse_go tool selecting the sections of its output to
+// the composer sections they keep, all of them by default
+var sectionToggles = []struct {
+	argument string
+	section  composer.Section
+}{
+	{"includeImports", composer.SectionImports},
+	{"includeFunctions", composer.SectionFunctions},
+	{"includeGlobals", composer.SectionGlobals},
+	{"includeInterfaces", composer.SectionInterfaces},
+	{"includeUsedImports", composer.SectionUsedImports},
+}
+
 // NewParseGoTool returns the mcp.Tool for parsing Go code
 func NewParseGoTool() mcp.Tool {
 	return mcp.NewTool("parse_go",
@@ -20,43 +50,314 @@ func NewParseGoTool() mcp.Tool {
 		),
 		mcp.WithString("filePath",
 			mcp.Required(),
-			mcp.Description("Path to the current file"),
+			mcp.Description("Path to the current file: absolute, relative to the project, or its end such as the file name if only one file matches"),
+		),
+		mcp.WithString("format",
+			mcp.Description("Output format: text (default) or json"),
+			mcp.Enum(formatText, formatJSON),
+		),
+		mcp.WithNumber("schemaVersion",
+			mcp.Description(fmt.Sprintf("Schema version of the json output, %d to %d (default %d). Version 2 lists imports as paths, version 1 also names item fields like Go fields, e.g. ReturnTypes", ourtypes.MinSchemaVersion, ourtypes.SchemaVersion, ourtypes.SchemaVersion)),
+		),
+		mcp.WithBoolean("positions",
+			mcp.Description("Include the file:line:column position of every declaration"),
+		),
+		mcp.WithBoolean("minify",
+			mcp.Description("Compact the text output: drop comments, list struct fields on one line and abbreviate package paths"),
+		),
+		mcp.WithBoolean("inlineFields",
+			mcp.Description("List the fields of the project struct a struct field holds under the field, e.g. the fields of User under Owner *models.User"),
+		),
+		mcp.WithBoolean("moduleAlias",
+			mcp.Description("Replace the module path with ~ in the text output, e.g. ~/internal/sub.Type"),
+		),
+		mcp.WithString("verbosity",
+			mcp.Description("Level of detail of the text output: signatures, comments, fields (default, unless the project's .ast2llm.yaml sets another) or bodies. Function sources are only available if the server runs with --function-bodies"),
+			mcp.Enum(composer.VerbosityNames()...),
 		),
+		mcp.WithString("focusSymbol",
+			mcp.Description("Type or function to focus on, e.g. Config, or its ID from the json output to tell apart symbols of the same name, e.g. example.com/app/config.Config; it and the items it refers to are listed first"),
+		),
+		mcp.WithNumber("maxFields",
+			mcp.Description("Maximum number of fields listed per struct in the text output, the rest being counted as (+N more fields); 0 for no limit (default, unless the project's .ast2llm.yaml sets one)"),
+		),
+		mcp.WithNumber("maxMethods",
+			mcp.Description("Maximum number of methods listed per struct or interface in the text output, the rest being counted as (+N more methods); 0 for no limit (default, unless the project's .ast2llm.yaml sets one)"),
+		),
+		mcp.WithString("expandSymbol",
+			mcp.Description("Type to list with all its fields and methods despite maxFields and maxMethods, by name or ID like focusSymbol; use it to get the full definition of a type shown with (+N more ...)"),
+		),
+		mcp.WithString("deadExports",
+			mcp.Description("Exports of the file nothing in the project refers to: list them in an Unused Exports section, or drop also leaves their declarations out of the text output"),
+			mcp.Enum(deadExportsList, deadExportsDrop),
+		),
+		mcp.WithBoolean("includeImports",
+			mcp.Description("Include the imports of the file and the modules providing them (default true)"),
+		),
+		mcp.WithBoolean("includeFunctions",
+			mcp.Description("Include the functions and methods of the file (default true)"),
+		),
+		mcp.WithBoolean("includeGlobals",
+			mcp.Description("Include the global variables, constants and enums of the file (default true)"),
+		),
+		mcp.WithBoolean("includeInterfaces",
+			mcp.Description("Include the interfaces of the file (default true)"),
+		),
+		mcp.WithBoolean("includeUsedImports",
+			mcp.Description("Include the types, functions and variables the file uses from other packages (default true)"),
+		),
+		mcp.WithBoolean("syntaxOnly",
+			mcp.Description("Only parse the project's files, without type-checking them against the packages they import: much faster on large projects and enough to list imports or declarations, but types from other packages are only known by name and type errors are not reported"),
+		),
+		withChunkingArguments(),
+		withKnownHashArgument(),
+		withCompressArgument(),
 	)
 }
 
-// ParseGoToolHandler returns a handler for the parse_go tool
-func ParseGoToolHandler(p *parser.ProjectParser) func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+// NewOpenProjectTool returns the mcp.Tool for opening a project session
+func NewOpenProjectTool() mcp.Tool {
+	return mcp.NewTool("open_project",
+		mcp.WithDescription("Parse a Go project once and keep it up to date while its files change, so later parse_go calls for it are fast. Its files are also listed as ast2llm://project/<file path> resources"),
+		mcp.WithString("projectPath",
+			mcp.Required(),
+			mcp.Description("Path to the Go project"),
+		),
+	)
+}
+
+// NewCloseProjectTool returns the mcp.Tool for closing a project session
+func NewCloseProjectTool() mcp.Tool {
+	return mcp.NewTool("close_project",
+		mcp.WithDescription("Close a project session opened with open_project"),
+		mcp.WithString("session",
+			mcp.Required(),
+			mcp.Description("Session handle returned by open_project"),
+		),
+	)
+}
+
+// OpenProjectToolHandler returns a handler for the open_project tool.
+// The files of opened projects are listed in resources; resources may be nil.
+func OpenProjectToolHandler(sessions *session.Manager, resources *ProjectResources) func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		projectPath, err := request.RequireString("projectPath")
 		if err != nil {
 			return mcp.NewToolResultError(err.Error()), nil
 		}
 
+		s, err := sessions.Open(projectPath)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("failed to open project: %v", err)), nil
+		}
+		if resources != nil {
+			if err := resources.Add(s); err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to list project files: %v", err)), nil
+			}
+		}
+
+		return mcp.NewToolResultText(s.ID), nil
+	}
+}
+
+// CloseProjectToolHandler returns a handler for the close_project tool.
+// The files of closed projects are removed from resources; resources may be nil.
+func CloseProjectToolHandler(sessions *session.Manager, resources *ProjectResources) func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		id, err := request.RequireString("session")
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		if err := sessions.Close(id); err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		if resources != nil {
+			resources.Remove(id)
+		}
+
+		return mcp.NewToolResultText(fmt.Sprintf("closed %s", id)), nil
+	}
+}
+
+// ParseGoToolHandler returns a handler for the parse_go tool.
+// Projects with an open session in sessions are served from the session instead of being parsed again;
+// sessions may be nil. Parsing reports its progress if the request carries a progress token.
+func ParseGoToolHandler(p parser.Parser, sessions *session.Manager) func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return compressible(func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		projectPath, err := request.RequireString("projectPath")
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
 		filePath, err := request.RequireString("filePath")
 		if err != nil {
 			return mcp.NewToolResultError(err.Error()), nil
 		}
 
-		projectInfo, err := p.ParseProject(projectPath)
+		format := request.GetString("format", formatText)
+		if format != formatText && format != formatJSON {
+			return mcp.NewToolResultError(fmt.Sprintf("unsupported format: %s", format)), nil
+		}
+
+		// The project's .ast2llm.yaml provides the default verbosity and budget
+		cfg, err := config.Load(projectPath)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		opts := cfg.ComposerOptions()
+		if name := request.GetString("verbosity", ""); name != "" {
+			verbosity, err := composer.ParseVerbosity(name)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			opts = append(opts, composer.WithVerbosity(verbosity))
+		}
+
+		var projectInfo parser.ProjectInfo
+		if _, ok := lookupSession(sessions, projectPath); !ok && request.GetBool("syntaxOnly", false) {
+			projectInfo, err = p.ParseProjectSyntax(projectPath)
+		} else {
+			projectInfo, err = loadProjectInfo(ctx, request, p, sessions, projectPath)
+		}
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("failed to parse project: %v", err)), nil
 		}
 
-		fullFilePath := fmt.Sprintf("%s/%s", projectPath, filePath)
-		projectComposer := composer.New(projectInfo)
+		fullFilePath, err := resolveProjectFile(projectInfo, projectPath, filePath)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		if request.GetBool("positions", false) {
+			opts = append(opts, composer.WithPositions())
+		}
+		if request.GetBool("minify", false) {
+			opts = append(opts, composer.WithMinify())
+		}
+		if request.GetBool("inlineFields", false) {
+			opts = append(opts, composer.WithInlineFieldStructs())
+		}
+		for _, toggle := range sectionToggles {
+			if !request.GetBool(toggle.argument, true) {
+				opts = append(opts, composer.WithoutSections(toggle.section))
+			}
+		}
+		if focusSymbol := request.GetString("focusSymbol", ""); focusSymbol != "" {
+			opts = append(opts, composer.WithFocusSymbol(focusSymbol))
+		}
+		// Limits given by the call, 0 included, override the ones of .ast2llm.yaml
+		if maxFields := request.GetInt("maxFields", -1); maxFields >= 0 {
+			opts = append(opts, composer.WithMaxFields(maxFields))
+		}
+		if maxMethods := request.GetInt("maxMethods", -1); maxMethods >= 0 {
+			opts = append(opts, composer.WithMaxMethods(maxMethods))
+		}
+		if expandSymbol := request.GetString("expandSymbol", ""); expandSymbol != "" {
+			opts = append(opts, composer.WithExpandSymbol(expandSymbol))
+		}
+		if format == formatJSON {
+			opts = append(opts, composer.WithSchemaVersion(request.GetInt("schemaVersion", ourtypes.SchemaVersion)))
+		}
+		switch deadExports := request.GetString("deadExports", ""); deadExports {
+		case "":
+		case deadExportsList, deadExportsDrop:
+			exports, err := p.FindDeadExports(projectPath)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to find dead exports: %v", err)), nil
+			}
+			opts = append(opts, composer.WithDeadExports(exports))
+			if deadExports == deadExportsDrop {
+				opts = append(opts, composer.WithDropDeadExports())
+			}
+		default:
+			return mcp.NewToolResultError(fmt.Sprintf("unsupported deadExports: %s", deadExports)), nil
+		}
+		if migrations, err := parser.FindMigrations(projectPath); err == nil && len(migrations) > 0 {
+			opts = append(opts, composer.WithMigrations(migrations))
+		}
+		if moduleInfo, err := modinfo.Load(projectPath); err == nil {
+			opts = append(opts, composer.WithModuleInfo(moduleInfo))
+			if request.GetBool("moduleAlias", false) && moduleInfo.Path != "" {
+				opts = append(opts, composer.WithModuleAlias(moduleInfo.Path))
+			}
+		}
+		projectComposer := composer.New(projectInfo, opts...)
 
-		info, err := projectComposer.Compose(fullFilePath)
+		var info string
+		if format == formatJSON {
+			info, err = projectComposer.ComposeJSON(fullFilePath)
+		} else {
+			info, err = projectComposer.Compose(fullFilePath)
+		}
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("failed to compose project info: %v", err)), nil
 		}
 
-		return mcp.NewToolResultText(info), nil
+		return knownHashResult(request, info, func() *mcp.CallToolResult {
+			return chunkedResult(request, info)
+		}), nil
+	})
+}
+
+// loadProjectInfo returns the project at projectPath from its open session in sessions, if any, and parses it
+// with p otherwise, reporting the progress to the client if request carries a progress token. sessions may be
+// nil.
+func loadProjectInfo(ctx context.Context, request mcp.CallToolRequest, p parser.Parser, sessions *session.Manager, projectPath string) (parser.ProjectInfo, error) {
+	if s, ok := lookupSession(sessions, projectPath); ok {
+		return s.ProjectInfo()
+	}
+	return p.ParseProjectWithProgress(projectPath, progressReporter(ctx, request))
+}
+
+// lookupSession returns the open session of the project, if any
+func lookupSession(sessions *session.Manager, projectPath string) (*session.Session, bool) {
+	if sessions == nil {
+		return nil, false
+	}
+	return sessions.Lookup(projectPath)
+}
+
+// Option configures the tools registered by RegisterTools
+type Option func(*toolsConfig)
+
+// toolsConfig holds the settings of optional tools
+type toolsConfig struct {
+	embedder      embeddings.Embedder // Embeds symbols for rank_context, nil leaves the tool out
+	embeddingsDir string              // Directory the embeddings indexes are persisted in
+}
+
+// WithEmbeddings registers the rank_context tool, embedding symbols with e and persisting the index of every
+// project in dir
+func WithEmbeddings(e embeddings.Embedder, dir string) Option {
+	return func(c *toolsConfig) {
+		c.embedder = e
+		c.embeddingsDir = dir
 	}
 }
 
 // RegisterTools registers all tools with the MCP server
-func RegisterTools(s *server.MCPServer, p *parser.ProjectParser) error {
-	s.AddTool(NewParseGoTool(), ParseGoToolHandler(p))
+func RegisterTools(s *server.MCPServer, p parser.Parser, opts ...Option) error {
+	var cfg toolsConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	sessions := session.NewManager(p)
+	resources := NewProjectResources(s, sessions)
+	s.AddTool(NewParseGoTool(), ParseGoToolHandler(p, sessions))
+	s.AddTool(NewParseProjectTool(), ParseProjectToolHandler(p, sessions))
+	s.AddTool(NewParseSourceTool(), ParseSourceToolHandler(p, sessions))
+	s.AddTool(NewOpenProjectTool(), OpenProjectToolHandler(sessions, resources))
+	s.AddTool(NewCloseProjectTool(), CloseProjectToolHandler(sessions, resources))
+	s.AddTool(NewDependencyGraphTool(), DependencyGraphToolHandler(p))
+	s.AddTool(NewStructUsagesTool(), StructUsagesToolHandler(p))
+	s.AddTool(NewDeadExportsTool(), DeadExportsToolHandler(p))
+	s.AddTool(NewCompareAPITool(), CompareAPIToolHandler(p))
+	s.AddTool(NewInterfaceImplTool(), InterfaceImplToolHandler(p))
+	s.AddTool(NewTypeMethodsTool(), TypeMethodsToolHandler(p))
+	s.AddTool(NewSearchSymbolsTool(), SearchSymbolsToolHandler(p, sessions))
+	s.AddTool(NewListRoutesTool(), ListRoutesToolHandler(p, sessions))
+	if cfg.embedder != nil {
+		s.AddTool(NewRankContextTool(), RankContextToolHandler(p, sessions, cfg.embedder, cfg.embeddingsDir))
+	}
 	return nil
 }
@@ -3,18 +3,20 @@ package tools
 import (
 	"context"
 	"encoding/json"
-	"fmt"
 	"os"
-	"os/exec"
 	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/vlad/ast2llm-go/internal/parser"
-	// Alias ourtypes
+	"github.com/vlad/ast2llm-go/internal/session"
+	"github.com/vlad/ast2llm-go/internal/testutil"
+	ourtypes "github.com/vlad/ast2llm-go/internal/types" // Alias ourtypes
 )
 
 func TestNewParseGoTool(t *testing.T) {
@@ -31,20 +33,27 @@ func TestNewParseGoTool(t *testing.T) {
 	assert.Contains(t, js, "filePath")
 	assert.Contains(t, js, "Path to the Go project")
 	assert.Contains(t, js, "Path to the current file")
+	assert.Contains(t, js, "format")
+	assert.Contains(t, js, "positions")
+	assert.Contains(t, js, "focusSymbol")
+	assert.Contains(t, js, "maxFields")
+	assert.Contains(t, js, "expandSymbol")
+	assert.Contains(t, js, "minify")
+	assert.Contains(t, js, "inlineFields")
+	assert.Contains(t, js, "moduleAlias")
+	assert.Contains(t, js, "verbosity")
+	assert.Contains(t, js, "includeImports")
+	assert.Contains(t, js, "includeUsedImports")
+	assert.Contains(t, js, "compress")
 	assert.NotContains(t, js, "Raw Go code")
 }
 
 func TestParseGoToolHandler(t *testing.T) {
 	p := parser.New()
-	handler := ParseGoToolHandler(p)
+	handler := ParseGoToolHandler(p, nil)
 
 	// Create a dummy project for testing
-	tmpDir := t.TempDir()
-	projectPath := filepath.Join(tmpDir, "testproject")
-	err := os.MkdirAll(projectPath, 0755)
-	require.NoError(t, err)
-
-	err = os.WriteFile(filepath.Join(projectPath, "main.go"), []byte(`package main
+	projectPath := testutil.WriteModule(t, "example.com/testproject_tools", map[string]string{"main.go": `package main
 
 import "fmt"
 
@@ -55,18 +64,7 @@ func main(){
 	fmt.Println("Hello")
 	_ = MyStruct{}
 }
-`), 0644)
-	require.NoError(t, err)
-	err = os.WriteFile(filepath.Join(projectPath, "go.mod"), []byte(fmt.Sprintf("module %s\ngo 1.21\n", "example.com/testproject_tools")), 0644)
-	require.NoError(t, err)
-
-	// Run go mod tidy to ensure go.mod is valid
-	cmd := exec.Command("go", "mod", "tidy")
-	cmd.Dir = projectPath
-	cmd.Stderr = os.Stderr
-	cmd.Stdout = os.Stdout
-	err = cmd.Run()
-	require.NoError(t, err, "go mod tidy failed in test setup")
+`})
 
 	tests := []struct {
 		name        string
@@ -82,6 +80,16 @@ func main(){
 			},
 			wantErr: false,
 		},
+		{
+			name: "unsupported format",
+			args: map[string]any{
+				"projectPath": projectPath,
+				"filePath":    "main.go",
+				"format":      "xml",
+			},
+			wantErr:     true,
+			errContains: "unsupported format: xml",
+		},
 		{
 			name:        "missing filePath",
 			args:        map[string]any{},
@@ -131,6 +139,361 @@ func main(){
 	}
 }
 
+func TestParseGoToolHandler_JSON(t *testing.T) {
+	p := parser.New()
+	handler := ParseGoToolHandler(p, nil)
+
+	tmpDir := t.TempDir()
+	projectPath := filepath.Join(tmpDir, "testproject_json")
+	require.NoError(t, os.MkdirAll(projectPath, 0755))
+	err := os.WriteFile(filepath.Join(projectPath, "main.go"), []byte("package main\n\n// MyStruct is a simple struct\ntype MyStruct struct{ ID int }\n\nfunc main() {}\n"), 0644)
+	require.NoError(t, err)
+	err = os.WriteFile(filepath.Join(projectPath, "go.mod"), []byte("module example.com/testproject_json\ngo 1.21\n"), 0644)
+	require.NoError(t, err)
+
+	request := mcp.CallToolRequest{
+		Params: mcp.CallToolParams{
+			Arguments: map[string]any{
+				"projectPath": projectPath,
+				"filePath":    "main.go",
+				"format":      "json",
+			},
+		},
+	}
+
+	result, err := handler(context.Background(), request)
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	var composed map[string]any
+	require.NoError(t, json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &composed))
+	assert.Equal(t, "main", composed["package"])
+	assert.Equal(t, filepath.Join(projectPath, "main.go"), composed["file_path"])
+	assert.Len(t, composed["structs"], 1)
+	assert.Equal(t, "MyStruct is a simple struct", composed["structs"].([]any)[0].(map[string]any)["comment"])
+
+	// Clients pinned to the first schema get the Go field names of items
+	request.Params.Arguments.(map[string]any)["schemaVersion"] = 1
+	result, err = handler(context.Background(), request)
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+	require.NoError(t, json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &composed))
+	assert.EqualValues(t, 1, composed["schema_version"])
+	assert.Equal(t, "MyStruct is a simple struct", composed["structs"].([]any)[0].(map[string]any)["Comment"])
+
+	request.Params.Arguments.(map[string]any)["schemaVersion"] = 5
+	result, err = handler(context.Background(), request)
+	require.NoError(t, err)
+	assert.True(t, result.IsError)
+}
+
+// stubParser serves a fixed ProjectInfo, standing in for ProjectParser
+type stubParser struct {
+	parser.Parser
+	info parser.ProjectInfo
+}
+
+func (s *stubParser) ParseProject(string) (parser.ProjectInfo, error) { return s.info, nil }
+
+func (s *stubParser) ParseProjectWithProgress(string, parser.ProgressFunc) (parser.ProjectInfo, error) {
+	return s.info, nil
+}
+
+func TestParseGoToolHandler_CustomParser(t *testing.T) {
+	root := filepath.Join(t.TempDir(), "virtual")
+	mainPath := filepath.Join(root, "cmd", "main.go")
+	p := &stubParser{info: parser.ProjectInfo{
+		mainPath: {PackageName: "virtual", Functions: []*ourtypes.FunctionInfo{{Name: "Run"}}},
+	}}
+	handler := ParseGoToolHandler(p, nil)
+
+	for _, filePath := range []string{"cmd/main.go", "./cmd/../cmd/main.go", mainPath, filepath.ToSlash(mainPath)} {
+		result, err := handler(context.Background(), mcp.CallToolRequest{
+			Params: mcp.CallToolParams{Arguments: map[string]any{"projectPath": root, "filePath": filePath}},
+		})
+		require.NoError(t, err)
+		require.False(t, result.IsError, result.Content)
+		text := result.Content[0].(mcp.TextContent).Text
+		assert.Contains(t, text, "--- File: "+mainPath+" ---", "file path %s", filePath)
+		assert.Contains(t, text, "Package: virtual")
+		assert.Contains(t, text, "Function: Run")
+	}
+}
+
+func TestParseGoToolHandler_Positions(t *testing.T) {
+	handler := ParseGoToolHandler(parser.New(), nil)
+
+	projectPath := filepath.Join(t.TempDir(), "testproject_positions")
+	require.NoError(t, os.MkdirAll(projectPath, 0755))
+	mainPath := filepath.Join(projectPath, "main.go")
+	require.NoError(t, os.WriteFile(mainPath, []byte("package main\n\nfunc main() {}\n"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(projectPath, "go.mod"), []byte("module example.com/testproject_positions\ngo 1.21\n"), 0644))
+
+	request := mcp.CallToolRequest{
+		Params: mcp.CallToolParams{
+			Arguments: map[string]any{
+				"projectPath": projectPath,
+				"filePath":    "main.go",
+				"positions":   true,
+			},
+		},
+	}
+
+	result, err := handler(context.Background(), request)
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+	assert.Contains(t, result.Content[0].(mcp.TextContent).Text, "Position: "+mainPath+":3:6\n")
+}
+
+func TestParseGoToolHandler_FocusSymbol(t *testing.T) {
+	handler := ParseGoToolHandler(parser.New(), nil)
+
+	projectPath := filepath.Join(t.TempDir(), "testproject_focus")
+	require.NoError(t, os.MkdirAll(projectPath, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(projectPath, "main.go"), []byte("package main\n\ntype Config struct{ Name string }\n\ntype Unrelated struct{}\n\nfunc main() {}\n\nfunc load(c Config) error { return nil }\n"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(projectPath, "go.mod"), []byte("module example.com/testproject_focus\ngo 1.21\n"), 0644))
+
+	request := mcp.CallToolRequest{
+		Params: mcp.CallToolParams{
+			Arguments: map[string]any{
+				"projectPath": projectPath,
+				"filePath":    "main.go",
+				"focusSymbol": "load",
+			},
+		},
+	}
+
+	result, err := handler(context.Background(), request)
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+	text := result.Content[0].(mcp.TextContent).Text
+	focusStart := strings.Index(text, "Focus:\n  Function: load\n")
+	require.GreaterOrEqual(t, focusStart, 0, text)
+	assert.Less(t, focusStart, strings.Index(text, "Struct: example.com/testproject_focus.Config"))
+	assert.Less(t, strings.Index(text, "Struct: example.com/testproject_focus.Config"), strings.Index(text, "Functions:\n"))
+	assert.Greater(t, strings.Index(text, "Unrelated"), strings.Index(text, "Functions:\n"))
+}
+
+func TestParseGoToolHandler_Minify(t *testing.T) {
+	handler := ParseGoToolHandler(parser.New(), nil)
+
+	projectPath := filepath.Join(t.TempDir(), "testproject_minify")
+	require.NoError(t, os.MkdirAll(projectPath, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(projectPath, "main.go"), []byte("package main\n\n// Config holds the settings.\ntype Config struct {\n\tName string\n\tPort int\n}\n\nfunc main() {}\n"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(projectPath, "go.mod"), []byte("module example.com/testproject_minify\ngo 1.21\n"), 0644))
+
+	request := mcp.CallToolRequest{
+		Params: mcp.CallToolParams{
+			Arguments: map[string]any{
+				"projectPath": projectPath,
+				"filePath":    "main.go",
+				"minify":      true,
+			},
+		},
+	}
+
+	result, err := handler(context.Background(), request)
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+	text := result.Content[0].(mcp.TextContent).Text
+	assert.NotContains(t, text, "Config holds the settings.")
+	assert.Contains(t, text, "Package aliases: testproject_minify = example.com/testproject_minify\n")
+	assert.Contains(t, text, "Struct: testproject_minify.Config\n    Fields: Name string; Port int\n")
+
+	request.Params.Arguments = map[string]any{
+		"projectPath": projectPath,
+		"filePath":    "main.go",
+		"moduleAlias": true,
+	}
+	result, err = handler(context.Background(), request)
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+	text = result.Content[0].(mcp.TextContent).Text
+	assert.Contains(t, text, "Module alias: ~ = example.com/testproject_minify\n")
+	assert.Contains(t, text, "Struct: ~.Config\n")
+}
+
+func TestParseGoToolHandler_DeadExports(t *testing.T) {
+	handler := ParseGoToolHandler(parser.New(), nil)
+
+	projectPath := filepath.Join(t.TempDir(), "testproject_dead")
+	require.NoError(t, os.MkdirAll(projectPath, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(projectPath, "main.go"), []byte("package main\n\n// Config is unused.\ntype Config struct{ Name string }\n\nfunc main() {}\n"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(projectPath, "go.mod"), []byte("module example.com/testproject_dead\ngo 1.21\n"), 0644))
+
+	call := func(deadExports string) *mcp.CallToolResult {
+		request := mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]any{
+			"projectPath": projectPath,
+			"filePath":    "main.go",
+			"deadExports": deadExports,
+		}}}
+		result, err := handler(context.Background(), request)
+		require.NoError(t, err)
+		return result
+	}
+
+	result := call("list")
+	require.False(t, result.IsError, result.Content)
+	text := result.Content[0].(mcp.TextContent).Text
+	assert.Contains(t, text, "Unused Exports:\n- 4: type Config\n")
+	assert.Contains(t, text, "Struct: example.com/testproject_dead.Config")
+
+	result = call("drop")
+	require.False(t, result.IsError, result.Content)
+	text = result.Content[0].(mcp.TextContent).Text
+	assert.Contains(t, text, "Unused Exports:\n- 4: type Config\n")
+	assert.NotContains(t, text, "Struct:")
+
+	result = call("hide")
+	assert.True(t, result.IsError)
+}
+
+func TestParseGoToolHandler_SectionToggles(t *testing.T) {
+	handler := ParseGoToolHandler(parser.New(), nil)
+
+	projectPath := filepath.Join(t.TempDir(), "testproject_sections")
+	require.NoError(t, os.MkdirAll(projectPath, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(projectPath, "main.go"), []byte("package main\n\nimport \"fmt\"\n\nvar greeting = \"hi\"\n\ntype Runner interface{ Run() }\n\nfunc main() { fmt.Println(greeting) }\n"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(projectPath, "go.mod"), []byte("module example.com/testproject_sections\ngo 1.21\n"), 0644))
+
+	call := func(args map[string]any) string {
+		args["projectPath"] = projectPath
+		args["filePath"] = "main.go"
+		result, err := handler(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: args}})
+		require.NoError(t, err)
+		require.False(t, result.IsError, result.Content)
+		return result.Content[0].(mcp.TextContent).Text
+	}
+
+	text := call(map[string]any{})
+	for _, title := range []string{"Imports:", "Functions:", "Global Variables/Constants:", "Local Interfaces:"} {
+		assert.Contains(t, text, title)
+	}
+
+	text = call(map[string]any{"includeImports": false, "includeGlobals": false, "includeInterfaces": true})
+	assert.NotContains(t, text, "Imports:")
+	assert.NotContains(t, text, "Global Variables/Constants:")
+	assert.Contains(t, text, "Local Interfaces:")
+	assert.Contains(t, text, "Functions:")
+
+	text = call(map[string]any{"includeFunctions": false, "includeInterfaces": false, "format": "json"})
+	assert.Contains(t, text, `"functions": []`)
+	assert.Contains(t, text, `"interfaces": []`)
+	assert.Contains(t, text, `"imports": [`)
+}
+
+func TestParseGoToolHandler_SyntaxOnly(t *testing.T) {
+	handler := ParseGoToolHandler(parser.New(), nil)
+
+	projectPath := filepath.Join(t.TempDir(), "testproject_syntax")
+	require.NoError(t, os.MkdirAll(projectPath, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(projectPath, "main.go"), []byte("package main\n\nimport \"example.com/missing/dep\"\n\ntype Config struct{ Store dep.Store }\n\nfunc main() {}\n"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(projectPath, "go.mod"), []byte("module example.com/testproject_syntax\ngo 1.21\n"), 0644))
+
+	request := mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]any{
+		"projectPath": projectPath,
+		"filePath":    "main.go",
+		"syntaxOnly":  true,
+	}}}
+	result, err := handler(context.Background(), request)
+	require.NoError(t, err)
+	require.False(t, result.IsError, result.Content)
+	text := result.Content[0].(mcp.TextContent).Text
+	assert.Contains(t, text, "syntax-only parse")
+	assert.Contains(t, text, "Store example.com/missing/dep.Store")
+	assert.NotContains(t, text, "could not import")
+}
+
+func TestParseGoToolHandler_Verbosity(t *testing.T) {
+	handler := ParseGoToolHandler(parser.New(parser.WithFunctionBodies()), nil)
+
+	projectPath := filepath.Join(t.TempDir(), "testproject_verbosity")
+	require.NoError(t, os.MkdirAll(projectPath, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(projectPath, "main.go"), []byte("package main\n\n// Config holds the settings.\ntype Config struct{ Port int }\n\nfunc main() {}\n"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(projectPath, "go.mod"), []byte("module example.com/testproject_verbosity\ngo 1.21\n"), 0644))
+
+	call := func(verbosity string) *mcp.CallToolResult {
+		result, err := handler(context.Background(), mcp.CallToolRequest{
+			Params: mcp.CallToolParams{
+				Arguments: map[string]any{
+					"projectPath": projectPath,
+					"filePath":    "main.go",
+					"verbosity":   verbosity,
+				},
+			},
+		})
+		require.NoError(t, err)
+		return result
+	}
+
+	signatures := call("signatures")
+	require.False(t, signatures.IsError)
+	assert.NotContains(t, signatures.Content[0].(mcp.TextContent).Text, "Comment:")
+	assert.NotContains(t, signatures.Content[0].(mcp.TextContent).Text, "Fields:")
+
+	bodies := call("bodies")
+	require.False(t, bodies.IsError)
+	assert.Contains(t, bodies.Content[0].(mcp.TextContent).Text, "Fields:")
+	assert.Contains(t, bodies.Content[0].(mcp.TextContent).Text, "Source:")
+
+	assert.True(t, call("everything").IsError)
+}
+
+func TestParseGoToolHandler_ProjectConfig(t *testing.T) {
+	handler := ParseGoToolHandler(parser.New(), nil)
+
+	projectPath := filepath.Join(t.TempDir(), "testproject_config")
+	require.NoError(t, os.MkdirAll(projectPath, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(projectPath, "main.go"), []byte("package main\n\n// Config holds the settings.\ntype Config struct{ Port int }\n\nfunc main() {}\n"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(projectPath, "go.mod"), []byte("module example.com/testproject_config\ngo 1.21\n"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(projectPath, ".ast2llm.yaml"), []byte("verbosity: signatures\n"), 0644))
+
+	call := func(args map[string]any) string {
+		args["projectPath"], args["filePath"] = projectPath, "main.go"
+		result, err := handler(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: args}})
+		require.NoError(t, err)
+		require.False(t, result.IsError, result.Content)
+		return result.Content[0].(mcp.TextContent).Text
+	}
+
+	assert.NotContains(t, call(map[string]any{}), "Comment:", "the project default applies")
+	assert.Contains(t, call(map[string]any{"verbosity": "fields"}), "Comment: Config holds the settings.", "the request overrides it")
+
+	require.NoError(t, os.WriteFile(filepath.Join(projectPath, ".ast2llm.yaml"), []byte("verbosity: everything\n"), 0644))
+	result, err := handler(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]any{"projectPath": projectPath, "filePath": "main.go"}}})
+	require.NoError(t, err)
+	assert.True(t, result.IsError)
+}
+
+func TestParseGoToolHandler_MemberLimits(t *testing.T) {
+	handler := ParseGoToolHandler(parser.New(), nil)
+
+	projectPath := filepath.Join(t.TempDir(), "testproject_limits")
+	require.NoError(t, os.MkdirAll(projectPath, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(projectPath, "main.go"), []byte("package main\n\ntype Config struct{ A, B, C, D int }\n\ntype Other struct{ X, Y, Z int }\n\nfunc main() {}\n"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(projectPath, "go.mod"), []byte("module example.com/testproject_limits\ngo 1.21\n"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(projectPath, ".ast2llm.yaml"), []byte("max_fields: 1\n"), 0644))
+
+	call := func(args map[string]any) string {
+		args["projectPath"], args["filePath"] = projectPath, "main.go"
+		result, err := handler(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: args}})
+		require.NoError(t, err)
+		require.False(t, result.IsError, result.Content)
+		return result.Content[0].(mcp.TextContent).Text
+	}
+
+	text := call(map[string]any{})
+	assert.Contains(t, text, "      - A int\n      (+3 more fields)\n", "the project default applies")
+	assert.Contains(t, text, "      - X int\n      (+2 more fields)\n")
+
+	text = call(map[string]any{"maxFields": 2, "expandSymbol": "Config"})
+	assert.Contains(t, text, "      - D int\n", "the expand symbol is listed in full")
+	assert.NotContains(t, text, "(+3 more fields)")
+	assert.Contains(t, text, "      - Y int\n      (+1 more field)\n")
+
+	assert.NotContains(t, call(map[string]any{"maxFields": 0}), "more fields", "0 lifts the limit")
+}
+
 func TestRegisterTools(t *testing.T) {
 	p := parser.New()
 	s := server.NewMCPServer("Test Server", "1.0.0")
@@ -139,26 +502,11 @@ func TestRegisterTools(t *testing.T) {
 	require.NoError(t, err)
 
 	// Проверяем, что инструмент зарегистрирован
-	handler := ParseGoToolHandler(p)
+	handler := ParseGoToolHandler(p, nil)
 	require.NotNil(t, handler)
 
 	// Create a dummy project for testing the handler
-	tmpDir := t.TempDir()
-	projectPath := filepath.Join(tmpDir, "testproject_reg")
-	err = os.MkdirAll(projectPath, 0755)
-	require.NoError(t, err)
-
-	err = os.WriteFile(filepath.Join(projectPath, "main.go"), []byte("package main\nfunc init(){}\n"), 0644)
-	require.NoError(t, err)
-	err = os.WriteFile(filepath.Join(projectPath, "go.mod"), []byte(fmt.Sprintf("module %s\ngo 1.21\n", "example.com/testproject_reg")), 0644)
-	require.NoError(t, err)
-
-	cmd := exec.Command("go", "mod", "tidy")
-	cmd.Dir = projectPath
-	cmd.Stderr = os.Stderr
-	cmd.Stdout = os.Stdout
-	err = cmd.Run()
-	require.NoError(t, err, "go mod tidy failed in test setup for registration")
+	projectPath := testutil.WriteModule(t, "example.com/testproject_reg", map[string]string{"main.go": "package main\nfunc init(){}\n"})
 
 	// Тестируем обработчик с базовым запросом
 	request := mcp.CallToolRequest{
@@ -180,3 +528,58 @@ func TestRegisterTools(t *testing.T) {
 	assert.NotContains(t, composedOutput, "Local Structs:\n  Struct:")
 	assert.NotContains(t, composedOutput, "Used Imported Structs (from this project, if available):\n")
 }
+
+func TestProjectSessionTools(t *testing.T) {
+	p := parser.New()
+	sessions := session.NewManager(p)
+	defer sessions.CloseAll()
+
+	projectPath := filepath.Join(t.TempDir(), "testproject_session")
+	require.NoError(t, os.MkdirAll(projectPath, 0755))
+	mainPath := filepath.Join(projectPath, "main.go")
+	require.NoError(t, os.WriteFile(mainPath, []byte("package main\n\nfunc main() {}\n"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(projectPath, "go.mod"), []byte("module example.com/testproject_session\ngo 1.21\n"), 0644))
+
+	open := OpenProjectToolHandler(sessions, nil)
+	result, err := open(context.Background(), mcp.CallToolRequest{
+		Params: mcp.CallToolParams{Arguments: map[string]any{"projectPath": projectPath}},
+	})
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+	id := result.Content[0].(mcp.TextContent).Text
+	assert.NotEmpty(t, id)
+
+	parseGo := ParseGoToolHandler(p, sessions)
+	request := mcp.CallToolRequest{
+		Params: mcp.CallToolParams{
+			Arguments: map[string]any{
+				"projectPath": projectPath,
+				"filePath":    "main.go",
+			},
+		},
+	}
+	result, err = parseGo(context.Background(), request)
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+	assert.Contains(t, result.Content[0].(mcp.TextContent).Text, "Function: main")
+
+	// The session picks up changes without reopening the project.
+	require.NoError(t, os.WriteFile(mainPath, []byte("package main\n\nfunc main() {}\n\nfunc helper() {}\n"), 0644))
+	require.Eventually(t, func() bool {
+		result, err := parseGo(context.Background(), request)
+		return err == nil && !result.IsError && strings.Contains(result.Content[0].(mcp.TextContent).Text, "Function: helper")
+	}, 5*time.Second, 50*time.Millisecond)
+
+	closeProject := CloseProjectToolHandler(sessions, nil)
+	result, err = closeProject(context.Background(), mcp.CallToolRequest{
+		Params: mcp.CallToolParams{Arguments: map[string]any{"session": id}},
+	})
+	require.NoError(t, err)
+	assert.False(t, result.IsError)
+
+	result, err = closeProject(context.Background(), mcp.CallToolRequest{
+		Params: mcp.CallToolParams{Arguments: map[string]any{"session": id}},
+	})
+	require.NoError(t, err)
+	assert.True(t, result.IsError)
+}
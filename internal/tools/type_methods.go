@@ -0,0 +1,61 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/vlad/ast2llm-go/internal/composer"
+	"github.com/vlad/ast2llm-go/internal/parser"
+	ourtypes "github.com/vlad/ast2llm-go/internal/types"
+)
+
+// NewTypeMethodsTool returns the mcp.Tool for listing the methods of a type
+func NewTypeMethodsTool() mcp.Tool {
+	return mcp.NewTool("list_methods_of_type",
+		mcp.WithDescription("List every method declared on a Go type, whichever file of its package declares it, with signatures, comments and positions"),
+		mcp.WithString("projectPath",
+			mcp.Required(),
+			mcp.Description("Path to the Go project"),
+		),
+		mcp.WithString("typeName",
+			mcp.Required(),
+			mcp.Description("Type declared in the project, e.g. Memory, store.Memory or its ID example.com/app/store.Memory"),
+		),
+	)
+}
+
+// TypeMethodsToolHandler returns a handler for the list_methods_of_type tool
+func TypeMethodsToolHandler(p parser.Parser) func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		projectPath, err := request.RequireString("projectPath")
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		typeName, err := request.RequireString("typeName")
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		name, methods, err := p.ListMethods(projectPath, typeName)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("failed to list methods: %v", err)), nil
+		}
+		return mcp.NewToolResultText(formatTypeMethods(name, methods)), nil
+	}
+}
+
+// formatTypeMethods renders the methods of a type like parse_go does, with their positions
+func formatTypeMethods(name string, methods []*ourtypes.FunctionInfo) string {
+	var builder strings.Builder
+	builder.WriteString(fmt.Sprintf("Type: %s\nMethods:\n", name))
+	if len(methods) == 0 {
+		builder.WriteString("  (none)\n")
+	}
+	c := composer.New(nil, composer.WithPositions())
+	for _, m := range methods {
+		c.FormatFunction(&builder, m, "  ")
+	}
+	return builder.String()
+}
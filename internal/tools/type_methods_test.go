@@ -0,0 +1,69 @@
+package tools
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vlad/ast2llm-go/internal/parser"
+)
+
+func TestNewTypeMethodsTool(t *testing.T) {
+	tool := NewTypeMethodsTool()
+	assert.Equal(t, "list_methods_of_type", tool.Name)
+	assert.ElementsMatch(t, []string{"projectPath", "typeName"}, tool.InputSchema.Required)
+}
+
+func TestTypeMethodsToolHandler(t *testing.T) {
+	root := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(root, "go.mod"), []byte("module example.com/methods\ngo 1.21\n"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "main.go"), []byte(`package main
+
+type File struct{}
+
+// Close releases the file.
+func (f *File) Close() error { return nil }
+
+type Empty struct{}
+
+func main() {}
+`), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "read.go"), []byte(`package main
+
+func (f File) Read(p []byte) int { return 0 }
+`), 0644))
+	handler := TypeMethodsToolHandler(parser.New())
+
+	call := func(args map[string]any) *mcp.CallToolResult {
+		result, err := handler(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: args}})
+		require.NoError(t, err)
+		return result
+	}
+
+	result := call(map[string]any{"projectPath": root, "typeName": "File"})
+	require.False(t, result.IsError, result.Content)
+	assert.Equal(t, `Type: example.com/methods.File
+Methods:
+  Method: (*File) Close
+    Position: `+filepath.Join(root, "main.go")+`:6:16
+    Comment: Close releases the file.
+    Signature: () -> (error)
+  Method: (File) Read
+    Position: `+filepath.Join(root, "read.go")+`:3:15
+    Signature: (p []byte) -> (int)
+`, result.Content[0].(mcp.TextContent).Text)
+
+	result = call(map[string]any{"projectPath": root, "typeName": "Empty"})
+	require.False(t, result.IsError, result.Content)
+	assert.Equal(t, "Type: example.com/methods.Empty\nMethods:\n  (none)\n", result.Content[0].(mcp.TextContent).Text)
+
+	result = call(map[string]any{"projectPath": root, "typeName": "Missing"})
+	assert.True(t, result.IsError)
+
+	result = call(map[string]any{"projectPath": root})
+	assert.True(t, result.IsError)
+}
@@ -0,0 +1,151 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/vlad/ast2llm-go/internal/parser"
+)
+
+// warningsLogger names the logger of the logging notifications sent by WarningNotifier
+const warningsLogger = "parser"
+
+// skippedDelay is how long skipped files are collected before being sent as a single notification
+const skippedDelay = 200 * time.Millisecond
+
+// warningLevels maps the severities of parse warnings to MCP logging levels
+var warningLevels = map[parser.Severity]mcp.LoggingLevel{
+	parser.SeverityInfo:    mcp.LoggingLevelInfo,
+	parser.SeverityWarning: mcp.LoggingLevelWarning,
+	parser.SeverityError:   mcp.LoggingLevelError,
+}
+
+// loggingLevels lists the MCP logging levels from the least to the most severe
+var loggingLevels = []mcp.LoggingLevel{
+	mcp.LoggingLevelDebug, mcp.LoggingLevelInfo, mcp.LoggingLevelNotice, mcp.LoggingLevelWarning,
+	mcp.LoggingLevelError, mcp.LoggingLevelCritical, mcp.LoggingLevelAlert, mcp.LoggingLevelEmergency,
+}
+
+// loggingRank returns the position of level in loggingLevels, 0 for an unknown level.
+func loggingRank(level mcp.LoggingLevel) int {
+	for i, l := range loggingLevels {
+		if l == level {
+			return i
+		}
+	}
+	return 0
+}
+
+// WarningNotifier forwards parse warnings to the clients of an MCP server as logging notifications, as they
+// are found. Each client session only gets the warnings at or above the level it set with logging/setLevel.
+// Skipped files are collected for a moment and sent as one notification, a project can skip many of them.
+//
+// The parser must be created with parser.WithWarnings(n.Notify), and the server, created later, with
+// server.WithLogging and server.WithHooks of hooks passed to AddHooks. Warnings found before Attach, or
+// without a server, are logged like the parser does.
+type WarningNotifier struct {
+	mu       sync.Mutex
+	srv      *server.MCPServer
+	sessions map[string]server.SessionWithLogging
+	skipped  []parser.Warning
+	flush    *time.Timer
+}
+
+// Attach sends the following warnings to the clients of s.
+func (n *WarningNotifier) Attach(s *server.MCPServer) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.srv = s
+}
+
+// AddHooks keeps track of the client sessions of the server created with hooks, so that warnings are sent to
+// each of them according to its logging level. Sessions that do not support logging get no warnings.
+func (n *WarningNotifier) AddHooks(hooks *server.Hooks) {
+	hooks.AddOnRegisterSession(func(_ context.Context, session server.ClientSession) {
+		if logging, ok := session.(server.SessionWithLogging); ok {
+			n.mu.Lock()
+			defer n.mu.Unlock()
+			if n.sessions == nil {
+				n.sessions = make(map[string]server.SessionWithLogging)
+			}
+			n.sessions[session.SessionID()] = logging
+		}
+	})
+	hooks.AddOnUnregisterSession(func(_ context.Context, session server.ClientSession) {
+		n.mu.Lock()
+		defer n.mu.Unlock()
+		delete(n.sessions, session.SessionID())
+	})
+}
+
+// Notify is the parser.WarningFunc of the notifier.
+func (n *WarningNotifier) Notify(w parser.Warning) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if n.srv == nil {
+		if w.Severity > parser.SeverityInfo {
+			log.Print(w)
+		}
+		return
+	}
+
+	if w.Kind == parser.WarningSkipped {
+		n.skipped = append(n.skipped, w)
+		if n.flush == nil {
+			n.flush = time.AfterFunc(skippedDelay, n.flushSkipped)
+		}
+		return
+	}
+
+	data := map[string]any{"kind": w.Kind, "message": w.Message}
+	if w.Package != "" {
+		data["package"] = w.Package
+	}
+	if w.Pos != "" {
+		data["pos"] = w.Pos
+	}
+	n.send(warningLevels[w.Severity], data)
+}
+
+// flushSkipped sends the skipped files collected since the last flush as one notification.
+func (n *WarningNotifier) flushSkipped() {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	skipped := n.skipped
+	n.skipped, n.flush = nil, nil
+	if len(skipped) == 0 {
+		return
+	}
+
+	files := make([]map[string]any, 0, len(skipped))
+	for _, w := range skipped {
+		files = append(files, map[string]any{"pos": w.Pos, "message": w.Message})
+	}
+	message := "1 file skipped"
+	if len(files) > 1 {
+		message = fmt.Sprintf("%d files skipped", len(files))
+	}
+	n.send(warningLevels[parser.SeverityInfo], map[string]any{"kind": parser.WarningSkipped, "message": message, "files": files})
+}
+
+// send sends a logging notification with data to every session whose logging level is at most level.
+// The caller holds n.mu.
+func (n *WarningNotifier) send(level mcp.LoggingLevel, data map[string]any) {
+	params := map[string]any{
+		"level":  level,
+		"logger": warningsLogger,
+		"data":   data,
+	}
+	for id, session := range n.sessions {
+		if loggingRank(level) < loggingRank(session.GetLogLevel()) {
+			continue
+		}
+		// A blocked or closed session only misses the warning
+		_ = n.srv.SendNotificationToSpecificClient(id, "notifications/message", params)
+	}
+}
@@ -0,0 +1,86 @@
+package tools
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vlad/ast2llm-go/internal/parser"
+)
+
+// loggingSession is a client session that set its logging level
+type loggingSession struct {
+	notificationSession
+	id    string
+	level mcp.LoggingLevel
+}
+
+func (s *loggingSession) SessionID() string                  { return s.id }
+func (s *loggingSession) SetLogLevel(level mcp.LoggingLevel) { s.level = level }
+func (s *loggingSession) GetLogLevel() mcp.LoggingLevel      { return s.level }
+
+func TestWarningNotifier(t *testing.T) {
+	root := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(root, "go.mod"), []byte("module example.com/warnings\ngo 1.21\n"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "main.go"), []byte("package main\n\nimport \"example.com/missing\"\n\nfunc main() { missing.Run() }\n"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "main_gen.go"), []byte("package main\n"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "util_gen.go"), []byte("package main\n"), 0644))
+
+	notifier := &WarningNotifier{}
+	p := parser.New(parser.WithWarnings(notifier.Notify), parser.WithExcludeGlobs("*_gen.go"))
+	hooks := &server.Hooks{}
+	notifier.AddHooks(hooks)
+	s := server.NewMCPServer("test", "1.0.0", server.WithLogging(), server.WithHooks(hooks))
+	notifier.Attach(s)
+
+	newSession := func(id string, level mcp.LoggingLevel) *loggingSession {
+		session := &loggingSession{notificationSession: notificationSession{notifications: make(chan mcp.JSONRPCNotification, 10)}, id: id, level: level}
+		require.NoError(t, s.RegisterSession(context.Background(), session))
+		return session
+	}
+	verbose := newSession("verbose", mcp.LoggingLevelInfo)
+	quiet := newSession("quiet", mcp.LoggingLevelError)
+	unregistered := newSession("unregistered", mcp.LoggingLevelDebug)
+	s.UnregisterSession(context.Background(), "unregistered")
+
+	_, err := p.ParseProject(root)
+	require.NoError(t, err)
+	var params []map[string]any
+	for len(params) < 2 {
+		select {
+		case n := <-verbose.notifications:
+			assert.Equal(t, "notifications/message", n.Method)
+			params = append(params, n.Params.AdditionalFields)
+		case <-time.After(5 * time.Second):
+			t.Fatalf("got %d notifications, want 2", len(params))
+		}
+	}
+	time.Sleep(2 * skippedDelay)
+	assert.Empty(t, verbose.notifications, "the skipped files are sent together")
+	require.Len(t, params, 2)
+	assert.Equal(t, mcp.LoggingLevelWarning, params[0]["level"])
+	assert.Equal(t, "parser", params[0]["logger"])
+	data := params[0]["data"].(map[string]any)
+	assert.Equal(t, parser.WarningImport, data["kind"])
+	assert.Equal(t, "example.com/warnings", data["package"])
+	assert.Equal(t, filepath.Join(root, "main.go")+":3:8", data["pos"])
+	assert.Contains(t, data["message"], "could not import example.com/missing")
+
+	assert.Equal(t, mcp.LoggingLevelInfo, params[1]["level"])
+	data = params[1]["data"].(map[string]any)
+	assert.Equal(t, parser.WarningSkipped, data["kind"])
+	assert.Equal(t, "2 files skipped", data["message"])
+	assert.ElementsMatch(t, []map[string]any{
+		{"pos": filepath.Join(root, "main_gen.go"), "message": "excluded by pattern"},
+		{"pos": filepath.Join(root, "util_gen.go"), "message": "excluded by pattern"},
+	}, data["files"])
+
+	assert.Empty(t, quiet.notifications, "below the level of the session")
+	assert.Empty(t, unregistered.notifications)
+}
@@ -0,0 +1,121 @@
+package types
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// SchemaVersion is the version of the JSON serialization of the types of this package. It is incremented
+// whenever a field is renamed, removed or changes type; new optional fields keep the version.
+//
+// Version 3 describes imports as objects with their alias, see ImportInfo. Version 2 listed their paths.
+// Version 2 names fields in snake_case and leaves out empty optional fields. Version 1 named fields after
+// their Go fields, e.g. "ReturnTypes", and always included them.
+const SchemaVersion = 3
+
+// MinSchemaVersion is the oldest schema version ForSchemaVersion can produce
+const MinSchemaVersion = 1
+
+// ForSchemaVersion returns the value to pass to a JSON encoder to serialize v, or any value holding the types
+// of this package, in the given schema version. Structs of other packages keep their json tags.
+func ForSchemaVersion(v any, version int) (any, error) {
+	switch version {
+	case SchemaVersion:
+		return v, nil
+	case 1, 2:
+		return olderValue(reflect.ValueOf(v), version), nil
+	}
+	return nil, fmt.Errorf("unsupported schema version %d, supported versions are %d to %d", version, MinSchemaVersion, SchemaVersion)
+}
+
+// typesPkgPath is the path of this package, whose structs are serialized with Go field names in version 1
+var typesPkgPath = reflect.TypeOf(Position{}).PkgPath()
+
+// importInfoType is the type of the imports, serialized as their paths before version 3
+var importInfoType = reflect.TypeOf(ImportInfo{})
+
+// anyType is the type of the fields of the structs built by olderStruct
+var anyType = reflect.TypeOf((*any)(nil)).Elem()
+
+// olderValue converts v to the shape of an older schema version.
+func olderValue(v reflect.Value, version int) any {
+	switch v.Kind() {
+	case reflect.Invalid:
+		return nil
+	case reflect.Pointer, reflect.Interface:
+		if v.IsNil() {
+			return nil
+		}
+		return olderValue(v.Elem(), version)
+	case reflect.Slice:
+		if v.IsNil() {
+			return nil
+		}
+		items := make([]any, v.Len())
+		for i := range items {
+			items[i] = olderValue(v.Index(i), version)
+		}
+		return items
+	case reflect.Map:
+		if v.IsNil() {
+			return nil
+		}
+		entries := make(map[string]any, v.Len())
+		for iter := v.MapRange(); iter.Next(); {
+			entries[fmt.Sprint(iter.Key().Interface())] = olderValue(iter.Value(), version)
+		}
+		return entries
+	case reflect.Struct:
+		if v.Type() == importInfoType {
+			return v.Interface().(ImportInfo).Path
+		}
+		return olderStruct(v, version)
+	}
+	return v.Interface()
+}
+
+// olderStruct converts a struct to an equivalent struct with the keys of an older schema version, keeping the
+// order of its fields. Structs of other packages, and in version 2 those of this package, keep the names and
+// omitempty options of their json tags.
+func olderStruct(v reflect.Value, version int) any {
+	t := v.Type()
+	goNames := version == 1 && t.PkgPath() == typesPkgPath
+	var fields []reflect.StructField
+	var values []any
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		key := f.Name
+		if !goNames {
+			name, opts, _ := strings.Cut(f.Tag.Get("json"), ",")
+			if name == "-" || (strings.Contains(opts, "omitempty") && isEmptyValue(v.Field(i))) {
+				continue
+			}
+			if name != "" {
+				key = name
+			}
+		}
+		fields = append(fields, reflect.StructField{Name: f.Name, Type: anyType, Tag: reflect.StructTag(`json:"` + key + `"`)})
+		values = append(values, olderValue(v.Field(i), version))
+	}
+
+	s := reflect.New(reflect.StructOf(fields)).Elem()
+	for i := range values {
+		s.Field(i).Set(reflect.ValueOf(&values[i]).Elem())
+	}
+	return s.Interface()
+}
+
+// isEmptyValue reports whether v is left out by the omitempty option of encoding/json.
+func isEmptyValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return v.Len() == 0
+	case reflect.Pointer, reflect.Interface:
+		return v.IsNil()
+	}
+	return v.IsZero()
+}
@@ -0,0 +1,103 @@
+package types_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vlad/ast2llm-go/internal/types"
+)
+
+func TestFileInfo_JSON(t *testing.T) {
+	info := types.NewFileInfo()
+	info.PackageName = "main"
+	info.Functions = append(info.Functions, &types.FunctionInfo{
+		Name:    "Run",
+		Params:  []string{"ctx context.Context"},
+		Returns: []string{},
+		Pos:     &types.Position{File: "/project/main.go", Line: 3, Column: 1},
+	})
+
+	data, err := json.Marshal(info)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"package_name": "main",
+		"functions": [{
+			"name": "Run",
+			"params": ["ctx context.Context"],
+			"pos": {"file": "/project/main.go", "line": 3, "column": 1}
+		}]
+	}`, string(data), "empty optional fields are left out whether nil or empty")
+
+	var decoded types.FileInfo
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, "Run", decoded.Functions[0].Name)
+	assert.Equal(t, 3, decoded.Functions[0].Pos.Line)
+}
+
+func TestInterfaceCheck_JSON(t *testing.T) {
+	data, err := json.Marshal(&types.InterfaceCheck{Type: "example.com/p.T", Interface: "io.Reader"})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"type": "example.com/p.T", "interface": "io.Reader", "implements": false, "pointer_implements": false}`,
+		string(data), "the outcome of a check is always included")
+}
+
+func TestForSchemaVersion(t *testing.T) {
+	type wrapper struct {
+		Version int                `json:"version"`
+		Extra   string             `json:"extra,omitempty"`
+		Files   []*types.FileInfo  `json:"files"`
+		Graph   *types.CallGraph   `json:"graph"`
+		Struct  *types.StructField `json:"-"`
+	}
+	v := &wrapper{
+		Version: 1,
+		Files: []*types.FileInfo{{
+			PackageName: "main",
+			Imports:     []*types.ImportInfo{{Path: "context", Alias: "myctx"}},
+			Structs:     []*types.StructInfo{{Name: "T", Fields: []*types.StructField{{Name: "ID", Type: "int"}}}},
+		}},
+		Graph: &types.CallGraph{Nodes: map[string]*types.CallNode{"main.Run": {Name: "main.Run", Calls: []string{}}}},
+	}
+
+	current, err := types.ForSchemaVersion(v, types.SchemaVersion)
+	require.NoError(t, err)
+	assert.Same(t, v, current)
+
+	legacy, err := types.ForSchemaVersion(v, 1)
+	require.NoError(t, err)
+	data, err := json.Marshal(legacy)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"version": 1,
+		"files": [{
+			"PackageName": "main", "PackageDoc": "", "ContentHash": "", "ModTime": "", "Generated": false, "HasCgo": false, "HasAsm": false, "Imports": ["context"], "Functions": null,
+			"Methods": null, "Interfaces": null, "GlobalVars": null, "ConstGroups": null, "InlineTypes": null, "CustomItems": null, "Directives": null, "ErrorValues": null, "EntryPoints": null, "Routes": null, "SQLQueries": null,
+			"UsedImportedStructs": null, "UsedImportedInterfaces": null, "UsedImportedFunctions": null,
+			"UsedImportedGlobalVars": null, "ExternalStructs": null, "ExternalInterfaces": null, "Diagnostics": null,
+			"Structs": [{
+				"Name": "T", "ID": "", "Comment": "", "TypeParams": null, "Methods": null, "Examples": null, "ORM": "", "Table": "", "UsedFields": null, "Pos": null,
+				"Fields": [{"Name": "ID", "Type": "int", "PromotedFrom": "", "TypeRef": "", "Tag": "", "Column": ""}]
+			}]
+		}],
+		"graph": {"Nodes": {"main.Run": {"Name": "main.Run", "Calls": [], "CalledBy": null}}}
+	}`, string(data), "item fields are named after their Go fields and always included")
+
+	previous, err := types.ForSchemaVersion(v, 2)
+	require.NoError(t, err)
+	data, err = json.Marshal(previous)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"version": 1,
+		"files": [{
+			"package_name": "main",
+			"imports": ["context"],
+			"structs": [{"name": "T", "fields": [{"name": "ID", "type": "int"}]}]
+		}],
+		"graph": {"nodes": {"main.Run": {"name": "main.Run"}}}
+	}`, string(data), "imports are listed as paths before version 3")
+
+	_, err = types.ForSchemaVersion(v, types.SchemaVersion+1)
+	assert.EqualError(t, err, "unsupported schema version 4, supported versions are 1 to 3")
+}
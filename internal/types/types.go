@@ -1,36 +1,128 @@
 package types
 
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
 // FileInfo represents the parsed information about a Go file
 type FileInfo struct {
-	PackageName            string           // Name of the package
-	Imports                []string         // List of imported packages
-	Functions              []*FunctionInfo  // List of functions with details
-	Structs                []*StructInfo    // List of struct names with their comments, fields, and methods
-	Interfaces             []*InterfaceInfo // List of interface names with their comments, methods, and embeddeds
-	GlobalVars             []*GlobalVarInfo // List of global variables and constants
-	UsedImportedStructs    []*StructInfo    // List of imported struct names used in the file, with fields and methods
-	UsedImportedFunctions  []*FunctionInfo  // List of imported function names used in the file, with signature and comment
-	UsedImportedGlobalVars []*GlobalVarInfo // List of imported global variables and constants
+	PackageName            string           `json:"package_name"`                        // Name of the package
+	PackageDoc             string           `json:"package_doc,omitempty"`               // Doc comment of the package, from doc.go or the first file having one
+	ContentHash            string           `json:"content_hash,omitempty"`              // Hex SHA-256 of the parsed content of the file
+	ModTime                string           `json:"mod_time,omitempty"`                  // Modification time of the file when parsed, in RFC 3339 format; empty for sources not read from disk
+	Generated              bool             `json:"generated,omitempty"`                 // True if the file has a "Code generated ... DO NOT EDIT." header
+	HasCgo                 bool             `json:"has_cgo,omitempty"`                   // True if the file imports "C"; packages using cgo are parsed syntax-only, as a diagnostic says
+	HasAsm                 bool             `json:"has_asm,omitempty"`                   // True if the package has assembly (.s) files implementing the functions declared without a body
+	Imports                []*ImportInfo    `json:"imports,omitempty"`                   // Imports of the file, with their aliases
+	Functions              []*FunctionInfo  `json:"functions,omitempty"`                 // List of functions with details
+	Methods                []*FunctionInfo  `json:"methods,omitempty"`                   // List of methods declared in the file, with their receivers
+	Structs                []*StructInfo    `json:"structs,omitempty"`                   // List of struct names with their comments, fields, and methods
+	Interfaces             []*InterfaceInfo `json:"interfaces,omitempty"`                // List of interface names with their comments, methods, and embeddeds
+	GlobalVars             []*GlobalVarInfo `json:"global_vars,omitempty"`               // List of global variables and constants
+	ConstGroups            []*ConstGroup    `json:"const_groups,omitempty"`              // Const blocks using iota, kept out of GlobalVars
+	InlineTypes            []*InlineType    `json:"inline_types,omitempty"`              // Significant anonymous structs and function literals, in source order
+	CustomItems            []*CustomItem    `json:"custom_items,omitempty"`              // Items found by custom extractors, e.g. wire providers
+	Directives             []*Directive     `json:"directives,omitempty"`                // Directive comments such as //go:generate, in source order
+	ErrorValues            []*ErrorInfo     `json:"error_values,omitempty"`              // Sentinel errors and error types declared in the file
+	EntryPoints            []*EntryPoint    `json:"entry_points,omitempty"`              // Main functions, HTTP handlers, CLI commands and gRPC services, in source order
+	Routes                 []*Route         `json:"routes,omitempty"`                    // HTTP routes registered in the file, in source order
+	SQLQueries             []*SQLQuery      `json:"sql_queries,omitempty"`               // SQL statements in string constants and query builder calls, in source order
+	UsedImportedStructs    []*StructInfo    `json:"used_imported_structs,omitempty"`     // List of imported struct names used in the file, with fields and methods
+	UsedImportedInterfaces []*InterfaceInfo `json:"used_imported_interfaces,omitempty"`  // List of imported interface names used in the file
+	UsedImportedFunctions  []*FunctionInfo  `json:"used_imported_functions,omitempty"`   // List of imported function names used in the file, with signature and comment
+	UsedImportedGlobalVars []*GlobalVarInfo `json:"used_imported_global_vars,omitempty"` // List of imported global variables and constants
+	ExternalStructs        []*StructInfo    `json:"external_structs,omitempty"`          // Exported members of used structs declared outside the project, if enabled
+	ExternalInterfaces     []*InterfaceInfo `json:"external_interfaces,omitempty"`       // Methods of used interfaces declared outside the project, if enabled
+	Diagnostics            []*Diagnostic    `json:"diagnostics,omitempty"`               // Syntax and type errors reported for the file
 }
 
 // NewFileInfo creates a new FileInfo instance
 func NewFileInfo() *FileInfo {
 	return &FileInfo{
-		Imports:                make([]string, 0),
+		Imports:                make([]*ImportInfo, 0),
 		Functions:              make([]*FunctionInfo, 0),
+		Methods:                make([]*FunctionInfo, 0),
 		Structs:                make([]*StructInfo, 0),
 		Interfaces:             make([]*InterfaceInfo, 0),
 		GlobalVars:             make([]*GlobalVarInfo, 0),
+		ConstGroups:            make([]*ConstGroup, 0),
+		InlineTypes:            make([]*InlineType, 0),
+		CustomItems:            make([]*CustomItem, 0),
+		Directives:             make([]*Directive, 0),
+		ErrorValues:            make([]*ErrorInfo, 0),
+		EntryPoints:            make([]*EntryPoint, 0),
+		Routes:                 make([]*Route, 0),
+		SQLQueries:             make([]*SQLQuery, 0),
 		UsedImportedStructs:    make([]*StructInfo, 0),
+		UsedImportedInterfaces: make([]*InterfaceInfo, 0),
 		UsedImportedFunctions:  make([]*FunctionInfo, 0),
 		UsedImportedGlobalVars: make([]*GlobalVarInfo, 0),
+		ExternalStructs:        make([]*StructInfo, 0),
+		ExternalInterfaces:     make([]*InterfaceInfo, 0),
+		Diagnostics:            make([]*Diagnostic, 0),
+	}
+}
+
+// ImportInfo represents an import of a file
+type ImportInfo struct {
+	Path    string `json:"path"`               // Import path
+	Alias   string `json:"alias,omitempty"`    // Name the file refers to the package by, e.g. myctx for import myctx "context"; empty if the import is not renamed, or is blank or dot
+	IsBlank bool   `json:"is_blank,omitempty"` // True for imports for side effects only, e.g. import _ "embed"
+	IsDot   bool   `json:"is_dot,omitempty"`   // True for imports whose exported names the file uses unqualified, e.g. import . "math"
+}
+
+// String formats the import as its path followed by the name the file uses for the package, if it is not the
+// package name: "context (as myctx)", "embed (blank)" or "math (dot)"
+func (i *ImportInfo) String() string {
+	switch {
+	case i.IsBlank:
+		return i.Path + " (blank)"
+	case i.IsDot:
+		return i.Path + " (dot)"
+	case i.Alias != "":
+		return fmt.Sprintf("%s (as %s)", i.Path, i.Alias)
+	}
+	return i.Path
+}
+
+// ImportPaths returns the paths of imports
+func ImportPaths(imports []*ImportInfo) []string {
+	paths := make([]string, len(imports))
+	for i, imp := range imports {
+		paths[i] = imp.Path
 	}
+	return paths
+}
+
+// Position represents a location in a source file
+type Position struct {
+	File   string `json:"file"`   // Absolute file path
+	Line   int    `json:"line"`   // Line number, starting at 1
+	Column int    `json:"column"` // Column number in bytes, starting at 1
+}
+
+// String formats the position as file:line:column
+func (p *Position) String() string {
+	return fmt.Sprintf("%s:%d:%d", p.File, p.Line, p.Column)
+}
+
+// Diagnostic represents a compiler error reported for a file
+type Diagnostic struct {
+	Kind    string    `json:"kind"`          // "syntax", "type", "load" or "extractor"
+	Message string    `json:"message"`       // Error message
+	Pos     *Position `json:"pos,omitempty"` // Position of the error, nil if unknown
 }
 
 // StructField represents a field within a struct
 type StructField struct {
-	Name string // Field name
-	Type string // Field type
+	Name         string `json:"name"`                    // Field name
+	Type         string `json:"type"`                    // Field type
+	PromotedFrom string `json:"promoted_from,omitempty"` // Embedded type the field is promoted from, empty for declared fields
+	TypeRef      string `json:"type_ref,omitempty"`      // Fully qualified name of the named struct type the field holds, through pointers, slices, arrays, maps and channels, empty otherwise
+	Tag          string `json:"tag,omitempty"`           // Struct tag, e.g. `json:"name" gorm:"column:user_name"`
+	Column       string `json:"column,omitempty"`        // Database column the field maps to, derived from the ORM tags of the struct, see StructInfo.ORM
 }
 
 // NewStructField creates a new StructField instance
@@ -40,10 +132,12 @@ func NewStructField() *StructField {
 
 // StructMethod represents a method associated with a struct
 type StructMethod struct {
-	Name        string   // Method name
-	Comment     string   // Method comment
-	Parameters  []string // List of parameter types
-	ReturnTypes []string // List of return types
+	Name              string   `json:"name"`                          // Method name
+	Comment           string   `json:"comment,omitempty"`             // Method comment
+	Parameters        []string `json:"parameters,omitempty"`          // List of parameter types
+	ReturnTypes       []string `json:"return_types,omitempty"`        // List of return types, with names for named results
+	PromotedFrom      string   `json:"promoted_from,omitempty"`       // Embedded type the method is promoted from, empty for declared methods
+	ReceiverIsPointer bool     `json:"receiver_is_pointer,omitempty"` // True if the method has a pointer receiver, i.e. it is only in the method set of *T
 }
 
 // NewStructMethod creates a new StructMethod instance
@@ -56,40 +150,51 @@ func NewStructMethod() *StructMethod {
 
 // StructInfo represents detailed information about a struct
 type StructInfo struct {
-	Name    string          // Struct name
-	Comment string          // Struct comment
-	Fields  []*StructField  // List of fields
-	Methods []*StructMethod // List of methods
+	Name       string          `json:"name"`                  // Struct name
+	ID         string          `json:"id,omitempty"`          // Stable identifier, see FunctionInfo.ID
+	Comment    string          `json:"comment,omitempty"`     // Struct comment
+	TypeParams []string        `json:"type_params,omitempty"` // Type parameters with constraints, e.g. "T comparable"
+	Fields     []*StructField  `json:"fields,omitempty"`      // List of fields
+	Methods    []*StructMethod `json:"methods,omitempty"`     // List of methods
+	Examples   []*Example      `json:"examples,omitempty"`    // Example functions of the type and its methods, only populated on request
+	ORM        string          `json:"orm,omitempty"`         // ORM whose tags map the struct to a table: "gorm", "bun" or "sqlx"; empty for other structs
+	Table      string          `json:"table,omitempty"`       // Table the struct maps to, from its TableName method, its tags or the naming convention of the ORM
+	UsedFields []string        `json:"used_fields,omitempty"` // Only on used imported structs the file builds with keyed composite literals: the fields it sets or selects, sorted
+	Pos        *Position       `json:"pos,omitempty"`         // Declaration position, nil if unknown
 }
 
 // NewStructInfo creates a new StructInfo instance
 func NewStructInfo() *StructInfo {
 	return &StructInfo{
-		Fields:  make([]*StructField, 0),
-		Methods: make([]*StructMethod, 0),
+		TypeParams: make([]string, 0),
+		Fields:     make([]*StructField, 0),
+		Methods:    make([]*StructMethod, 0),
 	}
 }
 
 // Node represents a package in the dependency graph
 type Node struct {
-	PkgPath   string   // Package path
-	Functions []string // Exported functions
-	DependsOn []string // Imported packages
-	Files     []string // Source files in the package
+	PkgPath      string   `json:"pkg_path"`                 // Package path
+	Doc          string   `json:"doc,omitempty"`            // Package doc comment, empty if none
+	Functions    []string `json:"functions,omitempty"`      // Exported functions
+	DependsOn    []string `json:"depends_on,omitempty"`     // Imported packages
+	DependedOnBy []string `json:"depended_on_by,omitempty"` // Project packages importing this package
+	Files        []string `json:"files,omitempty"`          // Source files in the package
 }
 
 // NewNode creates a new Node instance
 func NewNode() *Node {
 	return &Node{
-		Functions: make([]string, 0),
-		DependsOn: make([]string, 0),
-		Files:     make([]string, 0),
+		Functions:    make([]string, 0),
+		DependsOn:    make([]string, 0),
+		DependedOnBy: make([]string, 0),
+		Files:        make([]string, 0),
 	}
 }
 
 // DependencyGraph represents the project's dependency structure
 type DependencyGraph struct {
-	Nodes map[string]*Node // Key: package path
+	Nodes map[string]*Node `json:"nodes"` // Key: package path
 }
 
 // NewDependencyGraph creates a new DependencyGraph instance
@@ -99,12 +204,393 @@ func NewDependencyGraph() *DependencyGraph {
 	}
 }
 
+// LinkReverseDeps rebuilds the DependedOnBy index of every node from the DependsOn lists
+func (g *DependencyGraph) LinkReverseDeps() {
+	for _, node := range g.Nodes {
+		node.DependedOnBy = make([]string, 0)
+	}
+	for _, node := range g.Nodes {
+		for _, dep := range node.DependsOn {
+			if depNode, ok := g.Nodes[dep]; ok {
+				depNode.DependedOnBy = append(depNode.DependedOnBy, node.PkgPath)
+			}
+		}
+	}
+	for _, node := range g.Nodes {
+		sort.Strings(node.DependedOnBy)
+	}
+}
+
+// ReverseDeps returns the project packages importing pkgPath, or nil if pkgPath is not part of the graph
+func (g *DependencyGraph) ReverseDeps(pkgPath string) []string {
+	node, ok := g.Nodes[pkgPath]
+	if !ok {
+		return nil
+	}
+	return node.DependedOnBy
+}
+
+// Subgraph returns the nodes reachable from root through DependsOn edges within maxDepth imports.
+// A maxDepth of 0 or less means unlimited. The result is nil if root is not part of the graph.
+func (g *DependencyGraph) Subgraph(root string, maxDepth int) *DependencyGraph {
+	if _, ok := g.Nodes[root]; !ok {
+		return nil
+	}
+
+	depth := map[string]int{root: 0}
+	queue := []string{root}
+	for len(queue) > 0 {
+		pkgPath := queue[0]
+		queue = queue[1:]
+		if maxDepth > 0 && depth[pkgPath] >= maxDepth {
+			continue
+		}
+		for _, dep := range g.Nodes[pkgPath].DependsOn {
+			if _, ok := g.Nodes[dep]; !ok {
+				continue
+			}
+			if _, seen := depth[dep]; !seen {
+				depth[dep] = depth[pkgPath] + 1
+				queue = append(queue, dep)
+			}
+		}
+	}
+
+	return g.Filter(func(pkgPath string) bool {
+		_, ok := depth[pkgPath]
+		return ok
+	})
+}
+
+// Filter returns a copy of the graph with only the nodes for which keep returns true.
+// Edges to dropped nodes are removed; edges to packages outside of the graph are kept.
+func (g *DependencyGraph) Filter(keep func(pkgPath string) bool) *DependencyGraph {
+	filtered := NewDependencyGraph()
+	for pkgPath, node := range g.Nodes {
+		if !keep(pkgPath) {
+			continue
+		}
+		copied := NewNode()
+		copied.PkgPath = node.PkgPath
+		copied.Functions = append(copied.Functions, node.Functions...)
+		copied.Files = append(copied.Files, node.Files...)
+		for _, dep := range node.DependsOn {
+			if _, isProjectPkg := g.Nodes[dep]; isProjectPkg && !keep(dep) {
+				continue
+			}
+			copied.DependsOn = append(copied.DependsOn, dep)
+		}
+		filtered.Nodes[pkgPath] = copied
+	}
+	filtered.LinkReverseDeps()
+	return filtered
+}
+
+// ToDOT renders the graph in the Graphviz DOT language.
+// Only edges between nodes of the graph are drawn, so standard library and third-party imports are left out.
+func (g *DependencyGraph) ToDOT() string {
+	var builder strings.Builder
+	builder.WriteString("digraph dependencies {\n")
+	for _, pkgPath := range g.sortedPaths() {
+		builder.WriteString(fmt.Sprintf("  %q;\n", pkgPath))
+	}
+	for _, pkgPath := range g.sortedPaths() {
+		for _, dep := range g.Nodes[pkgPath].DependsOn {
+			if _, ok := g.Nodes[dep]; ok {
+				builder.WriteString(fmt.Sprintf("  %q -> %q;\n", pkgPath, dep))
+			}
+		}
+	}
+	builder.WriteString("}\n")
+	return builder.String()
+}
+
+// ToMermaid renders the graph as a Mermaid flowchart.
+// Nodes get generated IDs labelled with their package path; like ToDOT, only edges between nodes of the graph are drawn.
+func (g *DependencyGraph) ToMermaid() string {
+	paths := g.sortedPaths()
+	ids := make(map[string]string, len(paths))
+
+	var builder strings.Builder
+	builder.WriteString("graph LR\n")
+	for i, pkgPath := range paths {
+		ids[pkgPath] = fmt.Sprintf("n%d", i)
+		builder.WriteString(fmt.Sprintf("  %s[\"%s\"]\n", ids[pkgPath], pkgPath))
+	}
+	for _, pkgPath := range paths {
+		for _, dep := range g.Nodes[pkgPath].DependsOn {
+			if depID, ok := ids[dep]; ok {
+				builder.WriteString(fmt.Sprintf("  %s --> %s\n", ids[pkgPath], depID))
+			}
+		}
+	}
+	return builder.String()
+}
+
+// Cycle is a group of project packages that depend on each other
+type Cycle struct {
+	Packages []string `json:"packages"` // Sorted package paths in the cycle
+	// ViaInternal is set for near-cycles: the packages only form a cycle when every internal package
+	// is counted as part of the package owning its internal directory.
+	ViaInternal bool `json:"via_internal,omitempty"`
+}
+
+// DetectCycles reports the import cycles between project packages, followed by the near-cycles
+// that go through internal packages, e.g. app -> app/db -> app/internal/config.
+// Near-cycles are reported with the owning packages, as app and app/db in the example.
+func (g *DependencyGraph) DetectCycles() []Cycle {
+	edges := make(map[string][]string, len(g.Nodes))
+	for pkgPath, node := range g.Nodes {
+		for _, dep := range node.DependsOn {
+			if _, ok := g.Nodes[dep]; ok {
+				edges[pkgPath] = append(edges[pkgPath], dep)
+			}
+		}
+	}
+
+	var cycles []Cycle
+	for _, component := range stronglyConnected(g.sortedPaths(), edges) {
+		cycles = append(cycles, Cycle{Packages: component})
+	}
+
+	// Collapse internal packages into their owners and look for cycles again.
+	owners := make(map[string]bool)
+	ownerEdges := make(map[string][]string)
+	for pkgPath, deps := range edges {
+		from := internalOwner(pkgPath)
+		owners[from] = true
+		for _, dep := range deps {
+			if to := internalOwner(dep); to != from {
+				ownerEdges[from] = append(ownerEdges[from], to)
+			}
+		}
+	}
+	for pkgPath := range g.Nodes {
+		owners[internalOwner(pkgPath)] = true
+	}
+	ownerPaths := make([]string, 0, len(owners))
+	for owner := range owners {
+		ownerPaths = append(ownerPaths, owner)
+	}
+	sort.Strings(ownerPaths)
+
+	for _, component := range stronglyConnected(ownerPaths, ownerEdges) {
+		if !containsCycle(cycles, component) {
+			cycles = append(cycles, Cycle{Packages: component, ViaInternal: true})
+		}
+	}
+	return cycles
+}
+
+// internalOwner returns the package owning the internal directory pkgPath lives in, or pkgPath itself
+// if it is not an internal package.
+func internalOwner(pkgPath string) string {
+	if strings.HasSuffix(pkgPath, "/internal") {
+		return strings.TrimSuffix(pkgPath, "/internal")
+	}
+	if i := strings.Index(pkgPath, "/internal/"); i >= 0 {
+		return pkgPath[:i]
+	}
+	return pkgPath
+}
+
+// containsCycle reports whether cycles already has a cycle with exactly the given packages.
+func containsCycle(cycles []Cycle, packages []string) bool {
+	for _, c := range cycles {
+		if strings.Join(c.Packages, "\x00") == strings.Join(packages, "\x00") {
+			return true
+		}
+	}
+	return false
+}
+
+// stronglyConnected returns the strongly connected components of the graph that contain a cycle,
+// using Tarjan's algorithm. Components are sorted internally and by their first node.
+func stronglyConnected(nodes []string, edges map[string][]string) [][]string {
+	index := make(map[string]int, len(nodes))
+	lowlink := make(map[string]int, len(nodes))
+	onStack := make(map[string]bool)
+	var stack []string
+	var components [][]string
+
+	var visit func(node string)
+	visit = func(node string) {
+		index[node] = len(index)
+		lowlink[node] = index[node]
+		stack = append(stack, node)
+		onStack[node] = true
+
+		selfLoop := false
+		for _, next := range edges[node] {
+			if next == node {
+				selfLoop = true
+			}
+			if _, seen := index[next]; !seen {
+				visit(next)
+				lowlink[node] = min(lowlink[node], lowlink[next])
+			} else if onStack[next] {
+				lowlink[node] = min(lowlink[node], index[next])
+			}
+		}
+
+		if lowlink[node] != index[node] {
+			return
+		}
+		var component []string
+		for {
+			top := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			onStack[top] = false
+			component = append(component, top)
+			if top == node {
+				break
+			}
+		}
+		if len(component) > 1 || selfLoop {
+			sort.Strings(component)
+			components = append(components, component)
+		}
+	}
+
+	for _, node := range nodes {
+		if _, seen := index[node]; !seen {
+			visit(node)
+		}
+	}
+	sort.Slice(components, func(i, j int) bool { return components[i][0] < components[j][0] })
+	return components
+}
+
+// sortedPaths returns the package paths of all nodes in sorted order
+func (g *DependencyGraph) sortedPaths() []string {
+	paths := make([]string, 0, len(g.Nodes))
+	for pkgPath := range g.Nodes {
+		paths = append(paths, pkgPath)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// CallNode represents a function or method in the call graph
+type CallNode struct {
+	Name     string   `json:"name"`                // Fully qualified function name
+	Calls    []string `json:"calls,omitempty"`     // Functions called by this function
+	CalledBy []string `json:"called_by,omitempty"` // Functions calling this function
+}
+
+// NewCallNode creates a new CallNode instance
+func NewCallNode() *CallNode {
+	return &CallNode{
+		Calls:    make([]string, 0),
+		CalledBy: make([]string, 0),
+	}
+}
+
+// CallGraph represents which functions of the project call which
+type CallGraph struct {
+	Nodes map[string]*CallNode `json:"nodes"` // Key: fully qualified function name
+}
+
+// NewCallGraph creates a new CallGraph instance
+func NewCallGraph() *CallGraph {
+	return &CallGraph{
+		Nodes: make(map[string]*CallNode),
+	}
+}
+
+// FunctionDeps lists what the body of a function refers to
+type FunctionDeps struct {
+	Function   string   `json:"function"`              // Fully qualified function or method name, e.g. "(*example.com/pkg.T).Method"
+	Types      []string `json:"types,omitempty"`       // Named types, fully qualified
+	Functions  []string `json:"functions,omitempty"`   // Functions and methods, named like Function
+	GlobalVars []string `json:"global_vars,omitempty"` // Package-level variables and constants, fully qualified
+	Packages   []string `json:"packages,omitempty"`    // Import paths of the other packages the referenced items belong to
+}
+
+// NewFunctionDeps creates a new FunctionDeps instance
+func NewFunctionDeps() *FunctionDeps {
+	return &FunctionDeps{
+		Types:      make([]string, 0),
+		Functions:  make([]string, 0),
+		GlobalVars: make([]string, 0),
+		Packages:   make([]string, 0),
+	}
+}
+
+// Kinds of APIChange
+const (
+	APIAdded   = "added"   // Declaration only in the new version
+	APIRemoved = "removed" // Declaration only in the old version
+	APIChanged = "changed" // Declaration whose signature differs between the versions
+)
+
+// APIChange represents a difference between the exported API surfaces of two versions of a project
+type APIChange struct {
+	Kind string `json:"kind"`          // APIAdded, APIRemoved or APIChanged
+	Name string `json:"name"`          // Fully qualified name, e.g. "example.com/app/store.New" or "example.com/app/store.Store.Get" for methods
+	Old  string `json:"old,omitempty"` // Signature in the old version, e.g. "func New() *Store"; empty if added
+	New  string `json:"new,omitempty"` // Signature in the new version; empty if removed
+}
+
+// DeadExport represents an exported package-level symbol that nothing in the project refers to
+type DeadExport struct {
+	Name string    `json:"name"`          // Fully qualified name, e.g. "example.com/app/store.Reset"
+	Kind string    `json:"kind"`          // "func", "type", "var" or "const"
+	Pos  *Position `json:"pos,omitempty"` // Position of the declared name, nil if unknown
+}
+
+// Kinds of StructUsage
+const (
+	UsageConstruct = "construct" // Composite literal of the struct
+	UsageWrite     = "write"     // Assignment to a field of the struct
+)
+
+// StructUsage represents a place where a struct is constructed or one of its fields is written
+type StructUsage struct {
+	Kind     string    `json:"kind"`               // UsageConstruct or UsageWrite
+	Field    string    `json:"field,omitempty"`    // Written field, empty for constructions
+	Function string    `json:"function,omitempty"` // Enclosing function or method, e.g. "Save" or "(*Store).Add"; empty at package level
+	Pos      *Position `json:"pos,omitempty"`      // Position of the composite literal or the written field
+}
+
+// Kinds of MethodProblem
+const (
+	ProblemMissing         = "missing"          // The type has no method of that name
+	ProblemWrongSignature  = "wrong-signature"  // The method exists with a different signature
+	ProblemPointerReceiver = "pointer-receiver" // The method has a pointer receiver, so only *T has it
+)
+
+// MethodProblem represents an interface method a type does not provide as required
+type MethodProblem struct {
+	Method string `json:"method"`         // Method name
+	Kind   string `json:"kind"`           // ProblemMissing, ProblemWrongSignature or ProblemPointerReceiver
+	Want   string `json:"want"`           // Signature required by the interface
+	Have   string `json:"have,omitempty"` // Signature of the method of the type, empty if missing
+}
+
+// InterfaceCheck represents whether a type implements an interface and, if not, why
+type InterfaceCheck struct {
+	Type              string           `json:"type"`               // Fully qualified type name
+	Interface         string           `json:"interface"`          // Fully qualified interface name
+	Implements        bool             `json:"implements"`         // True if values of the type implement the interface
+	PointerImplements bool             `json:"pointer_implements"` // True if pointers to the type implement the interface
+	Problems          []*MethodProblem `json:"problems,omitempty"` // Methods preventing values of the type from implementing it, sorted by name
+}
+
+// InterfaceUsage lists the functions and methods of a project taking or returning an interface, the
+// de-facto contract surface of the interface
+type InterfaceUsage struct {
+	Interface  string   `json:"interface"`             // Fully qualified interface name
+	AcceptedBy []string `json:"accepted_by,omitempty"` // Functions and methods taking the interface, e.g. "store.New" or "(*store.Cache).Wrap", sorted
+	ReturnedBy []string `json:"returned_by,omitempty"` // Functions and methods returning the interface, sorted
+}
+
 // InterfaceMethod represents a method within an interface
 type InterfaceMethod struct {
-	Name        string   // Method name
-	Comment     string   // Method comment
-	Parameters  []string // List of parameter types
-	ReturnTypes []string // List of return types
+	Name        string   `json:"name"`                   // Method name
+	Comment     string   `json:"comment,omitempty"`      // Method comment
+	Parameters  []string `json:"parameters,omitempty"`   // List of parameter types
+	ReturnTypes []string `json:"return_types,omitempty"` // List of return types, with names for named results
 }
 
 // NewInterfaceMethod creates a new InterfaceMethod instance
@@ -117,27 +603,34 @@ func NewInterfaceMethod() *InterfaceMethod {
 
 // InterfaceInfo represents detailed information about an interface
 type InterfaceInfo struct {
-	Name      string             // Interface name (fully qualified)
-	Comment   string             // Interface comment
-	Methods   []*InterfaceMethod // List of methods
-	Embeddeds []string           // Names of embedded interfaces
+	Name       string             `json:"name"`                  // Interface name (fully qualified)
+	ID         string             `json:"id,omitempty"`          // Stable identifier, see FunctionInfo.ID
+	Comment    string             `json:"comment,omitempty"`     // Interface comment
+	TypeParams []string           `json:"type_params,omitempty"` // Type parameters with constraints, e.g. "T comparable"
+	Methods    []*InterfaceMethod `json:"methods,omitempty"`     // List of methods
+	Embeddeds  []string           `json:"embeddeds,omitempty"`   // Names of embedded interfaces
+	Examples   []*Example         `json:"examples,omitempty"`    // Example functions of the interface and its methods, only populated on request
+	Pos        *Position          `json:"pos,omitempty"`         // Declaration position, nil if unknown
 }
 
 // NewInterfaceInfo creates a new InterfaceInfo instance
 func NewInterfaceInfo() *InterfaceInfo {
 	return &InterfaceInfo{
-		Methods:   make([]*InterfaceMethod, 0),
-		Embeddeds: make([]string, 0),
+		TypeParams: make([]string, 0),
+		Methods:    make([]*InterfaceMethod, 0),
+		Embeddeds:  make([]string, 0),
 	}
 }
 
 // GlobalVarInfo represents a global variable or constant.
 type GlobalVarInfo struct {
-	Name    string // Variable name
-	Comment string // Associated comment
-	Type    string // Variable type
-	Value   string // Value, if it's a constant or has a simple literal value
-	IsConst bool   // True if it's a constant
+	Name    string    `json:"name"`               // Variable name
+	ID      string    `json:"id,omitempty"`       // Stable identifier, see FunctionInfo.ID
+	Comment string    `json:"comment,omitempty"`  // Associated comment
+	Type    string    `json:"type,omitempty"`     // Variable type
+	Value   string    `json:"value,omitempty"`    // Value, if it's a constant or has a simple literal value
+	IsConst bool      `json:"is_const,omitempty"` // True if it's a constant
+	Pos     *Position `json:"pos,omitempty"`      // Declaration position, nil if unknown
 }
 
 // NewGlobalVarInfo creates a new GlobalVarInfo instance
@@ -145,18 +638,147 @@ func NewGlobalVarInfo() *GlobalVarInfo {
 	return &GlobalVarInfo{}
 }
 
+// ConstGroup represents a const block using iota, i.e. an enum-like set of constants
+type ConstGroup struct {
+	Type       string           `json:"type"`                 // Type of the constants, e.g. "example.com/project.Color" or "untyped int"
+	Underlying string           `json:"underlying,omitempty"` // Underlying type if Type is a named type, e.g. "int"
+	Comment    string           `json:"comment,omitempty"`    // Comment of the block
+	Constants  []*GlobalVarInfo `json:"constants,omitempty"`  // Constants in declaration order
+	Pos        *Position        `json:"pos,omitempty"`        // Position of the const keyword, nil if unknown
+}
+
+// Kinds of InlineType
+const (
+	InlineStruct = "struct" // Anonymous struct type, e.g. a field of type struct{...}
+	InlineFunc   = "func"   // Function literal, e.g. a closure assigned to a variable
+)
+
+// InlineType represents an anonymous struct or a function literal declared inline, which has no name of its own
+type InlineType struct {
+	Name      string         `json:"name"`                // Synthetic name made of its position, e.g. "main.go:42 anonymous struct"
+	Kind      string         `json:"kind"`                // InlineStruct or InlineFunc
+	Context   string         `json:"context"`             // Declaration it appears in and what it is bound to, e.g. "func main, var handler"
+	Fields    []*StructField `json:"fields,omitempty"`    // Fields of anonymous structs
+	Signature string         `json:"signature,omitempty"` // Parameters and results of function literals, e.g. "(w io.Writer) error"
+	Lines     int            `json:"lines"`               // Number of source lines it spans
+	Pos       *Position      `json:"pos,omitempty"`       // Position of the struct or func keyword, nil if unknown
+}
+
+// CustomItem represents an item found by a custom extractor, e.g. a wire provider or a gRPC service registration
+type CustomItem struct {
+	Section string    `json:"section"`           // Title of the section listing the item, e.g. "Wire Providers"
+	Name    string    `json:"name"`              // Name of the item, e.g. "NewStore"
+	Details []string  `json:"details,omitempty"` // Lines describing the item, e.g. "provides *Store"
+	Pos     *Position `json:"pos,omitempty"`     // Position of the item, nil if unknown
+}
+
+// Directive represents a directive comment, e.g. //go:generate, //go:build, //nolint:errcheck or //ast2llm:keep
+type Directive struct {
+	Name   string    `json:"name"`             // Directive up to the first space, e.g. "go:generate" or "nolint:errcheck"
+	Args   string    `json:"args,omitempty"`   // Rest of the comment, e.g. "stringer -type=Color"
+	Target string    `json:"target,omitempty"` // Top-level declaration the directive belongs to, e.g. "type Color"; empty at file level
+	Pos    *Position `json:"pos,omitempty"`    // Position of the comment, nil if unknown
+}
+
+// Kinds of ErrorInfo
+const (
+	ErrorSentinel = "sentinel" // Package-level variable or constant implementing error, e.g. ErrNotFound
+	ErrorType     = "type"     // Named type implementing error, e.g. *ValidationError
+)
+
+// ErrorInfo represents an error value or error type a package declares for its callers to check
+type ErrorInfo struct {
+	Name        string    `json:"name"`                   // Fully qualified name, e.g. "example.com/project/store.ErrNotFound"
+	ID          string    `json:"id,omitempty"`           // Stable identifier, see FunctionInfo.ID
+	Kind        string    `json:"kind"`                   // ErrorSentinel or ErrorType
+	Type        string    `json:"type"`                   // Type of sentinel errors, underlying type of error types
+	Message     string    `json:"message,omitempty"`      // Message of sentinel errors created by errors.New or fmt.Errorf from a literal
+	PointerOnly bool      `json:"pointer_only,omitempty"` // True for error types only implementing error through a pointer
+	Comment     string    `json:"comment,omitempty"`      // Doc comment
+	Pos         *Position `json:"pos,omitempty"`          // Declaration position, nil if unknown
+}
+
+// Kinds of EntryPoint
+const (
+	EntryMain = "main" // The main function of a main package
+	EntryHTTP = "http" // An HTTP handler registered on a router, e.g. with http.HandleFunc
+	EntryCLI  = "cli"  // A command of a CLI framework, e.g. a cobra.Command
+	EntryGRPC = "grpc" // A gRPC service implementation registered on a server
+)
+
+// EntryPoint represents a place where control enters the program, e.g. its main function or an HTTP handler
+type EntryPoint struct {
+	Kind    string    `json:"kind"`              // EntryMain, EntryHTTP, EntryCLI or EntryGRPC
+	Name    string    `json:"name"`              // Route pattern, command name or service name; "main" for main functions
+	Handler string    `json:"handler,omitempty"` // Expression handling the entry point, e.g. "api.listUsers" or "func literal"
+	Detail  string    `json:"detail,omitempty"`  // HTTP method of routes registered per method, short description of commands
+	Pos     *Position `json:"pos,omitempty"`     // Position of the declaration or registration, nil if unknown
+}
+
+// Route represents an HTTP route registered on a router, e.g. r.Get("/users/{id}", getUser)
+type Route struct {
+	Method    string    `json:"method,omitempty"` // Upper-case HTTP method, e.g. "GET"; empty if the route matches every method
+	Path      string    `json:"path"`             // Path pattern including the prefixes of its groups, e.g. "/api/users/{id}"
+	Handler   string    `json:"handler"`          // Expression handling the route, e.g. "api.getUser" or "func literal"
+	Framework string    `json:"framework"`        // Router, e.g. "net/http", "chi", "gin" or "echo"
+	Pos       *Position `json:"pos,omitempty"`    // Position of the registration, nil if unknown
+}
+
+// SQLQuery represents an SQL statement of the project, e.g. a query constant or a call to a query builder
+type SQLQuery struct {
+	Name      string    `json:"name,omitempty"`   // Constant or variable holding the query, or the sqlc query name; empty for inline queries
+	Statement string    `json:"statement"`        // Upper-case statement keyword, e.g. "SELECT" or "INSERT"
+	Tables    []string  `json:"tables,omitempty"` // Tables the statement reads or writes, in order of appearance
+	Query     string    `json:"query,omitempty"`  // Text of the statement on one line; empty for query builder calls
+	Source    string    `json:"source"`           // Where the query was found: "constant", "call" of a database/sql method, "sqlc" or "squirrel"
+	Pos       *Position `json:"pos,omitempty"`    // Position of the declaration or call, nil if unknown
+}
+
+// MigrationSet represents a directory of SQL migrations
+type MigrationSet struct {
+	Dir   string   `json:"dir"`            // Directory relative to the project root, with slashes, e.g. "db/migrations"
+	Tool  string   `json:"tool,omitempty"` // Migration tool recognized from the files, e.g. "golang-migrate" or "goose"
+	Files []string `json:"files"`          // Names of the migration files, sorted
+}
+
+// NewConstGroup creates a new ConstGroup instance
+func NewConstGroup() *ConstGroup {
+	return &ConstGroup{
+		Constants: make([]*GlobalVarInfo, 0),
+	}
+}
+
 // FunctionInfo represents detailed information about a function
 type FunctionInfo struct {
-	Name    string   // Function name (fully qualified)
-	Comment string   // Function comment
-	Params  []string // List of parameter types (with names if possible)
-	Returns []string // List of return types
+	Name             string     `json:"name"`                        // Function name (fully qualified)
+	ID               string     `json:"id,omitempty"`                // Stable identifier made of the package path, the receiver type for methods and the name, e.g. "example.com/app/store.Store.Get"; unlike Name it does not depend on the naming mode
+	Receiver         string     `json:"receiver,omitempty"`          // Receiver type for methods, e.g. "*MyStruct"; empty for functions
+	Comment          string     `json:"comment,omitempty"`           // Function comment
+	TypeParams       []string   `json:"type_params,omitempty"`       // Type parameters with constraints, e.g. "T comparable"
+	Params           []string   `json:"params,omitempty"`            // List of parameter types (with names if possible)
+	Returns          []string   `json:"returns,omitempty"`           // List of return types, with names for named results
+	ParamInterfaces  []string   `json:"param_interfaces,omitempty"`  // Fully qualified names of the named interfaces the parameters hold, error excepted
+	ReturnInterfaces []string   `json:"return_interfaces,omitempty"` // Fully qualified names of the named interfaces the results hold, error excepted
+	Body             string     `json:"body,omitempty"`              // Source of the declaration, only populated on request
+	Examples         []*Example `json:"examples,omitempty"`          // Example functions from the package tests, only populated on request
+	CallSite         string     `json:"call_site,omitempty"`         // Call of the function as written in the file using it, e.g. "store.Open(ctx, cfg)"; only set on used imported functions
+	Pos              *Position  `json:"pos,omitempty"`               // Declaration position, nil if unknown
+}
+
+// Example represents an ExampleXxx function of a package's _test.go files
+type Example struct {
+	Name   string `json:"name"`             // Function name, e.g. "ExampleStack_Push"
+	Code   string `json:"code"`             // Body of the function without the output comment
+	Output string `json:"output,omitempty"` // Expected output, empty if the example has none
 }
 
 // NewFunctionInfo creates a new FunctionInfo instance
 func NewFunctionInfo() *FunctionInfo {
 	return &FunctionInfo{
-		Params:  make([]string, 0),
-		Returns: make([]string, 0),
+		TypeParams:       make([]string, 0),
+		Params:           make([]string, 0),
+		Returns:          make([]string, 0),
+		ParamInterfaces:  make([]string, 0),
+		ReturnInterfaces: make([]string, 0),
 	}
 }
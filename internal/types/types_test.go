@@ -4,20 +4,46 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestNewFileInfo(t *testing.T) {
 	fi := NewFileInfo()
 	assert.NotNil(t, fi)
 	assert.Empty(t, fi.PackageName)
+	assert.False(t, fi.Generated)
 	assert.NotNil(t, fi.Imports)
 	assert.NotNil(t, fi.Functions)
+	assert.NotNil(t, fi.Methods)
 	assert.NotNil(t, fi.Structs)
 	assert.NotNil(t, fi.Interfaces)
 	assert.NotNil(t, fi.GlobalVars)
+	assert.NotNil(t, fi.CustomItems)
+	assert.NotNil(t, fi.Directives)
+	assert.NotNil(t, fi.ErrorValues)
+	assert.NotNil(t, fi.EntryPoints)
+	assert.NotNil(t, fi.Routes)
+	assert.NotNil(t, fi.SQLQueries)
 	assert.NotNil(t, fi.UsedImportedStructs)
+	assert.NotNil(t, fi.UsedImportedInterfaces)
 	assert.NotNil(t, fi.UsedImportedFunctions)
 	assert.NotNil(t, fi.UsedImportedGlobalVars)
+	assert.NotNil(t, fi.ExternalStructs)
+	assert.NotNil(t, fi.ExternalInterfaces)
+	assert.NotNil(t, fi.Diagnostics)
+}
+
+func TestPosition_String(t *testing.T) {
+	pos := &Position{File: "/project/main.go", Line: 12, Column: 6}
+	assert.Equal(t, "/project/main.go:12:6", pos.String())
+}
+
+func TestImportInfo_String(t *testing.T) {
+	assert.Equal(t, "fmt", (&ImportInfo{Path: "fmt"}).String())
+	assert.Equal(t, "context (as myctx)", (&ImportInfo{Path: "context", Alias: "myctx"}).String())
+	assert.Equal(t, "embed (blank)", (&ImportInfo{Path: "embed", IsBlank: true}).String())
+	assert.Equal(t, "math (dot)", (&ImportInfo{Path: "math", IsDot: true}).String())
+	assert.Equal(t, []string{"fmt", "embed"}, ImportPaths([]*ImportInfo{{Path: "fmt"}, {Path: "embed", IsBlank: true}}))
 }
 
 func TestNewStructField(t *testing.T) {
@@ -25,6 +51,7 @@ func TestNewStructField(t *testing.T) {
 	assert.NotNil(t, f)
 	assert.Empty(t, f.Name)
 	assert.Empty(t, f.Type)
+	assert.Empty(t, f.PromotedFrom)
 }
 
 func TestNewStructMethod(t *testing.T) {
@@ -36,6 +63,7 @@ func TestNewStructMethod(t *testing.T) {
 	assert.NotNil(t, m.ReturnTypes)
 	assert.Empty(t, m.Parameters)
 	assert.Empty(t, m.ReturnTypes)
+	assert.Empty(t, m.PromotedFrom)
 }
 
 func TestNewStructInfo(t *testing.T) {
@@ -43,6 +71,8 @@ func TestNewStructInfo(t *testing.T) {
 	assert.NotNil(t, si)
 	assert.Empty(t, si.Name)
 	assert.Empty(t, si.Comment)
+	assert.NotNil(t, si.TypeParams)
+	assert.Empty(t, si.TypeParams)
 	assert.NotNil(t, si.Fields)
 	assert.NotNil(t, si.Methods)
 	assert.Empty(t, si.Fields)
@@ -55,9 +85,11 @@ func TestNewNode(t *testing.T) {
 	assert.Empty(t, n.PkgPath)
 	assert.NotNil(t, n.Functions)
 	assert.NotNil(t, n.DependsOn)
+	assert.NotNil(t, n.DependedOnBy)
 	assert.NotNil(t, n.Files)
 	assert.Empty(t, n.Functions)
 	assert.Empty(t, n.DependsOn)
+	assert.Empty(t, n.DependedOnBy)
 	assert.Empty(t, n.Files)
 }
 
@@ -68,6 +100,169 @@ func TestNewDependencyGraph(t *testing.T) {
 	assert.Empty(t, dg.Nodes)
 }
 
+func TestDependencyGraph_ReverseDeps(t *testing.T) {
+	dg := NewDependencyGraph()
+	for path, deps := range map[string][]string{
+		"example.com/app":     {"example.com/app/svc", "example.com/app/db", "fmt"},
+		"example.com/app/svc": {"example.com/app/db"},
+		"example.com/app/db":  {"database/sql"},
+	} {
+		n := NewNode()
+		n.PkgPath = path
+		n.DependsOn = deps
+		dg.Nodes[path] = n
+	}
+
+	dg.LinkReverseDeps()
+
+	assert.Equal(t, []string{"example.com/app", "example.com/app/svc"}, dg.ReverseDeps("example.com/app/db"))
+	assert.Equal(t, []string{"example.com/app"}, dg.ReverseDeps("example.com/app/svc"))
+	assert.Empty(t, dg.ReverseDeps("example.com/app"))
+	assert.Nil(t, dg.ReverseDeps("fmt"))
+}
+
+// newTestDependencyGraph builds a small graph: app -> svc -> db, app -> db and app -> fmt
+func newTestDependencyGraph() *DependencyGraph {
+	dg := NewDependencyGraph()
+	for path, deps := range map[string][]string{
+		"example.com/app":     {"example.com/app/db", "example.com/app/svc", "fmt"},
+		"example.com/app/svc": {"example.com/app/db"},
+		"example.com/app/db":  {"database/sql"},
+	} {
+		n := NewNode()
+		n.PkgPath = path
+		n.DependsOn = deps
+		dg.Nodes[path] = n
+	}
+	dg.LinkReverseDeps()
+	return dg
+}
+
+func TestDependencyGraph_Subgraph(t *testing.T) {
+	dg := newTestDependencyGraph()
+
+	sub := dg.Subgraph("example.com/app/svc", 0)
+	require.NotNil(t, sub)
+	assert.Len(t, sub.Nodes, 2)
+	assert.Contains(t, sub.Nodes, "example.com/app/db")
+	assert.Equal(t, []string{"example.com/app/svc"}, sub.ReverseDeps("example.com/app/db"))
+
+	assert.Len(t, dg.Subgraph("example.com/app", 0).Nodes, 3)
+	assert.Len(t, dg.Subgraph("example.com/app", 1).Nodes, 3)
+	assert.Len(t, dg.Subgraph("example.com/app/svc", 1).Nodes, 2)
+	assert.Nil(t, dg.Subgraph("fmt", 0))
+}
+
+func TestDependencyGraph_Filter(t *testing.T) {
+	dg := newTestDependencyGraph()
+
+	filtered := dg.Filter(func(pkgPath string) bool { return pkgPath != "example.com/app/svc" })
+	require.Len(t, filtered.Nodes, 2)
+	assert.Equal(t, []string{"example.com/app/db", "fmt"}, filtered.Nodes["example.com/app"].DependsOn)
+	assert.Equal(t, []string{"example.com/app"}, filtered.ReverseDeps("example.com/app/db"))
+
+	// The original graph is left untouched.
+	assert.Len(t, dg.Nodes, 3)
+	assert.Len(t, dg.Nodes["example.com/app"].DependsOn, 3)
+}
+
+func TestDependencyGraph_ToDOT(t *testing.T) {
+	dot := newTestDependencyGraph().ToDOT()
+	assert.Equal(t, `digraph dependencies {
+  "example.com/app";
+  "example.com/app/db";
+  "example.com/app/svc";
+  "example.com/app" -> "example.com/app/db";
+  "example.com/app" -> "example.com/app/svc";
+  "example.com/app/svc" -> "example.com/app/db";
+}
+`, dot)
+}
+
+func TestDependencyGraph_ToMermaid(t *testing.T) {
+	mermaid := newTestDependencyGraph().ToMermaid()
+	assert.Equal(t, `graph LR
+  n0["example.com/app"]
+  n1["example.com/app/db"]
+  n2["example.com/app/svc"]
+  n0 --> n1
+  n0 --> n2
+  n2 --> n1
+`, mermaid)
+}
+
+func TestDependencyGraph_DetectCycles(t *testing.T) {
+	newGraph := func(deps map[string][]string) *DependencyGraph {
+		dg := NewDependencyGraph()
+		for path, imports := range deps {
+			n := NewNode()
+			n.PkgPath = path
+			n.DependsOn = imports
+			dg.Nodes[path] = n
+		}
+		return dg
+	}
+
+	t.Run("acyclic", func(t *testing.T) {
+		assert.Empty(t, newTestDependencyGraph().DetectCycles())
+	})
+
+	t.Run("direct cycle", func(t *testing.T) {
+		dg := newGraph(map[string][]string{
+			"example.com/app":   {"example.com/app/a", "fmt"},
+			"example.com/app/a": {"example.com/app/b"},
+			"example.com/app/b": {"example.com/app/a"},
+		})
+		assert.Equal(t, []Cycle{{Packages: []string{"example.com/app/a", "example.com/app/b"}}}, dg.DetectCycles())
+	})
+
+	t.Run("near-cycle through internal package", func(t *testing.T) {
+		dg := newGraph(map[string][]string{
+			"example.com/app":                 {"example.com/app/db"},
+			"example.com/app/db":              {"example.com/app/internal/config"},
+			"example.com/app/internal/config": {},
+		})
+		assert.Equal(t, []Cycle{{Packages: []string{"example.com/app", "example.com/app/db"}, ViaInternal: true}}, dg.DetectCycles())
+	})
+
+	t.Run("owner importing its own internal package", func(t *testing.T) {
+		dg := newGraph(map[string][]string{
+			"example.com/app":              {"example.com/app/internal/x"},
+			"example.com/app/internal":     {},
+			"example.com/app/internal/x":   {"example.com/app/internal"},
+			"example.com/app/internal/x/y": {"example.com/app/internal/x"},
+		})
+		assert.Empty(t, dg.DetectCycles())
+	})
+}
+
+func TestNewFunctionDeps(t *testing.T) {
+	d := NewFunctionDeps()
+	assert.NotNil(t, d)
+	assert.Empty(t, d.Function)
+	assert.NotNil(t, d.Types)
+	assert.NotNil(t, d.Functions)
+	assert.NotNil(t, d.GlobalVars)
+	assert.NotNil(t, d.Packages)
+}
+
+func TestNewCallNode(t *testing.T) {
+	n := NewCallNode()
+	assert.NotNil(t, n)
+	assert.Empty(t, n.Name)
+	assert.NotNil(t, n.Calls)
+	assert.NotNil(t, n.CalledBy)
+	assert.Empty(t, n.Calls)
+	assert.Empty(t, n.CalledBy)
+}
+
+func TestNewCallGraph(t *testing.T) {
+	cg := NewCallGraph()
+	assert.NotNil(t, cg)
+	assert.NotNil(t, cg.Nodes)
+	assert.Empty(t, cg.Nodes)
+}
+
 func TestNewInterfaceMethod(t *testing.T) {
 	im := NewInterfaceMethod()
 	assert.NotNil(t, im)
@@ -84,6 +279,8 @@ func TestNewInterfaceInfo(t *testing.T) {
 	assert.NotNil(t, ii)
 	assert.Empty(t, ii.Name)
 	assert.Empty(t, ii.Comment)
+	assert.NotNil(t, ii.TypeParams)
+	assert.Empty(t, ii.TypeParams)
 	assert.NotNil(t, ii.Methods)
 	assert.NotNil(t, ii.Embeddeds)
 	assert.Empty(t, ii.Methods)
@@ -104,9 +301,15 @@ func TestNewFunctionInfo(t *testing.T) {
 	fn := NewFunctionInfo()
 	assert.NotNil(t, fn)
 	assert.Empty(t, fn.Name)
+	assert.Empty(t, fn.Receiver)
 	assert.Empty(t, fn.Comment)
+	assert.NotNil(t, fn.TypeParams)
+	assert.Empty(t, fn.TypeParams)
 	assert.NotNil(t, fn.Params)
 	assert.NotNil(t, fn.Returns)
+	assert.NotNil(t, fn.ParamInterfaces)
+	assert.NotNil(t, fn.ReturnInterfaces)
 	assert.Empty(t, fn.Params)
 	assert.Empty(t, fn.Returns)
+	assert.Empty(t, fn.Body)
 }